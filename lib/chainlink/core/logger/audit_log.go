@@ -0,0 +1,298 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// auditLogsFile is the name of the audit log file.
+const auditLogsFile = "chainlink_audit.log"
+
+// Audit events of Auditw.
+const (
+	AuditKeyUsed             = "KEY_USED"
+	AuditConfigChanged       = "CONFIG_CHANGED"
+	AuditChangesetExecuted   = "CHANGESET_EXECUTED"
+	AuditChangesetProposed   = "CHANGESET_PROPOSED"
+	AuditChangesetRolledBack = "CHANGESET_ROLLED_BACK"
+)
+
+// AuditConfig configures the audit log, which has its own file and retention.
+type AuditConfig struct {
+	Dir            string
+	FileMaxSizeMB  int
+	FileMaxAgeDays int
+	FileMaxBackups int // files
+	// FileNoCompress keeps the rotated files uncompressed, they are gzipped by default.
+	FileNoCompress bool
+}
+
+func (c AuditConfig) File() string {
+	return filepath.Join(c.Dir, auditLogsFile)
+}
+
+// AuditRecord is a record of the audit log. Each record has the hash of the previous one, so that removed or
+// modified records break the chain.
+type AuditRecord struct {
+	Seq    uint64         `json:"seq"`
+	TS     time.Time      `json:"ts"`
+	Logger string         `json:"logger,omitempty"`
+	Event  string         `json:"event"`
+	Fields map[string]any `json:"fields,omitempty"`
+	Prev   string         `json:"prev"`
+}
+
+// auditLine is a line of the audit log file. Hash is the sha256 of the bytes of Record.
+type auditLine struct {
+	Record json.RawMessage `json:"record"`
+	Hash   string          `json:"hash"`
+}
+
+// AuditLog is an always-on core writing hash-chained AuditRecords to the audit log file, regardless of the log
+// level. Entries are recorded with their message as event.
+type AuditLog struct {
+	fields []zapcore.Field
+	state  *auditState
+}
+
+type auditState struct {
+	mu   sync.Mutex
+	out  io.WriteCloser
+	seq  uint64
+	prev string
+	now  func() time.Time
+}
+
+var _ zapcore.Core = &AuditLog{}
+
+// NewAuditLog opens the audit log file, continuing the chain of its last record.
+func NewAuditLog(cfg AuditConfig) (*AuditLog, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("missing audit log directory")
+	}
+	seq, prev, err := lastAuditRecord(cfg.File())
+	if err != nil {
+		return nil, err
+	}
+	file := &lumberjack.Logger{
+		Filename:   cfg.File(),
+		MaxSize:    cfg.FileMaxSizeMB,
+		MaxAge:     cfg.FileMaxAgeDays,
+		MaxBackups: cfg.FileMaxBackups,
+		Compress:   !cfg.FileNoCompress,
+	}
+	return newAuditLog(file, seq, prev), nil
+}
+
+func newAuditLog(out io.WriteCloser, seq uint64, prev string) *AuditLog {
+	return &AuditLog{state: &auditState{out: out, seq: seq, prev: prev, now: time.Now}}
+}
+
+// lastAuditRecord returns the sequence number and hash of the last record of the file, if any.
+func lastAuditRecord(path string) (uint64, string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, "", nil
+	} else if err != nil {
+		return 0, "", fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	last, _, err := VerifyAuditLog(f, "")
+	if err != nil {
+		var chainErr *AuditChainError
+		// The first record continues the chain of a rotated file
+		if !errors.As(err, &chainErr) || chainErr.Seq != 0 {
+			return 0, "", fmt.Errorf("invalid audit log %s: %w", path, err)
+		}
+		if _, err = f.Seek(0, io.SeekStart); err != nil {
+			return 0, "", err
+		}
+		if last, _, err = VerifyAuditLog(f, chainErr.Prev); err != nil {
+			return 0, "", fmt.Errorf("invalid audit log %s: %w", path, err)
+		}
+	}
+	if last == nil {
+		return 0, "", nil
+	}
+	return last.Seq, last.hash, nil
+}
+
+func (a *AuditLog) Enabled(zapcore.Level) bool { return true }
+
+func (a *AuditLog) With(fs []zapcore.Field) zapcore.Core {
+	return &AuditLog{fields: append(a.fields[:len(a.fields):len(a.fields)], fs...), state: a.state}
+}
+
+func (a *AuditLog) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(e, a)
+}
+
+func (a *AuditLog) Write(e zapcore.Entry, fs []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range a.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fs {
+		f.AddTo(enc)
+	}
+	var fields map[string]any
+	if len(enc.Fields) > 0 {
+		fields = enc.Fields
+	}
+	return a.state.write(e.LoggerName, e.Message, e.Time, fields)
+}
+
+func (a *AuditLog) Sync() error { return nil }
+
+// Close closes the audit log file.
+func (a *AuditLog) Close() error {
+	a.state.mu.Lock()
+	defer a.state.mu.Unlock()
+	return a.state.out.Close()
+}
+
+// Auditw records the event with the logger name and key-value pairs.
+func (a *AuditLog) Auditw(logger string, event string, keyvals ...any) error {
+	return a.Write(zapcore.Entry{LoggerName: logger, Message: event}, sweetenFields(keyvals))
+}
+
+func (s *auditState) write(logger, event string, ts time.Time, fields map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ts.IsZero() {
+		ts = s.now()
+	}
+	record, err := json.Marshal(AuditRecord{
+		Seq:    s.seq + 1,
+		TS:     ts.UTC(),
+		Logger: logger,
+		Event:  event,
+		Fields: fields,
+		Prev:   s.prev,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	sum := sha256.Sum256(record)
+	hash := hex.EncodeToString(sum[:])
+	line, err := json.Marshal(auditLine{Record: record, Hash: hash})
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	if _, err = s.out.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	s.seq++
+	s.prev = hash
+	return nil
+}
+
+// sweetenFields converts loosely typed key-value pairs to fields, like zap.SugaredLogger.
+func sweetenFields(keyvals []any) []zapcore.Field {
+	fields := make([]zapcore.Field, 0, len(keyvals)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		if i == len(keyvals)-1 {
+			fields = append(fields, zap.Any("ignored", keyvals[i]))
+			break
+		}
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		fields = append(fields, zap.Any(key, keyvals[i+1]))
+	}
+	return fields
+}
+
+// AuditChainError is returned by VerifyAuditLog when a record does not chain to the previous one.
+type AuditChainError struct {
+	// Seq is the sequence number of the previous record, 0 for the first record of the file.
+	Seq uint64
+	// Prev is the previous hash of the record.
+	Prev string
+}
+
+func (e *AuditChainError) Error() string {
+	return fmt.Sprintf("audit record after %d does not chain to the previous record", e.Seq)
+}
+
+// VerifiedAuditRecord is an AuditRecord with its hash.
+type VerifiedAuditRecord struct {
+	AuditRecord
+	hash string
+}
+
+// Hash returns the hash of the record, which the next record has as previous hash.
+func (r VerifiedAuditRecord) Hash() string { return r.hash }
+
+// VerifyAuditLog verifies the hashes and the chain of the records of r, starting with prev, the hash of the last
+// record of the previous file, if any. It returns the last record and the number of records.
+func VerifyAuditLog(r io.Reader, prev string) (*VerifiedAuditRecord, int, error) {
+	var (
+		last *VerifiedAuditRecord
+		n    int
+		br   = bufio.NewReader(r)
+	)
+	for {
+		b, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(b)) > 0 {
+			var line auditLine
+			if jerr := json.Unmarshal(b, &line); jerr != nil {
+				return last, n, fmt.Errorf("invalid audit line %d: %w", n+1, jerr)
+			}
+			sum := sha256.Sum256(line.Record)
+			if hex.EncodeToString(sum[:]) != line.Hash {
+				return last, n, fmt.Errorf("audit line %d: hash mismatch", n+1)
+			}
+			var record AuditRecord
+			if jerr := json.Unmarshal(line.Record, &record); jerr != nil {
+				return last, n, fmt.Errorf("invalid audit record %d: %w", n+1, jerr)
+			}
+			var prevSeq uint64
+			if last != nil {
+				prevSeq = last.Seq
+			}
+			if record.Prev != prev || (last != nil && record.Seq != prevSeq+1) {
+				return last, n, &AuditChainError{Seq: prevSeq, Prev: record.Prev}
+			}
+			last = &VerifiedAuditRecord{AuditRecord: record, hash: line.Hash}
+			prev = line.Hash
+			n++
+		}
+		if errors.Is(err, io.EOF) {
+			return last, n, nil
+		} else if err != nil {
+			return last, n, fmt.Errorf("failed to read audit log: %w", err)
+		}
+	}
+}
+
+// Auditor is implemented by the loggers of this package which have an audit log.
+type Auditor interface {
+	Auditw(event string, keyvals ...any)
+}
+
+// Auditw records the event in the audit log of l, or logs it at info level if l has none.
+func Auditw(l Logger, event string, keyvals ...any) {
+	if a, ok := l.(Auditor); ok {
+		a.Auditw(event, keyvals...)
+		return
+	}
+	l.Infow(event, append(keyvals[:len(keyvals):len(keyvals)], "audit", true)...)
+}
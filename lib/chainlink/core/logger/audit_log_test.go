@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAuditw(t *testing.T) {
+	auditCfg := AuditConfig{Dir: t.TempDir()}
+	lggr := newTestLogger(t, Config{LogLevel: zapcore.ErrorLevel, Audit: &auditCfg})
+
+	// Audit events are recorded regardless of the log level
+	Sugared(lggr.Named("Keystore")).Auditw(AuditKeyUsed, "key", "0xabc")
+	Auditw(lggr.Named("Config").With("node", "a"), AuditConfigChanged, "path", "Log.Level")
+
+	f, err := os.Open(auditCfg.File())
+	require.NoError(t, err)
+	defer f.Close()
+	last, n, err := VerifyAuditLog(f, "")
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	assert.Equal(t, uint64(2), last.Seq)
+	assert.Equal(t, "Config", last.Logger)
+	assert.Equal(t, AuditConfigChanged, last.Event)
+	assert.Equal(t, "a", last.Fields["node"])
+	assert.Equal(t, "Log.Level", last.Fields["path"])
+	assert.Contains(t, last.Fields, "version")
+	assert.NotEmpty(t, last.Prev)
+
+	// Reopening the audit log continues the chain
+	audit, err := NewAuditLog(auditCfg)
+	require.NoError(t, err)
+	require.NoError(t, audit.Auditw("Deployment", AuditChangesetExecuted, "changeset", "deploy"))
+	require.NoError(t, audit.Close())
+
+	b, err := os.ReadFile(auditCfg.File())
+	require.NoError(t, err)
+	last, n, err = VerifyAuditLog(bytes.NewReader(b), "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, uint64(3), last.Seq)
+}
+
+func TestAuditw_NoAuditLog(t *testing.T) {
+	lggr, o := TestLoggerWithObserver(t, zapcore.InfoLevel)
+	Sugared(lggr).Auditw(AuditKeyUsed, "key", "0xabc")
+	require.Equal(t, 1, o.ByField("audit", true).Len())
+	assert.Equal(t, AuditKeyUsed, o.All()[0].Message)
+}
+
+func TestVerifyAuditLog(t *testing.T) {
+	var buf bytes.Buffer
+	audit := newAuditLog(nopWriteCloser{&buf}, 0, "")
+	lggr := zap.New(audit).Sugar()
+	for i := range 3 {
+		lggr.Debugw(AuditChangesetExecuted, "i", i)
+	}
+	lines := strings.SplitAfter(buf.String(), "\n")
+	require.Len(t, lines, 4)
+
+	_, n, err := VerifyAuditLog(strings.NewReader(buf.String()), "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	t.Run("modified record", func(t *testing.T) {
+		tampered := strings.Replace(buf.String(), `"i":1`, `"i":7`, 1)
+		_, n, err := VerifyAuditLog(strings.NewReader(tampered), "")
+		require.ErrorContains(t, err, "audit line 2: hash mismatch")
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("removed record", func(t *testing.T) {
+		_, _, err := VerifyAuditLog(strings.NewReader(lines[0]+lines[2]), "")
+		var chainErr *AuditChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.Equal(t, uint64(1), chainErr.Seq)
+	})
+
+	t.Run("rotated file", func(t *testing.T) {
+		first, _, err := VerifyAuditLog(strings.NewReader(lines[0]), "")
+		require.NoError(t, err)
+		_, n, err := VerifyAuditLog(strings.NewReader(lines[1]+lines[2]), first.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		_, _, err = VerifyAuditLog(strings.NewReader(lines[1]+lines[2]), "")
+		var chainErr *AuditChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.Equal(t, uint64(0), chainErr.Seq)
+	})
+}
+
+type nopWriteCloser struct {
+	*bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error { return nil }
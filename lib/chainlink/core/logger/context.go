@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ctxKey struct{}
+
+type ctxLogger struct {
+	l           Logger
+	baggageKeys []string
+}
+
+// WithContext returns a copy of ctx carrying l. Loggers returned by FromContext also log the baggage members with
+// the given keys.
+func WithContext(ctx context.Context, l Logger, baggageKeys ...string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, ctxLogger{l: l, baggageKeys: baggageKeys})
+}
+
+// FromContext returns the logger carried by ctx, with the trace and span IDs of the span of ctx and the selected
+// baggage members as fields. It returns NullLogger if ctx carries no logger.
+func FromContext(ctx context.Context) Logger {
+	cl, ok := ctx.Value(ctxKey{}).(ctxLogger)
+	if !ok {
+		return NullLogger
+	}
+	fields := ContextFields(ctx, cl.baggageKeys...)
+	if len(fields) == 0 {
+		return cl.l
+	}
+	return cl.l.With(fields...)
+}
+
+// ContextFields returns the trace_id and span_id of the span of ctx, if valid, and the members of the baggage of
+// ctx with the given keys, as key-value pairs.
+func ContextFields(ctx context.Context, baggageKeys ...string) []any {
+	var fields []any
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields, "trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+	if len(baggageKeys) > 0 {
+		b := baggage.FromContext(ctx)
+		for _, k := range baggageKeys {
+			if m := b.Member(k); m.Key() != "" {
+				fields = append(fields, k, m.Value())
+			}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, NullLogger, FromContext(context.Background()))
+
+	lggr, o := TestLoggerWithObserver(t, zapcore.DebugLevel)
+	ctx := WithContext(context.Background(), lggr, "tenant", "missing")
+
+	FromContext(ctx).Info("no span")
+	require.Equal(t, 1, o.Len())
+	assert.NotContains(t, o.All()[0].ContextMap(), "trace_id")
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx = trace.ContextWithSpanContext(ctx, sc)
+	member, err := baggage.NewMember("tenant", "acme")
+	require.NoError(t, err)
+	other, err := baggage.NewMember("other", "ignored")
+	require.NoError(t, err)
+	b, err := baggage.New(member, other)
+	require.NoError(t, err)
+	ctx = baggage.ContextWithBaggage(ctx, b)
+
+	o.Reset()
+	FromContext(ctx).Infow("with span", "key", "value")
+	require.Equal(t, 1, o.Len())
+	fields := o.All()[0].ContextMap()
+	assert.Equal(t, sc.TraceID().String(), fields["trace_id"])
+	assert.Equal(t, sc.SpanID().String(), fields["span_id"])
+	assert.Equal(t, "acme", fields["tenant"])
+	assert.Equal(t, "value", fields["key"])
+	assert.NotContains(t, fields, "other")
+	assert.NotContains(t, fields, "missing")
+}
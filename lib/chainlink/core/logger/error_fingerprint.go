@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrorFingerprintConfig configures an ErrorFingerprinter.
+type ErrorFingerprintConfig struct {
+	// Fields are the keys of the fields part of the fingerprint, along with the logger name and the message template.
+	Fields []string
+	// Interval is the period of the summaries, one hour if zero.
+	Interval time.Duration
+	// Top is the number of fingerprints of each summary, 10 if zero.
+	Top int
+}
+
+// ErrorFingerprint is a group of error and critical entries with the same logger, message template and fields.
+type ErrorFingerprint struct {
+	Logger   string            `json:"logger,omitempty"`
+	Template string            `json:"template"`
+	Fields   map[string]string `json:"fields,omitempty"`
+	Count    int               `json:"count"`
+	First    time.Time         `json:"first"`
+	Last     time.Time         `json:"last"`
+}
+
+var templateValues = regexp.MustCompile(`0x[0-9a-fA-F]+|\d+`)
+
+// messageTemplate replaces the hex strings and numbers of msg with placeholders, so that formatted messages of the
+// same call site share a fingerprint.
+func messageTemplate(msg string) string {
+	return templateValues.ReplaceAllStringFunc(msg, func(v string) string {
+		if strings.HasPrefix(v, "0x") {
+			return "0x?"
+		}
+		return "?"
+	})
+}
+
+// ErrorFingerprinter counts the error and critical entries by fingerprint, for "top errors" summaries.
+// Its Core must be added to the logger, e.g. with AtomicCore.AddCore.
+type ErrorFingerprinter struct {
+	cfg ErrorFingerprintConfig
+	now func() time.Time
+
+	mu     sync.Mutex
+	counts map[string]*ErrorFingerprint
+}
+
+func NewErrorFingerprinter(cfg ErrorFingerprintConfig) *ErrorFingerprinter {
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Hour
+	}
+	if cfg.Top == 0 {
+		cfg.Top = 10
+	}
+	return &ErrorFingerprinter{cfg: cfg, now: time.Now, counts: make(map[string]*ErrorFingerprint)}
+}
+
+// Core returns the core counting the entries.
+func (f *ErrorFingerprinter) Core() zapcore.Core {
+	return &fingerprintCore{f: f}
+}
+
+func (f *ErrorFingerprinter) add(e zapcore.Entry, fields map[string]string) {
+	template := messageTemplate(e.Message)
+	var key strings.Builder
+	key.WriteString(e.LoggerName)
+	key.WriteByte(0)
+	key.WriteString(template)
+	for _, k := range f.cfg.Fields {
+		if v, ok := fields[k]; ok {
+			fmt.Fprintf(&key, "\x00%s=%s", k, v)
+		}
+	}
+
+	t := e.Time
+	if t.IsZero() {
+		t = f.now()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fp, ok := f.counts[key.String()]
+	if !ok {
+		fp = &ErrorFingerprint{Logger: e.LoggerName, Template: template, Fields: fields, First: t}
+		f.counts[key.String()] = fp
+	}
+	fp.Count++
+	fp.Last = t
+}
+
+// Top returns the n most frequent fingerprints since the last summary, all of them if n <= 0.
+func (f *ErrorFingerprinter) Top(n int) []ErrorFingerprint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.top(n)
+}
+
+func (f *ErrorFingerprinter) top(n int) []ErrorFingerprint {
+	fps := make([]ErrorFingerprint, 0, len(f.counts))
+	for _, fp := range f.counts {
+		c := *fp
+		c.Fields = maps.Clone(fp.Fields)
+		fps = append(fps, c)
+	}
+	slices.SortFunc(fps, func(a, b ErrorFingerprint) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Logger, b.Logger), cmp.Compare(a.Template, b.Template))
+	})
+	if n > 0 && len(fps) > n {
+		fps = fps[:n]
+	}
+	return fps
+}
+
+// Summarize returns the top fingerprints and the total number of counted entries, and resets the counters.
+func (f *ErrorFingerprinter) Summarize() ([]ErrorFingerprint, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	top := f.top(f.cfg.Top)
+	var total int
+	for _, fp := range f.counts {
+		total += fp.Count
+	}
+	clear(f.counts)
+	return top, total
+}
+
+// Run logs a summary of the top errors to lggr every interval, until ctx is done. Intervals without errors are not
+// logged.
+func (f *ErrorFingerprinter) Run(ctx context.Context, lggr Logger) {
+	ticker := time.NewTicker(f.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.logSummary(lggr)
+		}
+	}
+}
+
+func (f *ErrorFingerprinter) logSummary(lggr Logger) {
+	top, total := f.Summarize()
+	if total == 0 {
+		return
+	}
+	lggr.Infow(fmt.Sprintf("Top errors in the last %s", f.cfg.Interval), "total", total, "distinct", len(top), "errors", top)
+}
+
+// fingerprintCore counts the error and critical entries of the logger in its ErrorFingerprinter.
+type fingerprintCore struct {
+	f      *ErrorFingerprinter
+	fields []zapcore.Field
+}
+
+var _ zapcore.Core = &fingerprintCore{}
+
+func (c *fingerprintCore) Enabled(l zapcore.Level) bool { return l >= zapcore.ErrorLevel }
+
+func (c *fingerprintCore) With(fs []zapcore.Field) zapcore.Core {
+	return &fingerprintCore{f: c.f, fields: append(slices.Clip(c.fields), fs...)}
+}
+
+func (c *fingerprintCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *fingerprintCore) Write(e zapcore.Entry, fs []zapcore.Field) error {
+	var fields map[string]string
+	if len(c.f.cfg.Fields) > 0 {
+		enc := zapcore.NewMapObjectEncoder()
+		for _, f := range c.fields {
+			f.AddTo(enc)
+		}
+		for _, f := range fs {
+			f.AddTo(enc)
+		}
+		for _, k := range c.f.cfg.Fields {
+			if v, ok := enc.Fields[k]; ok {
+				if fields == nil {
+					fields = make(map[string]string, len(c.f.cfg.Fields))
+				}
+				fields[k] = fmt.Sprint(v)
+			}
+		}
+	}
+	c.f.add(e, fields)
+	return nil
+}
+
+func (c *fingerprintCore) Sync() error { return nil }
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMessageTemplate(t *testing.T) {
+	assert.Equal(t, "failed to fetch block ? from 0x?", messageTemplate("failed to fetch block 1234 from 0xdeadBEEF"))
+	assert.Equal(t, "no numbers", messageTemplate("no numbers"))
+}
+
+func TestErrorFingerprinter(t *testing.T) {
+	f := NewErrorFingerprinter(ErrorFingerprintConfig{Fields: []string{"chainID"}, Top: 2})
+	lggr := zap.New(f.Core()).Sugar().Named("Txm")
+
+	for i := range 3 {
+		lggr.Errorw("failed to send tx", "chainID", 1, "nonce", i)
+	}
+	lggr.Errorw("failed to send tx", "chainID", 2)
+	lggr.With("chainID", 1).Errorf("failed to fetch block %d", 10)
+	lggr.With("chainID", 1).Errorf("failed to fetch block %d", 11)
+	lggr.Warn("not counted")
+	lggr.Info("not counted")
+
+	top := f.Top(0)
+	require.Len(t, top, 3)
+	assert.Equal(t, "Txm", top[0].Logger)
+	assert.Equal(t, "failed to send tx", top[0].Template)
+	assert.Equal(t, map[string]string{"chainID": "1"}, top[0].Fields)
+	assert.Equal(t, 3, top[0].Count)
+	assert.Equal(t, "failed to fetch block ?", top[1].Template)
+	assert.Equal(t, 2, top[1].Count)
+	assert.Equal(t, map[string]string{"chainID": "2"}, top[2].Fields)
+
+	summary, total := f.Summarize()
+	assert.Len(t, summary, 2)
+	assert.Equal(t, 6, total)
+	assert.Empty(t, f.Top(0))
+
+	// Summaries are only logged when errors were counted
+	summaryLggr, o := TestLoggerWithObserver(t, zapcore.InfoLevel)
+	f.logSummary(summaryLggr)
+	assert.Equal(t, 0, o.Len())
+
+	lggr.Error("failed")
+	f.logSummary(summaryLggr)
+	require.Equal(t, 1, o.Len())
+	assert.Equal(t, "Top errors in the last 1h0m0s", o.All()[0].Message)
+	assert.Equal(t, int64(1), o.All()[0].ContextMap()["total"])
+}
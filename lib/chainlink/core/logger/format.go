@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Format is the format of the console output.
+type Format struct {
+	// JSON logs JSON lines instead of pretty printed entries.
+	JSON bool
+	// UnixTS logs unix timestamps instead of ISO8601 times, in the console and the disk logs.
+	UnixTS bool
+}
+
+// AtomicFormat is a Format that can be changed at runtime, like zap.AtomicLevel.
+type AtomicFormat struct {
+	f atomic.Pointer[Format]
+}
+
+// NewAtomicFormat returns an AtomicFormat set to f.
+func NewAtomicFormat(f Format) *AtomicFormat {
+	var a AtomicFormat
+	a.SetFormat(f)
+	return &a
+}
+
+// Format returns the current format.
+func (a *AtomicFormat) Format() Format { return *a.f.Load() }
+
+// SetFormat changes the format of the loggers using a.
+func (a *AtomicFormat) SetFormat(f Format) { a.f.Store(&f) }
+
+func (a *AtomicFormat) encodeTime(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	if a.Format().UnixTS {
+		zapcore.EpochTimeEncoder(t, enc)
+		return
+	}
+	zapcore.ISO8601TimeEncoder(t, enc)
+}
+
+// encoderConfig returns the encoder config with the time encoded in the current format.
+func (a *AtomicFormat) encoderConfig() zapcore.EncoderConfig {
+	cfg := makeEncoderConfig(true)
+	cfg.EncodeTime = a.encodeTime
+	return cfg
+}
+
+// formatSink writes the JSON lines of the encoder as is, or pretty printed, depending on the current format.
+type formatSink struct {
+	zapcore.WriteSyncer
+	format *AtomicFormat
+}
+
+func newFormatSink(ws zapcore.WriteSyncer, format *AtomicFormat) *formatSink {
+	return &formatSink{WriteSyncer: ws, format: format}
+}
+
+func (s *formatSink) Write(b []byte) (int, error) {
+	if s.format.Format().JSON {
+		return s.WriteSyncer.Write(b)
+	}
+	pretty, err := prettyPrint(b)
+	if err != nil {
+		return 0, err
+	}
+	return s.WriteSyncer.Write(pretty)
+}
+
+// FormatSetter is implemented by the loggers of this package whose format can be changed at runtime.
+type FormatSetter interface {
+	// SetLogFormat changes the format for this and all connected Loggers.
+	SetLogFormat(Format)
+}
+
+// SetLogFormat changes the format of l and all connected Loggers, and reports whether l supports it.
+func SetLogFormat(l Logger, f Format) bool {
+	s, ok := l.(FormatSetter)
+	if ok {
+		s.SetLogFormat(f)
+	}
+	return ok
+}
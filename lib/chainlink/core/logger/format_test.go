@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSetLogFormat(t *testing.T) {
+	var buf bytes.Buffer
+	format := NewAtomicFormat(Format{JSON: true, UnixTS: true})
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(format.encoderConfig()), newFormatSink(zapcore.AddSync(&buf), format), zapcore.DebugLevel)
+	zl := &zapLogger{level: zap.NewAtomicLevel(), format: format, SugaredLogger: zap.New(core).Sugar()}
+	lggr := Sugared(newPrometheusLogger(zl.Named("Test")))
+
+	lggr.Infow("json", "key", "value")
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "json", entry["msg"])
+	assert.IsType(t, float64(0), entry["ts"])
+
+	require.True(t, SetLogFormat(lggr, Format{JSON: false, UnixTS: false}))
+	assert.Equal(t, Format{}, format.Format())
+
+	buf.Reset()
+	lggr.Infow("pretty", "key", "value")
+	out := buf.String()
+	assert.False(t, json.Valid(buf.Bytes()))
+	assert.Contains(t, out, "pretty")
+	assert.Contains(t, out, "key=value")
+	assert.Contains(t, out, "Z") // ISO8601 in UTC
+
+	assert.False(t, SetLogFormat(NullLogger, Format{JSON: true}))
+}
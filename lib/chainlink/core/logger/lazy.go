@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Lazy returns a value for the key-value pairs of the log methods, which is only computed by fn when the entry is
+// encoded, i.e. after it passed the level and sampling checks. fn is called at most once, even if the entry is
+// written to several cores. The value is encoded as JSON, e.g.
+//
+//	lggr.Debugw("Received report", "report", logger.Lazy(func() any { return decodeReport(raw) }))
+//
+// Values passed to With are encoded immediately, like any other field.
+func Lazy(fn func() any) any {
+	return &lazyValue{fn: sync.OnceValue(fn)}
+}
+
+type lazyValue struct {
+	fn func() any
+}
+
+var (
+	_ json.Marshaler = &lazyValue{}
+	_ fmt.Formatter  = &lazyValue{}
+)
+
+func (l *lazyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.fn())
+}
+
+// Format formats the computed value, for the cores printing fields with fmt.
+func (l *lazyValue) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, fmt.FormatString(f, verb), l.fn())
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLazy(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+	newCore := func(buf *bytes.Buffer) zapcore.Core {
+		return zapcore.NewCore(zapcore.NewJSONEncoder(makeEncoderConfig(true)), zapcore.AddSync(buf), zapcore.InfoLevel)
+	}
+	core := zapcore.NewTee(newCore(&buf1), newCore(&buf2))
+	lggr := &zapLogger{level: zap.NewAtomicLevel(), SugaredLogger: zap.New(core).Sugar()}
+
+	var calls int
+	value := func() any {
+		calls++
+		return map[string]int{"size": 42}
+	}
+
+	lggr.Debugw("not logged", "report", Lazy(value))
+	assert.Equal(t, 0, calls)
+	assert.Zero(t, buf1.Len())
+
+	lggr.Infow("logged", "report", Lazy(value))
+	assert.Equal(t, 1, calls)
+	for _, buf := range []*bytes.Buffer{&buf1, &buf2} {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, map[string]any{"size": float64(42)}, entry["report"])
+	}
+
+	assert.Equal(t, "7", fmt.Sprint(Lazy(func() any { return 7 })))
+}
+
+func TestLazy_Sampling(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(makeEncoderConfig(true)), zapcore.AddSync(&buf), zapcore.DebugLevel)
+	sampling := &SamplingConfig{Levels: map[zapcore.Level]SamplingRate{zapcore.DebugLevel: {Initial: 1}}}
+	core, err := sampling.wrap(core)
+	require.NoError(t, err)
+	lggr := zap.New(core).Sugar()
+
+	var calls int
+	for range 3 {
+		lggr.Debugw("sampled", "value", Lazy(func() any { calls++; return calls }))
+	}
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,190 @@
+package logger
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelOverrides overrides the log level of named loggers at runtime, e.g. EVM.Txm=debug while everything else stays
+// at the global level. An override applies to the logger with that name and all its Named children, the longest
+// matching name wins.
+//
+// Pass it in Config.LevelOverrides and keep a reference to change the overrides at runtime. Overrides apply to the
+// console output and to the AtomicCores passed to NewWithCores, see AtomicCore.SetLevelOverrides. The other cores of
+// NewWithCores keep their own level.
+type LevelOverrides struct {
+	mu     sync.Mutex // serializes the changes of the lookup
+	lookup atomic.Pointer[levelLookup]
+}
+
+// levelLookup is an immutable snapshot of the overrides, replaced on every change so that lookups are lock-free.
+type levelLookup struct {
+	base      zap.AtomicLevel
+	overrides map[string]zapcore.Level
+	// min is the lowest level of the overrides, valid if there are overrides.
+	min zapcore.Level
+}
+
+// NewLevelOverrides returns empty overrides.
+func NewLevelOverrides() *LevelOverrides {
+	o := &LevelOverrides{}
+	o.lookup.Store(&levelLookup{base: zap.NewAtomicLevel()})
+	return o
+}
+
+// ParseLevelOverrides parses comma separated name=level pairs, e.g. "EVM.Txm=debug,Mercury=warn".
+func ParseLevelOverrides(s string) (map[string]zapcore.Level, error) {
+	out := make(map[string]zapcore.Level)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, lvl, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid level override %q, expected name=level", pair)
+		}
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(lvl)); err != nil {
+			return nil, fmt.Errorf("invalid level override %q: %w", pair, err)
+		}
+		out[name] = level
+	}
+	return out, nil
+}
+
+// Set overrides the level of the named logger and its children.
+func (o *LevelOverrides) Set(name string, lvl zapcore.Level) {
+	o.update(func(l *levelLookup) {
+		l.overrides[name] = lvl
+	})
+}
+
+// SetAll replaces all the overrides.
+func (o *LevelOverrides) SetAll(overrides map[string]zapcore.Level) {
+	o.update(func(l *levelLookup) {
+		l.overrides = maps.Clone(overrides)
+	})
+}
+
+// Remove removes the override of the named logger, which falls back to the override of its parents or the global level.
+func (o *LevelOverrides) Remove(name string) {
+	o.update(func(l *levelLookup) {
+		delete(l.overrides, name)
+	})
+}
+
+func (o *LevelOverrides) setBase(base zap.AtomicLevel) {
+	o.update(func(l *levelLookup) {
+		l.base = base
+	})
+}
+
+// update stores a copy of the lookup changed by fn.
+func (o *LevelOverrides) update(fn func(l *levelLookup)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	old := o.lookup.Load()
+	l := &levelLookup{base: old.base, overrides: maps.Clone(old.overrides)}
+	if l.overrides == nil {
+		l.overrides = make(map[string]zapcore.Level)
+	}
+	fn(l)
+	l.min = zapcore.InvalidLevel
+	for _, lvl := range l.overrides {
+		if l.min == zapcore.InvalidLevel || lvl < l.min {
+			l.min = lvl
+		}
+	}
+	o.lookup.Store(l)
+}
+
+// Level returns the level of the named logger.
+func (o *LevelOverrides) Level(name string) zapcore.Level {
+	l := o.lookup.Load()
+	if lvl, ok := l.override(name); ok {
+		return lvl
+	}
+	return l.base.Level()
+}
+
+// Enabled returns whether the level is enabled for any logger.
+func (o *LevelOverrides) Enabled(lvl zapcore.Level) bool {
+	l := o.lookup.Load()
+	return l.base.Enabled(lvl) || l.overridesEnabled(lvl)
+}
+
+// override returns the override of the named logger, or of its closest parent.
+func (l *levelLookup) override(name string) (zapcore.Level, bool) {
+	if len(l.overrides) == 0 {
+		return 0, false
+	}
+	for {
+		if lvl, ok := l.overrides[name]; ok {
+			return lvl, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// overridesEnabled returns whether the level is enabled by any override.
+func (l *levelLookup) overridesEnabled(lvl zapcore.Level) bool {
+	return len(l.overrides) > 0 && l.min.Enabled(lvl)
+}
+
+// levelOverrideCore filters entries with the level of their logger name.
+type levelOverrideCore struct {
+	zapcore.Core
+	overrides *LevelOverrides
+}
+
+func newLevelOverrideCore(encoder zapcore.Encoder, sink zapcore.WriteSyncer, overrides *LevelOverrides) zapcore.Core {
+	return &levelOverrideCore{Core: zapcore.NewCore(encoder, sink, overrides), overrides: overrides}
+}
+
+func (c *levelOverrideCore) With(fs []zapcore.Field) zapcore.Core {
+	return &levelOverrideCore{Core: c.Core.With(fs), overrides: c.overrides}
+}
+
+func (c *levelOverrideCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.overrides.Level(e.LoggerName).Enabled(e.Level) {
+		return ce
+	}
+	return c.Core.Check(e, ce)
+}
+
+// overriddenCore applies the overrides to the entries of the overridden loggers, which are written to the wrapped
+// core whatever its level, and lets the wrapped core filter the other entries.
+type overriddenCore struct {
+	zapcore.Core
+	overrides *LevelOverrides
+}
+
+func (c *overriddenCore) Enabled(lvl zapcore.Level) bool {
+	return c.Core.Enabled(lvl) || c.overrides.lookup.Load().overridesEnabled(lvl)
+}
+
+func (c *overriddenCore) With(fs []zapcore.Field) zapcore.Core {
+	return &overriddenCore{Core: c.Core.With(fs), overrides: c.overrides}
+}
+
+func (c *overriddenCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	lvl, ok := c.overrides.lookup.Load().override(e.LoggerName)
+	if !ok {
+		return c.Core.Check(e, ce)
+	}
+	if !lvl.Enabled(e.Level) {
+		return ce
+	}
+	return ce.AddCore(e, c.Core)
+}
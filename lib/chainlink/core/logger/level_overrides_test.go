@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestParseLevelOverrides(t *testing.T) {
+	overrides, err := ParseLevelOverrides("EVM.Txm=debug, Mercury=warn,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]zapcore.Level{"EVM.Txm": zapcore.DebugLevel, "Mercury": zapcore.WarnLevel}, overrides)
+
+	_, err = ParseLevelOverrides("EVM.Txm")
+	require.ErrorContains(t, err, "expected name=level")
+
+	_, err = ParseLevelOverrides("EVM.Txm=loud")
+	require.ErrorContains(t, err, `invalid level override "EVM.Txm=loud"`)
+}
+
+func TestLevelOverrides(t *testing.T) {
+	base := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	overrides := NewLevelOverrides()
+	overrides.setBase(base)
+
+	var buf bytes.Buffer
+	core := newLevelOverrideCore(zapcore.NewJSONEncoder(makeEncoderConfig(false)), zapcore.AddSync(&buf), overrides)
+	root := &zapLogger{level: base, SugaredLogger: zap.New(core).Sugar()}
+	txm := root.Named("EVM").Named("Txm").With("chainID", 1)
+	head := root.Named("EVM").Named("HeadTracker")
+	txmer := root.Named("EVM").Named("Txmer")
+
+	logged := func(l Logger, msg string) bool {
+		buf.Reset()
+		l.Debug(msg)
+		return bytes.Contains(buf.Bytes(), []byte(msg))
+	}
+
+	assert.False(t, logged(txm, "no override"))
+
+	overrides.Set("EVM.Txm", zapcore.DebugLevel)
+	assert.True(t, logged(txm, "override"))
+	assert.True(t, logged(txm.Named("Broadcaster"), "child override"))
+	assert.False(t, logged(head, "sibling"))
+	assert.False(t, logged(txmer, "same prefix"))
+
+	overrides.Set("EVM", zapcore.ErrorLevel)
+	assert.True(t, logged(txm, "longest match"))
+	assert.Equal(t, zapcore.ErrorLevel, overrides.Level("EVM.HeadTracker"))
+
+	overrides.Remove("EVM.Txm")
+	assert.False(t, logged(txm, "removed"))
+
+	overrides.SetAll(nil)
+	root.SetLogLevel(zapcore.DebugLevel)
+	assert.True(t, logged(head, "global level"))
+}
+
+func TestAtomicCore_LevelOverrides(t *testing.T) {
+	overrides := NewLevelOverrides()
+	atomicCore := NewAtomicCore()
+	atomicCore.SetLevelOverrides(overrides)
+	obsCore, logs := observer.New(zapcore.InfoLevel)
+	atomicCore.Store(obsCore)
+
+	root := zap.New(atomicCore)
+	txm := root.Named("EVM").Named("Txm").With(zap.Int("chainID", 1)).Sugar()
+	head := root.Named("EVM").Named("HeadTracker").Sugar()
+
+	txm.Debug("no override")
+	head.Info("stored core level")
+	assert.Equal(t, []string{"stored core level"}, messages(logs.TakeAll()))
+
+	overrides.Set("EVM.Txm", zapcore.DebugLevel)
+	overrides.Set("EVM.HeadTracker", zapcore.ErrorLevel)
+	txm.Debug("override")
+	txm.Named("Broadcaster").Debug("child override")
+	head.Warn("overridden by a higher level")
+	assert.Equal(t, []string{"override", "child override"}, messages(logs.TakeAll()))
+
+	// added cores get the overrides too
+	addedCore, added := observer.New(zapcore.ErrorLevel)
+	require.NoError(t, atomicCore.AddCore("added", addedCore))
+	txm.Debug("added core")
+	assert.Equal(t, []string{"added core"}, messages(added.TakeAll()))
+	assert.Equal(t, []string{"added core"}, messages(logs.TakeAll()))
+
+	atomicCore.SetLevelOverrides(nil)
+	txm.Debug("removed")
+	assert.Zero(t, logs.Len())
+	assert.Zero(t, added.Len())
+}
+
+func messages(entries []observer.LoggedEntry) []string {
+	msgs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
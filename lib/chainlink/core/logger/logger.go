@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/fatih/color"
 	"go.uber.org/zap"
@@ -133,15 +134,12 @@ type Logger interface {
 	Recover(panicErr any)
 }
 
-// newZapConfigProd returns a new production zap.Config.
-func newZapConfigProd(jsonConsole bool, unixTS bool) zap.Config {
+// newZapConfigProd returns a new production zap.Config. The console format is set by the AtomicFormat of the logger.
+func newZapConfigProd(unixTS bool) zap.Config {
 	config := newZapConfigBase()
 	if !unixTS {
 		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
-	if !jsonConsole {
-		config.OutputPaths = []string{"pretty://console"}
-	}
 	return config
 }
 
@@ -165,7 +163,17 @@ type Config struct {
 	FileMaxSizeMB  int
 	FileMaxAgeDays int
 	FileMaxBackups int // files
+	// FileRotateInterval rotates the file on this interval even if it did not reach FileMaxSizeMB, disabled if zero.
+	FileRotateInterval time.Duration
+	// FileNoCompress keeps the rotated files uncompressed, they are gzipped by default.
+	FileNoCompress bool
 	SentryEnabled  bool
+	// LevelOverrides overrides LogLevel for named loggers, optional.
+	LevelOverrides *LevelOverrides
+	// Sampling samples repeated debug and info entries, optional.
+	Sampling *SamplingConfig
+	// Audit enables the audit log of Auditw, optional.
+	Audit *AuditConfig
 
 	diskSpaceAvailableFn diskSpaceAvailableFn
 	diskPollConfig       zapDiskPollConfig
@@ -179,8 +187,15 @@ func (c *Config) New() (Logger, func() error) {
 	return c.NewWithCores()
 }
 
-// NewWithCores is like New, but includes additional zapcore.Cores.
+// NewWithCores is like New, but includes additional zapcore.Cores. The LevelOverrides apply to the AtomicCores.
 func (c *Config) NewWithCores(cores ...zapcore.Core) (Logger, func() error) {
+	if c.LevelOverrides != nil {
+		for _, core := range cores {
+			if atomicCore, ok := core.(*AtomicCore); ok {
+				atomicCore.SetLevelOverrides(c.LevelOverrides)
+			}
+		}
+	}
 	if c.diskSpaceAvailableFn == nil {
 		c.diskSpaceAvailableFn = diskSpaceAvailable
 	}
@@ -188,7 +203,7 @@ func (c *Config) NewWithCores(cores ...zapcore.Core) (Logger, func() error) {
 		c.diskPollConfig = newDiskPollConfig(diskPollInterval)
 	}
 
-	cfg := newZapConfigProd(c.JsonConsole, c.UnixTS)
+	cfg := newZapConfigProd(c.UnixTS)
 	cfg.Level.SetLevel(c.LogLevel)
 	var (
 		l           Logger
@@ -196,7 +211,7 @@ func (c *Config) NewWithCores(cores ...zapcore.Core) (Logger, func() error) {
 		err         error
 	)
 	if !c.DebugLogsToDisk() {
-		l, closeLogger, err = newDefaultLogger(cfg, c.UnixTS, cores...)
+		l, closeLogger, err = newDefaultLogger(cfg, *c, cores...)
 	} else {
 		l, closeLogger, err = newRotatingFileLogger(cfg, *c, cores...)
 	}
@@ -204,6 +219,18 @@ func (c *Config) NewWithCores(cores ...zapcore.Core) (Logger, func() error) {
 		log.Fatal(err)
 	}
 
+	if c.Audit != nil {
+		audit, err := NewAuditLog(*c.Audit)
+		if err != nil {
+			log.Fatal(err)
+		}
+		l.(interface{ setAudit(*AuditLog) }).setAudit(audit)
+		closeLoggerOnly := closeLogger
+		closeLogger = func() error {
+			return errors.Join(closeLoggerOnly(), audit.Close())
+		}
+	}
+
 	if c.SentryEnabled {
 		l = newSentryLogger(l)
 	}
@@ -212,6 +239,10 @@ func (c *Config) NewWithCores(cores ...zapcore.Core) (Logger, func() error) {
 	return l, closeLogger
 }
 
+func (c Config) newAtomicFormat() *AtomicFormat {
+	return NewAtomicFormat(Format{JSON: c.JsonConsole, UnixTS: c.UnixTS})
+}
+
 // DebugLogsToDisk returns whether debug logs should be stored in disk
 func (c Config) DebugLogsToDisk() bool {
 	return c.FileMaxSizeMB > 0
@@ -248,8 +279,9 @@ func newZapConfigBase() zap.Config {
 	return cfg
 }
 
-func newDefaultLogger(zcfg zap.Config, unixTS bool, cores ...zapcore.Core) (Logger, func() error, error) {
-	core, coreCloseFn, err := newDefaultLoggingCore(zcfg, unixTS)
+func newDefaultLogger(zcfg zap.Config, c Config, cores ...zapcore.Core) (Logger, func() error, error) {
+	format := c.newAtomicFormat()
+	core, coreCloseFn, err := newDefaultLoggingCore(zcfg, format, c.LevelOverrides)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -258,11 +290,18 @@ func newDefaultLogger(zcfg zap.Config, unixTS bool, cores ...zapcore.Core) (Logg
 		core = zapcore.NewTee(append([]zapcore.Core{core}, cores...)...)
 	}
 
+	core, err = c.Sampling.wrap(core)
+	if err != nil {
+		coreCloseFn()
+		return nil, nil, err
+	}
+
 	l, loggerCloseFn, err := newLoggerForCore(zcfg, core)
 	if err != nil {
 		coreCloseFn()
 		return nil, nil, err
 	}
+	l.format = format
 
 	return l, func() error {
 		coreCloseFn()
@@ -283,41 +322,47 @@ func newLoggerForCore(zcfg zap.Config, core zapcore.Core) (*zapLogger, func(), e
 	}, closeFn, nil
 }
 
-func newDefaultLoggingCore(zcfg zap.Config, unixTS bool) (zapcore.Core, func(), error) {
-	encoder := zapcore.NewJSONEncoder(makeEncoderConfig(unixTS))
+func newDefaultLoggingCore(zcfg zap.Config, format *AtomicFormat, overrides *LevelOverrides) (zapcore.Core, func(), error) {
+	encoder := zapcore.NewJSONEncoder(format.encoderConfig())
 
-	sink, closeOut, err := zap.Open(zcfg.OutputPaths...)
+	out, closeOut, err := zap.Open(zcfg.OutputPaths...)
 	if err != nil {
 		return nil, nil, err
 	}
+	sink := newFormatSink(out, format)
 
 	if zcfg.Level == (zap.AtomicLevel{}) {
 		return nil, nil, errors.New("missing Level")
 	}
 
+	if overrides != nil {
+		overrides.setBase(zcfg.Level)
+		return newLevelOverrideCore(encoder, sink, overrides), closeOut, nil
+	}
+
 	filteredLogLevels := zap.LevelEnablerFunc(zcfg.Level.Enabled)
 
 	core := zapcore.NewCore(encoder, sink, filteredLogLevels)
 	return core, closeOut, nil
 }
 
-func newDiskCore(diskLogLevel zap.AtomicLevel, local Config) (zapcore.Core, error) {
+func newDiskCore(diskLogLevel zap.AtomicLevel, local Config, format *AtomicFormat) (zapcore.Core, *lumberjack.Logger, error) {
 	diskUsage, err := local.DiskSpaceAvailable(local.Dir)
 	if err != nil || diskUsage < local.RequiredDiskSpace() {
 		diskLogLevel.SetLevel(disabledLevel)
 	}
 
 	var (
-		encoder = zapcore.NewConsoleEncoder(makeEncoderConfig(local.UnixTS))
-		sink    = zapcore.AddSync(&lumberjack.Logger{
+		encoder = zapcore.NewConsoleEncoder(format.encoderConfig())
+		file    = &lumberjack.Logger{
 			Filename:   local.logFileURI(),
 			MaxSize:    local.FileMaxSizeMB,
 			MaxAge:     local.FileMaxAgeDays,
 			MaxBackups: local.FileMaxBackups,
-			Compress:   true,
-		})
+			Compress:   !local.FileNoCompress,
+		}
 		allLogLevels = zap.LevelEnablerFunc(diskLogLevel.Enabled)
 	)
 
-	return zapcore.NewCore(encoder, sink, allLogLevels), nil
+	return zapcore.NewCore(encoder, zapcore.AddSync(file), allLogLevels), file, nil
 }
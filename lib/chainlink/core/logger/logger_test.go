@@ -1,11 +1,14 @@
 package logger
 
 import (
+	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/log/noop"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 
@@ -15,11 +18,11 @@ import (
 func TestConfig(t *testing.T) {
 	// no sampling
 	assert.Nil(t, newZapConfigBase().Sampling)
-	assert.Nil(t, newZapConfigProd(false, false).Sampling)
+	assert.Nil(t, newZapConfigProd(false).Sampling)
 
 	// not development, which would trigger panics for Critical level
 	assert.False(t, newZapConfigBase().Development)
-	assert.False(t, newZapConfigProd(false, false).Development)
+	assert.False(t, newZapConfigProd(false).Development)
 }
 
 func TestStderrWriter(t *testing.T) {
@@ -129,3 +132,91 @@ func TestAtomicCoreSwap(t *testing.T) {
 	assert.Equal(t, zapcore.InfoLevel, otelLogs.All()[0].Level)
 
 }
+
+func TestAtomicCore_AddRemoveCore(t *testing.T) {
+	atomicCore := NewAtomicCore()
+	lggr := zap.New(atomicCore).Sugar().With("chainID", 1)
+
+	baseCore, baseLogs := observer.New(zapcore.InfoLevel)
+	atomicCore.Store(baseCore)
+
+	debugCore, debugLogs := observer.New(zapcore.DebugLevel)
+	require.NoError(t, atomicCore.AddCore("debug", debugCore))
+	require.ErrorContains(t, atomicCore.AddCore("debug", debugCore), "already added")
+	assert.Equal(t, []string{"debug"}, atomicCore.CoreIDs())
+
+	lggr.Debug("debug only")
+	lggr.Info("both")
+	assert.Equal(t, 1, baseLogs.Len())
+	require.Equal(t, 2, debugLogs.Len())
+	assert.Equal(t, int64(1), debugLogs.All()[1].ContextMap()["chainID"])
+
+	// Added cores are kept when the base core is swapped
+	newBaseCore, newBaseLogs := observer.New(zapcore.InfoLevel)
+	atomicCore.Store(newBaseCore)
+	lggr.Info("after store")
+	assert.Equal(t, 1, newBaseLogs.Len())
+	assert.Equal(t, 3, debugLogs.Len())
+
+	assert.True(t, atomicCore.RemoveCore("debug"))
+	assert.False(t, atomicCore.RemoveCore("debug"))
+	assert.Empty(t, atomicCore.CoreIDs())
+
+	lggr.Info("after remove")
+	assert.Equal(t, 2, newBaseLogs.Len())
+	assert.Equal(t, 3, debugLogs.Len())
+}
+
+func TestAtomicCore_ConcurrentStore(t *testing.T) {
+	atomicCore := NewAtomicCore()
+	root := zap.New(atomicCore).Sugar()
+
+	const workers = 8
+	children := make([]*zap.SugaredLogger, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lggr := root.With("worker", i)
+			for j := range 100 {
+				lggr = lggr.With("depth", j%3)
+				lggr.Info("working")
+			}
+			children[i] = lggr
+		}()
+	}
+	for range 50 {
+		c, _ := observer.New(zapcore.InfoLevel)
+		atomicCore.Store(c)
+	}
+	wg.Wait()
+
+	// Every child, however it raced with the swaps, logs to the last stored core
+	final, logs := observer.New(zapcore.InfoLevel)
+	atomicCore.Store(final)
+	for _, lggr := range children {
+		lggr.Info("done")
+	}
+	require.Equal(t, workers, logs.Len())
+	for _, e := range logs.All() {
+		assert.Contains(t, e.ContextMap(), "worker")
+	}
+}
+
+func TestAtomicCore_CollectsChildren(t *testing.T) {
+	atomicCore := NewAtomicCore()
+	root := zap.New(atomicCore).Sugar()
+	for i := range 10 {
+		root.With("i", i).Info("short lived")
+	}
+	kept := root.With("kept", true)
+
+	runtime.GC()
+	c, logs := observer.New(zapcore.InfoLevel)
+	atomicCore.Store(c)
+
+	assert.Len(t, *atomicCore.children.Load(), 1)
+	kept.Info("still logging")
+	assert.Equal(t, 1, logs.Len())
+}
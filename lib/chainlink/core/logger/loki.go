@@ -0,0 +1,298 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LokiLoggerLabel is the label with the logger name of the pushed entries.
+const LokiLoggerLabel = "logger"
+
+// LokiConfig configures a core pushing logs to a Loki compatible HTTP endpoint.
+type LokiConfig struct {
+	// URL is the push endpoint, e.g. http://loki:3100/loki/api/v1/push.
+	URL string
+	// Labels are the static labels of every stream, along with the logger name.
+	Labels map[string]string
+	// Headers are added to every push, e.g. X-Scope-OrgID or Authorization.
+	Headers map[string]string
+	// Level is the minimum level pushed.
+	Level zapcore.Level
+
+	// BatchSize is the number of entries pushed at once, 1000 if zero.
+	BatchSize int
+	// BatchInterval is the maximum delay of an entry, one second if zero.
+	BatchInterval time.Duration
+	// MaxBuffered is the number of entries kept while the endpoint is unavailable, the oldest are dropped beyond it.
+	// 10 batches if zero.
+	MaxBuffered int
+	// Timeout is the timeout of each push, 10 seconds if zero.
+	Timeout time.Duration
+	// Client is the HTTP client, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+func (c *LokiConfig) setDefaults() {
+	if c.BatchSize == 0 {
+		c.BatchSize = 1000
+	}
+	if c.BatchInterval == 0 {
+		c.BatchInterval = time.Second
+	}
+	if c.MaxBuffered == 0 {
+		c.MaxBuffered = 10 * c.BatchSize
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.Client == nil {
+		c.Client = http.DefaultClient
+	}
+}
+
+// NewLokiCore returns a zapcore.Core pushing logs in batches to a Loki compatible endpoint, to be plugged in with
+// AtomicCore.Store or AtomicCore.AddCore. Entries are streamed by logger name, and encoded as JSON lines.
+// The returned func pushes the pending entries and stops the pusher.
+func NewLokiCore(cfg LokiConfig) (zapcore.Core, func(context.Context) error, error) {
+	if cfg.URL == "" {
+		return nil, nil, errors.New("missing Loki URL")
+	}
+	if cfg.BatchSize < 0 || cfg.BatchInterval < 0 || cfg.MaxBuffered < 0 || cfg.Timeout < 0 {
+		return nil, nil, errors.New("loki batching config must not be negative")
+	}
+	if _, ok := cfg.Labels[LokiLoggerLabel]; ok {
+		return nil, nil, fmt.Errorf("label %s is reserved for the logger name", LokiLoggerLabel)
+	}
+	cfg.setDefaults()
+
+	p := &lokiPusher{
+		cfg:   cfg,
+		flush: make(chan struct{}, 1),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go p.run()
+
+	core := &lokiCore{
+		LevelEnabler: cfg.Level,
+		enc:          zapcore.NewJSONEncoder(makeEncoderConfig(true)),
+		p:            p,
+	}
+	return core, p.close, nil
+}
+
+type lokiEntry struct {
+	logger string
+	ts     time.Time
+	line   string
+}
+
+// lokiPusher buffers the entries of all the cores derived from a Loki core, and pushes them.
+type lokiPusher struct {
+	cfg   LokiConfig
+	flush chan struct{}
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+
+	mu       sync.Mutex
+	pending  []lokiEntry
+	dropped  int
+	reported int
+
+	// pushMu serializes the pushes, so that the entries of a stream are pushed in order.
+	pushMu sync.Mutex
+}
+
+func (p *lokiPusher) add(e lokiEntry) {
+	p.mu.Lock()
+	if len(p.pending) >= p.cfg.MaxBuffered {
+		p.pending = p.pending[1:]
+		p.dropped++
+	}
+	p.pending = append(p.pending, e)
+	full := len(p.pending) >= p.cfg.BatchSize
+	p.mu.Unlock()
+
+	if full {
+		select {
+		case p.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (p *lokiPusher) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.cfg.BatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		case <-p.flush:
+		}
+		// Failed pushes are retried on the next tick, dropped entries are reported by Sync.
+		_ = p.pushPending(context.Background())
+	}
+}
+
+// pushPending pushes the pending entries in batches. Entries of failed pushes are put back in the buffer.
+func (p *lokiPusher) pushPending(ctx context.Context) error {
+	p.pushMu.Lock()
+	defer p.pushMu.Unlock()
+	for {
+		p.mu.Lock()
+		n := min(len(p.pending), p.cfg.BatchSize)
+		batch := p.pending[:n:n]
+		p.pending = p.pending[n:]
+		p.mu.Unlock()
+
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := p.push(ctx, batch); err != nil {
+			p.mu.Lock()
+			p.pending = append(batch, p.pending...)
+			if over := len(p.pending) - p.cfg.MaxBuffered; over > 0 {
+				p.pending = p.pending[over:]
+				p.dropped += over
+			}
+			p.mu.Unlock()
+			return err
+		}
+	}
+}
+
+// sync pushes the pending entries, and reports the entries dropped since the last sync.
+func (p *lokiPusher) sync(ctx context.Context) error {
+	err := p.pushPending(ctx)
+	p.mu.Lock()
+	dropped := p.dropped - p.reported
+	p.reported = p.dropped
+	p.mu.Unlock()
+	if dropped > 0 {
+		err = errors.Join(err, fmt.Errorf("dropped %d log entries while the Loki endpoint was unavailable", dropped))
+	}
+	return err
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPushRequest struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+func (p *lokiPusher) push(ctx context.Context, batch []lokiEntry) error {
+	var req lokiPushRequest
+	streams := make(map[string]*lokiStream)
+	for _, e := range batch {
+		s, ok := streams[e.logger]
+		if !ok {
+			labels := maps.Clone(p.cfg.Labels)
+			if labels == nil {
+				labels = make(map[string]string, 1)
+			}
+			if e.logger != "" {
+				labels[LokiLoggerLabel] = e.logger
+			}
+			s = &lokiStream{Stream: labels}
+			streams[e.logger] = s
+			req.Streams = append(req.Streams, s)
+		}
+		s.Values = append(s.Values, [2]string{strconv.FormatInt(e.ts.UnixNano(), 10), e.line})
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Loki push request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Loki push request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range p.cfg.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := p.cfg.Client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to push logs to Loki: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to push logs to Loki: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func (p *lokiPusher) close(ctx context.Context) error {
+	p.once.Do(func() { close(p.stop) })
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return p.sync(ctx)
+}
+
+// lokiCore encodes the entries for its lokiPusher.
+type lokiCore struct {
+	zapcore.LevelEnabler
+	enc zapcore.Encoder
+	p   *lokiPusher
+}
+
+var _ zapcore.Core = &lokiCore{}
+
+func (c *lokiCore) With(fs []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, f := range fs {
+		f.AddTo(enc)
+	}
+	return &lokiCore{LevelEnabler: c.LevelEnabler, enc: enc, p: c.p}
+}
+
+func (c *lokiCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *lokiCore) Write(e zapcore.Entry, fs []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(e, fs)
+	if err != nil {
+		return err
+	}
+	line := strings.TrimSuffix(buf.String(), "\n")
+	buf.Free()
+	c.p.add(lokiEntry{logger: e.LoggerName, ts: e.Time, line: line})
+	return nil
+}
+
+// Sync pushes the pending entries.
+func (c *lokiCore) Sync() error {
+	return c.p.sync(context.Background())
+}
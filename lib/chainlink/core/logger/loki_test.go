@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type lokiServer struct {
+	*httptest.Server
+	fail atomic.Bool
+
+	mu       sync.Mutex
+	requests []lokiPushRequest
+	headers  []http.Header
+}
+
+func newLokiServer(t *testing.T) *lokiServer {
+	s := &lokiServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.fail.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var req lokiPushRequest
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, req)
+		s.headers = append(s.headers, r.Header)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *lokiServer) streams() map[string]*lokiStream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]*lokiStream)
+	for _, req := range s.requests {
+		for _, stream := range req.Streams {
+			name := stream.Stream[LokiLoggerLabel]
+			if out[name] == nil {
+				out[name] = &lokiStream{Stream: stream.Stream}
+			}
+			out[name].Values = append(out[name].Values, stream.Values...)
+		}
+	}
+	return out
+}
+
+func TestNewLokiCore_Validation(t *testing.T) {
+	_, _, err := NewLokiCore(LokiConfig{})
+	require.ErrorContains(t, err, "missing Loki URL")
+
+	_, _, err = NewLokiCore(LokiConfig{URL: "http://localhost", Labels: map[string]string{LokiLoggerLabel: "x"}})
+	require.ErrorContains(t, err, "reserved")
+
+	_, _, err = NewLokiCore(LokiConfig{URL: "http://localhost", BatchSize: -1})
+	require.ErrorContains(t, err, "must not be negative")
+}
+
+func TestLokiCore(t *testing.T) {
+	srv := newLokiServer(t)
+
+	core, closeFn, err := NewLokiCore(LokiConfig{
+		URL:           srv.URL,
+		Labels:        map[string]string{"job": "chainlink"},
+		Headers:       map[string]string{"X-Scope-OrgID": "tenant"},
+		Level:         zapcore.InfoLevel,
+		BatchInterval: time.Hour,
+	})
+	require.NoError(t, err)
+
+	atomicCore := NewAtomicCore()
+	require.NoError(t, atomicCore.AddCore("loki", core))
+	lggr := zap.New(atomicCore).Sugar()
+
+	lggr.Named("Txm").With("chainID", 1).Infow("tx sent", "nonce", 3)
+	lggr.Named("Txm").Debug("not pushed")
+	lggr.Named("Head").Warn("slow head")
+	lggr.Info("root")
+
+	require.NoError(t, lggr.Sync())
+	require.NoError(t, closeFn(t.Context()))
+
+	streams := srv.streams()
+	require.Len(t, streams, 3)
+	txm := streams["Txm"]
+	require.NotNil(t, txm)
+	assert.Equal(t, map[string]string{"job": "chainlink", LokiLoggerLabel: "Txm"}, txm.Stream)
+	require.Len(t, txm.Values, 1)
+	var line map[string]any
+	require.NoError(t, json.Unmarshal([]byte(txm.Values[0][1]), &line))
+	assert.Equal(t, "tx sent", line["msg"])
+	assert.InDelta(t, 1, line["chainID"], 0)
+	assert.InDelta(t, 3, line["nonce"], 0)
+	assert.Len(t, streams["Head"].Values, 1)
+	assert.Equal(t, map[string]string{"job": "chainlink"}, streams[""].Stream)
+	assert.Equal(t, "tenant", srv.headers[0].Get("X-Scope-OrgID"))
+}
+
+func TestLokiCore_Buffering(t *testing.T) {
+	srv := newLokiServer(t)
+	srv.fail.Store(true)
+
+	core, closeFn, err := NewLokiCore(LokiConfig{
+		URL:           srv.URL,
+		BatchSize:     2,
+		BatchInterval: time.Hour,
+		MaxBuffered:   3,
+	})
+	require.NoError(t, err)
+	lggr := zap.New(core).Sugar()
+
+	for i := range 5 {
+		lggr.Infow("entry", "i", i)
+	}
+	err = core.Sync()
+	require.ErrorContains(t, err, "status 503")
+	require.ErrorContains(t, err, "dropped 2 log entries")
+
+	srv.fail.Store(false)
+	require.NoError(t, closeFn(context.Background()))
+
+	streams := srv.streams()
+	require.Len(t, streams[""].Values, 3)
+	var line map[string]any
+	require.NoError(t, json.Unmarshal([]byte(streams[""].Values[0][1]), &line))
+	assert.InDelta(t, 2, line["i"], 0)
+}
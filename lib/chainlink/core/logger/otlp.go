@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger/otelzap"
+)
+
+const (
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http"
+
+	otlpScopeName = "github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// OTLPRetryConfig configures the retry of failed exports. Zero durations use the exporter defaults.
+type OTLPRetryConfig struct {
+	Disabled        bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// OTLPConfig configures a core exporting logs over OTLP.
+type OTLPConfig struct {
+	// Endpoint is the host:port of the collector.
+	Endpoint string
+	// Protocol is OTLPProtocolGRPC (default) or OTLPProtocolHTTP.
+	Protocol string
+	Insecure bool
+	Headers  map[string]string
+	// ResourceAttributes are attached to every exported log, e.g. service.name.
+	ResourceAttributes map[string]string
+	// Level is the minimum level exported.
+	Level zapcore.Level
+
+	// Batching, zero values use the SDK defaults.
+	ExportInterval     time.Duration
+	ExportTimeout      time.Duration
+	MaxQueueSize       int
+	ExportMaxBatchSize int
+
+	Retry OTLPRetryConfig
+}
+
+// NewOTLPCore returns a zapcore.Core exporting logs in batches to an OTLP collector, to be plugged in with
+// AtomicCore.Store. The returned func flushes the pending logs and shuts down the exporter.
+func NewOTLPCore(ctx context.Context, cfg OTLPConfig) (zapcore.Core, func(context.Context) error, error) {
+	if cfg.Endpoint == "" {
+		return nil, nil, errors.New("missing OTLP endpoint")
+	}
+
+	var (
+		exporter sdklog.Exporter
+		err      error
+	)
+	switch cfg.Protocol {
+	case "", OTLPProtocolGRPC:
+		opts := []otlploggrpc.Option{otlploggrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlploggrpc.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlploggrpc.WithHeaders(cfg.Headers))
+		}
+		opts = append(opts, otlploggrpc.WithRetry(otlploggrpc.RetryConfig{
+			Enabled:         !cfg.Retry.Disabled,
+			InitialInterval: cfg.Retry.InitialInterval,
+			MaxInterval:     cfg.Retry.MaxInterval,
+			MaxElapsedTime:  cfg.Retry.MaxElapsedTime,
+		}))
+		exporter, err = otlploggrpc.New(ctx, opts...)
+	case OTLPProtocolHTTP:
+		opts := []otlploghttp.Option{otlploghttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlploghttp.WithHeaders(cfg.Headers))
+		}
+		opts = append(opts, otlploghttp.WithRetry(otlploghttp.RetryConfig{
+			Enabled:         !cfg.Retry.Disabled,
+			InitialInterval: cfg.Retry.InitialInterval,
+			MaxInterval:     cfg.Retry.MaxInterval,
+			MaxElapsedTime:  cfg.Retry.MaxElapsedTime,
+		}))
+		exporter, err = otlploghttp.New(ctx, opts...)
+	default:
+		return nil, nil, fmt.Errorf("unsupported OTLP protocol %q", cfg.Protocol)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OTLP log exporter: %w", err)
+	}
+
+	core, shutdown := newOTLPCore(cfg, exporter)
+	return core, shutdown, nil
+}
+
+func newOTLPCore(cfg OTLPConfig, exporter sdklog.Exporter) (zapcore.Core, func(context.Context) error) {
+	var batchOpts []sdklog.BatchProcessorOption
+	if cfg.ExportInterval > 0 {
+		batchOpts = append(batchOpts, sdklog.WithExportInterval(cfg.ExportInterval))
+	}
+	if cfg.ExportTimeout > 0 {
+		batchOpts = append(batchOpts, sdklog.WithExportTimeout(cfg.ExportTimeout))
+	}
+	if cfg.MaxQueueSize > 0 {
+		batchOpts = append(batchOpts, sdklog.WithMaxQueueSize(cfg.MaxQueueSize))
+	}
+	if cfg.ExportMaxBatchSize > 0 {
+		batchOpts = append(batchOpts, sdklog.WithExportMaxBatchSize(cfg.ExportMaxBatchSize))
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(cfg.ResourceAttributes))
+	for _, k := range slices.Sorted(maps.Keys(cfg.ResourceAttributes)) {
+		attrs = append(attrs, attribute.String(k, cfg.ResourceAttributes[k]))
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(sdkresource.NewSchemaless(attrs...)),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter, batchOpts...)),
+	)
+
+	return &otlpCore{
+		Core:     otelzap.NewCore(provider.Logger(otlpScopeName), otelzap.WithLevel(cfg.Level)),
+		provider: provider,
+	}, provider.Shutdown
+}
+
+// otlpCore flushes the batched logs on Sync.
+type otlpCore struct {
+	zapcore.Core
+	provider *sdklog.LoggerProvider
+}
+
+func (c *otlpCore) With(fs []zapcore.Field) zapcore.Core {
+	return &otlpCore{Core: c.Core.With(fs), provider: c.provider}
+}
+
+func (c *otlpCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *otlpCore) Sync() error {
+	return c.provider.ForceFlush(context.Background())
+}
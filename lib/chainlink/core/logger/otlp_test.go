@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap/zapcore"
+)
+
+type recordingExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *recordingExporter) all() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]sdklog.Record(nil), e.records...)
+}
+
+func TestOTLPCore(t *testing.T) {
+	exporter := &recordingExporter{}
+	otlpCore, shutdown := newOTLPCore(OTLPConfig{
+		Level:              zapcore.InfoLevel,
+		ResourceAttributes: map[string]string{"service.name": "chainlink"},
+	}, exporter)
+
+	atomicCore := NewAtomicCore()
+	lggrCfg := Config{LogLevel: zapcore.DebugLevel, JsonConsole: true}
+	lggr, closeFn := lggrCfg.NewWithCores(atomicCore)
+	defer func() { require.NoError(t, closeFn()) }()
+
+	atomicCore.Store(otlpCore)
+	lggr.Named("Test").Debugw("not exported")
+	lggr.Named("Test").Infow("exported", "key", "value")
+	require.NoError(t, lggr.Sync())
+
+	records := exporter.all()
+	require.Len(t, records, 1)
+	assert.Equal(t, "exported", records[0].Body().AsString())
+	assert.Equal(t, log.SeverityInfo, records[0].Severity())
+
+	resource := records[0].Resource()
+	v, ok := resource.Set().Value("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "chainlink", v.AsString())
+
+	var keys []string
+	records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		keys = append(keys, kv.Key)
+		return true
+	})
+	assert.Contains(t, keys, "key")
+
+	require.NoError(t, shutdown(t.Context()))
+}
+
+func TestNewOTLPCore_Validation(t *testing.T) {
+	_, _, err := NewOTLPCore(t.Context(), OTLPConfig{})
+	require.ErrorContains(t, err, "missing OTLP endpoint")
+
+	_, _, err = NewOTLPCore(t.Context(), OTLPConfig{Endpoint: "localhost:4317", Protocol: "udp"})
+	require.ErrorContains(t, err, `unsupported OTLP protocol "udp"`)
+}
@@ -40,13 +40,22 @@ type PrettyConsole struct {
 // Write reformats the incoming json bytes with colors, newlines and whitespace
 // for better readability in console.
 func (pc PrettyConsole) Write(b []byte) (int, error) {
+	pretty, err := prettyPrint(b)
+	if err != nil {
+		return 0, err
+	}
+	return pc.Sink.Write(pretty)
+}
+
+// prettyPrint reformats the json bytes of an entry for the console.
+func prettyPrint(b []byte) ([]byte, error) {
 	if !gjson.ValidBytes(b) {
-		return 0, fmt.Errorf("unable to parse json for pretty console: %s", string(b))
+		return nil, fmt.Errorf("unable to parse json for pretty console: %s", string(b))
 	}
 	js := gjson.ParseBytes(b)
 	headline := generateHeadline(js)
 	details := generateDetails(js)
-	return pc.Sink.Write(fmt.Appendln(nil, headline, details))
+	return fmt.Appendln(nil, headline, details), nil
 }
 
 // Close is overridden to prevent accidental closure of stderr/stdout
@@ -92,6 +92,14 @@ func (s *prometheusLogger) SetLogLevel(level zapcore.Level) {
 	s.h.SetLogLevel(level)
 }
 
+func (s *prometheusLogger) SetLogFormat(f Format) {
+	SetLogFormat(s.h, f)
+}
+
+func (s *prometheusLogger) Auditw(event string, keyvals ...any) {
+	Auditw(s.h, event, keyvals...)
+}
+
 func (s *prometheusLogger) Trace(args ...any) {
 	s.h.Trace(args...)
 }
@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimit configures how often the rate limited helpers of SugaredLogger log the same message.
+type RateLimit struct {
+	// Burst is how many times the same message is logged per Interval.
+	Burst    int
+	Interval time.Duration
+}
+
+// DefaultRateLimit is the RateLimit of Sugared.
+var DefaultRateLimit = RateLimit{Burst: 10, Interval: time.Minute}
+
+type rateWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+	// log logs the summary of the suppressed occurrences, at the level of the last one.
+	log func(msg string, keyvals ...any)
+}
+
+// rateLimiter counts the occurrences of each message in fixed windows. The expired windows are evicted, the ones
+// with suppressed occurrences once their summary is logged.
+type rateLimiter struct {
+	cfg RateLimit
+	now func() time.Time
+	// afterFunc schedules the summary of a window with suppressed occurrences at its end.
+	afterFunc func(d time.Duration, f func())
+
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+func newRateLimiter(cfg RateLimit) *rateLimiter {
+	return &rateLimiter{
+		cfg: cfg,
+		now: time.Now,
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+		windows: make(map[string]*rateWindow),
+	}
+}
+
+// allow returns whether the message can be logged. When it cannot, the number of its occurrences suppressed in the
+// window is logged with log at the end of the window.
+func (r *rateLimiter) allow(msg string, log func(msg string, keyvals ...any)) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.sweep(now)
+	w, ok := r.windows[msg]
+	if !ok || now.Sub(w.start) >= r.cfg.Interval {
+		// An expired window with suppressed occurrences is kept by its pending summary.
+		w = &rateWindow{start: now}
+		r.windows[msg] = w
+	}
+	if w.count < r.cfg.Burst {
+		w.count++
+		return true
+	}
+	w.suppressed++
+	w.log = log
+	if w.suppressed == 1 {
+		r.afterFunc(w.start.Add(r.cfg.Interval).Sub(now), func() { r.summarize(msg, w) })
+	}
+	return false
+}
+
+// sweep evicts the expired windows without suppressed occurrences, at most once per interval. r.mu must be held.
+func (r *rateLimiter) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < r.cfg.Interval {
+		return
+	}
+	r.lastSweep = now
+	for msg, w := range r.windows {
+		if w.suppressed == 0 && now.Sub(w.start) >= r.cfg.Interval {
+			delete(r.windows, msg)
+		}
+	}
+}
+
+// summarize logs the number of occurrences of the message suppressed in the window, and evicts the window.
+func (r *rateLimiter) summarize(msg string, w *rateWindow) {
+	r.mu.Lock()
+	suppressed, log := w.suppressed, w.log
+	if r.windows[msg] == w {
+		delete(r.windows, msg)
+	}
+	r.mu.Unlock()
+
+	log(msg, "suppressed", suppressed)
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSugared_RateLimited(t *testing.T) {
+	lggr, o := TestLoggerWithObserver(t, zapcore.DebugLevel)
+	sugared := SugaredWithRateLimit(lggr, RateLimit{Burst: 2, Interval: time.Minute}).(*sugared)
+	now := time.Now()
+	sugared.limiter.now = func() time.Time { return now }
+	var summaries []func()
+	var delays []time.Duration
+	sugared.limiter.afterFunc = func(d time.Duration, f func()) {
+		delays = append(delays, d)
+		summaries = append(summaries, f)
+	}
+
+	for i := range 5 {
+		sugared.WarnwRateLimited("RPC failed", "attempt", i)
+		sugared.ErrorwRateLimited("Tx failed")
+		now = now.Add(time.Second)
+	}
+	assert.Equal(t, 2, o.ByMessage("RPC failed").FilterLevelExact(zapcore.WarnLevel).Len())
+	assert.Equal(t, 2, o.ByMessage("Tx failed").FilterLevelExact(zapcore.ErrorLevel).Len())
+	assert.Equal(t, 0, o.Logs().FilterFieldKey("suppressed").Len())
+
+	// the summaries are logged at the end of the windows, without waiting for the next occurrence
+	require.Len(t, summaries, 2)
+	assert.Equal(t, []time.Duration{time.Minute - 2*time.Second, time.Minute - 2*time.Second}, delays)
+	o.Reset()
+	now = now.Add(time.Minute)
+	for _, summarize := range summaries {
+		summarize()
+	}
+	logs := o.All()
+	require.Len(t, logs, 2)
+	assert.Equal(t, "RPC failed", logs[0].Message)
+	assert.Equal(t, zapcore.WarnLevel, logs[0].Level)
+	assert.Equal(t, int64(3), logs[0].ContextMap()["suppressed"])
+	assert.Equal(t, "Tx failed", logs[1].Message)
+	assert.Equal(t, zapcore.ErrorLevel, logs[1].Level)
+	assert.Equal(t, int64(3), logs[1].ContextMap()["suppressed"])
+	assert.Empty(t, sugared.limiter.windows)
+
+	o.Reset()
+	sugared.WarnwRateLimited("RPC failed", "attempt", 5)
+	logs = o.All()
+	require.Len(t, logs, 1)
+	assert.NotContains(t, logs[0].ContextMap(), "suppressed")
+	assert.Equal(t, int64(5), logs[0].ContextMap()["attempt"])
+	assert.Contains(t, logs[0].Caller.String(), "core/logger/rate_limited_test.go")
+}
+
+func TestRateLimiter_Eviction(t *testing.T) {
+	r := newRateLimiter(RateLimit{Burst: 1, Interval: time.Minute})
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	r.afterFunc = func(time.Duration, func()) {}
+	log := func(string, ...any) {}
+
+	assert.True(t, r.allow("a", log))
+	assert.True(t, r.allow("b", log))
+	assert.False(t, r.allow("b", log))
+	require.Len(t, r.windows, 2)
+
+	// the expired window without suppressed occurrences is evicted, the one waiting for its summary is kept
+	now = now.Add(time.Minute)
+	assert.True(t, r.allow("c", log))
+	assert.NotContains(t, r.windows, "a")
+	assert.Contains(t, r.windows, "b")
+	assert.Contains(t, r.windows, "c")
+}
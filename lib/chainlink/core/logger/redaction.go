@@ -0,0 +1,164 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const redacted = "[REDACTED]"
+
+// DefaultRedactedKeys are the field keys redacted by default. Keys are matched case-insensitively, ignoring '_' and '-'.
+var DefaultRedactedKeys = []string{
+	"privateKey", "mnemonic", "seedPhrase", "password", "secret", "apiSecret", "apiKey", "accessToken",
+}
+
+// DefaultRedactedPatterns are the patterns redacted from messages and string fields by DefaultPatternRedactor.
+var DefaultRedactedPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+	regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9._~+/=-]+`),
+}
+
+// Redactor redacts sensitive fields and patterns from log entries.
+type Redactor struct {
+	keys     map[string]struct{}
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor returns a Redactor of the field keys and the patterns.
+func NewRedactor(keys []string, patterns []*regexp.Regexp) *Redactor {
+	r := &Redactor{keys: make(map[string]struct{}, len(keys)), patterns: patterns}
+	for _, k := range keys {
+		r.keys[normalizeRedactedKey(k)] = struct{}{}
+	}
+	return r
+}
+
+// DefaultRedactor returns a Redactor of DefaultRedactedKeys. Patterns are not scanned, since matching them against
+// every message and string field is costly on the hot path.
+func DefaultRedactor() *Redactor {
+	return NewRedactor(DefaultRedactedKeys, nil)
+}
+
+// DefaultPatternRedactor returns a Redactor of DefaultRedactedKeys and DefaultRedactedPatterns, to opt in to the
+// pattern scan of messages and string fields.
+func DefaultPatternRedactor() *Redactor {
+	return NewRedactor(DefaultRedactedKeys, DefaultRedactedPatterns)
+}
+
+func normalizeRedactedKey(k string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(k))
+}
+
+func (r *Redactor) redactString(s string) string {
+	for _, p := range r.patterns {
+		s = p.ReplaceAllString(s, redacted)
+	}
+	return s
+}
+
+func (r *Redactor) redactFields(fs []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fs {
+		rf, changed := r.redactField(f)
+		if changed && out == nil {
+			out = make([]zapcore.Field, len(fs))
+			copy(out, fs[:i])
+		}
+		if out != nil {
+			out[i] = rf
+		}
+	}
+	if out == nil {
+		return fs
+	}
+	return out
+}
+
+func (r *Redactor) redactField(f zapcore.Field) (zapcore.Field, bool) {
+	if _, ok := r.keys[normalizeRedactedKey(f.Key)]; ok {
+		return zap.String(f.Key, redacted), true
+	}
+	if len(r.patterns) == 0 {
+		return f, false
+	}
+	var s string
+	switch f.Type {
+	case zapcore.StringType:
+		s = f.String
+	case zapcore.StringerType:
+		s = fmt.Sprint(f.Interface)
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok && err != nil {
+			s = err.Error()
+		}
+	default:
+		return f, false
+	}
+	if rs := r.redactString(s); rs != s {
+		return zap.String(f.Key, rs), true
+	}
+	return f, false
+}
+
+// NewRedactingCore wraps the core so that the fields and messages are redacted before they are encoded.
+func NewRedactingCore(core zapcore.Core, r *Redactor) zapcore.Core {
+	return &redactingCore{Core: core, redactor: r}
+}
+
+type redactingCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+func (c *redactingCore) With(fs []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redactor.redactFields(fs)), redactor: c.redactor}
+}
+
+func (c *redactingCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// The wrapped core decides which of its cores log the entry, the fields are redacted when it is written.
+	checked := c.Core.Check(e, nil)
+	if checked == nil {
+		return ce
+	}
+	return ce.AddCore(e, &redactedEntry{checked: checked, redactor: c.redactor})
+}
+
+func (c *redactingCore) Write(e zapcore.Entry, fs []zapcore.Field) error {
+	e.Message = c.redactor.redactString(e.Message)
+	return c.Core.Write(e, c.redactor.redactFields(fs))
+}
+
+// redactedEntry writes a checked entry of the wrapped core with redacted fields.
+type redactedEntry struct {
+	zapcore.Core
+	checked  *zapcore.CheckedEntry
+	redactor *Redactor
+}
+
+func (w *redactedEntry) Write(e zapcore.Entry, fs []zapcore.Field) error {
+	// The checked entry reports the errors of the wrapped core to its error output instead of returning them.
+	var errs writeErrors
+	w.checked.ErrorOutput = &errs
+	w.checked.Entry.Message = w.redactor.redactString(e.Message)
+	w.checked.Write(w.redactor.redactFields(fs)...)
+	return errs.err
+}
+
+func (w *redactedEntry) Sync() error { return nil }
+
+// writeErrors collects the write errors reported to the error output of a checked entry.
+type writeErrors struct {
+	err error
+}
+
+func (o *writeErrors) Write(p []byte) (int, error) {
+	o.err = errors.Join(o.err, errors.New(strings.TrimSpace(string(p))))
+	return len(p), nil
+}
+
+func (o *writeErrors) Sync() error { return nil }
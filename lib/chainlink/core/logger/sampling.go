@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingRate logs the first Initial entries with the same level and message every tick, then every Thereafter-th.
+type SamplingRate struct {
+	Initial    int
+	Thereafter int
+}
+
+// SamplingConfig samples repeated debug and info entries in hot paths. Warn and above are never sampled.
+type SamplingConfig struct {
+	// Tick is the sampling interval, one second if zero.
+	Tick time.Duration
+	// Levels are the rates of the sampled levels. Levels without rate are not sampled.
+	Levels map[zapcore.Level]SamplingRate
+}
+
+func (c SamplingConfig) Validate() error {
+	if c.Tick < 0 {
+		return errors.New("sampling tick must not be negative")
+	}
+	for lvl, rate := range c.Levels {
+		if lvl >= zapcore.WarnLevel {
+			return fmt.Errorf("level %s must not be sampled", lvl)
+		}
+		if rate.Initial < 0 || rate.Thereafter < 0 {
+			return fmt.Errorf("sampling rate of level %s must not be negative", lvl)
+		}
+	}
+	return nil
+}
+
+// wrap returns the core sampling the configured levels.
+func (c *SamplingConfig) wrap(core zapcore.Core) (zapcore.Core, error) {
+	if c == nil || len(c.Levels) == 0 {
+		return core, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	tick := c.Tick
+	if tick == 0 {
+		tick = time.Second
+	}
+
+	samplers := make(map[zapcore.Level]zapcore.Core, len(c.Levels))
+	for lvl, rate := range c.Levels {
+		samplers[lvl] = zapcore.NewSamplerWithOptions(core, tick, rate.Initial, rate.Thereafter)
+	}
+	return &samplingCore{Core: core, samplers: samplers}, nil
+}
+
+// samplingCore routes entries to the sampler of their level, if any.
+type samplingCore struct {
+	zapcore.Core
+	samplers map[zapcore.Level]zapcore.Core
+}
+
+func (c *samplingCore) With(fs []zapcore.Field) zapcore.Core {
+	samplers := make(map[zapcore.Level]zapcore.Core, len(c.samplers))
+	for lvl, s := range c.samplers {
+		samplers[lvl] = s.With(fs)
+	}
+	return &samplingCore{Core: c.Core.With(fs), samplers: samplers}
+}
+
+func (c *samplingCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if s, ok := c.samplers[e.Level]; ok {
+		return s.Check(e, ce)
+	}
+	return c.Core.Check(e, ce)
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSamplingConfig_Validate(t *testing.T) {
+	require.NoError(t, SamplingConfig{Levels: map[zapcore.Level]SamplingRate{zapcore.DebugLevel: {Initial: 1, Thereafter: 10}}}.Validate())
+	require.ErrorContains(t, SamplingConfig{Levels: map[zapcore.Level]SamplingRate{zapcore.ErrorLevel: {}}}.Validate(), "must not be sampled")
+	require.ErrorContains(t, SamplingConfig{Levels: map[zapcore.Level]SamplingRate{zapcore.InfoLevel: {Initial: -1}}}.Validate(), "must not be negative")
+	require.ErrorContains(t, SamplingConfig{Tick: -time.Second}.Validate(), "tick must not be negative")
+}
+
+func TestSampling(t *testing.T) {
+	obsCore, logs := observer.New(zapcore.DebugLevel)
+	cfg := &SamplingConfig{
+		Tick:   time.Minute,
+		Levels: map[zapcore.Level]SamplingRate{zapcore.DebugLevel: {Initial: 2, Thereafter: 3}},
+	}
+	core, err := cfg.wrap(obsCore)
+	require.NoError(t, err)
+	lggr := zap.New(core).Sugar().With("key", "value")
+
+	for range 10 {
+		lggr.Debug("hot path")
+		lggr.Info("not sampled")
+		lggr.Warn("never sampled")
+	}
+	lggr.Debug("other message")
+
+	assert.Equal(t, 4, logs.FilterMessage("hot path").Len())
+	assert.Equal(t, 10, logs.FilterMessage("not sampled").Len())
+	assert.Equal(t, 10, logs.FilterMessage("never sampled").Len())
+	assert.Equal(t, 1, logs.FilterMessage("other message").Len())
+	assert.Equal(t, logs.Len(), logs.FilterField(zap.String("key", "value")).Len())
+
+	var none *SamplingConfig
+	same, err := none.wrap(obsCore)
+	require.NoError(t, err)
+	assert.Equal(t, obsCore, same)
+}
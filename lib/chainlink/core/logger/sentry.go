@@ -44,6 +44,14 @@ func (s *sentryLogger) SetLogLevel(level zapcore.Level) {
 	s.h.SetLogLevel(level)
 }
 
+func (s *sentryLogger) SetLogFormat(f Format) {
+	SetLogFormat(s.h, f)
+}
+
+func (s *sentryLogger) Auditw(event string, keyvals ...any) {
+	Auditw(s.h, event, keyvals...)
+}
+
 func (s *sentryLogger) Trace(args ...any) {
 	s.h.Trace(args...)
 }
@@ -12,19 +12,43 @@ type SugaredLogger interface {
 	// ErrorIfFn calls fn() and logs any returned error along with msg.
 	// Unlike ErrorIf, this can be deffered inline, since the function call is delayed.
 	ErrorIfFn(fn func() error, msg string)
+	// RateLimited variants log the same message at most RateLimit.Burst times per RateLimit.Interval. When repeats
+	// were suppressed, the message is logged again at the end of the interval with a "suppressed" field with their
+	// number.
+	WarnwRateLimited(msg string, keyvals ...any)
+	ErrorwRateLimited(msg string, keyvals ...any)
+	// Auditw records events like key usage, config changes and changeset executions in the audit log of the logger,
+	// regardless of the log level. See Config.Audit.
+	Auditw(event string, keyvals ...any)
 }
 
-// Sugared returns a new SugaredLogger wrapping the given Logger.
+// Sugared returns a new SugaredLogger wrapping the given Logger, rate limited with DefaultRateLimit.
 func Sugared(l Logger) SugaredLogger {
+	return SugaredWithRateLimit(l, DefaultRateLimit)
+}
+
+// SugaredWithRateLimit is like Sugared, with the rate limit of the RateLimited variants.
+func SugaredWithRateLimit(l Logger, rl RateLimit) SugaredLogger {
 	return &sugared{
-		Logger: l,
-		h:      l.Helper(1),
+		Logger:  l,
+		h:       l.Helper(1),
+		limiter: newRateLimiter(rl),
 	}
 }
 
 type sugared struct {
 	Logger
-	h Logger // helper with stack trace skip level
+	h       Logger // helper with stack trace skip level
+	limiter *rateLimiter
+}
+
+// SetLogFormat changes the format of the wrapped Logger, if it supports it.
+func (s *sugared) SetLogFormat(f Format) {
+	SetLogFormat(s.Logger, f)
+}
+
+func (s *sugared) Auditw(event string, keyvals ...any) {
+	Auditw(s.h, event, keyvals...)
 }
 
 // AssumptionViolation wraps Error logs with assumption violation tag.
@@ -53,3 +77,15 @@ func (s *sugared) ErrorIfFn(fn func() error, msg string) {
 		s.h.Errorw(msg, "err", err)
 	}
 }
+
+func (s *sugared) WarnwRateLimited(msg string, keyvals ...any) {
+	if s.limiter.allow(msg, s.Logger.Warnw) {
+		s.h.Warnw(msg, keyvals...)
+	}
+}
+
+func (s *sugared) ErrorwRateLimited(msg string, keyvals ...any) {
+	if s.limiter.allow(msg, s.Logger.Errorw) {
+		s.h.Errorw(msg, keyvals...)
+	}
+}
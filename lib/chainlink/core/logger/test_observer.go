@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestObserver records log entries with their fields for tests. It is a zapcore.Core, so it can be installed with
+// AtomicCore.Store or passed to Config.NewWithCores.
+type TestObserver struct {
+	zapcore.Core
+	logs *observer.ObservedLogs
+}
+
+// NewTestObserver returns a TestObserver recording entries at lvl or above.
+func NewTestObserver(lvl zapcore.LevelEnabler) *TestObserver {
+	core, logs := observer.New(lvl)
+	return &TestObserver{Core: core, logs: logs}
+}
+
+// TestLoggerWithObserver is like TestLoggerObserved, but returns a TestObserver.
+//
+// Note: It is not necessary to Sync().
+func TestLoggerWithObserver(tb testing.TB, lvl zapcore.Level) (Logger, *TestObserver) {
+	o := NewTestObserver(lvl)
+	return testLogger(tb, o, lvl), o
+}
+
+// Logs returns the recorded entries, to be queried with the observer.ObservedLogs filters.
+func (o *TestObserver) Logs() *observer.ObservedLogs {
+	return o.logs
+}
+
+// All returns a copy of the recorded entries.
+func (o *TestObserver) All() []observer.LoggedEntry {
+	return o.logs.All()
+}
+
+// Len returns the number of recorded entries.
+func (o *TestObserver) Len() int {
+	return o.logs.Len()
+}
+
+// Reset removes the recorded entries and returns them.
+func (o *TestObserver) Reset() []observer.LoggedEntry {
+	return o.logs.TakeAll()
+}
+
+// ByLevel returns the entries logged at exactly lvl.
+func (o *TestObserver) ByLevel(lvl zapcore.Level) *observer.ObservedLogs {
+	return o.logs.FilterLevelExact(lvl)
+}
+
+// ByMessage returns the entries whose message contains substr.
+func (o *TestObserver) ByMessage(substr string) *observer.ObservedLogs {
+	return o.logs.FilterMessageSnippet(substr)
+}
+
+// ByField returns the entries with the field key, whose value formats like value. This allows matching a field
+// regardless of how it was encoded, e.g. an int logged with Infow is recorded as an int64.
+func (o *TestObserver) ByField(key string, value any) *observer.ObservedLogs {
+	want := fmt.Sprint(value)
+	return o.logs.Filter(func(e observer.LoggedEntry) bool {
+		v, ok := e.ContextMap()[key]
+		return ok && fmt.Sprint(v) == want
+	})
+}
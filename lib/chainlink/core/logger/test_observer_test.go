@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestTestObserver(t *testing.T) {
+	atomicCore := NewAtomicCore()
+	lggr := zap.New(atomicCore).Sugar().Named("Service").With("chainID", 1)
+
+	o := NewTestObserver(zapcore.InfoLevel)
+	atomicCore.Store(o)
+
+	lggr.Debug("not recorded")
+	lggr.Infow("started worker", "worker", "a")
+	lggr.Warnw("worker failed", "worker", "b")
+	lggr.Errorw("worker failed again", "worker", "b")
+
+	require.Equal(t, 3, o.Len())
+	assert.Equal(t, 1, o.ByLevel(zapcore.WarnLevel).Len())
+	assert.Equal(t, 2, o.ByMessage("failed").Len())
+	assert.Equal(t, 2, o.ByField("worker", "b").Len())
+	assert.Equal(t, 3, o.ByField("chainID", 1).Len())
+	assert.Equal(t, 0, o.ByField("missing", "").Len())
+	assert.Equal(t, 1, o.ByMessage("failed").FilterLevelExact(zapcore.ErrorLevel).Len())
+	assert.Equal(t, "Service", o.All()[0].LoggerName)
+
+	assert.Len(t, o.Reset(), 3)
+	assert.Equal(t, 0, o.Len())
+}
+
+func TestTestLoggerWithObserver(t *testing.T) {
+	lggr, o := TestLoggerWithObserver(t, zapcore.DebugLevel)
+	lggr.Named("Worker").Debugw("work done", "result", "success")
+
+	logs := o.ByField("result", "success").All()
+	require.Len(t, logs, 1)
+	assert.Equal(t, "Worker", logs[0].LoggerName)
+}
@@ -1,9 +1,11 @@
 package logger
 
 import (
+	"fmt"
 	"os"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"weak"
 
 	pkgerrors "github.com/pkg/errors"
@@ -13,48 +15,171 @@ import (
 
 // AtomicCore provides thread-safe core swapping using atomic operations.
 // It starts as a noop core and can be atomically swapped to include additional cores.
+// Cores can also be attached and detached by ID with AddCore and RemoveCore, alongside the stored core.
+// Stored cores are wrapped with the DefaultRedactor, so that the fields holding secrets don't reach exporters.
+// Enabled, Check, Write and With are lock-free, only the changes of the core are serialized.
 var _ zapcore.Core = &AtomicCore{}
 
 type AtomicCore struct {
-	mu       sync.RWMutex
-	core     zapcore.Core
-	children []weak.Pointer[withCore]
+	core     atomic.Pointer[zapcore.Core]
+	children atomic.Pointer[[]weak.Pointer[withCore]] // copy-on-write
+
+	mu        sync.Mutex // serializes the changes of the core
+	base      zapcore.Core
+	added     []identifiedCore
+	redactor  *Redactor
+	overrides *LevelOverrides
+}
+
+type identifiedCore struct {
+	id   string
+	core zapcore.Core
 }
 
 // NewAtomicCore creates a new AtomicCore initialized with a noop core
 func NewAtomicCore() *AtomicCore {
-	return &AtomicCore{core: zapcore.NewNopCore()}
+	return NewAtomicCoreWithRedactor(DefaultRedactor())
+}
+
+// NewAtomicCoreWithRedactor is like NewAtomicCore, but redacts stored cores with r. A nil r disables redaction.
+func NewAtomicCoreWithRedactor(r *Redactor) *AtomicCore {
+	d := &AtomicCore{base: zapcore.NewNopCore(), redactor: r}
+	d.setCore(zapcore.NewNopCore())
+	return d
 }
 
+// Store replaces the base core. Cores added with AddCore are kept.
 func (d *AtomicCore) Store(core zapcore.Core) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	d.core = core
-	d.children = slices.DeleteFunc(d.children, func(p weak.Pointer[withCore]) bool {
-		c := p.Value()
-		if c == nil {
-			return true
-		}
-		c.Store(d.core)
+	d.base = core
+	d.update()
+}
+
+// AddCore attaches core under id, in a tee with the stored core and the other added cores.
+func (d *AtomicCore) AddCore(id string, core zapcore.Core) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if slices.ContainsFunc(d.added, func(c identifiedCore) bool { return c.id == id }) {
+		return fmt.Errorf("core %q already added", id)
+	}
+	d.added = append(d.added, identifiedCore{id: id, core: core})
+	d.update()
+	return nil
+}
+
+// RemoveCore detaches the core added under id, and reports whether it was found. The caller is responsible for
+// syncing and closing the removed core.
+func (d *AtomicCore) RemoveCore(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i := slices.IndexFunc(d.added, func(c identifiedCore) bool { return c.id == id })
+	if i < 0 {
 		return false
-	})
+	}
+	d.added = slices.Delete(d.added, i, i+1)
+	d.update()
+	return true
+}
+
+// SetLevelOverrides applies the overrides to the stored and added cores: the entries of an overridden logger are
+// logged with the level of the override, whatever the level of the cores. A nil o removes the overrides.
+func (d *AtomicCore) SetLevelOverrides(o *LevelOverrides) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.overrides = o
+	d.update()
+}
+
+// CoreIDs returns the IDs of the added cores, in the order they were added.
+func (d *AtomicCore) CoreIDs() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ids := make([]string, len(d.added))
+	for i, c := range d.added {
+		ids[i] = c.id
+	}
+	return ids
+}
+
+// update rebuilds the core from the base and added cores. d.mu must be held.
+func (d *AtomicCore) update() {
+	core := d.base
+	if len(d.added) > 0 {
+		cores := make([]zapcore.Core, 0, len(d.added)+1)
+		cores = append(cores, d.base)
+		for _, c := range d.added {
+			cores = append(cores, c.core)
+		}
+		core = zapcore.NewTee(cores...)
+	}
+	if d.redactor != nil {
+		core = NewRedactingCore(core, d.redactor)
+	}
+	if d.overrides != nil {
+		core = &overriddenCore{Core: core, overrides: d.overrides}
+	}
+	d.setCore(core)
+}
+
+// setCore stores the core, then stores it in the children and drops the collected ones. The callers are serialized
+// by the mutex of the root AtomicCore.
+func (d *AtomicCore) setCore(core zapcore.Core) {
+	d.core.Store(&core)
+
+	// Children added after the core was stored get it in With.
+	children := d.children.Load()
+	if children == nil {
+		return
+	}
+	var dead bool
+	for _, p := range *children {
+		if c := p.Value(); c != nil {
+			c.Store(core)
+		} else {
+			dead = true
+		}
+	}
+	if !dead {
+		return
+	}
+	for {
+		old := d.children.Load()
+		live := slices.DeleteFunc(slices.Clone(*old), func(p weak.Pointer[withCore]) bool { return p.Value() == nil })
+		if d.children.CompareAndSwap(old, &live) {
+			return
+		}
+	}
 }
 
 func (d *AtomicCore) load() zapcore.Core {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
-	return d.core
+	return *d.core.Load()
 }
 
 func (d *AtomicCore) Enabled(l zapcore.Level) bool { return d.load().Enabled(l) }
 
 func (d *AtomicCore) With(fs []zapcore.Field) zapcore.Core {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-	coreWithFields := d.core.With(fs)
-	w := &withCore{fields: fs, AtomicCore: AtomicCore{core: coreWithFields}}
-	d.children = append(d.children, weak.Make(w))
-	return w
+	w := &withCore{fields: fs, parent: d}
+	for {
+		old := d.children.Load()
+		var children []weak.Pointer[withCore]
+		if old != nil {
+			children = slices.Clone(*old)
+		}
+		children = append(children, weak.Make(w))
+		if d.children.CompareAndSwap(old, &children) {
+			break
+		}
+	}
+	// w is tracked, so a concurrent setCore either sees it or stored its core before the one loaded here.
+	for {
+		core := d.core.Load()
+		coreWithFields := (*core).With(fs)
+		w.core.Store(&coreWithFields)
+		if d.core.Load() == core {
+			return w
+		}
+	}
 }
 
 func (d *AtomicCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
@@ -67,21 +192,14 @@ func (d *AtomicCore) Sync() error { return d.load().Sync() }
 
 type withCore struct {
 	fields []zapcore.Field
+	// parent is only referenced to keep it alive, since parents track their children with weak pointers.
+	parent *AtomicCore
 	AtomicCore
 }
 
+// Store re-parents w on the core of its parent.
 func (w *withCore) Store(core zapcore.Core) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	w.core = core.With(w.fields)
-	w.children = slices.DeleteFunc(w.children, func(p weak.Pointer[withCore]) bool {
-		c := p.Value()
-		if c == nil {
-			return true
-		}
-		c.Store(w.core)
-		return false
-	})
+	w.setCore(core.With(w.fields))
 }
 
 var _ Logger = &zapLogger{}
@@ -89,6 +207,8 @@ var _ Logger = &zapLogger{}
 type zapLogger struct {
 	*zap.SugaredLogger
 	level      zap.AtomicLevel
+	format     *AtomicFormat
+	audit      *AuditLog
 	fields     []any
 	callerSkip int
 }
@@ -109,6 +229,28 @@ func (l *zapLogger) SetLogLevel(lvl zapcore.Level) {
 	l.level.SetLevel(lvl)
 }
 
+func (l *zapLogger) SetLogFormat(f Format) {
+	if l.format != nil {
+		l.format.SetFormat(f)
+	}
+}
+
+func (l *zapLogger) setAudit(audit *AuditLog) {
+	l.audit = audit
+}
+
+// Auditw records the event in the audit log, with the fields of the logger. Without audit log, the event is logged
+// at info level.
+func (l *zapLogger) Auditw(event string, keyvals ...any) {
+	if l.audit == nil {
+		l.sugaredHelper(1).Infow(event, append(keyvals[:len(keyvals):len(keyvals)], "audit", true)...)
+		return
+	}
+	if err := l.audit.Auditw(l.Name(), event, copyFields(l.fields, keyvals...)...); err != nil {
+		l.sugaredHelper(1).Errorw("Failed to write audit record", "event", event, "err", err)
+	}
+}
+
 func (l *zapLogger) With(args ...any) Logger {
 	newLogger := *l
 	newLogger.SugaredLogger = l.SugaredLogger.With(args...)
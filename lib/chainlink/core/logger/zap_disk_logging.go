@@ -6,6 +6,7 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
@@ -41,6 +42,8 @@ type zapDiskLogger struct {
 	zapLogger
 	config            Config
 	diskLogLevel      zap.AtomicLevel
+	file              *lumberjack.Logger
+	rotateChan        <-chan time.Time
 	pollDiskSpaceStop chan struct{}
 	pollDiskSpaceDone chan struct{}
 }
@@ -53,6 +56,10 @@ func (l *zapDiskLogger) pollDiskSpace() {
 		select {
 		case <-l.pollDiskSpaceStop:
 			return
+		case <-l.rotateChan:
+			if err := l.file.Rotate(); err != nil {
+				l.Warnw("Failed to rotate log file", "err", err)
+			}
 		case <-l.config.diskPollConfig.pollChan:
 			lvl := zapcore.DebugLevel
 
@@ -87,29 +94,36 @@ func (l *zapDiskLogger) pollDiskSpace() {
 }
 
 func newRotatingFileLogger(zcfg zap.Config, c Config, cores ...zapcore.Core) (*zapDiskLogger, func() error, error) {
-	defaultCore, defaultCloseFn, err := newDefaultLoggingCore(zcfg, c.UnixTS)
+	format := c.newAtomicFormat()
+	defaultCore, defaultCloseFn, err := newDefaultLoggingCore(zcfg, format, c.LevelOverrides)
 	if err != nil {
 		return nil, nil, err
 	}
 	cores = append(cores, defaultCore)
 
 	diskLogLevel := zap.NewAtomicLevelAt(zapcore.DebugLevel)
-	diskCore, diskErr := newDiskCore(diskLogLevel, c)
+	diskCore, file, diskErr := newDiskCore(diskLogLevel, c, format)
 	if diskErr != nil {
 		defaultCloseFn()
 		return nil, nil, diskErr
 	}
 	cores = append(cores, diskCore)
 
-	core := zapcore.NewTee(cores...)
+	core, err := c.Sampling.wrap(zapcore.NewTee(cores...))
+	if err != nil {
+		defaultCloseFn()
+		return nil, nil, err
+	}
 	l, diskCloseFn, err := newLoggerForCore(zcfg, core)
 	if err != nil {
 		defaultCloseFn()
 		return nil, nil, err
 	}
+	l.format = format
 
 	lggr := &zapDiskLogger{
 		config: c,
+		file:   file,
 
 		pollDiskSpaceStop: make(chan struct{}),
 		pollDiskSpaceDone: make(chan struct{}),
@@ -117,6 +131,13 @@ func newRotatingFileLogger(zcfg zap.Config, c Config, cores ...zapcore.Core) (*z
 		diskLogLevel:      diskLogLevel,
 	}
 
+	stopRotate := func() {}
+	if c.FileRotateInterval > 0 {
+		ticker := time.NewTicker(c.FileRotateInterval)
+		lggr.rotateChan = ticker.C
+		stopRotate = ticker.Stop
+	}
+
 	go lggr.pollDiskSpace()
 
 	closeLogger := sync.OnceValue(func() error {
@@ -125,6 +146,7 @@ func newRotatingFileLogger(zcfg zap.Config, c Config, cores ...zapcore.Core) (*z
 
 		close(lggr.pollDiskSpaceStop)
 		<-lggr.pollDiskSpaceDone
+		stopRotate()
 
 		return lggr.Sync()
 	})
@@ -267,3 +267,47 @@ func TestZapLogger_Name(t *testing.T) {
 	lggr2 := lggr1.Named("Lggr2")
 	require.Equal(t, "Lggr1.Lggr2", lggr2.Name())
 }
+
+func TestZapLogger_RotateInterval(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		noCompress bool
+		suffix     string
+	}{
+		{name: "compressed", suffix: ".log.gz"},
+		{name: "uncompressed", noCompress: true, suffix: ".log"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			logsDir := t.TempDir()
+			pollChan := make(chan time.Time)
+			local := Config{
+				Dir:                logsDir,
+				FileMaxSizeMB:      1,
+				FileMaxBackups:     2,
+				FileRotateInterval: 50 * time.Millisecond,
+				FileNoCompress:     tc.noCompress,
+
+				diskSpaceAvailableFn: func(string) (utils.FileSize, error) { return 100 * utils.MB, nil },
+				diskPollConfig:       zapDiskPollConfig{stop: func() { close(pollChan) }, pollChan: pollChan},
+			}
+
+			lggr := newTestLogger(t, local)
+			lggr.Debug("before rotation")
+
+			backups := func() []string {
+				entries, err := os.ReadDir(logsDir)
+				require.NoError(t, err)
+				var out []string
+				for _, e := range entries {
+					if e.Name() != logsFile && strings.HasSuffix(e.Name(), tc.suffix) {
+						out = append(out, e.Name())
+					}
+				}
+				return out
+			}
+			require.Eventually(t, func() bool { return len(backups()) >= 2 }, 5*time.Second, 10*time.Millisecond)
+			// Older backups are removed, keeping FileMaxBackups files
+			require.Eventually(t, func() bool { return len(backups()) <= 2 }, 5*time.Second, 10*time.Millisecond)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// AddressBookQualifier is the qualifier of the address ref of an address book entry. Since the address book does not
+// have a qualifier, the address and type are used as a unique identifier of the address ref, otherwise the refs of the
+// contracts of the same type and version on a chain would clash.
+func AddressBookQualifier(address string, contractType cldf.ContractType) string {
+	return fmt.Sprintf("%s-%s", address, contractType)
+}
+
+// AddressRefFromAddressBook returns the address ref of an address book entry, with its labels.
+func AddressRefFromAddressBook(chainSelector uint64, address string, tv cldf.TypeAndVersion) datastore.AddressRef {
+	version := tv.Version
+	ref := datastore.AddressRef{
+		ChainSelector: chainSelector,
+		Address:       address,
+		Type:          datastore.ContractType(tv.Type),
+		Version:       &version,
+		Qualifier:     AddressBookQualifier(address, tv.Type),
+	}
+	if !tv.Labels.IsEmpty() {
+		ref.Labels = datastore.NewLabelSet(tv.Labels.List()...)
+	}
+	return ref
+}
+
+// MigrateAddressBookInto records the entries of the address book in the datastore. Entries already migrated are
+// updated, so that the migration can be run again after the address book changed.
+func MigrateAddressBookInto(addrBook cldf.AddressBook, ds datastore.MutableDataStore) error {
+	addrs, err := addrBook.Addresses()
+	if err != nil {
+		return err
+	}
+	for chainSelector, chainAddresses := range addrs {
+		for addr, typever := range chainAddresses {
+			if err := ds.Addresses().Upsert(AddressRefFromAddressBook(chainSelector, addr, typever)); err != nil {
+				return fmt.Errorf("failed to migrate address %s of chain %d: %w", addr, chainSelector, err)
+			}
+		}
+	}
+	return nil
+}
+
+// DualWriteAddressBook is an address book which also records its addresses in a datastore, for the changesets which
+// still write to the address book while the datastore is being adopted.
+type DualWriteAddressBook struct {
+	cldf.AddressBook
+	dataStore datastore.MutableDataStore
+}
+
+var _ cldf.AddressBook = (*DualWriteAddressBook)(nil)
+
+// NewDualWriteAddressBook returns an address book writing to both the address book and the datastore.
+func NewDualWriteAddressBook(addrBook cldf.AddressBook, ds datastore.MutableDataStore) *DualWriteAddressBook {
+	return &DualWriteAddressBook{AddressBook: addrBook, dataStore: ds}
+}
+
+// NewMemoryDualWriteAddressBook returns an address book writing to a new memory address book and datastore, to be
+// returned in the output of a changeset.
+func NewMemoryDualWriteAddressBook() *DualWriteAddressBook {
+	return NewDualWriteAddressBook(cldf.NewMemoryAddressBook(), datastore.NewMemoryDataStore())
+}
+
+// DataStore returns the datastore the addresses are recorded in.
+func (ab *DualWriteAddressBook) DataStore() datastore.MutableDataStore {
+	return ab.dataStore
+}
+
+func (ab *DualWriteAddressBook) Save(chainSelector uint64, address string, tv cldf.TypeAndVersion) error {
+	if err := ab.AddressBook.Save(chainSelector, address, tv); err != nil {
+		return err
+	}
+	// the address book normalizes the EVM addresses, the ref records them the same way
+	if common.IsHexAddress(address) {
+		address = common.HexToAddress(address).Hex()
+	}
+	return ab.dataStore.Addresses().Upsert(AddressRefFromAddressBook(chainSelector, address, tv))
+}
+
+func (ab *DualWriteAddressBook) Merge(other cldf.AddressBook) error {
+	if err := ab.AddressBook.Merge(other); err != nil {
+		return err
+	}
+	return MigrateAddressBookInto(other, ab.dataStore)
+}
+
+func (ab *DualWriteAddressBook) Remove(other cldf.AddressBook) error {
+	existing, err := ab.AddressBook.Addresses()
+	if err != nil {
+		return err
+	}
+	if err := ab.AddressBook.Remove(other); err != nil {
+		return err
+	}
+	addrs, err := other.Addresses()
+	if err != nil {
+		return err
+	}
+	for chainSelector, chainAddresses := range addrs {
+		for addr := range chainAddresses {
+			// the refs are built from the removed entries, the address book only matches the addresses
+			ref := AddressRefFromAddressBook(chainSelector, addr, existing[chainSelector][addr])
+			err := ab.dataStore.Addresses().Delete(ref.Key())
+			if err != nil && !errors.Is(err, datastore.ErrAddressRefNotFound) {
+				return fmt.Errorf("failed to remove address %s of chain %d: %w", addr, chainSelector, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AddressStoreDiff is the difference between the addresses of an address book and of a datastore.
+type AddressStoreDiff struct {
+	// OnlyInAddressBook are the address book entries without a ref of the same address, type and version.
+	OnlyInAddressBook []datastore.AddressRef
+	// OnlyInDataStore are the refs without an address book entry of the same address, type and version.
+	OnlyInDataStore []datastore.AddressRef
+}
+
+// IsEmpty reports whether both stores have the same addresses.
+func (d AddressStoreDiff) IsEmpty() bool {
+	return len(d.OnlyInAddressBook) == 0 && len(d.OnlyInDataStore) == 0
+}
+
+func (d AddressStoreDiff) String() string {
+	if d.IsEmpty() {
+		return "address book and datastore are in sync"
+	}
+	var sb strings.Builder
+	for _, ref := range d.OnlyInAddressBook {
+		fmt.Fprintf(&sb, "only in address book: chain %d, %s %s at %s\n", ref.ChainSelector, ref.Type, ref.Version, ref.Address)
+	}
+	for _, ref := range d.OnlyInDataStore {
+		fmt.Fprintf(&sb, "only in datastore: chain %d, %s %s at %s (qualifier %q)\n", ref.ChainSelector, ref.Type, ref.Version, ref.Address, ref.Qualifier)
+	}
+	return sb.String()
+}
+
+// VerifyAddressBookMigration compares the addresses of the address book and of the datastore, and reports the entries
+// present in only one of them. Entries are matched by chain, address, type and version: the qualifiers and labels of
+// the refs are not compared, as the datastore may record an address with a more specific qualifier.
+func VerifyAddressBookMigration(addrBook cldf.AddressBook, ds datastore.DataStore) (AddressStoreDiff, error) {
+	var diff AddressStoreDiff
+	addrs, err := addrBook.Addresses()
+	if err != nil {
+		return diff, err
+	}
+	refs, err := ds.Addresses().Fetch()
+	if err != nil {
+		return diff, err
+	}
+
+	inDataStore := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		inDataStore[addressStoreKey(ref)] = true
+	}
+	inAddressBook := make(map[string]bool)
+	for chainSelector, chainAddresses := range addrs {
+		for addr, typever := range chainAddresses {
+			ref := AddressRefFromAddressBook(chainSelector, addr, typever)
+			key := addressStoreKey(ref)
+			inAddressBook[key] = true
+			if !inDataStore[key] {
+				diff.OnlyInAddressBook = append(diff.OnlyInAddressBook, ref)
+			}
+		}
+	}
+	for _, ref := range refs {
+		if !inAddressBook[addressStoreKey(ref)] {
+			diff.OnlyInDataStore = append(diff.OnlyInDataStore, ref)
+		}
+	}
+
+	sortRefs := func(a, b datastore.AddressRef) int {
+		if a.ChainSelector != b.ChainSelector {
+			if a.ChainSelector < b.ChainSelector {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Address, b.Address)
+	}
+	slices.SortFunc(diff.OnlyInAddressBook, sortRefs)
+	slices.SortFunc(diff.OnlyInDataStore, sortRefs)
+	return diff, nil
+}
+
+// addressStoreKey identifies an address in both stores. EVM addresses are compared in their EIP55 form, as the address
+// book always stores them this way.
+func addressStoreKey(ref datastore.AddressRef) string {
+	address := ref.Address
+	if common.IsHexAddress(address) {
+		address = common.HexToAddress(address).Hex()
+	}
+	version := ""
+	if ref.Version != nil {
+		version = ref.Version.String()
+	}
+	return fmt.Sprintf("%d/%s/%s/%s", ref.ChainSelector, address, ref.Type, version)
+}
@@ -0,0 +1,69 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+func TestAddressBookMigration(t *testing.T) {
+	evmSel := chain_selectors.TEST_90000001.Selector
+	solSel := chain_selectors.TEST_22222222222222222222222222222222222222222222.Selector
+	evmAddr := "0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c"
+	solAddr := "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+	tv := cldf.NewTypeAndVersion("Router", *semver.MustParse("1.0.0"))
+	labeled := cldf.NewTypeAndVersion("Token", *semver.MustParse("1.0.0"))
+	labeled.Labels = cldf.NewLabelSet("pool")
+
+	// the dual-write address book records the addresses in both stores
+	ab := NewMemoryDualWriteAddressBook()
+	require.NoError(t, ab.Save(evmSel, "0x5a0b54d5dc17e0aadc383d2db43b0a0d3e029c4c", tv))
+	require.NoError(t, ab.Save(solSel, solAddr, labeled))
+	ref, err := ab.DataStore().Addresses().Get(datastore.NewAddressRefKey(solSel, "Token", semver.MustParse("1.0.0"), AddressBookQualifier(solAddr, "Token")))
+	require.NoError(t, err)
+	require.Equal(t, solAddr, ref.Address)
+	require.True(t, ref.Labels.Contains("pool"))
+	diff, err := VerifyAddressBookMigration(ab, ab.DataStore().Seal())
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty(), diff.String())
+
+	// the migration of the address book records the same refs
+	migrated, err := MigrateAddressBook(ab)
+	require.NoError(t, err)
+	diff, err = VerifyAddressBookMigration(ab, migrated.Seal())
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty(), diff.String())
+	require.NoError(t, MigrateAddressBookInto(ab, migrated), "migration is idempotent")
+
+	// the entries present in only one store are reported
+	legacy := cldf.NewMemoryAddressBook()
+	require.NoError(t, legacy.Save(evmSel, evmAddr, tv))
+	ds := datastore.NewMemoryDataStore()
+	require.NoError(t, ds.Addresses().Add(datastore.AddressRef{
+		ChainSelector: solSel,
+		Address:       solAddr,
+		Type:          "Token",
+		Version:       semver.MustParse("1.0.0"),
+		Qualifier:     "my-token",
+	}))
+	diff, err = VerifyAddressBookMigration(legacy, ds.Seal())
+	require.NoError(t, err)
+	require.Len(t, diff.OnlyInAddressBook, 1)
+	require.Equal(t, evmAddr, diff.OnlyInAddressBook[0].Address)
+	require.Len(t, diff.OnlyInDataStore, 1)
+	require.Equal(t, "my-token", diff.OnlyInDataStore[0].Qualifier)
+
+	// removed addresses are removed from both stores
+	require.NoError(t, ab.Remove(legacy))
+	diff, err = VerifyAddressBookMigration(ab, ab.DataStore().Seal())
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty(), diff.String())
+	refs, err := ab.DataStore().Addresses().Fetch()
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+}
@@ -26,8 +26,8 @@ import (
 var _ cldf.ChangeSet[DisableRemoteChainConfig] = DisableRemoteChain
 
 type DisableRemoteChainConfig struct {
-	ChainSelector uint64
-	RemoteChains  []uint64 // the remote chain selectors to disable
+	ChainSelector uint64   `validate:"required,chainselector=solana"`
+	RemoteChains  []uint64 `validate:"required,chainselector"` // the remote chain selectors to disable
 	MCMS          *proposalutils.TimelockConfig
 }
 
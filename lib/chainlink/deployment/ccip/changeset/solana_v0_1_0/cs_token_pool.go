@@ -1019,9 +1019,7 @@ func AddTokenPoolLookupTable(e cldf.Environment, cfg TokenPoolLookupTableConfig)
 	}
 	newAddressBook := cldf.NewMemoryAddressBook()
 	tv := cldf.NewTypeAndVersion(shared.TokenPoolLookupTable, deployment.Version1_0_0)
-	tv.Labels.Add(tokenPubKey.String())
-	tv.Labels.Add(cfg.PoolType.String())
-	tv.Labels.Add(cfg.Metadata)
+	tv.Labels = shared.TokenPoolLabels{Address: tokenPubKey.String(), PoolType: cfg.PoolType, Metadata: cfg.Metadata}.LabelSet()
 	if err := newAddressBook.Save(cfg.ChainSelector, table.String(), tv); err != nil {
 		return cldf.ChangesetOutput{}, fmt.Errorf("failed to save tokenpool address lookup table: %w", err)
 	}
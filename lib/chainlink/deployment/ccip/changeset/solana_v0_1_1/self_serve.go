@@ -115,6 +115,11 @@ func OnboardTokenPoolsForSelfServe(e cldf.Environment, cfg OnboardTokenPoolsForS
 	}
 	mcmsTxs := []mcmsTypes.Transaction{}
 	instructions := [][]solana.Instruction{}
+	newAddresses := deployment.NewMemoryDualWriteAddressBook()
+	existingAddresses, err := e.ExistingAddresses.AddressesForChain(cfg.ChainSelector)
+	if err != nil && !errors.Is(err, cldf.ErrChainNotFound) {
+		return cldf.ChangesetOutput{}, err
+	}
 	for _, registerTokenConfig := range cfg.RegisterTokenConfigs {
 		// Propose Admin in Token Admin Registry
 		proposeTokenAdminRegistryAdminIx, err := generateProposeTokenAdminRegistryAdministratorIx(registerTokenConfig, routerState)
@@ -172,19 +177,33 @@ func OnboardTokenPoolsForSelfServe(e cldf.Environment, cfg OnboardTokenPoolsForS
 			instructions = append(instructions, tokenInstructions)
 		}
 		if !registerTokenConfig.Override {
-			// Store in Address Book only first time running this
-			newAddresses := cldf.NewMemoryAddressBook()
+			// Store in Address Book and DataStore only first time running this
+			tokenMint := registerTokenConfig.TokenMint.String()
 			tv := cldf.NewTypeAndVersion(registerTokenConfig.TokenProgramName, deployment.Version1_0_0)
-			tv.AddLabel(registerTokenConfig.Metadata)                            // Customer Identifier
-			tv.AddLabel(registerTokenConfig.PoolType.String())                   // Pool Type
-			tv.AddLabel(currentTokenPoolSolanaState.tokenPoolProgramID.String()) // Token Pool Program ID
-			err = newAddresses.Save(cfg.ChainSelector, registerTokenConfig.TokenMint.String(), tv)
+			tv.Labels = shared.TokenPoolLabels{
+				Address:  currentTokenPoolSolanaState.tokenPoolProgramID.String(),
+				PoolType: registerTokenConfig.PoolType,
+				Metadata: registerTokenConfig.Metadata, // Customer Identifier
+			}.LabelSet()
+			if _, exists := existingAddresses[tokenMint]; exists {
+				// the address book already records the token, which cannot be saved twice: the labels of the pool are
+				// only recorded in the DataStore, whose ref of the token is updated with them
+				err = newAddresses.DataStore().Addresses().Upsert(deployment.AddressRefFromAddressBook(cfg.ChainSelector, tokenMint, tv))
+			} else {
+				err = newAddresses.Save(cfg.ChainSelector, tokenMint, tv)
+			}
 			if err != nil {
 				return cldf.ChangesetOutput{}, err
 			}
 		}
 	}
-	return ExecuteInstructionsAndBuildProposals(e, ExecuteConfig{ChainSelector: cfg.ChainSelector, MCMS: cfg.MCMS, Chain: solChainState.chain}, instructions, mcmsTxs)
+	out, err := ExecuteInstructionsAndBuildProposals(e, ExecuteConfig{ChainSelector: cfg.ChainSelector, MCMS: cfg.MCMS, Chain: solChainState.chain}, instructions, mcmsTxs)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	out.AddressBook = newAddresses
+	out.DataStore = newAddresses.DataStore()
+	return out, nil
 }
 
 func generateProposeTokenAdminRegistryAdministratorIx(registerTokenConfig OnboardTokenPoolConfig, routerState routerSolanaState) (solana.Instruction, error) {
@@ -21,6 +21,7 @@ import (
 	solanastateview "github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/solana"
 	"github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
 	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/deployment/utils/solutils"
 )
 
 type CCIPSolanaContractVersion string
@@ -263,10 +264,19 @@ type ExecuteConfig struct {
 	ChainSelector uint64
 	MCMS          *proposalutils.TimelockConfig
 	Chain         cldf_solana.Chain
+	// Signer signs and pays the instructions instead of the deployer key of the chain, e.g. a remote signer. The
+	// instructions must use it as their authority.
+	Signer solutils.Signer
 }
 
 func ExecuteInstructionsAndBuildProposals(e cldf.Environment, cfg ExecuteConfig, instructions [][]solana.Instruction, mcmsTxs []mcmsTypes.Transaction) (cldf.ChangesetOutput, error) {
 	for _, instructionSet := range instructions {
+		if cfg.Signer != nil {
+			if _, err := solutils.SendAndConfirmWithSigner(e.GetContext(), cfg.Chain.Client, cfg.Signer, instructionSet); err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to confirm instructions signed by %s: %w", cfg.Signer.PublicKey(), err)
+			}
+			continue
+		}
 		if err := cfg.Chain.Confirm(instructionSet); err != nil {
 			return cldf.ChangesetOutput{}, fmt.Errorf("failed to confirm instructions: %w", err)
 		}
@@ -0,0 +1,258 @@
+package sui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	cldf_sui "github.com/smartcontractkit/chainlink-deployments-framework/chain/sui"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	"github.com/smartcontractkit/chainlink-sui/bindings/bind"
+	module_fee_quoter "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip/fee_quoter"
+	sui_deployment "github.com/smartcontractkit/chainlink-sui/deployment"
+	sui_ops "github.com/smartcontractkit/chainlink-sui/deployment/ops"
+	ccipops "github.com/smartcontractkit/chainlink-sui/deployment/ops/ccip"
+)
+
+// minDestBytesOverhead is the minimum dest bytes overhead of a token transfer fee config accepted by the fee quoter,
+// i.e. the size of the return data of lock_or_burn.
+const minDestBytesOverhead = 32
+
+var (
+	_ cldf.ChangeSetV2[SetFeeQuoterPremiumMultipliersConfig]      = SetFeeQuoterPremiumMultipliers{}
+	_ cldf.ChangeSetV2[SetFeeQuoterTokenTransferFeeConfigsConfig] = SetFeeQuoterTokenTransferFeeConfigs{}
+)
+
+type SetFeeQuoterPremiumMultipliersConfig struct {
+	// PremiumMultipliersByChain are the premium multipliers in wei per eth to set, by Sui chain selector and fee token.
+	PremiumMultipliersByChain map[uint64]map[string]uint64
+}
+
+// SetFeeQuoterPremiumMultipliers sets the premium multipliers of fee tokens on the fee quoter of Sui chains, and
+// verifies them with dev inspect calls.
+type SetFeeQuoterPremiumMultipliers struct{}
+
+// Apply implements deployment.ChangeSetV2.
+func (s SetFeeQuoterPremiumMultipliers) Apply(e cldf.Environment, config SetFeeQuoterPremiumMultipliersConfig) (cldf.ChangesetOutput, error) {
+	suiState, err := sui_deployment.LoadOnchainStatesui(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load Sui onchain state: %w", err)
+	}
+
+	for chainSelector, multipliers := range config.PremiumMultipliersByChain {
+		suiChain := e.BlockChains.SuiChains()[chainSelector]
+		chainState := suiState[chainSelector]
+		tokens := slices.Sorted(maps.Keys(multipliers))
+		input := ccipops.FeeQuoterApplyPremiumMultiplierWeiPerEthUpdatesInput{
+			CCIPPackageId:    chainState.CCIPAddress,
+			StateObjectId:    chainState.CCIPObjectRef,
+			OwnerCapObjectId: chainState.CCIPOwnerCapObjectId,
+			Tokens:           tokens,
+		}
+		for _, token := range tokens {
+			input.PremiumMultiplierWeiPerEth = append(input.PremiumMultiplierWeiPerEth, multipliers[token])
+		}
+		_, err = operations.ExecuteOperation(e.OperationsBundle, ccipops.FeeQuoterApplyPremiumMultiplierWeiPerEthUpdatesOp, feeQuoterTxDeps(suiChain), input)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to set premium multipliers on sui chain %d: %w", chainSelector, err)
+		}
+
+		feeQuoter, err := module_fee_quoter.NewFeeQuoter(chainState.CCIPAddress, suiChain.Client)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to create fee quoter binding for sui chain %d: %w", chainSelector, err)
+		}
+		opts := &bind.CallOpts{Signer: suiChain.Signer}
+		for _, token := range tokens {
+			multiplier, err := feeQuoter.DevInspect().GetPremiumMultiplierWeiPerEth(e.GetContext(), opts, bind.Object{Id: chainState.CCIPObjectRef}, token)
+			if err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to get premium multiplier of token %s on sui chain %d: %w", token, chainSelector, err)
+			}
+			if multiplier != multipliers[token] {
+				return cldf.ChangesetOutput{}, fmt.Errorf("premium multiplier of token %s on sui chain %d is %d, expected %d", token, chainSelector, multiplier, multipliers[token])
+			}
+		}
+		e.Logger.Infow("Set premium multipliers on Sui fee quoter", "chain", chainSelector, "tokens", tokens)
+	}
+
+	return cldf.ChangesetOutput{}, nil
+}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (s SetFeeQuoterPremiumMultipliers) VerifyPreconditions(e cldf.Environment, config SetFeeQuoterPremiumMultipliersConfig) error {
+	if len(config.PremiumMultipliersByChain) == 0 {
+		return errors.New("no premium multipliers provided")
+	}
+	suiState, err := sui_deployment.LoadOnchainStatesui(e)
+	if err != nil {
+		return fmt.Errorf("failed to load Sui onchain state: %w", err)
+	}
+	for chainSelector, multipliers := range config.PremiumMultipliersByChain {
+		if err := validateFeeQuoterState(e, suiState, chainSelector); err != nil {
+			return err
+		}
+		if len(multipliers) == 0 {
+			return fmt.Errorf("no premium multipliers provided for sui chain %d", chainSelector)
+		}
+		for token := range multipliers {
+			if token == "" {
+				return fmt.Errorf("empty token address for sui chain %d", chainSelector)
+			}
+		}
+	}
+	return nil
+}
+
+// FeeQuoterTokenTransferFeeConfigUpdates are the updates of the token transfer fee configs of a destination chain.
+type FeeQuoterTokenTransferFeeConfigUpdates struct {
+	// TokenTransferFeeConfigs are the token transfer fee configs to add or replace, by token.
+	TokenTransferFeeConfigs map[string]module_fee_quoter.TokenTransferFeeConfig
+	// RemovedTokens are the tokens whose token transfer fee configs are removed.
+	RemovedTokens []string
+}
+
+type SetFeeQuoterTokenTransferFeeConfigsConfig struct {
+	// UpdatesByChain are the updates to apply, by Sui chain selector and destination chain selector.
+	UpdatesByChain map[uint64]map[uint64]FeeQuoterTokenTransferFeeConfigUpdates
+}
+
+// SetFeeQuoterTokenTransferFeeConfigs sets the token transfer fee configs of tokens to destination chains on the fee
+// quoter of Sui chains, and verifies them with dev inspect calls.
+type SetFeeQuoterTokenTransferFeeConfigs struct{}
+
+// Apply implements deployment.ChangeSetV2.
+func (s SetFeeQuoterTokenTransferFeeConfigs) Apply(e cldf.Environment, config SetFeeQuoterTokenTransferFeeConfigsConfig) (cldf.ChangesetOutput, error) {
+	suiState, err := sui_deployment.LoadOnchainStatesui(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load Sui onchain state: %w", err)
+	}
+
+	for chainSelector, updatesByDest := range config.UpdatesByChain {
+		suiChain := e.BlockChains.SuiChains()[chainSelector]
+		chainState := suiState[chainSelector]
+		feeQuoter, err := module_fee_quoter.NewFeeQuoter(chainState.CCIPAddress, suiChain.Client)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to create fee quoter binding for sui chain %d: %w", chainSelector, err)
+		}
+
+		for _, destChainSelector := range slices.Sorted(maps.Keys(updatesByDest)) {
+			updates := updatesByDest[destChainSelector]
+			tokens := slices.Sorted(maps.Keys(updates.TokenTransferFeeConfigs))
+			input := ccipops.FeeQuoterApplyTokenTransferFeeConfigUpdatesInput{
+				CCIPPackageId:     chainState.CCIPAddress,
+				StateObjectId:     chainState.CCIPObjectRef,
+				OwnerCapObjectId:  chainState.CCIPOwnerCapObjectId,
+				DestChainSelector: destChainSelector,
+				AddTokens:         tokens,
+				RemoveTokens:      updates.RemovedTokens,
+			}
+			for _, token := range tokens {
+				cfg := updates.TokenTransferFeeConfigs[token]
+				input.AddMinFeeUsdCents = append(input.AddMinFeeUsdCents, cfg.MinFeeUsdCents)
+				input.AddMaxFeeUsdCents = append(input.AddMaxFeeUsdCents, cfg.MaxFeeUsdCents)
+				input.AddDeciBps = append(input.AddDeciBps, cfg.DeciBps)
+				input.AddDestGasOverhead = append(input.AddDestGasOverhead, cfg.DestGasOverhead)
+				input.AddDestBytesOverhead = append(input.AddDestBytesOverhead, cfg.DestBytesOverhead)
+				input.AddIsEnabled = append(input.AddIsEnabled, cfg.IsEnabled)
+			}
+			_, err = operations.ExecuteOperation(e.OperationsBundle, ccipops.FeeQuoterApplyTokenTransferFeeConfigUpdatesOp, feeQuoterTxDeps(suiChain), input)
+			if err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to set token transfer fee configs to chain %d on sui chain %d: %w", destChainSelector, chainSelector, err)
+			}
+
+			// removed tokens have an empty config
+			expected := make(map[string]module_fee_quoter.TokenTransferFeeConfig, len(tokens)+len(updates.RemovedTokens))
+			for _, token := range updates.RemovedTokens {
+				expected[token] = module_fee_quoter.TokenTransferFeeConfig{}
+			}
+			maps.Copy(expected, updates.TokenTransferFeeConfigs)
+			opts := &bind.CallOpts{Signer: suiChain.Signer}
+			for token, expectedCfg := range expected {
+				cfg, err := feeQuoter.DevInspect().GetTokenTransferFeeConfig(e.GetContext(), opts, bind.Object{Id: chainState.CCIPObjectRef}, destChainSelector, token)
+				if err != nil {
+					return cldf.ChangesetOutput{}, fmt.Errorf("failed to get token transfer fee config of token %s to chain %d on sui chain %d: %w", token, destChainSelector, chainSelector, err)
+				}
+				if cfg != expectedCfg {
+					return cldf.ChangesetOutput{}, fmt.Errorf("token transfer fee config of token %s to chain %d on sui chain %d is %+v, expected %+v", token, destChainSelector, chainSelector, cfg, expectedCfg)
+				}
+			}
+			e.Logger.Infow("Set token transfer fee configs on Sui fee quoter", "chain", chainSelector, "destChain", destChainSelector, "tokens", tokens, "removedTokens", updates.RemovedTokens)
+		}
+	}
+
+	return cldf.ChangesetOutput{}, nil
+}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (s SetFeeQuoterTokenTransferFeeConfigs) VerifyPreconditions(e cldf.Environment, config SetFeeQuoterTokenTransferFeeConfigsConfig) error {
+	if len(config.UpdatesByChain) == 0 {
+		return errors.New("no token transfer fee config updates provided")
+	}
+	suiState, err := sui_deployment.LoadOnchainStatesui(e)
+	if err != nil {
+		return fmt.Errorf("failed to load Sui onchain state: %w", err)
+	}
+	for chainSelector, updatesByDest := range config.UpdatesByChain {
+		if err := validateFeeQuoterState(e, suiState, chainSelector); err != nil {
+			return err
+		}
+		for destChainSelector, updates := range updatesByDest {
+			if destChainSelector == chainSelector {
+				return fmt.Errorf("destination chain cannot be the sui chain %d", chainSelector)
+			}
+			if err := cldf.IsValidChainSelector(destChainSelector); err != nil {
+				return fmt.Errorf("invalid destination chain selector %d: %w", destChainSelector, err)
+			}
+			if len(updates.TokenTransferFeeConfigs) == 0 && len(updates.RemovedTokens) == 0 {
+				return fmt.Errorf("no token transfer fee config updates provided to chain %d on sui chain %d", destChainSelector, chainSelector)
+			}
+			for token, cfg := range updates.TokenTransferFeeConfigs {
+				if token == "" {
+					return fmt.Errorf("empty token address to chain %d on sui chain %d", destChainSelector, chainSelector)
+				}
+				if cfg.MinFeeUsdCents >= cfg.MaxFeeUsdCents {
+					return fmt.Errorf("min fee must be lower than max fee for token %s to chain %d on sui chain %d", token, destChainSelector, chainSelector)
+				}
+				if cfg.DestBytesOverhead < minDestBytesOverhead {
+					return fmt.Errorf("dest bytes overhead must be at least %d for token %s to chain %d on sui chain %d", minDestBytesOverhead, token, destChainSelector, chainSelector)
+				}
+			}
+			for _, token := range updates.RemovedTokens {
+				if _, ok := updates.TokenTransferFeeConfigs[token]; ok {
+					return fmt.Errorf("token %s to chain %d on sui chain %d cannot be both updated and removed", token, destChainSelector, chainSelector)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateFeeQuoterState(e cldf.Environment, suiState map[uint64]sui_deployment.CCIPChainState, chainSelector uint64) error {
+	if _, ok := e.BlockChains.SuiChains()[chainSelector]; !ok {
+		return fmt.Errorf("sui chain %d not found in environment", chainSelector)
+	}
+	chainState, ok := suiState[chainSelector]
+	if !ok || chainState.CCIPAddress == "" || chainState.CCIPObjectRef == "" {
+		return fmt.Errorf("missing CCIP package for sui chain %d", chainSelector)
+	}
+	if chainState.CCIPOwnerCapObjectId == "" {
+		return fmt.Errorf("missing CCIP owner cap for sui chain %d", chainSelector)
+	}
+	return nil
+}
+
+func feeQuoterTxDeps(suiChain cldf_sui.Chain) sui_ops.OpTxDeps {
+	return sui_ops.OpTxDeps{
+		Client: suiChain.Client,
+		Signer: suiChain.Signer,
+		GetCallOpts: func() *bind.CallOpts {
+			b := uint64(400_000_000)
+			return &bind.CallOpts{
+				WaitForExecution: true,
+				GasBudget:        &b,
+			}
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package sui_test
+
+import (
+	"testing"
+
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	"github.com/smartcontractkit/chainlink-sui/bindings/bind"
+	module_fee_quoter "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip/fee_quoter"
+
+	suics "github.com/smartcontractkit/chainlink/deployment/ccip/changeset/sui"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+)
+
+func TestSetFeeQuoterConfigs_Apply(t *testing.T) {
+	t.Parallel()
+	deployedEnvironment, _ := testhelpers.NewMemoryEnvironment(t, testhelpers.WithSuiChains(1))
+	env := deployedEnvironment.Env
+	suiChainSelector := env.BlockChains.ListChainSelectors(chain.WithFamily(chain_selectors.FamilySui))[0]
+	evmChainSelector := env.BlockChains.ListChainSelectors(chain.WithFamily(chain_selectors.FamilyEVM))[0]
+
+	state, err := stateview.LoadOnchainState(env)
+	require.NoError(t, err)
+	suiState := state.SuiChains[suiChainSelector]
+	linkToken := suiState.LinkTokenCoinMetadataId
+	transferFeeConfig := module_fee_quoter.TokenTransferFeeConfig{
+		MinFeeUsdCents:    25,
+		MaxFeeUsdCents:    10_000,
+		DeciBps:           10,
+		DestGasOverhead:   90_000,
+		DestBytesOverhead: 32,
+		IsEnabled:         true,
+	}
+
+	env, _, err = commonchangeset.ApplyChangesets(t, env, []commonchangeset.ConfiguredChangeSet{
+		commonchangeset.Configure(suics.SetFeeQuoterPremiumMultipliers{}, suics.SetFeeQuoterPremiumMultipliersConfig{
+			PremiumMultipliersByChain: map[uint64]map[string]uint64{suiChainSelector: {linkToken: 9e17}},
+		}),
+		commonchangeset.Configure(suics.SetFeeQuoterTokenTransferFeeConfigs{}, suics.SetFeeQuoterTokenTransferFeeConfigsConfig{
+			UpdatesByChain: map[uint64]map[uint64]suics.FeeQuoterTokenTransferFeeConfigUpdates{
+				suiChainSelector: {evmChainSelector: {
+					TokenTransferFeeConfigs: map[string]module_fee_quoter.TokenTransferFeeConfig{linkToken: transferFeeConfig},
+				}},
+			},
+		}),
+	})
+	require.NoError(t, err)
+
+	suiChain := env.BlockChains.SuiChains()[suiChainSelector]
+	feeQuoter, err := module_fee_quoter.NewFeeQuoter(suiState.CCIPAddress, suiChain.Client)
+	require.NoError(t, err)
+	opts := &bind.CallOpts{Signer: suiChain.Signer}
+	ccipObject := bind.Object{Id: suiState.CCIPObjectRef}
+
+	multiplier, err := feeQuoter.DevInspect().GetPremiumMultiplierWeiPerEth(t.Context(), opts, ccipObject, linkToken)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9e17), multiplier)
+
+	cfg, err := feeQuoter.DevInspect().GetTokenTransferFeeConfig(t.Context(), opts, ccipObject, evmChainSelector, linkToken)
+	require.NoError(t, err)
+	require.Equal(t, transferFeeConfig, cfg)
+
+	// removing the token transfer fee config resets it
+	_, _, err = commonchangeset.ApplyChangesets(t, env, []commonchangeset.ConfiguredChangeSet{
+		commonchangeset.Configure(suics.SetFeeQuoterTokenTransferFeeConfigs{}, suics.SetFeeQuoterTokenTransferFeeConfigsConfig{
+			UpdatesByChain: map[uint64]map[uint64]suics.FeeQuoterTokenTransferFeeConfigUpdates{
+				suiChainSelector: {evmChainSelector: {RemovedTokens: []string{linkToken}}},
+			},
+		}),
+	})
+	require.NoError(t, err)
+	cfg, err = feeQuoter.DevInspect().GetTokenTransferFeeConfig(t.Context(), opts, ccipObject, evmChainSelector, linkToken)
+	require.NoError(t, err)
+	require.Equal(t, module_fee_quoter.TokenTransferFeeConfig{}, cfg)
+}
@@ -0,0 +1,219 @@
+package sui
+
+import (
+	"testing"
+
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	suichain "github.com/smartcontractkit/chainlink-deployments-framework/chain/sui"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	module_fee_quoter "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip/fee_quoter"
+	sui_deployment "github.com/smartcontractkit/chainlink-sui/deployment"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	mockCCIPAddress  = "0x1"
+	mockCCIPObject   = "0x2"
+	mockCCIPOwnerCap = "0x3"
+	mockToken        = "0x4"
+)
+
+var (
+	suiSelector  = chain_selectors.SUI_TESTNET.Selector
+	destSelector = chain_selectors.ETHEREUM_TESTNET_SEPOLIA.Selector
+)
+
+// getTestEnv returns an environment with a Sui chain and the given addresses of its CCIP package.
+func getTestEnv(t *testing.T, addresses map[string]cldf.TypeAndVersion) cldf.Environment {
+	ab := cldf.NewMemoryAddressBook()
+	for addr, tv := range addresses {
+		require.NoError(t, ab.Save(suiSelector, addr, tv))
+	}
+	return cldf.Environment{
+		Name:              "test",
+		Logger:            logger.TestLogger(t),
+		ExistingAddresses: ab,
+		BlockChains: chain.NewBlockChains(map[uint64]chain.BlockChain{
+			suiSelector: suichain.Chain{},
+		}),
+	}
+}
+
+func ccipAddresses() map[string]cldf.TypeAndVersion {
+	return map[string]cldf.TypeAndVersion{
+		mockCCIPAddress:  {Type: sui_deployment.SuiCCIPType},
+		mockCCIPObject:   {Type: sui_deployment.SuiCCIPObjectRefType},
+		mockCCIPOwnerCap: {Type: sui_deployment.SuiCCIPOwnerCapObjectIDType},
+	}
+}
+
+func validTokenTransferFeeConfig() module_fee_quoter.TokenTransferFeeConfig {
+	return module_fee_quoter.TokenTransferFeeConfig{
+		MinFeeUsdCents:    25,
+		MaxFeeUsdCents:    10_000,
+		DeciBps:           10,
+		DestGasOverhead:   90_000,
+		DestBytesOverhead: minDestBytesOverhead,
+		IsEnabled:         true,
+	}
+}
+
+func TestSetFeeQuoterPremiumMultipliers_VerifyPreconditions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		addresses map[string]cldf.TypeAndVersion
+		config    SetFeeQuoterPremiumMultipliersConfig
+		wantErrRe string
+	}{
+		{
+			name:      "success - valid config",
+			addresses: ccipAddresses(),
+			config: SetFeeQuoterPremiumMultipliersConfig{
+				PremiumMultipliersByChain: map[uint64]map[string]uint64{suiSelector: {mockToken: 9e17}},
+			},
+		},
+		{
+			name:      "error - no multipliers",
+			addresses: ccipAddresses(),
+			config:    SetFeeQuoterPremiumMultipliersConfig{},
+			wantErrRe: "no premium multipliers provided",
+		},
+		{
+			name:      "error - unknown chain",
+			addresses: ccipAddresses(),
+			config: SetFeeQuoterPremiumMultipliersConfig{
+				PremiumMultipliersByChain: map[uint64]map[string]uint64{destSelector: {mockToken: 9e17}},
+			},
+			wantErrRe: "sui chain .* not found in environment",
+		},
+		{
+			name: "error - missing owner cap",
+			addresses: map[string]cldf.TypeAndVersion{
+				mockCCIPAddress: {Type: sui_deployment.SuiCCIPType},
+				mockCCIPObject:  {Type: sui_deployment.SuiCCIPObjectRefType},
+			},
+			config: SetFeeQuoterPremiumMultipliersConfig{
+				PremiumMultipliersByChain: map[uint64]map[string]uint64{suiSelector: {mockToken: 9e17}},
+			},
+			wantErrRe: "missing CCIP owner cap",
+		},
+		{
+			name:      "error - empty token",
+			addresses: ccipAddresses(),
+			config: SetFeeQuoterPremiumMultipliersConfig{
+				PremiumMultipliersByChain: map[uint64]map[string]uint64{suiSelector: {"": 9e17}},
+			},
+			wantErrRe: "empty token address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := SetFeeQuoterPremiumMultipliers{}.VerifyPreconditions(getTestEnv(t, tt.addresses), tt.config)
+			if tt.wantErrRe != "" {
+				require.Error(t, err)
+				require.Regexp(t, tt.wantErrRe, err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSetFeeQuoterTokenTransferFeeConfigs_VerifyPreconditions(t *testing.T) {
+	t.Parallel()
+	withConfig := func(cfg module_fee_quoter.TokenTransferFeeConfig) SetFeeQuoterTokenTransferFeeConfigsConfig {
+		return SetFeeQuoterTokenTransferFeeConfigsConfig{
+			UpdatesByChain: map[uint64]map[uint64]FeeQuoterTokenTransferFeeConfigUpdates{
+				suiSelector: {destSelector: {TokenTransferFeeConfigs: map[string]module_fee_quoter.TokenTransferFeeConfig{mockToken: cfg}}},
+			},
+		}
+	}
+	minAboveMax := validTokenTransferFeeConfig()
+	minAboveMax.MinFeeUsdCents = minAboveMax.MaxFeeUsdCents
+	lowBytesOverhead := validTokenTransferFeeConfig()
+	lowBytesOverhead.DestBytesOverhead = minDestBytesOverhead - 1
+
+	tests := []struct {
+		name      string
+		config    SetFeeQuoterTokenTransferFeeConfigsConfig
+		wantErrRe string
+	}{
+		{
+			name:   "success - valid config",
+			config: withConfig(validTokenTransferFeeConfig()),
+		},
+		{
+			name: "success - removed token",
+			config: SetFeeQuoterTokenTransferFeeConfigsConfig{
+				UpdatesByChain: map[uint64]map[uint64]FeeQuoterTokenTransferFeeConfigUpdates{
+					suiSelector: {destSelector: {RemovedTokens: []string{mockToken}}},
+				},
+			},
+		},
+		{
+			name:      "error - no updates",
+			config:    SetFeeQuoterTokenTransferFeeConfigsConfig{},
+			wantErrRe: "no token transfer fee config updates provided",
+		},
+		{
+			name: "error - sui destination",
+			config: SetFeeQuoterTokenTransferFeeConfigsConfig{
+				UpdatesByChain: map[uint64]map[uint64]FeeQuoterTokenTransferFeeConfigUpdates{
+					suiSelector: {suiSelector: {RemovedTokens: []string{mockToken}}},
+				},
+			},
+			wantErrRe: "destination chain cannot be the sui chain",
+		},
+		{
+			name: "error - empty updates",
+			config: SetFeeQuoterTokenTransferFeeConfigsConfig{
+				UpdatesByChain: map[uint64]map[uint64]FeeQuoterTokenTransferFeeConfigUpdates{
+					suiSelector: {destSelector: {}},
+				},
+			},
+			wantErrRe: "no token transfer fee config updates provided to chain",
+		},
+		{
+			name:      "error - min fee not lower than max fee",
+			config:    withConfig(minAboveMax),
+			wantErrRe: "min fee must be lower than max fee",
+		},
+		{
+			name:      "error - dest bytes overhead too low",
+			config:    withConfig(lowBytesOverhead),
+			wantErrRe: "dest bytes overhead must be at least",
+		},
+		{
+			name: "error - token updated and removed",
+			config: SetFeeQuoterTokenTransferFeeConfigsConfig{
+				UpdatesByChain: map[uint64]map[uint64]FeeQuoterTokenTransferFeeConfigUpdates{
+					suiSelector: {destSelector: {
+						TokenTransferFeeConfigs: map[string]module_fee_quoter.TokenTransferFeeConfig{mockToken: validTokenTransferFeeConfig()},
+						RemovedTokens:           []string{mockToken},
+					}},
+				},
+			},
+			wantErrRe: "cannot be both updated and removed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := SetFeeQuoterTokenTransferFeeConfigs{}.VerifyPreconditions(getTestEnv(t, ccipAddresses()), tt.config)
+			if tt.wantErrRe != "" {
+				require.Error(t, err)
+				require.Regexp(t, tt.wantErrRe, err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package sui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/block-vision/sui-go-sdk/models"
+	"github.com/block-vision/sui-go-sdk/transaction"
+
+	cldf_sui "github.com/smartcontractkit/chainlink-deployments-framework/chain/sui"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	"github.com/smartcontractkit/chainlink-sui/bindings/bind"
+	module_lock_release_token_pool "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_token_pools/lock_release_token_pool"
+	bindutils "github.com/smartcontractkit/chainlink-sui/bindings/utils"
+	sui_deployment "github.com/smartcontractkit/chainlink-sui/deployment"
+	lockreleasetokenpoolops "github.com/smartcontractkit/chainlink-sui/deployment/ops/ccip_lock_release_token_pool"
+)
+
+var (
+	_ cldf.ChangeSetV2[ProvideLockReleaseLiquidityConfig]  = ProvideLockReleaseLiquidity{}
+	_ cldf.ChangeSetV2[WithdrawLockReleaseLiquidityConfig] = WithdrawLockReleaseLiquidity{}
+	_ cldf.ChangeSetV2[SetLockReleaseRebalancerConfig]     = SetLockReleaseRebalancer{}
+)
+
+// LockReleasePool identifies a lock release token pool of a Sui chain.
+type LockReleasePool struct {
+	SuiChainSelector uint64
+	// TokenSymbol is the symbol of the token of the pool in the address book.
+	TokenSymbol string
+	// CoinType is the type of the token of the pool, e.g. 0x2::sui::SUI.
+	CoinType string
+}
+
+type ProvideLockReleaseLiquidityConfig struct {
+	LockReleasePool
+	// RebalancerCapObjectId is the rebalancer cap of the pool, which must be owned by the Sui signer.
+	RebalancerCapObjectId string
+	// CoinObjectId is the coin provided to the pool, its whole balance is provided.
+	CoinObjectId string
+}
+
+// ProvideLockReleaseLiquidity provides the balance of a coin to a lock release token pool of a Sui chain, and asserts
+// that the balance of the pool increased accordingly.
+type ProvideLockReleaseLiquidity struct{}
+
+// Apply implements deployment.ChangeSetV2.
+func (s ProvideLockReleaseLiquidity) Apply(e cldf.Environment, config ProvideLockReleaseLiquidityConfig) (cldf.ChangesetOutput, error) {
+	suiChain, poolState, pool, err := loadLockReleasePool(e, config.LockReleasePool)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	amount, err := coinBalance(e, suiChain, config.CoinObjectId)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	balanceBefore, err := lockReleasePoolBalance(e, suiChain, poolState, pool, config.CoinType)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+
+	_, err = operations.ExecuteOperation(e.OperationsBundle, lockreleasetokenpoolops.LockReleaseTokenPoolProvideLiquidityOp, feeQuoterTxDeps(suiChain),
+		lockreleasetokenpoolops.LockReleaseTokenPoolProvideLiquidityInput{
+			LockReleaseTokenPoolPackageId: poolState.PackageID,
+			CoinObjectTypeArg:             config.CoinType,
+			StateObjectId:                 poolState.StateObjectId,
+			RebalancerCapObjectId:         config.RebalancerCapObjectId,
+			Coin:                          config.CoinObjectId,
+		})
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to provide liquidity to %s pool on sui chain %d: %w", config.TokenSymbol, config.SuiChainSelector, err)
+	}
+
+	if err := assertLockReleasePoolBalance(e, suiChain, poolState, pool, config.LockReleasePool, balanceBefore+amount); err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	e.Logger.Infow("Provided liquidity to Sui lock release token pool", "chain", config.SuiChainSelector, "token", config.TokenSymbol, "amount", amount)
+	return cldf.ChangesetOutput{}, nil
+}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (s ProvideLockReleaseLiquidity) VerifyPreconditions(e cldf.Environment, config ProvideLockReleaseLiquidityConfig) error {
+	if config.CoinObjectId == "" {
+		return errors.New("coin object ID must be defined")
+	}
+	return validateRebalancerCap(e, config.LockReleasePool, config.RebalancerCapObjectId)
+}
+
+type WithdrawLockReleaseLiquidityConfig struct {
+	LockReleasePool
+	// RebalancerCapObjectId is the rebalancer cap of the pool, which must be owned by the Sui signer.
+	RebalancerCapObjectId string
+	// Amount is the amount of tokens withdrawn from the pool.
+	Amount uint64
+	// Recipient receives the withdrawn tokens.
+	Recipient string
+}
+
+// WithdrawLockReleaseLiquidity withdraws liquidity from a lock release token pool of a Sui chain to a recipient, and
+// asserts that the balance of the pool decreased accordingly.
+type WithdrawLockReleaseLiquidity struct{}
+
+// Apply implements deployment.ChangeSetV2.
+func (s WithdrawLockReleaseLiquidity) Apply(e cldf.Environment, config WithdrawLockReleaseLiquidityConfig) (cldf.ChangesetOutput, error) {
+	suiChain, poolState, pool, err := loadLockReleasePool(e, config.LockReleasePool)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	balanceBefore, err := lockReleasePoolBalance(e, suiChain, poolState, pool, config.CoinType)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+
+	// withdraw_liquidity returns the withdrawn coin, which is transferred to the recipient in the same transaction
+	encodedCall, err := pool.Encoder().WithdrawLiquidity([]string{config.CoinType}, bind.Object{Id: poolState.StateObjectId}, bind.Object{Id: config.RebalancerCapObjectId}, config.Amount)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to encode withdraw_liquidity call: %w", err)
+	}
+	opts := feeQuoterTxDeps(suiChain).GetCallOpts()
+	opts.Signer = suiChain.Signer
+	ptb := transaction.NewTransaction()
+	coin, err := pool.Bound().AppendPTB(e.GetContext(), opts, ptb, encodedCall)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to build withdraw_liquidity transaction: %w", err)
+	}
+	ptb.TransferObjects([]transaction.Argument{*coin}, ptb.Pure(config.Recipient))
+	tx, err := bind.ExecutePTB(e.GetContext(), opts, suiChain.Client, ptb)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to withdraw liquidity from %s pool on sui chain %d: %w", config.TokenSymbol, config.SuiChainSelector, err)
+	}
+
+	if err := assertLockReleasePoolBalance(e, suiChain, poolState, pool, config.LockReleasePool, balanceBefore-config.Amount); err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	e.Logger.Infow("Withdrew liquidity from Sui lock release token pool", "chain", config.SuiChainSelector, "token", config.TokenSymbol, "amount", config.Amount, "recipient", config.Recipient, "digest", tx.Digest)
+	return cldf.ChangesetOutput{}, nil
+}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (s WithdrawLockReleaseLiquidity) VerifyPreconditions(e cldf.Environment, config WithdrawLockReleaseLiquidityConfig) error {
+	if config.Amount == 0 {
+		return errors.New("amount must be positive")
+	}
+	if config.Recipient == "" {
+		return errors.New("recipient must be defined")
+	}
+	if err := validateRebalancerCap(e, config.LockReleasePool, config.RebalancerCapObjectId); err != nil {
+		return err
+	}
+	suiChain, poolState, pool, err := loadLockReleasePool(e, config.LockReleasePool)
+	if err != nil {
+		return err
+	}
+	balance, err := lockReleasePoolBalance(e, suiChain, poolState, pool, config.CoinType)
+	if err != nil {
+		return err
+	}
+	if balance < config.Amount {
+		return fmt.Errorf("%s pool on sui chain %d has a balance of %d, lower than %d", config.TokenSymbol, config.SuiChainSelector, balance, config.Amount)
+	}
+	return nil
+}
+
+type SetLockReleaseRebalancerConfig struct {
+	LockReleasePool
+	// Rebalancer receives a new rebalancer cap of the pool, which invalidates the previous one.
+	Rebalancer string
+}
+
+// SetLockReleaseRebalancer sends a new rebalancer cap of a lock release token pool of a Sui chain to a rebalancer,
+// with the owner cap of the pool held by the Sui signer. The MCMS can't be set as rebalancer with this changeset.
+type SetLockReleaseRebalancer struct{}
+
+// Apply implements deployment.ChangeSetV2.
+func (s SetLockReleaseRebalancer) Apply(e cldf.Environment, config SetLockReleaseRebalancerConfig) (cldf.ChangesetOutput, error) {
+	suiChain, poolState, pool, err := loadLockReleasePool(e, config.LockReleasePool)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	oldRebalancerCap, err := lockReleasePoolRebalancerCap(e, suiChain, poolState, pool, config.CoinType)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+
+	opts := feeQuoterTxDeps(suiChain).GetCallOpts()
+	opts.Signer = suiChain.Signer
+	tx, err := pool.SetRebalancer(e.GetContext(), opts, []string{config.CoinType}, bind.Object{Id: poolState.StateObjectId}, bind.Object{Id: poolState.OwnerCapObjectId}, config.Rebalancer)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to set rebalancer of %s pool on sui chain %d: %w", config.TokenSymbol, config.SuiChainSelector, err)
+	}
+
+	newRebalancerCap, err := lockReleasePoolRebalancerCap(e, suiChain, poolState, pool, config.CoinType)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	if newRebalancerCap == oldRebalancerCap {
+		return cldf.ChangesetOutput{}, fmt.Errorf("rebalancer cap of %s pool on sui chain %d did not change", config.TokenSymbol, config.SuiChainSelector)
+	}
+	e.Logger.Infow("Set rebalancer of Sui lock release token pool", "chain", config.SuiChainSelector, "token", config.TokenSymbol, "rebalancer", config.Rebalancer, "rebalancerCap", newRebalancerCap, "digest", tx.Digest)
+	return cldf.ChangesetOutput{}, nil
+}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (s SetLockReleaseRebalancer) VerifyPreconditions(e cldf.Environment, config SetLockReleaseRebalancerConfig) error {
+	if config.Rebalancer == "" {
+		return errors.New("rebalancer must be defined")
+	}
+	_, poolState, _, err := loadLockReleasePool(e, config.LockReleasePool)
+	if err != nil {
+		return err
+	}
+	if poolState.OwnerCapObjectId == "" {
+		return fmt.Errorf("missing owner cap of %s pool on sui chain %d", config.TokenSymbol, config.SuiChainSelector)
+	}
+	return nil
+}
+
+func loadLockReleasePool(e cldf.Environment, config LockReleasePool) (cldf_sui.Chain, sui_deployment.CCIPPoolState, module_lock_release_token_pool.ILockReleaseTokenPool, error) {
+	if config.TokenSymbol == "" || config.CoinType == "" {
+		return cldf_sui.Chain{}, sui_deployment.CCIPPoolState{}, nil, errors.New("token symbol and coin type must be defined")
+	}
+	suiChain, ok := e.BlockChains.SuiChains()[config.SuiChainSelector]
+	if !ok {
+		return cldf_sui.Chain{}, sui_deployment.CCIPPoolState{}, nil, fmt.Errorf("sui chain %d not found in environment", config.SuiChainSelector)
+	}
+	suiState, err := sui_deployment.LoadOnchainStatesui(e)
+	if err != nil {
+		return cldf_sui.Chain{}, sui_deployment.CCIPPoolState{}, nil, fmt.Errorf("failed to load Sui onchain state: %w", err)
+	}
+	poolState, ok := suiState[config.SuiChainSelector].LnRTokenPools[config.TokenSymbol]
+	if !ok || poolState.PackageID == "" || poolState.StateObjectId == "" {
+		return cldf_sui.Chain{}, sui_deployment.CCIPPoolState{}, nil, fmt.Errorf("missing %s lock release token pool on sui chain %d", config.TokenSymbol, config.SuiChainSelector)
+	}
+	pool, err := module_lock_release_token_pool.NewLockReleaseTokenPool(poolState.PackageID, suiChain.Client)
+	if err != nil {
+		return cldf_sui.Chain{}, sui_deployment.CCIPPoolState{}, nil, fmt.Errorf("failed to create lock release token pool binding: %w", err)
+	}
+	return suiChain, poolState, pool, nil
+}
+
+// validateRebalancerCap checks that the rebalancer cap is the current rebalancer cap of the pool.
+func validateRebalancerCap(e cldf.Environment, config LockReleasePool, rebalancerCapObjectID string) error {
+	if rebalancerCapObjectID == "" {
+		return errors.New("rebalancer cap object ID must be defined")
+	}
+	suiChain, poolState, pool, err := loadLockReleasePool(e, config)
+	if err != nil {
+		return err
+	}
+	rebalancerCap, err := lockReleasePoolRebalancerCap(e, suiChain, poolState, pool, config.CoinType)
+	if err != nil {
+		return err
+	}
+	expected, err := bindutils.ConvertAddressToString(rebalancerCapObjectID)
+	if err != nil {
+		return fmt.Errorf("invalid rebalancer cap object ID %s: %w", rebalancerCapObjectID, err)
+	}
+	if rebalancerCap != expected {
+		return fmt.Errorf("rebalancer cap of %s pool on sui chain %d is %s, not %s", config.TokenSymbol, config.SuiChainSelector, rebalancerCap, rebalancerCapObjectID)
+	}
+	return nil
+}
+
+func lockReleasePoolBalance(e cldf.Environment, suiChain cldf_sui.Chain, poolState sui_deployment.CCIPPoolState, pool module_lock_release_token_pool.ILockReleaseTokenPool, coinType string) (uint64, error) {
+	balance, err := pool.DevInspect().GetBalance(e.GetContext(), &bind.CallOpts{Signer: suiChain.Signer}, []string{coinType}, bind.Object{Id: poolState.StateObjectId})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance of pool %s: %w", poolState.StateObjectId, err)
+	}
+	return balance, nil
+}
+
+func lockReleasePoolRebalancerCap(e cldf.Environment, suiChain cldf_sui.Chain, poolState sui_deployment.CCIPPoolState, pool module_lock_release_token_pool.ILockReleaseTokenPool, coinType string) (string, error) {
+	rebalancerCap, err := pool.DevInspect().GetRebalancer(e.GetContext(), &bind.CallOpts{Signer: suiChain.Signer}, []string{coinType}, bind.Object{Id: poolState.StateObjectId})
+	if err != nil {
+		return "", fmt.Errorf("failed to get rebalancer cap of pool %s: %w", poolState.StateObjectId, err)
+	}
+	return bindutils.ConvertAddressToString(rebalancerCap)
+}
+
+func assertLockReleasePoolBalance(e cldf.Environment, suiChain cldf_sui.Chain, poolState sui_deployment.CCIPPoolState, pool module_lock_release_token_pool.ILockReleaseTokenPool, config LockReleasePool, expected uint64) error {
+	balance, err := lockReleasePoolBalance(e, suiChain, poolState, pool, config.CoinType)
+	if err != nil {
+		return err
+	}
+	if balance != expected {
+		return fmt.Errorf("%s pool on sui chain %d has a balance of %d, expected %d", config.TokenSymbol, config.SuiChainSelector, balance, expected)
+	}
+	return nil
+}
+
+// coinBalance returns the balance of a coin object.
+func coinBalance(e cldf.Environment, suiChain cldf_sui.Chain, coinObjectID string) (uint64, error) {
+	rsp, err := suiChain.Client.SuiGetObject(e.GetContext(), models.SuiGetObjectRequest{
+		ObjectId: coinObjectID,
+		Options:  models.SuiObjectDataOptions{ShowContent: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get coin %s: %w", coinObjectID, err)
+	}
+	if rsp.Data == nil || rsp.Data.Content == nil {
+		return 0, fmt.Errorf("coin %s not found", coinObjectID)
+	}
+	balance, ok := rsp.Data.Content.Fields["balance"].(string)
+	if !ok {
+		return 0, fmt.Errorf("object %s is not a coin", coinObjectID)
+	}
+	amount, err := strconv.ParseUint(balance, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid balance %s of coin %s: %w", balance, coinObjectID, err)
+	}
+	return amount, nil
+}
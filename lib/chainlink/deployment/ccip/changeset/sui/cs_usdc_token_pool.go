@@ -0,0 +1,385 @@
+package sui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/usdc_token_pool"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	suibind "github.com/smartcontractkit/chainlink-sui/bindings/bind"
+	module_usdc_token_pool "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_token_pools/usdc_token_pool"
+	sui_deployment "github.com/smartcontractkit/chainlink-sui/deployment"
+	usdctokenpoolops "github.com/smartcontractkit/chainlink-sui/deployment/ops/ccip_usdc_token_pool"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/deployergroup"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+var (
+	_ cldf.ChangeSetV2[DeployUSDCTokenPoolConfig]           = DeployUSDCTokenPool{}
+	_ cldf.ChangeSetV2[ConfigureUSDCTokenPoolsSuiEVMConfig] = ConfigureUSDCTokenPoolsSuiEVM{}
+)
+
+type DeployUSDCTokenPoolConfig struct {
+	SuiChainSelector uint64
+	// CoinType is the type of USDC on the Sui chain, e.g. 0x...::usdc::USDC.
+	CoinType             string
+	CoinMetadataObjectId string
+	TreasuryObjectId     string
+	// The CCTP packages of the Sui chain the USDC token pool burns and mints USDC through.
+	TokenMessengerMinterPackageId     string
+	TokenMessengerMinterStateObjectId string
+	MessageTransmitterPackageId       string
+	MessageTransmitterStateObjectId   string
+	// LocalDomainIdentifier is the CCTP domain of the Sui chain.
+	LocalDomainIdentifier uint32
+	// SetPool sets the USDC token pool in the token admin registry. The USDC token pool must be registered separately
+	// from its deployment, so the Sui signer must already be the administrator of USDC in the registry.
+	SetPool bool
+}
+
+// DeployUSDCTokenPool deploys and initializes the USDC token pool of a Sui chain, wired to the CCTP packages of the
+// chain, and saves the addresses of the pool and of the CCTP packages to the address book.
+type DeployUSDCTokenPool struct{}
+
+// Apply implements deployment.ChangeSetV2.
+func (s DeployUSDCTokenPool) Apply(e cldf.Environment, config DeployUSDCTokenPoolConfig) (cldf.ChangesetOutput, error) {
+	suiState, err := sui_deployment.LoadOnchainStatesui(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load Sui onchain state: %w", err)
+	}
+	chainState := suiState[config.SuiChainSelector]
+	suiChain := e.BlockChains.SuiChains()[config.SuiChainSelector]
+	deployerAddr, err := suiChain.Signer.GetAddress()
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	deps := feeQuoterTxDeps(suiChain)
+	reports := make([]operations.Report[any, any], 0)
+
+	deployReport, err := operations.ExecuteOperation(e.OperationsBundle, usdctokenpoolops.DeployCCIPUSDCTokenPoolOp, deps, usdctokenpoolops.USDCTokenPoolDeployInput{
+		CCIPPackageId:                     chainState.CCIPAddress,
+		USDCCoinMetadataObjectId:          config.CoinMetadataObjectId,
+		TokenMessengerMinterPackageId:     config.TokenMessengerMinterPackageId,
+		TokenMessengerMinterStateObjectId: config.TokenMessengerMinterStateObjectId,
+		MessageTransmitterPackageId:       config.MessageTransmitterPackageId,
+		MessageTransmitterStateObjectId:   config.MessageTransmitterStateObjectId,
+		TreasuryObjectId:                  config.TreasuryObjectId,
+		MCMSAddress:                       chainState.MCMSPackageID,
+		MCMSOwnerAddress:                  deployerAddr,
+	})
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to deploy USDC token pool on sui chain %d: %w", config.SuiChainSelector, err)
+	}
+	reports = append(reports, deployReport.ToGenericReport())
+	packageID := deployReport.Output.PackageId
+	ownerCapID := deployReport.Output.Objects.OwnerCapObjectId
+
+	initReport, err := operations.ExecuteOperation(e.OperationsBundle, usdctokenpoolops.USDCTokenPoolInitializeOp, deps, usdctokenpoolops.USDCTokenPoolInitializeInput{
+		USDCTokenPoolPackageId: packageID,
+		OwnerCapObjectId:       ownerCapID,
+		CoinObjectTypeArg:      config.CoinType,
+		CoinMetadataObjectId:   config.CoinMetadataObjectId,
+		LocalDomainIdentifier:  config.LocalDomainIdentifier,
+	})
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to initialize USDC token pool on sui chain %d: %w", config.SuiChainSelector, err)
+	}
+	reports = append(reports, initReport.ToGenericReport())
+	stateObjectID := initReport.Output.Objects.StateObjectId
+
+	if config.SetPool {
+		setPoolReport, err := operations.ExecuteOperation(e.OperationsBundle, usdctokenpoolops.USDCTokenPoolSetPoolOp, deps, usdctokenpoolops.USDCTokenPoolSetPoolInput{
+			USDCTokenPoolPackageId: packageID,
+			CoinObjectTypeArg:      config.CoinType,
+			RefObjectId:            chainState.CCIPObjectRef,
+			StateObjectId:          stateObjectID,
+			OwnerCap:               ownerCapID,
+			CoinMetadataAddress:    config.CoinMetadataObjectId,
+		})
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to set USDC token pool in the token admin registry of sui chain %d: %w", config.SuiChainSelector, err)
+		}
+		reports = append(reports, setPoolReport.ToGenericReport())
+	}
+
+	ab := cldf.NewMemoryAddressBook()
+	for addr, contractType := range map[string]cldf.ContractType{
+		packageID:                                SuiUSDCTokenPoolType,
+		stateObjectID:                            SuiUSDCTokenPoolStateType,
+		ownerCapID:                               SuiUSDCTokenPoolOwnerIDType,
+		config.CoinMetadataObjectId:              SuiUSDCCoinMetadataType,
+		config.TreasuryObjectId:                  SuiUSDCTreasuryType,
+		config.TokenMessengerMinterPackageId:     SuiCCTPTokenMessengerMinterType,
+		config.TokenMessengerMinterStateObjectId: SuiCCTPTokenMessengerMinterStateType,
+		config.MessageTransmitterPackageId:       SuiCCTPMessageTransmitterType,
+		config.MessageTransmitterStateObjectId:   SuiCCTPMessageTransmitterStateType,
+	} {
+		if err := ab.Save(config.SuiChainSelector, addr, cldf.NewTypeAndVersion(contractType, sui_deployment.Version1_0_0)); err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to save %s address %s for sui chain %d: %w", contractType, addr, config.SuiChainSelector, err)
+		}
+	}
+	e.Logger.Infow("Deployed Sui USDC token pool", "chain", config.SuiChainSelector, "package", packageID, "state", stateObjectID)
+
+	return cldf.ChangesetOutput{
+		AddressBook: ab,
+		Reports:     reports,
+	}, nil
+}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (s DeployUSDCTokenPool) VerifyPreconditions(e cldf.Environment, config DeployUSDCTokenPoolConfig) error {
+	if _, ok := e.BlockChains.SuiChains()[config.SuiChainSelector]; !ok {
+		return fmt.Errorf("sui chain %d not found in environment", config.SuiChainSelector)
+	}
+	if config.CoinType == "" {
+		return errors.New("USDC coin type must be defined")
+	}
+	for name, id := range map[string]string{
+		"coin metadata":                config.CoinMetadataObjectId,
+		"treasury":                     config.TreasuryObjectId,
+		"token messenger minter":       config.TokenMessengerMinterPackageId,
+		"token messenger minter state": config.TokenMessengerMinterStateObjectId,
+		"message transmitter":          config.MessageTransmitterPackageId,
+		"message transmitter state":    config.MessageTransmitterStateObjectId,
+	} {
+		if id == "" {
+			return fmt.Errorf("USDC %s object ID must be defined", name)
+		}
+	}
+	suiState, err := sui_deployment.LoadOnchainStatesui(e)
+	if err != nil {
+		return fmt.Errorf("failed to load Sui onchain state: %w", err)
+	}
+	chainState := suiState[config.SuiChainSelector]
+	if chainState.CCIPAddress == "" || chainState.CCIPObjectRef == "" {
+		return fmt.Errorf("missing CCIP package for sui chain %d", config.SuiChainSelector)
+	}
+	usdcStates, err := LoadUSDCChainStates(e)
+	if err != nil {
+		return err
+	}
+	if usdcStates[config.SuiChainSelector].TokenPoolPackageId != "" {
+		return fmt.Errorf("USDC token pool already deployed on sui chain %d", config.SuiChainSelector)
+	}
+	return nil
+}
+
+type ConfigureUSDCTokenPoolsSuiEVMConfig struct {
+	SuiChainSelector uint64
+	// CoinType is the type of USDC on the Sui chain.
+	CoinType string
+	// SuiDomainIdentifier is the CCTP domain of the Sui chain.
+	SuiDomainIdentifier uint32
+	// SuiAllowedCaller is the address allowed to receive the CCTP messages of the EVM chains on the Sui chain, the
+	// USDC token pool of the Sui chain if empty.
+	SuiAllowedCaller string
+	// EVMDomainIdentifiers are the CCTP domains of the EVM chains to connect, by chain selector.
+	EVMDomainIdentifiers map[uint64]uint32
+	// EVMPoolVersion is the version of the USDC token pools of the EVM chains, 1.5.1 if empty.
+	EVMPoolVersion *semver.Version
+	// MCMS defines the delay to use for Timelock on the EVM chains (if absent, the changeset will attempt to use the
+	// deployer key). The USDC token pool of the Sui chain is configured with its owner cap held by the Sui signer.
+	MCMS *proposalutils.TimelockConfig
+}
+
+func (c ConfigureUSDCTokenPoolsSuiEVMConfig) evmPoolVersion() semver.Version {
+	if c.EVMPoolVersion == nil {
+		return deployment.Version1_5_1
+	}
+	return *c.EVMPoolVersion
+}
+
+// ConfigureUSDCTokenPoolsSuiEVM connects the USDC token pool of a Sui chain with the USDC token pools of EVM chains:
+// each pool adds the other as remote pool, and the CCTP domain of the other chain with its allowed caller. Chains
+// already supported by a pool are not added again, their domains are updated.
+type ConfigureUSDCTokenPoolsSuiEVM struct{}
+
+// Apply implements deployment.ChangeSetV2.
+func (s ConfigureUSDCTokenPoolsSuiEVM) Apply(e cldf.Environment, config ConfigureUSDCTokenPoolsSuiEVMConfig) (cldf.ChangesetOutput, error) {
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	usdcStates, err := LoadUSDCChainStates(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	usdcState := usdcStates[config.SuiChainSelector]
+	suiChain := e.BlockChains.SuiChains()[config.SuiChainSelector]
+	suiPool, err := module_usdc_token_pool.NewUsdcTokenPool(usdcState.TokenPoolPackageId, suiChain.Client)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to create USDC token pool binding for sui chain %d: %w", config.SuiChainSelector, err)
+	}
+	suiAllowedCaller := config.SuiAllowedCaller
+	if suiAllowedCaller == "" {
+		suiAllowedCaller = usdcState.TokenPoolPackageId
+	}
+	suiAllowedCallerBytes, err := sui_deployment.StrTo32(suiAllowedCaller)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("invalid sui allowed caller %s: %w", suiAllowedCaller, err)
+	}
+	suiPoolAddress, err := sui_deployment.StrTo32(usdcState.TokenPoolPackageId)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("invalid USDC token pool package %s: %w", usdcState.TokenPoolPackageId, err)
+	}
+	suiTokenAddress, err := sui_deployment.StrTo32(usdcState.CoinMetadataObjectId)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("invalid USDC coin metadata %s: %w", usdcState.CoinMetadataObjectId, err)
+	}
+
+	ctx := e.GetContext()
+	readOpts := &bind.CallOpts{Context: ctx}
+	suiReadOpts := &suibind.CallOpts{Signer: suiChain.Signer}
+	suiTypeArgs := []string{config.CoinType}
+	suiPoolState := suibind.Object{Id: usdcState.TokenPoolStateObjectId}
+	deployerGroup := deployergroup.NewDeployerGroup(e, state, config.MCMS).WithDeploymentContext(fmt.Sprintf("connect USDC token pools to sui chain %d", config.SuiChainSelector))
+
+	var (
+		suiChainsToAdd     []uint64
+		suiRemotePools     [][][]byte
+		suiRemoteTokens    [][]byte
+		suiDomainChains    []uint64
+		suiDomains         []uint32
+		suiAllowedCallers  [][]byte
+		suiDomainsEnableds []bool
+	)
+	for _, evmChainSelector := range slices.Sorted(maps.Keys(config.EVMDomainIdentifiers)) {
+		evmPool := state.Chains[evmChainSelector].USDCTokenPools[config.evmPoolVersion()]
+		evmToken, err := evmPool.GetToken(readOpts)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to get token of USDC token pool %s on chain %d: %w", evmPool.Address(), evmChainSelector, err)
+		}
+
+		// EVM side, the sui chain is added with disabled rate limits
+		writeOpts, err := deployerGroup.GetDeployer(evmChainSelector)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to get transaction opts for chain %d: %w", evmChainSelector, err)
+		}
+		supported, err := evmPool.IsSupportedChain(readOpts, config.SuiChainSelector)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to check support of sui chain %d on USDC token pool %s: %w", config.SuiChainSelector, evmPool.Address(), err)
+		}
+		if !supported {
+			disabled := usdc_token_pool.RateLimiterConfig{Capacity: big.NewInt(0), Rate: big.NewInt(0)}
+			if _, err := evmPool.ApplyChainUpdates(writeOpts, []uint64{}, []usdc_token_pool.TokenPoolChainUpdate{{
+				RemoteChainSelector:       config.SuiChainSelector,
+				RemotePoolAddresses:       [][]byte{suiPoolAddress},
+				RemoteTokenAddress:        suiTokenAddress,
+				OutboundRateLimiterConfig: disabled,
+				InboundRateLimiterConfig:  disabled,
+			}}); err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to create apply chain updates operation on chain %d: %w", evmChainSelector, err)
+			}
+		}
+		if _, err := evmPool.SetDomains(writeOpts, []usdc_token_pool.USDCTokenPoolDomainUpdate{{
+			AllowedCaller:     [32]byte(suiAllowedCallerBytes),
+			DomainIdentifier:  config.SuiDomainIdentifier,
+			DestChainSelector: config.SuiChainSelector,
+			Enabled:           true,
+		}}); err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to create set domains operation on chain %d: %w", evmChainSelector, err)
+		}
+
+		// Sui side
+		supported, err = suiPool.DevInspect().IsSupportedChain(ctx, suiReadOpts, suiTypeArgs, suiPoolState, evmChainSelector)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to check support of chain %d on sui chain %d: %w", evmChainSelector, config.SuiChainSelector, err)
+		}
+		if !supported {
+			suiChainsToAdd = append(suiChainsToAdd, evmChainSelector)
+			suiRemotePools = append(suiRemotePools, [][]byte{evmPool.Address().Bytes()})
+			suiRemoteTokens = append(suiRemoteTokens, common.LeftPadBytes(evmToken.Bytes(), 32))
+		}
+		suiDomainChains = append(suiDomainChains, evmChainSelector)
+		suiDomains = append(suiDomains, config.EVMDomainIdentifiers[evmChainSelector])
+		suiAllowedCallers = append(suiAllowedCallers, common.LeftPadBytes(evmPool.Address().Bytes(), 32))
+		suiDomainsEnableds = append(suiDomainsEnableds, true)
+	}
+
+	deps := feeQuoterTxDeps(suiChain)
+	if len(suiChainsToAdd) > 0 {
+		// the apply chain updates operation of the USDC token pool passes the addresses as strings instead of decoding
+		// them, so the binding is called directly
+		opts := deps.GetCallOpts()
+		opts.Signer = suiChain.Signer
+		if _, err := suiPool.ApplyChainUpdates(ctx, opts, suiTypeArgs, suiPoolState, suibind.Object{Id: usdcState.TokenPoolOwnerCapObjectId},
+			[]uint64{}, suiChainsToAdd, suiRemotePools, suiRemoteTokens); err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to add chains %v to USDC token pool on sui chain %d: %w", suiChainsToAdd, config.SuiChainSelector, err)
+		}
+	}
+	setDomainsReport, err := operations.ExecuteOperation(e.OperationsBundle, usdctokenpoolops.USDCTokenPoolSetDomainsOp, deps, usdctokenpoolops.USDCTokenPoolSetDomainsInput{
+		USDCTokenPoolPackageId:  usdcState.TokenPoolPackageId,
+		CoinObjectTypeArg:       config.CoinType,
+		StateObjectId:           usdcState.TokenPoolStateObjectId,
+		OwnerCap:                usdcState.TokenPoolOwnerCapObjectId,
+		RemoteChainSelectors:    suiDomainChains,
+		RemoteDomainIdentifiers: suiDomains,
+		AllowedRemoteCallers:    suiAllowedCallers,
+		Enableds:                suiDomainsEnableds,
+	})
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to set domains of USDC token pool on sui chain %d: %w", config.SuiChainSelector, err)
+	}
+	e.Logger.Infow("Configured Sui USDC token pool", "chain", config.SuiChainSelector, "addedChains", suiChainsToAdd, "domains", suiDomainChains)
+
+	out, err := deployerGroup.Enact()
+	if err != nil {
+		return out, err
+	}
+	out.Reports = append(out.Reports, setDomainsReport.ToGenericReport())
+	return out, nil
+}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (s ConfigureUSDCTokenPoolsSuiEVM) VerifyPreconditions(e cldf.Environment, config ConfigureUSDCTokenPoolsSuiEVMConfig) error {
+	if _, ok := e.BlockChains.SuiChains()[config.SuiChainSelector]; !ok {
+		return fmt.Errorf("sui chain %d not found in environment", config.SuiChainSelector)
+	}
+	if config.CoinType == "" {
+		return errors.New("USDC coin type must be defined")
+	}
+	if len(config.EVMDomainIdentifiers) == 0 {
+		return errors.New("no EVM chains provided")
+	}
+	usdcStates, err := LoadUSDCChainStates(e)
+	if err != nil {
+		return err
+	}
+	usdcState := usdcStates[config.SuiChainSelector]
+	if usdcState.TokenPoolPackageId == "" || usdcState.TokenPoolStateObjectId == "" || usdcState.TokenPoolOwnerCapObjectId == "" {
+		return fmt.Errorf("missing USDC token pool on sui chain %d", config.SuiChainSelector)
+	}
+	if usdcState.CoinMetadataObjectId == "" {
+		return fmt.Errorf("missing USDC coin metadata on sui chain %d", config.SuiChainSelector)
+	}
+
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	version := config.evmPoolVersion()
+	for evmChainSelector := range config.EVMDomainIdentifiers {
+		if _, ok := e.BlockChains.EVMChains()[evmChainSelector]; !ok {
+			return fmt.Errorf("chain %d is not an EVM chain of the environment", evmChainSelector)
+		}
+		if _, ok := state.Chains[evmChainSelector].USDCTokenPools[version]; !ok {
+			return fmt.Errorf("no USDC token pool found on chain %d with version %s", evmChainSelector, version)
+		}
+		if config.MCMS != nil && state.Chains[evmChainSelector].Timelock == nil {
+			return fmt.Errorf("missing timelock on chain %d", evmChainSelector)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package sui
+
+import (
+	"errors"
+	"fmt"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// The contract types of the USDC token pool of Sui chains and of the CCTP packages it is wired to. Unlike the other
+// Sui token pools, they are not part of the Sui onchain state and are loaded with LoadUSDCChainStates.
+const (
+	SuiUSDCTokenPoolType                 cldf.ContractType = "SuiUSDCTokenPool"
+	SuiUSDCTokenPoolStateType            cldf.ContractType = "SuiUSDCTokenPoolState"
+	SuiUSDCTokenPoolOwnerIDType          cldf.ContractType = "SuiUSDCTokenPoolOwnerID"
+	SuiUSDCCoinMetadataType              cldf.ContractType = "SuiUSDCCoinMetadata"
+	SuiUSDCTreasuryType                  cldf.ContractType = "SuiUSDCTreasury"
+	SuiCCTPTokenMessengerMinterType      cldf.ContractType = "SuiCCTPTokenMessengerMinter"
+	SuiCCTPTokenMessengerMinterStateType cldf.ContractType = "SuiCCTPTokenMessengerMinterState"
+	SuiCCTPMessageTransmitterType        cldf.ContractType = "SuiCCTPMessageTransmitter"
+	SuiCCTPMessageTransmitterStateType   cldf.ContractType = "SuiCCTPMessageTransmitterState"
+)
+
+// USDCChainState is the state of the USDC token pool of a Sui chain.
+type USDCChainState struct {
+	TokenPoolPackageId        string
+	TokenPoolStateObjectId    string
+	TokenPoolOwnerCapObjectId string
+	CoinMetadataObjectId      string
+	TreasuryObjectId          string
+
+	TokenMessengerMinterPackageId     string
+	TokenMessengerMinterStateObjectId string
+	MessageTransmitterPackageId       string
+	MessageTransmitterStateObjectId   string
+}
+
+// LoadUSDCChainStates loads the state of the USDC token pools of the Sui chains of the environment. Chains without a
+// USDC token pool have an empty state.
+func LoadUSDCChainStates(e cldf.Environment) (map[uint64]USDCChainState, error) {
+	states := make(map[uint64]USDCChainState)
+	for chainSelector := range e.BlockChains.SuiChains() {
+		addresses, err := e.ExistingAddresses.AddressesForChain(chainSelector)
+		if err != nil {
+			if !errors.Is(err, cldf.ErrChainNotFound) {
+				return nil, fmt.Errorf("failed to get addresses for sui chain %d: %w", chainSelector, err)
+			}
+			addresses = make(map[string]cldf.TypeAndVersion)
+		}
+
+		var state USDCChainState
+		for addr, typeAndVersion := range addresses {
+			switch typeAndVersion.Type {
+			case SuiUSDCTokenPoolType:
+				state.TokenPoolPackageId = addr
+			case SuiUSDCTokenPoolStateType:
+				state.TokenPoolStateObjectId = addr
+			case SuiUSDCTokenPoolOwnerIDType:
+				state.TokenPoolOwnerCapObjectId = addr
+			case SuiUSDCCoinMetadataType:
+				state.CoinMetadataObjectId = addr
+			case SuiUSDCTreasuryType:
+				state.TreasuryObjectId = addr
+			case SuiCCTPTokenMessengerMinterType:
+				state.TokenMessengerMinterPackageId = addr
+			case SuiCCTPTokenMessengerMinterStateType:
+				state.TokenMessengerMinterStateObjectId = addr
+			case SuiCCTPMessageTransmitterType:
+				state.MessageTransmitterPackageId = addr
+			case SuiCCTPMessageTransmitterStateType:
+				state.MessageTransmitterStateObjectId = addr
+			}
+		}
+		states[chainSelector] = state
+	}
+	return states, nil
+}
@@ -477,6 +477,7 @@ func ConfirmCommitWithExpectedSeqNumRange(
 	defer timeout.Stop()
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
+	var rpcErrors rpcErrorBudget
 	for {
 		select {
 		case <-t.Context().Done():
@@ -490,10 +491,15 @@ func ConfirmCommitWithExpectedSeqNumRange(
 				Context: t.Context(),
 			})
 
-			// In some test case the test ends while the filter is still running resulting in a context.Canceled error.
-			if err != nil && !errors.Is(err, context.Canceled) {
-				require.NoError(t, err)
+			if err != nil {
+				// In some test case the test ends while the filter is still running resulting in a context.Canceled error.
+				if !errors.Is(err, context.Canceled) {
+					require.False(t, rpcErrors.exhausted(), "failed to filter CommitReportAccepted: %v", err)
+					t.Logf("Failed to filter CommitReportAccepted, retrying: %v", err)
+				}
+				continue
 			}
+			rpcErrors.reset()
 			for iter.Next() {
 				event := iter.Event
 				verified := verifyCommitReport(event)
@@ -527,6 +533,8 @@ func SolEventEmitter[T any](ctx context.Context, client *solrpc.Client, address
 	go func() {
 		defer ticker.Stop()
 		var until solana.Signature
+		var rpcErrors rpcErrorBudget
+	scan:
 		for {
 			select {
 			case <-done:
@@ -542,11 +550,15 @@ func SolEventEmitter[T any](ctx context.Context, client *solrpc.Client, address
 					},
 				)
 				if err != nil {
-					errorCh <- err
-					return
+					if rpcErrors.exhausted() {
+						errorCh <- err
+						return
+					}
+					continue // retried on the next tick
 				}
 
 				if len(txSigs) == 0 {
+					rpcErrors.reset()
 					continue
 				}
 
@@ -571,8 +583,12 @@ func SolEventEmitter[T any](ctx context.Context, client *solrpc.Client, address
 						},
 					)
 					if err != nil {
-						errorCh <- err
-						return
+						if rpcErrors.exhausted() {
+							errorCh <- err
+							return
+						}
+						// the signatures are scanned again on the next tick
+						continue scan
 					}
 
 					events, err := solcommon.ParseMultipleEvents[T](tx.Meta.LogMessages, eventType, solconfig.PrintEvents)
@@ -597,6 +613,7 @@ func SolEventEmitter[T any](ctx context.Context, client *solrpc.Client, address
 				}
 				// next scan should stop at the newest signature we've received
 				until = txSigs[0].Signature
+				rpcErrors.reset()
 			}
 		}
 	}()
@@ -678,6 +695,7 @@ func AptosEventEmitter[T any](
 		ticker := time.NewTicker(time.Second * 2)
 		defer ticker.Stop()
 
+		var rpcErrors rpcErrorBudget
 		for {
 			for {
 				// As this can take a few iterations if there are many events, check for done before each request
@@ -688,9 +706,13 @@ func AptosEventEmitter[T any](
 				}
 				events, err := client.EventsByHandle(address, eventHandle, fieldname, &seqNum, &limit)
 				if err != nil {
-					errChan <- err
-					return
+					if rpcErrors.exhausted() {
+						errChan <- err
+						return
+					}
+					break // retried on the next tick
 				}
+				rpcErrors.reset()
 				if len(events) == 0 {
 					// No new events found
 					break
@@ -944,11 +966,18 @@ func ConfirmExecWithSeqNrs(
 	for _, seqNr := range expectedSeqNrs {
 		seqNrsToWatch[seqNr] = struct{}{}
 	}
+	var rpcErrors rpcErrorBudget
 	for {
 		select {
 		case <-tick.C:
 			for expectedSeqNr := range seqNrsToWatch {
-				scc, executionState := getExecutionState(t, sourceSelector, offRamp, expectedSeqNr)
+				scc, executionState, err := getExecutionState(sourceSelector, offRamp, expectedSeqNr)
+				if err != nil {
+					require.False(t, rpcErrors.exhausted(), "failed to read the execution state of sequence number %d: %v", expectedSeqNr, err)
+					t.Logf("Failed to read the execution state of sequence number %d, retrying: %v", expectedSeqNr, err)
+					break
+				}
+				rpcErrors.reset()
 				t.Logf("Waiting for ExecutionStateChanged on chain %d (offramp %s) from chain %d with expected sequence number %d, current onchain minSeqNr: %d, execution state: %s",
 					dest.Selector, offRamp.Address().String(), sourceSelector, expectedSeqNr, scc.MinSeqNr, executionStateToString(executionState))
 				if executionState == EXECUTION_STATE_SUCCESS || executionState == EXECUTION_STATE_FAILURE {
@@ -1205,8 +1234,15 @@ func ConfirmNoExecConsistentlyWithSeqNr(
 	expectedSeqNr uint64,
 	timeout time.Duration,
 ) {
+	var rpcErrors rpcErrorBudget
 	RequireConsistently(t, func() bool {
-		scc, executionState := getExecutionState(t, sourceSelector, offRamp, expectedSeqNr)
+		scc, executionState, err := getExecutionState(sourceSelector, offRamp, expectedSeqNr)
+		if err != nil {
+			require.False(t, rpcErrors.exhausted(), "failed to read the execution state of sequence number %d: %v", expectedSeqNr, err)
+			t.Logf("Failed to read the execution state of sequence number %d, retrying: %v", expectedSeqNr, err)
+			return true
+		}
+		rpcErrors.reset()
 		t.Logf("Waiting for ExecutionStateChanged on chain %d (offramp %s) from chain %d with expected sequence number %d, current onchain minSeqNr: %d, execution state: %s",
 			dest.Selector, offRamp.Address().String(), sourceSelector, expectedSeqNr, scc.MinSeqNr, executionStateToString(executionState))
 		if executionState == EXECUTION_STATE_UNTOUCHED {
@@ -1226,8 +1262,15 @@ func ConfirmNoExecSuccessConsistentlyWithSeqNr(
 	expectedSeqNr uint64,
 	timeout time.Duration,
 ) {
+	var rpcErrors rpcErrorBudget
 	RequireConsistently(t, func() bool {
-		scc, executionState := getExecutionState(t, sourceSelector, offRamp, expectedSeqNr)
+		scc, executionState, err := getExecutionState(sourceSelector, offRamp, expectedSeqNr)
+		if err != nil {
+			require.False(t, rpcErrors.exhausted(), "failed to read the execution state of sequence number %d: %v", expectedSeqNr, err)
+			t.Logf("Failed to read the execution state of sequence number %d, retrying: %v", expectedSeqNr, err)
+			return true
+		}
+		rpcErrors.reset()
 		t.Logf("Waiting for ExecutionStateChanged on chain %d (offramp %s) from chain %d with expected sequence number %d, current onchain minSeqNr: %d, execution state: %s",
 			dest.Selector, offRamp.Address().String(), sourceSelector, expectedSeqNr, scc.MinSeqNr, executionStateToString(executionState))
 		if executionState == EXECUTION_STATE_SUCCESS {
@@ -1239,12 +1282,36 @@ func ConfirmNoExecSuccessConsistentlyWithSeqNr(
 	}, timeout, 3*time.Second, "Expected no execution success on chain %d (offramp %s) from chain %d with expected sequence number %d", dest.Selector, offRamp.Address().String(), sourceSelector, expectedSeqNr)
 }
 
-func getExecutionState(t *testing.T, sourceSelector uint64, offRamp offramp.OffRampInterface, expectedSeqNr uint64) (offramp.OffRampSourceChainConfig, uint8) {
+func getExecutionState(sourceSelector uint64, offRamp offramp.OffRampInterface, expectedSeqNr uint64) (offramp.OffRampSourceChainConfig, uint8, error) {
 	scc, err := offRamp.GetSourceChainConfig(nil, sourceSelector)
-	require.NoError(t, err)
+	if err != nil {
+		return offramp.OffRampSourceChainConfig{}, 0, fmt.Errorf("failed to get source chain config: %w", err)
+	}
 	executionState, err := offRamp.GetExecutionState(nil, sourceSelector, expectedSeqNr)
-	require.NoError(t, err)
-	return scc, executionState
+	if err != nil {
+		return offramp.OffRampSourceChainConfig{}, 0, fmt.Errorf("failed to get execution state: %w", err)
+	}
+	return scc, executionState, nil
+}
+
+// maxConsecutiveRPCErrors is the number of consecutive failed RPC reads tolerated by the Confirm* helpers while
+// polling. A failed read is retried on the next poll, so that a flaky RPC doesn't fail the whole test.
+const maxConsecutiveRPCErrors = 5
+
+// rpcErrorBudget counts the consecutive failed RPC reads of a poller.
+type rpcErrorBudget struct {
+	failed int
+}
+
+// exhausted records the failed read and returns whether the poller should give up.
+func (b *rpcErrorBudget) exhausted() bool {
+	b.failed++
+	return b.failed >= maxConsecutiveRPCErrors
+}
+
+// reset records a successful read.
+func (b *rpcErrorBudget) reset() {
+	b.failed = 0
 }
 
 func RequireConsistently(t *testing.T, condition func() bool, duration time.Duration, tick time.Duration, msgAndArgs ...any) {
@@ -1385,6 +1452,7 @@ func SuiEventEmitter[T any](
 		ticker := time.NewTicker(time.Second * 2)
 		defer ticker.Stop()
 
+		var rpcErrors rpcErrorBudget
 		for {
 			for {
 				// As this can take a few iterations if there are many events, check for done before each request
@@ -1403,9 +1471,13 @@ func SuiEventEmitter[T any](
 					DescendingOrder: false,
 				})
 				if err != nil {
-					errChan <- err
-					return
+					if rpcErrors.exhausted() {
+						errChan <- err
+						return
+					}
+					break // retried on the next tick
 				}
+				rpcErrors.reset()
 
 				if len(events.Data) == 0 {
 					// No new events found
@@ -0,0 +1,95 @@
+package testhelpers
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	module_onramp "github.com/smartcontractkit/chainlink-aptos/bindings/ccip_onramp/onramp"
+	"github.com/smartcontractkit/chainlink-aptos/relayer/codec"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/onramp"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	sui_module_onramp "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_onramp/onramp"
+
+	ccipclient "github.com/smartcontractkit/chainlink/deployment/ccip/shared/client"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+)
+
+// SendRequests sends a batch of requests, possibly across lanes, each configured by its options as for SendRequest.
+// At most concurrency requests are in flight, all of them if concurrency <= 0. Requests from the same source chain
+// and sender are sent one at a time, so that their nonces do not conflict.
+// The results are in the order of the requests, failed requests have their error set.
+func SendRequests(
+	e cldf.Environment,
+	state stateview.CCIPOnChainState,
+	concurrency int,
+	requests ...[]ccipclient.SendReqOpts,
+) ccipclient.SendResults {
+	results := make(ccipclient.SendResults, len(requests))
+	senders := make(map[string]*sync.Mutex)
+	senderOf := make([]*sync.Mutex, len(requests))
+	for i, opts := range requests {
+		cfg := &ccipclient.CCIPSendReqConfig{}
+		for _, opt := range opts {
+			opt(cfg)
+		}
+		results[i] = ccipclient.SendResult{Index: i, SourceChain: cfg.SourceChain, DestChain: cfg.DestChain}
+
+		// The deployer key is used when no sender is set, and is the only sender of non-EVM chains
+		key := fmt.Sprint(cfg.SourceChain)
+		if cfg.Sender != nil {
+			key += "/" + cfg.Sender.From.String()
+		}
+		if _, ok := senders[key]; !ok {
+			senders[key] = &sync.Mutex{}
+		}
+		senderOf[i] = senders[key]
+	}
+
+	var grp errgroup.Group
+	if concurrency > 0 {
+		grp.SetLimit(concurrency)
+	}
+	for i, opts := range requests {
+		grp.Go(func() error {
+			senderOf[i].Lock()
+			defer senderOf[i].Unlock()
+
+			res := &results[i]
+			res.Event, res.Err = SendRequest(e, state, opts...)
+			if res.Err != nil {
+				e.Logger.Errorw("Failed to send CCIP request", "index", i,
+					"sourceChain", res.SourceChain, "destChain", res.DestChain, "err", res.Err)
+				return nil
+			}
+			res.SequenceNumber = res.Event.SequenceNumber
+			res.MessageID, res.Fee, res.Err = messageSentInfo(res.Event)
+			return nil
+		})
+	}
+	_ = grp.Wait() // errors are reported per request
+	return results
+}
+
+// messageSentInfo returns the message ID and the fee paid of a message sent event, as returned by SendRequest.
+// They are left unset for the events of TON, which has no typed event yet.
+func messageSentInfo(event *ccipclient.AnyMsgSentEvent) (msgID [32]byte, fee *big.Int, err error) {
+	switch raw := event.RawEvent.(type) {
+	case *onramp.OnRampCCIPMessageSent: // EVM and Solana
+		return raw.Message.Header.MessageId, raw.Message.FeeTokenAmount, nil
+	case module_onramp.CCIPMessageSent: // Aptos
+		copy(msgID[:], raw.Message.Header.MessageId)
+		return msgID, new(big.Int).SetUint64(raw.Message.FeeTokenAmount), nil
+	case map[string]any: // Sui
+		var msgSent sui_module_onramp.CCIPMessageSent
+		if err = codec.DecodeAptosJsonValue(raw, &msgSent); err != nil {
+			return msgID, nil, fmt.Errorf("failed to decode Sui CCIPMessageSent event: %w", err)
+		}
+		copy(msgID[:], msgSent.Message.Header.MessageId)
+		return msgID, new(big.Int).SetUint64(msgSent.Message.FeeTokenAmount), nil
+	default:
+		return msgID, nil, nil
+	}
+}
@@ -0,0 +1,172 @@
+package testhelpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	ccipclient "github.com/smartcontractkit/chainlink/deployment/ccip/shared/client"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+)
+
+// PickNodes returns n of the non-bootstrap nodes, ordered by ID so that the same nodes are picked across runs.
+func PickNodes(t *testing.T, nodes map[string]memory.Node, n int) []memory.Node {
+	var picked []memory.Node
+	for _, node := range nodes {
+		if !node.IsBoostrap {
+			picked = append(picked, node)
+		}
+	}
+	require.GreaterOrEqual(t, len(picked), n, "not enough non-bootstrap nodes")
+	slices.SortFunc(picked, func(a, b memory.Node) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return picked[:n]
+}
+
+// StopNodes stops the nodes, e.g. to simulate a crash of part of the DON. The nodes are stopped concurrently.
+func StopNodes(nodes []memory.Node) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(nodes))
+	for i, node := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = node.Stop()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// RestartNodes starts new applications of the nodes, stopping the nodes which are still running first. The nodes
+// resume their jobs from their database. The nodes are restarted concurrently.
+func RestartNodes(ctx context.Context, nodes []memory.Node) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(nodes))
+	for i, node := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = node.Restart(ctx)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// NodeOutage is an outage of some nodes of the DON.
+type NodeOutage struct {
+	// Nodes are the nodes stopped, e.g. picked with PickNodes. Stopping more than f nodes of a DON stalls it until
+	// quorum is restored.
+	Nodes []memory.Node
+	// After is the delay before the nodes are stopped, e.g. to stop them once messages are in flight.
+	After time.Duration
+	// Downtime is how long the nodes stay stopped before they are restarted.
+	Downtime time.Duration
+}
+
+// RunNodeOutage runs the outage in the background: it stops the nodes after the delay, and restarts them after the
+// downtime. The returned function waits until the nodes are restarted, and returns the error of the outage. If ctx is
+// done during the outage the nodes are restarted right away.
+func RunNodeOutage(ctx context.Context, outage NodeOutage) (wait func() error) {
+	done := make(chan error, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			done <- ctx.Err()
+			return
+		case <-time.After(outage.After):
+		}
+		if err := StopNodes(outage.Nodes); err != nil {
+			done <- fmt.Errorf("failed to stop nodes: %w", err)
+			return
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(outage.Downtime):
+		}
+		// The nodes are restarted even if ctx is done, so that the environment is cleaned up as usual
+		if err := RestartNodes(context.WithoutCancel(ctx), outage.Nodes); err != nil {
+			done <- fmt.Errorf("failed to restart nodes: %w", err)
+			return
+		}
+		done <- nil
+	}()
+	var once sync.Once
+	var err error
+	return func() error {
+		once.Do(func() {
+			err = <-done
+		})
+		return err
+	}
+}
+
+// InFlightMessage is a message sent before or during an outage.
+type InFlightMessage struct {
+	SourceChain uint64
+	DestChain   uint64
+	MessageID   [32]byte
+}
+
+// RequireMessagesRecovered waits until the outage is over, then requires that all the messages are committed and
+// executed successfully within the timeout, i.e. that the DONs recovered once quorum was restored. The messages are
+// followed with TrackMessage, so EVM, Solana and Sui destinations are supported.
+func RequireMessagesRecovered(
+	t *testing.T,
+	e cldf.Environment,
+	state stateview.CCIPOnChainState,
+	waitOutage func() error,
+	msgs []InFlightMessage,
+	timeout time.Duration,
+	opts ...TrackMessageOption,
+) {
+	require.NoError(t, waitOutage(), "node outage failed")
+
+	ctx, cancel := context.WithTimeout(t.Context(), timeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	finals := make([]ccipclient.MessageStatusUpdate, len(msgs))
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		updates, errCh := TrackMessage(ctx, e, state, msg.SourceChain, msg.DestChain, msg.MessageID, opts...)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var committed bool
+			for update := range updates {
+				switch update.Status {
+				case ccipclient.MessageCommitted:
+					committed = true
+				case ccipclient.MessageExecutionSuccess, ccipclient.MessageExecutionFailure:
+					finals[i] = update
+				}
+			}
+			select {
+			case errs[i] = <-errCh:
+			default:
+			}
+			if errs[i] == nil && !committed {
+				errs[i] = ctx.Err()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, msg := range msgs {
+		require.NoError(t, errs[i], "message %x from chain %d to chain %d was not committed after the outage", msg.MessageID, msg.SourceChain, msg.DestChain)
+		require.Equal(t, ccipclient.MessageExecutionSuccess, finals[i].Status, "message %x from chain %d to chain %d was not executed after the outage", msg.MessageID, msg.SourceChain, msg.DestChain)
+		t.Logf("Message %x from chain %d to chain %d recovered, executed in %s", msg.MessageID, msg.SourceChain, msg.DestChain, finals[i].Tx)
+	}
+}
@@ -22,7 +22,6 @@ import (
 	solanago "github.com/gagliardetto/solana-go"
 
 	ops "github.com/smartcontractkit/chainlink-ton/deployment/ccip"
-	tonOperation "github.com/smartcontractkit/chainlink-ton/deployment/ccip/operation"
 
 	chain_selectors "github.com/smartcontractkit/chain-selectors"
 	mcmstypes "github.com/smartcontractkit/mcms/types"
@@ -47,8 +46,8 @@ import (
 	sui_cs "github.com/smartcontractkit/chainlink-sui/deployment/changesets"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
 	aptoscs "github.com/smartcontractkit/chainlink/deployment/ccip/changeset/aptos"
-	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/internal"
 	sui_cs_core "github.com/smartcontractkit/chainlink/deployment/ccip/changeset/sui"
+	ton_cs_core "github.com/smartcontractkit/chainlink/deployment/ccip/changeset/ton"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_6"
 	ccipops "github.com/smartcontractkit/chainlink/deployment/ccip/operation/evm/v1_6"
 	ccipseq "github.com/smartcontractkit/chainlink/deployment/ccip/sequence/evm/v1_6"
@@ -533,9 +532,9 @@ func (m *MemoryEnvironment) StartNodes(t *testing.T, crConfig deployment.Capabil
 	ctx := testcontext.Get(t)
 	lggr := logger.Test(t)
 	for _, node := range nodes {
-		require.NoError(t, node.App.Start(ctx))
+		require.NoError(t, node.Start(ctx))
 		t.Cleanup(func() {
-			require.NoError(t, node.App.Stop())
+			require.NoError(t, node.Stop())
 		})
 	}
 	m.nodes = nodes
@@ -547,6 +546,11 @@ func (m *MemoryEnvironment) StartNodes(t *testing.T, crConfig deployment.Capabil
 	)
 }
 
+// Nodes returns the in-memory nodes of the environment by ID, e.g. to restart them with RunNodeOutage.
+func (m *MemoryEnvironment) Nodes() map[string]memory.Node {
+	return m.nodes
+}
+
 func (m *MemoryEnvironment) DeleteJobs(ctx context.Context, jobIDs map[string][]string) error {
 	for id, node := range m.nodes {
 		if jobsToDelete, ok := jobIDs[id]; ok {
@@ -556,7 +560,7 @@ func (m *MemoryEnvironment) DeleteJobs(ctx context.Context, jobIDs map[string][]
 				if err != nil {
 					return err
 				}
-				err = node.App.DeleteJob(ctx, int32(jobID))
+				err = node.Application().DeleteJob(ctx, int32(jobID))
 				if err != nil {
 					return fmt.Errorf("failed to delete job %s: %w", jobToDelete, err)
 				}
@@ -951,85 +955,6 @@ func DeployChainContractsToSolChainCS(e DeployedEnv, solChainSelector uint64, pr
 		)}, nil
 }
 
-type WrapSetOCR3Config struct{}
-
-func (cs WrapSetOCR3Config) VerifyPreconditions(env cldf.Environment, config WrapSetOCR3ConfigArgs) error {
-	// NOTE: this is a workaround and it only validates RemoteChainSelectors
-	return ops.SetOCR3Config{}.VerifyPreconditions(env, ops.SetOCR3OffRampConfig{RemoteChainSels: config.RemoteChainSels})
-}
-
-// NOTE: this should become the new standard function that returns generic OCR3ConfigArgs
-func ocr3ConfigArgs(e cldf.Environment, homeChainSelector uint64, chainSelector uint64, configType globals.ConfigType) ([]tonOperation.OCR3ConfigArgs, error) {
-	state, err := stateview.LoadOnchainState(e, stateview.WithLoadLegacyContracts(true))
-	if err != nil {
-		return nil, err
-	}
-
-	donID, err := internal.DonIDForChain(
-		state.Chains[homeChainSelector].CapabilityRegistry,
-		state.Chains[homeChainSelector].CCIPHome,
-		chainSelector,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get DON ID: %w", err)
-	}
-
-	// Default to active config if not set
-	if configType == "" {
-		configType = globals.ConfigTypeActive
-	}
-
-	ocr3Args, err := internal.BuildSetOCR3ConfigArgsAptos(
-		donID,
-		state.Chains[homeChainSelector].CCIPHome,
-		chainSelector,
-		configType,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build OCR3 config args: %w", err)
-	}
-
-	// MAP TO ARGS, this will become unnecessary once BuildSetOCR3Config uses correct types
-	var args []tonOperation.OCR3ConfigArgs
-	for _, arg := range ocr3Args {
-		args = append(args, tonOperation.OCR3ConfigArgs{
-			ConfigDigest:                   arg.ConfigDigest,
-			PluginType:                     tonOperation.PluginType(arg.OcrPluginType),
-			F:                              arg.F,
-			IsSignatureVerificationEnabled: arg.IsSignatureVerificationEnabled,
-			Signers:                        arg.Signers,
-			Transmitters:                   arg.Transmitters,
-		})
-	}
-	return args, nil
-}
-
-type WrapSetOCR3ConfigArgs struct {
-	HomeChainSel    uint64
-	RemoteChainSels []uint64
-	ConfigType      globals.ConfigType
-}
-
-func (cs WrapSetOCR3Config) Apply(env cldf.Environment, config WrapSetOCR3ConfigArgs) (cldf.ChangesetOutput, error) {
-	// TODO: loop over tonChains
-	args, err := ocr3ConfigArgs(env, config.HomeChainSel, config.RemoteChainSels[0], config.ConfigType)
-	if err != nil {
-		return cldf.ChangesetOutput{}, err
-	}
-
-	configs := make(map[tonOperation.PluginType]tonOperation.OCR3ConfigArgs, 2)
-	for _, arg := range args {
-		configs[arg.PluginType] = arg
-	}
-
-	// TODO: don't only wrap TON
-	return ops.SetOCR3Config{}.Apply(env, ops.SetOCR3OffRampConfig{
-		// TODO: map[remoteChainSels => configs]
-		RemoteChainSels: config.RemoteChainSels,
-		Configs:         configs,
-	})
-}
-
 func AddCCIPContractsToEnvironment(t *testing.T, allChains []uint64, tEnv TestEnvironment, mcmsEnabled bool) DeployedEnv {
 	tc := tEnv.TestConfigs()
 	e := tEnv.DeployedEnvironment()
@@ -1553,11 +1478,11 @@ func AddCCIPContractsToEnvironment(t *testing.T, allChains []uint64, tEnv TestEn
 		if len(tonChains) > 0 {
 			apps = append(apps, commonchangeset.Configure(
 				// Enable the OCR config on the remote chains.
-				WrapSetOCR3Config{},
-				WrapSetOCR3ConfigArgs{
-					HomeChainSel:    e.HomeChainSel,
-					RemoteChainSels: tonChains,
-					ConfigType:      globals.ConfigTypeActive,
+				ton_cs_core.SetOCR3Offramp{},
+				v1_6.SetOCR3OffRampConfig{
+					HomeChainSel:       e.HomeChainSel,
+					RemoteChainSels:    tonChains,
+					CCIPHomeConfigType: globals.ConfigTypeActive,
 				},
 			))
 		}
@@ -0,0 +1,113 @@
+package testhelpers
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/erc20"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/bindings"
+)
+
+// GetFastTransferRequest returns the fast transfer requested to the destination chain in the given block of the
+// source chain, i.e. the block returned when confirming the CcipSendToken transaction.
+func GetFastTransferRequest(
+	t *testing.T,
+	sourcePool *bindings.FastTransferTokenPoolWrapper,
+	destChainSelector uint64,
+	blockNum uint64,
+) *bindings.FastTransferRequestedEvent {
+	it, err := sourcePool.FilterFastTransferRequested(&bind.FilterOpts{
+		Start:   blockNum,
+		End:     &blockNum,
+		Context: t.Context(),
+	}, []uint64{destChainSelector}, nil, nil)
+	require.NoError(t, err)
+	defer it.Close()
+
+	require.True(t, it.Next(), "no fast transfer requested to chain %d in block %d", destChainSelector, blockNum)
+	event := it.Event()
+	require.False(t, it.Next(), "more than one fast transfer requested to chain %d in block %d", destChainSelector, blockNum)
+	return event
+}
+
+// FastFillTransfer fills the fast transfer request on the destination pool with the tokens of the filler, which must
+// be allowed on the pool and have approved it. It asserts that the receiver got the requested amount, net of fees,
+// and that the transfer is filled by the filler.
+func FastFillTransfer(
+	t *testing.T,
+	destChain cldf_evm.Chain,
+	destPool *bindings.FastTransferTokenPoolWrapper,
+	filler *bind.TransactOpts,
+	sourceChainSelector uint64,
+	request *bindings.FastTransferRequestedEvent,
+) {
+	opts := &bind.CallOpts{Context: t.Context()}
+	receiver := common.BytesToAddress(request.Receiver)
+	tokenAddress, err := destPool.GetToken(opts)
+	require.NoError(t, err)
+	token, err := erc20.NewERC20(tokenAddress, destChain.Client)
+	require.NoError(t, err)
+	decimals, err := token.Decimals(opts)
+	require.NoError(t, err)
+	balanceBefore, err := token.BalanceOf(opts, receiver)
+	require.NoError(t, err)
+
+	tx, err := destPool.FastFill(filler, request.FillID, request.SettlementID, sourceChainSelector, request.SourceAmountNetFee, request.SourceDecimals, receiver)
+	require.NoError(t, err)
+	_, err = destChain.Confirm(tx)
+	require.NoError(t, err)
+
+	balanceAfter, err := token.BalanceOf(opts, receiver)
+	require.NoError(t, err)
+	expected := new(big.Int).Add(balanceBefore, toLocalAmount(request.SourceAmountNetFee, request.SourceDecimals, decimals))
+	require.Equal(t, expected.String(), balanceAfter.String(), "unexpected balance of receiver %s after fast fill", receiver)
+
+	fillInfo, err := destPool.GetFillInfo(opts, request.FillID)
+	require.NoError(t, err)
+	require.Equal(t, bindings.FillStateFilled, fillInfo.State, "fast transfer is not filled")
+	require.Equal(t, filler.From, fillInfo.Filler, "fast transfer is not filled by %s", filler.From)
+}
+
+// ConfirmFastTransferSettled waits until the fast transfer request is settled on the destination pool by the regular
+// CCIP message. If filler is defined, it asserts that the transfer was fast filled by it, otherwise that it was not
+// fast filled.
+func ConfirmFastTransferSettled(
+	t *testing.T,
+	destPool *bindings.FastTransferTokenPoolWrapper,
+	request *bindings.FastTransferRequestedEvent,
+	filler common.Address,
+	timeout time.Duration,
+) {
+	var fillInfo bindings.FillInfo
+	require.Eventually(t, func() bool {
+		var err error
+		fillInfo, err = destPool.GetFillInfo(&bind.CallOpts{Context: t.Context()}, request.FillID)
+		if err != nil {
+			t.Logf("failed to get fill info of fast transfer %x: %v", request.FillID, err)
+			return false
+		}
+		return fillInfo.State == bindings.FillStateSettled
+	}, timeout, time.Second, "fast transfer %x is not settled", request.FillID)
+	require.Equal(t, filler, fillInfo.Filler, "unexpected filler of fast transfer %x", request.FillID)
+}
+
+// toLocalAmount converts an amount of tokens with the source decimals to the local decimals
+func toLocalAmount(amount *big.Int, sourceDecimals, localDecimals uint8) *big.Int {
+	if sourceDecimals == localDecimals {
+		return new(big.Int).Set(amount)
+	}
+	if localDecimals > sourceDecimals {
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(localDecimals-sourceDecimals)), nil)
+		return new(big.Int).Mul(amount, factor)
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(sourceDecimals-localDecimals)), nil)
+	return new(big.Int).Div(amount, factor)
+}
@@ -11,8 +11,6 @@ import (
 
 	"github.com/aptos-labs/aptos-go-sdk"
 	"github.com/aptos-labs/aptos-go-sdk/api"
-	"github.com/aptos-labs/aptos-go-sdk/bcs"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	chainsel "github.com/smartcontractkit/chain-selectors"
 	mcmstypes "github.com/smartcontractkit/mcms/types"
 	"github.com/stretchr/testify/require"
@@ -103,27 +101,13 @@ func DeployChainContractsToAptosCS(t *testing.T, e DeployedEnv, chainSelector ui
 // MakeBCSEVMExtraArgsV2 makes the BCS encoded extra args for a message sent from a Move based chain that is destined for an EVM chain.
 // The extra args are used to specify the gas limit and allow out of order flag for the message.
 func MakeBCSEVMExtraArgsV2(gasLimit *big.Int, allowOOO bool) []byte {
-	s := &bcs.Serializer{}
-	s.U256(*gasLimit)
-	s.Bool(allowOOO)
-	return append(hexutil.MustDecode(GenericExtraArgsV2Tag), s.ToBytes()...)
+	return ccipclient.MakeBCSEVMExtraArgsV2(gasLimit, allowOOO)
 }
 
-// Aptos doesn't provide any struct that we could reuse here
-
-type AptosSendRequest struct {
-	Receiver      []byte
-	Data          []byte
-	ExtraArgs     []byte
-	FeeToken      aptos.AccountAddress
-	FeeTokenStore aptos.AccountAddress
-	TokenAmounts  []AptosTokenAmount
-}
-
-type AptosTokenAmount struct {
-	Token  aptos.AccountAddress
-	Amount uint64
-}
+type (
+	AptosSendRequest = ccipclient.AptosSendRequest
+	AptosTokenAmount = ccipclient.AptosTokenAmount
+)
 
 func SendRequestAptos(
 	e cldf.Environment,
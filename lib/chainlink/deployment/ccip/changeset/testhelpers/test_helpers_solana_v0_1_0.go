@@ -19,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	solbinary "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/programs/token"
@@ -86,6 +87,7 @@ import (
 	solTestReceiver "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_0/test_ccip_receiver"
 	solccip "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/ccip"
 	solcommon "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/common"
+	"github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/fees"
 	solstate "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/state"
 	soltokens "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/tokens"
 
@@ -422,20 +424,17 @@ func CCIPSendRequest(
 // retryCcipSendUntilNativeFeeIsSufficient sends a CCIP message with a native fee,
 // and retries until the fee is sufficient. This is due to the fact that the fee is not known in advance,
 // and the message will be rejected if the fee is insufficient.
-// The function will retry based on the config's MaxRetries setting for errors other than insufficient fee.
+// Other errors are returned, and transient ones are retried by SendRequest.
 func retryCcipSendUntilNativeFeeIsSufficient(
 	e cldf.Environment,
 	r *router.Router,
 	cfg *ccipclient.CCIPSendReqConfig,
 ) (*types.Transaction, uint64, error) {
 	const errCodeInsufficientFee = "0x07da6ee6"
-	const cannotDecodeErrorReason = "could not decode error reason"
-	const errMsgMissingTrieNode = "missing trie node"
 
 	defer func() { cfg.Sender.Value = nil }()
 
 	msg := cfg.Message.(router.ClientEVM2AnyMessage)
-	var retryCount int
 	for {
 		fee, err := r.GetFee(&bind.CallOpts{Context: context.Background()}, cfg.DestChain, msg)
 		if err != nil {
@@ -455,16 +454,6 @@ func retryCcipSendUntilNativeFeeIsSufficient(
 				// Don't count insufficient fee as part of the retry count
 				// because this is expected and we need to adjust the fee
 				continue
-			} else if strings.Contains(err.Error(), cannotDecodeErrorReason) ||
-				strings.Contains(err.Error(), errMsgMissingTrieNode) {
-				// If the error reason cannot be decoded, we retry to avoid transient issues. The retry behavior is disabled by default
-				// It is configured in the CCIPSendReqConfig.
-				// This retry was originally added to solve transient failure in end to end tests
-				if retryCount >= cfg.MaxRetries {
-					return nil, 0, fmt.Errorf("failed to confirm CCIP message after %d retries: %w", retryCount, cldf.MaybeDataErr(err))
-				}
-				retryCount++
-				continue
 			}
 
 			return nil, 0, fmt.Errorf("failed to confirm CCIP message: %w", cldf.MaybeDataErr(err))
@@ -502,6 +491,8 @@ func TestSendRequest(
 }
 
 // SendRequest similar to TestSendRequest but returns an error.
+// Transient errors are retried according to the retry policy of the options, and the returned errors are
+// classified with ccipclient.ClassifyError.
 func SendRequest(
 	e cldf.Environment,
 	state stateview.CCIPOnChainState,
@@ -515,10 +506,52 @@ func SendRequest(
 	if err != nil {
 		return nil, err
 	}
+	if err = ccipclient.ApplyExtraArgs(e, state, cfg); err != nil {
+		return nil, err
+	}
 
+	if cfg.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(e.GetContext(), cfg.Timeout)
+		defer cancel()
+		e.GetContext = func() context.Context { return ctx }
+	}
+	ctx := e.GetContext()
+
+	for retryCount := 0; ; retryCount++ {
+		event, err := sendRequest(e, state, family, cfg)
+		if err == nil {
+			return event, nil
+		}
+		err = ccipclient.ClassifyError(err)
+		if !ccipclient.IsTransient(err) || retryCount >= cfg.MaxRetries {
+			return nil, err
+		}
+		e.Logger.Warnw("Retrying CCIP request after transient error",
+			"sourceChain", cfg.SourceChain, "destChain", cfg.DestChain, "retry", retryCount+1, "err", err)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to send CCIP request after %d retries: %w", retryCount, errors.Join(err, ctx.Err()))
+		case <-time.After(cfg.RetryDelay):
+		}
+	}
+}
+
+func sendRequest(
+	e cldf.Environment,
+	state stateview.CCIPOnChainState,
+	family string,
+	cfg *ccipclient.CCIPSendReqConfig,
+) (*ccipclient.AnyMsgSentEvent, error) {
 	switch family {
 	case chainsel.FamilyEVM:
-		return SendRequestEVM(e, state, cfg)
+		event, err := SendRequestEVM(e, state, cfg)
+		if err != nil || !cfg.WaitForFinality {
+			return event, err
+		}
+		if err = waitForEVMFinality(e, cfg.SourceChain, event.RawEvent.(*onramp.OnRampCCIPMessageSent).Raw.BlockNumber); err != nil {
+			return nil, err
+		}
+		return event, nil
 	case chainsel.FamilySolana:
 		return SendRequestSol(e, state, cfg)
 	case chainsel.FamilySui:
@@ -526,6 +559,9 @@ func SendRequest(
 	case chainsel.FamilyAptos:
 		return SendRequestAptos(e, state, cfg)
 	case chainsel.FamilyTon:
+		if cfg.WaitForFinality {
+			return nil, errors.New("send request: wait for finality is not supported for TON")
+		}
 		seq, raw, err := tonOps.SendTonRequest(e, state.TonChains[cfg.SourceChain], cfg.SourceChain, cfg.DestChain, cfg.Message.(tonOps.TonSendRequest))
 		if err != nil {
 			return nil, err
@@ -540,6 +576,28 @@ func SendRequest(
 	}
 }
 
+// waitForEVMFinality waits for the finalized block of the chain to reach blockNum.
+func waitForEVMFinality(e cldf.Environment, chainSelector uint64, blockNum uint64) error {
+	ctx := e.GetContext()
+	client := e.BlockChains.EVMChains()[chainSelector].Client
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		finalized, err := client.HeaderByNumber(ctx, big.NewInt(gethrpc.FinalizedBlockNumber.Int64()))
+		if err != nil {
+			return fmt.Errorf("failed to get finalized header for chain %d: %w", chainSelector, err)
+		}
+		if finalized.Number.Uint64() >= blockNum {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("block %d of chain %d is not finalized: %w", blockNum, chainSelector, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 func SendRequestEVM(
 	e cldf.Environment,
 	state stateview.CCIPOnChainState,
@@ -606,7 +664,11 @@ func SendRequestSol(
 	c := e.BlockChains.SolanaChains()[cfg.SourceChain]
 
 	destinationChainSelector := cfg.DestChain
-	message := cfg.Message.(solRouter.SVM2AnyMessage)
+	req, err := ccipclient.ToSolanaSendRequest(cfg.Message)
+	if err != nil {
+		return nil, err
+	}
+	message := req.SVM2AnyMessage()
 	feeToken := message.FeeToken
 	client := c.Client
 
@@ -634,12 +696,13 @@ func SendRequestSol(
 			return nil, fmt.Errorf("the provided fee token is not a valid token: (err = %w)", err)
 		}
 
-		ata, _, err := soltokens.FindAssociatedTokenAddress(feeTokenProgramID, feeToken, sender.PublicKey())
-		if err != nil {
-			return nil, err
+		feeTokenUserATA = req.FeeTokenUserATA
+		if feeTokenUserATA.IsZero() {
+			feeTokenUserATA, _, err = soltokens.FindAssociatedTokenAddress(feeTokenProgramID, feeToken, sender.PublicKey())
+			if err != nil {
+				return nil, err
+			}
 		}
-
-		feeTokenUserATA = ata
 	}
 
 	destinationChainStatePDA, err := solstate.FindDestChainStatePDA(destinationChainSelector, s.Router)
@@ -714,6 +777,13 @@ func SendRequestSol(
 	}
 
 	addressTables := map[solana.PublicKey]solana.PublicKeySlice{}
+	for _, table := range req.AddressLookupTables {
+		entries, err := solcommon.GetAddressLookupTable(ctx, client, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get address lookup table %s: %w", table, err)
+		}
+		addressTables[table] = entries
+	}
 
 	requiredAccounts := len(base.AccountMetaSlice)
 	tokenIndexes := []byte{}
@@ -723,18 +793,21 @@ func SendRequestSol(
 	solconfig.CcipRouterProgram = s.Router
 
 	// Append token accounts to the account metas
-	for _, tokenAmount := range message.TokenAmounts {
+	for _, tokenAmount := range req.TokenAmounts {
 		tokenPubKey := tokenAmount.Token
 
-		allTokenPools := solana.PublicKeySlice{}
-		allTokenPools = slices.AppendSeq(allTokenPools, maps.Values(s.LockReleaseTokenPools))
-		allTokenPools = slices.AppendSeq(allTokenPools, maps.Values(s.BurnMintTokenPools))
-		allTokenPools = append(allTokenPools, s.CCTPTokenPool)
-
-		e.Logger.Infof("Found %d token pools in state - searching for matching token pool", len(allTokenPools))
-		tokenPoolPubKey, err := MatchTokenToTokenPool(ctx, client, tokenPubKey, allTokenPools)
-		if err != nil {
-			return nil, err
+		tokenPoolPubKey := tokenAmount.TokenPool
+		if tokenPoolPubKey.IsZero() {
+			allTokenPools := solana.PublicKeySlice{}
+			allTokenPools = slices.AppendSeq(allTokenPools, maps.Values(s.LockReleaseTokenPools))
+			allTokenPools = slices.AppendSeq(allTokenPools, maps.Values(s.BurnMintTokenPools))
+			allTokenPools = append(allTokenPools, s.CCTPTokenPool)
+
+			e.Logger.Infof("Found %d token pools in state - searching for matching token pool", len(allTokenPools))
+			tokenPoolPubKey, err = MatchTokenToTokenPool(ctx, client, tokenPubKey, allTokenPools)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		e.Logger.Infof("Token '%s' was matched to token pool '%s'",
@@ -777,9 +850,12 @@ func SendRequestSol(
 
 		tokenPool.Billing[cfg.DestChain] = billingPDA
 
-		userTokenAccount, _, err := soltokens.FindAssociatedTokenAddress(tokenProgramID, tokenPubKey, sender.PublicKey())
-		if err != nil {
-			return nil, err
+		userTokenAccount := tokenAmount.UserTokenAccount
+		if userTokenAccount.IsZero() {
+			userTokenAccount, _, err = soltokens.FindAssociatedTokenAddress(tokenProgramID, tokenPubKey, sender.PublicKey())
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		tokenMetas, tokenAddressTables, err := soltokens.ParseTokenLookupTableWithChain(ctx, client, tokenPool, userTokenAccount, cfg.DestChain)
@@ -806,8 +882,16 @@ func SendRequestSol(
 
 	// for some reason onchain doesn't see extraAccounts
 
+	computeUnitLimit := fees.ComputeUnitLimit(req.ComputeUnitLimit)
+	if computeUnitLimit == 0 {
+		computeUnitLimit = 400_000
+	}
+	commitment := solconfig.DefaultCommitment
+	if cfg.WaitForFinality {
+		commitment = rpc.CommitmentFinalized
+	}
 	ixs := []solana.Instruction{ix}
-	result, err := solcommon.SendAndConfirmWithLookupTables(ctx, client, ixs, *sender, solconfig.DefaultCommitment, addressTables, solcommon.AddComputeUnitLimit(400_000))
+	result, err := solcommon.SendAndConfirmWithLookupTables(ctx, client, ixs, *sender, commitment, addressTables, solcommon.AddComputeUnitLimit(computeUnitLimit))
 	if err != nil {
 		return nil, err
 	}
@@ -941,7 +1025,7 @@ func MatchTokenToTokenPool(ctx context.Context, client *rpc.Client, tokenPubKey
 }
 
 // bytes4 public constant EVM_EXTRA_ARGS_V2_TAG = 0x181dcf10;
-const GenericExtraArgsV2Tag = "0x181dcf10"
+const GenericExtraArgsV2Tag = ccipclient.GenericExtraArgsV2Tag
 const SVMExtraArgsV1Tag = "0x1f3b3aba"
 
 // MakeEVMExtraArgsV2 creates the extra args for the EVM2Any message that is destined
@@ -1815,6 +1899,18 @@ func deployTransferTokenOneEnd(
 	deployer *bind.TransactOpts,
 	addressBook cldf.AddressBook,
 	tokenSymbol string,
+) (*burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, error) {
+	return deployTransferTokenOneEndWithDecimals(lggr, chain, deployer, addressBook, tokenSymbol, 18)
+}
+
+// deployTransferTokenOneEndWithDecimals deploys a burn mint token with the given decimals and its burn mint token pool.
+func deployTransferTokenOneEndWithDecimals(
+	lggr logger.Logger,
+	chain cldf_evm.Chain,
+	deployer *bind.TransactOpts,
+	addressBook cldf.AddressBook,
+	tokenSymbol string,
+	tokenDecimals uint8,
 ) (*burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, error) {
 	var rmnAddress, routerAddress string
 	chainAddresses, err := addressBook.AddressesForChain(chain.Selector)
@@ -1833,8 +1929,6 @@ func deployTransferTokenOneEnd(
 		}
 	}
 
-	tokenDecimals := uint8(18)
-
 	tokenContract, err := cldf.DeployContract(lggr, chain, addressBook,
 		func(chain cldf_evm.Chain) cldf.ContractDeploy[*burn_mint_erc677.BurnMintERC677] {
 			tokenAddress, tx, token, err2 := burn_mint_erc677.DeployBurnMintERC677(
@@ -0,0 +1,231 @@
+package testhelpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	ccipclient "github.com/smartcontractkit/chainlink/deployment/ccip/shared/client"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the buckets of the latency histograms.
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second, 45 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute,
+}
+
+// MessageLatency is the time from the send of a message on the source chain to its commit report and to its execution
+// on the destination chain, as observed by the test.
+type MessageLatency struct {
+	SourceChain, DestChain uint64
+	MessageID              [32]byte
+	SentAt                 time.Time
+	// Commit is the time from the send to the commit report, zero until committed.
+	Commit time.Duration
+	// Exec is the time from the send to the execution, zero until executed.
+	Exec time.Duration
+}
+
+// LatencyRecorder records the commit and execution latencies of the messages of a test, to assert latency SLOs so that
+// performance regressions fail the test. The latencies are observed by polling the chains, so their precision is the
+// poll interval.
+type LatencyRecorder struct {
+	pollInterval time.Duration
+
+	mu       sync.Mutex
+	messages []*MessageLatency
+	errs     []error
+	wg       sync.WaitGroup
+}
+
+// NewLatencyRecorder creates a recorder polling the chains every pollInterval, 500ms if zero.
+func NewLatencyRecorder(pollInterval time.Duration) *LatencyRecorder {
+	if pollInterval == 0 {
+		pollInterval = 500 * time.Millisecond
+	}
+	return &LatencyRecorder{pollInterval: pollInterval}
+}
+
+// Track records the latencies of the message sent at sentAt, e.g. just before the send transaction, following it with
+// TrackMessage in the background until it is executed or ctx is done.
+func (r *LatencyRecorder) Track(
+	ctx context.Context,
+	e cldf.Environment,
+	state stateview.CCIPOnChainState,
+	src, dest uint64,
+	msgID [32]byte,
+	sentAt time.Time,
+	opts ...TrackMessageOption,
+) {
+	m := &MessageLatency{SourceChain: src, DestChain: dest, MessageID: msgID, SentAt: sentAt}
+	r.mu.Lock()
+	r.messages = append(r.messages, m)
+	r.mu.Unlock()
+
+	updates, errCh := TrackMessage(ctx, e, state, src, dest, msgID, append([]TrackMessageOption{WithTrackPollInterval(r.pollInterval)}, opts...)...)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for update := range updates {
+			elapsed := time.Since(sentAt)
+			r.mu.Lock()
+			switch update.Status {
+			case ccipclient.MessageCommitted:
+				m.Commit = elapsed
+			case ccipclient.MessageExecutionSuccess, ccipclient.MessageExecutionFailure:
+				m.Exec = elapsed
+			default:
+			}
+			r.mu.Unlock()
+		}
+		select {
+		case err := <-errCh:
+			r.mu.Lock()
+			r.errs = append(r.errs, err)
+			r.mu.Unlock()
+		default:
+		}
+	}()
+}
+
+// Wait waits until all the tracked messages are executed, or their tracking failed or was canceled, and returns the
+// tracking errors.
+func (r *LatencyRecorder) Wait() error {
+	r.wg.Wait()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return errors.Join(r.errs...)
+}
+
+// Messages returns the latencies of the tracked messages.
+func (r *LatencyRecorder) Messages() []MessageLatency {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]MessageLatency, len(r.messages))
+	for i, m := range r.messages {
+		out[i] = *m
+	}
+	return out
+}
+
+// CommitStats returns the statistics of the commit latencies of the committed messages.
+func (r *LatencyRecorder) CommitStats() LatencyStats {
+	return r.stats(func(m MessageLatency) time.Duration { return m.Commit })
+}
+
+// ExecStats returns the statistics of the execution latencies of the executed messages.
+func (r *LatencyRecorder) ExecStats() LatencyStats {
+	return r.stats(func(m MessageLatency) time.Duration { return m.Exec })
+}
+
+func (r *LatencyRecorder) stats(latency func(MessageLatency) time.Duration) LatencyStats {
+	var samples []time.Duration
+	for _, m := range r.Messages() {
+		if l := latency(m); l > 0 {
+			samples = append(samples, l)
+		}
+	}
+	return NewLatencyStats(samples)
+}
+
+// RequireCommitLatency fails the test unless the given percentile, e.g. 95, of the commit latencies is at most max.
+func (r *LatencyRecorder) RequireCommitLatency(t *testing.T, percentile float64, maxLatency time.Duration) {
+	t.Helper()
+	requireLatency(t, "commit", r.CommitStats(), percentile, maxLatency)
+}
+
+// RequireExecLatency fails the test unless the given percentile, e.g. 95, of the execution latencies is at most max.
+func (r *LatencyRecorder) RequireExecLatency(t *testing.T, percentile float64, maxLatency time.Duration) {
+	t.Helper()
+	requireLatency(t, "exec", r.ExecStats(), percentile, maxLatency)
+}
+
+func requireLatency(t *testing.T, name string, stats LatencyStats, percentile float64, maxLatency time.Duration) {
+	t.Helper()
+	require.NotZero(t, stats.Count(), "no %s latency recorded", name)
+	got := stats.Percentile(percentile)
+	t.Logf("%s latency: %s", name, stats)
+	require.LessOrEqual(t, got, maxLatency, "p%v %s latency %s exceeds %s", percentile, name, got, maxLatency)
+}
+
+// LatencyStats are the statistics of a set of latencies.
+type LatencyStats struct {
+	samples []time.Duration // sorted
+}
+
+// NewLatencyStats returns the statistics of the latencies.
+func NewLatencyStats(samples []time.Duration) LatencyStats {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	return LatencyStats{samples: sorted}
+}
+
+// Count returns the number of latencies.
+func (s LatencyStats) Count() int {
+	return len(s.samples)
+}
+
+// Percentile returns the nearest-rank percentile of the latencies, e.g. Percentile(95), zero without latencies.
+func (s LatencyStats) Percentile(p float64) time.Duration {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(s.samples))))
+	rank = min(max(rank, 1), len(s.samples))
+	return s.samples[rank-1]
+}
+
+// Mean returns the mean of the latencies, zero without latencies.
+func (s LatencyStats) Mean() time.Duration {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, l := range s.samples {
+		sum += l
+	}
+	return sum / time.Duration(len(s.samples))
+}
+
+// Histogram returns the number of latencies in each bucket, whose upper bounds are given in increasing order, and the
+// number of latencies above the last bucket.
+func (s LatencyStats) Histogram(buckets []time.Duration) (counts []int, overflow int) {
+	counts = make([]int, len(buckets))
+	for _, l := range s.samples {
+		i, _ := slices.BinarySearch(buckets, l)
+		if i == len(buckets) {
+			overflow++
+			continue
+		}
+		counts[i]++
+	}
+	return counts, overflow
+}
+
+// String returns the count, mean and percentiles of the latencies with their histogram in DefaultLatencyBuckets.
+func (s LatencyStats) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "n=%d mean=%s p50=%s p90=%s p95=%s p99=%s max=%s",
+		s.Count(), s.Mean(), s.Percentile(50), s.Percentile(90), s.Percentile(95), s.Percentile(99), s.Percentile(100))
+	counts, overflow := s.Histogram(DefaultLatencyBuckets)
+	for i, bucket := range DefaultLatencyBuckets {
+		if counts[i] > 0 {
+			fmt.Fprintf(&sb, " <=%s:%d", bucket, counts[i])
+		}
+	}
+	if overflow > 0 {
+		fmt.Fprintf(&sb, " >%s:%d", DefaultLatencyBuckets[len(DefaultLatencyBuckets)-1], overflow)
+	}
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+package testhelpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyStats_Percentile(t *testing.T) {
+	samples := []time.Duration{5 * time.Second, 1 * time.Second, 4 * time.Second, 2 * time.Second, 3 * time.Second}
+	tests := []struct {
+		name    string
+		samples []time.Duration
+		p       float64
+		want    time.Duration
+	}{
+		{name: "empty", samples: nil, p: 50, want: 0},
+		{name: "empty p100", samples: nil, p: 100, want: 0},
+		{name: "p0 is the minimum", samples: samples, p: 0, want: 1 * time.Second},
+		{name: "p20 is the first rank", samples: samples, p: 20, want: 1 * time.Second},
+		{name: "p21 rounds up to the next rank", samples: samples, p: 21, want: 2 * time.Second},
+		{name: "p50", samples: samples, p: 50, want: 3 * time.Second},
+		{name: "p100 is the maximum", samples: samples, p: 100, want: 5 * time.Second},
+		{name: "above p100 is the maximum", samples: samples, p: 150, want: 5 * time.Second},
+		{name: "single sample", samples: []time.Duration{time.Second}, p: 0, want: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewLatencyStats(tt.samples).Percentile(tt.p))
+		})
+	}
+}
+
+func TestLatencyStats_Mean(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []time.Duration
+		want    time.Duration
+	}{
+		{name: "empty", samples: nil, want: 0},
+		{name: "single sample", samples: []time.Duration{3 * time.Second}, want: 3 * time.Second},
+		{name: "several samples", samples: []time.Duration{3 * time.Second, time.Second, 2 * time.Second}, want: 2 * time.Second},
+		{name: "truncated", samples: []time.Duration{1, 2}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewLatencyStats(tt.samples).Mean())
+		})
+	}
+}
+
+func TestLatencyStats_Histogram(t *testing.T) {
+	buckets := []time.Duration{time.Second, 10 * time.Second, time.Minute}
+	tests := []struct {
+		name         string
+		samples      []time.Duration
+		buckets      []time.Duration
+		wantCounts   []int
+		wantOverflow int
+	}{
+		{name: "empty", samples: nil, buckets: buckets, wantCounts: []int{0, 0, 0}},
+		{
+			name:       "upper bounds are inclusive",
+			samples:    []time.Duration{time.Second, 10 * time.Second, time.Minute},
+			buckets:    buckets,
+			wantCounts: []int{1, 1, 1},
+		},
+		{
+			name:         "spread with overflow",
+			samples:      []time.Duration{0, 500 * time.Millisecond, 2 * time.Second, 30 * time.Second, 2 * time.Minute},
+			buckets:      buckets,
+			wantCounts:   []int{2, 1, 1},
+			wantOverflow: 1,
+		},
+		{
+			name:         "no buckets",
+			samples:      []time.Duration{time.Second, time.Minute},
+			buckets:      nil,
+			wantCounts:   []int{},
+			wantOverflow: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts, overflow := NewLatencyStats(tt.samples).Histogram(tt.buckets)
+			assert.Equal(t, tt.wantCounts, counts)
+			assert.Equal(t, tt.wantOverflow, overflow)
+		})
+	}
+}
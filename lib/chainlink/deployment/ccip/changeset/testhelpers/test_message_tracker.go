@@ -0,0 +1,363 @@
+package testhelpers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/block-vision/sui-go-sdk/models"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gagliardetto/solana-go"
+	solrpc "github.com/gagliardetto/solana-go/rpc"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-aptos/relayer/codec"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/offramp"
+	"github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/latest/ccip_offramp"
+	solccip "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/ccip"
+	solcommon "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/common"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/consts"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	sui_module_offramp "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_offramp/offramp"
+	sui_module_onramp "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_onramp/onramp"
+
+	ccipclient "github.com/smartcontractkit/chainlink/deployment/ccip/shared/client"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+)
+
+type trackMessageConfig struct {
+	startBlocks  map[uint64]*uint64
+	pollInterval time.Duration
+}
+
+type TrackMessageOption func(*trackMessageConfig)
+
+// WithTrackStartBlocks sets the blocks (slots on Solana) from which the events of each chain are searched, e.g. the
+// start blocks returned by Transfer. Events are searched from genesis on chains without a start block. Sui events are
+// always searched from genesis.
+func WithTrackStartBlocks(startBlocks map[uint64]*uint64) TrackMessageOption {
+	return func(c *trackMessageConfig) {
+		c.startBlocks = startBlocks
+	}
+}
+
+// WithTrackPollInterval sets the interval at which the chains are polled, 2 seconds by default.
+func WithTrackPollInterval(interval time.Duration) TrackMessageOption {
+	return func(c *trackMessageConfig) {
+		c.pollInterval = interval
+	}
+}
+
+// TrackMessage follows the message with the given ID from the source chain to the destination chain. It sends an
+// update on the returned channel for each stage of its lifecycle: sent, committed, then executed with success or
+// failure. The update channel is closed once the message reached a final state, ctx is done, or tracking failed, in
+// which case the error is sent on the error channel.
+// EVM, Solana and Sui chains are supported on both ends.
+func TrackMessage(
+	ctx context.Context,
+	e cldf.Environment,
+	state stateview.CCIPOnChainState,
+	src, dest uint64,
+	msgID [32]byte,
+	opts ...TrackMessageOption,
+) (<-chan ccipclient.MessageStatusUpdate, <-chan error) {
+	cfg := trackMessageConfig{pollInterval: 2 * time.Second}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	t := &messageTracker{e: e, state: state, src: src, dest: dest, msgID: msgID, cfg: cfg}
+
+	// One slot per status, so that sending never blocks
+	updates := make(chan ccipclient.MessageStatusUpdate, 3)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(updates)
+		if err := t.track(ctx, updates); err != nil && !errors.Is(err, context.Canceled) {
+			errCh <- err
+		}
+	}()
+	return updates, errCh
+}
+
+type messageTracker struct {
+	e         cldf.Environment
+	state     stateview.CCIPOnChainState
+	src, dest uint64
+	msgID     [32]byte
+	cfg       trackMessageConfig
+}
+
+func (t *messageTracker) track(ctx context.Context, updates chan<- ccipclient.MessageStatusUpdate) error {
+	update := func(status ccipclient.MessageStatus, seqNr uint64, tx string) {
+		updates <- ccipclient.MessageStatusUpdate{MessageID: t.msgID, Status: status, SequenceNumber: seqNr, Tx: tx}
+	}
+
+	seqNr, tx, err := t.waitSent(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find message %x on chain %d: %w", t.msgID, t.src, err)
+	}
+	update(ccipclient.MessageSent, seqNr, tx)
+
+	tx, err = t.waitCommitted(ctx, seqNr)
+	if err != nil {
+		return fmt.Errorf("failed to find commit report of message %x on chain %d: %w", t.msgID, t.dest, err)
+	}
+	update(ccipclient.MessageCommitted, seqNr, tx)
+
+	executionState, tx, err := t.waitExecuted(ctx, seqNr)
+	if err != nil {
+		return fmt.Errorf("failed to find execution of message %x on chain %d: %w", t.msgID, t.dest, err)
+	}
+	status := ccipclient.MessageExecutionSuccess
+	if executionState != EXECUTION_STATE_SUCCESS {
+		status = ccipclient.MessageExecutionFailure
+	}
+	update(status, seqNr, tx)
+	return nil
+}
+
+func (t *messageTracker) startBlock(chain uint64) uint64 {
+	if start := t.cfg.startBlocks[chain]; start != nil {
+		return *start
+	}
+	return 0
+}
+
+// poll calls check every poll interval until it is done.
+func (t *messageTracker) poll(ctx context.Context, check func() (bool, error)) error {
+	ticker := time.NewTicker(t.cfg.pollInterval)
+	defer ticker.Stop()
+	for {
+		if done, err := check(); err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitSent returns the sequence number of the message and the transaction sending it.
+func (t *messageTracker) waitSent(ctx context.Context) (seqNr uint64, tx string, err error) {
+	family, err := chainsel.GetSelectorFamily(t.src)
+	if err != nil {
+		return 0, "", err
+	}
+
+	switch family {
+	case chainsel.FamilyEVM:
+		onRamp := t.state.MustGetEVMChainState(t.src).OnRamp
+		err = t.poll(ctx, func() (bool, error) {
+			it, err := onRamp.FilterCCIPMessageSent(&bind.FilterOpts{Start: t.startBlock(t.src), Context: ctx}, []uint64{t.dest}, nil)
+			if err != nil {
+				return false, err
+			}
+			defer it.Close()
+			for it.Next() {
+				if it.Event.Message.Header.MessageId == t.msgID {
+					seqNr, tx = it.Event.SequenceNumber, it.Event.Raw.TxHash.Hex()
+					return true, nil
+				}
+			}
+			return false, it.Error()
+		})
+		return seqNr, tx, err
+	case chainsel.FamilySolana:
+		event, tx, err := waitSolEvent(ctx, t, t.src, t.state.SolChains[t.src].Router, consts.EventNameCCIPMessageSent,
+			func(event solccip.EventCCIPMessageSent) bool {
+				return event.DestinationChainSelector == t.dest && event.Message.Header.MessageId == t.msgID
+			})
+		return event.SequenceNumber, tx, err
+	case chainsel.FamilySui:
+		eventType := fmt.Sprintf("%s::onramp::CCIPMessageSent", t.state.SuiChains[t.src].OnRampAddress)
+		event, tx, err := waitSuiEvent(ctx, t, t.src, eventType, func(event sui_module_onramp.CCIPMessageSent) bool {
+			return event.DestChainSelector == t.dest && bytes.Equal(event.Message.Header.MessageId, t.msgID[:])
+		})
+		return event.SequenceNumber, tx, err
+	default:
+		return 0, "", fmt.Errorf("track message: unsupported source chain family: %v", family)
+	}
+}
+
+// waitCommitted returns the transaction of the commit report including the message.
+func (t *messageTracker) waitCommitted(ctx context.Context, seqNr uint64) (tx string, err error) {
+	family, err := chainsel.GetSelectorFamily(t.dest)
+	if err != nil {
+		return "", err
+	}
+	includes := func(sourceChainSelector, minSeqNr, maxSeqNr uint64) bool {
+		return sourceChainSelector == t.src && minSeqNr <= seqNr && seqNr <= maxSeqNr
+	}
+
+	switch family {
+	case chainsel.FamilyEVM:
+		offRamp := t.state.MustGetEVMChainState(t.dest).OffRamp
+		err = t.poll(ctx, func() (bool, error) {
+			it, err := offRamp.FilterCommitReportAccepted(&bind.FilterOpts{Start: t.startBlock(t.dest), Context: ctx})
+			if err != nil {
+				return false, err
+			}
+			defer it.Close()
+			for it.Next() {
+				for _, mr := range append(it.Event.BlessedMerkleRoots, it.Event.UnblessedMerkleRoots...) {
+					if includes(mr.SourceChainSelector, mr.MinSeqNr, mr.MaxSeqNr) {
+						tx = it.Event.Raw.TxHash.Hex()
+						return true, nil
+					}
+				}
+			}
+			return false, it.Error()
+		})
+		return tx, err
+	case chainsel.FamilySolana:
+		_, tx, err := waitSolEvent(ctx, t, t.dest, t.state.SolChains[t.dest].OffRamp, consts.EventNameCommitReportAccepted,
+			func(event solcommon.EventCommitReportAccepted) bool {
+				// Reports without merkle root only contain price updates
+				return event.Report != nil && includes(event.Report.SourceChainSelector, event.Report.MinSeqNr, event.Report.MaxSeqNr)
+			})
+		return tx, err
+	case chainsel.FamilySui:
+		eventType := fmt.Sprintf("%s::offramp::CommitReportAccepted", t.state.SuiChains[t.dest].OffRampAddress)
+		_, tx, err := waitSuiEvent(ctx, t, t.dest, eventType, func(event sui_module_offramp.CommitReportAccepted) bool {
+			for _, mr := range append(event.BlessedMerkleRoots, event.UnblessedMerkleRoots...) {
+				if includes(mr.SourceChainSelector, mr.MinSeqNr, mr.MaxSeqNr) {
+					return true
+				}
+			}
+			return false
+		})
+		return tx, err
+	default:
+		return "", fmt.Errorf("track message: unsupported destination chain family: %v", family)
+	}
+}
+
+// waitExecuted returns the final execution state of the message, and the transaction which executed it.
+func (t *messageTracker) waitExecuted(ctx context.Context, seqNr uint64) (executionState uint8, tx string, err error) {
+	family, err := chainsel.GetSelectorFamily(t.dest)
+	if err != nil {
+		return 0, "", err
+	}
+
+	switch family {
+	case chainsel.FamilyEVM:
+		offRamp := t.state.MustGetEVMChainState(t.dest).OffRamp
+		err = t.poll(ctx, func() (bool, error) {
+			it, err := offRamp.FilterExecutionStateChanged(&bind.FilterOpts{Start: t.startBlock(t.dest), Context: ctx},
+				[]uint64{t.src}, []uint64{seqNr}, [][32]byte{t.msgID})
+			if err != nil {
+				return false, err
+			}
+			defer it.Close()
+			var event *offramp.OffRampExecutionStateChanged
+			for it.Next() {
+				// Failed messages can be manually executed later, the last state wins
+				if it.Event.State != EXECUTION_STATE_INPROGRESS {
+					event = it.Event
+				}
+			}
+			if err := it.Error(); err != nil || event == nil {
+				return false, err
+			}
+			executionState, tx = event.State, event.Raw.TxHash.Hex()
+			return true, nil
+		})
+		return executionState, tx, err
+	case chainsel.FamilySolana:
+		event, tx, err := waitSolEvent(ctx, t, t.dest, t.state.SolChains[t.dest].OffRamp, consts.EventNameExecutionStateChanged,
+			func(event solccip.EventExecutionStateChanged) bool {
+				return event.MessageID == t.msgID && event.State != ccip_offramp.InProgress_MessageExecutionState
+			})
+		return uint8(event.State), tx, err
+	case chainsel.FamilySui:
+		eventType := fmt.Sprintf("%s::offramp::ExecutionStateChanged", t.state.SuiChains[t.dest].OffRampAddress)
+		event, tx, err := waitSuiEvent(ctx, t, t.dest, eventType, func(event sui_module_offramp.ExecutionStateChanged) bool {
+			return bytes.Equal(event.MessageId, t.msgID[:]) && event.State != EXECUTION_STATE_INPROGRESS
+		})
+		return event.State, tx, err
+	default:
+		return 0, "", fmt.Errorf("track message: unsupported destination chain family: %v", family)
+	}
+}
+
+// waitSolEvent returns the first event of the address matching the predicate, and the signature of its transaction.
+func waitSolEvent[T any](
+	ctx context.Context,
+	t *messageTracker,
+	chain uint64,
+	address solana.PublicKey,
+	eventType string,
+	match func(T) bool,
+) (T, string, error) {
+	var zero T
+	client := t.e.BlockChains.SolanaChains()[chain].Client
+	done := make(chan any)
+	defer close(done)
+	sink, errCh := SolEventEmitter[T](ctx, client, address, eventType, t.startBlock(chain), done, time.NewTicker(t.cfg.pollInterval))
+	for {
+		select {
+		case <-ctx.Done():
+			return zero, "", ctx.Err()
+		case err := <-errCh:
+			return zero, "", err
+		case eventWithTxn := <-sink:
+			if match(eventWithTxn.Event) {
+				return eventWithTxn.Event, solSignature(eventWithTxn.Txn), nil
+			}
+		}
+	}
+}
+
+func solSignature(txn *solrpc.GetTransactionResult) string {
+	tx, err := txn.Transaction.GetTransaction()
+	if err != nil || len(tx.Signatures) == 0 {
+		return ""
+	}
+	return tx.Signatures[0].String()
+}
+
+// waitSuiEvent returns the first event of the given type matching the predicate, and the digest of its transaction.
+func waitSuiEvent[T any](
+	ctx context.Context,
+	t *messageTracker,
+	chain uint64,
+	eventType string,
+	match func(T) bool,
+) (event T, tx string, err error) {
+	client := t.e.BlockChains.SuiChains()[chain].Client
+	var cursor any
+	err = t.poll(ctx, func() (bool, error) {
+		for {
+			events, err := client.SuiXQueryEvents(ctx, models.SuiXQueryEventsRequest{
+				SuiEventFilter: models.EventFilterByMoveEventType{MoveEventType: eventType},
+				Cursor:         cursor,
+				Limit:          50,
+			})
+			if err != nil {
+				return false, err
+			}
+			for _, ev := range events.Data {
+				var out T
+				if err := codec.DecodeAptosJsonValue(ev.ParsedJson, &out); err != nil {
+					return false, fmt.Errorf("failed to decode %s event: %w", eventType, err)
+				}
+				if match(out) {
+					event, tx = out, ev.Id.TxDigest
+					return true, nil
+				}
+			}
+			if len(events.Data) > 0 {
+				cursor = events.NextCursor
+			}
+			if !events.HasNextPage {
+				return false, nil
+			}
+		}
+	})
+	return event, tx, err
+}
@@ -0,0 +1,221 @@
+package testhelpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/block-vision/sui-go-sdk/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-aptos/relayer/codec"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_2_0/router"
+	solRouter "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_0/ccip_router"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	sui_module_onramp "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_onramp/onramp"
+
+	ccipclient "github.com/smartcontractkit/chainlink/deployment/ccip/shared/client"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+)
+
+// TransferHop is a lane of a multi-hop transfer. Its source chain is the destination chain of the previous hop, or the
+// source chain of the transfer for the first hop.
+type TransferHop struct {
+	DestChain uint64
+	// Receiver is the receiver of the message on DestChain. The receivers of all hops but the last one are
+	// receive-and-forward receivers: they send the tokens they receive to the next hop in the transaction executing
+	// the message.
+	Receiver []byte
+}
+
+// MultiHopTransferRequest is a transfer chained across several lanes, e.g. EVM A -> Sui -> EVM B.
+type MultiHopTransferRequest struct {
+	Name        string
+	SourceChain uint64
+	Hops        []TransferHop
+	// The tokens of the first hop, depending on the family of the source chain
+	Tokens      []router.ClientEVMTokenAmount
+	SolTokens   []solRouter.SVMTokenAmount
+	AptosTokens []AptosTokenAmount
+	SuiTokens   []SuiTokenAmount
+	// Data, ExtraArgs and FeeToken of the first hop, the next hops are built by the forwarders
+	Data          []byte
+	ExtraArgs     []byte
+	FeeToken      string
+	UseTestRouter bool
+	// ExpectedTokenBalances are the balances expected on the destination chain of the last hop, for its receiver or for
+	// TokenReceiver if set, e.g. the token account of the receiver on Solana and Sui.
+	ExpectedTokenBalances []ExpectedBalance
+	TokenReceiver         []byte
+}
+
+// MultiHopTransfer sends the first hop of the transfer, then follows the message across each hop: it waits for the
+// message to be committed and successfully executed on the destination chain of the hop, and finds the message sent
+// by the forwarder in the execution transaction to follow it on the next hop. Finally, it waits for the expected token
+// balances on the destination chain of the last hop.
+// It returns the final status update of the message of each hop.
+// Forwarders are supported on EVM and Sui chains.
+func MultiHopTransfer(
+	ctx context.Context,
+	t *testing.T,
+	env cldf.Environment,
+	state stateview.CCIPOnChainState,
+	req MultiHopTransferRequest,
+) []ccipclient.MessageStatusUpdate {
+	require.NotEmpty(t, req.Hops, "multi-hop transfer %q has no hop", req.Name)
+
+	// Events are searched from the blocks before the first hop, as the next hops are sent by the forwarders
+	startBlocks := make(map[uint64]*uint64)
+	for _, hop := range req.Hops {
+		block, err := LatestBlock(ctx, env, hop.DestChain)
+		require.NoError(t, err)
+		startBlocks[hop.DestChain] = &block
+	}
+
+	family, err := chainsel.GetSelectorFamily(req.SourceChain)
+	require.NoError(t, err)
+	var tokens any
+	switch family {
+	case chainsel.FamilyEVM:
+		tokens = req.Tokens
+	case chainsel.FamilySolana:
+		tokens = req.SolTokens
+	case chainsel.FamilyAptos:
+		tokens = req.AptosTokens
+	case chainsel.FamilySui:
+		tokens = req.SuiTokens
+	default:
+		require.FailNow(t, "unsupported source chain", "family %v", family)
+	}
+
+	first := req.Hops[0]
+	msgSent, _ := Transfer(ctx, t, env, state, req.SourceChain, first.DestChain, tokens, first.Receiver,
+		req.UseTestRouter, req.Data, req.ExtraArgs, req.FeeToken)
+	msgID, _, err := messageSentInfo(msgSent)
+	require.NoError(t, err)
+
+	src := req.SourceChain
+	finalUpdates := make([]ccipclient.MessageStatusUpdate, 0, len(req.Hops))
+	for i, hop := range req.Hops {
+		final := waitHop(ctx, t, env, state, src, hop.DestChain, msgID, startBlocks)
+		require.Equal(t, ccipclient.MessageExecutionSuccess, final.Status,
+			"message %x of hop %d (%d -> %d) of %q was not executed successfully", msgID, i, src, hop.DestChain, req.Name)
+		finalUpdates = append(finalUpdates, final)
+
+		if i < len(req.Hops)-1 {
+			next := req.Hops[i+1].DestChain
+			msgID, err = forwardedMessageID(ctx, env, state, hop.DestChain, next, final.Tx)
+			require.NoError(t, err, "failed to find the message forwarded by %x on chain %d", hop.Receiver, hop.DestChain)
+			t.Logf("Message of %q forwarded by %x on chain %d to chain %d: %x", req.Name, hop.Receiver, hop.DestChain, next, msgID)
+		}
+		src = hop.DestChain
+	}
+
+	last := req.Hops[len(req.Hops)-1]
+	receiver := last.Receiver
+	if len(req.TokenReceiver) > 0 {
+		receiver = req.TokenReceiver
+	}
+	expectedTokenBalances := make(TokenBalanceAccumulator)
+	expectedTokenBalances.add(last.DestChain, receiver, req.ExpectedTokenBalances)
+	WaitForTokenBalances(ctx, t, env, expectedTokenBalances)
+	return finalUpdates
+}
+
+// waitHop tracks the message of a hop until it reached a final state, which is returned. The commit of the message is
+// required before its execution.
+func waitHop(
+	ctx context.Context,
+	t *testing.T,
+	env cldf.Environment,
+	state stateview.CCIPOnChainState,
+	src, dest uint64,
+	msgID [32]byte,
+	startBlocks map[uint64]*uint64,
+) ccipclient.MessageStatusUpdate {
+	updates, errCh := TrackMessage(ctx, env, state, src, dest, msgID, WithTrackStartBlocks(startBlocks))
+	var committed bool
+	var final ccipclient.MessageStatusUpdate
+	for update := range updates {
+		t.Logf("Message %x from chain %d to chain %d: %s in %s", msgID, src, dest, update.Status, update.Tx)
+		switch update.Status {
+		case ccipclient.MessageCommitted:
+			committed = true
+		case ccipclient.MessageExecutionSuccess, ccipclient.MessageExecutionFailure:
+			final = update
+		}
+	}
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	default:
+	}
+	require.True(t, committed, "message %x from chain %d to chain %d was not committed", msgID, src, dest)
+	require.NotEmpty(t, final.Status, "message %x from chain %d to chain %d was not executed", msgID, src, dest)
+	return final
+}
+
+// forwardedMessageID returns the ID of the message sent to the next chain in the given transaction of the forwarding
+// chain, i.e. the message sent by the forwarder when receiving the previous hop.
+func forwardedMessageID(
+	ctx context.Context,
+	env cldf.Environment,
+	state stateview.CCIPOnChainState,
+	chain, next uint64,
+	tx string,
+) (msgID [32]byte, err error) {
+	family, err := chainsel.GetSelectorFamily(chain)
+	if err != nil {
+		return msgID, err
+	}
+
+	switch family {
+	case chainsel.FamilyEVM:
+		receipt, err := env.BlockChains.EVMChains()[chain].Client.TransactionReceipt(ctx, common.HexToHash(tx))
+		if err != nil {
+			return msgID, fmt.Errorf("failed to get receipt of transaction %s: %w", tx, err)
+		}
+		onRamp := state.MustGetEVMChainState(chain).OnRamp
+		for _, log := range receipt.Logs {
+			if log.Address != onRamp.Address() {
+				continue
+			}
+			event, err := onRamp.ParseCCIPMessageSent(*log)
+			if err != nil {
+				continue // not a CCIPMessageSent event
+			}
+			if event.DestChainSelector == next {
+				return event.Message.Header.MessageId, nil
+			}
+		}
+	case chainsel.FamilySui:
+		resp, err := env.BlockChains.SuiChains()[chain].Client.SuiGetTransactionBlock(ctx, models.SuiGetTransactionBlockRequest{
+			Digest:  tx,
+			Options: models.SuiTransactionBlockOptions{ShowEvents: true},
+		})
+		if err != nil {
+			return msgID, fmt.Errorf("failed to get transaction %s: %w", tx, err)
+		}
+		eventType := fmt.Sprintf("%s::onramp::CCIPMessageSent", state.SuiChains[chain].OnRampAddress)
+		for _, ev := range resp.Events {
+			if ev.Type != eventType {
+				continue
+			}
+			var event sui_module_onramp.CCIPMessageSent
+			if err := codec.DecodeAptosJsonValue(ev.ParsedJson, &event); err != nil {
+				return msgID, fmt.Errorf("failed to decode %s event: %w", eventType, err)
+			}
+			if event.DestChainSelector == next {
+				copy(msgID[:], event.Message.Header.MessageId)
+				return msgID, nil
+			}
+		}
+	default:
+		return msgID, fmt.Errorf("unsupported forwarding chain family: %v", family)
+	}
+	return msgID, errors.New("no message sent to the next chain in the execution transaction")
+}
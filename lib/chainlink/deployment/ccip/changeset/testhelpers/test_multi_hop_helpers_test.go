@@ -0,0 +1,117 @@
+package testhelpers
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/onramp"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
+)
+
+// receiptClient returns the receipts of its transactions.
+type receiptClient struct {
+	cldf_evm.OnchainClient
+	receipts map[common.Hash]*types.Receipt
+}
+
+func (c *receiptClient) TransactionReceipt(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, ok := c.receipts[hash]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return receipt, nil
+}
+
+// messageSentLog returns a CCIPMessageSent log of the onRamp for a message with the given ID to destChain.
+func messageSentLog(t *testing.T, onRamp common.Address, destChain uint64, msgID [32]byte) *types.Log {
+	parsed, err := onramp.OnRampMetaData.GetAbi()
+	require.NoError(t, err)
+	event := parsed.Events["CCIPMessageSent"]
+	data, err := event.Inputs.NonIndexed().Pack(onramp.InternalEVM2AnyRampMessage{
+		Header:         onramp.InternalRampMessageHeader{MessageId: msgID, DestChainSelector: destChain, SequenceNumber: 1},
+		FeeTokenAmount: big.NewInt(0),
+		FeeValueJuels:  big.NewInt(0),
+		TokenAmounts:   []onramp.InternalEVM2AnyTokenTransfer{},
+	})
+	require.NoError(t, err)
+	return &types.Log{
+		Address: onRamp,
+		Topics: []common.Hash{
+			event.ID,
+			common.BigToHash(new(big.Int).SetUint64(destChain)),
+			common.BigToHash(big.NewInt(1)),
+		},
+		Data: data,
+	}
+}
+
+func TestForwardedMessageID(t *testing.T) {
+	forwarder := chainsel.TEST_90000001.Selector
+	next := chainsel.TEST_90000002.Selector
+	other := chainsel.TEST_90000003.Selector
+	onRampAddress := common.HexToAddress("0x0a")
+	msgID := [32]byte{1, 2, 3}
+
+	client := &receiptClient{receipts: map[common.Hash]*types.Receipt{
+		common.HexToHash("0x01"): {Logs: []*types.Log{
+			// a message sent by another onRamp is ignored
+			messageSentLog(t, common.HexToAddress("0x0b"), next, [32]byte{9}),
+			// as well as a message sent to another chain, or another event of the onRamp
+			messageSentLog(t, onRampAddress, other, [32]byte{8}),
+			{Address: onRampAddress, Topics: []common.Hash{{}}},
+			messageSentLog(t, onRampAddress, next, msgID),
+		}},
+		common.HexToHash("0x02"): {Logs: []*types.Log{
+			messageSentLog(t, onRampAddress, other, [32]byte{8}),
+		}},
+	}}
+	onRamp, err := onramp.NewOnRamp(onRampAddress, client)
+	require.NoError(t, err)
+
+	state, err := (&stateview.Snapshot{Version: stateview.SnapshotVersion}).State()
+	require.NoError(t, err)
+	state.WriteEVMChainState(forwarder, evm.CCIPChainState{OnRamp: onRamp})
+	env := cldf.Environment{
+		BlockChains: chain.NewBlockChains(map[uint64]chain.BlockChain{
+			forwarder: cldf_evm.Chain{Selector: forwarder, Client: client},
+		}),
+	}
+
+	tests := []struct {
+		name      string
+		chain     uint64
+		tx        string
+		want      [32]byte
+		wantErrRe string
+	}{
+		{name: "message forwarded", chain: forwarder, tx: "0x01", want: msgID},
+		{name: "no message to the next chain", chain: forwarder, tx: "0x02", wantErrRe: "no message sent to the next chain"},
+		{name: "unknown transaction", chain: forwarder, tx: "0x03", wantErrRe: "failed to get receipt of transaction 0x03"},
+		{name: "unsupported family", chain: chainsel.APTOS_LOCALNET.Selector, tx: "0x01", wantErrRe: "unsupported forwarding chain family: aptos"},
+		{name: "unknown selector", chain: 1, tx: "0x01", wantErrRe: "unknown chain selector"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := forwardedMessageID(t.Context(), env, state, tt.chain, next, tt.tx)
+			if tt.wantErrRe != "" {
+				require.Error(t, err)
+				require.Regexp(t, tt.wantErrRe, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/block-vision/sui-go-sdk/models"
 	"github.com/block-vision/sui-go-sdk/sui"
 	suitx "github.com/block-vision/sui-go-sdk/transaction"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/stretchr/testify/require"
 
 	chainsel "github.com/smartcontractkit/chain-selectors"
@@ -26,6 +27,9 @@ import (
 	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
 	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/burn_mint_erc677"
 	suiBind "github.com/smartcontractkit/chainlink-sui/bindings/bind"
+	module_burn_mint_token_pool "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_token_pools/burn_mint_token_pool"
+	module_lock_release_token_pool "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_token_pools/lock_release_token_pool"
+	suistate "github.com/smartcontractkit/chainlink-sui/deployment"
 	sui_cs "github.com/smartcontractkit/chainlink-sui/deployment/changesets"
 	sui_ops "github.com/smartcontractkit/chainlink-sui/deployment/ops"
 	ccipops "github.com/smartcontractkit/chainlink-sui/deployment/ops/ccip"
@@ -40,20 +44,10 @@ import (
 
 const TokenSymbolLINK = "LINK"
 
-type SuiSendRequest struct {
-	Receiver         []byte
-	Data             []byte
-	ExtraArgs        []byte
-	FeeToken         string
-	FeeTokenStore    string
-	TokenAmounts     []SuiTokenAmount
-	TokenReceiverATA []byte
-}
-
-type SuiTokenAmount struct {
-	Token  string
-	Amount uint64
-}
+type (
+	SuiSendRequest = ccipclient.SuiSendRequest
+	SuiTokenAmount = ccipclient.SuiTokenAmount
+)
 
 type RampMessageHeader struct {
 	MessageID           []byte `json:"message_id"`
@@ -524,7 +518,51 @@ func MakeSuiExtraArgs(gasLimit uint64, allowOOO bool, receiverObjectIDs [][32]by
 	return extraArgs
 }
 
-func HandleTokenAndPoolDeploymentForSUI(e cldf.Environment, suiChainSel, evmChainSel uint64) (cldf.Environment, *burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, error) {
+// SuiTokenDecimals are the decimals of a token transferred between an EVM chain and a Sui chain.
+type SuiTokenDecimals struct {
+	EVM uint8
+	Sui uint8
+}
+
+// DefaultSuiTokenDecimals are the decimals of the token deployed by HandleTokenAndPoolDeploymentForSUI by default.
+var DefaultSuiTokenDecimals = SuiTokenDecimals{EVM: 18, Sui: 9}
+
+// ToSui returns the amount released on the Sui chain for an amount sent from the EVM chain.
+func (d SuiTokenDecimals) ToSui(evmAmount *big.Int) *big.Int {
+	return ScaleTokenAmount(evmAmount, d.EVM, d.Sui)
+}
+
+// ToEVM returns the amount released on the EVM chain for an amount sent from the Sui chain.
+func (d SuiTokenDecimals) ToEVM(suiAmount uint64) *big.Int {
+	return ScaleTokenAmount(new(big.Int).SetUint64(suiAmount), d.Sui, d.EVM)
+}
+
+// ScaleTokenAmount converts an amount of a token with srcDecimals to the amount of the same token with destDecimals,
+// as done by the token pools of the destination chain: the amount is truncated when the destination has fewer
+// decimals.
+func ScaleTokenAmount(amount *big.Int, srcDecimals, destDecimals uint8) *big.Int {
+	if srcDecimals > destDecimals {
+		return new(big.Int).Quo(amount, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(srcDecimals-destDecimals)), nil))
+	}
+	return new(big.Int).Mul(amount, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(destDecimals-srcDecimals)), nil))
+}
+
+type SuiTokenDeploymentOption func(*SuiTokenDecimals)
+
+// WithSuiTokenDecimals sets the decimals of the token of HandleTokenAndPoolDeploymentForSUI. The EVM token is deployed
+// with the EVM decimals. The Sui token is the LINK coin of the chain, whose decimals are fixed when it is published:
+// the Sui decimals are only validated against it.
+func WithSuiTokenDecimals(decimals SuiTokenDecimals) SuiTokenDeploymentOption {
+	return func(d *SuiTokenDecimals) {
+		*d = decimals
+	}
+}
+
+func HandleTokenAndPoolDeploymentForSUI(e cldf.Environment, suiChainSel, evmChainSel uint64, opts ...SuiTokenDeploymentOption) (cldf.Environment, *burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, error) {
+	decimals := DefaultSuiTokenDecimals
+	for _, opt := range opts {
+		opt(&decimals)
+	}
 	suiChains := e.BlockChains.SuiChains()
 	suiChain := suiChains[suiChainSel]
 
@@ -543,7 +581,7 @@ func HandleTokenAndPoolDeploymentForSUI(e cldf.Environment, suiChainSel, evmChai
 	linkTokenTreasuryCapID := state.SuiChains[suiChainSel].LinkTokenTreasuryCapId
 
 	// Deploy transferrable token on EVM
-	evmToken, evmPool, err := deployTransferTokenOneEnd(e.Logger, evmChain, evmDeployerKey, e.ExistingAddresses, "TOKEN")
+	evmToken, evmPool, err := deployTransferTokenOneEndWithDecimals(e.Logger, evmChain, evmDeployerKey, e.ExistingAddresses, "TOKEN", decimals.EVM)
 	if err != nil {
 		return cldf.Environment{}, nil, nil, errors.New("failed to deploy transfer token for evm chain " + err.Error())
 	}
@@ -641,9 +679,55 @@ func HandleTokenAndPoolDeploymentForSUI(e cldf.Environment, suiChainSel, evmChai
 		return cldf.Environment{}, nil, nil, errors.New("failed to grant burnMint " + err.Error())
 	}
 
+	err = validateSuiTokenPoolDecimals(e, suiChainSel, linkTokenPkgID+"::link::LINK", bnmTokenPool, evmPool, decimals)
+	if err != nil {
+		return cldf.Environment{}, nil, nil, err
+	}
+
 	return e, evmToken, evmPool, nil
 }
 
+// validateSuiTokenPoolDecimals checks that the decimals of the Sui coin and of the token pools on both sides match the
+// expected decimals, so that amounts are scaled as expected between the chains.
+func validateSuiTokenPoolDecimals(
+	e cldf.Environment,
+	suiChainSel uint64,
+	coinType string,
+	suiPool suistate.CCIPPoolState,
+	evmPool *burn_mint_token_pool.BurnMintTokenPool,
+	expected SuiTokenDecimals,
+) error {
+	suiChain := e.BlockChains.SuiChains()[suiChainSel]
+	metadata, err := suiChain.Client.SuiXGetCoinMetadata(e.GetContext(), models.SuiXGetCoinMetadataRequest{CoinType: coinType})
+	if err != nil {
+		return fmt.Errorf("failed to get metadata of coin %s: %w", coinType, err)
+	}
+	if metadata.Decimals != int(expected.Sui) {
+		return fmt.Errorf("coin %s has %d decimals, expected %d", coinType, metadata.Decimals, expected.Sui)
+	}
+
+	pool, err := module_burn_mint_token_pool.NewBurnMintTokenPool(suiPool.PackageID, suiChain.Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind Sui burn mint token pool: %w", err)
+	}
+	suiPoolDecimals, err := pool.DevInspect().GetTokenDecimals(e.GetContext(), &suiBind.CallOpts{Signer: suiChain.Signer}, []string{coinType}, suiBind.Object{Id: suiPool.StateObjectId})
+	if err != nil {
+		return fmt.Errorf("failed to get decimals of Sui token pool: %w", err)
+	}
+	if suiPoolDecimals != expected.Sui {
+		return fmt.Errorf("sui token pool has %d decimals, expected %d", suiPoolDecimals, expected.Sui)
+	}
+
+	evmPoolDecimals, err := evmPool.GetTokenDecimals(&bind.CallOpts{Context: e.GetContext()})
+	if err != nil {
+		return fmt.Errorf("failed to get decimals of EVM token pool: %w", err)
+	}
+	if evmPoolDecimals != expected.EVM {
+		return fmt.Errorf("evm token pool has %d decimals, expected %d", evmPoolDecimals, expected.EVM)
+	}
+	return nil
+}
+
 func WaitForTokenBalanceSui(
 	ctx context.Context,
 	t *testing.T,
@@ -667,3 +751,82 @@ func WaitForTokenBalanceSui(
 		return balance.Cmp(expected) == 0
 	}, tests.WaitTimeout(t), 500*time.Millisecond)
 }
+
+// AssertSuiLockReleasePoolBalance asserts that the lock release token pool of tokenSymbol on a Sui chain eventually
+// holds the expected balance.
+func AssertSuiLockReleasePoolBalance(t *testing.T, e cldf.Environment, chainSel uint64, tokenSymbol string, coinType string, expected uint64) {
+	suiChain := e.BlockChains.SuiChains()[chainSel]
+	state, err := stateview.LoadOnchainState(e)
+	require.NoError(t, err)
+	poolState, ok := state.SuiChains[chainSel].LnRTokenPools[tokenSymbol]
+	require.True(t, ok, "missing %s lock release token pool on sui chain %d", tokenSymbol, chainSel)
+	pool, err := module_lock_release_token_pool.NewLockReleaseTokenPool(poolState.PackageID, suiChain.Client)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		balance, err := pool.DevInspect().GetBalance(t.Context(), &suiBind.CallOpts{Signer: suiChain.Signer}, []string{coinType}, suiBind.Object{Id: poolState.StateObjectId})
+		require.NoError(t, err)
+		return balance == expected
+	}, tests.WaitTimeout(t), 500*time.Millisecond)
+}
+
+// DeployUSDCPoolsSui deploys the USDC token pool of a Sui chain wired to the CCTP packages of cfg, and connects it
+// with the USDC token pool of an EVM chain, which must already be deployed. It returns the USDC state of the Sui chain.
+func DeployUSDCPoolsSui(
+	t *testing.T,
+	e cldf.Environment,
+	cfg suideps.DeployUSDCTokenPoolConfig,
+	evmChainSel uint64,
+	evmDomainIdentifier uint32,
+) (cldf.Environment, suideps.USDCChainState) {
+	e, err := commoncs.Apply(t, e,
+		commoncs.Configure(suideps.DeployUSDCTokenPool{}, cfg),
+		commoncs.Configure(suideps.ConfigureUSDCTokenPoolsSuiEVM{}, suideps.ConfigureUSDCTokenPoolsSuiEVMConfig{
+			SuiChainSelector:     cfg.SuiChainSelector,
+			CoinType:             cfg.CoinType,
+			SuiDomainIdentifier:  cfg.LocalDomainIdentifier,
+			EVMDomainIdentifiers: map[uint64]uint32{evmChainSel: evmDomainIdentifier},
+		}),
+	)
+	require.NoError(t, err)
+
+	usdcStates, err := suideps.LoadUSDCChainStates(e)
+	require.NoError(t, err)
+	usdcState := usdcStates[cfg.SuiChainSelector]
+	require.NotEmpty(t, usdcState.TokenPoolStateObjectId)
+	return e, usdcState
+}
+
+// DeploySuiDummyReceiver deploys the dummy receiver of a Sui chain and registers it, and returns the result of its
+// deployment.
+func DeploySuiDummyReceiver(t *testing.T, e cldf.Environment, suiChainSel uint64) sui_ops.OpTxResult[ccipops.DeployDummyReceiverObjects] {
+	state, err := stateview.LoadOnchainState(e)
+	require.NoError(t, err)
+
+	const deployStep = "deploy-dummy-receiver"
+	deployedReceiver := func(outputs commoncs.PipelineOutputs) (sui_ops.OpTxResult[ccipops.DeployDummyReceiverObjects], error) {
+		return commoncs.ReportOutput[sui_ops.OpTxResult[ccipops.DeployDummyReceiverObjects]](outputs, deployStep)
+	}
+	pipeline, err := commoncs.NewPipeline(
+		commoncs.StaticStep(deployStep, commoncs.Configure(sui_cs.DeployDummyReceiver{}, sui_cs.DeployDummyReceiverConfig{
+			SuiChainSelector: suiChainSel,
+			McmsOwner:        "0x1",
+		})),
+		commoncs.Step("register-dummy-receiver", sui_cs.RegisterDummyReceiver{}, func(outputs commoncs.PipelineOutputs) (sui_cs.RegisterDummyReceiverConfig, error) {
+			receiver, err := deployedReceiver(outputs)
+			return sui_cs.RegisterDummyReceiverConfig{
+				SuiChainSelector:       suiChainSel,
+				OwnerCapObjectId:       receiver.Objects.OwnerCapObjectId,
+				CCIPObjectRefObjectId:  state.SuiChains[suiChainSel].CCIPObjectRef,
+				DummyReceiverPackageId: receiver.PackageId,
+			}, err
+		}, deployStep),
+	)
+	require.NoError(t, err)
+	_, outputs, err := pipeline.Run(t, e)
+	require.NoError(t, err)
+
+	receiver, err := deployedReceiver(outputs)
+	require.NoError(t, err)
+	return receiver
+}
@@ -203,6 +203,8 @@ func DeployTestTokenPools(
 					timelockOwnedContractsByChain[selector] = getPoolsOwnedByDeployer(t, state.MustGetEVMChainState(selector).BurnMintWithExternalMinterFastTransferTokenPools[TestTokenSymbol], e.BlockChains.EVMChains()[selector])
 				case shared.HybridWithExternalMinterFastTransferTokenPool:
 					timelockOwnedContractsByChain[selector] = getPoolsOwnedByDeployer(t, state.MustGetEVMChainState(selector).HybridWithExternalMinterFastTransferTokenPools[TestTokenSymbol], e.BlockChains.EVMChains()[selector])
+				case shared.SiloedLockReleaseTokenPool:
+					timelockOwnedContractsByChain[selector] = getPoolsOwnedByDeployer(t, state.MustGetEVMChainState(selector).SiloedLockReleaseTokenPools[TestTokenSymbol], e.BlockChains.EVMChains()[selector])
 				default:
 				}
 			}
@@ -333,6 +333,7 @@ func streamEvents[T any](
 		loader := tonlploader.NewTxLoader(lggr, clientProvider, txBatchSize)
 
 		lastProcessedBlock := startBlock
+		var rpcErrors rpcErrorBudget
 
 		for {
 			select {
@@ -342,8 +343,11 @@ func streamEvents[T any](
 				// 1. Get block range
 				blockRange, newSeqNo, err := getBlockRange(ctx, tonChain, lastProcessedBlock)
 				if err != nil {
-					errorCh <- err
-					return
+					if rpcErrors.exhausted() {
+						errorCh <- err
+						return
+					}
+					continue // retried on the next tick
 				}
 
 				// Skip if no new blocks
@@ -354,9 +358,13 @@ func streamEvents[T any](
 				// 2. Fetch transactions
 				txs, err := loader.FetchTxsForAddress(ctx, blockRange, contract)
 				if err != nil {
-					errorCh <- fmt.Errorf("failed to load transactions: %w", err)
-					return
+					if rpcErrors.exhausted() {
+						errorCh <- fmt.Errorf("failed to load transactions: %w", err)
+						return
+					}
+					continue // retried on the next tick
 				}
+				rpcErrors.reset()
 
 				// 3. Extract and filter events
 				events, err := extractEventMessage[T](txs, lggr, eventName)
@@ -0,0 +1,100 @@
+package ton
+
+import (
+	"fmt"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	ops "github.com/smartcontractkit/chainlink-ton/deployment/ccip"
+	tonOperation "github.com/smartcontractkit/chainlink-ton/deployment/ccip/operation"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/globals"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/internal"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_6"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+)
+
+var _ cldf.ChangeSetV2[v1_6.SetOCR3OffRampConfig] = SetOCR3Offramp{}
+
+// SetOCR3Offramp sets the OCR3 configs of the offramps of TON chains to the configs of their DONs in the CCIPHome
+// of the home chain.
+type SetOCR3Offramp struct{}
+
+// Apply implements deployment.ChangeSetV2.
+func (s SetOCR3Offramp) Apply(e cldf.Environment, config v1_6.SetOCR3OffRampConfig) (cldf.ChangesetOutput, error) {
+	state, err := stateview.LoadOnchainState(e, stateview.WithLoadLegacyContracts(true))
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	configType := config.CCIPHomeConfigType
+	if configType == "" {
+		configType = globals.ConfigTypeActive
+	}
+
+	var reports []operations.Report[any, any]
+	for _, remoteSelector := range config.RemoteChainSels {
+		configs, err := ocr3Configs(state, config.HomeChainSel, remoteSelector, configType)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to build OCR3 configs for chain %d: %w", remoteSelector, err)
+		}
+		// the configs are specific to each chain, so they are set one chain at a time
+		out, err := ops.SetOCR3Config{}.Apply(e, ops.SetOCR3OffRampConfig{
+			RemoteChainSels: []uint64{remoteSelector},
+			Configs:         configs,
+		})
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to set OCR3 configs for chain %d: %w", remoteSelector, err)
+		}
+		reports = append(reports, out.Reports...)
+	}
+
+	return cldf.ChangesetOutput{
+		Reports: reports,
+	}, nil
+}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (s SetOCR3Offramp) VerifyPreconditions(e cldf.Environment, config v1_6.SetOCR3OffRampConfig) error {
+	if _, ok := e.BlockChains.EVMChains()[config.HomeChainSel]; !ok {
+		return fmt.Errorf("home chain %d not found in environment", config.HomeChainSel)
+	}
+	return ops.SetOCR3Config{}.VerifyPreconditions(e, ops.SetOCR3OffRampConfig{RemoteChainSels: config.RemoteChainSels})
+}
+
+// ocr3Configs returns the OCR3 configs of the offramp of chainSelector, by plugin type.
+func ocr3Configs(state stateview.CCIPOnChainState, homeChainSelector uint64, chainSelector uint64, configType globals.ConfigType) (map[tonOperation.PluginType]tonOperation.OCR3ConfigArgs, error) {
+	donID, err := internal.DonIDForChain(
+		state.Chains[homeChainSelector].CapabilityRegistry,
+		state.Chains[homeChainSelector].CCIPHome,
+		chainSelector,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DON ID: %w", err)
+	}
+
+	// TON uses the same config args as Aptos
+	ocr3Args, err := internal.BuildSetOCR3ConfigArgsAptos(
+		donID,
+		state.Chains[homeChainSelector].CCIPHome,
+		chainSelector,
+		configType,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OCR3 config args: %w", err)
+	}
+
+	configs := make(map[tonOperation.PluginType]tonOperation.OCR3ConfigArgs, len(ocr3Args))
+	for _, arg := range ocr3Args {
+		configs[tonOperation.PluginType(arg.OcrPluginType)] = tonOperation.OCR3ConfigArgs{
+			ConfigDigest:                   arg.ConfigDigest,
+			PluginType:                     tonOperation.PluginType(arg.OcrPluginType),
+			F:                              arg.F,
+			IsSignatureVerificationEnabled: arg.IsSignatureVerificationEnabled,
+			Signers:                        arg.Signers,
+			Transmitters:                   arg.Transmitters,
+		}
+	}
+	return configs, nil
+}
@@ -0,0 +1,133 @@
+package ton_test
+
+import (
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xssnick/tonutils-go/tlb"
+
+	cldf_chain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	tonOperation "github.com/smartcontractkit/chainlink-ton/deployment/ccip/operation"
+	"github.com/smartcontractkit/chainlink-ton/pkg/ccip/bindings/offramp"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/globals"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/internal"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/ton"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_6"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+)
+
+func TestSetOCR3Offramp_VerifyPreconditions(t *testing.T) {
+	homeChainSel := chainsel.TEST_90000001.Selector
+	env := cldf.Environment{
+		BlockChains: cldf_chain.NewBlockChainsFromSlice([]cldf_chain.BlockChain{cldf_evm.Chain{Selector: homeChainSel}}),
+	}
+
+	tests := []struct {
+		name    string
+		config  v1_6.SetOCR3OffRampConfig
+		wantErr string
+	}{
+		{
+			name: "home chain not in environment",
+			config: v1_6.SetOCR3OffRampConfig{
+				HomeChainSel:    chainsel.TEST_90000002.Selector,
+				RemoteChainSels: []uint64{chainsel.TON_LOCALNET.Selector},
+			},
+			wantErr: "not found in environment",
+		},
+		{
+			name: "remote chain not a TON chain",
+			config: v1_6.SetOCR3OffRampConfig{
+				HomeChainSel:    homeChainSel,
+				RemoteChainSels: []uint64{homeChainSel},
+			},
+			wantErr: "is not an Ton chain",
+		},
+		{
+			name: "TON chain not in environment",
+			config: v1_6.SetOCR3OffRampConfig{
+				HomeChainSel:    homeChainSel,
+				RemoteChainSels: []uint64{chainsel.TON_LOCALNET.Selector},
+			},
+			wantErr: "is not in Ton env",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ton.SetOCR3Offramp{}.VerifyPreconditions(env, tt.config)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSetOCR3Offramp(t *testing.T) {
+	tenv, _ := testhelpers.NewMemoryEnvironment(t, testhelpers.WithTonChains(1))
+	e := tenv.Env
+	tonChainSel := e.BlockChains.ListChainSelectors(cldf_chain.WithFamily(chainsel.FamilyTon))[0]
+
+	e, err := commonchangeset.Apply(t, e, commonchangeset.Configure(
+		ton.SetOCR3Offramp{},
+		v1_6.SetOCR3OffRampConfig{
+			HomeChainSel:       tenv.HomeChainSel,
+			RemoteChainSels:    []uint64{tonChainSel},
+			CCIPHomeConfigType: globals.ConfigTypeActive,
+		},
+	))
+	require.NoError(t, err)
+
+	state, err := stateview.LoadOnchainState(e, stateview.WithLoadLegacyContracts(true))
+	require.NoError(t, err)
+	homeChain := state.Chains[tenv.HomeChainSel]
+	donID, err := internal.DonIDForChain(homeChain.CapabilityRegistry, homeChain.CCIPHome, tonChainSel)
+	require.NoError(t, err)
+	ocr3Args, err := internal.BuildSetOCR3ConfigArgsAptos(donID, homeChain.CCIPHome, tonChainSel, globals.ConfigTypeActive)
+	require.NoError(t, err)
+	expected := make(map[tonOperation.PluginType]internal.MultiOCR3BaseOCRConfigArgsAptos, len(ocr3Args))
+	for _, args := range ocr3Args {
+		expected[tonOperation.PluginType(args.OcrPluginType)] = args
+	}
+
+	// The ocr3Config getter of the offramp returns the commit config at index 1 and the exec config at index 2
+	tonChain := e.BlockChains.TonChains()[tonChainSel]
+	offRamp := state.TonChains[tonChainSel].OffRamp
+	block, err := tonChain.Client.CurrentMasterchainInfo(t.Context())
+	require.NoError(t, err)
+	result, err := tonChain.Client.RunGetMethod(t.Context(), block, &offRamp, "ocr3Config")
+	require.NoError(t, err)
+
+	for pluginType, index := range map[tonOperation.PluginType]uint{
+		tonOperation.PluginTypeCCIPCommit: 1,
+		tonOperation.PluginTypeCCIPExec:   2,
+	} {
+		want, ok := expected[pluginType]
+		require.True(t, ok, "no config for plugin type %d in CCIPHome", pluginType)
+
+		configCell, err := result.Cell(index)
+		require.NoError(t, err, "no config for plugin type %d on the offramp", pluginType)
+		var got offramp.OCR3Config
+		require.NoError(t, tlb.LoadFromCell(&got, configCell.BeginParse()))
+
+		assert.Equal(t, want.ConfigDigest[:], got.ConfigInfo.ConfigDigest)
+		assert.Equal(t, want.F, got.ConfigInfo.F)
+		assert.Equal(t, want.IsSignatureVerificationEnabled, got.ConfigInfo.IsSignatureVerificationEnabled)
+		assert.Len(t, want.Signers, int(got.ConfigInfo.N))
+
+		signers, err := got.Signers.LoadAll()
+		require.NoError(t, err)
+		gotSigners := make([][]byte, 0, len(signers))
+		for _, entry := range signers {
+			signer, err := entry.Key.LoadSlice(256)
+			require.NoError(t, err)
+			gotSigners = append(gotSigners, signer)
+		}
+		assert.ElementsMatch(t, want.Signers, gotSigners)
+	}
+}
@@ -0,0 +1,12 @@
+// Package ton contains the CCIP changesets for TON chains that need the state of the other chains.
+//
+// The TON contracts (router, fee quoter, onramp, offramp, LINK token) are deployed with the DeployCCIPContracts
+// changeset of chainlink-ton, and the lanes, including their fee quoter configs, are added with its AddTonLanes
+// changeset. This package only adds SetOCR3Offramp, which builds the OCR3 configs of the offramps from the CCIPHome of
+// the home chain.
+//
+// As for Sui, the changesets of the core contracts live next to the contracts, in chainlink-ton, and are not
+// duplicated here. chainlink-ton has no token pools yet, so the TON token pool changesets and the TON<->EVM token
+// transfer smoke test are left for when it ships them. Until then TON token transfers can't be configured and the TON
+// smoke tests only cover messaging.
+package ton
@@ -0,0 +1,79 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/http/fullnode"
+
+	cldf_tron "github.com/smartcontractkit/chainlink-deployments-framework/chain/tron"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// contract is an EVM contract deployed on Tron chains.
+type contract struct {
+	Type    cldf.ContractType
+	Version semver.Version
+	ABI     string
+	Bin     string
+}
+
+// deployContract deploys c with the constructor args, saves its address in ab, and returns it. The args are the Go
+// values of the EVM bindings, e.g. structs and common.Address.
+func deployContract(ctx context.Context, chain cldf_tron.Chain, ab cldf.AddressBook, c contract, opts *cldf_tron.DeployOptions, args ...any) (address.Address, error) {
+	addr, _, err := chain.DeployContractAndConfirm(ctx, c.Type.String(), c.ABI, c.Bin, args, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy %s on %s: %w", c.Type, chain, err)
+	}
+	if err := ab.Save(chain.Selector, addr.String(), cldf.NewTypeAndVersion(c.Type, c.Version)); err != nil {
+		return nil, fmt.Errorf("failed to save %s on %s: %w", c.Type, chain, err)
+	}
+	return addr, nil
+}
+
+// callContract sends a transaction calling method of the contract at addr with the args. Unlike the
+// TriggerContractAndConfirm of the chain, it encodes the args with the ABI of the contract, so they can be structs
+// like in the EVM bindings.
+func callContract(ctx context.Context, chain cldf_tron.Chain, addr address.Address, contractABI string, method string, opts *cldf_tron.TriggerOptions, args ...any) error {
+	if opts == nil {
+		opts = cldf_tron.DefaultTriggerOptions()
+	}
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	m, ok := parsed.Methods[method]
+	if !ok {
+		return fmt.Errorf("method %s not found in ABI", method)
+	}
+	params, err := m.Inputs.Pack(args...)
+	if err != nil {
+		return fmt.Errorf("failed to encode args of %s: %w", method, err)
+	}
+
+	var rsp fullnode.TriggerSmartContractResponse
+	err = chain.Client.FullNodeClient().Post("/triggersmartcontract", fullnode.TriggerSmartContractRequest{
+		OwnerAddress:     chain.Address.String(),
+		ContractAddress:  addr.String(),
+		FunctionSelector: m.Sig,
+		Parameter:        hex.EncodeToString(params),
+		FeeLimit:         opts.FeeLimit,
+		CallValue:        opts.TAmount,
+		Visible:          true,
+	}, &rsp)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction calling %s of %s: %w", method, addr, err)
+	}
+	if !rsp.Result.Result || rsp.Transaction == nil {
+		return fmt.Errorf("failed to build transaction calling %s of %s", method, addr)
+	}
+	if _, err := chain.SendAndConfirm(ctx, rsp.Transaction, opts.ConfirmRetryOptions); err != nil {
+		return fmt.Errorf("failed to call %s of %s on %s: %w", method, addr, chain, err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package tron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_0_0/rmn_proxy_contract"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_2_0/router"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/token_admin_registry"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/nonce_manager"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/offramp"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/onramp"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/rmn_remote"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_3/fee_quoter"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/link_token"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/weth9"
+
+	cldf_tron "github.com/smartcontractkit/chainlink-deployments-framework/chain/tron"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	ccipops "github.com/smartcontractkit/chainlink/deployment/ccip/operation/evm/v1_6"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	tronstate "github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/tron"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+)
+
+var _ cldf.ChangeSetV2[DeployChainContractsConfig] = DeployChainContracts{}
+
+var (
+	linkTokenContract          = contract{commontypes.LinkToken, deployment.Version1_0_0, link_token.LinkTokenABI, link_token.LinkTokenBin}
+	weth9Contract              = contract{shared.WETH9, deployment.Version1_0_0, weth9.WETH9ABI, weth9.WETH9Bin}
+	rmnRemoteContract          = contract{shared.RMNRemote, deployment.Version1_6_0, rmn_remote.RMNRemoteABI, rmn_remote.RMNRemoteBin}
+	rmnProxyContract           = contract{shared.ARMProxy, deployment.Version1_0_0, rmn_proxy_contract.RMNProxyABI, rmn_proxy_contract.RMNProxyBin}
+	tokenAdminRegistryContract = contract{shared.TokenAdminRegistry, deployment.Version1_5_0, token_admin_registry.TokenAdminRegistryABI, token_admin_registry.TokenAdminRegistryBin}
+	nonceManagerContract       = contract{shared.NonceManager, deployment.Version1_6_0, nonce_manager.NonceManagerABI, nonce_manager.NonceManagerBin}
+	routerContract             = contract{shared.Router, deployment.Version1_2_0, router.RouterABI, router.RouterBin}
+	feeQuoterContract          = contract{shared.FeeQuoter, deployment.Version1_6_3, fee_quoter.FeeQuoterABI, fee_quoter.FeeQuoterBin}
+	onRampContract             = contract{shared.OnRamp, deployment.Version1_6_0, onramp.OnRampABI, onramp.OnRampBin}
+	offRampContract            = contract{shared.OffRamp, deployment.Version1_6_0, offramp.OffRampABI, offramp.OffRampBin}
+)
+
+// DeployChainContractsConfig configures DeployChainContracts.
+type DeployChainContractsConfig struct {
+	ChainSelectors  []uint64
+	FeeQuoterParams ccipops.FeeQuoterParams
+	OffRampParams   ccipops.OffRampParams
+	// DeployOptions and TriggerOptions default to the ones of cldf_tron if nil
+	DeployOptions  *cldf_tron.DeployOptions
+	TriggerOptions *cldf_tron.TriggerOptions
+}
+
+// DeployChainContracts deploys the CCIP 1.6 EVM contracts on Tron chains, with the LINK token and WETH9 they depend
+// on. Contracts which are already in the address book are not deployed again. The onramp and offramp are authorized
+// on the nonce manager, and the offramp on the fee quoter, when they are deployed.
+type DeployChainContracts struct{}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (cs DeployChainContracts) VerifyPreconditions(e cldf.Environment, cfg DeployChainContractsConfig) error {
+	if len(cfg.ChainSelectors) == 0 {
+		return errors.New("no chain selectors")
+	}
+	for _, chainSelector := range cfg.ChainSelectors {
+		if _, ok := e.BlockChains.TronChains()[chainSelector]; !ok {
+			return fmt.Errorf("tron chain %d not found in environment", chainSelector)
+		}
+	}
+	if err := cfg.FeeQuoterParams.Validate(); err != nil {
+		return fmt.Errorf("invalid fee quoter params: %w", err)
+	}
+	if err := cfg.OffRampParams.Validate(false); err != nil {
+		return fmt.Errorf("invalid offramp params: %w", err)
+	}
+	return nil
+}
+
+// Apply implements deployment.ChangeSetV2.
+func (cs DeployChainContracts) Apply(e cldf.Environment, cfg DeployChainContractsConfig) (cldf.ChangesetOutput, error) {
+	state, err := tronstate.LoadOnchainState(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load Tron onchain state: %w", err)
+	}
+
+	ab := cldf.NewMemoryAddressBook()
+	for _, chainSelector := range cfg.ChainSelectors {
+		chain := e.BlockChains.TronChains()[chainSelector]
+		if err := deployChainContracts(e.GetContext(), chain, ab, state[chainSelector], cfg); err != nil {
+			return cldf.ChangesetOutput{AddressBook: ab}, err
+		}
+		e.Logger.Infow("Deployed CCIP contracts", "chain", chain.String())
+	}
+
+	ds, err := shared.PopulateDataStore(ab)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to populate in-memory DataStore: %w", err)
+	}
+	return cldf.ChangesetOutput{
+		AddressBook: ab,
+		DataStore:   ds,
+	}, nil
+}
+
+func deployChainContracts(ctx context.Context, chain cldf_tron.Chain, ab cldf.AddressBook, s tronstate.CCIPChainState, cfg DeployChainContractsConfig) error {
+	// deploy deploys c unless it is already deployed at *addr, and sets *addr to its address
+	deploy := func(addr *address.Address, c contract, args ...any) error {
+		if *addr != nil {
+			return nil
+		}
+		deployed, err := deployContract(ctx, chain, ab, c, cfg.DeployOptions, args...)
+		if err != nil {
+			return err
+		}
+		*addr = deployed
+		return nil
+	}
+
+	if err := deploy(&s.LinkToken, linkTokenContract); err != nil {
+		return err
+	}
+	if err := deploy(&s.WETH9, weth9Contract); err != nil {
+		return err
+	}
+	if err := deploy(&s.RMNRemote, rmnRemoteContract, chain.Selector, common.Address{}); err != nil {
+		return err
+	}
+	if err := deploy(&s.RMNProxy, rmnProxyContract, s.RMNRemote.EthAddress()); err != nil {
+		return err
+	}
+	if err := deploy(&s.TokenAdminRegistry, tokenAdminRegistryContract); err != nil {
+		return err
+	}
+	if err := deploy(&s.NonceManager, nonceManagerContract, []common.Address{}); err != nil {
+		return err
+	}
+	if err := deploy(&s.Router, routerContract, s.WETH9.EthAddress(), s.RMNProxy.EthAddress()); err != nil {
+		return err
+	}
+	params := cfg.FeeQuoterParams
+	err := deploy(&s.FeeQuoter, feeQuoterContract,
+		fee_quoter.FeeQuoterStaticConfig{
+			MaxFeeJuelsPerMsg:            params.MaxFeeJuelsPerMsg,
+			LinkToken:                    s.LinkToken.EthAddress(),
+			TokenPriceStalenessThreshold: params.TokenPriceStalenessThreshold,
+		},
+		[]common.Address{},
+		[]common.Address{s.WETH9.EthAddress(), s.LinkToken.EthAddress()},
+		params.TokenPriceFeedUpdates,
+		params.TokenTransferFeeConfigArgs,
+		append([]fee_quoter.FeeQuoterPremiumMultiplierWeiPerEthArgs{
+			{Token: s.LinkToken.EthAddress(), PremiumMultiplierWeiPerEth: params.LinkPremiumMultiplierWeiPerEth},
+			{Token: s.WETH9.EthAddress(), PremiumMultiplierWeiPerEth: params.WethPremiumMultiplierWeiPerEth},
+		}, params.MorePremiumMultiplierWeiPerEth...),
+		[]fee_quoter.FeeQuoterDestChainConfigArgs{},
+	)
+	if err != nil {
+		return err
+	}
+
+	var newRamps []common.Address
+	if s.OnRamp == nil {
+		err := deploy(&s.OnRamp, onRampContract,
+			onramp.OnRampStaticConfig{
+				ChainSelector:      chain.Selector,
+				RmnRemote:          s.RMNRemote.EthAddress(),
+				NonceManager:       s.NonceManager.EthAddress(),
+				TokenAdminRegistry: s.TokenAdminRegistry.EthAddress(),
+			},
+			onramp.OnRampDynamicConfig{
+				FeeQuoter:     s.FeeQuoter.EthAddress(),
+				FeeAggregator: chain.Address.EthAddress(),
+			},
+			[]onramp.OnRampDestChainConfigArgs{},
+		)
+		if err != nil {
+			return err
+		}
+		newRamps = append(newRamps, s.OnRamp.EthAddress())
+	}
+	if s.OffRamp == nil {
+		err := deploy(&s.OffRamp, offRampContract,
+			offramp.OffRampStaticConfig{
+				ChainSelector:        chain.Selector,
+				GasForCallExactCheck: cfg.OffRampParams.GasForCallExactCheck,
+				RmnRemote:            s.RMNRemote.EthAddress(),
+				NonceManager:         s.NonceManager.EthAddress(),
+				TokenAdminRegistry:   s.TokenAdminRegistry.EthAddress(),
+			},
+			offramp.OffRampDynamicConfig{
+				FeeQuoter:                               s.FeeQuoter.EthAddress(),
+				PermissionLessExecutionThresholdSeconds: cfg.OffRampParams.PermissionLessExecutionThresholdSeconds,
+				MessageInterceptor:                      cfg.OffRampParams.MessageInterceptor,
+			},
+			[]offramp.OffRampSourceChainConfigArgs{},
+		)
+		if err != nil {
+			return err
+		}
+		newRamps = append(newRamps, s.OffRamp.EthAddress())
+
+		// the offramp updates the prices of the fee quoter
+		err = callContract(ctx, chain, s.FeeQuoter, fee_quoter.FeeQuoterABI, "applyAuthorizedCallerUpdates", cfg.TriggerOptions,
+			fee_quoter.AuthorizedCallersAuthorizedCallerArgs{AddedCallers: []common.Address{s.OffRamp.EthAddress()}, RemovedCallers: []common.Address{}})
+		if err != nil {
+			return err
+		}
+	}
+	if len(newRamps) > 0 {
+		err := callContract(ctx, chain, s.NonceManager, nonce_manager.NonceManagerABI, "applyAuthorizedCallerUpdates", cfg.TriggerOptions,
+			nonce_manager.AuthorizedCallersAuthorizedCallerArgs{AddedCallers: newRamps, RemovedCallers: []common.Address{}})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
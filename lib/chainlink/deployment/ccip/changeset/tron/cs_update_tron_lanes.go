@@ -0,0 +1,160 @@
+package tron
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_2_0/router"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/offramp"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/onramp"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_3/fee_quoter"
+
+	cldf_tron "github.com/smartcontractkit/chainlink-deployments-framework/chain/tron"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+)
+
+var _ cldf.ChangeSetV2[UpdateTronLanesConfig] = UpdateTronLanes{}
+
+// TronLaneConfig configures the lanes between a Tron chain and a remote chain, in both directions.
+type TronLaneConfig struct {
+	TronChainSelector   uint64
+	RemoteChainSelector uint64
+	IsDisabled          bool
+	// AllowListEnabled enables the allow list of the senders of messages to the remote chain
+	AllowListEnabled bool
+	// RMNVerificationDisabled disables the RMN verification of messages from the remote chain
+	RMNVerificationDisabled bool
+	// FeeQuoterDestChainConfig is the config of the remote chain on the fee quoter of the Tron chain. Its IsEnabled
+	// is set from IsDisabled.
+	FeeQuoterDestChainConfig fee_quoter.FeeQuoterDestChainConfig
+}
+
+// UpdateTronLanesConfig configures UpdateTronLanes.
+type UpdateTronLanesConfig struct {
+	Lanes []TronLaneConfig
+	// TriggerOptions defaults to the one of cldf_tron if nil
+	TriggerOptions *cldf_tron.TriggerOptions
+}
+
+// UpdateTronLanes adds, updates or disables lanes on the Tron side: the fee quoter, onramp, offramp and router of
+// the Tron chain. The remote side of the lanes is configured with the changesets of the family of the remote chain,
+// e.g. v1_6.UpdateBidirectionalLanesChangeset for EVM chains, which look up the Tron ramps in the onchain state.
+type UpdateTronLanes struct{}
+
+// VerifyPreconditions implements deployment.ChangeSetV2.
+func (cs UpdateTronLanes) VerifyPreconditions(e cldf.Environment, cfg UpdateTronLanesConfig) error {
+	if len(cfg.Lanes) == 0 {
+		return errors.New("no lanes")
+	}
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	for _, lane := range cfg.Lanes {
+		if _, ok := e.BlockChains.TronChains()[lane.TronChainSelector]; !ok {
+			return fmt.Errorf("tron chain %d not found in environment", lane.TronChainSelector)
+		}
+		s := state.TronChains[lane.TronChainSelector]
+		if s.Router == nil || s.FeeQuoter == nil || s.OnRamp == nil || s.OffRamp == nil {
+			return fmt.Errorf("CCIP contracts are not deployed on tron chain %d", lane.TronChainSelector)
+		}
+		if lane.RemoteChainSelector == lane.TronChainSelector {
+			return fmt.Errorf("cannot add a lane from tron chain %d to itself", lane.TronChainSelector)
+		}
+		if err := state.ValidateRamp(lane.RemoteChainSelector, shared.OnRamp); err != nil {
+			return fmt.Errorf("invalid remote chain %d: %w", lane.RemoteChainSelector, err)
+		}
+	}
+	return nil
+}
+
+// Apply implements deployment.ChangeSetV2.
+func (cs UpdateTronLanes) Apply(e cldf.Environment, cfg UpdateTronLanesConfig) (cldf.ChangesetOutput, error) {
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	lanesByChain := make(map[uint64][]TronLaneConfig)
+	for _, lane := range cfg.Lanes {
+		lanesByChain[lane.TronChainSelector] = append(lanesByChain[lane.TronChainSelector], lane)
+	}
+	for chainSelector, lanes := range lanesByChain {
+		chain := e.BlockChains.TronChains()[chainSelector]
+		s := state.TronChains[chainSelector]
+		routerAddress := s.Router.EthAddress()
+		onRampAddress := s.OnRamp.EthAddress()
+		offRampAddress := s.OffRamp.EthAddress()
+
+		var (
+			feeQuoterDests []fee_quoter.FeeQuoterDestChainConfigArgs
+			onRampDests    []onramp.OnRampDestChainConfigArgs
+			offRampSources []offramp.OffRampSourceChainConfigArgs
+			routerOnRamps  []router.RouterOnRamp
+			routerRemoves  []router.RouterOffRamp
+			routerAdds     []router.RouterOffRamp
+		)
+		for _, lane := range lanes {
+			remote := lane.RemoteChainSelector
+			isEnabled := !lane.IsDisabled
+			remoteOnRamp, err := state.GetOnRampAddressBytes(remote)
+			if err != nil {
+				return cldf.ChangesetOutput{}, err
+			}
+
+			destConfig := lane.FeeQuoterDestChainConfig
+			destConfig.IsEnabled = isEnabled
+			feeQuoterDests = append(feeQuoterDests, fee_quoter.FeeQuoterDestChainConfigArgs{
+				DestChainSelector: remote,
+				DestChainConfig:   destConfig,
+			})
+			offRampSources = append(offRampSources, offramp.OffRampSourceChainConfigArgs{
+				Router:                    routerAddress,
+				SourceChainSelector:       remote,
+				IsEnabled:                 isEnabled,
+				IsRMNVerificationDisabled: lane.RMNVerificationDisabled,
+				OnRamp:                    common.LeftPadBytes(remoteOnRamp, 32),
+			})
+			// the router of the onramp and the onramp of the router are set to 0x0 to disable the lane
+			onRamp := onramp.OnRampDestChainConfigArgs{
+				DestChainSelector: remote,
+				AllowlistEnabled:  lane.AllowListEnabled,
+			}
+			routerOnRamp := router.RouterOnRamp{DestChainSelector: remote}
+			if isEnabled {
+				onRamp.Router = routerAddress
+				routerOnRamp.OnRamp = onRampAddress
+				routerAdds = append(routerAdds, router.RouterOffRamp{SourceChainSelector: remote, OffRamp: offRampAddress})
+			} else {
+				routerRemoves = append(routerRemoves, router.RouterOffRamp{SourceChainSelector: remote, OffRamp: offRampAddress})
+			}
+			onRampDests = append(onRampDests, onRamp)
+			routerOnRamps = append(routerOnRamps, routerOnRamp)
+		}
+
+		ctx := e.GetContext()
+		err = callContract(ctx, chain, s.FeeQuoter, fee_quoter.FeeQuoterABI, "applyDestChainConfigUpdates", cfg.TriggerOptions, feeQuoterDests)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+		err = callContract(ctx, chain, s.OnRamp, onramp.OnRampABI, "applyDestChainConfigUpdates", cfg.TriggerOptions, onRampDests)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+		err = callContract(ctx, chain, s.OffRamp, offramp.OffRampABI, "applySourceChainConfigUpdates", cfg.TriggerOptions, offRampSources)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+		err = callContract(ctx, chain, s.Router, router.RouterABI, "applyRampUpdates", cfg.TriggerOptions, routerOnRamps, routerRemoves, routerAdds)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+		e.Logger.Infow("Updated lanes", "chain", chain.String(), "lanes", len(lanes))
+	}
+	return cldf.ChangesetOutput{}, nil
+}
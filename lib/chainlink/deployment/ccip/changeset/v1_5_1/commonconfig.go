@@ -256,6 +256,12 @@ func GetTokenPoolAddressFromSymbolTypeAndVersion(
 				return tokenPool.Address(), true
 			}
 		}
+	case shared.SiloedLockReleaseTokenPool:
+		if tokenPools, ok := chainState.SiloedLockReleaseTokenPools[symbol]; ok {
+			if tokenPool, ok := tokenPools[version]; ok {
+				return tokenPool.Address(), true
+			}
+		}
 	}
 
 	return utils.ZeroAddress, false
@@ -13,9 +13,13 @@ import (
 	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/deployergroup"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	changeset2 "github.com/smartcontractkit/chainlink/deployment/common/changeset"
 )
 
-var _ cldf.ChangeSet[TokenAdminRegistryChangesetConfig] = AcceptAdminRoleChangeset
+var (
+	_ cldf.ChangeSet[TokenAdminRegistryChangesetConfig]           = AcceptAdminRoleChangeset
+	_ changeset2.PostCondition[TokenAdminRegistryChangesetConfig] = AcceptAdminRolePostCondition
+)
 
 func validateAcceptAdminRole(
 	config token_admin_registry.TokenAdminRegistryTokenConfig,
@@ -64,3 +68,39 @@ func AcceptAdminRoleChangeset(env cldf.Environment, c TokenAdminRegistryChangese
 
 	return deployerGroup.Enact()
 }
+
+// AcceptAdminRolePostCondition checks that the admin role of the tokens was accepted: the administrator of each token
+// on the token admin registry is the timelock, or the deployer key without MCMS, and no administrator is pending.
+func AcceptAdminRolePostCondition(env cldf.Environment, c TokenAdminRegistryChangesetConfig) error {
+	state, err := stateview.LoadOnchainState(env)
+	if err != nil {
+		return fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	for chainSelector, tokenSymbolToPoolInfo := range c.Pools {
+		chain, ok := env.BlockChains.EVMChains()[chainSelector]
+		if !ok {
+			return fmt.Errorf("chain with selector %d does not exist in environment", chainSelector)
+		}
+		chainState, ok := state.EVMChainState(chainSelector)
+		if !ok || chainState.TokenAdminRegistry == nil {
+			return fmt.Errorf("missing tokenAdminRegistry on %s", chain)
+		}
+		admin := chain.DeployerKey.From
+		if c.MCMS != nil {
+			admin = chainState.Timelock.Address()
+		}
+		for symbol, poolInfo := range tokenSymbolToPoolInfo {
+			config, err := poolInfo.GetConfigOnRegistry(env.GetContext(), symbol, chain, chainState)
+			if err != nil {
+				return err
+			}
+			if config.Administrator != admin {
+				return fmt.Errorf("administrator of %s token on %s registry is %s, expected %s", symbol, chain, config.Administrator, admin)
+			}
+			if config.PendingAdministrator != (common.Address{}) {
+				return fmt.Errorf("%s token on %s registry has a pending administrator %s", symbol, chain, config.PendingAdministrator)
+			}
+		}
+	}
+	return nil
+}
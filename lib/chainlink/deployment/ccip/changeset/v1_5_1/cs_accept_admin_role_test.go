@@ -204,6 +204,29 @@ func TestAcceptAdminRoleChangeset_Execution(t *testing.T) {
 			} else {
 				require.Equal(t, e.BlockChains.EVMChains()[selectorB].DeployerKey.From, configOnB.Administrator)
 			}
+
+			// re-running the accepted changeset in verify-only mode checks its post-condition without sending anything
+			var report commonchangeset.ComplianceReport
+			_, _, err = commonchangeset.ApplyChangesets(t, e, []commonchangeset.ConfiguredChangeSet{
+				commonchangeset.ConfigureWithPostConditions(
+					cldf.CreateLegacyChangeSet(v1_5_1.AcceptAdminRoleChangeset),
+					v1_5_1.TokenAdminRegistryChangesetConfig{
+						MCMS: mcmsConfig,
+						Pools: map[uint64]map[shared.TokenSymbol]v1_5_1.TokenPoolInfo{
+							selectorA: {
+								testhelpers.TestTokenSymbol: {
+									Type:    shared.BurnMintTokenPool,
+									Version: deployment.Version1_5_1,
+								},
+							},
+						},
+					},
+					v1_5_1.AcceptAdminRolePostCondition,
+				),
+			}, commonchangeset.WithVerifyOnly(&report))
+			require.NoError(t, err)
+			require.True(t, report.Compliant(), report.String())
+			require.Equal(t, 1, report.Changesets[0].PostConditions)
 		})
 	}
 }
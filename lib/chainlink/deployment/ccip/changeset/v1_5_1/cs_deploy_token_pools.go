@@ -9,6 +9,7 @@ import (
 	"github.com/smartcontractkit/ccip-contract-examples/chains/evm/gobindings/generated/latest/hybrid_with_external_minter_token_pool"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -32,6 +33,7 @@ import (
 	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/burn_with_from_mint_token_pool"
 	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/lock_release_token_pool"
 	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/siloed_lock_release_token_pool"
 	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/erc20"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/bindings/burn_mint_with_external_minter_fast_transfer_token_pool"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/bindings/hybrid_with_external_minter_fast_transfer_token_pool"
@@ -118,9 +120,10 @@ func (i DeployTokenPoolInput) Validate(ctx context.Context, chain cldf_evm.Chain
 	}
 
 	// We should check if a token pool with this type, version, and symbol already exists
-	_, ok := GetTokenPoolAddressFromSymbolTypeAndVersion(state, chain, tokenSymbol, i.Type, shared.CurrentTokenPoolVersion)
+	version := tokenPoolVersion(i.Type)
+	_, ok := GetTokenPoolAddressFromSymbolTypeAndVersion(state, chain, tokenSymbol, i.Type, version)
 	if ok {
-		return fmt.Errorf("token pool with type %s and version %s already exists for %s on %s", i.Type, shared.CurrentTokenPoolVersion, tokenSymbol, chain)
+		return fmt.Errorf("token pool with type %s and version %s already exists for %s on %s", i.Type, version, tokenSymbol, chain)
 	}
 
 	return nil
@@ -202,20 +205,7 @@ func DeployTokenPoolContractsChangeset(env cldf.Environment, c DeployTokenPoolCo
 			if err != nil {
 				return fmt.Errorf("failed to deploy token pool contract: %w", err)
 			}
-			if poolConfig.TokenType == shared.BurnMintERC20Token {
-				if err := addMinterAndBurnerForBurnMintERC20Token(env, chain.Selector, poolConfig.TokenAddress, contract.Address); err != nil {
-					return fmt.Errorf("failed to add minter and burner for BurnMintERC20 token %s on %s: %w",
-						poolConfig.TokenAddress, chain, err)
-				}
-			}
-			if poolConfig.TokenType == shared.ERC677TokenHelper || poolConfig.TokenType == commontypes.LinkToken {
-				if err := addMinterForERC677Token(env, chain, poolConfig.TokenAddress, contract.Address); err != nil {
-					return fmt.Errorf("failed to add Token pool as minter and burner for ERC677 token %s on %s: %w",
-						poolConfig.TokenAddress, chain, err)
-				}
-			}
-
-			return nil
+			return grantTokenRolesToPool(env, chain, poolConfig, contract.Address)
 		})
 	}
 
@@ -235,6 +225,39 @@ func DeployTokenPoolContractsChangeset(env cldf.Environment, c DeployTokenPoolCo
 	}, nil
 }
 
+// grantTokenRolesToPool grants the roles of the token needed by a new pool, for the token types deployed by AddTokensE2E.
+func grantTokenRolesToPool(env cldf.Environment, chain cldf_evm.Chain, poolConfig DeployTokenPoolInput, poolAddress common.Address) error {
+	if poolConfig.TokenType == shared.BurnMintERC20Token {
+		if err := addMinterAndBurnerForBurnMintERC20Token(env, chain.Selector, poolConfig.TokenAddress, poolAddress); err != nil {
+			return fmt.Errorf("failed to add minter and burner for BurnMintERC20 token %s on %s: %w",
+				poolConfig.TokenAddress, chain, err)
+		}
+	}
+	if poolConfig.TokenType == shared.ERC677TokenHelper || poolConfig.TokenType == commontypes.LinkToken {
+		if err := addMinterForERC677Token(env, chain, poolConfig.TokenAddress, poolAddress); err != nil {
+			return fmt.Errorf("failed to add Token pool as minter and burner for ERC677 token %s on %s: %w",
+				poolConfig.TokenAddress, chain, err)
+		}
+	}
+	return nil
+}
+
+// tokenPoolVersion returns the version of the token pool contract deployed for the pool type.
+func tokenPoolVersion(poolType cldf.ContractType) semver.Version {
+	switch poolType {
+	case shared.BurnMintFastTransferTokenPool:
+		return deployment.Version1_6_3Dev
+	case shared.BurnMintWithExternalMinterFastTransferTokenPool,
+		shared.HybridWithExternalMinterFastTransferTokenPool,
+		shared.BurnMintWithExternalMinterTokenPool,
+		shared.HybridWithExternalMinterTokenPool,
+		shared.SiloedLockReleaseTokenPool:
+		return deployment.Version1_6_0
+	default:
+		return shared.CurrentTokenPoolVersion
+	}
+}
+
 // deployTokenPool deploys a token pool contract based on a given type & configuration.
 func deployTokenPool(
 	logger logger.Logger,
@@ -255,7 +278,6 @@ func deployTokenPool(
 			var tpAddr common.Address
 			var tx *types.Transaction
 			var err error
-			tokenPoolVersion := shared.CurrentTokenPoolVersion
 			switch poolConfig.Type {
 			case shared.BurnMintTokenPool:
 				tpAddr, tx, _, err = burn_mint_token_pool.DeployBurnMintTokenPool(
@@ -278,35 +300,35 @@ func deployTokenPool(
 					poolConfig.AllowList, rmnProxy.Address(), *poolConfig.AcceptLiquidity, router.Address(),
 				)
 			case shared.BurnMintFastTransferTokenPool:
-				tokenPoolVersion = deployment.Version1_6_3Dev
 				tpAddr, tx, _, err = fast_transfer_token_pool.DeployBurnMintFastTransferTokenPool(
 					chain.DeployerKey, chain.Client, poolConfig.TokenAddress, poolConfig.LocalTokenDecimals,
 					poolConfig.AllowList, rmnProxy.Address(), router.Address(), chain.Selector,
 				)
 			case shared.BurnMintWithExternalMinterFastTransferTokenPool:
-				tokenPoolVersion = deployment.Version1_6_0
 				tpAddr, tx, _, err = burn_mint_with_external_minter_fast_transfer_token_pool.DeployBurnMintWithExternalMinterFastTransferTokenPool(
 					chain.DeployerKey, chain.Client, poolConfig.ExternalMinter, poolConfig.TokenAddress, poolConfig.LocalTokenDecimals,
 					poolConfig.AllowList, rmnProxy.Address(), router.Address(),
 				)
 			case shared.HybridWithExternalMinterFastTransferTokenPool:
-				tokenPoolVersion = deployment.Version1_6_0
 				tpAddr, tx, _, err = hybrid_with_external_minter_fast_transfer_token_pool.DeployHybridWithExternalMinterFastTransferTokenPool(
 					chain.DeployerKey, chain.Client, poolConfig.ExternalMinter, poolConfig.TokenAddress, poolConfig.LocalTokenDecimals,
 					poolConfig.AllowList, rmnProxy.Address(), router.Address(),
 				)
 			case shared.BurnMintWithExternalMinterTokenPool:
-				tokenPoolVersion = deployment.Version1_6_0
 				tpAddr, tx, _, err = burn_mint_with_external_minter_token_pool.DeployBurnMintWithExternalMinterTokenPool(
 					chain.DeployerKey, chain.Client, poolConfig.TokenGovernor, poolConfig.TokenAddress, poolConfig.LocalTokenDecimals,
 					poolConfig.AllowList, rmnProxy.Address(), router.Address(),
 				)
 			case shared.HybridWithExternalMinterTokenPool:
-				tokenPoolVersion = deployment.Version1_6_0
 				tpAddr, tx, _, err = hybrid_with_external_minter_token_pool.DeployHybridWithExternalMinterTokenPool(
 					chain.DeployerKey, chain.Client, poolConfig.TokenGovernor, poolConfig.TokenAddress, poolConfig.LocalTokenDecimals,
 					poolConfig.AllowList, rmnProxy.Address(), router.Address(),
 				)
+			case shared.SiloedLockReleaseTokenPool:
+				tpAddr, tx, _, err = siloed_lock_release_token_pool.DeploySiloedLockReleaseTokenPool(
+					chain.DeployerKey, chain.Client, poolConfig.TokenAddress, poolConfig.LocalTokenDecimals,
+					poolConfig.AllowList, rmnProxy.Address(), router.Address(),
+				)
 			}
 			var tp *token_pool.TokenPool
 			if err == nil { // prevents overwriting the error (also, if there were an error with deployment, converting to an abstract token pool wouldn't be useful)
@@ -315,7 +337,7 @@ func deployTokenPool(
 			return cldf.ContractDeploy[*token_pool.TokenPool]{
 				Address:  tpAddr,
 				Contract: tp,
-				Tv:       cldf.NewTypeAndVersion(poolConfig.Type, tokenPoolVersion),
+				Tv:       cldf.NewTypeAndVersion(poolConfig.Type, tokenPoolVersion(poolConfig.Type)),
 				Tx:       tx,
 				Err:      err,
 			}
@@ -0,0 +1,344 @@
+package v1_5_1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/token_pool"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/erc20"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	ccipseq "github.com/smartcontractkit/chainlink/deployment/ccip/sequence/evm/v1_5_1"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
+	commoncs "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	opsutil "github.com/smartcontractkit/chainlink/deployment/common/opsutils"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+// MigrateTokenPoolChangeset migrates a token from its current pool to a new pool on multiple chains, e.g. from a
+// LockRelease pool to a BurnMint pool. On each chain it
+//
+//  1. deploys the new pool with the deployer key
+//  2. adds the remote chains of the old pool to the new pool, with their remote pools, remote token and rate limits
+//  3. removes the remote chains of the old pool, which pauses it
+//  4. withdraws the liquidity of a LockRelease old pool, and provides it to a SiloedLockRelease new pool or transfers it
+//     to a recipient
+//  5. sets the new pool as the pool of the token on the token admin registry
+//
+// Steps 3 to 5 are proposed to the timelock if MCMS is defined, in a single batch per chain. Each call is returned as a
+// report, in order.
+//
+// With MCMS, the liquidity withdrawn is the balance of the old pool when the proposal is built, as the LockRelease pool
+// can only withdraw a given amount. Transfers to and from the old pool until the proposal is executed change its
+// balance: if it decreased, the withdrawal reverts with the rest of the batch, and the migration has to be proposed
+// again. If it increased, the difference stays in the old pool, where the timelock can withdraw it as its rebalancer.
+//
+// The new pool stays owned by the deployer key, and the remote pools must add it as a remote pool, e.g. with
+// ConfigureTokenPoolContractsChangeset, for transfers to this chain to keep working.
+var MigrateTokenPoolChangeset = cldf.CreateChangeSet(migrateTokenPoolLogic, migrateTokenPoolPrecondition)
+
+// MigrateTokenPoolInput defines the migration of a token to a new pool on a chain.
+type MigrateTokenPoolInput struct {
+	// OldPoolType is the type of the pool the token is migrated from.
+	OldPoolType cldf.ContractType `json:"oldPoolType"`
+
+	// OldPoolVersion is the version of the pool the token is migrated from.
+	OldPoolVersion semver.Version `json:"oldPoolVersion"`
+
+	// NewPool defines the pool the token is migrated to.
+	NewPool DeployTokenPoolInput `json:"newPool"`
+
+	// LiquidityRecipient receives the liquidity of a LockRelease old pool. It must be defined if the old pool has
+	// liquidity and the new pool is not a SiloedLockRelease pool, which is given the liquidity otherwise.
+	LiquidityRecipient common.Address `json:"liquidityRecipient"`
+}
+
+type MigrateTokenPoolConfig struct {
+	// TokenSymbol is the symbol of the token being migrated.
+	TokenSymbol shared.TokenSymbol `json:"tokenSymbol"`
+
+	// Migrations defines the migration of the token on each chain.
+	Migrations map[uint64]MigrateTokenPoolInput `json:"migrations"`
+
+	// IsTestRouter indicates whether or not the new pools use the test router.
+	IsTestRouter bool `json:"isTestRouter"`
+
+	// MCMS defines the delay to use for Timelock (if absent, the changeset will attempt to use the deployer key).
+	MCMS *proposalutils.TimelockConfig `json:"mcms,omitempty"`
+}
+
+func (c MigrateTokenPoolConfig) Validate(env cldf.Environment) error {
+	if c.TokenSymbol == "" {
+		return errors.New("token symbol must be defined")
+	}
+	if len(c.Migrations) == 0 {
+		return errors.New("no migrations defined")
+	}
+	state, err := stateview.LoadOnchainState(env)
+	if err != nil {
+		return fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	for chainSelector, migration := range c.Migrations {
+		if err := stateview.ValidateChain(env, state, chainSelector, c.MCMS); err != nil {
+			return fmt.Errorf("failed to validate chain selector %d: %w", chainSelector, err)
+		}
+		chain, ok := env.BlockChains.EVMChains()[chainSelector]
+		if !ok {
+			return fmt.Errorf("chain with selector %d does not exist in environment", chainSelector)
+		}
+		chainState := state.Chains[chainSelector]
+		if err := migration.Validate(env.GetContext(), chain, chainState, c.TokenSymbol, c.MCMS != nil); err != nil {
+			return fmt.Errorf("failed to validate migration of %s token on %s: %w", c.TokenSymbol, chain, err)
+		}
+	}
+	return nil
+}
+
+func (i MigrateTokenPoolInput) Validate(ctx context.Context, chain cldf_evm.Chain, chainState evm.CCIPChainState, symbol shared.TokenSymbol, useMCMS bool) error {
+	oldPoolInfo := TokenPoolInfo{Type: i.OldPoolType, Version: i.OldPoolVersion}
+	if err := oldPoolInfo.Validate(); err != nil {
+		return fmt.Errorf("invalid old pool: %w", err)
+	}
+	// 1.5.0 pools only have one remote pool per chain, and siloed pools have one liquidity per chain
+	if i.OldPoolVersion.Equal(&deployment.Version1_5_0) {
+		return fmt.Errorf("cannot migrate from %s pools", deployment.Version1_5_0)
+	}
+	if i.OldPoolType == shared.SiloedLockReleaseTokenPool || i.OldPoolType == shared.HybridLockReleaseUSDCTokenPool {
+		return fmt.Errorf("cannot migrate from %s pools", i.OldPoolType)
+	}
+	// the state keeps one pool per symbol, type and version, which could not tell the old and new pools apart
+	if newPoolVersion := tokenPoolVersion(i.NewPool.Type); i.NewPool.Type == i.OldPoolType && newPoolVersion.Equal(&i.OldPoolVersion) {
+		return fmt.Errorf("cannot migrate to a %s pool of the same version %s as the old pool", i.NewPool.Type, i.OldPoolVersion)
+	}
+	if chainState.TokenAdminRegistry == nil {
+		return fmt.Errorf("missing tokenAdminRegistry on %s", chain)
+	}
+	if err := i.NewPool.Validate(ctx, chain, chainState, symbol); err != nil {
+		return fmt.Errorf("invalid new pool: %w", err)
+	}
+
+	oldPool, tokenAddress, err := oldPoolInfo.GetPoolAndTokenAddress(ctx, symbol, chain, chainState)
+	if err != nil {
+		return err
+	}
+	if tokenAddress != i.NewPool.TokenAddress {
+		return fmt.Errorf("token of old pool (%s) does not match token of new pool (%s)", tokenAddress, i.NewPool.TokenAddress)
+	}
+	sender := migrationSender(chain, chainState, useMCMS)
+	if err := commoncs.ValidateOwnership(ctx, useMCMS, chain.DeployerKey.From, sender, oldPool); err != nil {
+		return fmt.Errorf("old pool failed ownership validation: %w", err)
+	}
+	tokenConfig, err := chainState.TokenAdminRegistry.GetTokenConfig(&bind.CallOpts{Context: ctx}, tokenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get config of token %s from registry: %w", tokenAddress, err)
+	}
+	if tokenConfig.TokenPool != oldPool.Address() {
+		return fmt.Errorf("pool of token %s on registry is %s, not the old pool %s", tokenAddress, tokenConfig.TokenPool, oldPool.Address())
+	}
+	if tokenConfig.Administrator != sender {
+		return fmt.Errorf("%s is not the administrator of token %s on registry (%s)", sender, tokenAddress, tokenConfig.Administrator)
+	}
+
+	liquidity, err := oldPoolLiquidity(ctx, chain, i.OldPoolType, oldPool.Address(), tokenAddress)
+	if err != nil {
+		return err
+	}
+	if liquidity.Sign() > 0 && i.LiquidityRecipient == (common.Address{}) {
+		if i.NewPool.Type != shared.SiloedLockReleaseTokenPool {
+			return fmt.Errorf("liquidity recipient must be defined to migrate the liquidity of the old pool to a %s pool", i.NewPool.Type)
+		}
+	}
+	return nil
+}
+
+// migrationSender returns the address calling the old pool and the token admin registry.
+func migrationSender(chain cldf_evm.Chain, chainState evm.CCIPChainState, useMCMS bool) common.Address {
+	if useMCMS {
+		return chainState.Timelock.Address()
+	}
+	return chain.DeployerKey.From
+}
+
+// oldPoolLiquidity returns the liquidity of a LockRelease pool, which is its token balance, or 0 for other pools.
+func oldPoolLiquidity(ctx context.Context, chain cldf_evm.Chain, poolType cldf.ContractType, pool common.Address, tokenAddress common.Address) (*big.Int, error) {
+	if poolType != shared.LockReleaseTokenPool {
+		return big.NewInt(0), nil
+	}
+	token, err := erc20.NewERC20(tokenAddress, chain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect address %s with erc20 bindings: %w", tokenAddress, err)
+	}
+	balance, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of pool %s: %w", pool, err)
+	}
+	return balance, nil
+}
+
+// oldPoolChainUpdates returns the remote chains of the old pool with their remote pools, remote token and current
+// rate limits.
+func oldPoolChainUpdates(ctx context.Context, pool *token_pool.TokenPool) ([]token_pool.TokenPoolChainUpdate, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	remoteChainSelectors, err := pool.GetSupportedChains(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get supported chains of pool %s: %w", pool.Address(), err)
+	}
+	updates := make([]token_pool.TokenPoolChainUpdate, 0, len(remoteChainSelectors))
+	for _, remoteChainSelector := range remoteChainSelectors {
+		remotePools, err := pool.GetRemotePools(opts, remoteChainSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get remote pools of chain %d on pool %s: %w", remoteChainSelector, pool.Address(), err)
+		}
+		remoteToken, err := pool.GetRemoteToken(opts, remoteChainSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get remote token of chain %d on pool %s: %w", remoteChainSelector, pool.Address(), err)
+		}
+		outbound, err := pool.GetCurrentOutboundRateLimiterState(opts, remoteChainSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get outbound rate limits of chain %d on pool %s: %w", remoteChainSelector, pool.Address(), err)
+		}
+		inbound, err := pool.GetCurrentInboundRateLimiterState(opts, remoteChainSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inbound rate limits of chain %d on pool %s: %w", remoteChainSelector, pool.Address(), err)
+		}
+		updates = append(updates, token_pool.TokenPoolChainUpdate{
+			RemoteChainSelector: remoteChainSelector,
+			RemotePoolAddresses: remotePools,
+			RemoteTokenAddress:  remoteToken,
+			OutboundRateLimiterConfig: token_pool.RateLimiterConfig{
+				IsEnabled: outbound.IsEnabled,
+				Capacity:  outbound.Capacity,
+				Rate:      outbound.Rate,
+			},
+			InboundRateLimiterConfig: token_pool.RateLimiterConfig{
+				IsEnabled: inbound.IsEnabled,
+				Capacity:  inbound.Capacity,
+				Rate:      inbound.Rate,
+			},
+		})
+	}
+	return updates, nil
+}
+
+func migrateTokenPoolPrecondition(env cldf.Environment, c MigrateTokenPoolConfig) error {
+	return c.Validate(env)
+}
+
+func migrateTokenPoolLogic(env cldf.Environment, c MigrateTokenPoolConfig) (cldf.ChangesetOutput, error) {
+	if err := c.Validate(env); err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("invalid MigrateTokenPoolConfig: %w", err)
+	}
+	state, err := stateview.LoadOnchainState(env)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	ctx := env.GetContext()
+	newAddresses := cldf.NewMemoryAddressBook()
+	migrationsByChain := make(map[uint64]ccipseq.TokenPoolMigration, len(c.Migrations))
+	for chainSelector, migration := range c.Migrations {
+		chain := env.BlockChains.EVMChains()[chainSelector]
+		chainState := state.Chains[chainSelector]
+
+		oldPoolInfo := TokenPoolInfo{Type: migration.OldPoolType, Version: migration.OldPoolVersion}
+		oldPool, tokenAddress, err := oldPoolInfo.GetPoolAndTokenAddress(ctx, c.TokenSymbol, chain, chainState)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+		chainUpdates, err := oldPoolChainUpdates(ctx, oldPool)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+		liquidity, err := oldPoolLiquidity(ctx, chain, migration.OldPoolType, oldPool.Address(), tokenAddress)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+
+		newPool, err := deployTokenPool(env.Logger, chain, chainState, newAddresses, migration.NewPool, c.IsTestRouter)
+		if err != nil {
+			return cldf.ChangesetOutput{AddressBook: newAddresses}, fmt.Errorf("failed to deploy new %s token pool on %s: %w", c.TokenSymbol, chain, err)
+		}
+		if err := grantTokenRolesToPool(env, chain, migration.NewPool, newPool.Address); err != nil {
+			return cldf.ChangesetOutput{AddressBook: newAddresses}, err
+		}
+
+		removedChains := make([]uint64, 0, len(chainUpdates))
+		for _, update := range chainUpdates {
+			removedChains = append(removedChains, update.RemoteChainSelector)
+		}
+		migrationsByChain[chainSelector] = ccipseq.TokenPoolMigration{
+			Token:              tokenAddress,
+			TokenAdminRegistry: chainState.TokenAdminRegistry.Address(),
+			OldPool:            oldPool.Address(),
+			OldPoolType:        migration.OldPoolType,
+			NewPool:            newPool.Address,
+			NewPoolType:        migration.NewPool.Type,
+			ChainUpdates:       chainUpdates,
+			RemovedChains:      removedChains,
+			Liquidity:          liquidity,
+			LiquidityRecipient: migration.LiquidityRecipient,
+			Sender:             migrationSender(chain, chainState, c.MCMS != nil),
+			NoSend:             c.MCMS != nil,
+		}
+	}
+
+	ds, err := shared.PopulateDataStore(newAddresses)
+	if err != nil {
+		return cldf.ChangesetOutput{AddressBook: newAddresses}, fmt.Errorf("failed to populate in-memory DataStore: %w", err)
+	}
+	csOutput := cldf.ChangesetOutput{
+		AddressBook: newAddresses,
+		DataStore:   ds,
+	}
+
+	seqReport, err := operations.ExecuteSequence(env.OperationsBundle, ccipseq.TokenPoolMigrationSequence, env.BlockChains.EVMChains(), ccipseq.TokenPoolMigrationSequenceInput{
+		MigrationsByChain: migrationsByChain,
+	})
+	csOutput, err = opsutil.AddEVMCallSequenceToCSOutput(
+		env,
+		csOutput,
+		seqReport,
+		err,
+		state.EVMMCMSStateByChain(),
+		c.MCMS,
+		fmt.Sprintf("Migrate %s token pools", c.TokenSymbol),
+	)
+	if err != nil {
+		return csOutput, err
+	}
+	// the migration of a chain is rolled back as a whole if one of its calls reverts, e.g. the withdrawal of liquidity
+	for i := range csOutput.MCMSTimelockProposals {
+		csOutput.MCMSTimelockProposals[i].Operations = batchOperationsByChain(csOutput.MCMSTimelockProposals[i].Operations)
+	}
+	return csOutput, nil
+}
+
+// batchOperationsByChain merges the operations of each chain into a single batch operation, keeping the order of the
+// calls.
+func batchOperationsByChain(ops []mcmstypes.BatchOperation) []mcmstypes.BatchOperation {
+	batches := make([]mcmstypes.BatchOperation, 0, len(ops))
+	indexes := make(map[mcmstypes.ChainSelector]int)
+	for _, op := range ops {
+		i, ok := indexes[op.ChainSelector]
+		if !ok {
+			indexes[op.ChainSelector] = len(batches)
+			batches = append(batches, mcmstypes.BatchOperation{ChainSelector: op.ChainSelector})
+			i = len(batches) - 1
+		}
+		batches[i].Transactions = append(batches[i].Transactions, op.Transactions...)
+	}
+	return batches
+}
@@ -0,0 +1,227 @@
+package v1_5_1_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/token_pool"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/burn_mint_erc677"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_5_1"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestMigrateTokenPoolChangeset(t *testing.T) {
+	liquidity := big.NewInt(1e18)
+	recipient := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	tests := []struct {
+		Msg                string
+		NewPoolType        cldf.ContractType
+		NewPoolVersion     semver.Version
+		LiquidityRecipient common.Address
+		ErrStr             string
+	}{
+		{
+			Msg:                "LockRelease to BurnMint",
+			NewPoolType:        shared.BurnMintTokenPool,
+			NewPoolVersion:     deployment.Version1_5_1,
+			LiquidityRecipient: recipient,
+		},
+		{
+			Msg:            "LockRelease to SiloedLockRelease",
+			NewPoolType:    shared.SiloedLockReleaseTokenPool,
+			NewPoolVersion: deployment.Version1_6_0,
+		},
+		{
+			Msg:            "LockRelease to LockRelease",
+			NewPoolType:    shared.LockReleaseTokenPool,
+			NewPoolVersion: deployment.Version1_5_1,
+			ErrStr:         "cannot migrate to a LockReleaseTokenPool pool of the same version 1.5.1 as the old pool",
+		},
+	}
+
+	for _, test := range tests {
+		for _, mcmsConfig := range []*proposalutils.TimelockConfig{nil, {MinDelay: 0 * time.Second}} {
+			msg := test.Msg + " with MCMS"
+			if mcmsConfig == nil {
+				msg = test.Msg + " without MCMS"
+			}
+
+			t.Run(msg, func(t *testing.T) {
+				e, selectorA, selectorB, tokens := testhelpers.SetupTwoChainEnvironmentWithTokens(t, logger.TestLogger(t), mcmsConfig != nil)
+
+				acceptLiquidity := true
+				e = testhelpers.DeployTestTokenPools(t, e, map[uint64]v1_5_1.DeployTokenPoolInput{
+					selectorA: {
+						Type:               shared.LockReleaseTokenPool,
+						TokenAddress:       tokens[selectorA].Address,
+						LocalTokenDecimals: testhelpers.LocalTokenDecimals,
+						AcceptLiquidity:    &acceptLiquidity,
+					},
+					selectorB: {
+						Type:               shared.LockReleaseTokenPool,
+						TokenAddress:       tokens[selectorB].Address,
+						LocalTokenDecimals: testhelpers.LocalTokenDecimals,
+						AcceptLiquidity:    &acceptLiquidity,
+					},
+				}, mcmsConfig != nil)
+
+				oldPoolInfo := v1_5_1.TokenPoolInfo{
+					Type:    shared.LockReleaseTokenPool,
+					Version: deployment.Version1_5_1,
+				}
+				rateLimits := testhelpers.CreateSymmetricRateLimits(100, 1000)
+				_, err := commonchangeset.Apply(t, e, commonchangeset.Configure(
+					cldf.CreateLegacyChangeSet(v1_5_1.ConfigureTokenPoolContractsChangeset),
+					v1_5_1.ConfigureTokenPoolContractsConfig{
+						TokenSymbol: testhelpers.TestTokenSymbol,
+						MCMS:        mcmsConfig,
+						PoolUpdates: map[uint64]v1_5_1.TokenPoolConfig{
+							selectorA: {
+								Type:         shared.LockReleaseTokenPool,
+								Version:      deployment.Version1_5_1,
+								ChainUpdates: v1_5_1.RateLimiterPerChain{selectorB: rateLimits},
+							},
+							selectorB: {
+								Type:         shared.LockReleaseTokenPool,
+								Version:      deployment.Version1_5_1,
+								ChainUpdates: v1_5_1.RateLimiterPerChain{selectorA: rateLimits},
+							},
+						},
+					},
+				), commonchangeset.Configure(
+					cldf.CreateLegacyChangeSet(v1_5_1.ProposeAdminRoleChangeset),
+					v1_5_1.TokenAdminRegistryChangesetConfig{
+						MCMS:  mcmsConfig,
+						Pools: map[uint64]map[shared.TokenSymbol]v1_5_1.TokenPoolInfo{selectorA: {testhelpers.TestTokenSymbol: oldPoolInfo}},
+					},
+				), commonchangeset.Configure(
+					cldf.CreateLegacyChangeSet(v1_5_1.AcceptAdminRoleChangeset),
+					v1_5_1.TokenAdminRegistryChangesetConfig{
+						MCMS:  mcmsConfig,
+						Pools: map[uint64]map[shared.TokenSymbol]v1_5_1.TokenPoolInfo{selectorA: {testhelpers.TestTokenSymbol: oldPoolInfo}},
+					},
+				), commonchangeset.Configure(
+					cldf.CreateLegacyChangeSet(v1_5_1.SetPoolChangeset),
+					v1_5_1.TokenAdminRegistryChangesetConfig{
+						MCMS:  mcmsConfig,
+						Pools: map[uint64]map[shared.TokenSymbol]v1_5_1.TokenPoolInfo{selectorA: {testhelpers.TestTokenSymbol: oldPoolInfo}},
+					},
+				))
+				require.NoError(t, err)
+
+				state, err := stateview.LoadOnchainState(e)
+				require.NoError(t, err)
+				chain := e.BlockChains.EVMChains()[selectorA]
+				oldPool := state.Chains[selectorA].LockReleaseTokenPools[testhelpers.TestTokenSymbol][deployment.Version1_5_1]
+				remotePools, err := oldPool.GetRemotePools(nil, selectorB)
+				require.NoError(t, err)
+				remoteToken, err := oldPool.GetRemoteToken(nil, selectorB)
+				require.NoError(t, err)
+
+				// The liquidity of the old pool is its token balance
+				token := tokens[selectorA].Contract
+				tx, err := token.GrantMintRole(chain.DeployerKey, chain.DeployerKey.From)
+				require.NoError(t, err)
+				_, err = chain.Confirm(tx)
+				require.NoError(t, err)
+				tx, err = token.Mint(chain.DeployerKey, oldPool.Address(), liquidity)
+				require.NoError(t, err)
+				_, err = chain.Confirm(tx)
+				require.NoError(t, err)
+
+				newPool := v1_5_1.DeployTokenPoolInput{
+					Type:               test.NewPoolType,
+					TokenAddress:       tokens[selectorA].Address,
+					LocalTokenDecimals: testhelpers.LocalTokenDecimals,
+				}
+				if test.NewPoolType == shared.LockReleaseTokenPool {
+					newPool.AcceptLiquidity = &acceptLiquidity
+				}
+				e, err = commonchangeset.Apply(t, e, commonchangeset.Configure(
+					v1_5_1.MigrateTokenPoolChangeset,
+					v1_5_1.MigrateTokenPoolConfig{
+						TokenSymbol: testhelpers.TestTokenSymbol,
+						MCMS:        mcmsConfig,
+						Migrations: map[uint64]v1_5_1.MigrateTokenPoolInput{
+							selectorA: {
+								OldPoolType:        shared.LockReleaseTokenPool,
+								OldPoolVersion:     deployment.Version1_5_1,
+								NewPool:            newPool,
+								LiquidityRecipient: test.LiquidityRecipient,
+							},
+						},
+					},
+				))
+				if test.ErrStr != "" {
+					require.ErrorContains(t, err, test.ErrStr)
+					return
+				}
+				require.NoError(t, err)
+
+				state, err = stateview.LoadOnchainState(e)
+				require.NoError(t, err)
+				newPoolAddress, ok := v1_5_1.GetTokenPoolAddressFromSymbolTypeAndVersion(state.Chains[selectorA], chain, testhelpers.TestTokenSymbol, test.NewPoolType, test.NewPoolVersion)
+				require.True(t, ok)
+
+				// The token admin registry points to the new pool
+				config, err := state.Chains[selectorA].TokenAdminRegistry.GetTokenConfig(nil, tokens[selectorA].Address)
+				require.NoError(t, err)
+				require.Equal(t, newPoolAddress, config.TokenPool)
+
+				// The remote chain moved from the old pool to the new pool, with its remote pools, token and rate limits
+				oldChains, err := oldPool.GetSupportedChains(nil)
+				require.NoError(t, err)
+				require.Empty(t, oldChains)
+				pool, err := token_pool.NewTokenPool(newPoolAddress, chain.Client)
+				require.NoError(t, err)
+				newChains, err := pool.GetSupportedChains(nil)
+				require.NoError(t, err)
+				require.Equal(t, []uint64{selectorB}, newChains)
+				newRemotePools, err := pool.GetRemotePools(nil, selectorB)
+				require.NoError(t, err)
+				require.Equal(t, remotePools, newRemotePools)
+				newRemoteToken, err := pool.GetRemoteToken(nil, selectorB)
+				require.NoError(t, err)
+				require.Equal(t, remoteToken, newRemoteToken)
+				outbound, err := pool.GetCurrentOutboundRateLimiterState(nil, selectorB)
+				require.NoError(t, err)
+				require.True(t, outbound.IsEnabled)
+				require.Equal(t, rateLimits.Outbound.Capacity, outbound.Capacity)
+				require.Equal(t, rateLimits.Outbound.Rate, outbound.Rate)
+				inbound, err := pool.GetCurrentInboundRateLimiterState(nil, selectorB)
+				require.NoError(t, err)
+				require.True(t, inbound.IsEnabled)
+				require.Equal(t, rateLimits.Inbound.Capacity, inbound.Capacity)
+				require.Equal(t, rateLimits.Inbound.Rate, inbound.Rate)
+
+				// The liquidity moved to the recipient, or to the new pool otherwise
+				requireBalance(t, token, oldPool.Address(), big.NewInt(0))
+				if test.LiquidityRecipient != (common.Address{}) {
+					requireBalance(t, token, test.LiquidityRecipient, liquidity)
+				} else {
+					requireBalance(t, token, newPoolAddress, liquidity)
+				}
+			})
+		}
+	}
+}
+
+func requireBalance(t *testing.T, token *burn_mint_erc677.BurnMintERC677, account common.Address, expected *big.Int) {
+	balance, err := token.BalanceOf(nil, account)
+	require.NoError(t, err)
+	require.Zero(t, expected.Cmp(balance), "expected balance %s of %s, got %s", expected, account, balance)
+}
@@ -0,0 +1,368 @@
+package v1_5_1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/lock_release_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/token_pool_factory"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/erc20"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/deployergroup"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
+	commoncs "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+// OnboardTokenPoolsForSelfServeChangeset onboards existing customer tokens on EVM chains, like the Solana
+// OnboardTokenPoolsForSelfServe changeset does on Solana. For each token, it
+//  1. deploys and configures a token pool with the TokenPoolFactory, in one transaction sent with the deployer key
+//  2. begins the transfer of the ownership of the pool to the customer
+//  3. proposes the customer as administrator of the token on the token admin registry
+//  4. verifies the deployed pool and records it in the address book
+//
+// The customer must then accept the ownership of the pool and the administrator role, set the pool on the token admin
+// registry and grant the mint and burn roles of the token to the pool (burn mint pools only).
+var OnboardTokenPoolsForSelfServeChangeset = cldf.CreateChangeSet(onboardTokenPoolsForSelfServeLogic, onboardTokenPoolsForSelfServePrecondition)
+
+// selfServePoolTypes maps the pool types supported by the TokenPoolFactory to the pool type enum of the factory.
+var selfServePoolTypes = map[cldf.ContractType]uint8{
+	shared.BurnMintTokenPool:    0,
+	shared.LockReleaseTokenPool: 1,
+}
+
+// SelfServeRemoteTokenPool defines an existing token pool on a remote chain.
+type SelfServeRemoteTokenPool struct {
+	// PoolAddress is the address of the remote pool, encoded as expected by the remote chain (e.g. left padded to 32 bytes for EVM).
+	PoolAddress []byte `json:"poolAddress"`
+	// TokenAddress is the address of the remote token, encoded as expected by the remote chain.
+	TokenAddress []byte `json:"tokenAddress"`
+	// RateLimiterConfig is applied to both inbound and outbound transfers with the remote chain.
+	RateLimiterConfig token_pool.RateLimiterConfig `json:"rateLimiterConfig"`
+}
+
+// SelfServeTokenPoolInput defines a customer token to onboard and its pool.
+type SelfServeTokenPoolInput struct {
+	// TokenAddress is the address of the existing customer token.
+	TokenAddress common.Address `json:"tokenAddress"`
+	// PoolType is the type of the pool, either BurnMintTokenPool or LockReleaseTokenPool.
+	PoolType cldf.ContractType `json:"poolType"`
+	// LocalTokenDecimals is the number of decimals of the token, checked against the token if it implements decimals().
+	LocalTokenDecimals uint8 `json:"localTokenDecimals"`
+	// ProposedOwner is the customer address proposed as owner of the pool and administrator of the token.
+	ProposedOwner common.Address `json:"proposedOwner"`
+	// RemoteTokenPools are the pools of the token on remote chains, by remote chain selector.
+	RemoteTokenPools map[uint64]SelfServeRemoteTokenPool `json:"remoteTokenPools"`
+	// Salt is the salt of the CREATE2 deployment of the pool, which is combined with the deployer address by the factory.
+	Salt [32]byte `json:"salt"`
+}
+
+type OnboardTokenPoolsForSelfServeConfig struct {
+	// PoolsByChain are the tokens to onboard, by chain selector.
+	PoolsByChain map[uint64][]SelfServeTokenPoolInput `json:"poolsByChain"`
+	// MCMS defines the delay to use for Timelock (if absent, the changeset will attempt to use the deployer key).
+	// The pools are always deployed with the deployer key, only the administrators are proposed with MCMS.
+	MCMS *proposalutils.TimelockConfig
+}
+
+func (i SelfServeTokenPoolInput) Validate(e cldf.Environment, chain cldf_evm.Chain, chainState evm.CCIPChainState) error {
+	if i.TokenAddress == utils.ZeroAddress {
+		return errors.New("token address must be defined")
+	}
+	if _, ok := selfServePoolTypes[i.PoolType]; !ok {
+		return fmt.Errorf("pool type %s is not supported by the token pool factory", i.PoolType)
+	}
+	if i.ProposedOwner == utils.ZeroAddress {
+		return fmt.Errorf("proposed owner must be defined for token %s", i.TokenAddress)
+	}
+	if i.ProposedOwner == i.TokenAddress {
+		return fmt.Errorf("proposed owner cannot be the same as token %s", i.TokenAddress)
+	}
+	for remoteChainSelector, remotePool := range i.RemoteTokenPools {
+		if remoteChainSelector == chain.Selector {
+			return fmt.Errorf("remote chain %d cannot be the chain of the pool", remoteChainSelector)
+		}
+		if err := cldf.IsValidChainSelector(remoteChainSelector); err != nil {
+			return fmt.Errorf("invalid remote chain selector %d: %w", remoteChainSelector, err)
+		}
+		if len(remotePool.PoolAddress) == 0 || len(remotePool.TokenAddress) == 0 {
+			return fmt.Errorf("remote pool and token addresses must be defined for remote chain %d", remoteChainSelector)
+		}
+	}
+
+	opts := &bind.CallOpts{Context: e.GetContext()}
+	token, err := erc20.NewERC20(i.TokenAddress, chain.Client)
+	if err != nil {
+		return fmt.Errorf("failed to connect address %s with erc20 bindings: %w", i.TokenAddress, err)
+	}
+	// decimals() is not part of the ERC20 standard, the configured decimals are used if the token does not implement it
+	decimals, err := token.Decimals(opts)
+	if err != nil {
+		e.Logger.Warnw("Failed to get decimals of token, using configured decimals", "token", i.TokenAddress, "decimals", i.LocalTokenDecimals, "chain", chain.String(), "err", err)
+	} else if decimals != i.LocalTokenDecimals {
+		return fmt.Errorf("token %s has %d decimals, expected %d", i.TokenAddress, decimals, i.LocalTokenDecimals)
+	}
+
+	tokenConfig, err := chainState.TokenAdminRegistry.GetTokenConfig(opts, i.TokenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get config of token %s from registry: %w", i.TokenAddress, err)
+	}
+	if tokenConfig.Administrator != utils.ZeroAddress {
+		return fmt.Errorf("token %s already has an administrator (%s)", i.TokenAddress, tokenConfig.Administrator)
+	}
+	if tokenConfig.PendingAdministrator != utils.ZeroAddress {
+		return fmt.Errorf("token %s already has a pending administrator (%s)", i.TokenAddress, tokenConfig.PendingAdministrator)
+	}
+	return nil
+}
+
+func onboardTokenPoolsForSelfServePrecondition(e cldf.Environment, cfg OnboardTokenPoolsForSelfServeConfig) error {
+	if len(cfg.PoolsByChain) == 0 {
+		return errors.New("at least one chain with tokens must be specified in OnboardTokenPoolsForSelfServeConfig")
+	}
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	for chainSelector, pools := range cfg.PoolsByChain {
+		if err := stateview.ValidateChain(e, state, chainSelector, cfg.MCMS); err != nil {
+			return fmt.Errorf("failed to validate chain with selector %d: %w", chainSelector, err)
+		}
+		chain := e.BlockChains.EVMChains()[chainSelector]
+		chainState := state.Chains[chainSelector]
+		if chainState.TokenPoolFactory == nil {
+			return fmt.Errorf("token pool factory does not exist on %s", chain)
+		}
+		if chainState.TokenAdminRegistry == nil {
+			return fmt.Errorf("token admin registry does not exist on %s", chain)
+		}
+		var timelock common.Address
+		if chainState.Timelock != nil {
+			timelock = chainState.Timelock.Address()
+		}
+		if err := commoncs.ValidateOwnership(e.GetContext(), cfg.MCMS != nil, chain.DeployerKey.From, timelock, chainState.TokenAdminRegistry); err != nil {
+			return fmt.Errorf("token admin registry failed ownership validation on %s: %w", chain, err)
+		}
+		if len(pools) == 0 {
+			return fmt.Errorf("no tokens provided for %s", chain)
+		}
+
+		seenTokens := make(map[common.Address]bool, len(pools))
+		for _, pool := range pools {
+			if seenTokens[pool.TokenAddress] {
+				return fmt.Errorf("duplicate token %s on %s", pool.TokenAddress, chain)
+			}
+			seenTokens[pool.TokenAddress] = true
+			if err := pool.Validate(e, chain, chainState); err != nil {
+				return fmt.Errorf("invalid token pool input on %s: %w", chain, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func onboardTokenPoolsForSelfServeLogic(e cldf.Environment, cfg OnboardTokenPoolsForSelfServeConfig) (cldf.ChangesetOutput, error) {
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	addressBook := cldf.NewMemoryAddressBook()
+	deployerGroup := deployergroup.NewDeployerGroup(e, state, cfg.MCMS).WithDeploymentContext("onboard tokens for self serve")
+
+	for chainSelector, pools := range cfg.PoolsByChain {
+		chain := e.BlockChains.EVMChains()[chainSelector]
+		chainState := state.Chains[chainSelector]
+		opts, err := deployerGroup.GetDeployer(chainSelector)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to get deployer for %s: %w", chain, err)
+		}
+
+		for _, pool := range pools {
+			poolAddress, err := deploySelfServeTokenPool(e, chain, chainState, pool)
+			if err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to onboard token %s on %s: %w", pool.TokenAddress, chain, err)
+			}
+			if err := verifySelfServeTokenPool(e, chain, chainState, pool, poolAddress); err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to verify pool %s of token %s on %s: %w", poolAddress, pool.TokenAddress, chain, err)
+			}
+			err = addressBook.Save(chainSelector, poolAddress.Hex(), cldf.NewTypeAndVersion(pool.PoolType, deployment.Version1_5_1))
+			if err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to save address %s for chain %d: %w", poolAddress.Hex(), chainSelector, err)
+			}
+
+			_, err = chainState.TokenAdminRegistry.ProposeAdministrator(opts, pool.TokenAddress, pool.ProposedOwner)
+			if err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to create proposeAdministrator transaction for token %s on %s: %w", pool.TokenAddress, chain, err)
+			}
+			e.Logger.Infow("Onboarded token for self serve", "token", pool.TokenAddress, "pool", poolAddress, "proposedOwner", pool.ProposedOwner, "chain", chain.String())
+		}
+	}
+
+	ds, err := shared.PopulateDataStore(addressBook)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to populate in-memory DataStore: %w", err)
+	}
+	out, err := deployerGroup.Enact()
+	if err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	out.AddressBook = addressBook
+	out.DataStore = ds
+	return out, nil
+}
+
+// deploySelfServeTokenPool deploys the pool with the token pool factory, which begins the transfer of its ownership to
+// the deployer, then accepts the ownership and begins its transfer to the proposed owner.
+func deploySelfServeTokenPool(e cldf.Environment, chain cldf_evm.Chain, chainState evm.CCIPChainState, pool SelfServeTokenPoolInput) (common.Address, error) {
+	var poolInitCode []byte
+	switch pool.PoolType {
+	case shared.BurnMintTokenPool:
+		poolInitCode = common.FromHex(burn_mint_token_pool.BurnMintTokenPoolBin)
+	case shared.LockReleaseTokenPool:
+		poolInitCode = common.FromHex(lock_release_token_pool.LockReleaseTokenPoolBin)
+	}
+	poolType := selfServePoolTypes[pool.PoolType]
+
+	remoteTokenPools := make([]token_pool_factory.TokenPoolFactoryRemoteTokenPoolInfo, 0, len(pool.RemoteTokenPools))
+	for _, remoteChainSelector := range slices.Sorted(maps.Keys(pool.RemoteTokenPools)) {
+		remotePool := pool.RemoteTokenPools[remoteChainSelector]
+		remoteTokenPools = append(remoteTokenPools, token_pool_factory.TokenPoolFactoryRemoteTokenPoolInfo{
+			RemoteChainSelector: remoteChainSelector,
+			RemotePoolAddress:   remotePool.PoolAddress,
+			RemoteTokenAddress:  remotePool.TokenAddress,
+			PoolType:            poolType,
+			RateLimiterConfig: token_pool_factory.RateLimiterConfig{
+				IsEnabled: remotePool.RateLimiterConfig.IsEnabled,
+				Capacity:  remotePool.RateLimiterConfig.Capacity,
+				Rate:      remotePool.RateLimiterConfig.Rate,
+			},
+		})
+	}
+
+	tx, err := chainState.TokenPoolFactory.DeployTokenPoolWithExistingToken(chain.DeployerKey, pool.TokenAddress, pool.LocalTokenDecimals, remoteTokenPools, poolInitCode, pool.Salt, poolType)
+	if _, err := cldf.ConfirmIfNoErrorWithABI(chain, tx, token_pool_factory.TokenPoolFactoryABI, err); err != nil {
+		return common.Address{}, fmt.Errorf("failed to deploy pool with token pool factory: %w", err)
+	}
+	poolAddress, err := findSelfServeTokenPool(e, chain, tx.Hash())
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	poolContract, err := token_pool.NewTokenPool(poolAddress, chain.Client)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to connect address %s with token pool bindings: %w", poolAddress, err)
+	}
+	tx, err = poolContract.AcceptOwnership(chain.DeployerKey)
+	if _, err := cldf.ConfirmIfNoErrorWithABI(chain, tx, token_pool.TokenPoolABI, err); err != nil {
+		return common.Address{}, fmt.Errorf("failed to accept ownership of pool %s: %w", poolAddress, err)
+	}
+	tx, err = poolContract.TransferOwnership(chain.DeployerKey, pool.ProposedOwner)
+	if _, err := cldf.ConfirmIfNoErrorWithABI(chain, tx, token_pool.TokenPoolABI, err); err != nil {
+		return common.Address{}, fmt.Errorf("failed to transfer ownership of pool %s to %s: %w", poolAddress, pool.ProposedOwner, err)
+	}
+	return poolAddress, nil
+}
+
+// findSelfServeTokenPool returns the address of the pool deployed by the factory, which is the emitter of the
+// OwnershipTransferRequested event to the deployer.
+func findSelfServeTokenPool(e cldf.Environment, chain cldf_evm.Chain, txHash common.Hash) (common.Address, error) {
+	receipt, err := chain.Client.TransactionReceipt(e.GetContext(), txHash)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get receipt of transaction %s: %w", txHash, err)
+	}
+	filterer, err := token_pool.NewTokenPoolFilterer(common.Address{}, chain.Client)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to create token pool filterer: %w", err)
+	}
+	for _, log := range receipt.Logs {
+		event, err := filterer.ParseOwnershipTransferRequested(*log)
+		if err != nil {
+			continue
+		}
+		if event.To == chain.DeployerKey.From {
+			return log.Address, nil
+		}
+	}
+	return common.Address{}, fmt.Errorf("no pool deployed by transaction %s", txHash)
+}
+
+// verifySelfServeTokenPool checks that the deployed pool has the token, decimals, CCIP contracts and remote pools of
+// the input, and that it is owned by the deployer until the proposed owner accepts it.
+func verifySelfServeTokenPool(e cldf.Environment, chain cldf_evm.Chain, chainState evm.CCIPChainState, pool SelfServeTokenPoolInput, poolAddress common.Address) error {
+	poolContract, err := token_pool.NewTokenPool(poolAddress, chain.Client)
+	if err != nil {
+		return fmt.Errorf("failed to connect address %s with token pool bindings: %w", poolAddress, err)
+	}
+	opts := &bind.CallOpts{Context: e.GetContext()}
+
+	token, err := poolContract.GetToken(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+	if token != pool.TokenAddress {
+		return fmt.Errorf("pool has token %s, expected %s", token, pool.TokenAddress)
+	}
+	decimals, err := poolContract.GetTokenDecimals(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get token decimals: %w", err)
+	}
+	if decimals != pool.LocalTokenDecimals {
+		return fmt.Errorf("pool has %d token decimals, expected %d", decimals, pool.LocalTokenDecimals)
+	}
+	router, err := poolContract.GetRouter(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get router: %w", err)
+	}
+	if router != chainState.Router.Address() {
+		return fmt.Errorf("pool has router %s, expected %s", router, chainState.Router.Address())
+	}
+	owner, err := poolContract.Owner(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get owner: %w", err)
+	}
+	if owner != chain.DeployerKey.From {
+		return fmt.Errorf("pool is owned by %s, expected %s", owner, chain.DeployerKey.From)
+	}
+
+	supportedChains, err := poolContract.GetSupportedChains(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get supported chains: %w", err)
+	}
+	if len(supportedChains) != len(pool.RemoteTokenPools) {
+		return fmt.Errorf("pool supports %d remote chains, expected %d", len(supportedChains), len(pool.RemoteTokenPools))
+	}
+	for remoteChainSelector, remotePool := range pool.RemoteTokenPools {
+		isRemotePool, err := poolContract.IsRemotePool(opts, remoteChainSelector, remotePool.PoolAddress)
+		if err != nil {
+			return fmt.Errorf("failed to check remote pool of remote chain %d: %w", remoteChainSelector, err)
+		}
+		if !isRemotePool {
+			return fmt.Errorf("%x is not a remote pool of remote chain %d", remotePool.PoolAddress, remoteChainSelector)
+		}
+		remoteToken, err := poolContract.GetRemoteToken(opts, remoteChainSelector)
+		if err != nil {
+			return fmt.Errorf("failed to get remote token of remote chain %d: %w", remoteChainSelector, err)
+		}
+		if !bytes.Equal(remoteToken, remotePool.TokenAddress) {
+			return fmt.Errorf("pool has remote token %x for remote chain %d, expected %x", remoteToken, remoteChainSelector, remotePool.TokenAddress)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package v1_5_1_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/token_pool"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_5_1"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func selfServeTokenPoolInput(tokenAddress common.Address, proposedOwner common.Address, remoteChainSelector uint64) v1_5_1.SelfServeTokenPoolInput {
+	return v1_5_1.SelfServeTokenPoolInput{
+		TokenAddress:       tokenAddress,
+		PoolType:           shared.BurnMintTokenPool,
+		LocalTokenDecimals: testhelpers.LocalTokenDecimals,
+		ProposedOwner:      proposedOwner,
+		RemoteTokenPools: map[uint64]v1_5_1.SelfServeRemoteTokenPool{
+			remoteChainSelector: {
+				PoolAddress:  common.LeftPadBytes(utils.RandomAddress().Bytes(), 32),
+				TokenAddress: common.LeftPadBytes(utils.RandomAddress().Bytes(), 32),
+				RateLimiterConfig: token_pool.RateLimiterConfig{
+					IsEnabled: true,
+					Capacity:  big.NewInt(1000),
+					Rate:      big.NewInt(100),
+				},
+			},
+		},
+	}
+}
+
+func TestOnboardTokenPoolsForSelfServeChangeset_Validations(t *testing.T) {
+	t.Parallel()
+
+	e, selectorA, selectorB, tokens := testhelpers.SetupTwoChainEnvironmentWithTokens(t, logger.TestLogger(t), false)
+	e, err := commonchangeset.Apply(t, e, commonchangeset.Configure(
+		v1_5_1.DeployTokenPoolFactoryChangeset,
+		v1_5_1.DeployTokenPoolFactoryConfig{Chains: []uint64{selectorA}},
+	))
+	require.NoError(t, err)
+
+	proposedOwner := utils.RandomAddress()
+	tests := []struct {
+		Msg    string
+		Input  func(input v1_5_1.SelfServeTokenPoolInput) v1_5_1.SelfServeTokenPoolInput
+		Chain  uint64
+		ErrStr string
+	}{
+		{
+			Msg:    "Token pool factory is missing",
+			Chain:  selectorB,
+			ErrStr: "token pool factory does not exist",
+		},
+		{
+			Msg: "Pool type is not supported",
+			Input: func(input v1_5_1.SelfServeTokenPoolInput) v1_5_1.SelfServeTokenPoolInput {
+				input.PoolType = shared.BurnFromMintTokenPool
+				return input
+			},
+			ErrStr: "is not supported by the token pool factory",
+		},
+		{
+			Msg: "Proposed owner is missing",
+			Input: func(input v1_5_1.SelfServeTokenPoolInput) v1_5_1.SelfServeTokenPoolInput {
+				input.ProposedOwner = utils.ZeroAddress
+				return input
+			},
+			ErrStr: "proposed owner must be defined",
+		},
+		{
+			Msg: "Token decimals do not match",
+			Input: func(input v1_5_1.SelfServeTokenPoolInput) v1_5_1.SelfServeTokenPoolInput {
+				input.LocalTokenDecimals = testhelpers.LocalTokenDecimals + 1
+				return input
+			},
+			ErrStr: "decimals, expected",
+		},
+		{
+			Msg: "Remote pool is missing",
+			Input: func(input v1_5_1.SelfServeTokenPoolInput) v1_5_1.SelfServeTokenPoolInput {
+				input.RemoteTokenPools[selectorB] = v1_5_1.SelfServeRemoteTokenPool{}
+				return input
+			},
+			ErrStr: "remote pool and token addresses must be defined",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Msg, func(t *testing.T) {
+			chain := test.Chain
+			if chain == 0 {
+				chain = selectorA
+			}
+			input := selfServeTokenPoolInput(tokens[chain].Address, proposedOwner, selectorB)
+			if test.Input != nil {
+				input = test.Input(input)
+			}
+			_, err := commonchangeset.Apply(t, e, commonchangeset.Configure(
+				v1_5_1.OnboardTokenPoolsForSelfServeChangeset,
+				v1_5_1.OnboardTokenPoolsForSelfServeConfig{
+					PoolsByChain: map[uint64][]v1_5_1.SelfServeTokenPoolInput{chain: {input}},
+				},
+			))
+			require.Error(t, err)
+			require.ErrorContains(t, err, test.ErrStr)
+		})
+	}
+}
+
+func TestOnboardTokenPoolsForSelfServeChangeset_Execution(t *testing.T) {
+	t.Parallel()
+
+	for _, mcmsConfig := range []*proposalutils.TimelockConfig{nil, {MinDelay: 0 * time.Second}} {
+		msg := "Onboard token without MCMS"
+		if mcmsConfig != nil {
+			msg = "Onboard token with MCMS"
+		}
+		t.Run(msg, func(t *testing.T) {
+			e, selectorA, selectorB, tokens := testhelpers.SetupTwoChainEnvironmentWithTokens(t, logger.TestLogger(t), mcmsConfig != nil)
+			e, err := commonchangeset.Apply(t, e, commonchangeset.Configure(
+				v1_5_1.DeployTokenPoolFactoryChangeset,
+				v1_5_1.DeployTokenPoolFactoryConfig{Chains: []uint64{selectorA}},
+			))
+			require.NoError(t, err)
+
+			proposedOwner := utils.RandomAddress()
+			input := selfServeTokenPoolInput(tokens[selectorA].Address, proposedOwner, selectorB)
+			e, err = commonchangeset.Apply(t, e, commonchangeset.Configure(
+				v1_5_1.OnboardTokenPoolsForSelfServeChangeset,
+				v1_5_1.OnboardTokenPoolsForSelfServeConfig{
+					PoolsByChain: map[uint64][]v1_5_1.SelfServeTokenPoolInput{selectorA: {input}},
+					MCMS:         mcmsConfig,
+				},
+			))
+			require.NoError(t, err)
+
+			state, err := stateview.LoadOnchainState(e)
+			require.NoError(t, err)
+			chainState := state.MustGetEVMChainState(selectorA)
+			pool, ok := chainState.BurnMintTokenPools[testhelpers.TestTokenSymbol][deployment.Version1_5_1]
+			require.True(t, ok, "pool should be recorded in the address book")
+
+			opts := &bind.CallOpts{Context: t.Context()}
+			owner, err := pool.Owner(opts)
+			require.NoError(t, err)
+			require.Equal(t, e.BlockChains.EVMChains()[selectorA].DeployerKey.From, owner, "pool should be owned by the deployer until the proposed owner accepts it")
+			remoteToken, err := pool.GetRemoteToken(opts, selectorB)
+			require.NoError(t, err)
+			require.Equal(t, input.RemoteTokenPools[selectorB].TokenAddress, remoteToken)
+
+			tokenConfig, err := chainState.TokenAdminRegistry.GetTokenConfig(opts, tokens[selectorA].Address)
+			require.NoError(t, err)
+			require.Equal(t, proposedOwner, tokenConfig.PendingAdministrator)
+			require.Equal(t, utils.ZeroAddress, tokenConfig.TokenPool, "pool should be set by the administrator")
+
+			// The token can't be onboarded twice
+			_, err = commonchangeset.Apply(t, e, commonchangeset.Configure(
+				v1_5_1.OnboardTokenPoolsForSelfServeChangeset,
+				v1_5_1.OnboardTokenPoolsForSelfServeConfig{
+					PoolsByChain: map[uint64][]v1_5_1.SelfServeTokenPoolInput{selectorA: {input}},
+					MCMS:         mcmsConfig,
+				},
+			))
+			require.ErrorContains(t, err, "already has a pending administrator")
+		})
+	}
+}
@@ -64,7 +64,7 @@ type ChainDefinition struct {
 	// ConnectionConfig holds configuration for connection.
 	ConnectionConfig `json:"connectionConfig"`
 	// Selector is the chain selector of this chain.
-	Selector uint64 `json:"selector"`
+	Selector uint64 `json:"selector" validate:"required,chainselector"`
 	// GasPrice defines the USD price (18 decimals) per unit gas for this chain as a destination.
 	GasPrice *big.Int `json:"gasPrice"`
 	// TokenPrices define the USD price (18 decimals) per 1e18 of the smallest token denomination for various tokens on this chain.
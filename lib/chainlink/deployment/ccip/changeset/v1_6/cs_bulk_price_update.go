@@ -0,0 +1,344 @@
+package v1_6
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+
+	cldf_sui "github.com/smartcontractkit/chainlink-deployments-framework/chain/sui"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	suiBind "github.com/smartcontractkit/chainlink-sui/bindings/bind"
+	module_fee_quoter "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip/fee_quoter"
+	sui_deployment "github.com/smartcontractkit/chainlink-sui/deployment"
+	sui_ops "github.com/smartcontractkit/chainlink-sui/deployment/ops"
+	sui_ccipops "github.com/smartcontractkit/chainlink-sui/deployment/ops/ccip"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+const defaultPriceSourceTimeout = 10 * time.Second
+
+// PriceManifest is a snapshot of token and gas prices taken from an external price source, which is either given
+// directly to BulkUpdateFeeQuoterPricesChangeset or served as JSON by a price API.
+type PriceManifest struct {
+	// Timestamp is the time at which the prices were observed by the price source.
+	Timestamp time.Time `json:"timestamp"`
+	// PricesByChain are the prices to set on the fee quoter of each chain, by chain selector.
+	PricesByChain map[uint64]ManifestChainPrices `json:"pricesByChain"`
+}
+
+// ManifestChainPrices are the prices to set on the fee quoter of a chain.
+type ManifestChainPrices struct {
+	// TokenPrices are the USD prices of the tokens of the chain, with 18 decimals per whole token, by token address.
+	// Token addresses are hex EVM addresses on EVM chains and coin metadata object IDs on Sui chains.
+	TokenPrices map[string]*big.Int `json:"tokenPrices"`
+	// GasPrices are the USD prices per unit of gas of the destination chains, by destination chain selector.
+	GasPrices map[uint64]*big.Int `json:"gasPrices"`
+}
+
+// BulkUpdateFeeQuoterPricesConfig configures BulkUpdateFeeQuoterPricesChangeset.
+type BulkUpdateFeeQuoterPricesConfig struct {
+	// Manifest contains the prices to set, it is mutually exclusive with PriceSourceURL.
+	Manifest *PriceManifest
+	// PriceSourceURL is the URL of a price API returning the prices to set as a JSON PriceManifest.
+	PriceSourceURL string
+	// PriceSourceTimeout is the timeout of the request to the price API, 10 seconds by default.
+	PriceSourceTimeout time.Duration
+	// MaxPriceAge rejects manifests older than this duration, it is not checked if zero.
+	MaxPriceAge time.Duration
+	// MaxDeviationBps rejects prices deviating from the current onchain prices by more than this number of basis
+	// points. It is not checked if zero, nor for prices which are not set onchain yet.
+	MaxDeviationBps uint64
+	// MCMS defines the delay to use for Timelock (if absent, the changeset will attempt to use the deployer key).
+	// It only applies to EVM chains, the prices of Sui chains are always updated by the Sui signer with the CCIP owner cap.
+	MCMS *proposalutils.TimelockConfig
+}
+
+func (cfg BulkUpdateFeeQuoterPricesConfig) Validate() error {
+	if (cfg.Manifest == nil) == (cfg.PriceSourceURL == "") {
+		return errors.New("exactly one of Manifest and PriceSourceURL must be defined")
+	}
+	if cfg.PriceSourceTimeout < 0 || cfg.MaxPriceAge < 0 {
+		return errors.New("PriceSourceTimeout and MaxPriceAge cannot be negative")
+	}
+	return nil
+}
+
+// BulkUpdateFeeQuoterPricesChangeset updates the token and gas prices of the fee quoters of multiple EVM and Sui chains
+// from a price manifest, or from the manifest returned by a price API. The prices of each chain are set with a single
+// updatePrices call, and the calls of all EVM chains are batched in a single proposal if MCMS is defined.
+// The manifest is rejected if it is older than MaxPriceAge, or if a price deviates from the current onchain price by
+// more than MaxDeviationBps.
+func BulkUpdateFeeQuoterPricesChangeset(e cldf.Environment, cfg BulkUpdateFeeQuoterPricesConfig) (cldf.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	manifest := cfg.Manifest
+	if manifest == nil {
+		var err error
+		manifest, err = fetchPriceManifest(e, cfg)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+	}
+	if err := validatePriceManifest(*manifest, cfg.MaxPriceAge); err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	evmCfg := UpdateFeeQuoterPricesConfig{
+		PricesByChain: make(map[uint64]FeeQuoterPriceUpdatePerSource),
+		MCMS:          cfg.MCMS,
+	}
+	suiPrices := make(map[uint64]ManifestChainPrices)
+	for chainSel, prices := range manifest.PricesByChain {
+		family, err := chain_selectors.GetSelectorFamily(chainSel)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to get family of chain %d: %w", chainSel, err)
+		}
+		switch family {
+		case chain_selectors.FamilyEVM:
+			update, err := evmPriceUpdate(e, state, chainSel, prices, cfg.MaxDeviationBps)
+			if err != nil {
+				return cldf.ChangesetOutput{}, err
+			}
+			evmCfg.PricesByChain[chainSel] = update
+		case chain_selectors.FamilySui:
+			if err := validateSuiPrices(e, state, chainSel, prices, cfg.MaxDeviationBps); err != nil {
+				return cldf.ChangesetOutput{}, err
+			}
+			suiPrices[chainSel] = prices
+		default:
+			return cldf.ChangesetOutput{}, fmt.Errorf("chain %d of family %s is not supported", chainSel, family)
+		}
+	}
+
+	out := cldf.ChangesetOutput{}
+	if len(evmCfg.PricesByChain) > 0 {
+		out, err = UpdateFeeQuoterPricesChangeset(e, evmCfg)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to update prices on EVM chains: %w", err)
+		}
+	}
+	for chainSel, prices := range suiPrices {
+		if err := updateSuiPrices(e, state, chainSel, prices); err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// fetchPriceManifest queries the price API for the manifest of the prices to set.
+func fetchPriceManifest(e cldf.Environment, cfg BulkUpdateFeeQuoterPricesConfig) (*PriceManifest, error) {
+	timeout := cfg.PriceSourceTimeout
+	if timeout == 0 {
+		timeout = defaultPriceSourceTimeout
+	}
+	req, err := http.NewRequestWithContext(e.GetContext(), http.MethodGet, cfg.PriceSourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to price source: %w", err)
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query price source: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("price source returned status %d", resp.StatusCode)
+	}
+
+	var manifest PriceManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode price manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+func validatePriceManifest(manifest PriceManifest, maxPriceAge time.Duration) error {
+	if len(manifest.PricesByChain) == 0 {
+		return errors.New("price manifest has no prices")
+	}
+	if manifest.Timestamp.IsZero() {
+		return errors.New("price manifest has no timestamp")
+	}
+	if manifest.Timestamp.After(time.Now()) {
+		return fmt.Errorf("price manifest timestamp %s is in the future", manifest.Timestamp)
+	}
+	if maxPriceAge > 0 {
+		if age := time.Since(manifest.Timestamp); age > maxPriceAge {
+			return fmt.Errorf("price manifest is stale: it is %s old, the maximum age is %s", age.Round(time.Second), maxPriceAge)
+		}
+	}
+	for chainSel, prices := range manifest.PricesByChain {
+		if len(prices.TokenPrices) == 0 && len(prices.GasPrices) == 0 {
+			return fmt.Errorf("no prices for chain %d", chainSel)
+		}
+		for token, price := range prices.TokenPrices {
+			if price == nil || price.Sign() <= 0 {
+				return fmt.Errorf("price of token %s on chain %d must be positive", token, chainSel)
+			}
+		}
+		for dest, price := range prices.GasPrices {
+			if price == nil || price.Sign() <= 0 {
+				return fmt.Errorf("gas price of chain %d on chain %d must be positive", dest, chainSel)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPriceDeviation returns an error if the new price deviates from the current price by more than maxDeviationBps.
+// It does not check prices which are not set yet.
+func checkPriceDeviation(current, updated *big.Int, maxDeviationBps uint64) error {
+	if maxDeviationBps == 0 || current == nil || current.Sign() == 0 {
+		return nil
+	}
+	diff := new(big.Int).Abs(new(big.Int).Sub(updated, current))
+	deviationBps := new(big.Int).Div(new(big.Int).Mul(diff, big.NewInt(10_000)), current)
+	if deviationBps.Cmp(new(big.Int).SetUint64(maxDeviationBps)) > 0 {
+		return fmt.Errorf("price %s deviates from current price %s by %s bps, the maximum is %d bps", updated, current, deviationBps, maxDeviationBps)
+	}
+	return nil
+}
+
+func evmPriceUpdate(e cldf.Environment, state stateview.CCIPOnChainState, chainSel uint64, prices ManifestChainPrices, maxDeviationBps uint64) (FeeQuoterPriceUpdatePerSource, error) {
+	chainState, ok := state.Chains[chainSel]
+	if !ok || chainState.FeeQuoter == nil {
+		return FeeQuoterPriceUpdatePerSource{}, fmt.Errorf("missing fee quoter for chain %d", chainSel)
+	}
+	opts := &bind.CallOpts{Context: e.GetContext()}
+	update := FeeQuoterPriceUpdatePerSource{
+		TokenPrices: make(map[common.Address]*big.Int, len(prices.TokenPrices)),
+		GasPrices:   make(map[uint64]*big.Int, len(prices.GasPrices)),
+	}
+	for token, price := range prices.TokenPrices {
+		if !common.IsHexAddress(token) {
+			return FeeQuoterPriceUpdatePerSource{}, fmt.Errorf("invalid token address %s for chain %d", token, chainSel)
+		}
+		tokenAddress := common.HexToAddress(token)
+		current, err := chainState.FeeQuoter.GetTokenPrice(opts, tokenAddress)
+		if err != nil {
+			return FeeQuoterPriceUpdatePerSource{}, fmt.Errorf("failed to get price of token %s on chain %d: %w", token, chainSel, err)
+		}
+		if err := checkPriceDeviation(current.Value, price, maxDeviationBps); err != nil {
+			return FeeQuoterPriceUpdatePerSource{}, fmt.Errorf("invalid price of token %s on chain %d: %w", token, chainSel, err)
+		}
+		update.TokenPrices[tokenAddress] = price
+	}
+	for dest, price := range prices.GasPrices {
+		current, err := chainState.FeeQuoter.GetDestinationChainGasPrice(opts, dest)
+		if err != nil {
+			return FeeQuoterPriceUpdatePerSource{}, fmt.Errorf("failed to get gas price of chain %d on chain %d: %w", dest, chainSel, err)
+		}
+		if err := checkPriceDeviation(current.Value, price, maxDeviationBps); err != nil {
+			return FeeQuoterPriceUpdatePerSource{}, fmt.Errorf("invalid gas price of chain %d on chain %d: %w", dest, chainSel, err)
+		}
+		update.GasPrices[dest] = price
+	}
+	return update, nil
+}
+
+func suiFeeQuoter(e cldf.Environment, state stateview.CCIPOnChainState, chainSel uint64) (cldf_sui.Chain, sui_deployment.CCIPChainState, module_fee_quoter.IFeeQuoter, error) {
+	chain, ok := e.BlockChains.SuiChains()[chainSel]
+	if !ok {
+		return cldf_sui.Chain{}, sui_deployment.CCIPChainState{}, nil, fmt.Errorf("sui chain %d not found in environment", chainSel)
+	}
+	chainState, ok := state.SuiChains[chainSel]
+	if !ok || chainState.CCIPAddress == "" || chainState.CCIPObjectRef == "" {
+		return cldf_sui.Chain{}, sui_deployment.CCIPChainState{}, nil, fmt.Errorf("missing CCIP package for sui chain %d", chainSel)
+	}
+	feeQuoter, err := module_fee_quoter.NewFeeQuoter(chainState.CCIPAddress, chain.Client)
+	if err != nil {
+		return cldf_sui.Chain{}, sui_deployment.CCIPChainState{}, nil, fmt.Errorf("failed to create fee quoter binding for sui chain %d: %w", chainSel, err)
+	}
+	return chain, chainState, feeQuoter, nil
+}
+
+// validateSuiPrices checks the deviation of the prices from the current prices, which are read with dev inspect calls.
+// The fee quoter aborts when reading a price which is not set yet, such prices are not checked.
+func validateSuiPrices(e cldf.Environment, state stateview.CCIPOnChainState, chainSel uint64, prices ManifestChainPrices, maxDeviationBps uint64) error {
+	chain, chainState, feeQuoter, err := suiFeeQuoter(e, state, chainSel)
+	if err != nil {
+		return err
+	}
+	if chainState.CCIPOwnerCapObjectId == "" {
+		return fmt.Errorf("missing CCIP owner cap for sui chain %d", chainSel)
+	}
+	if maxDeviationBps == 0 {
+		return nil
+	}
+	opts := &suiBind.CallOpts{Signer: chain.Signer}
+	ref := suiBind.Object{Id: chainState.CCIPObjectRef}
+	for token, price := range prices.TokenPrices {
+		current, err := feeQuoter.DevInspect().GetTokenPrice(e.GetContext(), opts, ref, token)
+		if err != nil {
+			e.Logger.Warnw("Failed to get current token price, skipping deviation check", "chain", chainSel, "token", token, "err", err)
+			continue
+		}
+		if err := checkPriceDeviation(current.Value, price, maxDeviationBps); err != nil {
+			return fmt.Errorf("invalid price of token %s on sui chain %d: %w", token, chainSel, err)
+		}
+	}
+	for dest, price := range prices.GasPrices {
+		current, err := feeQuoter.DevInspect().GetDestChainGasPrice(e.GetContext(), opts, ref, dest)
+		if err != nil {
+			e.Logger.Warnw("Failed to get current gas price, skipping deviation check", "chain", chainSel, "dest", dest, "err", err)
+			continue
+		}
+		if err := checkPriceDeviation(current.Value, price, maxDeviationBps); err != nil {
+			return fmt.Errorf("invalid gas price of chain %d on sui chain %d: %w", dest, chainSel, err)
+		}
+	}
+	return nil
+}
+
+func updateSuiPrices(e cldf.Environment, state stateview.CCIPOnChainState, chainSel uint64, prices ManifestChainPrices) error {
+	chain, chainState, _, err := suiFeeQuoter(e, state, chainSel)
+	if err != nil {
+		return err
+	}
+	input := sui_ccipops.FeeQuoterUpdatePricesWithOwnerCapInput{
+		CCIPPackageId:    chainState.CCIPAddress,
+		CCIPObjectRef:    chainState.CCIPObjectRef,
+		OwnerCapObjectId: chainState.CCIPOwnerCapObjectId,
+	}
+	for token, price := range prices.TokenPrices {
+		input.SourceTokens = append(input.SourceTokens, token)
+		input.SourceUsdPerToken = append(input.SourceUsdPerToken, price)
+	}
+	for dest, price := range prices.GasPrices {
+		input.GasDestChainSelectors = append(input.GasDestChainSelectors, dest)
+		input.GasUsdPerUnitGas = append(input.GasUsdPerUnitGas, price)
+	}
+
+	deps := sui_ops.OpTxDeps{
+		Client: chain.Client,
+		Signer: chain.Signer,
+		GetCallOpts: func() *suiBind.CallOpts {
+			b := uint64(400_000_000)
+			return &suiBind.CallOpts{
+				WaitForExecution: true,
+				GasBudget:        &b,
+			}
+		},
+	}
+	report, err := operations.ExecuteOperation(e.OperationsBundle, sui_ccipops.FeeQuoterUpdatePricesWithOwnerCapOp, deps, input)
+	if err != nil {
+		return fmt.Errorf("failed to update prices on sui chain %d: %w", chainSel, err)
+	}
+	e.Logger.Infow("Updated fee quoter prices", "chain", chainSel, "digest", report.Output.Digest)
+	return nil
+}
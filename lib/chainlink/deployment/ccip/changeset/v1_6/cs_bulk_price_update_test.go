@@ -0,0 +1,93 @@
+package v1_6_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_6"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+)
+
+func TestBulkUpdateFeeQuoterPricesChangeset(t *testing.T) {
+	t.Parallel()
+	tenv, _ := testhelpers.NewMemoryEnvironment(t, testhelpers.WithNumOfChains(2))
+	state, err := stateview.LoadOnchainState(tenv.Env)
+	require.NoError(t, err)
+	allChains := maps.Keys(tenv.Env.BlockChains.EVMChains())
+	source, dest := allChains[0], allChains[1]
+	linkToken := state.Chains[source].LinkToken.Address()
+
+	manifest := func(linkPrice, gasPrice *big.Int, timestamp time.Time) *v1_6.PriceManifest {
+		return &v1_6.PriceManifest{
+			Timestamp: timestamp,
+			PricesByChain: map[uint64]v1_6.ManifestChainPrices{
+				source: {
+					TokenPrices: map[string]*big.Int{linkToken.Hex(): linkPrice},
+					GasPrices:   map[uint64]*big.Int{dest: gasPrice},
+				},
+			},
+		}
+	}
+	apply := func(cfg v1_6.BulkUpdateFeeQuoterPricesConfig) error {
+		var err error
+		tenv.Env, err = commonchangeset.Apply(t, tenv.Env, commonchangeset.Configure(
+			cldf.CreateLegacyChangeSet(v1_6.BulkUpdateFeeQuoterPricesChangeset), cfg,
+		))
+		return err
+	}
+	assertPrices := func(linkPrice, gasPrice *big.Int) {
+		opts := &bind.CallOpts{Context: t.Context()}
+		tokenPrice, err := state.Chains[source].FeeQuoter.GetTokenPrice(opts, linkToken)
+		require.NoError(t, err)
+		require.Equal(t, linkPrice.String(), tokenPrice.Value.String())
+		destGasPrice, err := state.Chains[source].FeeQuoter.GetDestinationChainGasPrice(opts, dest)
+		require.NoError(t, err)
+		require.Equal(t, gasPrice.String(), destGasPrice.Value.String())
+	}
+
+	linkPrice, gasPrice := deployment.E18Mult(20), big.NewInt(1e9)
+	require.NoError(t, apply(v1_6.BulkUpdateFeeQuoterPricesConfig{Manifest: manifest(linkPrice, gasPrice, time.Now())}))
+	assertPrices(linkPrice, gasPrice)
+
+	// stale manifests are rejected
+	err = apply(v1_6.BulkUpdateFeeQuoterPricesConfig{
+		Manifest:    manifest(linkPrice, gasPrice, time.Now().Add(-time.Hour)),
+		MaxPriceAge: time.Minute,
+	})
+	require.ErrorContains(t, err, "price manifest is stale")
+
+	// prices deviating too much from the current prices are rejected
+	err = apply(v1_6.BulkUpdateFeeQuoterPricesConfig{
+		Manifest:        manifest(deployment.E18Mult(30), gasPrice, time.Now()),
+		MaxDeviationBps: 1_000,
+	})
+	require.ErrorContains(t, err, "deviates from current price")
+
+	// prices within the bounds are fetched from the price source and set
+	linkPrice, gasPrice = deployment.E18Mult(21), big.NewInt(1.05e9)
+	body, err := json.Marshal(manifest(linkPrice, gasPrice, time.Now()))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+	require.NoError(t, apply(v1_6.BulkUpdateFeeQuoterPricesConfig{
+		PriceSourceURL:  server.URL,
+		MaxPriceAge:     time.Minute,
+		MaxDeviationBps: 1_000,
+	}))
+	assertPrices(linkPrice, gasPrice)
+}
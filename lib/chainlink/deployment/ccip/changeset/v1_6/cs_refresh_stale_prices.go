@@ -0,0 +1,156 @@
+package v1_6
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	ccipops "github.com/smartcontractkit/chainlink/deployment/ccip/operation/evm/v1_6"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+// RefreshStalePricesConfig configures RefreshStalePricesChangeset.
+type RefreshStalePricesConfig struct {
+	// Chains are the EVM chains whose FeeQuoter is scanned, all EVM chains with a FeeQuoter if empty.
+	Chains []uint64
+	// StalenessThreshold is the maximum age of a price, the staleness thresholds of each FeeQuoter are used if zero.
+	StalenessThreshold time.Duration
+	// Refresh updates the stale prices if set, otherwise they are only reported.
+	Refresh bool
+	// Prices are the new values of the stale prices. The current values of the stale prices not in the manifest are
+	// updated, which only refreshes their timestamp.
+	Prices *PriceManifest
+	// MCMS defines the delay to use for Timelock (if absent, the changeset will attempt to use the deployer key).
+	MCMS *proposalutils.TimelockConfig
+}
+
+func (cfg RefreshStalePricesConfig) Validate(e cldf.Environment, state stateview.CCIPOnChainState) error {
+	if cfg.StalenessThreshold < 0 {
+		return errors.New("StalenessThreshold cannot be negative")
+	}
+	if !cfg.Refresh && (cfg.Prices != nil || cfg.MCMS != nil) {
+		return errors.New("prices and MCMS can only be defined when refreshing stale prices")
+	}
+	for _, chainSel := range cfg.Chains {
+		if _, ok := e.BlockChains.EVMChains()[chainSel]; !ok {
+			return fmt.Errorf("chain %d is not an EVM chain of the environment", chainSel)
+		}
+		chainState, ok := state.Chains[chainSel]
+		if !ok || chainState.FeeQuoter == nil {
+			return fmt.Errorf("missing fee quoter for chain %d", chainSel)
+		}
+	}
+	return nil
+}
+
+// RefreshStalePricesChangeset scans the fee token prices and the gas prices of the lanes of the FeeQuoters of EVM
+// chains, and flags the prices older than the staleness threshold. If Refresh is set, the stale prices are updated
+// through UpdateFeeQuoterPricesChangeset. The scan of each FeeQuoter is returned in the reports of the output, which
+// are meant to be consumed by a periodic keeper job.
+func RefreshStalePricesChangeset(e cldf.Environment, cfg RefreshStalePricesConfig) (cldf.ChangesetOutput, error) {
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	if err := cfg.Validate(e, state); err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	chains := cfg.Chains
+	if len(chains) == 0 {
+		for chainSel := range e.BlockChains.EVMChains() {
+			if chainState, ok := state.Chains[chainSel]; ok && chainState.FeeQuoter != nil {
+				chains = append(chains, chainSel)
+			}
+		}
+	}
+	slices.Sort(chains)
+	lanes := slices.Sorted(maps.Keys(state.SupportedChains()))
+
+	out := cldf.ChangesetOutput{}
+	scannedAt := time.Now().UTC()
+	updates := make(map[uint64]FeeQuoterPriceUpdatePerSource)
+	for _, chainSel := range chains {
+		report, err := operations.ExecuteOperation(e.OperationsBundle, ccipops.FeeQuoterScanPriceStalenessOp, e.BlockChains.EVMChains()[chainSel], ccipops.FeeQuoterPriceStalenessInput{
+			Address:       state.Chains[chainSel].FeeQuoter.Address(),
+			ChainSelector: chainSel,
+			DestChainSelectors: slices.DeleteFunc(slices.Clone(lanes), func(dest uint64) bool {
+				return dest == chainSel
+			}),
+			Threshold: cfg.StalenessThreshold,
+			Timestamp: scannedAt,
+		})
+		if err != nil {
+			return out, fmt.Errorf("failed to scan prices of chain %d: %w", chainSel, err)
+		}
+		out.Reports = append(out.Reports, report.ToGenericReport())
+		for _, stale := range report.Output.StalePrices {
+			e.Logger.Warnw("Stale FeeQuoter price", "chain", chainSel, "token", stale.Token, "destChain", stale.DestChainSelector, "updatedAt", stale.UpdatedAt, "age", stale.Age, "threshold", stale.Threshold)
+		}
+		e.Logger.Infow("Scanned FeeQuoter prices", "chain", chainSel, "checked", report.Output.CheckedPrices, "stale", len(report.Output.StalePrices))
+		if cfg.Refresh && len(report.Output.StalePrices) > 0 {
+			update, err := refreshedPrices(chainSel, report.Output.StalePrices, cfg.Prices)
+			if err != nil {
+				return out, err
+			}
+			updates[chainSel] = update
+		}
+	}
+	if len(updates) == 0 {
+		return out, nil
+	}
+
+	updateOut, err := UpdateFeeQuoterPricesChangeset(e, UpdateFeeQuoterPricesConfig{PricesByChain: updates, MCMS: cfg.MCMS})
+	if err != nil {
+		return out, fmt.Errorf("failed to refresh stale prices: %w", err)
+	}
+	updateOut.Reports = append(out.Reports, updateOut.Reports...)
+	return updateOut, nil
+}
+
+// refreshedPrices returns the price updates of the stale prices of a chain, with the prices of the manifest if it has
+// them, otherwise with their current values.
+func refreshedPrices(chainSel uint64, stalePrices []ccipops.StalePrice, manifest *PriceManifest) (FeeQuoterPriceUpdatePerSource, error) {
+	var manifestPrices ManifestChainPrices
+	if manifest != nil {
+		manifestPrices = manifest.PricesByChain[chainSel]
+	}
+	update := FeeQuoterPriceUpdatePerSource{
+		TokenPrices: make(map[common.Address]*big.Int),
+		GasPrices:   make(map[uint64]*big.Int),
+	}
+	for _, stale := range stalePrices {
+		price := stale.Value
+		if stale.IsGasPrice() {
+			if manifestPrice, ok := manifestPrices.GasPrices[stale.DestChainSelector]; ok {
+				price = manifestPrice
+			}
+		} else {
+			for token, manifestPrice := range manifestPrices.TokenPrices {
+				if common.IsHexAddress(token) && common.HexToAddress(token) == stale.Token {
+					price = manifestPrice
+				}
+			}
+		}
+		if price == nil || price.Sign() <= 0 {
+			if stale.IsGasPrice() {
+				return FeeQuoterPriceUpdatePerSource{}, fmt.Errorf("no gas price of chain %d to refresh the stale price on chain %d", stale.DestChainSelector, chainSel)
+			}
+			return FeeQuoterPriceUpdatePerSource{}, fmt.Errorf("no price of token %s to refresh the stale price on chain %d", stale.Token, chainSel)
+		}
+		if stale.IsGasPrice() {
+			update.GasPrices[stale.DestChainSelector] = price
+		} else {
+			update.TokenPrices[stale.Token] = price
+		}
+	}
+	return update, nil
+}
@@ -0,0 +1,63 @@
+package v1_6_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_6"
+	ccipops "github.com/smartcontractkit/chainlink/deployment/ccip/operation/evm/v1_6"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+)
+
+func TestRefreshStalePricesChangeset(t *testing.T) {
+	t.Parallel()
+	tenv, _ := testhelpers.NewMemoryEnvironment(t, testhelpers.WithNumOfChains(2))
+	state, err := stateview.LoadOnchainState(tenv.Env)
+	require.NoError(t, err)
+
+	stalePrices := func(out cldf.ChangesetOutput) map[uint64][]ccipops.StalePrice {
+		stale := make(map[uint64][]ccipops.StalePrice)
+		for _, report := range out.Reports {
+			output, ok := report.Output.(ccipops.FeeQuoterPriceStalenessOutput)
+			if !ok {
+				continue
+			}
+			for _, price := range output.StalePrices {
+				stale[price.ChainSelector] = append(stale[price.ChainSelector], price)
+			}
+		}
+		return stale
+	}
+
+	// the prices of the fee tokens have never been set
+	out, err := v1_6.RefreshStalePricesChangeset(tenv.Env, v1_6.RefreshStalePricesConfig{})
+	require.NoError(t, err)
+	stale := stalePrices(out)
+	manifest := &v1_6.PriceManifest{Timestamp: time.Now(), PricesByChain: make(map[uint64]v1_6.ManifestChainPrices)}
+	for chainSel := range tenv.Env.BlockChains.EVMChains() {
+		require.Len(t, stale[chainSel], 2, "LINK and WETH prices should be stale on chain %d", chainSel)
+		for _, price := range stale[chainSel] {
+			require.False(t, price.IsGasPrice())
+			require.True(t, price.UpdatedAt.IsZero())
+		}
+		manifest.PricesByChain[chainSel] = v1_6.ManifestChainPrices{
+			TokenPrices: map[string]*big.Int{
+				state.Chains[chainSel].LinkToken.Address().Hex(): testhelpers.DefaultLinkPrice,
+				state.Chains[chainSel].Weth9.Address().Hex():     testhelpers.DefaultWethPrice,
+			},
+		}
+	}
+
+	// the stale prices are refreshed with the prices of the manifest
+	_, err = v1_6.RefreshStalePricesChangeset(tenv.Env, v1_6.RefreshStalePricesConfig{Refresh: true, Prices: manifest})
+	require.NoError(t, err)
+	out, err = v1_6.RefreshStalePricesChangeset(tenv.Env, v1_6.RefreshStalePricesConfig{StalenessThreshold: time.Hour})
+	require.NoError(t, err)
+	require.Empty(t, stalePrices(out))
+}
@@ -0,0 +1,182 @@
+package v1_6
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/siloed_lock_release_token_pool"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/deployergroup"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+var _ cldf.ChangeSet[ConfigureSiloedLockReleaseTokenPoolConfig] = ConfigureSiloedLockReleaseTokenPoolChangeset
+
+type SiloedLockReleasePoolUpdate struct {
+	// Version is the version of the token pool.
+	Version semver.Version `json:"version"`
+
+	// RemovedSilos are the remote chains whose liquidity goes back to the shared (unsiloed) liquidity of the pool.
+	RemovedSilos []uint64 `json:"removedSilos"`
+
+	// AddedSilos are the remote chains that get their own liquidity, provided and withdrawn by the given rebalancer.
+	// The remote chains must already be supported by the pool.
+	AddedSilos []siloed_lock_release_token_pool.SiloedLockReleaseTokenPoolSiloConfigUpdate `json:"addedSilos"`
+
+	// SiloRebalancers sets the rebalancers of existing silos, by remote chain selector.
+	SiloRebalancers map[uint64]common.Address `json:"siloRebalancers"`
+
+	// Rebalancer sets the rebalancer of the unsiloed liquidity if defined.
+	Rebalancer *common.Address `json:"rebalancer"`
+}
+
+type ConfigureSiloedLockReleaseTokenPoolConfig struct {
+	// MCMS defines the delay to use for Timelock (if absent, the changeset will attempt to use the deployer key).
+	MCMS *proposalutils.TimelockConfig
+
+	// Symbol is the symbol of the token of interest.
+	TokenSymbol shared.TokenSymbol
+
+	// PoolUpdates are the updates to the siloed lock release token pools, by chain selector. Can only be called by the owner.
+	PoolUpdates map[uint64]SiloedLockReleasePoolUpdate
+}
+
+func (c ConfigureSiloedLockReleaseTokenPoolConfig) Validate(env cldf.Environment) error {
+	if c.TokenSymbol == "" {
+		return errors.New("token symbol must be defined")
+	}
+	state, err := stateview.LoadOnchainState(env)
+	if err != nil {
+		return fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	for chainSelector, poolUpdate := range c.PoolUpdates {
+		err := cldf.IsValidChainSelector(chainSelector)
+		if err != nil {
+			return fmt.Errorf("failed to validate chain selector %d: %w", chainSelector, err)
+		}
+		chain, ok := env.BlockChains.EVMChains()[chainSelector]
+		if !ok {
+			return fmt.Errorf("chain with selector %d does not exist in environment", chainSelector)
+		}
+		chainState, ok := state.Chains[chainSelector]
+		if !ok {
+			return fmt.Errorf("%s does not exist in state", chain.String())
+		}
+		if c.MCMS != nil {
+			if timelock := chainState.Timelock; timelock == nil {
+				return fmt.Errorf("missing timelock on %s", chain.String())
+			}
+			if proposerMcm := chainState.ProposerMcm; proposerMcm == nil {
+				return fmt.Errorf("missing proposerMcm on %s", chain.String())
+			}
+		}
+		pool, ok := chainState.SiloedLockReleaseTokenPools[c.TokenSymbol][poolUpdate.Version]
+		if !ok {
+			return fmt.Errorf("siloed lock release token pool with version %s does not exist on %s with symbol %s", poolUpdate.Version, chain, c.TokenSymbol)
+		}
+		if err := poolUpdate.Validate(env, pool); err != nil {
+			return fmt.Errorf("invalid pool update on %s: %w", chain.String(), err)
+		}
+	}
+
+	return nil
+}
+
+func (c SiloedLockReleasePoolUpdate) Validate(env cldf.Environment, pool *siloed_lock_release_token_pool.SiloedLockReleaseTokenPool) error {
+	if _, ok := shared.TokenPoolVersions[c.Version]; !ok {
+		return fmt.Errorf("%s is not a known token pool version", c.Version)
+	}
+
+	opts := &bind.CallOpts{Context: env.GetContext()}
+	removed := make(map[uint64]struct{}, len(c.RemovedSilos))
+	for _, remoteChainSelector := range c.RemovedSilos {
+		removed[remoteChainSelector] = struct{}{}
+	}
+	for _, silo := range c.AddedSilos {
+		if silo.Rebalancer == (common.Address{}) {
+			return fmt.Errorf("rebalancer must be defined for silo of remote chain %d", silo.RemoteChainSelector)
+		}
+		supported, err := pool.IsSupportedChain(opts, silo.RemoteChainSelector)
+		if err != nil {
+			return fmt.Errorf("failed to check if remote chain %d is supported by pool %s: %w", silo.RemoteChainSelector, pool.Address(), err)
+		}
+		if !supported {
+			return fmt.Errorf("remote chain %d is not supported by pool %s", silo.RemoteChainSelector, pool.Address())
+		}
+		if _, ok := removed[silo.RemoteChainSelector]; ok {
+			continue
+		}
+		siloed, err := pool.IsSiloed(opts, silo.RemoteChainSelector)
+		if err != nil {
+			return fmt.Errorf("failed to check if remote chain %d is siloed on pool %s: %w", silo.RemoteChainSelector, pool.Address(), err)
+		}
+		if siloed {
+			return fmt.Errorf("remote chain %d is already siloed on pool %s", silo.RemoteChainSelector, pool.Address())
+		}
+	}
+	for remoteChainSelector := range c.SiloRebalancers {
+		siloed, err := pool.IsSiloed(opts, remoteChainSelector)
+		if err != nil {
+			return fmt.Errorf("failed to check if remote chain %d is siloed on pool %s: %w", remoteChainSelector, pool.Address(), err)
+		}
+		if !siloed {
+			return fmt.Errorf("remote chain %d is not siloed on pool %s, add a silo instead", remoteChainSelector, pool.Address())
+		}
+	}
+
+	return nil
+}
+
+// ConfigureSiloedLockReleaseTokenPoolChangeset updates the silos and rebalancers of siloed lock release token pools for
+// a given token across multiple chains. The pools are deployed with the DeployTokenPoolContractsChangeset, and
+// registered in the token admin registry and connected to their remote pools like other token pools.
+func ConfigureSiloedLockReleaseTokenPoolChangeset(env cldf.Environment, c ConfigureSiloedLockReleaseTokenPoolConfig) (cldf.ChangesetOutput, error) {
+	if err := c.Validate(env); err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("invalid ConfigureSiloedLockReleaseTokenPoolConfig: %w", err)
+	}
+	state, err := stateview.LoadOnchainState(env)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	deployerGroup := deployergroup.NewDeployerGroup(env, state, c.MCMS).WithDeploymentContext(fmt.Sprintf("configure %s siloed lock release token pools", c.TokenSymbol))
+
+	for chainSelector, poolUpdate := range c.PoolUpdates {
+		chain := env.BlockChains.EVMChains()[chainSelector]
+		chainState, _ := state.EVMChainState(chainSelector)
+
+		opts, err := deployerGroup.GetDeployer(chainSelector)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to get deployer for %s", chain)
+		}
+
+		pool := chainState.SiloedLockReleaseTokenPools[c.TokenSymbol][poolUpdate.Version]
+		if len(poolUpdate.RemovedSilos) > 0 || len(poolUpdate.AddedSilos) > 0 {
+			if _, err := pool.UpdateSiloDesignations(opts, poolUpdate.RemovedSilos, poolUpdate.AddedSilos); err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to update silo designations on token pool with address %s on %s: %w", pool.Address().String(), chain, err)
+			}
+		}
+		for _, remoteChainSelector := range slices.Sorted(maps.Keys(poolUpdate.SiloRebalancers)) {
+			if _, err := pool.SetSiloRebalancer(opts, remoteChainSelector, poolUpdate.SiloRebalancers[remoteChainSelector]); err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to set rebalancer of silo %d on token pool with address %s on %s: %w", remoteChainSelector, pool.Address().String(), chain, err)
+			}
+		}
+		if poolUpdate.Rebalancer != nil {
+			if _, err := pool.SetRebalancer(opts, *poolUpdate.Rebalancer); err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to set rebalancer on token pool with address %s on %s: %w", pool.Address().String(), chain, err)
+			}
+		}
+	}
+
+	return deployerGroup.Enact()
+}
@@ -0,0 +1,170 @@
+package v1_6_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/siloed_lock_release_token_pool"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_5_1"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_6"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestConfigureSiloedLockReleaseTokenPoolChangeset(t *testing.T) {
+	tests := []struct {
+		Msg         string
+		MCMSEnabled bool
+	}{
+		{Msg: "Deployer key", MCMSEnabled: false},
+		{Msg: "MCMS", MCMSEnabled: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Msg, func(t *testing.T) {
+			mcmsEnabled := test.MCMSEnabled
+			e, selectorA, selectorB, tokens := testhelpers.SetupTwoChainEnvironmentWithTokens(t, logger.TestLogger(t), mcmsEnabled)
+
+			newPools := map[uint64]v1_5_1.DeployTokenPoolInput{
+				selectorA: {
+					Type:               shared.SiloedLockReleaseTokenPool,
+					TokenAddress:       tokens[selectorA].Address,
+					LocalTokenDecimals: testhelpers.LocalTokenDecimals,
+				},
+				selectorB: {
+					Type:               shared.SiloedLockReleaseTokenPool,
+					TokenAddress:       tokens[selectorB].Address,
+					LocalTokenDecimals: testhelpers.LocalTokenDecimals,
+				},
+			}
+			e = testhelpers.DeployTestTokenPools(t, e, newPools, mcmsEnabled)
+
+			// The pools are deployed at 1.6.0, so deploying them again is caught as a duplicate
+			_, err := commonchangeset.Apply(t, e, commonchangeset.Configure(
+				cldf.CreateLegacyChangeSet(v1_5_1.DeployTokenPoolContractsChangeset),
+				v1_5_1.DeployTokenPoolContractsConfig{
+					TokenSymbol: testhelpers.TestTokenSymbol,
+					NewPools:    newPools,
+				},
+			))
+			require.ErrorContains(t, err, "token pool with type SiloedLockReleaseTokenPool and version 1.6.0 already exists")
+
+			var mcmsConfig *proposalutils.TimelockConfig
+			if mcmsEnabled {
+				mcmsConfig = &proposalutils.TimelockConfig{
+					MinDelay: 0,
+				}
+			}
+
+			_, err = commonchangeset.Apply(t, e, commonchangeset.Configure(
+				cldf.CreateLegacyChangeSet(v1_5_1.ConfigureTokenPoolContractsChangeset),
+				v1_5_1.ConfigureTokenPoolContractsConfig{
+					TokenSymbol: testhelpers.TestTokenSymbol,
+					MCMS:        mcmsConfig,
+					PoolUpdates: map[uint64]v1_5_1.TokenPoolConfig{
+						selectorA: {
+							Type:         shared.SiloedLockReleaseTokenPool,
+							Version:      deployment.Version1_6_0,
+							ChainUpdates: v1_5_1.RateLimiterPerChain{selectorB: testhelpers.CreateSymmetricRateLimits(0, 0)},
+						},
+						selectorB: {
+							Type:         shared.SiloedLockReleaseTokenPool,
+							Version:      deployment.Version1_6_0,
+							ChainUpdates: v1_5_1.RateLimiterPerChain{selectorA: testhelpers.CreateSymmetricRateLimits(0, 0)},
+						},
+					},
+				},
+			))
+			require.NoError(t, err)
+
+			rebalancer := common.HexToAddress("0x1111111111111111111111111111111111111111")
+			siloRebalancer := common.HexToAddress("0x2222222222222222222222222222222222222222")
+			newSiloRebalancer := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+			// Silo the liquidity of chain B on the pool of chain A
+			_, err = commonchangeset.Apply(t, e, commonchangeset.Configure(
+				cldf.CreateLegacyChangeSet(v1_6.ConfigureSiloedLockReleaseTokenPoolChangeset),
+				v1_6.ConfigureSiloedLockReleaseTokenPoolConfig{
+					TokenSymbol: testhelpers.TestTokenSymbol,
+					MCMS:        mcmsConfig,
+					PoolUpdates: map[uint64]v1_6.SiloedLockReleasePoolUpdate{
+						selectorA: {
+							Version: deployment.Version1_6_0,
+							AddedSilos: []siloed_lock_release_token_pool.SiloedLockReleaseTokenPoolSiloConfigUpdate{
+								{RemoteChainSelector: selectorB, Rebalancer: siloRebalancer},
+							},
+							Rebalancer: &rebalancer,
+						},
+					},
+				},
+			))
+			require.NoError(t, err)
+
+			state, err := stateview.LoadOnchainState(e)
+			require.NoError(t, err)
+			pool := state.MustGetEVMChainState(selectorA).SiloedLockReleaseTokenPools[testhelpers.TestTokenSymbol][deployment.Version1_6_0]
+			siloed, err := pool.IsSiloed(nil, selectorB)
+			require.NoError(t, err)
+			require.True(t, siloed)
+			chainRebalancer, err := pool.GetChainRebalancer(nil, selectorB)
+			require.NoError(t, err)
+			require.Equal(t, siloRebalancer, chainRebalancer)
+			poolRebalancer, err := pool.GetRebalancer(nil)
+			require.NoError(t, err)
+			require.Equal(t, rebalancer, poolRebalancer)
+
+			// Adding the same silo again is rejected
+			_, err = commonchangeset.Apply(t, e, commonchangeset.Configure(
+				cldf.CreateLegacyChangeSet(v1_6.ConfigureSiloedLockReleaseTokenPoolChangeset),
+				v1_6.ConfigureSiloedLockReleaseTokenPoolConfig{
+					TokenSymbol: testhelpers.TestTokenSymbol,
+					MCMS:        mcmsConfig,
+					PoolUpdates: map[uint64]v1_6.SiloedLockReleasePoolUpdate{
+						selectorA: {
+							Version: deployment.Version1_6_0,
+							AddedSilos: []siloed_lock_release_token_pool.SiloedLockReleaseTokenPoolSiloConfigUpdate{
+								{RemoteChainSelector: selectorB, Rebalancer: siloRebalancer},
+							},
+						},
+					},
+				},
+			))
+			require.ErrorContains(t, err, "is already siloed")
+
+			// Hand the silo over to another rebalancer
+			_, err = commonchangeset.Apply(t, e, commonchangeset.Configure(
+				cldf.CreateLegacyChangeSet(v1_6.ConfigureSiloedLockReleaseTokenPoolChangeset),
+				v1_6.ConfigureSiloedLockReleaseTokenPoolConfig{
+					TokenSymbol: testhelpers.TestTokenSymbol,
+					MCMS:        mcmsConfig,
+					PoolUpdates: map[uint64]v1_6.SiloedLockReleasePoolUpdate{
+						selectorA: {
+							Version:         deployment.Version1_6_0,
+							SiloRebalancers: map[uint64]common.Address{selectorB: newSiloRebalancer},
+						},
+					},
+				},
+			))
+			require.NoError(t, err)
+
+			chainRebalancer, err = pool.GetChainRebalancer(nil, selectorB)
+			require.NoError(t, err)
+			require.Equal(t, newSiloRebalancer, chainRebalancer)
+
+			// The liquidity of chain A stays shared on the pool of chain B
+			pool = state.MustGetEVMChainState(selectorB).SiloedLockReleaseTokenPools[testhelpers.TestTokenSymbol][deployment.Version1_6_0]
+			siloed, err = pool.IsSiloed(nil, selectorA)
+			require.NoError(t, err)
+			require.False(t, siloed)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	ccipseqs "github.com/smartcontractkit/chainlink/deployment/ccip/sequence/evm/v1_6"
 
 	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	commoncs "github.com/smartcontractkit/chainlink/deployment/common/changeset"
 	opsutil "github.com/smartcontractkit/chainlink/deployment/common/opsutils"
 	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
 )
@@ -54,6 +55,13 @@ type UpdateBidirectionalLanesChangesetConfigs struct {
 }
 
 func (c UpdateBidirectionalLanesConfig) BuildConfigs() UpdateBidirectionalLanesChangesetConfigs {
+	return c.buildConfigs(func(lane BidirectionalLaneDefinition, _ laneDefinition) bool {
+		return !lane.IsDisabled
+	})
+}
+
+// buildConfigs builds the configs of the lanes, each direction of a lane being enabled or disabled by isEnabled.
+func (c UpdateBidirectionalLanesConfig) buildConfigs(isEnabled func(lane BidirectionalLaneDefinition, laneDef laneDefinition) bool) UpdateBidirectionalLanesChangesetConfigs {
 	onRampUpdatesByChain := make(map[uint64]map[uint64]OnRampDestinationUpdate)
 	offRampUpdatesByChain := make(map[uint64]map[uint64]OffRampSourceUpdate)
 	routerUpdatesByChain := make(map[uint64]RouterUpdates)
@@ -61,7 +69,6 @@ func (c UpdateBidirectionalLanesConfig) BuildConfigs() UpdateBidirectionalLanesC
 	feeQuoterPriceUpdatesByChain := make(map[uint64]FeeQuoterPriceUpdatePerSource)
 
 	for _, lane := range c.Lanes {
-		isEnabled := !lane.IsDisabled
 		chainA := lane.Chains[0]
 		chainB := lane.Chains[1]
 
@@ -75,6 +82,8 @@ func (c UpdateBidirectionalLanesConfig) BuildConfigs() UpdateBidirectionalLanesC
 		}
 
 		for _, laneDef := range []laneDefinition{laneAToB, laneBToA} {
+			isEnabled := isEnabled(lane, laneDef)
+
 			// Setting the destination on the on ramp
 			if onRampUpdatesByChain[laneDef.Source.Selector] == nil {
 				onRampUpdatesByChain[laneDef.Source.Selector] = make(map[uint64]OnRampDestinationUpdate)
@@ -157,6 +166,106 @@ func (c UpdateBidirectionalLanesConfig) BuildConfigs() UpdateBidirectionalLanesC
 	}
 }
 
+// LaneStates records whether the lanes were enabled, by source and destination chain selector.
+type LaneStates map[uint64]map[uint64]bool
+
+// UpdateBidirectionalLanesSnapshot reads whether each direction of the lanes of the config is enabled, i.e. whether
+// the on ramp of the source chain is set on its router for the destination chain, and the source chain is enabled on
+// the off ramp of the destination chain. Use it with UpdateBidirectionalLanesRollback.
+func UpdateBidirectionalLanesSnapshot(e cldf.Environment, c UpdateBidirectionalLanesConfig) (LaneStates, error) {
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	states := make(LaneStates)
+	for _, lane := range c.Lanes {
+		for _, laneDef := range []laneDefinition{
+			{Source: lane.Chains[0], Dest: lane.Chains[1]},
+			{Source: lane.Chains[1], Dest: lane.Chains[0]},
+		} {
+			enabled, err := isLaneEnabled(state, laneDef, c.TestRouter)
+			if err != nil {
+				return nil, err
+			}
+			if states[laneDef.Source.Selector] == nil {
+				states[laneDef.Source.Selector] = make(map[uint64]bool)
+			}
+			states[laneDef.Source.Selector][laneDef.Dest.Selector] = enabled
+		}
+	}
+	return states, nil
+}
+
+func isLaneEnabled(state stateview.CCIPOnChainState, laneDef laneDefinition, testRouter bool) (bool, error) {
+	source, dest := laneDef.Source.Selector, laneDef.Dest.Selector
+	sourceState, destState := state.Chains[source], state.Chains[dest]
+	router := sourceState.Router
+	if testRouter {
+		router = sourceState.TestRouter
+	}
+	if router == nil || sourceState.OnRamp == nil || destState.OffRamp == nil {
+		return false, fmt.Errorf("router, on ramp or off ramp missing for lane %d -> %d", source, dest)
+	}
+	onRamp, err := router.GetOnRamp(nil, dest)
+	if err != nil {
+		return false, fmt.Errorf("failed to get on ramp of lane %d -> %d from router: %w", source, dest, err)
+	}
+	if onRamp != sourceState.OnRamp.Address() {
+		return false, nil
+	}
+	sourceConfig, err := destState.OffRamp.GetSourceChainConfig(nil, source)
+	if err != nil {
+		return false, fmt.Errorf("failed to get source chain config of lane %d -> %d from off ramp: %w", source, dest, err)
+	}
+	return sourceConfig.IsEnabled, nil
+}
+
+// UpdateBidirectionalLanesRollback rolls back UpdateBidirectionalLanesChangeset: each direction of the lanes is enabled
+// or disabled again as read by UpdateBidirectionalLanesSnapshot before the changeset was applied, with the same MCMS
+// configuration. The fee quoter configs and gas prices set by the changeset are not restored.
+// Use it with commoncs.ConfigureWithSnapshotRollback.
+func UpdateBidirectionalLanesRollback(e cldf.Environment, c UpdateBidirectionalLanesConfig, before LaneStates, _ cldf.ChangesetOutput) (commoncs.ConfiguredChangeSet, error) {
+	for _, lane := range c.Lanes {
+		a, b := lane.Chains[0].Selector, lane.Chains[1].Selector
+		if _, ok := before[a][b]; !ok {
+			return nil, fmt.Errorf("no state recorded for lane %d -> %d", a, b)
+		}
+		if _, ok := before[b][a]; !ok {
+			return nil, fmt.Errorf("no state recorded for lane %d -> %d", b, a)
+		}
+	}
+	return commoncs.Configure(RestoreLanesChangeset, RestoreLanesConfig{
+		Lanes:   c,
+		Enabled: before,
+	}), nil
+}
+
+// RestoreLanesChangeset enables or disables each direction of the lanes as recorded, e.g. to restore the lanes updated
+// by UpdateBidirectionalLanesChangeset.
+var RestoreLanesChangeset = cldf.CreateChangeSet(restoreLanesLogic, restoreLanesPrecondition)
+
+// RestoreLanesConfig is a configuration struct for RestoreLanesChangeset.
+type RestoreLanesConfig struct {
+	// Lanes are the lanes to restore. Their IsDisabled field is ignored.
+	Lanes UpdateBidirectionalLanesConfig
+	// Enabled records whether each direction of the lanes is enabled.
+	Enabled LaneStates
+}
+
+func (c RestoreLanesConfig) buildConfigs() UpdateBidirectionalLanesChangesetConfigs {
+	return c.Lanes.buildConfigs(func(_ BidirectionalLaneDefinition, laneDef laneDefinition) bool {
+		return c.Enabled[laneDef.Source.Selector][laneDef.Dest.Selector]
+	})
+}
+
+func restoreLanesPrecondition(e cldf.Environment, c RestoreLanesConfig) error {
+	return UpdateLanesPrecondition(e, c.buildConfigs())
+}
+
+func restoreLanesLogic(e cldf.Environment, c RestoreLanesConfig) (cldf.ChangesetOutput, error) {
+	return UpdateLanesLogic(e, c.Lanes.MCMSConfig, c.buildConfigs())
+}
+
 func updateBidirectionalLanesPrecondition(e cldf.Environment, c UpdateBidirectionalLanesConfig) error {
 	configs := c.BuildConfigs()
 
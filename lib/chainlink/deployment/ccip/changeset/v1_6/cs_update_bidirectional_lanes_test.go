@@ -274,6 +274,7 @@ func TestUpdateBidirectionalLanesChangeset(t *testing.T) {
 		TestRouter bool
 		MCMS       *proposalutils.TimelockConfig
 		Disable    bool
+		Rollback   bool
 	}
 
 	mcmsConfig := &proposalutils.TimelockConfig{
@@ -293,6 +294,12 @@ func TestUpdateBidirectionalLanesChangeset(t *testing.T) {
 			TestRouter: false,
 			MCMS:       mcmsConfig,
 		},
+		{
+			Msg:        "Use test router (without MCMS) & roll back afterwards",
+			TestRouter: true,
+			MCMS:       nil,
+			Rollback:   true,
+		},
 		{
 			Msg:        "Use test router (without MCMS)",
 			TestRouter: true,
@@ -359,16 +366,32 @@ func TestUpdateBidirectionalLanesChangeset(t *testing.T) {
 				}
 			}
 
-			e, err = commonchangeset.Apply(t, e,
-				commonchangeset.Configure(
-					v1_6.UpdateBidirectionalLanesChangeset,
-					v1_6.UpdateBidirectionalLanesConfig{
-						TestRouter: test.TestRouter,
-						MCMSConfig: test.MCMS,
-						Lanes:      getAllPossibleLanes(chains, false),
-					},
-				),
+			if test.Rollback {
+				// the lane enabled before the changeset is applied must stay enabled when it is rolled back
+				e, err = commonchangeset.Apply(t, e,
+					commonchangeset.Configure(
+						v1_6.UpdateBidirectionalLanesChangeset,
+						v1_6.UpdateBidirectionalLanesConfig{
+							TestRouter: test.TestRouter,
+							MCMSConfig: test.MCMS,
+							Lanes:      getAllPossibleLanes(chains[:2], false),
+						},
+					),
+				)
+				require.NoError(t, err, "must enable the first lane")
+			}
+
+			addLanes := commonchangeset.ConfigureWithSnapshotRollback(
+				v1_6.UpdateBidirectionalLanesChangeset,
+				v1_6.UpdateBidirectionalLanesConfig{
+					TestRouter: test.TestRouter,
+					MCMSConfig: test.MCMS,
+					Lanes:      getAllPossibleLanes(chains, false),
+				},
+				v1_6.UpdateBidirectionalLanesSnapshot,
+				v1_6.UpdateBidirectionalLanesRollback,
 			)
+			e, outputs, err := commonchangeset.ApplyChangesets(t, e, []commonchangeset.ConfiguredChangeSet{addLanes})
 			require.NoError(t, err, "must apply AddBidirectionalLanesChangeset")
 
 			for i, chain := range chains {
@@ -378,6 +401,21 @@ func TestUpdateBidirectionalLanesChangeset(t *testing.T) {
 				}
 			}
 
+			if test.Rollback {
+				e, _, err = commonchangeset.RollbackChangesets(t, e, []commonchangeset.AppliedChangeset{
+					{Name: "add-lanes", Changeset: addLanes, Output: outputs[0]},
+				})
+				require.NoError(t, err, "must roll back AddBidirectionalLanesChangeset")
+
+				for i, chain := range chains {
+					remoteChains := getRemoteChains(chains, i)
+					for _, remoteChain := range remoteChains {
+						enabledBefore := i < 2 && remoteChain.Selector == chains[1-i].Selector
+						checkBidirectionalLaneConnectivity(t, e, state, chain, remoteChain, test.TestRouter, !enabledBefore)
+					}
+				}
+			}
+
 			if test.Disable {
 				e, err = commonchangeset.Apply(t, e,
 					commonchangeset.Configure(
@@ -0,0 +1,334 @@
+package v1_6
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	ccipseq "github.com/smartcontractkit/chainlink/deployment/ccip/sequence/evm/v1_6"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+// prerequisiteContracts are the contracts deployed by DeployPrerequisitesChangeset.
+var prerequisiteContracts = []cldf.ContractType{
+	shared.ARMProxy,
+	shared.Router,
+	shared.TokenAdminRegistry,
+	shared.RegistryModule,
+	shared.WETH9,
+}
+
+// chainContracts are the contracts deployed by DeployChainContractsChangeset.
+var chainContracts = []cldf.ContractType{
+	shared.RMNRemote,
+	shared.FeeQuoter,
+	shared.NonceManager,
+	shared.OnRamp,
+	shared.OffRamp,
+}
+
+// EnvironmentManifest is the desired state of the CCIP contracts and lanes of the EVM chains of an environment.
+// ReconcileManifest compares it with the state of the environment, and returns the changesets converging to it.
+type EnvironmentManifest struct {
+	// HomeChainSelector is the selector of the home chain, used to deploy the chain contracts.
+	HomeChainSelector uint64 `json:"homeChainSelector"`
+	// Chains are the contracts which should be deployed, by chain selector.
+	Chains map[uint64]ChainManifest `json:"chains"`
+	// Lanes are the lanes which should be enabled, or disabled.
+	Lanes []BidirectionalLaneDefinition `json:"lanes"`
+	// TestRouter indicates if the lanes are enabled on the test router.
+	TestRouter bool `json:"testRouter"`
+	// MCMS is the MCMS configuration of the changesets updating the lanes.
+	MCMS *proposalutils.TimelockConfig `json:"mcms,omitempty"`
+}
+
+// ChainManifest is the desired state of the contracts of a chain.
+type ChainManifest struct {
+	// Contracts are the versions of the contracts which should be deployed, by contract type.
+	Contracts map[cldf.ContractType]semver.Version `json:"contracts"`
+	// ContractParams are the params of the chain contracts, required to deploy them when they are missing.
+	ContractParams *ccipseq.ChainContractParams `json:"contractParams,omitempty"`
+}
+
+// ManifestDrift is a difference between the manifest and the state of the environment.
+type ManifestDrift struct {
+	ChainSelector uint64 `json:"chainSelector"`
+	// Contract is the contract which is missing or has another version, empty for the drifts of lanes.
+	Contract cldf.ContractType `json:"contract,omitempty"`
+	// RemoteChainSelector is the remote chain of the drifts of lanes.
+	RemoteChainSelector uint64 `json:"remoteChainSelector,omitempty"`
+	Reason              string `json:"reason"`
+	// ChangeSet is the name of the changeset of the reconciliation converging the drift, empty if the drift must be
+	// resolved manually, e.g. for contract upgrades.
+	ChangeSet string `json:"changeSet,omitempty"`
+}
+
+func (d ManifestDrift) String() string {
+	subject := fmt.Sprintf("chain %d", d.ChainSelector)
+	switch {
+	case d.Contract != "":
+		subject += " " + string(d.Contract)
+	case d.RemoteChainSelector != 0:
+		subject = fmt.Sprintf("lane %d -> %d", d.ChainSelector, d.RemoteChainSelector)
+	}
+	if d.ChangeSet == "" {
+		return fmt.Sprintf("%s: %s (unresolved)", subject, d.Reason)
+	}
+	return fmt.Sprintf("%s: %s (resolved by %s)", subject, d.Reason, d.ChangeSet)
+}
+
+// ManifestReconciliation is the result of ReconcileManifest.
+type ManifestReconciliation struct {
+	// Drifts are all the differences between the manifest and the environment.
+	Drifts []ManifestDrift
+	// ChangeSets are the changesets converging the environment to the manifest. They must be applied in order, each
+	// with the addresses deployed by the previous ones.
+	ChangeSets []changeset.WithConfig
+}
+
+// InSync reports whether the environment matches the manifest.
+func (r ManifestReconciliation) InSync() bool {
+	return len(r.Drifts) == 0
+}
+
+// Unresolved returns the drifts which none of the changesets converge.
+func (r ManifestReconciliation) Unresolved() []ManifestDrift {
+	var unresolved []ManifestDrift
+	for _, drift := range r.Drifts {
+		if drift.ChangeSet == "" {
+			unresolved = append(unresolved, drift)
+		}
+	}
+	return unresolved
+}
+
+func (m EnvironmentManifest) Validate(e cldf.Environment) error {
+	for selector := range m.Chains {
+		if _, ok := e.BlockChains.EVMChains()[selector]; !ok {
+			return fmt.Errorf("manifest chain %d is not an EVM chain of the environment", selector)
+		}
+	}
+	for i, lane := range m.Lanes {
+		for _, chain := range lane.Chains {
+			if _, ok := e.BlockChains.EVMChains()[chain.Selector]; !ok {
+				return fmt.Errorf("lane %d: chain %d is not an EVM chain of the environment", i, chain.Selector)
+			}
+		}
+		if lane.Chains[0].Selector == lane.Chains[1].Selector {
+			return fmt.Errorf("lane %d: chains must be different", i)
+		}
+	}
+	return nil
+}
+
+// ReconcileManifest compares the manifest with the address book and the onchain state of the environment, and returns
+// the drifts with the changesets converging the environment to the manifest:
+//   - DeployPrerequisitesChangeset and DeployChainContractsChangeset for the chains with missing contracts;
+//   - UpdateBidirectionalLanesChangeset for the lanes whose ramps, routers or fee quoters differ from the manifest.
+//
+// Contracts deployed with another version are reported as unresolved drifts, since their upgrade is not automated.
+// Contracts which are neither prerequisites nor chain contracts are only checked.
+func ReconcileManifest(e cldf.Environment, m EnvironmentManifest) (ManifestReconciliation, error) {
+	var result ManifestReconciliation
+	if err := m.Validate(e); err != nil {
+		return result, fmt.Errorf("invalid manifest: %w", err)
+	}
+	state, err := stateview.LoadOnchainState(e)
+	if err != nil {
+		return result, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	prerequisites := changeset.DeployPrerequisiteConfig{}
+	chainContractsCfg := ccipseq.DeployChainContractsConfig{
+		HomeChainSelector:      m.HomeChainSelector,
+		ContractParamsPerChain: make(map[uint64]ccipseq.ChainContractParams),
+	}
+	deploying := make(map[uint64]bool)
+	for _, selector := range slices.Sorted(maps.Keys(m.Chains)) {
+		drifts, err := reconcileContracts(e, selector, m.Chains[selector])
+		if err != nil {
+			return result, err
+		}
+		for i, drift := range drifts {
+			switch {
+			case drift.Reason != contractMissing:
+			case slices.Contains(prerequisiteContracts, drift.Contract):
+				drifts[i].ChangeSet = "DeployPrerequisitesChangeset"
+			case slices.Contains(chainContracts, drift.Contract) && m.Chains[selector].ContractParams != nil:
+				drifts[i].ChangeSet = "DeployChainContractsChangeset"
+			}
+			switch drifts[i].ChangeSet {
+			case "DeployPrerequisitesChangeset":
+				if !slices.ContainsFunc(prerequisites.Configs, func(c changeset.DeployPrerequisiteConfigPerChain) bool { return c.ChainSelector == selector }) {
+					prerequisites.Configs = append(prerequisites.Configs, changeset.DeployPrerequisiteConfigPerChain{ChainSelector: selector})
+				}
+				deploying[selector] = true
+			case "DeployChainContractsChangeset":
+				chainContractsCfg.ContractParamsPerChain[selector] = *m.Chains[selector].ContractParams
+				deploying[selector] = true
+			}
+		}
+		result.Drifts = append(result.Drifts, drifts...)
+	}
+	if len(prerequisites.Configs) > 0 {
+		result.ChangeSets = append(result.ChangeSets, changeset.CreateGenericChangeSetWithConfig(
+			cldf.CreateLegacyChangeSet(changeset.DeployPrerequisitesChangeset), prerequisites))
+	}
+	if len(chainContractsCfg.ContractParamsPerChain) > 0 {
+		result.ChangeSets = append(result.ChangeSets, changeset.CreateGenericChangeSetWithConfig(
+			cldf.CreateLegacyChangeSet(DeployChainContractsChangeset), chainContractsCfg))
+	}
+
+	lanesCfg := UpdateBidirectionalLanesConfig{MCMSConfig: m.MCMS, TestRouter: m.TestRouter}
+	for _, lane := range m.Lanes {
+		var laneDrifts []ManifestDrift
+		for _, direction := range [][2]ChainDefinition{{lane.Chains[0], lane.Chains[1]}, {lane.Chains[1], lane.Chains[0]}} {
+			source, dest := direction[0], direction[1]
+			drift := ManifestDrift{
+				ChainSelector:       source.Selector,
+				RemoteChainSelector: dest.Selector,
+				Reason:              "contracts of the lane are being deployed",
+				ChangeSet:           "UpdateBidirectionalLanesChangeset",
+			}
+			if !deploying[source.Selector] && !deploying[dest.Selector] {
+				drift.Reason, err = laneDrift(e, state, source, dest, !lane.IsDisabled, m.TestRouter)
+				switch {
+				case errors.Is(err, errLaneContractsMissing):
+					// the contracts are not in the manifest, the lane cannot be updated
+					drift.Reason, drift.ChangeSet = err.Error(), ""
+					result.Drifts = append(result.Drifts, drift)
+					continue
+				case err != nil:
+					return result, fmt.Errorf("failed to check lane %d -> %d: %w", source.Selector, dest.Selector, err)
+				}
+			}
+			if drift.Reason != "" {
+				laneDrifts = append(laneDrifts, drift)
+			}
+		}
+		if len(laneDrifts) > 0 {
+			result.Drifts = append(result.Drifts, laneDrifts...)
+			lanesCfg.Lanes = append(lanesCfg.Lanes, lane)
+		}
+	}
+	if len(lanesCfg.Lanes) > 0 {
+		result.ChangeSets = append(result.ChangeSets, changeset.CreateGenericChangeSetWithConfig(UpdateBidirectionalLanesChangeset, lanesCfg))
+	}
+	return result, nil
+}
+
+const contractMissing = "contract is not deployed"
+
+var errLaneContractsMissing = errors.New("contracts of the lane are not deployed")
+
+// reconcileContracts compares the contracts of the manifest of a chain with its address book.
+func reconcileContracts(e cldf.Environment, selector uint64, chain ChainManifest) ([]ManifestDrift, error) {
+	addresses, err := e.ExistingAddresses.AddressesForChain(selector)
+	if err != nil && !errors.Is(err, cldf.ErrChainNotFound) {
+		return nil, fmt.Errorf("failed to get addresses of chain %d: %w", selector, err)
+	}
+	deployed := make(map[cldf.ContractType][]semver.Version)
+	for _, tv := range addresses {
+		deployed[tv.Type] = append(deployed[tv.Type], tv.Version)
+	}
+
+	var drifts []ManifestDrift
+	for _, contract := range slices.Sorted(maps.Keys(chain.Contracts)) {
+		version := chain.Contracts[contract]
+		versions := deployed[contract]
+		switch {
+		case len(versions) == 0:
+			drifts = append(drifts, ManifestDrift{ChainSelector: selector, Contract: contract, Reason: contractMissing})
+		case !slices.ContainsFunc(versions, func(v semver.Version) bool { return v.Equal(&version) }):
+			drifts = append(drifts, ManifestDrift{
+				ChainSelector: selector,
+				Contract:      contract,
+				Reason:        fmt.Sprintf("contract is deployed with version %s, expected %s", versions[0].String(), version.String()),
+			})
+		}
+	}
+	return drifts, nil
+}
+
+// laneDrift returns how the lane from source to dest differs from the manifest, or an empty string if it does not.
+func laneDrift(e cldf.Environment, state stateview.CCIPOnChainState, source, dest ChainDefinition, enabled, testRouter bool) (string, error) {
+	sourceChain, destChain := state.Chains[source.Selector], state.Chains[dest.Selector]
+	sourceRouter, destRouter := sourceChain.Router, destChain.Router
+	if testRouter {
+		sourceRouter, destRouter = sourceChain.TestRouter, destChain.TestRouter
+	}
+	if sourceChain.OnRamp == nil || sourceChain.FeeQuoter == nil || sourceRouter == nil || destChain.OffRamp == nil || destRouter == nil {
+		return "", errLaneContractsMissing
+	}
+	opts := &bind.CallOpts{Context: e.GetContext()}
+
+	expectedRouter := common.Address{}
+	if enabled {
+		expectedRouter = sourceRouter.Address()
+	}
+	onRampCfg, err := sourceChain.OnRamp.GetDestChainConfig(opts, dest.Selector)
+	if err != nil {
+		return "", err
+	}
+	if onRampCfg.Router != expectedRouter {
+		return fmt.Sprintf("onramp dest config has router %s, expected %s", onRampCfg.Router, expectedRouter), nil
+	}
+	if enabled && onRampCfg.AllowlistEnabled != dest.AllowListEnabled {
+		return fmt.Sprintf("onramp dest config has allowlist enabled %t, expected %t", onRampCfg.AllowlistEnabled, dest.AllowListEnabled), nil
+	}
+
+	routerOnRamp, err := sourceRouter.GetOnRamp(opts, dest.Selector)
+	if err != nil {
+		return "", err
+	}
+	if enabled != (routerOnRamp == sourceChain.OnRamp.Address()) {
+		return fmt.Sprintf("source router has onramp %s", routerOnRamp), nil
+	}
+
+	offRampCfg, err := destChain.OffRamp.GetSourceChainConfig(opts, source.Selector)
+	if err != nil {
+		return "", err
+	}
+	if offRampCfg.IsEnabled != enabled {
+		return fmt.Sprintf("offramp source config is enabled %t, expected %t", offRampCfg.IsEnabled, enabled), nil
+	}
+	if enabled {
+		switch {
+		case offRampCfg.Router != destRouter.Address():
+			return fmt.Sprintf("offramp source config has router %s, expected %s", offRampCfg.Router, destRouter.Address()), nil
+		case common.BytesToAddress(offRampCfg.OnRamp) != sourceChain.OnRamp.Address():
+			return fmt.Sprintf("offramp source config has onramp %x, expected %s", offRampCfg.OnRamp, sourceChain.OnRamp.Address()), nil
+		case offRampCfg.IsRMNVerificationDisabled != source.RMNVerificationDisabled:
+			return fmt.Sprintf("offramp source config has RMN verification disabled %t, expected %t", offRampCfg.IsRMNVerificationDisabled, source.RMNVerificationDisabled), nil
+		}
+	}
+
+	isOffRamp, err := destRouter.IsOffRamp(opts, source.Selector, destChain.OffRamp.Address())
+	if err != nil {
+		return "", err
+	}
+	if isOffRamp != enabled {
+		return fmt.Sprintf("dest router has offramp %t, expected %t", isOffRamp, enabled), nil
+	}
+
+	if enabled {
+		feeQuoterCfg, err := sourceChain.FeeQuoter.GetDestChainConfig(opts, dest.Selector)
+		if err != nil {
+			return "", err
+		}
+		if feeQuoterCfg.IsEnabled != dest.FeeQuoterDestChainConfig.IsEnabled {
+			return fmt.Sprintf("fee quoter dest config is enabled %t, expected %t", feeQuoterCfg.IsEnabled, dest.FeeQuoterDestChainConfig.IsEnabled), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,77 @@
+package v1_6_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	cldf_chain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/v1_6"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+)
+
+func TestReconcileManifest(t *testing.T) {
+	t.Parallel()
+	deployedEnvironment, _ := testhelpers.NewMemoryEnvironment(t, func(testCfg *testhelpers.TestConfigs) {
+		testCfg.Chains = 2
+	})
+	e := deployedEnvironment.Env
+	selectors := e.BlockChains.ListChainSelectors(cldf_chain.WithFamily(chain_selectors.FamilyEVM))
+
+	chains := make([]v1_6.ChainDefinition, len(selectors))
+	manifest := v1_6.EnvironmentManifest{
+		HomeChainSelector: deployedEnvironment.HomeChainSel,
+		Chains:            make(map[uint64]v1_6.ChainManifest),
+	}
+	for i, selector := range selectors {
+		chains[i] = v1_6.ChainDefinition{
+			ConnectionConfig:         v1_6.ConnectionConfig{RMNVerificationDisabled: true},
+			Selector:                 selector,
+			GasPrice:                 big.NewInt(1e17),
+			FeeQuoterDestChainConfig: v1_6.DefaultFeeQuoterDestChainConfig(true),
+		}
+		manifest.Chains[selector] = v1_6.ChainManifest{
+			Contracts: map[cldf.ContractType]semver.Version{
+				shared.Router: deployment.Version1_2_0,
+				shared.OnRamp: deployment.Version1_6_0,
+			},
+		}
+	}
+	manifest.Lanes = getAllPossibleLanes(chains, false)
+	// the first chain expects an onramp upgrade, which cannot be reconciled
+	manifest.Chains[selectors[0]].Contracts[shared.OnRamp] = deployment.Version1_5_0
+
+	reconciliation, err := v1_6.ReconcileManifest(e, manifest)
+	require.NoError(t, err)
+	require.False(t, reconciliation.InSync())
+	require.Len(t, reconciliation.Unresolved(), 1)
+	require.Equal(t, shared.OnRamp, reconciliation.Unresolved()[0].Contract)
+	// the lanes are not connected yet
+	require.Len(t, reconciliation.Drifts, 3)
+	require.Len(t, reconciliation.ChangeSets, 1)
+
+	for _, cs := range reconciliation.ChangeSets {
+		e, err = commonchangeset.Apply(t, e, commonchangeset.Configure(cs.ChangeSet, cs.Config))
+		require.NoError(t, err)
+	}
+
+	// the environment converged, except for the unresolved drift
+	reconciliation, err = v1_6.ReconcileManifest(e, manifest)
+	require.NoError(t, err)
+	require.Len(t, reconciliation.Drifts, 1)
+	require.Len(t, reconciliation.Unresolved(), 1)
+	require.Empty(t, reconciliation.ChangeSets)
+
+	manifest.Chains[selectors[0]].Contracts[shared.OnRamp] = deployment.Version1_6_0
+	reconciliation, err = v1_6.ReconcileManifest(e, manifest)
+	require.NoError(t, err)
+	require.True(t, reconciliation.InSync(), reconciliation.Drifts)
+}
@@ -0,0 +1,167 @@
+package v1_5_1
+
+import (
+	"math/big"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/token_admin_registry"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/lock_release_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/siloed_lock_release_token_pool"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/erc20"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	opsutil "github.com/smartcontractkit/chainlink/deployment/common/opsutils"
+)
+
+// ApplyChainUpdatesInput defines the input for adding and removing remote chains on token pools
+type ApplyChainUpdatesInput struct {
+	RemoteChainSelectorsToRemove []uint64
+	ChainsToAdd                  []token_pool.TokenPoolChainUpdate
+}
+
+// SetPoolInput defines the input for setting the pool of a token on the token admin registry
+type SetPoolInput struct {
+	Token common.Address
+	Pool  common.Address
+}
+
+// TransferInput defines the input for transferring or approving tokens
+type TransferInput struct {
+	To     common.Address
+	Amount *big.Int
+}
+
+var (
+	// TokenPoolApplyChainUpdatesOp adds and removes remote chains on token pool contracts (v1.5.1 and later).
+	// The contract type of the output is that of the base token pool, callers should set the actual type of the pool.
+	TokenPoolApplyChainUpdatesOp = opsutil.NewEVMCallOperation(
+		"TokenPoolApplyChainUpdatesOp",
+		semver.MustParse("1.0.0"),
+		"Add and remove remote chains on token pool contract",
+		token_pool.TokenPoolABI,
+		shared.BurnMintTokenPool,
+		func(address common.Address, backend bind.ContractBackend) (*token_pool.TokenPool, error) {
+			return token_pool.NewTokenPool(address, backend)
+		},
+		func(pool *token_pool.TokenPool, opts *bind.TransactOpts, input ApplyChainUpdatesInput) (*types.Transaction, error) {
+			return pool.ApplyChainUpdates(opts, input.RemoteChainSelectorsToRemove, input.ChainsToAdd)
+		},
+	)
+
+	LockReleaseTokenPoolSetRebalancerOp = opsutil.NewEVMCallOperation(
+		"LockReleaseTokenPoolSetRebalancerOp",
+		semver.MustParse("1.0.0"),
+		"Set rebalancer on LockRelease token pool contract",
+		lock_release_token_pool.LockReleaseTokenPoolABI,
+		shared.LockReleaseTokenPool,
+		func(address common.Address, backend bind.ContractBackend) (*lock_release_token_pool.LockReleaseTokenPool, error) {
+			return lock_release_token_pool.NewLockReleaseTokenPool(address, backend)
+		},
+		func(pool *lock_release_token_pool.LockReleaseTokenPool, opts *bind.TransactOpts, rebalancer common.Address) (*types.Transaction, error) {
+			return pool.SetRebalancer(opts, rebalancer)
+		},
+	)
+
+	LockReleaseTokenPoolWithdrawLiquidityOp = opsutil.NewEVMCallOperation(
+		"LockReleaseTokenPoolWithdrawLiquidityOp",
+		semver.MustParse("1.0.0"),
+		"Withdraw liquidity from LockRelease token pool contract",
+		lock_release_token_pool.LockReleaseTokenPoolABI,
+		shared.LockReleaseTokenPool,
+		func(address common.Address, backend bind.ContractBackend) (*lock_release_token_pool.LockReleaseTokenPool, error) {
+			return lock_release_token_pool.NewLockReleaseTokenPool(address, backend)
+		},
+		func(pool *lock_release_token_pool.LockReleaseTokenPool, opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+			return pool.WithdrawLiquidity(opts, amount)
+		},
+	)
+
+	LockReleaseTokenPoolProvideLiquidityOp = opsutil.NewEVMCallOperation(
+		"LockReleaseTokenPoolProvideLiquidityOp",
+		semver.MustParse("1.0.0"),
+		"Provide liquidity to LockRelease token pool contract",
+		lock_release_token_pool.LockReleaseTokenPoolABI,
+		shared.LockReleaseTokenPool,
+		func(address common.Address, backend bind.ContractBackend) (*lock_release_token_pool.LockReleaseTokenPool, error) {
+			return lock_release_token_pool.NewLockReleaseTokenPool(address, backend)
+		},
+		func(pool *lock_release_token_pool.LockReleaseTokenPool, opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+			return pool.ProvideLiquidity(opts, amount)
+		},
+	)
+
+	SiloedLockReleaseTokenPoolSetRebalancerOp = opsutil.NewEVMCallOperation(
+		"SiloedLockReleaseTokenPoolSetRebalancerOp",
+		semver.MustParse("1.0.0"),
+		"Set rebalancer of the unsiloed liquidity on SiloedLockRelease token pool contract",
+		siloed_lock_release_token_pool.SiloedLockReleaseTokenPoolABI,
+		shared.SiloedLockReleaseTokenPool,
+		func(address common.Address, backend bind.ContractBackend) (*siloed_lock_release_token_pool.SiloedLockReleaseTokenPool, error) {
+			return siloed_lock_release_token_pool.NewSiloedLockReleaseTokenPool(address, backend)
+		},
+		func(pool *siloed_lock_release_token_pool.SiloedLockReleaseTokenPool, opts *bind.TransactOpts, rebalancer common.Address) (*types.Transaction, error) {
+			return pool.SetRebalancer(opts, rebalancer)
+		},
+	)
+
+	SiloedLockReleaseTokenPoolProvideLiquidityOp = opsutil.NewEVMCallOperation(
+		"SiloedLockReleaseTokenPoolProvideLiquidityOp",
+		semver.MustParse("1.0.0"),
+		"Provide unsiloed liquidity to SiloedLockRelease token pool contract",
+		siloed_lock_release_token_pool.SiloedLockReleaseTokenPoolABI,
+		shared.SiloedLockReleaseTokenPool,
+		func(address common.Address, backend bind.ContractBackend) (*siloed_lock_release_token_pool.SiloedLockReleaseTokenPool, error) {
+			return siloed_lock_release_token_pool.NewSiloedLockReleaseTokenPool(address, backend)
+		},
+		func(pool *siloed_lock_release_token_pool.SiloedLockReleaseTokenPool, opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+			return pool.ProvideLiquidity(opts, amount)
+		},
+	)
+
+	ERC20ApproveOp = opsutil.NewEVMCallOperation(
+		"ERC20ApproveOp",
+		semver.MustParse("1.0.0"),
+		"Approve a spender of ERC20 tokens",
+		erc20.ERC20ABI,
+		shared.ERC20Token,
+		func(address common.Address, backend bind.ContractBackend) (*erc20.ERC20, error) {
+			return erc20.NewERC20(address, backend)
+		},
+		func(token *erc20.ERC20, opts *bind.TransactOpts, input TransferInput) (*types.Transaction, error) {
+			return token.Approve(opts, input.To, input.Amount)
+		},
+	)
+
+	ERC20TransferOp = opsutil.NewEVMCallOperation(
+		"ERC20TransferOp",
+		semver.MustParse("1.0.0"),
+		"Transfer ERC20 tokens",
+		erc20.ERC20ABI,
+		shared.ERC20Token,
+		func(address common.Address, backend bind.ContractBackend) (*erc20.ERC20, error) {
+			return erc20.NewERC20(address, backend)
+		},
+		func(token *erc20.ERC20, opts *bind.TransactOpts, input TransferInput) (*types.Transaction, error) {
+			return token.Transfer(opts, input.To, input.Amount)
+		},
+	)
+
+	TokenAdminRegistrySetPoolOp = opsutil.NewEVMCallOperation(
+		"TokenAdminRegistrySetPoolOp",
+		semver.MustParse("1.0.0"),
+		"Set the pool of a token on TokenAdminRegistry contract",
+		token_admin_registry.TokenAdminRegistryABI,
+		shared.TokenAdminRegistry,
+		func(address common.Address, backend bind.ContractBackend) (*token_admin_registry.TokenAdminRegistry, error) {
+			return token_admin_registry.NewTokenAdminRegistry(address, backend)
+		},
+		func(registry *token_admin_registry.TokenAdminRegistry, opts *bind.TransactOpts, input SetPoolInput) (*types.Transaction, error) {
+			return registry.SetPool(opts, input.Token, input.Pool)
+		},
+	)
+)
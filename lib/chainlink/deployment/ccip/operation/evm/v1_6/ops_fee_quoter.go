@@ -3,7 +3,9 @@ package v1_6
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/ethereum/go-ethereum/common"
@@ -14,6 +16,8 @@ import (
 	chain_selectors "github.com/smartcontractkit/chain-selectors"
 
 	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_3/fee_quoter"
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
 
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
@@ -233,3 +237,114 @@ func DefaultFeeQuoterDestChainConfig(configEnabled bool, destChainSelector ...ui
 		GasPriceStalenessThreshold:        90000,
 	}
 }
+
+// FeeQuoterPriceStalenessInput defines the FeeQuoter scanned by FeeQuoterScanPriceStalenessOp
+type FeeQuoterPriceStalenessInput struct {
+	Address       common.Address
+	ChainSelector uint64
+	// DestChainSelectors are the lanes of the chain, the gas prices of disabled lanes are not checked.
+	DestChainSelectors []uint64
+	// Threshold is the maximum age of a price, the staleness thresholds of the FeeQuoter are used if zero.
+	Threshold time.Duration
+	// Timestamp is the time of the scan, which the age of the prices is computed from.
+	Timestamp time.Time
+}
+
+// StalePrice is a token price or a gas price of a FeeQuoter older than the staleness threshold
+type StalePrice struct {
+	ChainSelector uint64 `json:"chainSelector"`
+	// Token is set for token prices
+	Token common.Address `json:"token,omitempty"`
+	// DestChainSelector is set for gas prices
+	DestChainSelector uint64   `json:"destChainSelector,omitempty"`
+	Value             *big.Int `json:"value"`
+	// UpdatedAt is zero if the price has never been set
+	UpdatedAt time.Time     `json:"updatedAt"`
+	Age       time.Duration `json:"age"`
+	Threshold time.Duration `json:"threshold"`
+}
+
+// IsGasPrice reports whether the stale price is a gas price, as opposed to a token price
+func (p StalePrice) IsGasPrice() bool {
+	return p.DestChainSelector != 0
+}
+
+type FeeQuoterPriceStalenessOutput struct {
+	// CheckedPrices is the number of prices checked
+	CheckedPrices int          `json:"checkedPrices"`
+	StalePrices   []StalePrice `json:"stalePrices"`
+}
+
+// FeeQuoterScanPriceStalenessOp checks the age of the prices of the fee tokens and of the gas prices of the enabled
+// lanes of a FeeQuoter, and returns the prices older than the threshold.
+var FeeQuoterScanPriceStalenessOp = operations.NewOperation(
+	"FeeQuoterScanPriceStalenessOp",
+	semver.MustParse("1.0.0"),
+	"Scans the token and gas prices of the FeeQuoter 1.6.0 contract for stale prices",
+	func(b operations.Bundle, chain cldf_evm.Chain, input FeeQuoterPriceStalenessInput) (FeeQuoterPriceStalenessOutput, error) {
+		feeQuoter, err := fee_quoter.NewFeeQuoter(input.Address, chain.Client)
+		if err != nil {
+			return FeeQuoterPriceStalenessOutput{}, fmt.Errorf("failed to bind FeeQuoter %s on %s: %w", input.Address, chain, err)
+		}
+		opts := &bind.CallOpts{Context: b.GetContext()}
+		output := FeeQuoterPriceStalenessOutput{}
+		check := func(stale StalePrice, price fee_quoter.InternalTimestampedPackedUint224, threshold time.Duration) {
+			output.CheckedPrices++
+			stale.ChainSelector = input.ChainSelector
+			stale.Value = price.Value
+			stale.Threshold = threshold
+			if price.Timestamp == 0 {
+				output.StalePrices = append(output.StalePrices, stale)
+				return
+			}
+			stale.UpdatedAt = time.Unix(int64(price.Timestamp), 0).UTC()
+			stale.Age = input.Timestamp.Sub(stale.UpdatedAt)
+			if stale.Age > threshold {
+				output.StalePrices = append(output.StalePrices, stale)
+			}
+		}
+
+		tokenThreshold := input.Threshold
+		if tokenThreshold == 0 {
+			staticConfig, err := feeQuoter.GetStaticConfig(opts)
+			if err != nil {
+				return FeeQuoterPriceStalenessOutput{}, fmt.Errorf("failed to get static config of FeeQuoter on %s: %w", chain, err)
+			}
+			tokenThreshold = time.Duration(staticConfig.TokenPriceStalenessThreshold) * time.Second
+		}
+		feeTokens, err := feeQuoter.GetFeeTokens(opts)
+		if err != nil {
+			return FeeQuoterPriceStalenessOutput{}, fmt.Errorf("failed to get fee tokens of FeeQuoter on %s: %w", chain, err)
+		}
+		for _, token := range feeTokens {
+			price, err := feeQuoter.GetTokenPrice(opts, token)
+			if err != nil {
+				return FeeQuoterPriceStalenessOutput{}, fmt.Errorf("failed to get price of token %s on %s: %w", token, chain, err)
+			}
+			check(StalePrice{Token: token}, price, tokenThreshold)
+		}
+
+		for _, dest := range input.DestChainSelectors {
+			destChainConfig, err := feeQuoter.GetDestChainConfig(opts, dest)
+			if err != nil {
+				return FeeQuoterPriceStalenessOutput{}, fmt.Errorf("failed to get config of dest chain %d on %s: %w", dest, chain, err)
+			}
+			if !destChainConfig.IsEnabled {
+				continue
+			}
+			gasThreshold := input.Threshold
+			if gasThreshold == 0 {
+				// a zero threshold disables the staleness check of the FeeQuoter
+				if destChainConfig.GasPriceStalenessThreshold == 0 {
+					continue
+				}
+				gasThreshold = time.Duration(destChainConfig.GasPriceStalenessThreshold) * time.Second
+			}
+			price, err := feeQuoter.GetDestinationChainGasPrice(opts, dest)
+			if err != nil {
+				return FeeQuoterPriceStalenessOutput{}, fmt.Errorf("failed to get gas price of dest chain %d on %s: %w", dest, chain, err)
+			}
+			check(StalePrice{DestChainSelector: dest}, price, gasThreshold)
+		}
+		return output, nil
+	})
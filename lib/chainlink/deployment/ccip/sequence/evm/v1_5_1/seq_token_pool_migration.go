@@ -0,0 +1,212 @@
+package v1_5_1
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/token_pool"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/erc20"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	ccipops "github.com/smartcontractkit/chainlink/deployment/ccip/operation/evm/v1_5_1"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	opsutil "github.com/smartcontractkit/chainlink/deployment/common/opsutils"
+)
+
+// TokenPoolMigration defines the migration of a token from one pool to another on a chain
+type TokenPoolMigration struct {
+	// Token is the address of the token being migrated
+	Token common.Address
+	// TokenAdminRegistry is the address of the token admin registry of the chain
+	TokenAdminRegistry common.Address
+	// OldPool and OldPoolType identify the pool the token is migrated from
+	OldPool     common.Address
+	OldPoolType cldf.ContractType
+	// NewPool and NewPoolType identify the pool the token is migrated to, which must be owned by the deployer key
+	NewPool     common.Address
+	NewPoolType cldf.ContractType
+	// ChainUpdates are the remote chain configs of the old pool, added to the new pool
+	ChainUpdates []token_pool.TokenPoolChainUpdate
+	// RemovedChains are the remote chains of the old pool, removed from it to stop transfers through it
+	RemovedChains []uint64
+	// Liquidity is the amount of tokens withdrawn from the old pool, which must be a LockRelease pool if it is positive.
+	// It is the balance of the old pool when NoSend is set, as the calls are executed later. Otherwise the balance is
+	// read again once the old pool is paused, so tokens locked in the meantime are not left behind.
+	Liquidity *big.Int
+	// LiquidityRecipient receives the liquidity if defined, otherwise it is provided to the new pool
+	LiquidityRecipient common.Address
+	// Sender is the address calling the old pool and the token admin registry, i.e. the timelock when NoSend is set
+	Sender common.Address
+	// NoSend prepares the calls to the old pool and the token admin registry without sending them
+	NoSend bool
+}
+
+// TokenPoolMigrationSequenceInput defines inputs for migrating tokens to new pools across multiple chains
+type TokenPoolMigrationSequenceInput struct {
+	// MigrationsByChain maps chain selector to the migration on that chain
+	MigrationsByChain map[uint64]TokenPoolMigration
+}
+
+var (
+	// TokenPoolMigrationSequence migrates tokens from one pool to another across multiple EVM chains. On each chain it
+	//  1. adds the remote chains of the old pool to the new pool
+	//  2. removes the remote chains of the old pool, which pauses it
+	//  3. moves the liquidity of the old pool to the new pool or to a recipient
+	//  4. sets the new pool as the pool of the token on the token admin registry
+	//
+	// Only the calls of step 1, and the rebalancer of the new pool, are sent with the deployer key if NoSend is set.
+	TokenPoolMigrationSequence = operations.NewSequence(
+		"TokenPoolMigrationSequence",
+		semver.MustParse("1.0.0"),
+		"Migrate tokens from one pool to another across multiple EVM chains",
+		func(b operations.Bundle, chains map[uint64]cldf_evm.Chain, input TokenPoolMigrationSequenceInput) (map[uint64][]opsutil.EVMCallOutput, error) {
+			opOutputs := make(map[uint64][]opsutil.EVMCallOutput, len(input.MigrationsByChain))
+
+			for chainSel, migration := range input.MigrationsByChain {
+				chain, ok := chains[chainSel]
+				if !ok {
+					return nil, fmt.Errorf("chain with selector %d not defined", chainSel)
+				}
+				outputs, err := migrateTokenPool(b, chain, migration)
+				if err != nil {
+					return nil, fmt.Errorf("failed to migrate token %s to pool %s on %s: %w", migration.Token, migration.NewPool, chain, err)
+				}
+				opOutputs[chainSel] = outputs
+			}
+			return opOutputs, nil
+		})
+)
+
+func migrateTokenPool(b operations.Bundle, chain cldf_evm.Chain, m TokenPoolMigration) ([]opsutil.EVMCallOutput, error) {
+	var outputs []opsutil.EVMCallOutput
+
+	if len(m.ChainUpdates) > 0 {
+		out, err := executeCall(b, ccipops.TokenPoolApplyChainUpdatesOp, chain, m.NewPool, ccipops.ApplyChainUpdatesInput{ChainsToAdd: m.ChainUpdates}, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add remote chains to pool %s: %w", m.NewPool, err)
+		}
+		// the call goes through the base token pool binding, so the output is given the actual type of the pool
+		out.ContractType = m.NewPoolType
+		outputs = append(outputs, out)
+	}
+	if len(m.RemovedChains) > 0 {
+		out, err := executeCall(b, ccipops.TokenPoolApplyChainUpdatesOp, chain, m.OldPool, ccipops.ApplyChainUpdatesInput{RemoteChainSelectorsToRemove: m.RemovedChains}, m.NoSend)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove remote chains from pool %s: %w", m.OldPool, err)
+		}
+		out.ContractType = m.OldPoolType
+		outputs = append(outputs, out)
+	}
+
+	if !m.NoSend && m.OldPoolType == shared.LockReleaseTokenPool {
+		// the old pool has no remote chains anymore, so its balance does not change until it is withdrawn
+		token, err := erc20.NewERC20(m.Token, chain.Client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect address %s with erc20 bindings: %w", m.Token, err)
+		}
+		if m.Liquidity, err = token.BalanceOf(&bind.CallOpts{Context: b.GetContext()}, m.OldPool); err != nil {
+			return nil, fmt.Errorf("failed to get balance of pool %s: %w", m.OldPool, err)
+		}
+	}
+	if m.Liquidity != nil && m.Liquidity.Sign() > 0 {
+		liquidityOutputs, err := moveLiquidity(b, chain, m)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, liquidityOutputs...)
+	}
+
+	out, err := executeCall(b, ccipops.TokenAdminRegistrySetPoolOp, chain, m.TokenAdminRegistry, ccipops.SetPoolInput{Token: m.Token, Pool: m.NewPool}, m.NoSend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set pool of token %s: %w", m.Token, err)
+	}
+	return append(outputs, out), nil
+}
+
+// moveLiquidity withdraws the liquidity of the old pool to the sender, then transfers it to the recipient or provides
+// it to the new pool.
+func moveLiquidity(b operations.Bundle, chain cldf_evm.Chain, m TokenPoolMigration) ([]opsutil.EVMCallOutput, error) {
+	if m.OldPoolType != shared.LockReleaseTokenPool {
+		return nil, fmt.Errorf("cannot withdraw liquidity from pool of type %s", m.OldPoolType)
+	}
+	var (
+		setRebalancerOp    *operations.Operation[opsutil.EVMCallInput[common.Address], opsutil.EVMCallOutput, cldf_evm.Chain]
+		provideLiquidityOp *operations.Operation[opsutil.EVMCallInput[*big.Int], opsutil.EVMCallOutput, cldf_evm.Chain]
+	)
+	if m.LiquidityRecipient == (common.Address{}) {
+		switch m.NewPoolType {
+		case shared.LockReleaseTokenPool:
+			setRebalancerOp = ccipops.LockReleaseTokenPoolSetRebalancerOp
+			provideLiquidityOp = ccipops.LockReleaseTokenPoolProvideLiquidityOp
+		case shared.SiloedLockReleaseTokenPool:
+			setRebalancerOp = ccipops.SiloedLockReleaseTokenPoolSetRebalancerOp
+			provideLiquidityOp = ccipops.SiloedLockReleaseTokenPoolProvideLiquidityOp
+		default:
+			return nil, fmt.Errorf("cannot provide liquidity to pool of type %s", m.NewPoolType)
+		}
+	}
+
+	var outputs []opsutil.EVMCallOutput
+	out, err := executeCall(b, ccipops.LockReleaseTokenPoolSetRebalancerOp, chain, m.OldPool, m.Sender, m.NoSend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set rebalancer of pool %s: %w", m.OldPool, err)
+	}
+	outputs = append(outputs, out)
+	out, err = executeCall(b, ccipops.LockReleaseTokenPoolWithdrawLiquidityOp, chain, m.OldPool, m.Liquidity, m.NoSend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to withdraw liquidity from pool %s: %w", m.OldPool, err)
+	}
+	outputs = append(outputs, out)
+
+	if m.LiquidityRecipient != (common.Address{}) {
+		out, err = executeCall(b, ccipops.ERC20TransferOp, chain, m.Token, ccipops.TransferInput{To: m.LiquidityRecipient, Amount: m.Liquidity}, m.NoSend)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transfer liquidity to %s: %w", m.LiquidityRecipient, err)
+		}
+		return append(outputs, out), nil
+	}
+
+	// the new pool is still owned by the deployer key, which makes the sender its rebalancer
+	out, err = executeCall(b, setRebalancerOp, chain, m.NewPool, m.Sender, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set rebalancer of pool %s: %w", m.NewPool, err)
+	}
+	outputs = append(outputs, out)
+	out, err = executeCall(b, ccipops.ERC20ApproveOp, chain, m.Token, ccipops.TransferInput{To: m.NewPool, Amount: m.Liquidity}, m.NoSend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve pool %s: %w", m.NewPool, err)
+	}
+	outputs = append(outputs, out)
+	out, err = executeCall(b, provideLiquidityOp, chain, m.NewPool, m.Liquidity, m.NoSend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to provide liquidity to pool %s: %w", m.NewPool, err)
+	}
+	return append(outputs, out), nil
+}
+
+func executeCall[IN any](
+	b operations.Bundle,
+	op *operations.Operation[opsutil.EVMCallInput[IN], opsutil.EVMCallOutput, cldf_evm.Chain],
+	chain cldf_evm.Chain,
+	address common.Address,
+	input IN,
+	noSend bool,
+) (opsutil.EVMCallOutput, error) {
+	report, err := operations.ExecuteOperation(b, op, chain, opsutil.EVMCallInput[IN]{
+		Address:       address,
+		ChainSelector: chain.Selector,
+		CallInput:     input,
+		NoSend:        noSend,
+	})
+	if err != nil {
+		return opsutil.EVMCallOutput{}, err
+	}
+	return report.Output, nil
+}
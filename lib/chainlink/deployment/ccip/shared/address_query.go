@@ -0,0 +1,109 @@
+package shared
+
+import (
+	"fmt"
+
+	solTestTokenPool "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_1/test_token_pool"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// FindAddresses returns the addresses of a chain in the address book with contractType and all the labels.
+func FindAddresses(ab cldf.AddressBook, chainSelector uint64, contractType cldf.ContractType, labels ...string) (map[string]cldf.TypeAndVersion, error) {
+	addresses, err := ab.AddressesForChain(chainSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addresses for chain %d: %w", chainSelector, err)
+	}
+	found := make(map[string]cldf.TypeAndVersion)
+	for address, tv := range addresses {
+		if tv.Type == contractType && hasLabels(tv.Labels.Contains, labels) {
+			found[address] = tv
+		}
+	}
+	return found, nil
+}
+
+// FindAddressRefs returns the address refs of a chain in the datastore with contractType, qualifier if it is not
+// empty, and all the labels.
+func FindAddressRefs(store datastore.AddressRefStore, chainSelector uint64, contractType datastore.ContractType, qualifier string, labels ...string) []datastore.AddressRef {
+	filters := []datastore.FilterFunc[datastore.AddressRefKey, datastore.AddressRef]{
+		datastore.AddressRefByChainSelector(chainSelector),
+		datastore.AddressRefByType(contractType),
+		AddressRefByLabels(labels...),
+	}
+	if qualifier != "" {
+		filters = append(filters, datastore.AddressRefByQualifier(qualifier))
+	}
+	return store.Filter(filters...)
+}
+
+// AddressRefByLabels returns a filter that only includes the records with all the labels.
+func AddressRefByLabels(labels ...string) datastore.FilterFunc[datastore.AddressRefKey, datastore.AddressRef] {
+	return func(records []datastore.AddressRef) []datastore.AddressRef {
+		filtered := make([]datastore.AddressRef, 0, len(records))
+		for _, record := range records {
+			if hasLabels(record.Labels.Contains, labels) {
+				filtered = append(filtered, record)
+			}
+		}
+		return filtered
+	}
+}
+
+func hasLabels(contains func(string) bool, labels []string) bool {
+	for _, label := range labels {
+		if !contains(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// legacyPoolTypes are the pool type labels of the Solana test token pool, which were recorded by older changesets.
+var legacyPoolTypes = map[string]cldf.ContractType{
+	solTestTokenPool.BurnAndMint_PoolType.String():    BurnMintTokenPool,
+	solTestTokenPool.LockAndRelease_PoolType.String(): LockReleaseTokenPool,
+}
+
+// TokenPoolLabels are the labels recorded in the address book with the token pool lookup tables and the tokens
+// onboarded with self-serve.
+type TokenPoolLabels struct {
+	// Address is the token of lookup tables, and the token pool program of self-serve tokens.
+	Address  string
+	PoolType cldf.ContractType
+	// Metadata is the client identifier of the pool.
+	Metadata string
+}
+
+// LabelSet returns the labels, without the empty ones.
+func (l TokenPoolLabels) LabelSet() cldf.LabelSet {
+	labels := cldf.NewLabelSet()
+	for _, label := range []string{l.Address, l.PoolType.String(), l.Metadata} {
+		if label != "" {
+			labels.Add(label)
+		}
+	}
+	return labels
+}
+
+// ParseTokenPoolLabels parses the labels of a token pool entry of the address book. isAddress reports whether a
+// label is an address of the chain. The metadata is CLLMetadata if there is no metadata label.
+func ParseTokenPoolLabels(labels cldf.LabelSet, isAddress func(label string) bool) TokenPoolLabels {
+	var parsed TokenPoolLabels
+	for label := range labels {
+		switch {
+		case isAddress(label):
+			parsed.Address = label
+		case label == BurnMintTokenPool.String(), label == LockReleaseTokenPool.String(), label == CCTPTokenPool.String():
+			parsed.PoolType = cldf.ContractType(label)
+		case legacyPoolTypes[label] != "":
+			parsed.PoolType = legacyPoolTypes[label]
+		default:
+			parsed.Metadata = label
+		}
+	}
+	if parsed.Metadata == "" {
+		parsed.Metadata = CLLMetadata
+	}
+	return parsed
+}
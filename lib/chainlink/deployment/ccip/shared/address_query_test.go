@@ -0,0 +1,61 @@
+package shared
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+func TestFindAddresses(t *testing.T) {
+	chainSelector := chain_selectors.TEST_90000001.Selector
+	labeled := cldf.NewTypeAndVersion(TokenPoolLookupTable, deployment.Version1_0_0)
+	labeled.Labels = TokenPoolLabels{Address: "token", PoolType: LockReleaseTokenPool, Metadata: "client"}.LabelSet()
+	ab := cldf.NewMemoryAddressBookFromMap(map[uint64]map[string]cldf.TypeAndVersion{
+		chainSelector: {
+			"0x1": labeled,
+			"0x2": cldf.NewTypeAndVersion(TokenPoolLookupTable, deployment.Version1_0_0),
+			"0x3": cldf.NewTypeAndVersion(Router, deployment.Version1_0_0),
+		},
+	})
+
+	found, err := FindAddresses(ab, chainSelector, TokenPoolLookupTable)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	found, err = FindAddresses(ab, chainSelector, TokenPoolLookupTable, "token", "client")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Contains(t, found, "0x1")
+	found, err = FindAddresses(ab, chainSelector, TokenPoolLookupTable, "other")
+	require.NoError(t, err)
+	require.Empty(t, found)
+	_, err = FindAddresses(ab, chain_selectors.TEST_90000002.Selector, TokenPoolLookupTable)
+	require.ErrorIs(t, err, cldf.ErrChainNotFound)
+
+	ds, err := PopulateDataStore(ab)
+	require.NoError(t, err)
+	refs := FindAddressRefs(ds.Addresses(), chainSelector, datastore.ContractType(TokenPoolLookupTable), "", "token")
+	require.Len(t, refs, 1)
+	require.Equal(t, "0x1", refs[0].Address)
+	refs = FindAddressRefs(ds.Addresses(), chainSelector, datastore.ContractType(TokenPoolLookupTable), "0x2-"+TokenPoolLookupTable.String())
+	require.Len(t, refs, 1)
+	require.Equal(t, "0x2", refs[0].Address)
+}
+
+func TestParseTokenPoolLabels(t *testing.T) {
+	isAddress := func(label string) bool { return strings.HasPrefix(label, "0x") }
+	labels := TokenPoolLabels{Address: "0x1", PoolType: LockReleaseTokenPool, Metadata: "client"}
+	require.Equal(t, labels, ParseTokenPoolLabels(labels.LabelSet(), isAddress))
+
+	// labels recorded by older changesets
+	require.Equal(t,
+		TokenPoolLabels{Address: "0x1", PoolType: BurnMintTokenPool, Metadata: CLLMetadata},
+		ParseTokenPoolLabels(cldf.NewLabelSet("0x1", "BurnAndMint"), isAddress))
+}
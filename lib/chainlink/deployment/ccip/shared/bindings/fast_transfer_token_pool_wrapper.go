@@ -27,6 +27,16 @@ type (
 
 	// Quote represents a fee quote for fast transfer operations
 	Quote = burn_mint_external.IFastTransferPoolQuote
+
+	// FillInfo represents the fill state and filler of a fast transfer on the destination pool
+	FillInfo = burn_mint_external.FastTransferTokenPoolAbstractFillInfo
+)
+
+// Fill states of a fast transfer on the destination pool, as returned by GetFillInfo
+const (
+	FillStateNotFilled uint8 = iota
+	FillStateFilled
+	FillStateSettled
 )
 
 // FastTransferPool defines the common interface for all fast transfer pool types
@@ -42,6 +52,8 @@ type FastTransferPool interface {
 	FilterFastTransferRequested(opts *bind.FilterOpts, destinationChainSelector []uint64, fillID [][32]byte, settlementID [][32]byte) (*FastTransferRequestedIterator, error)
 	GetAccumulatedPoolFees(opts *bind.CallOpts) (*big.Int, error)
 	WithdrawPoolFees(opts *bind.TransactOpts, recipient common.Address) (*types.Transaction, error)
+	FastFill(opts *bind.TransactOpts, fillID [32]byte, settlementID [32]byte, sourceChainSelector uint64, sourceAmountNetFee *big.Int, sourceDecimals uint8, receiver common.Address) (*types.Transaction, error)
+	GetFillInfo(opts *bind.CallOpts, fillID [32]byte) (FillInfo, error)
 }
 
 // Conversion functions for type compatibility
@@ -119,6 +131,20 @@ func convertQuoteFromHybrid(quote hybrid_external.IFastTransferPoolQuote) Quote
 	}
 }
 
+func convertFillInfoFromBurnMint(info fast_transfer_token_pool.FastTransferTokenPoolAbstractFillInfo) FillInfo {
+	return FillInfo{
+		State:  info.State,
+		Filler: info.Filler,
+	}
+}
+
+func convertFillInfoFromHybrid(info hybrid_external.FastTransferTokenPoolAbstractFillInfo) FillInfo {
+	return FillInfo{
+		State:  info.State,
+		Filler: info.Filler,
+	}
+}
+
 // burnMintPoolAdapter adapts BurnMintFastTransferTokenPool to the FastTransferPool interface
 type burnMintPoolAdapter struct {
 	pool *fast_transfer_token_pool.BurnMintFastTransferTokenPool
@@ -171,6 +197,14 @@ func (a *burnMintPoolAdapter) FilterFastTransferRequested(opts *bind.FilterOpts,
 	return &FastTransferRequestedIterator{iter: newBurnMintIteratorWrapper(iter)}, nil
 }
 
+func (a *burnMintPoolAdapter) GetFillInfo(opts *bind.CallOpts, fillID [32]byte) (FillInfo, error) {
+	info, err := a.pool.GetFillInfo(opts, fillID)
+	if err != nil {
+		return FillInfo{}, err
+	}
+	return convertFillInfoFromBurnMint(info), nil
+}
+
 // Direct delegation methods for burnMintPoolAdapter
 func (a *burnMintPoolAdapter) GetAllowedFillers(opts *bind.CallOpts) ([]common.Address, error) {
 	return a.pool.GetAllowedFillers(opts)
@@ -193,6 +227,9 @@ func (a *burnMintPoolAdapter) GetAccumulatedPoolFees(opts *bind.CallOpts) (*big.
 func (a *burnMintPoolAdapter) WithdrawPoolFees(opts *bind.TransactOpts, recipient common.Address) (*types.Transaction, error) {
 	return a.pool.WithdrawPoolFees(opts, recipient)
 }
+func (a *burnMintPoolAdapter) FastFill(opts *bind.TransactOpts, fillID [32]byte, settlementID [32]byte, sourceChainSelector uint64, sourceAmountNetFee *big.Int, sourceDecimals uint8, receiver common.Address) (*types.Transaction, error) {
+	return a.pool.FastFill(opts, fillID, settlementID, sourceChainSelector, sourceAmountNetFee, sourceDecimals, receiver)
+}
 
 // burnMintExternalPoolAdapter - no conversion needed, types already match
 func (a *burnMintExternalPoolAdapter) GetDestChainConfig(opts *bind.CallOpts, remoteChainSelector uint64) (DestChainConfig, []common.Address, error) {
@@ -225,6 +262,12 @@ func (a *burnMintExternalPoolAdapter) GetAccumulatedPoolFees(opts *bind.CallOpts
 func (a *burnMintExternalPoolAdapter) WithdrawPoolFees(opts *bind.TransactOpts, recipient common.Address) (*types.Transaction, error) {
 	return a.pool.WithdrawPoolFees(opts, recipient)
 }
+func (a *burnMintExternalPoolAdapter) FastFill(opts *bind.TransactOpts, fillID [32]byte, settlementID [32]byte, sourceChainSelector uint64, sourceAmountNetFee *big.Int, sourceDecimals uint8, receiver common.Address) (*types.Transaction, error) {
+	return a.pool.FastFill(opts, fillID, settlementID, sourceChainSelector, sourceAmountNetFee, sourceDecimals, receiver)
+}
+func (a *burnMintExternalPoolAdapter) GetFillInfo(opts *bind.CallOpts, fillID [32]byte) (FillInfo, error) {
+	return a.pool.GetFillInfo(opts, fillID)
+}
 
 func (a *burnMintExternalPoolAdapter) FilterFastTransferRequested(opts *bind.FilterOpts, destinationChainSelector []uint64, fillID [][32]byte, settlementID [][32]byte) (*FastTransferRequestedIterator, error) {
 	iter, err := a.pool.FilterFastTransferRequested(opts, destinationChainSelector, fillID, settlementID)
@@ -263,6 +306,14 @@ func (a *hybridExternalPoolAdapter) FilterFastTransferRequested(opts *bind.Filte
 	return &FastTransferRequestedIterator{iter: newHybridExternalIteratorWrapper(iter)}, nil
 }
 
+func (a *hybridExternalPoolAdapter) GetFillInfo(opts *bind.CallOpts, fillID [32]byte) (FillInfo, error) {
+	info, err := a.pool.GetFillInfo(opts, fillID)
+	if err != nil {
+		return FillInfo{}, err
+	}
+	return convertFillInfoFromHybrid(info), nil
+}
+
 // Direct delegation methods for hybridExternalPoolAdapter
 func (a *hybridExternalPoolAdapter) GetAllowedFillers(opts *bind.CallOpts) ([]common.Address, error) {
 	return a.pool.GetAllowedFillers(opts)
@@ -285,6 +336,9 @@ func (a *hybridExternalPoolAdapter) GetAccumulatedPoolFees(opts *bind.CallOpts)
 func (a *hybridExternalPoolAdapter) WithdrawPoolFees(opts *bind.TransactOpts, recipient common.Address) (*types.Transaction, error) {
 	return a.pool.WithdrawPoolFees(opts, recipient)
 }
+func (a *hybridExternalPoolAdapter) FastFill(opts *bind.TransactOpts, fillID [32]byte, settlementID [32]byte, sourceChainSelector uint64, sourceAmountNetFee *big.Int, sourceDecimals uint8, receiver common.Address) (*types.Transaction, error) {
+	return a.pool.FastFill(opts, fillID, settlementID, sourceChainSelector, sourceAmountNetFee, sourceDecimals, receiver)
+}
 
 // AdapterFactory defines the interface for creating pool adapters
 type AdapterFactory func(address common.Address, backend bind.ContractBackend) (FastTransferPool, error)
@@ -582,6 +636,24 @@ func (w *FastTransferTokenPoolWrapper) WithdrawPoolFees(opts *bind.TransactOpts,
 	return w.pool.WithdrawPoolFees(opts, recipient)
 }
 
+// FastFill fills a fast transfer requested on the source chain, paying the receiver from the filler's tokens
+func (w *FastTransferTokenPoolWrapper) FastFill(
+	opts *bind.TransactOpts,
+	fillID [32]byte,
+	settlementID [32]byte,
+	sourceChainSelector uint64,
+	sourceAmountNetFee *big.Int,
+	sourceDecimals uint8,
+	receiver common.Address,
+) (*types.Transaction, error) {
+	return w.pool.FastFill(opts, fillID, settlementID, sourceChainSelector, sourceAmountNetFee, sourceDecimals, receiver)
+}
+
+// GetFillInfo retrieves the fill state and filler of a fast transfer
+func (w *FastTransferTokenPoolWrapper) GetFillInfo(opts *bind.CallOpts, fillID [32]byte) (FillInfo, error) {
+	return w.pool.GetFillInfo(opts, fillID)
+}
+
 func GetFastTransferTokenPoolContract(env cldf.Environment, tokenSymbol shared.TokenSymbol, contractType cldf.ContractType, contractVersion semver.Version, chainSelector uint64) (*FastTransferTokenPoolWrapper, error) {
 	state, err := stateview.LoadOnchainState(env)
 	if err != nil {
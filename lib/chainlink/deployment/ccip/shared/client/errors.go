@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+var (
+	// ErrTransient classifies the errors of transient RPC failures, the request may succeed if it is retried.
+	ErrTransient = errors.New("transient error")
+	// ErrReverted classifies the errors of requests rejected by the chain, the same request fails again if it is
+	// retried.
+	ErrReverted = errors.New("request reverted")
+)
+
+// transientErrors are the messages of transient RPC failures. Undecodable error reasons and missing trie nodes are
+// returned by nodes lagging behind.
+var transientErrors = []string{
+	"could not decode error reason",
+	"missing trie node",
+	"header not found",
+	"nonce too low",
+	"replacement transaction underpriced",
+	"connection refused",
+	"connection reset",
+	"i/o timeout",
+	"unexpected eof",
+	"too many requests",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+	"blockhash not found",
+	"node is behind",
+}
+
+// revertErrors are the messages of deterministic failures of the request: EVM reverts, Solana program errors and
+// Move aborts.
+var revertErrors = []string{
+	"execution reverted",
+	"transaction reverted",
+	"custom program error",
+	"error processing instruction",
+	"moveabort",
+	"move_abort",
+	"move abort",
+}
+
+// ClassifyError wraps err with ErrTransient or ErrReverted if it is a transient RPC failure or a deterministic
+// revert. Errors which are already classified, context errors and unknown errors are returned as is.
+func ClassifyError(err error) error {
+	if err == nil || errors.Is(err, ErrTransient) || errors.Is(err, ErrReverted) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %w", ErrTransient, err)
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range transientErrors {
+		if strings.Contains(msg, s) {
+			return fmt.Errorf("%w: %w", ErrTransient, err)
+		}
+	}
+	for _, s := range revertErrors {
+		if strings.Contains(msg, s) {
+			return fmt.Errorf("%w: %w", ErrReverted, err)
+		}
+	}
+	return err
+}
+
+// IsTransient reports whether err is classified as a transient RPC failure.
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrTransient)
+}
+
+// IsReverted reports whether err is classified as a deterministic revert.
+func IsReverted(err error) bool {
+	return errors.Is(err, ErrReverted)
+}
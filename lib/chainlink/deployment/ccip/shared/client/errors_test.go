@@ -0,0 +1,41 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ccipclient "github.com/smartcontractkit/chainlink/deployment/ccip/shared/client"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+		reverted  bool
+	}{
+		{name: "nil", err: nil},
+		{name: "missing trie node", err: errors.New("failed to confirm CCIP message: missing trie node abc"), transient: true},
+		{name: "rate limited", err: errors.New("429 Too Many Requests"), transient: true},
+		{name: "eof", err: fmt.Errorf("failed to get fee: %w", io.EOF), transient: true},
+		{name: "evm revert", err: errors.New("execution reverted: 0x07da6ee6"), reverted: true},
+		{name: "solana program error", err: errors.New("Error processing Instruction 0: custom program error: 0x1771"), reverted: true},
+		{name: "move abort", err: errors.New("MoveAbort in 1st command, abort code: 65537"), reverted: true},
+		{name: "deadline", err: context.DeadlineExceeded},
+		{name: "unknown", err: errors.New("invalid receiver")},
+		{name: "already classified", err: fmt.Errorf("%w: %w", ccipclient.ErrReverted, errors.New("missing trie node")), reverted: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ccipclient.ClassifyError(tt.err)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.transient, ccipclient.IsTransient(err))
+			require.Equal(t, tt.reverted, ccipclient.IsReverted(err))
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/aptos-labs/aptos-go-sdk/bcs"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	solbinary "github.com/gagliardetto/binary"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	aptosBind "github.com/smartcontractkit/chainlink-aptos/bindings/bind"
+	"github.com/smartcontractkit/chainlink-aptos/bindings/ccip"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_2_0/router"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_3/message_hasher"
+	solconfig "github.com/smartcontractkit/chainlink-ccip/chains/solana/contracts/tests/config"
+	solRouter "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_0/ccip_router"
+	solFeeQuoter "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_0/fee_quoter"
+	solccip "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/ccip"
+	solcommon "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/common"
+	solstate "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/state"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	suiBind "github.com/smartcontractkit/chainlink-sui/bindings/bind"
+	module_fee_quoter "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip/fee_quoter"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/ccipevm"
+)
+
+// GenericExtraArgsV2Tag is the tag of the generic extra args: bytes4 public constant EVM_EXTRA_ARGS_V2_TAG = 0x181dcf10.
+const GenericExtraArgsV2Tag = "0x181dcf10"
+
+// MakeBCSEVMExtraArgsV2 makes the BCS encoded extra args for a message sent from a Move based chain that is destined for an EVM chain.
+// The extra args are used to specify the gas limit and allow out of order flag for the message.
+func MakeBCSEVMExtraArgsV2(gasLimit *big.Int, allowOOO bool) []byte {
+	s := &bcs.Serializer{}
+	s.U256(*gasLimit)
+	s.Bool(allowOOO)
+	return append(hexutil.MustDecode(GenericExtraArgsV2Tag), s.ToBytes()...)
+}
+
+// destChainLimits are the limits of the destination chain config of the source fee quoter which apply to extra args.
+type destChainLimits struct {
+	isEnabled         bool
+	maxPerMsgGasLimit uint32
+	enforceOutOfOrder bool
+}
+
+// ApplyExtraArgs validates the typed extra args of cfg against the destination chain config of the source fee quoter,
+// and sets them on the message, encoded for the source chain family. It is a no-op if cfg has no typed extra args.
+func ApplyExtraArgs(e cldf.Environment, state stateview.CCIPOnChainState, cfg *CCIPSendReqConfig) error {
+	if cfg.ExtraArgs == nil {
+		return nil
+	}
+	srcFamily, err := chainsel.GetSelectorFamily(cfg.SourceChain)
+	if err != nil {
+		return err
+	}
+	destFamily, err := chainsel.GetSelectorFamily(cfg.DestChain)
+	if err != nil {
+		return err
+	}
+
+	var (
+		gasLimit       uint64
+		allowOOO       bool
+		destFamilies   []string
+		encodeFamilies = map[string]func() ([]byte, error){}
+	)
+	switch args := cfg.ExtraArgs.(type) {
+	case EVMExtraArgsV2:
+		gasLimit, allowOOO = args.GasLimit, args.AllowOutOfOrderExecution
+		destFamilies = []string{chainsel.FamilyEVM, chainsel.FamilyAptos, chainsel.FamilyTon}
+		encodeFamilies[chainsel.FamilyEVM] = func() ([]byte, error) {
+			return ccipevm.SerializeClientGenericExtraArgsV2(message_hasher.ClientGenericExtraArgsV2{
+				GasLimit:                 new(big.Int).SetUint64(args.GasLimit),
+				AllowOutOfOrderExecution: args.AllowOutOfOrderExecution,
+			})
+		}
+		encodeFamilies[chainsel.FamilySolana] = func() ([]byte, error) {
+			return solccip.SerializeExtraArgs(solFeeQuoter.GenericExtraArgsV2{
+				GasLimit:                 solbinary.Uint128{Lo: args.GasLimit},
+				AllowOutOfOrderExecution: args.AllowOutOfOrderExecution,
+			}, solccip.GenericExtraArgsV2Tag)
+		}
+		encodeBCS := func() ([]byte, error) {
+			return MakeBCSEVMExtraArgsV2(new(big.Int).SetUint64(args.GasLimit), args.AllowOutOfOrderExecution), nil
+		}
+		encodeFamilies[chainsel.FamilyAptos] = encodeBCS
+		encodeFamilies[chainsel.FamilySui] = encodeBCS
+	case SVMExtraArgs:
+		gasLimit, allowOOO = uint64(args.ComputeUnits), args.AllowOutOfOrderExecution
+		destFamilies = []string{chainsel.FamilySolana}
+		encodeFamilies[chainsel.FamilyEVM] = func() ([]byte, error) {
+			return ccipevm.SerializeClientSVMExtraArgsV1(message_hasher.ClientSVMExtraArgsV1{
+				ComputeUnits:             args.ComputeUnits,
+				AccountIsWritableBitmap:  args.AccountIsWritableBitmap,
+				AllowOutOfOrderExecution: args.AllowOutOfOrderExecution,
+				TokenReceiver:            args.TokenReceiver,
+				Accounts:                 args.Accounts,
+			})
+		}
+		encodeFamilies[chainsel.FamilySolana] = func() ([]byte, error) {
+			return solccip.SerializeExtraArgs(solFeeQuoter.SVMExtraArgsV1{
+				ComputeUnits:             args.ComputeUnits,
+				AccountIsWritableBitmap:  args.AccountIsWritableBitmap,
+				AllowOutOfOrderExecution: args.AllowOutOfOrderExecution,
+				TokenReceiver:            args.TokenReceiver,
+				Accounts:                 args.Accounts,
+			}, solccip.SVMExtraArgsV1Tag)
+		}
+	case SuiExtraArgs:
+		gasLimit, allowOOO = args.GasLimit, args.AllowOutOfOrderExecution
+		destFamilies = []string{chainsel.FamilySui}
+		encodeFamilies[chainsel.FamilyEVM] = func() ([]byte, error) {
+			return ccipevm.SerializeClientSUIExtraArgsV1(message_hasher.ClientSuiExtraArgsV1{
+				GasLimit:                 new(big.Int).SetUint64(args.GasLimit),
+				AllowOutOfOrderExecution: args.AllowOutOfOrderExecution,
+				TokenReceiver:            args.TokenReceiver,
+				ReceiverObjectIds:        args.ReceiverObjectIDs,
+			})
+		}
+	default:
+		return fmt.Errorf("unsupported extra args type %T", cfg.ExtraArgs)
+	}
+
+	if !slices.Contains(destFamilies, destFamily) {
+		return fmt.Errorf("%T extra args are not supported by %s destination chain %d", cfg.ExtraArgs, destFamily, cfg.DestChain)
+	}
+	encode, ok := encodeFamilies[srcFamily]
+	if !ok {
+		return fmt.Errorf("%T extra args are not supported by %s source chain %d", cfg.ExtraArgs, srcFamily, cfg.SourceChain)
+	}
+
+	limits, err := getDestChainLimits(e, state, cfg.SourceChain, cfg.DestChain)
+	if err != nil {
+		return fmt.Errorf("failed to get destination chain config: %w", err)
+	}
+	if !limits.isEnabled {
+		return fmt.Errorf("destination chain %d is not enabled on the fee quoter of source chain %d", cfg.DestChain, cfg.SourceChain)
+	}
+	if gasLimit > uint64(limits.maxPerMsgGasLimit) {
+		return fmt.Errorf("gas limit %d exceeds the max per message gas limit %d of destination chain %d",
+			gasLimit, limits.maxPerMsgGasLimit, cfg.DestChain)
+	}
+	if limits.enforceOutOfOrder && !allowOOO {
+		return fmt.Errorf("destination chain %d enforces out of order execution", cfg.DestChain)
+	}
+
+	extraArgs, err := encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode extra args: %w", err)
+	}
+	return setMessageExtraArgs(cfg, extraArgs)
+}
+
+// getDestChainLimits returns the limits of the destination chain config of the source fee quoter.
+func getDestChainLimits(e cldf.Environment, state stateview.CCIPOnChainState, src, dest uint64) (destChainLimits, error) {
+	family, err := chainsel.GetSelectorFamily(src)
+	if err != nil {
+		return destChainLimits{}, err
+	}
+
+	switch family {
+	case chainsel.FamilyEVM:
+		cfg, err := state.MustGetEVMChainState(src).FeeQuoter.GetDestChainConfig(&bind.CallOpts{Context: e.GetContext()}, dest)
+		if err != nil {
+			return destChainLimits{}, cldf.MaybeDataErr(err)
+		}
+		return destChainLimits{cfg.IsEnabled, cfg.MaxPerMsgGasLimit, cfg.EnforceOutOfOrder}, nil
+	case chainsel.FamilySolana:
+		fqDestChainPDA, _, err := solstate.FindFqDestChainPDA(dest, state.SolChains[src].FeeQuoter)
+		if err != nil {
+			return destChainLimits{}, err
+		}
+		var destChain solFeeQuoter.DestChain
+		err = solcommon.GetAccountDataBorshInto(e.GetContext(), e.BlockChains.SolanaChains()[src].Client, fqDestChainPDA, solconfig.DefaultCommitment, &destChain)
+		if err != nil {
+			return destChainLimits{}, err
+		}
+		return destChainLimits{destChain.Config.IsEnabled, destChain.Config.MaxPerMsgGasLimit, destChain.Config.EnforceOutOfOrder}, nil
+	case chainsel.FamilyAptos:
+		ccipContract := ccip.Bind(state.AptosChains[src].CCIPAddress, e.BlockChains.AptosChains()[src].Client)
+		cfg, err := ccipContract.FeeQuoter().GetDestChainConfig(&aptosBind.CallOpts{}, dest)
+		if err != nil {
+			return destChainLimits{}, err
+		}
+		return destChainLimits{cfg.IsEnabled, cfg.MaxPerMsgGasLimit, cfg.EnforceOutOfOrder}, nil
+	case chainsel.FamilySui:
+		suiChain := e.BlockChains.SuiChains()[src]
+		feeQuoter, err := module_fee_quoter.NewFeeQuoter(state.SuiChains[src].CCIPAddress, suiChain.Client)
+		if err != nil {
+			return destChainLimits{}, err
+		}
+		cfg, err := feeQuoter.DevInspect().GetDestChainConfig(e.GetContext(), &suiBind.CallOpts{Signer: suiChain.Signer},
+			suiBind.Object{Id: state.SuiChains[src].CCIPObjectRef}, dest)
+		if err != nil {
+			return destChainLimits{}, err
+		}
+		return destChainLimits{cfg.IsEnabled, cfg.MaxPerMsgGasLimit, cfg.EnforceOutOfOrder}, nil
+	default:
+		return destChainLimits{}, fmt.Errorf("unsupported chain family: %v", family)
+	}
+}
+
+// setMessageExtraArgs sets the raw extra args of the message of cfg.
+func setMessageExtraArgs(cfg *CCIPSendReqConfig, extraArgs []byte) error {
+	switch msg := cfg.Message.(type) {
+	case router.ClientEVM2AnyMessage:
+		msg.ExtraArgs = extraArgs
+		cfg.Message = msg
+	case solRouter.SVM2AnyMessage:
+		msg.ExtraArgs = extraArgs
+		cfg.Message = msg
+	case SolanaSendRequest:
+		msg.ExtraArgs = extraArgs
+		cfg.Message = msg
+	case AptosSendRequest:
+		msg.ExtraArgs = extraArgs
+		cfg.Message = msg
+	case SuiSendRequest:
+		msg.ExtraArgs = extraArgs
+		cfg.Message = msg
+	case nil:
+		return errors.New("extra args require a message")
+	default:
+		return fmt.Errorf("unsupported message type %T for extra args", cfg.Message)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gagliardetto/solana-go"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-aptos/bindings/ccip_router"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_2_0/router"
+	solRouter "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_0/ccip_router"
+	solcommon "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/common"
+	solstate "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/state"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	suiBind "github.com/smartcontractkit/chainlink-sui/bindings/bind"
+	module_fee_quoter "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip/fee_quoter"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+)
+
+// GetFee returns the fee of the message built by opts, the same options as testhelpers.SendRequest, in the fee token of the
+// message. It uses the fee quoting path of the source chain family, so that callers can display or validate the fee
+// before sending the request.
+func GetFee(
+	e cldf.Environment,
+	state stateview.CCIPOnChainState,
+	opts ...SendReqOpts,
+) (*big.Int, error) {
+	cfg := &CCIPSendReqConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	family, err := chainsel.GetSelectorFamily(cfg.SourceChain)
+	if err != nil {
+		return nil, err
+	}
+	if err = ApplyExtraArgs(e, state, cfg); err != nil {
+		return nil, err
+	}
+
+	switch family {
+	case chainsel.FamilyEVM:
+		return GetFeeEVM(e, state, cfg)
+	case chainsel.FamilySolana:
+		return GetFeeSol(e, state, cfg)
+	case chainsel.FamilySui:
+		return GetFeeSui(e, state, cfg)
+	case chainsel.FamilyAptos:
+		return GetFeeAptos(e, state, cfg)
+	default:
+		return nil, fmt.Errorf("get fee: unsupported chain family: %v", family)
+	}
+}
+
+// GetFeeEVM returns the fee quoted by the router, or the test router if cfg.IsTestRouter is set.
+func GetFeeEVM(
+	e cldf.Environment,
+	state stateview.CCIPOnChainState,
+	cfg *CCIPSendReqConfig,
+) (*big.Int, error) {
+	msg, ok := cfg.Message.(router.ClientEVM2AnyMessage)
+	if !ok {
+		return nil, fmt.Errorf("expected message of type router.ClientEVM2AnyMessage, got %T", cfg.Message)
+	}
+	r := state.MustGetEVMChainState(cfg.SourceChain).Router
+	if cfg.IsTestRouter {
+		r = state.MustGetEVMChainState(cfg.SourceChain).TestRouter
+	}
+	fee, err := r.GetFee(&bind.CallOpts{Context: e.GetContext()}, cfg.DestChain, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get EVM fee: %w", cldf.MaybeDataErr(err))
+	}
+	return fee, nil
+}
+
+// GetFeeSol simulates the get_fee instruction of the router and returns the fee in the fee token, or in lamports
+// if the fee token is native SOL.
+func GetFeeSol(
+	e cldf.Environment,
+	state stateview.CCIPOnChainState,
+	cfg *CCIPSendReqConfig,
+) (*big.Int, error) {
+	ctx := e.GetContext()
+
+	s := state.SolChains[cfg.SourceChain]
+	c := e.BlockChains.SolanaChains()[cfg.SourceChain]
+
+	req, err := ToSolanaSendRequest(cfg.Message)
+	if err != nil {
+		return nil, err
+	}
+	message := req.SVM2AnyMessage()
+	feeToken := message.FeeToken
+	if feeToken.IsZero() {
+		feeToken = solana.SolMint
+	}
+
+	destinationChainStatePDA, err := solstate.FindDestChainStatePDA(cfg.DestChain, s.Router)
+	if err != nil {
+		return nil, err
+	}
+	fqDestChainPDA, _, err := solstate.FindFqDestChainPDA(cfg.DestChain, s.FeeQuoter)
+	if err != nil {
+		return nil, err
+	}
+	feeTokenFqBillingConfigPDA, _, err := solstate.FindFqBillingTokenConfigPDA(feeToken, s.FeeQuoter)
+	if err != nil {
+		return nil, err
+	}
+	linkFqBillingConfigPDA, _, err := solstate.FindFqBillingTokenConfigPDA(s.LinkToken, s.FeeQuoter)
+	if err != nil {
+		return nil, err
+	}
+
+	ix := solRouter.NewGetFeeInstruction(
+		cfg.DestChain,
+		message,
+		s.RouterConfigPDA,
+		destinationChainStatePDA,
+		s.FeeQuoter,
+		s.FeeQuoterConfigPDA,
+		fqDestChainPDA,
+		feeTokenFqBillingConfigPDA,
+		linkFqBillingConfigPDA,
+	)
+	// The fee quoter expects the billing and per chain configs of each transferred token
+	for _, tokenAmount := range message.TokenAmounts {
+		billingConfigPDA, _, err := solstate.FindFqBillingTokenConfigPDA(tokenAmount.Token, s.FeeQuoter)
+		if err != nil {
+			return nil, err
+		}
+		perChainConfigPDA, _, err := solstate.FindFqPerChainPerTokenConfigPDA(cfg.DestChain, tokenAmount.Token, s.FeeQuoter)
+		if err != nil {
+			return nil, err
+		}
+		ix.AccountMetaSlice = append(ix.AccountMetaSlice, solana.Meta(billingConfigPDA), solana.Meta(perChainConfigPDA))
+	}
+	instruction, err := ix.ValidateAndBuild()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := solcommon.SimulateTransaction(ctx, c.Client, []solana.Instruction{instruction}, *c.DeployerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate Solana get_fee: %w", err)
+	}
+	if res.Value.Err != nil {
+		return nil, fmt.Errorf("failed to simulate Solana get_fee. Err: %v, Logs: %v", res.Value.Err, res.Value.Logs)
+	}
+	fee, err := solcommon.ExtractAnchorTypedReturnValue[solRouter.GetFeeResult](ctx, res.Value.Logs, s.Router.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract Solana fee: %w", err)
+	}
+	return new(big.Int).SetUint64(fee.Amount), nil
+}
+
+// GetFeeAptos returns the fee quoted by the router in the fee token of the message.
+func GetFeeAptos(
+	e cldf.Environment,
+	state stateview.CCIPOnChainState,
+	cfg *CCIPSendReqConfig,
+) (*big.Int, error) {
+	msg, ok := cfg.Message.(AptosSendRequest)
+	if !ok {
+		return nil, fmt.Errorf("expected message of type AptosSendRequest, got %T", cfg.Message)
+	}
+	router := state.AptosChains[cfg.SourceChain].CCIPAddress
+	if cfg.IsTestRouter {
+		router = state.AptosChains[cfg.SourceChain].TestRouterAddress
+	}
+
+	tokenAddresses := make([]aptos.AccountAddress, len(msg.TokenAmounts))
+	tokenAmounts := make([]uint64, len(msg.TokenAmounts))
+	tokenStoreAddresses := make([]aptos.AccountAddress, len(msg.TokenAmounts))
+	for i, v := range msg.TokenAmounts {
+		tokenAddresses[i] = v.Token
+		tokenAmounts[i] = v.Amount
+	}
+
+	routerContract := ccip_router.Bind(router, e.BlockChains.AptosChains()[cfg.SourceChain].Client)
+	fee, err := routerContract.Router().GetFee(
+		nil,
+		cfg.DestChain,
+		msg.Receiver,
+		msg.Data,
+		tokenAddresses,
+		tokenAmounts,
+		tokenStoreAddresses,
+		msg.FeeToken,
+		msg.FeeTokenStore,
+		msg.ExtraArgs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Aptos fee: %w", err)
+	}
+	return new(big.Int).SetUint64(fee), nil
+}
+
+// GetFeeSui dev-inspects get_validated_fee of the fee quoter and returns the fee in LINK, the fee token of
+// testhelpers.SendSuiCCIPRequest.
+func GetFeeSui(e cldf.Environment, state stateview.CCIPOnChainState, cfg *CCIPSendReqConfig) (*big.Int, error) {
+	msg, ok := cfg.Message.(SuiSendRequest)
+	if !ok {
+		return nil, fmt.Errorf("expected message of type SuiSendRequest, got %T", cfg.Message)
+	}
+	suiChain := e.BlockChains.SuiChains()[cfg.SourceChain]
+	suiState := state.SuiChains[cfg.SourceChain]
+
+	feeQuoter, err := module_fee_quoter.NewFeeQuoter(suiState.CCIPAddress, suiChain.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind Sui fee quoter: %w", err)
+	}
+
+	tokenAddresses := make([]string, len(msg.TokenAmounts))
+	tokenAmounts := make([]uint64, len(msg.TokenAmounts))
+	for i, v := range msg.TokenAmounts {
+		tokenAddresses[i] = v.Token
+		tokenAmounts[i] = v.Amount
+	}
+
+	fee, err := feeQuoter.DevInspect().GetValidatedFee(e.GetContext(), &suiBind.CallOpts{Signer: suiChain.Signer},
+		suiBind.Object{Id: suiState.CCIPObjectRef},
+		suiBind.Object{Id: "0x6"},
+		cfg.DestChain,
+		msg.Receiver,
+		msg.Data,
+		tokenAddresses,
+		tokenAmounts,
+		suiState.LinkTokenCoinMetadataId,
+		msg.ExtraArgs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Sui fee: %w", err)
+	}
+	return new(big.Int).SetUint64(fee), nil
+}
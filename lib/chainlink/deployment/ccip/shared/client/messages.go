@@ -0,0 +1,107 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/gagliardetto/solana-go"
+
+	solRouter "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_0/ccip_router"
+)
+
+// SolanaSendRequest is a message sent from Solana, along with the accounts of the sender. It is the Solana
+// counterpart of AptosSendRequest and SuiSendRequest, and a plain solRouter.SVM2AnyMessage is sent as a
+// SolanaSendRequest with default accounts.
+type SolanaSendRequest struct {
+	Receiver  []byte
+	Data      []byte
+	ExtraArgs []byte
+	// FeeToken is the mint of the fee token, zero for native SOL.
+	FeeToken solana.PublicKey
+	// FeeTokenUserATA is the account paying the fee, the associated token account of the sender if zero.
+	// It is ignored for native SOL.
+	FeeTokenUserATA solana.PublicKey
+	TokenAmounts    []SolanaTokenAmount
+	// AddressLookupTables are used by the transaction along with the lookup tables of the token pools.
+	AddressLookupTables []solana.PublicKey
+	// ComputeUnitLimit is the compute unit limit of the transaction, 400k if zero.
+	ComputeUnitLimit uint32
+}
+
+type SolanaTokenAmount struct {
+	Token  solana.PublicKey
+	Amount uint64
+	// TokenPool is the pool of the token, matched against the pools of the state if zero.
+	TokenPool solana.PublicKey
+	// UserTokenAccount is the account the tokens are taken from, the associated token account of the sender if zero.
+	UserTokenAccount solana.PublicKey
+}
+
+// SVM2AnyMessage returns the message of the request as passed to the router.
+func (r SolanaSendRequest) SVM2AnyMessage() solRouter.SVM2AnyMessage {
+	tokenAmounts := make([]solRouter.SVMTokenAmount, len(r.TokenAmounts))
+	for i, v := range r.TokenAmounts {
+		tokenAmounts[i] = solRouter.SVMTokenAmount{Token: v.Token, Amount: v.Amount}
+	}
+	return solRouter.SVM2AnyMessage{
+		Receiver:     r.Receiver,
+		Data:         r.Data,
+		TokenAmounts: tokenAmounts,
+		FeeToken:     r.FeeToken,
+		ExtraArgs:    r.ExtraArgs,
+	}
+}
+
+// ToSolanaSendRequest returns the message of a request from Solana, either a SolanaSendRequest or a
+// solRouter.SVM2AnyMessage, as a SolanaSendRequest.
+func ToSolanaSendRequest(msg any) (SolanaSendRequest, error) {
+	switch m := msg.(type) {
+	case SolanaSendRequest:
+		return m, nil
+	case solRouter.SVM2AnyMessage:
+		tokenAmounts := make([]SolanaTokenAmount, len(m.TokenAmounts))
+		for i, v := range m.TokenAmounts {
+			tokenAmounts[i] = SolanaTokenAmount{Token: v.Token, Amount: v.Amount}
+		}
+		return SolanaSendRequest{
+			Receiver:     m.Receiver,
+			Data:         m.Data,
+			ExtraArgs:    m.ExtraArgs,
+			FeeToken:     m.FeeToken,
+			TokenAmounts: tokenAmounts,
+		}, nil
+	default:
+		return SolanaSendRequest{}, fmt.Errorf("expected message of type SolanaSendRequest or ccip_router.SVM2AnyMessage, got %T", msg)
+	}
+}
+
+// AptosSendRequest is a message sent from Aptos. Aptos doesn't provide any struct that we could reuse here.
+type AptosSendRequest struct {
+	Receiver      []byte
+	Data          []byte
+	ExtraArgs     []byte
+	FeeToken      aptos.AccountAddress
+	FeeTokenStore aptos.AccountAddress
+	TokenAmounts  []AptosTokenAmount
+}
+
+type AptosTokenAmount struct {
+	Token  aptos.AccountAddress
+	Amount uint64
+}
+
+// SuiSendRequest is a message sent from Sui.
+type SuiSendRequest struct {
+	Receiver         []byte
+	Data             []byte
+	ExtraArgs        []byte
+	FeeToken         string
+	FeeTokenStore    string
+	TokenAmounts     []SuiTokenAmount
+	TokenReceiverATA []byte
+}
+
+type SuiTokenAmount struct {
+	Token  string
+	Amount uint64
+}
@@ -1,6 +1,11 @@
 package client
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 )
 
@@ -14,15 +19,118 @@ type AnyMsgSentEvent struct {
 	RawEvent any
 }
 
+// SendResult is the result of a request sent by testhelpers.SendRequests.
+type SendResult struct {
+	// Index is the index of the request in the batch.
+	Index       int
+	SourceChain uint64
+	DestChain   uint64
+	// MessageID, SequenceNumber and Fee are only set if the request was sent. Fee is in the fee token of the message.
+	MessageID      [32]byte
+	SequenceNumber uint64
+	Fee            *big.Int
+	Event          *AnyMsgSentEvent
+	Err            error
+}
+
+// SendResults are the results of a batch of requests, in the order of the requests.
+type SendResults []SendResult
+
+// Failed returns the results of the failed requests.
+func (r SendResults) Failed() SendResults {
+	var failed SendResults
+	for _, res := range r {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Err joins the errors of the failed requests, nil if all the requests were sent.
+func (r SendResults) Err() error {
+	var errs []error
+	for _, res := range r.Failed() {
+		errs = append(errs, fmt.Errorf("request %d from %d to %d: %w", res.Index, res.SourceChain, res.DestChain, res.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// MessageStatus is a stage of the lifecycle of a message, as reported by testhelpers.TrackMessage.
+type MessageStatus string
+
+const (
+	// MessageSent is reported once the message is found on the source chain.
+	MessageSent MessageStatus = "SENT"
+	// MessageCommitted is reported once a commit report including the message is accepted on the destination chain.
+	MessageCommitted MessageStatus = "COMMITTED"
+	// MessageExecutionSuccess and MessageExecutionFailure are the final states of the message on the destination
+	// chain.
+	MessageExecutionSuccess MessageStatus = "SUCCESS"
+	MessageExecutionFailure MessageStatus = "FAILURE"
+)
+
+// MessageStatusUpdate is an update of the status of a tracked message.
+type MessageStatusUpdate struct {
+	MessageID      [32]byte
+	Status         MessageStatus
+	SequenceNumber uint64
+	// Tx identifies the transaction of the status change: its hash on EVM, signature on Solana and digest on Sui.
+	Tx string
+}
+
 type CCIPSendReqConfig struct {
 	SourceChain  uint64
 	DestChain    uint64
 	IsTestRouter bool
 	Sender       *bind.TransactOpts
 	Message      any
-	MaxRetries   int // Number of retries for errors (excluding insufficient fee errors)
+	MaxRetries   int // Number of retries for transient errors (excluding insufficient fee errors)
+	// RetryDelay is the delay between the retries of transient errors.
+	RetryDelay time.Duration
+	// Timeout bounds the request, including its retries and the wait for finality. No timeout if zero.
+	Timeout time.Duration
+	// WaitForFinality waits for the request to be final on the source chain instead of only confirmed: for the
+	// finalized block on EVM and the finalized commitment on Solana. Aptos and Sui transactions are final once
+	// executed.
+	WaitForFinality bool
+	// ExtraArgs are encoded for the source chain and set on Message when the request is sent, replacing its raw
+	// extra args.
+	ExtraArgs ExtraArgs
 }
 
+// ExtraArgs are the typed extra args of a message, one of EVMExtraArgsV2, SVMExtraArgs or SuiExtraArgs.
+type ExtraArgs interface {
+	isExtraArgs()
+}
+
+// EVMExtraArgsV2 are the generic extra args of EVM destinations, also accepted by Aptos and TON destinations.
+type EVMExtraArgsV2 struct {
+	GasLimit                 uint64
+	AllowOutOfOrderExecution bool
+}
+
+// SVMExtraArgs are the extra args of Solana destinations.
+type SVMExtraArgs struct {
+	ComputeUnits             uint32
+	AccountIsWritableBitmap  uint64
+	AllowOutOfOrderExecution bool
+	TokenReceiver            [32]byte
+	Accounts                 [][32]byte
+}
+
+// SuiExtraArgs are the extra args of Sui destinations.
+type SuiExtraArgs struct {
+	GasLimit                 uint64
+	AllowOutOfOrderExecution bool
+	TokenReceiver            [32]byte
+	ReceiverObjectIDs        [][32]byte
+}
+
+func (EVMExtraArgsV2) isExtraArgs() {}
+func (SVMExtraArgs) isExtraArgs()   {}
+func (SuiExtraArgs) isExtraArgs()   {}
+
 type SendReqOpts func(*CCIPSendReqConfig)
 
 // WithMaxRetries sets the maximum number of retries for the CCIP send request.
@@ -32,6 +140,28 @@ func WithMaxRetries(maxRetries int) SendReqOpts {
 	}
 }
 
+// WithRetryPolicy sets the number of retries of transient errors and the delay between them.
+func WithRetryPolicy(maxRetries int, delay time.Duration) SendReqOpts {
+	return func(c *CCIPSendReqConfig) {
+		c.MaxRetries = maxRetries
+		c.RetryDelay = delay
+	}
+}
+
+// WithTimeout bounds the CCIP send request, including its retries.
+func WithTimeout(timeout time.Duration) SendReqOpts {
+	return func(c *CCIPSendReqConfig) {
+		c.Timeout = timeout
+	}
+}
+
+// WithWaitForFinality waits for the CCIP send request to be final on the source chain.
+func WithWaitForFinality() SendReqOpts {
+	return func(c *CCIPSendReqConfig) {
+		c.WaitForFinality = true
+	}
+}
+
 func WithSender(sender *bind.TransactOpts) SendReqOpts {
 	return func(c *CCIPSendReqConfig) {
 		c.Sender = sender
@@ -61,3 +191,24 @@ func WithDestChain(destChain uint64) SendReqOpts {
 		c.DestChain = destChain
 	}
 }
+
+// WithEVMExtraArgsV2 sets the extra args of a message to an EVM, Aptos or TON destination.
+func WithEVMExtraArgsV2(gasLimit uint64, allowOutOfOrderExecution bool) SendReqOpts {
+	return func(c *CCIPSendReqConfig) {
+		c.ExtraArgs = EVMExtraArgsV2{GasLimit: gasLimit, AllowOutOfOrderExecution: allowOutOfOrderExecution}
+	}
+}
+
+// WithSVMExtraArgs sets the extra args of a message to a Solana destination.
+func WithSVMExtraArgs(args SVMExtraArgs) SendReqOpts {
+	return func(c *CCIPSendReqConfig) {
+		c.ExtraArgs = args
+	}
+}
+
+// WithSuiExtraArgs sets the extra args of a message to a Sui destination.
+func WithSuiExtraArgs(args SuiExtraArgs) SendReqOpts {
+	return func(c *CCIPSendReqConfig) {
+		c.ExtraArgs = args
+	}
+}
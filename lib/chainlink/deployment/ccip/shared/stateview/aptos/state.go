@@ -1,6 +1,7 @@
 package aptos
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -45,6 +46,92 @@ type CCIPChainState struct {
 	ReceiverAddress   aptos.AccountAddress
 }
 
+// chainStateJSON is the JSON encoding of CCIPChainState, with addresses as long hex strings.
+type chainStateJSON struct {
+	MCMSAddress            string                        `json:"mcmsAddress,omitempty"`
+	CCIPAddress            string                        `json:"ccipAddress,omitempty"`
+	LinkTokenAddress       string                        `json:"linkTokenAddress,omitempty"`
+	ManagedTokens          map[shared.TokenSymbol]string `json:"managedTokens,omitempty"`
+	AptosManagedTokenPools map[string]string             `json:"aptosManagedTokenPools,omitempty"`
+	RegulatedTokenPools    map[string]string             `json:"regulatedTokenPools,omitempty"`
+	BurnMintTokenPools     map[string]string             `json:"burnMintTokenPools,omitempty"`
+	LockReleaseTokenPools  map[string]string             `json:"lockReleaseTokenPools,omitempty"`
+	TestRouterAddress      string                        `json:"testRouterAddress,omitempty"`
+	ReceiverAddress        string                        `json:"receiverAddress,omitempty"`
+}
+
+func (s CCIPChainState) MarshalJSON() ([]byte, error) {
+	address := func(a aptos.AccountAddress) string {
+		if a == (aptos.AccountAddress{}) {
+			return ""
+		}
+		return a.StringLong()
+	}
+	pools := func(m map[aptos.AccountAddress]aptos.AccountAddress) map[string]string {
+		out := make(map[string]string, len(m))
+		for token, pool := range m {
+			out[token.StringLong()] = pool.StringLong()
+		}
+		return out
+	}
+	managedTokens := make(map[shared.TokenSymbol]string, len(s.ManagedTokens))
+	for symbol, token := range s.ManagedTokens {
+		managedTokens[symbol] = token.StringLong()
+	}
+	return json.Marshal(chainStateJSON{
+		MCMSAddress:            address(s.MCMSAddress),
+		CCIPAddress:            address(s.CCIPAddress),
+		LinkTokenAddress:       address(s.LinkTokenAddress),
+		ManagedTokens:          managedTokens,
+		AptosManagedTokenPools: pools(s.AptosManagedTokenPools),
+		RegulatedTokenPools:    pools(s.RegulatedTokenPools),
+		BurnMintTokenPools:     pools(s.BurnMintTokenPools),
+		LockReleaseTokenPools:  pools(s.LockReleaseTokenPools),
+		TestRouterAddress:      address(s.TestRouterAddress),
+		ReceiverAddress:        address(s.ReceiverAddress),
+	})
+}
+
+func (s *CCIPChainState) UnmarshalJSON(data []byte) error {
+	var j chainStateJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	var errs []error
+	address := func(str string) aptos.AccountAddress {
+		var a aptos.AccountAddress
+		if str != "" {
+			if err := a.ParseStringRelaxed(str); err != nil {
+				errs = append(errs, fmt.Errorf("failed to parse address %s: %w", str, err))
+			}
+		}
+		return a
+	}
+	pools := func(m map[string]string) map[aptos.AccountAddress]aptos.AccountAddress {
+		out := make(map[aptos.AccountAddress]aptos.AccountAddress, len(m))
+		for token, pool := range m {
+			out[address(token)] = address(pool)
+		}
+		return out
+	}
+	*s = CCIPChainState{
+		MCMSAddress:            address(j.MCMSAddress),
+		CCIPAddress:            address(j.CCIPAddress),
+		LinkTokenAddress:       address(j.LinkTokenAddress),
+		ManagedTokens:          make(map[shared.TokenSymbol]aptos.AccountAddress, len(j.ManagedTokens)),
+		AptosManagedTokenPools: pools(j.AptosManagedTokenPools),
+		RegulatedTokenPools:    pools(j.RegulatedTokenPools),
+		BurnMintTokenPools:     pools(j.BurnMintTokenPools),
+		LockReleaseTokenPools:  pools(j.LockReleaseTokenPools),
+		TestRouterAddress:      address(j.TestRouterAddress),
+		ReceiverAddress:        address(j.ReceiverAddress),
+	}
+	for symbol, token := range j.ManagedTokens {
+		s.ManagedTokens[symbol] = address(token)
+	}
+	return errors.Join(errs...)
+}
+
 // LoadOnchainStateAptos loads chain state for Aptos chains from env
 func LoadOnchainStateAptos(env cldf.Environment) (map[uint64]CCIPChainState, error) {
 	aptosChains := make(map[uint64]CCIPChainState)
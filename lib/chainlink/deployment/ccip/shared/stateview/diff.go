@@ -0,0 +1,386 @@
+package stateview
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	solanago "github.com/gagliardetto/solana-go"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
+	viewshared "github.com/smartcontractkit/chainlink/deployment/ccip/view/shared"
+)
+
+// DiffEntry is a difference between two states at a path of a chain. A is unset if the path only exists in the
+// second state, and B if it only exists in the first one.
+type DiffEntry struct {
+	ChainSelector uint64 `json:"chainSelector"`
+	Path          string `json:"path"`
+	A             string `json:"a,omitempty"`
+	B             string `json:"b,omitempty"`
+}
+
+// StateDiff is the report of DiffStates. Its entries are sorted by chain selector and path.
+type StateDiff struct {
+	Entries []DiffEntry `json:"entries"`
+	// SkippedChains are the chains whose onchain configs were not compared, as one of the states was reconstructed
+	// from a snapshot. Their contracts are still compared.
+	SkippedChains []uint64 `json:"skippedChains,omitempty"`
+}
+
+// Empty reports whether the states have no differences.
+func (d StateDiff) Empty() bool {
+	return len(d.Entries) == 0
+}
+
+func (d StateDiff) String() string {
+	var sb strings.Builder
+	for _, entry := range d.Entries {
+		fmt.Fprintf(&sb, "chain %d %s: %s != %s\n", entry.ChainSelector, entry.Path, orMissing(entry.A), orMissing(entry.B))
+	}
+	for _, selector := range d.SkippedChains {
+		fmt.Fprintf(&sb, "chain %d: onchain configs not compared\n", selector)
+	}
+	return sb.String()
+}
+
+func orMissing(s string) string {
+	if s == "" {
+		return "<missing>"
+	}
+	return s
+}
+
+type diffConfig struct {
+	chainPairs   map[uint64]uint64
+	topologyOnly bool
+}
+
+type DiffOption func(*diffConfig)
+
+// WithChainPairs compares the chains of the first state with the chains of other selectors in the second one, e.g.
+// the chains of a production environment with those of a staging environment. The report uses the selectors of the
+// first state.
+func WithChainPairs(pairs map[uint64]uint64) DiffOption {
+	return func(c *diffConfig) {
+		c.chainPairs = pairs
+	}
+}
+
+// WithTopologyOnly compares which contracts are deployed and how they are wired instead of their addresses, to
+// compare environments with different deployments.
+func WithTopologyOnly() DiffOption {
+	return func(c *diffConfig) {
+		c.topologyOnly = true
+	}
+}
+
+// DiffStates compares two states contract-by-contract: the contracts of the EVM, Solana, Aptos and Sui chains, and for EVM
+// chains the router onramp/offramp wiring, the onramp dest and offramp source configs, the fee quoter dest configs
+// and the token pool remote configs. Onchain configs are not compared for the chains of states reconstructed from
+// snapshots, which have no RPC access.
+func DiffStates(ctx context.Context, a, b CCIPOnChainState, opts ...DiffOption) (StateDiff, error) {
+	cfg := &diffConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	selectorsOfB := make(map[uint64]uint64, len(cfg.chainPairs))
+	for selectorOfA, selectorOfB := range cfg.chainPairs {
+		selectorsOfB[selectorOfB] = selectorOfA
+	}
+
+	left := &stateFlattener{cfg: cfg, state: a, selector: func(s uint64) uint64 { return s }}
+	right := &stateFlattener{cfg: cfg, state: b, selector: func(s uint64) uint64 {
+		if selector, ok := selectorsOfB[s]; ok {
+			return selector
+		}
+		return s
+	}}
+	leftPaths, err := left.flatten(ctx)
+	if err != nil {
+		return StateDiff{}, fmt.Errorf("failed to read first state: %w", err)
+	}
+	rightPaths, err := right.flatten(ctx)
+	if err != nil {
+		return StateDiff{}, fmt.Errorf("failed to read second state: %w", err)
+	}
+
+	var diff StateDiff
+	skipped := make(map[uint64]struct{})
+	for selector := range left.offline {
+		skipped[selector] = struct{}{}
+	}
+	for selector := range right.offline {
+		skipped[selector] = struct{}{}
+	}
+	for key, valueOfA := range leftPaths {
+		if _, ok := skipped[key.selector]; ok && key.config {
+			continue
+		}
+		if valueOfB := rightPaths[key]; valueOfA != valueOfB {
+			diff.Entries = append(diff.Entries, DiffEntry{ChainSelector: key.selector, Path: key.path, A: valueOfA, B: valueOfB})
+		}
+	}
+	for key, valueOfB := range rightPaths {
+		if _, ok := skipped[key.selector]; ok && key.config {
+			continue
+		}
+		if _, ok := leftPaths[key]; !ok {
+			diff.Entries = append(diff.Entries, DiffEntry{ChainSelector: key.selector, Path: key.path, B: valueOfB})
+		}
+	}
+	slices.SortFunc(diff.Entries, func(x, y DiffEntry) int {
+		if x.ChainSelector != y.ChainSelector {
+			return cmp.Compare(x.ChainSelector, y.ChainSelector)
+		}
+		return strings.Compare(x.Path, y.Path)
+	})
+	diff.SkippedChains = slices.Sorted(maps.Keys(skipped))
+	return diff, nil
+}
+
+type diffKey struct {
+	selector uint64
+	path     string
+	// config is set for the paths read from the chain, which are not compared for offline states
+	config bool
+}
+
+// stateFlattener flattens a state to values by chain and path, with the selectors of the first state of DiffStates.
+type stateFlattener struct {
+	cfg      *diffConfig
+	state    CCIPOnChainState
+	selector func(uint64) uint64
+
+	paths   map[diffKey]string
+	offline map[uint64]struct{}
+}
+
+func (f *stateFlattener) flatten(ctx context.Context) (map[diffKey]string, error) {
+	f.paths = make(map[diffKey]string)
+	f.offline = make(map[uint64]struct{})
+	for _, selector := range f.state.EVMChains() {
+		chain := f.state.MustGetEVMChainState(selector)
+		contracts, err := encodeSnapshotValue(reflect.ValueOf(chain))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode chain %d: %w", selector, err)
+		}
+		if err := f.addContracts(selector, contracts); err != nil {
+			return nil, err
+		}
+		err = f.addEVMConfigs(ctx, selector, chain)
+		if errors.Is(err, ErrOfflineState) {
+			f.offline[f.selector(selector)] = struct{}{}
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read configs of chain %d: %w", selector, err)
+		}
+	}
+	for selector, chain := range f.state.SolChains {
+		if err := f.addContracts(selector, chain); err != nil {
+			return nil, err
+		}
+	}
+	for selector, chain := range f.state.AptosChains {
+		if err := f.addContracts(selector, chain); err != nil {
+			return nil, err
+		}
+	}
+	for selector, chain := range f.state.SuiChains {
+		if err := f.addContracts(selector, chain); err != nil {
+			return nil, err
+		}
+	}
+	return f.paths, nil
+}
+
+// addContracts adds the contracts of a chain, from the JSON encoding of its state.
+func (f *stateFlattener) addContracts(selector uint64, chain any) error {
+	data, err := json.Marshal(chain)
+	if err != nil {
+		return fmt.Errorf("failed to encode chain %d: %w", selector, err)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to encode chain %d: %w", selector, err)
+	}
+	f.addJSON(f.selector(selector), "contracts", value)
+	return nil
+}
+
+func (f *stateFlattener) addJSON(selector uint64, path string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, elem := range v {
+			f.addJSON(selector, path+"/"+k, elem)
+		}
+	case []any:
+		for i, elem := range v {
+			f.addJSON(selector, path+"/"+strconv.Itoa(i), elem)
+		}
+	case string:
+		if v == "" || v == (solanago.PublicKey{}).String() {
+			return
+		}
+		f.paths[diffKey{selector: selector, path: path}] = f.address(v)
+	case nil:
+	default:
+		f.paths[diffKey{selector: selector, path: path}] = fmt.Sprint(v)
+	}
+}
+
+// address returns the value of an address, or whether it is set if only the topology is compared.
+func (f *stateFlattener) address(s string) string {
+	if f.cfg.topologyOnly && s != "" && s != (common.Address{}).Hex() {
+		return "set"
+	}
+	return s
+}
+
+func (f *stateFlattener) addConfig(selector uint64, path string, value string) {
+	f.paths[diffKey{selector: f.selector(selector), path: path, config: true}] = value
+}
+
+// tokenPoolRemoteConfigs is implemented by the token pools of all versions.
+type tokenPoolRemoteConfigs interface {
+	GetSupportedChains(opts *bind.CallOpts) ([]uint64, error)
+	GetRemotePools(opts *bind.CallOpts, remoteChainSelector uint64) ([][]byte, error)
+	GetRemoteToken(opts *bind.CallOpts, remoteChainSelector uint64) ([]byte, error)
+}
+
+// isTokenPoolsField reports whether a field of evm.CCIPChainState maps token symbols and versions to token pools.
+func isTokenPoolsField(field reflect.StructField) bool {
+	return field.Type.Kind() == reflect.Map && field.Type.Elem().Kind() == reflect.Map &&
+		field.Type.Elem().Key() == versionType && field.Type.Elem().Elem().Implements(reflect.TypeFor[tokenPoolRemoteConfigs]())
+}
+
+func (f *stateFlattener) addEVMConfigs(ctx context.Context, selector uint64, chain evm.CCIPChainState) error {
+	opts := &bind.CallOpts{Context: ctx}
+	var remoteChains []uint64
+	for remote := range f.state.SupportedChains() {
+		if remote != selector {
+			remoteChains = append(remoteChains, remote)
+		}
+	}
+	remoteKey := func(remote uint64) string {
+		return strconv.FormatUint(f.selector(remote), 10)
+	}
+
+	if chain.Router != nil {
+		for _, remote := range remoteChains {
+			onRamp, err := chain.Router.GetOnRamp(opts, remote)
+			if err != nil {
+				return fmt.Errorf("failed to get onramp of router for dest %d: %w", remote, err)
+			}
+			if onRamp != (common.Address{}) {
+				f.addConfig(selector, "Router/onRamps/"+remoteKey(remote), f.address(onRamp.Hex()))
+			}
+		}
+		offRamps, err := chain.Router.GetOffRamps(opts)
+		if err != nil {
+			return fmt.Errorf("failed to get offramps of router: %w", err)
+		}
+		offRampsBySource := make(map[uint64][]string)
+		for _, offRamp := range offRamps {
+			offRampsBySource[offRamp.SourceChainSelector] = append(offRampsBySource[offRamp.SourceChainSelector], f.address(offRamp.OffRamp.Hex()))
+		}
+		for source, addresses := range offRampsBySource {
+			slices.Sort(addresses)
+			f.addConfig(selector, "Router/offRamps/"+remoteKey(source), strings.Join(addresses, ","))
+		}
+	}
+
+	if chain.OnRamp != nil {
+		for _, remote := range remoteChains {
+			destConfig, err := chain.OnRamp.GetDestChainConfig(opts, remote)
+			if err != nil {
+				return fmt.Errorf("failed to get onramp dest config for %d: %w", remote, err)
+			}
+			if destConfig.Router != (common.Address{}) {
+				f.addConfig(selector, "OnRamp/destChainConfigs/"+remoteKey(remote),
+					fmt.Sprintf("router=%s allowlistEnabled=%t", f.address(destConfig.Router.Hex()), destConfig.AllowlistEnabled))
+			}
+		}
+	}
+
+	if chain.OffRamp != nil {
+		sources, sourceConfigs, err := chain.OffRamp.GetAllSourceChainConfigs(opts)
+		if err != nil {
+			return fmt.Errorf("failed to get offramp source configs: %w", err)
+		}
+		for i, source := range sources {
+			sourceConfig := sourceConfigs[i]
+			f.addConfig(selector, "OffRamp/sourceChainConfigs/"+remoteKey(source),
+				fmt.Sprintf("router=%s enabled=%t rmnVerificationDisabled=%t onRamp=%s",
+					f.address(sourceConfig.Router.Hex()), sourceConfig.IsEnabled, sourceConfig.IsRMNVerificationDisabled,
+					f.address(viewshared.GetAddressFromBytes(source, sourceConfig.OnRamp))))
+		}
+	}
+
+	if chain.FeeQuoter != nil {
+		for _, remote := range remoteChains {
+			destConfig, err := chain.FeeQuoter.GetDestChainConfig(opts, remote)
+			if err != nil {
+				return fmt.Errorf("failed to get fee quoter dest config for %d: %w", remote, err)
+			}
+			if destConfig.IsEnabled {
+				f.addConfig(selector, "FeeQuoter/destChainConfigs/"+remoteKey(remote), fmt.Sprintf("%+v", destConfig))
+			}
+		}
+	}
+
+	return f.addTokenPoolConfigs(opts, selector, chain, remoteKey)
+}
+
+// addTokenPoolConfigs adds the remote configs of the token pools of the chain, from the fields of evm.CCIPChainState
+// which map token symbols and versions to pools.
+func (f *stateFlattener) addTokenPoolConfigs(opts *bind.CallOpts, selector uint64, chain evm.CCIPChainState, remoteKey func(uint64) string) error {
+	v := reflect.ValueOf(chain)
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !isTokenPoolsField(field) {
+			continue
+		}
+		for symbols := v.Field(i).MapRange(); symbols.Next(); {
+			for versions := symbols.Value().MapRange(); versions.Next(); {
+				pool, ok := versions.Value().Interface().(tokenPoolRemoteConfigs)
+				if !ok || versions.Value().IsNil() {
+					continue
+				}
+				version := versions.Key().Interface().(semver.Version)
+				prefix := fmt.Sprintf("%s/%s/%s/remoteChains/", field.Name, symbols.Key().String(), version.String())
+				remotes, err := pool.GetSupportedChains(opts)
+				if err != nil {
+					return fmt.Errorf("failed to get supported chains of %s: %w", prefix, err)
+				}
+				for _, remote := range remotes {
+					remotePools, err := pool.GetRemotePools(opts, remote)
+					if err != nil {
+						return fmt.Errorf("failed to get remote pools of %s%d: %w", prefix, remote, err)
+					}
+					remoteToken, err := pool.GetRemoteToken(opts, remote)
+					if err != nil {
+						return fmt.Errorf("failed to get remote token of %s%d: %w", prefix, remote, err)
+					}
+					pools := make([]string, len(remotePools))
+					for j, remotePool := range remotePools {
+						pools[j] = f.address(viewshared.GetAddressFromBytes(remote, remotePool))
+					}
+					slices.Sort(pools)
+					f.addConfig(selector, prefix+remoteKey(remote), fmt.Sprintf("token=%s pools=[%s]",
+						f.address(viewshared.GetAddressFromBytes(remote, remoteToken)), strings.Join(pools, ",")))
+				}
+			}
+		}
+	}
+	return nil
+}
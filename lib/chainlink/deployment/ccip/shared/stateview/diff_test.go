@@ -0,0 +1,64 @@
+package stateview
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_2_0/router"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_3/fee_quoter"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
+)
+
+func offlineState(t *testing.T, chains map[uint64]evm.CCIPChainState) CCIPOnChainState {
+	data, err := CCIPOnChainState{Chains: chains, evmMu: &sync.RWMutex{}}.ExportSnapshot()
+	require.NoError(t, err)
+	state, err := ImportSnapshot(data)
+	require.NoError(t, err)
+	return state
+}
+
+func TestDiffStates(t *testing.T) {
+	address := func(b byte) common.Address { return common.BytesToAddress([]byte{b}) }
+	chain := func(routerAddress, feeQuoterAddress common.Address) evm.CCIPChainState {
+		r, err := router.NewRouter(routerAddress, nil)
+		require.NoError(t, err)
+		var state evm.CCIPChainState
+		state.Router = r
+		if feeQuoterAddress != (common.Address{}) {
+			state.FeeQuoter, err = fee_quoter.NewFeeQuoter(feeQuoterAddress, nil)
+			require.NoError(t, err)
+		}
+		return state
+	}
+	a := offlineState(t, map[uint64]evm.CCIPChainState{1: chain(address(1), address(2))})
+
+	t.Run("same state", func(t *testing.T) {
+		diff, err := DiffStates(t.Context(), a, a)
+		require.NoError(t, err)
+		require.True(t, diff.Empty())
+		require.Equal(t, []uint64{1}, diff.SkippedChains)
+	})
+
+	t.Run("different contracts", func(t *testing.T) {
+		b := offlineState(t, map[uint64]evm.CCIPChainState{1: chain(address(3), common.Address{})})
+		diff, err := DiffStates(t.Context(), a, b)
+		require.NoError(t, err)
+		require.Equal(t, []DiffEntry{
+			{ChainSelector: 1, Path: "contracts/FeeQuoter", A: address(2).Hex()},
+			{ChainSelector: 1, Path: "contracts/Router", A: address(1).Hex(), B: address(3).Hex()},
+		}, diff.Entries)
+	})
+
+	t.Run("topology of paired chains", func(t *testing.T) {
+		b := offlineState(t, map[uint64]evm.CCIPChainState{2: chain(address(3), common.Address{})})
+		diff, err := DiffStates(t.Context(), a, b, WithChainPairs(map[uint64]uint64{1: 2}), WithTopologyOnly())
+		require.NoError(t, err)
+		require.Equal(t, []DiffEntry{
+			{ChainSelector: 1, Path: "contracts/FeeQuoter", A: "set"},
+		}, diff.Entries)
+	})
+}
@@ -0,0 +1,552 @@
+package stateview
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	solanago "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+
+	aptosBind "github.com/smartcontractkit/chainlink-aptos/bindings/bind"
+	aptosCCIP "github.com/smartcontractkit/chainlink-aptos/bindings/ccip"
+	aptosOffRamp "github.com/smartcontractkit/chainlink-aptos/bindings/ccip_offramp"
+	aptosOnRamp "github.com/smartcontractkit/chainlink-aptos/bindings/ccip_onramp"
+	solOffRamp "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_0/ccip_offramp"
+	solRouter "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_0/ccip_router"
+	solFeeQuoter "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_0/fee_quoter"
+	solState "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/state"
+	cldf_aptos "github.com/smartcontractkit/chainlink-deployments-framework/chain/aptos"
+	cldf_solana "github.com/smartcontractkit/chainlink-deployments-framework/chain/solana"
+	cldf_sui "github.com/smartcontractkit/chainlink-deployments-framework/chain/sui"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	suiBind "github.com/smartcontractkit/chainlink-sui/bindings/bind"
+	module_fee_quoter "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip/fee_quoter"
+	module_offramp "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_offramp/offramp"
+	module_onramp "github.com/smartcontractkit/chainlink-sui/bindings/generated/ccip/ccip_onramp/onramp"
+	suistate "github.com/smartcontractkit/chainlink-sui/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	aptosstate "github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/aptos"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/solana"
+)
+
+// The checks of a lane audit.
+const (
+	// LaneCheckOnRamp checks that the onramp of the source chain has a config for the dest chain.
+	LaneCheckOnRamp = "onRampDestConfig"
+	// LaneCheckOffRamp checks that the offramp of the dest chain has an enabled config for the source chain, with the
+	// onramp of the source chain.
+	LaneCheckOffRamp = "offRampSourceConfig"
+	// LaneCheckPrices checks that the fee quoter of the source chain has the gas price of the dest chain, and the
+	// prices of its fee tokens.
+	LaneCheckPrices = "feeQuoterPrices"
+	// LaneCheckTokenPools checks that the token pools of the tokens deployed on both chains reference each other.
+	// It is only run on lanes between EVM chains.
+	LaneCheckTokenPools = "tokenPools"
+)
+
+// LaneCheckResult is the result of a check of a lane.
+type LaneCheckResult struct {
+	Check  string `json:"check"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// LaneAuditResult is the result of the audit of a lane. Lanes which are configured on neither side are not checked.
+type LaneAuditResult struct {
+	SourceChain uint64            `json:"sourceChain"`
+	DestChain   uint64            `json:"destChain"`
+	Configured  bool              `json:"configured"`
+	Checks      []LaneCheckResult `json:"checks,omitempty"`
+}
+
+// Passed reports whether all the checks of the lane passed.
+func (r LaneAuditResult) Passed() bool {
+	for _, check := range r.Checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// LaneAuditReport is the report of LaneAudit, with a result by (source, dest) pair.
+type LaneAuditReport []LaneAuditResult
+
+// Failed returns the results of the lanes which failed a check.
+func (r LaneAuditReport) Failed() LaneAuditReport {
+	var failed LaneAuditReport
+	for _, result := range r {
+		if !result.Passed() {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns the failed checks of all the lanes, or nil if all of them passed.
+func (r LaneAuditReport) Err() error {
+	var errs []error
+	for _, result := range r {
+		for _, check := range result.Checks {
+			if !check.Passed {
+				errs = append(errs, fmt.Errorf("lane %d -> %d: %s: %s", result.SourceChain, result.DestChain, check.Check, check.Reason))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LaneAudit checks the bidirectional consistency of every (source, dest) pair of the EVM, Solana, Aptos and Sui
+// chains of the environment: the onramp dest config, the offramp source config, the fee quoter prices and, between
+// EVM chains, the token pools.
+func (c CCIPOnChainState) LaneAudit(e cldf.Environment) (LaneAuditReport, error) {
+	endpoints := make(map[uint64]laneEndpoint)
+	for selector := range e.BlockChains.EVMChains() {
+		if chainState, ok := c.EVMChainState(selector); ok {
+			endpoints[selector] = evmLaneEndpoint{chain: chainState}
+		}
+	}
+	for selector, chain := range e.BlockChains.SolanaChains() {
+		if chainState, ok := c.SolChains[selector]; ok {
+			endpoints[selector] = solanaLaneEndpoint{chain: chain, state: chainState}
+		}
+	}
+	for selector, chain := range e.BlockChains.AptosChains() {
+		if chainState, ok := c.AptosChains[selector]; ok {
+			endpoints[selector] = aptosLaneEndpoint{chain: chain, state: chainState}
+		}
+	}
+	for selector, chain := range e.BlockChains.SuiChains() {
+		if chainState, ok := c.SuiChains[selector]; ok {
+			endpoints[selector] = suiLaneEndpoint{chain: chain, state: chainState}
+		}
+	}
+	selectors := slices.Sorted(maps.Keys(endpoints))
+
+	ctx := e.GetContext()
+	var report LaneAuditReport
+	for _, source := range selectors {
+		for _, dest := range selectors {
+			if source == dest {
+				continue
+			}
+			result, err := c.auditLane(ctx, source, dest, endpoints[source], endpoints[dest])
+			if err != nil {
+				return nil, fmt.Errorf("failed to audit lane %d -> %d: %w", source, dest, err)
+			}
+			report = append(report, result)
+		}
+	}
+	return report, nil
+}
+
+func (c CCIPOnChainState) auditLane(ctx context.Context, source, dest uint64, sourceEndpoint, destEndpoint laneEndpoint) (LaneAuditResult, error) {
+	result := LaneAuditResult{SourceChain: source, DestChain: dest}
+	onRampConfigured, err := sourceEndpoint.onRampDestConfigured(ctx, dest)
+	if err != nil {
+		return result, fmt.Errorf("failed to get onramp dest config: %w", err)
+	}
+	offRampEnabled, offRampOnRamp, err := destEndpoint.offRampSourceConfig(ctx, source)
+	if err != nil {
+		return result, fmt.Errorf("failed to get offramp source config: %w", err)
+	}
+	if !onRampConfigured && !offRampEnabled {
+		return result, nil
+	}
+	result.Configured = true
+
+	onRampCheck := LaneCheckResult{Check: LaneCheckOnRamp, Passed: onRampConfigured}
+	if !onRampConfigured {
+		onRampCheck.Reason = "onramp has no config for the dest chain, but the offramp of the dest chain is enabled for the source chain"
+	}
+	result.Checks = append(result.Checks, onRampCheck)
+
+	offRampCheck := LaneCheckResult{Check: LaneCheckOffRamp}
+	onRamp, err := c.GetOnRampAddressBytes(source)
+	switch {
+	case !offRampEnabled:
+		offRampCheck.Reason = "offramp is not enabled for the source chain, but the onramp of the source chain has a config for the dest chain"
+	case err != nil:
+		offRampCheck.Reason = err.Error()
+	case !sameAddress(source, onRamp, offRampOnRamp):
+		offRampCheck.Reason = fmt.Sprintf("offramp source config has onramp %x, expected %x", offRampOnRamp, onRamp)
+	default:
+		offRampCheck.Passed = true
+	}
+	result.Checks = append(result.Checks, offRampCheck)
+
+	missingPrices, err := sourceEndpoint.missingPrices(ctx, dest)
+	if err != nil {
+		return result, fmt.Errorf("failed to get fee quoter prices: %w", err)
+	}
+	pricesCheck := LaneCheckResult{Check: LaneCheckPrices, Passed: len(missingPrices) == 0}
+	if !pricesCheck.Passed {
+		pricesCheck.Reason = "fee quoter has no price for " + strings.Join(missingPrices, ", ")
+	}
+	result.Checks = append(result.Checks, pricesCheck)
+
+	sourceEVM, sourceIsEVM := sourceEndpoint.(evmLaneEndpoint)
+	destEVM, destIsEVM := destEndpoint.(evmLaneEndpoint)
+	if sourceIsEVM && destIsEVM {
+		tokenPoolsCheck, err := auditTokenPools(ctx, source, dest, sourceEVM.chain, destEVM.chain)
+		if err != nil {
+			return result, fmt.Errorf("failed to get token pool configs: %w", err)
+		}
+		result.Checks = append(result.Checks, tokenPoolsCheck)
+	}
+	return result, nil
+}
+
+// sameAddress reports whether two encodings of an address of a chain are equal. EVM addresses may be left padded to
+// 32 bytes.
+func sameAddress(selector uint64, a, b []byte) bool {
+	if family, err := chain_selectors.GetSelectorFamily(selector); err == nil && family == chain_selectors.FamilyEVM {
+		return common.BytesToAddress(a) == common.BytesToAddress(b)
+	}
+	return bytes.Equal(a, b)
+}
+
+// auditTokenPools checks that, for each token with pools on both chains, a pool of the source chain references a
+// pool of the dest chain which references it back.
+func auditTokenPools(ctx context.Context, source, dest uint64, sourceChain, destChain evm.CCIPChainState) (LaneCheckResult, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	sourcePools, destPools := evmTokenPools(sourceChain), evmTokenPools(destChain)
+	var unlinked []string
+	for _, symbol := range slices.Sorted(maps.Keys(sourcePools)) {
+		if _, ok := destPools[symbol]; !ok {
+			continue
+		}
+		linked, err := poolsReferenceEachOther(opts, source, dest, sourcePools[symbol], destPools[symbol])
+		if err != nil {
+			return LaneCheckResult{}, fmt.Errorf("token %s: %w", symbol, err)
+		}
+		if !linked {
+			unlinked = append(unlinked, symbol.String())
+		}
+	}
+	check := LaneCheckResult{Check: LaneCheckTokenPools, Passed: len(unlinked) == 0}
+	if !check.Passed {
+		check.Reason = "token pools do not reference each other for " + strings.Join(unlinked, ", ")
+	}
+	return check, nil
+}
+
+func poolsReferenceEachOther(opts *bind.CallOpts, source, dest uint64, sourcePools, destPools []evmTokenPool) (bool, error) {
+	for _, sourcePool := range sourcePools {
+		sourceRemotes, err := sourcePool.GetRemotePools(opts, dest)
+		if err != nil {
+			return false, err
+		}
+		for _, destPool := range destPools {
+			if !slices.ContainsFunc(sourceRemotes, func(remote []byte) bool {
+				return sameAddress(dest, remote, destPool.Address().Bytes())
+			}) {
+				continue
+			}
+			destRemotes, err := destPool.GetRemotePools(opts, source)
+			if err != nil {
+				return false, err
+			}
+			if slices.ContainsFunc(destRemotes, func(remote []byte) bool {
+				return sameAddress(source, remote, sourcePool.Address().Bytes())
+			}) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+type evmTokenPool interface {
+	Address() common.Address
+	tokenPoolRemoteConfigs
+}
+
+// evmTokenPools returns the token pools of all the versions of a chain, by token symbol.
+func evmTokenPools(chain evm.CCIPChainState) map[shared.TokenSymbol][]evmTokenPool {
+	pools := make(map[shared.TokenSymbol][]evmTokenPool)
+	v := reflect.ValueOf(chain)
+	for i := range v.NumField() {
+		if !isTokenPoolsField(v.Type().Field(i)) {
+			continue
+		}
+		for symbols := v.Field(i).MapRange(); symbols.Next(); {
+			symbol := shared.TokenSymbol(symbols.Key().String())
+			for versions := symbols.Value().MapRange(); versions.Next(); {
+				if pool, ok := versions.Value().Interface().(evmTokenPool); ok && !versions.Value().IsNil() {
+					pools[symbol] = append(pools[symbol], pool)
+				}
+			}
+		}
+	}
+	return pools
+}
+
+// laneEndpoint reads the lane configs of the contracts of a chain.
+type laneEndpoint interface {
+	// onRampDestConfigured reports whether the onramp has a config for dest.
+	onRampDestConfigured(ctx context.Context, dest uint64) (bool, error)
+	// offRampSourceConfig returns whether the offramp is enabled for source, and the onramp of its config.
+	offRampSourceConfig(ctx context.Context, source uint64) (enabled bool, onRamp []byte, err error)
+	// missingPrices returns the prices that the fee quoter needs to quote messages to dest, and does not have.
+	missingPrices(ctx context.Context, dest uint64) ([]string, error)
+}
+
+type evmLaneEndpoint struct {
+	chain evm.CCIPChainState
+}
+
+func (p evmLaneEndpoint) onRampDestConfigured(ctx context.Context, dest uint64) (bool, error) {
+	if p.chain.OnRamp == nil {
+		return false, nil
+	}
+	destConfig, err := p.chain.OnRamp.GetDestChainConfig(&bind.CallOpts{Context: ctx}, dest)
+	if err != nil {
+		return false, err
+	}
+	return destConfig.Router != (common.Address{}), nil
+}
+
+func (p evmLaneEndpoint) offRampSourceConfig(ctx context.Context, source uint64) (bool, []byte, error) {
+	if p.chain.OffRamp == nil {
+		return false, nil, nil
+	}
+	sourceConfig, err := p.chain.OffRamp.GetSourceChainConfig(&bind.CallOpts{Context: ctx}, source)
+	if err != nil {
+		return false, nil, err
+	}
+	return sourceConfig.IsEnabled, sourceConfig.OnRamp, nil
+}
+
+func (p evmLaneEndpoint) missingPrices(ctx context.Context, dest uint64) ([]string, error) {
+	if p.chain.FeeQuoter == nil {
+		return []string{"all tokens, no fee quoter found in the state"}, nil
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	var missing []string
+	gasPrice, err := p.chain.FeeQuoter.GetDestinationChainGasPrice(opts, dest)
+	if err != nil {
+		return nil, err
+	}
+	if isZeroPrice(gasPrice.Value) {
+		missing = append(missing, "dest chain gas")
+	}
+	feeTokens, err := p.chain.FeeQuoter.GetFeeTokens(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, feeToken := range feeTokens {
+		price, err := p.chain.FeeQuoter.GetTokenPrice(opts, feeToken)
+		if err != nil {
+			return nil, err
+		}
+		if isZeroPrice(price.Value) {
+			missing = append(missing, "fee token "+feeToken.Hex())
+		}
+	}
+	return missing, nil
+}
+
+func isZeroPrice(price *big.Int) bool {
+	return price == nil || price.Sign() == 0
+}
+
+type solanaLaneEndpoint struct {
+	chain cldf_solana.Chain
+	state solana.CCIPChainState
+}
+
+// getAccount reads an account of the chain, and reports whether it exists.
+func (p solanaLaneEndpoint) getAccount(ctx context.Context, pda solanago.PublicKey, out any) (bool, error) {
+	err := p.chain.GetAccountDataBorshInto(ctx, pda, out)
+	if errors.Is(err, rpc.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (p solanaLaneEndpoint) onRampDestConfigured(ctx context.Context, dest uint64) (bool, error) {
+	if p.state.Router.IsZero() {
+		return false, nil
+	}
+	pda, err := solState.FindDestChainStatePDA(dest, p.state.Router)
+	if err != nil {
+		return false, err
+	}
+	var destChain solRouter.DestChain
+	return p.getAccount(ctx, pda, &destChain)
+}
+
+func (p solanaLaneEndpoint) offRampSourceConfig(ctx context.Context, source uint64) (bool, []byte, error) {
+	if p.state.OffRamp.IsZero() {
+		return false, nil, nil
+	}
+	pda, _, err := solState.FindOfframpSourceChainPDA(source, p.state.OffRamp)
+	if err != nil {
+		return false, nil, err
+	}
+	var sourceChain solOffRamp.SourceChain
+	found, err := p.getAccount(ctx, pda, &sourceChain)
+	if !found || err != nil {
+		return false, nil, err
+	}
+	onRamp := sourceChain.Config.OnRamp
+	return sourceChain.Config.IsEnabled, onRamp.Bytes[:onRamp.Len], nil
+}
+
+func (p solanaLaneEndpoint) missingPrices(ctx context.Context, dest uint64) ([]string, error) {
+	if p.state.FeeQuoter.IsZero() {
+		return []string{"all tokens, no fee quoter found in the state"}, nil
+	}
+	pda, _, err := solState.FindFqDestChainPDA(dest, p.state.FeeQuoter)
+	if err != nil {
+		return nil, err
+	}
+	var destChain solFeeQuoter.DestChain
+	found, err := p.getAccount(ctx, pda, &destChain)
+	if err != nil {
+		return nil, err
+	}
+	if !found || destChain.State.UsdPerUnitGas.Value == [28]uint8{} {
+		return []string{"dest chain gas"}, nil
+	}
+	return nil, nil
+}
+
+// aptosLaneEndpoint reads the lane configs of the onramp, offramp and fee quoter modules, which are all deployed at
+// the CCIP address.
+type aptosLaneEndpoint struct {
+	chain cldf_aptos.Chain
+	state aptosstate.CCIPChainState
+}
+
+func (p aptosLaneEndpoint) onRampDestConfigured(_ context.Context, dest uint64) (bool, error) {
+	if p.state.CCIPAddress == (aptos.AccountAddress{}) {
+		return false, nil
+	}
+	return aptosOnRamp.Bind(p.state.CCIPAddress, p.chain.Client).Onramp().IsChainSupported(&aptosBind.CallOpts{}, dest)
+}
+
+func (p aptosLaneEndpoint) offRampSourceConfig(_ context.Context, source uint64) (bool, []byte, error) {
+	if p.state.CCIPAddress == (aptos.AccountAddress{}) {
+		return false, nil, nil
+	}
+	sourceConfig, err := aptosOffRamp.Bind(p.state.CCIPAddress, p.chain.Client).Offramp().GetSourceChainConfig(&aptosBind.CallOpts{}, source)
+	if err != nil {
+		return false, nil, err
+	}
+	return sourceConfig.IsEnabled, sourceConfig.OnRamp, nil
+}
+
+func (p aptosLaneEndpoint) missingPrices(_ context.Context, dest uint64) ([]string, error) {
+	if p.state.CCIPAddress == (aptos.AccountAddress{}) {
+		return []string{"all tokens, no fee quoter found in the state"}, nil
+	}
+	feeQuoter := aptosCCIP.Bind(p.state.CCIPAddress, p.chain.Client).FeeQuoter()
+	opts := &aptosBind.CallOpts{}
+	var missing []string
+	gasPrice, err := feeQuoter.GetDestChainGasPrice(opts, dest)
+	if err != nil {
+		return nil, err
+	}
+	if isZeroPrice(gasPrice.Value) {
+		missing = append(missing, "dest chain gas")
+	}
+	feeTokens, err := feeQuoter.GetFeeTokens(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, feeToken := range feeTokens {
+		price, err := feeQuoter.GetTokenPrice(opts, feeToken)
+		if err != nil {
+			return nil, err
+		}
+		if isZeroPrice(price.Value) {
+			missing = append(missing, "fee token "+feeToken.StringLong())
+		}
+	}
+	return missing, nil
+}
+
+type suiLaneEndpoint struct {
+	chain cldf_sui.Chain
+	state suistate.CCIPChainState
+}
+
+func (p suiLaneEndpoint) callOpts() *suiBind.CallOpts {
+	return &suiBind.CallOpts{Signer: p.chain.Signer}
+}
+
+func (p suiLaneEndpoint) onRampDestConfigured(ctx context.Context, dest uint64) (bool, error) {
+	if p.state.OnRampAddress == "" {
+		return false, nil
+	}
+	onRamp, err := module_onramp.NewOnramp(p.state.OnRampAddress, p.chain.Client)
+	if err != nil {
+		return false, err
+	}
+	return onRamp.DevInspect().IsChainSupported(ctx, p.callOpts(), suiBind.Object{Id: p.state.OnRampStateObjectId}, dest)
+}
+
+func (p suiLaneEndpoint) offRampSourceConfig(ctx context.Context, source uint64) (bool, []byte, error) {
+	if p.state.OffRampAddress == "" {
+		return false, nil, nil
+	}
+	offRamp, err := module_offramp.NewOfframp(p.state.OffRampAddress, p.chain.Client)
+	if err != nil {
+		return false, nil, err
+	}
+	sourceConfig, err := offRamp.DevInspect().GetSourceChainConfig(ctx, p.callOpts(),
+		suiBind.Object{Id: p.state.CCIPObjectRef}, suiBind.Object{Id: p.state.OffRampStateObjectId}, source)
+	if err != nil {
+		return false, nil, err
+	}
+	return sourceConfig.IsEnabled, sourceConfig.OnRamp, nil
+}
+
+func (p suiLaneEndpoint) missingPrices(ctx context.Context, dest uint64) ([]string, error) {
+	if p.state.CCIPAddress == "" {
+		return []string{"all tokens, no fee quoter found in the state"}, nil
+	}
+	feeQuoter, err := module_fee_quoter.NewFeeQuoter(p.state.CCIPAddress, p.chain.Client)
+	if err != nil {
+		return nil, err
+	}
+	ref := suiBind.Object{Id: p.state.CCIPObjectRef}
+	var missing []string
+	gasPrice, err := feeQuoter.DevInspect().GetDestChainGasPrice(ctx, p.callOpts(), ref, dest)
+	if err != nil {
+		return nil, err
+	}
+	if isZeroPrice(gasPrice.Value) {
+		missing = append(missing, "dest chain gas")
+	}
+	feeTokens, err := feeQuoter.DevInspect().GetFeeTokens(ctx, p.callOpts(), ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, feeToken := range feeTokens {
+		price, err := feeQuoter.DevInspect().GetTokenPrice(ctx, p.callOpts(), ref, feeToken)
+		if err != nil {
+			return nil, err
+		}
+		if isZeroPrice(price.Value) {
+			missing = append(missing, "fee token "+feeToken)
+		}
+	}
+	return missing, nil
+}
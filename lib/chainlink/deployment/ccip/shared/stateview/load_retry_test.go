@@ -0,0 +1,49 @@
+package stateview
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLoadRetries(t *testing.T) {
+	// flakyLoad fails the first failures calls
+	flakyLoad := func(failures int) (func() (int, error), *int) {
+		calls := 0
+		return func() (int, error) {
+			calls++
+			if calls <= failures {
+				return 0, errors.New("rate limited")
+			}
+			return calls, nil
+		}, &calls
+	}
+
+	t.Run("no retries by default", func(t *testing.T) {
+		load, calls := flakyLoad(1)
+		_, err := withLoadRetries(t.Context(), &loadStateOpts{}, load)
+		require.ErrorContains(t, err, "rate limited")
+		require.Equal(t, 1, *calls)
+	})
+
+	t.Run("retries until the load succeeds", func(t *testing.T) {
+		load, calls := flakyLoad(2)
+		config := &loadStateOpts{}
+		WithLoadRetries(2, time.Millisecond)(config)
+		out, err := withLoadRetries(t.Context(), config, load)
+		require.NoError(t, err)
+		require.Equal(t, 3, out)
+		require.Equal(t, 3, *calls)
+	})
+
+	t.Run("returns the last error once the retries are exhausted", func(t *testing.T) {
+		load, calls := flakyLoad(3)
+		config := &loadStateOpts{}
+		WithLoadRetries(1, time.Millisecond)(config)
+		_, err := withLoadRetries(t.Context(), config, load)
+		require.ErrorContains(t, err, "rate limited")
+		require.Equal(t, 2, *calls)
+	})
+}
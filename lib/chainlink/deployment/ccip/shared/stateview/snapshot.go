@@ -0,0 +1,529 @@
+package stateview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/ccip-contract-examples/chains/evm/gobindings/generated/latest/burn_mint_with_external_minter_token_pool"
+	"github.com/smartcontractkit/ccip-contract-examples/chains/evm/gobindings/generated/latest/hybrid_with_external_minter_token_pool"
+	"github.com/smartcontractkit/ccip-contract-examples/chains/evm/gobindings/generated/latest/token_governor"
+	mcmsbindings "github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/latest/don_id_claimer"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/latest/fast_transfer_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/latest/log_message_data_receiver"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/latest/maybe_revert_message_receiver"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/latest/mock_usdc_token_messenger"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/latest/mock_usdc_token_transmitter"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_0_0/rmn_proxy_contract"
+	price_registry_1_2_0 "github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_2_0/price_registry"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_2_0/router"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/burn_mint_token_pool_and_proxy"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/commit_store"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/evm_2_evm_offramp"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/evm_2_evm_onramp"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/mock_rmn_contract"
+	registryModuleOwnerCustomv15 "github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/registry_module_owner_custom"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/rmn_contract"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_0/token_admin_registry"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/burn_from_mint_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/burn_with_from_mint_token_pool"
+	factoryBurnMintERC20v1_5_1 "github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/factory_burn_mint_erc20"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/lock_release_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/token_pool_factory"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/usdc_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/ccip_home"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/nonce_manager"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/offramp"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/onramp"
+	registryModuleOwnerCustomv16 "github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/registry_module_owner_custom"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/rmn_home"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/rmn_remote"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/siloed_lock_release_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_2/cctp_message_transmitter_proxy"
+	factoryBurnMintERC20v1_6_2 "github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_2/factory_burn_mint_erc20"
+	usdc_token_pool_v1_6_2 "github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_2/usdc_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_3/fee_quoter"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/link_token_interface"
+	capabilities_registry "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/capabilities_registry_1_1_0"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/1_5_0/burn_mint_erc20_with_drip"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/aggregator_v3_interface"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/burn_mint_erc20"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/erc20"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/erc677"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/link_token"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/multicall3"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/weth9"
+
+	suistate "github.com/smartcontractkit/chainlink-sui/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/bindings/burn_mint_with_external_minter_fast_transfer_token_pool"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/bindings/hybrid_with_external_minter_fast_transfer_token_pool"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/bindings/signer_registry"
+	aptosstate "github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/aptos"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/solana"
+)
+
+// SnapshotVersion is the version of the snapshots written by NewSnapshot.
+const SnapshotVersion = 1
+
+// ErrOfflineState is returned by the contract calls of a state reconstructed from a snapshot.
+var ErrOfflineState = errors.New("state was reconstructed from a snapshot and has no RPC access")
+
+// Snapshot is a versioned JSON copy of the EVM, Solana, Aptos and Sui chains of a CCIPOnChainState, for audits, diffs and
+// offline tooling. EVM chains are recorded as the addresses of their contracts, by field of evm.CCIPChainState.
+type Snapshot struct {
+	Version     int                                  `json:"version"`
+	EVMChains   map[uint64]json.RawMessage           `json:"evmChains,omitempty"`
+	SolChains   map[uint64]solana.CCIPChainState     `json:"solChains,omitempty"`
+	AptosChains map[uint64]aptosstate.CCIPChainState `json:"aptosChains,omitempty"`
+	SuiChains   map[uint64]suistate.CCIPChainState   `json:"suiChains,omitempty"`
+}
+
+// NewSnapshot returns the snapshot of the EVM, Solana, Aptos and Sui chains of the state.
+func NewSnapshot(c CCIPOnChainState) (*Snapshot, error) {
+	s := &Snapshot{
+		Version:     SnapshotVersion,
+		EVMChains:   make(map[uint64]json.RawMessage),
+		SolChains:   c.SolChains,
+		AptosChains: c.AptosChains,
+		SuiChains:   c.SuiChains,
+	}
+	for _, selector := range c.EVMChains() {
+		chain, err := encodeSnapshotValue(reflect.ValueOf(c.MustGetEVMChainState(selector)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot chain %d: %w", selector, err)
+		}
+		if s.EVMChains[selector], err = json.Marshal(chain); err != nil {
+			return nil, fmt.Errorf("failed to snapshot chain %d: %w", selector, err)
+		}
+	}
+	return s, nil
+}
+
+// State reconstructs a read-only state from the snapshot. The contracts of its EVM chains have the addresses of the
+// snapshot, and their calls return ErrOfflineState.
+func (s *Snapshot) State() (CCIPOnChainState, error) {
+	if s.Version != SnapshotVersion {
+		return CCIPOnChainState{}, fmt.Errorf("unsupported snapshot version %d, expected %d", s.Version, SnapshotVersion)
+	}
+	state := CCIPOnChainState{
+		Chains:      make(map[uint64]evm.CCIPChainState),
+		SolChains:   s.SolChains,
+		AptosChains: s.AptosChains,
+		SuiChains:   s.SuiChains,
+		evmMu:       &sync.RWMutex{},
+	}
+	for selector, raw := range s.EVMChains {
+		var chain evm.CCIPChainState
+		if err := decodeSnapshotValue(raw, reflect.ValueOf(&chain).Elem()); err != nil {
+			return CCIPOnChainState{}, fmt.Errorf("failed to reconstruct chain %d: %w", selector, err)
+		}
+		state.Chains[selector] = chain
+	}
+	return state, nil
+}
+
+// ExportSnapshot returns the JSON snapshot of the EVM, Solana, Aptos and Sui chains of the state.
+func (c CCIPOnChainState) ExportSnapshot() ([]byte, error) {
+	s, err := NewSnapshot(c)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// ImportSnapshot reconstructs a read-only state from a JSON snapshot written by ExportSnapshot.
+func ImportSnapshot(data []byte) (CCIPOnChainState, error) {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return CCIPOnChainState{}, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+	}
+	return s.State()
+}
+
+type contractConstructor func(common.Address, bind.ContractBackend) (any, error)
+
+func constructor[T any](newContract func(common.Address, bind.ContractBackend) (*T, error)) contractConstructor {
+	return func(address common.Address, backend bind.ContractBackend) (any, error) {
+		return newContract(address, backend)
+	}
+}
+
+// snapshotContracts are the constructors of the contract types of evm.CCIPChainState, by field type.
+var snapshotContracts = map[reflect.Type]contractConstructor{
+	reflect.TypeFor[*mcmsbindings.ManyChainMultiSig]():                                                                          constructor(mcmsbindings.NewManyChainMultiSig),
+	reflect.TypeFor[*mcmsbindings.RBACTimelock]():                                                                               constructor(mcmsbindings.NewRBACTimelock),
+	reflect.TypeFor[*mcmsbindings.CallProxy]():                                                                                  constructor(mcmsbindings.NewCallProxy),
+	reflect.TypeFor[*link_token.LinkToken]():                                                                                    constructor(link_token.NewLinkToken),
+	reflect.TypeFor[*link_token_interface.LinkToken]():                                                                          constructor(link_token_interface.NewLinkToken),
+	reflect.TypeFor[onramp.OnRampInterface]():                                                                                   constructor(onramp.NewOnRamp),
+	reflect.TypeFor[offramp.OffRampInterface]():                                                                                 constructor(offramp.NewOffRamp),
+	reflect.TypeFor[*fee_quoter.FeeQuoter]():                                                                                    constructor(fee_quoter.NewFeeQuoter),
+	reflect.TypeFor[*rmn_proxy_contract.RMNProxy]():                                                                             constructor(rmn_proxy_contract.NewRMNProxy),
+	reflect.TypeFor[*nonce_manager.NonceManager]():                                                                              constructor(nonce_manager.NewNonceManager),
+	reflect.TypeFor[*token_admin_registry.TokenAdminRegistry]():                                                                 constructor(token_admin_registry.NewTokenAdminRegistry),
+	reflect.TypeFor[*token_pool_factory.TokenPoolFactory]():                                                                     constructor(token_pool_factory.NewTokenPoolFactory),
+	reflect.TypeFor[*registryModuleOwnerCustomv16.RegistryModuleOwnerCustom]():                                                  constructor(registryModuleOwnerCustomv16.NewRegistryModuleOwnerCustom),
+	reflect.TypeFor[*registryModuleOwnerCustomv15.RegistryModuleOwnerCustom]():                                                  constructor(registryModuleOwnerCustomv15.NewRegistryModuleOwnerCustom),
+	reflect.TypeFor[*router.Router]():                                                                                           constructor(router.NewRouter),
+	reflect.TypeFor[*weth9.WETH9]():                                                                                             constructor(weth9.NewWETH9),
+	reflect.TypeFor[*rmn_remote.RMNRemote]():                                                                                    constructor(rmn_remote.NewRMNRemote),
+	reflect.TypeFor[*erc20.ERC20]():                                                                                             constructor(erc20.NewERC20),
+	reflect.TypeFor[*factoryBurnMintERC20v1_6_2.FactoryBurnMintERC20]():                                                         constructor(factoryBurnMintERC20v1_6_2.NewFactoryBurnMintERC20),
+	reflect.TypeFor[*factoryBurnMintERC20v1_5_1.FactoryBurnMintERC20]():                                                         constructor(factoryBurnMintERC20v1_5_1.NewFactoryBurnMintERC20),
+	reflect.TypeFor[*erc677.ERC677]():                                                                                           constructor(erc677.NewERC677),
+	reflect.TypeFor[*burn_mint_erc677.BurnMintERC677]():                                                                         constructor(burn_mint_erc677.NewBurnMintERC677),
+	reflect.TypeFor[*burn_mint_erc20.BurnMintERC20]():                                                                           constructor(burn_mint_erc20.NewBurnMintERC20),
+	reflect.TypeFor[*burn_mint_erc20_with_drip.BurnMintERC20WithDrip]():                                                         constructor(burn_mint_erc20_with_drip.NewBurnMintERC20WithDrip),
+	reflect.TypeFor[*token_governor.TokenGovernor]():                                                                            constructor(token_governor.NewTokenGovernor),
+	reflect.TypeFor[*burn_mint_token_pool.BurnMintTokenPool]():                                                                  constructor(burn_mint_token_pool.NewBurnMintTokenPool),
+	reflect.TypeFor[*burn_mint_token_pool_and_proxy.BurnMintTokenPoolAndProxy]():                                                constructor(burn_mint_token_pool_and_proxy.NewBurnMintTokenPoolAndProxy),
+	reflect.TypeFor[*fast_transfer_token_pool.BurnMintFastTransferTokenPool]():                                                  constructor(fast_transfer_token_pool.NewBurnMintFastTransferTokenPool),
+	reflect.TypeFor[*burn_mint_with_external_minter_fast_transfer_token_pool.BurnMintWithExternalMinterFastTransferTokenPool](): constructor(burn_mint_with_external_minter_fast_transfer_token_pool.NewBurnMintWithExternalMinterFastTransferTokenPool),
+	reflect.TypeFor[*hybrid_with_external_minter_fast_transfer_token_pool.HybridWithExternalMinterFastTransferTokenPool]():      constructor(hybrid_with_external_minter_fast_transfer_token_pool.NewHybridWithExternalMinterFastTransferTokenPool),
+	reflect.TypeFor[*burn_with_from_mint_token_pool.BurnWithFromMintTokenPool]():                                                constructor(burn_with_from_mint_token_pool.NewBurnWithFromMintTokenPool),
+	reflect.TypeFor[*burn_from_mint_token_pool.BurnFromMintTokenPool]():                                                         constructor(burn_from_mint_token_pool.NewBurnFromMintTokenPool),
+	reflect.TypeFor[*burn_mint_with_external_minter_token_pool.BurnMintWithExternalMinterTokenPool]():                           constructor(burn_mint_with_external_minter_token_pool.NewBurnMintWithExternalMinterTokenPool),
+	reflect.TypeFor[*hybrid_with_external_minter_token_pool.HybridWithExternalMinterTokenPool]():                                constructor(hybrid_with_external_minter_token_pool.NewHybridWithExternalMinterTokenPool),
+	reflect.TypeFor[*cctp_message_transmitter_proxy.CCTPMessageTransmitterProxy]():                                              constructor(cctp_message_transmitter_proxy.NewCCTPMessageTransmitterProxy),
+	reflect.TypeFor[*usdc_token_pool.USDCTokenPool]():                                                                           constructor(usdc_token_pool.NewUSDCTokenPool),
+	reflect.TypeFor[*usdc_token_pool_v1_6_2.USDCTokenPool]():                                                                    constructor(usdc_token_pool_v1_6_2.NewUSDCTokenPool),
+	reflect.TypeFor[*lock_release_token_pool.LockReleaseTokenPool]():                                                            constructor(lock_release_token_pool.NewLockReleaseTokenPool),
+	reflect.TypeFor[*siloed_lock_release_token_pool.SiloedLockReleaseTokenPool]():                                               constructor(siloed_lock_release_token_pool.NewSiloedLockReleaseTokenPool),
+	reflect.TypeFor[*aggregator_v3_interface.AggregatorV3Interface]():                                                           constructor(aggregator_v3_interface.NewAggregatorV3Interface),
+	reflect.TypeFor[*capabilities_registry.CapabilitiesRegistry]():                                                              constructor(capabilities_registry.NewCapabilitiesRegistry),
+	reflect.TypeFor[*ccip_home.CCIPHome]():                                                                                      constructor(ccip_home.NewCCIPHome),
+	reflect.TypeFor[*rmn_home.RMNHome]():                                                                                        constructor(rmn_home.NewRMNHome),
+	reflect.TypeFor[*don_id_claimer.DonIDClaimer]():                                                                             constructor(don_id_claimer.NewDonIDClaimer),
+	reflect.TypeFor[maybe_revert_message_receiver.MaybeRevertMessageReceiverInterface]():                                        constructor(maybe_revert_message_receiver.NewMaybeRevertMessageReceiver),
+	reflect.TypeFor[*log_message_data_receiver.LogMessageDataReceiver]():                                                        constructor(log_message_data_receiver.NewLogMessageDataReceiver),
+	reflect.TypeFor[*mock_usdc_token_transmitter.MockE2EUSDCTransmitter]():                                                      constructor(mock_usdc_token_transmitter.NewMockE2EUSDCTransmitter),
+	reflect.TypeFor[*mock_usdc_token_messenger.MockE2EUSDCTokenMessenger]():                                                     constructor(mock_usdc_token_messenger.NewMockE2EUSDCTokenMessenger),
+	reflect.TypeFor[*multicall3.Multicall3]():                                                                                   constructor(multicall3.NewMulticall3),
+	reflect.TypeFor[*evm_2_evm_onramp.EVM2EVMOnRamp]():                                                                          constructor(evm_2_evm_onramp.NewEVM2EVMOnRamp),
+	reflect.TypeFor[*commit_store.CommitStore]():                                                                                constructor(commit_store.NewCommitStore),
+	reflect.TypeFor[*evm_2_evm_offramp.EVM2EVMOffRamp]():                                                                        constructor(evm_2_evm_offramp.NewEVM2EVMOffRamp),
+	reflect.TypeFor[*mock_rmn_contract.MockRMNContract]():                                                                       constructor(mock_rmn_contract.NewMockRMNContract),
+	reflect.TypeFor[*price_registry_1_2_0.PriceRegistry]():                                                                      constructor(price_registry_1_2_0.NewPriceRegistry),
+	reflect.TypeFor[*rmn_contract.RMNContract]():                                                                                constructor(rmn_contract.NewRMNContract),
+	reflect.TypeFor[*signer_registry.SignerRegistry]():                                                                          constructor(signer_registry.NewSignerRegistry),
+}
+
+var (
+	addressType = reflect.TypeFor[common.Address]()
+	versionType = reflect.TypeFor[semver.Version]()
+)
+
+// encodeSnapshotValue converts v to a JSON value, with contracts as their addresses. Nil and empty values are
+// omitted.
+func encodeSnapshotValue(v reflect.Value) (any, error) {
+	if _, ok := snapshotContracts[v.Type()]; ok {
+		if v.IsNil() {
+			return nil, nil
+		}
+		contract, ok := v.Interface().(interface{ Address() common.Address })
+		if !ok {
+			return nil, fmt.Errorf("contract %s has no address", v.Type())
+		}
+		return contract.Address().Hex(), nil
+	}
+	switch v.Type() {
+	case addressType:
+		if v.IsZero() {
+			return nil, nil
+		}
+		return v.Interface().(common.Address).Hex(), nil
+	case versionType:
+		version := v.Interface().(semver.Version)
+		return version.String(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return encodeSnapshotValue(v.Elem())
+	case reflect.Struct:
+		fields := make(map[string]any)
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			value, err := encodeSnapshotValue(v.Field(i))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", field.Name, err)
+			}
+			if value != nil {
+				fields[field.Name] = value
+			}
+		}
+		if len(fields) == 0 {
+			return nil, nil
+		}
+		return fields, nil
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil, nil
+		}
+		entries := make(map[string]any, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			key, err := encodeSnapshotKey(it.Key())
+			if err != nil {
+				return nil, err
+			}
+			value, err := encodeSnapshotValue(it.Value())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			if value != nil {
+				entries[key] = value
+			}
+		}
+		return entries, nil
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return nil, nil
+		}
+		values := make([]any, v.Len())
+		for i := range v.Len() {
+			value, err := encodeSnapshotValue(v.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			values[i] = value
+		}
+		return values, nil
+	case reflect.String:
+		return v.String(), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+func encodeSnapshotKey(k reflect.Value) (string, error) {
+	if k.Type() == versionType {
+		version := k.Interface().(semver.Version)
+		return version.String(), nil
+	}
+	switch k.Kind() {
+	case reflect.String:
+		return k.String(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("unsupported key type %s", k.Type())
+	}
+}
+
+// decodeSnapshotValue sets v from a JSON value written by encodeSnapshotValue. Contracts are bound to an offline
+// backend.
+func decodeSnapshotValue(raw json.RawMessage, v reflect.Value) error {
+	if newContract, ok := snapshotContracts[v.Type()]; ok {
+		address, err := decodeSnapshotAddress(raw)
+		if err != nil {
+			return err
+		}
+		contract, err := newContract(address, offlineBackend{})
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(contract))
+		return nil
+	}
+	switch v.Type() {
+	case addressType:
+		address, err := decodeSnapshotAddress(raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(address))
+		return nil
+	case versionType:
+		version, err := decodeSnapshotVersion(raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(*version))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := decodeSnapshotValue(raw, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return err
+		}
+		for name, value := range fields {
+			field, ok := v.Type().FieldByName(name)
+			if !ok || !field.IsExported() {
+				return fmt.Errorf("unknown field %s of %s", name, v.Type())
+			}
+			if err := decodeSnapshotValue(value, v.FieldByIndex(field.Index)); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		var entries map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return err
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(entries))
+		for k, value := range entries {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := decodeSnapshotKey(k, key); err != nil {
+				return err
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeSnapshotValue(value, elem); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			m.SetMapIndex(key, elem)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Slice:
+		var values []json.RawMessage
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := decodeSnapshotValue(value, s.Index(i)); err != nil {
+				return fmt.Errorf("%d: %w", i, err)
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.String:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+		v.SetString(s)
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+func decodeSnapshotKey(s string, k reflect.Value) error {
+	if k.Type() == versionType {
+		version, err := semver.NewVersion(s)
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		k.Set(reflect.ValueOf(*version))
+		return nil
+	}
+	switch k.Kind() {
+	case reflect.String:
+		k.SetString(s)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, k.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid key %q: %w", s, err)
+		}
+		k.SetUint(n)
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %s", k.Type())
+	}
+}
+
+func decodeSnapshotAddress(raw json.RawMessage) (common.Address, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return common.Address{}, err
+	}
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid address %q", s)
+	}
+	return common.HexToAddress(s), nil
+}
+
+func decodeSnapshotVersion(raw json.RawMessage) (*semver.Version, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	version, err := semver.NewVersion(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q: %w", s, err)
+	}
+	return version, nil
+}
+
+// offlineBackend is the backend of the contracts of a state reconstructed from a snapshot.
+type offlineBackend struct{}
+
+var _ bind.ContractBackend = offlineBackend{}
+
+func (offlineBackend) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return nil, ErrOfflineState
+}
+
+func (offlineBackend) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return nil, ErrOfflineState
+}
+
+func (offlineBackend) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 0, ErrOfflineState
+}
+
+func (offlineBackend) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return nil, ErrOfflineState
+}
+
+func (offlineBackend) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return nil, ErrOfflineState
+}
+
+func (offlineBackend) SendTransaction(context.Context, *types.Transaction) error {
+	return ErrOfflineState
+}
+
+func (offlineBackend) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return nil, ErrOfflineState
+}
+
+func (offlineBackend) PendingCodeAt(context.Context, common.Address) ([]byte, error) {
+	return nil, ErrOfflineState
+}
+
+func (offlineBackend) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return 0, ErrOfflineState
+}
+
+func (offlineBackend) FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, ErrOfflineState
+}
+
+func (offlineBackend) SubscribeFilterLogs(context.Context, ethereum.FilterQuery, chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, ErrOfflineState
+}
@@ -0,0 +1,100 @@
+package stateview
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	solanago "github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_5_1/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/onramp"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/registry_module_owner_custom"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_3/fee_quoter"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/shared/generated/initial/link_token"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	aptosstate "github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/aptos"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/solana"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	address := func(b byte) common.Address { return common.BytesToAddress([]byte{b}) }
+	feeQuoter, err := fee_quoter.NewFeeQuoter(address(1), nil)
+	require.NoError(t, err)
+	onRamp, err := onramp.NewOnRamp(address(2), nil)
+	require.NoError(t, err)
+	registryModule, err := registry_module_owner_custom.NewRegistryModuleOwnerCustom(address(3), nil)
+	require.NoError(t, err)
+	pool, err := burn_mint_token_pool.NewBurnMintTokenPool(address(4), nil)
+	require.NoError(t, err)
+	linkToken, err := link_token.NewLinkToken(address(5), nil)
+	require.NoError(t, err)
+
+	chain := evm.CCIPChainState{
+		ABIByAddress:       map[string]string{address(1).Hex(): "abi"},
+		OnRamp:             onRamp,
+		FeeQuoter:          feeQuoter,
+		FeeQuoterVersion:   semver.MustParse("1.6.3"),
+		RegistryModules1_6: []*registry_module_owner_custom.RegistryModuleOwnerCustom{registryModule},
+		BurnMintTokenPools: map[shared.TokenSymbol]map[semver.Version]*burn_mint_token_pool.BurnMintTokenPool{
+			shared.LinkSymbol: {*semver.MustParse("1.5.1"): pool},
+		},
+		FeeAggregator: address(6),
+	}
+	chain.LinkToken = linkToken
+	state := CCIPOnChainState{
+		Chains: map[uint64]evm.CCIPChainState{1: chain},
+		SolChains: map[uint64]solana.CCIPChainState{2: {
+			Router:    solanago.NewWallet().PublicKey(),
+			SPLTokens: []solanago.PublicKey{solanago.NewWallet().PublicKey()},
+		}},
+		AptosChains: map[uint64]aptosstate.CCIPChainState{3: {
+			CCIPAddress:            aptos.AccountAddress{31: 1},
+			LinkTokenAddress:       aptos.AccountAddress{31: 2},
+			ManagedTokens:          map[shared.TokenSymbol]aptos.AccountAddress{shared.LinkSymbol: {31: 2}},
+			AptosManagedTokenPools: map[aptos.AccountAddress]aptos.AccountAddress{},
+			RegulatedTokenPools:    map[aptos.AccountAddress]aptos.AccountAddress{},
+			BurnMintTokenPools:     map[aptos.AccountAddress]aptos.AccountAddress{{31: 2}: {31: 3}},
+			LockReleaseTokenPools:  map[aptos.AccountAddress]aptos.AccountAddress{},
+		}},
+		evmMu: &sync.RWMutex{},
+	}
+
+	data, err := state.ExportSnapshot()
+	require.NoError(t, err)
+	imported, err := ImportSnapshot(data)
+	require.NoError(t, err)
+
+	require.Equal(t, state.SolChains, imported.SolChains)
+	require.Equal(t, state.AptosChains, imported.AptosChains)
+	got := imported.MustGetEVMChainState(1)
+	require.Equal(t, chain.ABIByAddress, got.ABIByAddress)
+	require.Equal(t, address(1), got.FeeQuoter.Address())
+	require.Equal(t, address(2), got.OnRamp.Address())
+	require.Equal(t, "1.6.3", got.FeeQuoterVersion.String())
+	require.Len(t, got.RegistryModules1_6, 1)
+	require.Equal(t, address(3), got.RegistryModules1_6[0].Address())
+	require.Equal(t, address(4), got.BurnMintTokenPools[shared.LinkSymbol][*semver.MustParse("1.5.1")].Address())
+	require.Equal(t, address(5), got.LinkToken.Address())
+	require.Equal(t, address(6), got.FeeAggregator)
+	require.Nil(t, got.Router)
+
+	// the reconstructed state is read-only and has no RPC access
+	_, err = got.FeeQuoter.Owner(&bind.CallOpts{})
+	require.ErrorIs(t, err, ErrOfflineState)
+
+	reexported, err := imported.ExportSnapshot()
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(reexported))
+}
+
+func TestImportSnapshotVersion(t *testing.T) {
+	_, err := ImportSnapshot([]byte(`{"version": 2}`))
+	require.ErrorContains(t, err, "unsupported snapshot version 2")
+}
@@ -22,7 +22,6 @@ import (
 	solFeeQuoter "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_1/fee_quoter"
 	solLockReleaseTokenPool "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_1/lockrelease_token_pool"
 	rmnRemote "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_1/rmn_remote"
-	solTestTokenPool "github.com/smartcontractkit/chainlink-ccip/chains/solana/gobindings/v0_1_1/test_token_pool"
 	solState "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/state"
 	solTokenUtil "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/tokens"
 
@@ -430,30 +429,15 @@ func LoadChainStateSolana(chain cldf_solana.Chain, addresses map[string]cldf.Typ
 			}
 		case shared.TokenPoolLookupTable:
 			lookupTablePubKey := solana.MustPublicKeyFromBase58(address)
-			var poolType cldf.ContractType
+			labels := shared.ParseTokenPoolLabels(tvStr.Labels, func(label string) bool {
+				_, err := solana.PublicKeyFromBase58(label)
+				return err == nil
+			})
 			var tokenPubKey solana.PublicKey
-			var poolMetadata string
-			for label := range tvStr.Labels {
-				maybeTokenPubKey, err := solana.PublicKeyFromBase58(label)
-				if err == nil {
-					tokenPubKey = maybeTokenPubKey
-				} else {
-					switch label {
-					case solTestTokenPool.BurnAndMint_PoolType.String(), shared.BurnMintTokenPool.String():
-						poolType = shared.BurnMintTokenPool
-					case solTestTokenPool.LockAndRelease_PoolType.String(), shared.LockReleaseTokenPool.String():
-						poolType = shared.LockReleaseTokenPool
-					case shared.CCTPTokenPool.String():
-						poolType = shared.CCTPTokenPool
-					default:
-						poolMetadata = label
-					}
-				}
-			}
-			if poolMetadata == "" {
-				poolMetadata = shared.CLLMetadata
+			if labels.Address != "" {
+				tokenPubKey = solana.MustPublicKeyFromBase58(labels.Address)
 			}
-
+			poolType, poolMetadata := labels.PoolType, labels.Metadata
 			if poolType == "" {
 				poolType = shared.BurnMintTokenPool
 			}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/aptos-labs/aptos-go-sdk"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/sethvargo/go-retry"
 	"github.com/smartcontractkit/ccip-contract-examples/chains/evm/gobindings/generated/latest/burn_mint_with_external_minter_token_pool"
 	"github.com/smartcontractkit/ccip-contract-examples/chains/evm/gobindings/generated/latest/hybrid_with_external_minter_token_pool"
 	"github.com/smartcontractkit/ccip-contract-examples/chains/evm/gobindings/generated/latest/token_governor"
@@ -34,11 +36,11 @@ import (
 
 	suistate "github.com/smartcontractkit/chainlink-sui/deployment"
 	tonstate "github.com/smartcontractkit/chainlink-ton/deployment/state"
-
 	ccipshared "github.com/smartcontractkit/chainlink/deployment/ccip/shared"
 	aptosstate "github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/aptos"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/solana"
+	tronstate "github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/tron"
 
 	commonstate "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
 	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
@@ -85,6 +87,7 @@ import (
 	registryModuleOwnerCustomv16 "github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/registry_module_owner_custom"
 	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/rmn_home"
 	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/rmn_remote"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/siloed_lock_release_token_pool"
 	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_2/cctp_message_transmitter_proxy"
 	factoryBurnMintERC20v1_6_2 "github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_2/factory_burn_mint_erc20"
 	usdc_token_pool_v1_6_2 "github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_2/usdc_token_pool"
@@ -116,6 +119,7 @@ type CCIPOnChainState struct {
 	AptosChains map[uint64]aptosstate.CCIPChainState
 	SuiChains   map[uint64]suistate.CCIPChainState
 	TonChains   map[uint64]tonstate.CCIPChainState
+	TronChains  map[uint64]tronstate.CCIPChainState
 	evmMu       *sync.RWMutex
 }
 
@@ -417,6 +421,9 @@ func (c CCIPOnChainState) SupportedChains() map[uint64]struct{} {
 	for chain := range c.TonChains {
 		chains[chain] = struct{}{}
 	}
+	for chain := range c.TronChains {
+		chains[chain] = struct{}{}
+	}
 	return chains
 }
 
@@ -683,6 +690,12 @@ func (c CCIPOnChainState) GetOffRampAddressBytes(chainSelector uint64) ([]byte,
 		or := c.TonChains[chainSelector].OffRamp
 		rawBytes := codec.ToRawAddr(&or)
 		offRampAddress = rawBytes[:]
+	case chain_selectors.FamilyTron:
+		offRamp := c.TronChains[chainSelector].OffRamp
+		if offRamp == nil {
+			return nil, fmt.Errorf("no offramp found in the state for Tron chain %d", chainSelector)
+		}
+		offRampAddress = offRamp.EthAddress().Bytes()
 
 	default:
 		return nil, fmt.Errorf("unsupported chain family %s", family)
@@ -734,6 +747,12 @@ func (c CCIPOnChainState) GetOnRampAddressBytes(chainSelector uint64) ([]byte, e
 		}
 		rawAddress := codec.ToRawAddr(&ramp)
 		onRampAddressBytes = rawAddress[:]
+	case chain_selectors.FamilyTron:
+		onRamp := c.TronChains[chainSelector].OnRamp
+		if onRamp == nil {
+			return nil, fmt.Errorf("no onramp found in the state for Tron chain %d", chainSelector)
+		}
+		onRampAddressBytes = onRamp.EthAddress().Bytes()
 
 	default:
 		return nil, fmt.Errorf("unsupported chain family %s", family)
@@ -819,6 +838,23 @@ func (c CCIPOnChainState) ValidateRamp(chainSelector uint64, rampType cldf.Contr
 		default:
 			return fmt.Errorf("unknown ramp type %s", rampType)
 		}
+	case chain_selectors.FamilyTron:
+		chainState, exists := c.TronChains[chainSelector]
+		if !exists {
+			return fmt.Errorf("chain %d does not exist", chainSelector)
+		}
+		switch rampType {
+		case ccipshared.OffRamp:
+			if chainState.OffRamp == nil {
+				return fmt.Errorf("offramp contract does not exist on tron chain %d", chainSelector)
+			}
+		case ccipshared.OnRamp:
+			if chainState.OnRamp == nil {
+				return fmt.Errorf("onramp contract does not exist on tron chain %d", chainSelector)
+			}
+		default:
+			return fmt.Errorf("unknown ramp type %s", rampType)
+		}
 
 	default:
 		return fmt.Errorf("unknown chain family %s", family)
@@ -868,8 +904,17 @@ func (c CCIPOnChainState) UpdateMCMSStateWithAddressFromDatastoreForChain(e cldf
 
 type LoadOption func(*loadStateOpts)
 
+// defaultLoadConcurrency is the number of EVM chains loaded concurrently by default.
+const defaultLoadConcurrency = 8
+
+// defaultLoadRetryDelay is the delay between the attempts of a load retried with WithLoadRetries, if not set.
+const defaultLoadRetryDelay = time.Second
+
 type loadStateOpts struct {
 	loadLegacyContracts bool
+	concurrency         int
+	retries             uint64
+	retryDelay          time.Duration
 }
 
 func WithLoadLegacyContracts(load bool) LoadOption {
@@ -878,21 +923,78 @@ func WithLoadLegacyContracts(load bool) LoadOption {
 	}
 }
 
+// WithLoadRetries retries the load of each chain up to retries times, waiting delay between the attempts, or
+// defaultLoadRetryDelay if delay <= 0. The clients of the chains fail over between their RPCs on each call, the
+// retries cover the loads failing on all the RPCs, e.g. when a public RPC rate limits the reads of a large state.
+func WithLoadRetries(retries uint64, delay time.Duration) LoadOption {
+	return func(c *loadStateOpts) {
+		c.retries = retries
+		c.retryDelay = delay
+	}
+}
+
+// WithLoadConcurrency sets the number of EVM chains loaded concurrently, defaultLoadConcurrency if n <= 0.
+func WithLoadConcurrency(n int) LoadOption {
+	return func(c *loadStateOpts) {
+		c.concurrency = n
+	}
+}
+
+// withLoadRetries calls load until it succeeds, at most config.retries more times.
+func withLoadRetries[T any](ctx context.Context, config *loadStateOpts, load func() (T, error)) (T, error) {
+	var out T
+	if config.retries == 0 {
+		return load()
+	}
+	delay := config.retryDelay
+	if delay <= 0 {
+		delay = defaultLoadRetryDelay
+	}
+	err := retry.Do(ctx, retry.WithMaxRetries(config.retries, retry.NewConstant(delay)), func(context.Context) error {
+		var err error
+		out, err = load()
+		return retry.RetryableError(err)
+	})
+	return out, err
+}
+
 func LoadOnchainState(e cldf.Environment, opts ...LoadOption) (CCIPOnChainState, error) {
-	solanaState, err := LoadOnchainStateSolana(e)
+	config := &loadStateOpts{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.concurrency <= 0 {
+		config.concurrency = defaultLoadConcurrency
+	}
+
+	solanaState, err := withLoadRetries(e.GetContext(), config, func() (CCIPOnChainState, error) {
+		return LoadOnchainStateSolana(e)
+	})
+	if err != nil {
+		return CCIPOnChainState{}, err
+	}
+	aptosChains, err := withLoadRetries(e.GetContext(), config, func() (map[uint64]aptosstate.CCIPChainState, error) {
+		return aptosstate.LoadOnchainStateAptos(e)
+	})
 	if err != nil {
 		return CCIPOnChainState{}, err
 	}
-	aptosChains, err := aptosstate.LoadOnchainStateAptos(e)
+	tonChains, err := withLoadRetries(e.GetContext(), config, func() (map[uint64]tonstate.CCIPChainState, error) {
+		return tonstate.LoadOnchainState(e)
+	})
 	if err != nil {
 		return CCIPOnChainState{}, err
 	}
-	tonChains, err := tonstate.LoadOnchainState(e)
+	tronChains, err := withLoadRetries(e.GetContext(), config, func() (map[uint64]tronstate.CCIPChainState, error) {
+		return tronstate.LoadOnchainState(e)
+	})
 	if err != nil {
 		return CCIPOnChainState{}, err
 	}
 
-	suiChains, err := suistate.LoadOnchainStatesui(e)
+	suiChains, err := withLoadRetries(e.GetContext(), config, func() (map[uint64]suistate.CCIPChainState, error) {
+		return suistate.LoadOnchainStatesui(e)
+	})
 	if err != nil {
 		return CCIPOnChainState{}, err
 	}
@@ -903,26 +1005,55 @@ func LoadOnchainState(e cldf.Environment, opts ...LoadOption) (CCIPOnChainState,
 		AptosChains: aptosChains,
 		SuiChains:   suiChains,
 		TonChains:   tonChains,
+		TronChains:  tronChains,
 		evmMu:       &sync.RWMutex{},
 	}
-	for chainSelector, chain := range e.BlockChains.EVMChains() {
-		// get all addresses for chain from addressbook
-		// here we do not load addresses from datastore as there can be multiple
-		// contracts of the same type and version in datastore which can lead to
-		// ambiguity while loading the state
-		addresses, err := e.ExistingAddresses.AddressesForChain(chainSelector)
-		if err != nil && !errors.Is(err, cldf.ErrChainNotFound) {
-			return state, fmt.Errorf("failed to get addresses for chain %d: %w", chainSelector, err)
-		}
-		chainState, err := LoadChainState(e.GetContext(), chain, addresses, opts...)
-		if err != nil {
-			return state, err
-		}
-		state.WriteEVMChainState(chainSelector, chainState)
+
+	// chains are loaded concurrently, and the errors of all of them are returned
+	var (
+		grp    errgroup.Group
+		errsMu sync.Mutex
+		errs   []error
+	)
+	grp.SetLimit(config.concurrency)
+	for _, chain := range e.BlockChains.EVMChains() {
+		grp.Go(func() error {
+			_, err := withLoadRetries(e.GetContext(), config, func() (struct{}, error) {
+				return struct{}{}, state.loadEVMChainState(e, chain, opts...)
+			})
+			if err != nil {
+				errsMu.Lock()
+				errs = append(errs, err)
+				errsMu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = grp.Wait() // errors are collected per chain
+	if len(errs) > 0 {
+		return state, errors.Join(errs...)
 	}
 	return state, state.Validate()
 }
 
+// loadEVMChainState loads the state of an EVM chain from the address book of e, and writes it to the state.
+func (c CCIPOnChainState) loadEVMChainState(e cldf.Environment, chain cldf_evm.Chain, opts ...LoadOption) error {
+	// get all addresses for chain from addressbook
+	// here we do not load addresses from datastore as there can be multiple
+	// contracts of the same type and version in datastore which can lead to
+	// ambiguity while loading the state
+	addresses, err := e.ExistingAddresses.AddressesForChain(chain.Selector)
+	if err != nil && !errors.Is(err, cldf.ErrChainNotFound) {
+		return fmt.Errorf("failed to get addresses for chain %d: %w", chain.Selector, err)
+	}
+	chainState, err := LoadChainState(e.GetContext(), chain, addresses, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to load chain %d: %w", chain.Selector, err)
+	}
+	c.WriteEVMChainState(chain.Selector, chainState)
+	return nil
+}
+
 // LoadChainState Loads all state for a chain into state
 func LoadChainState(ctx context.Context, chain cldf_evm.Chain, addresses map[string]cldf.TypeAndVersion, opts ...LoadOption) (evm.CCIPChainState, error) {
 	config := &loadStateOpts{}
@@ -1447,6 +1578,14 @@ func LoadChainState(ctx context.Context, chain cldf_evm.Chain, addresses map[str
 			}
 			state.HybridWithExternalMinterTokenPool = helpers.AddValueToNestedMap(state.HybridWithExternalMinterTokenPool, metadata.Symbol, metadata.Version, pool)
 			state.ABIByAddress[address] = hybrid_with_external_minter_token_pool.HybridWithExternalMinterTokenPoolABI
+		case cldf.NewTypeAndVersion(ccipshared.SiloedLockReleaseTokenPool, deployment.Version1_6_0).String():
+			addr := common.HexToAddress(address)
+			pool, metadata, err := ccipshared.NewTokenPoolWithMetadata(ctx, siloed_lock_release_token_pool.NewSiloedLockReleaseTokenPool, addr, chain.Client)
+			if err != nil {
+				return state, fmt.Errorf("failed to connect address %s with token pool bindings and get token symbol: %w", addr, err)
+			}
+			state.SiloedLockReleaseTokenPools = helpers.AddValueToNestedMap(state.SiloedLockReleaseTokenPools, metadata.Symbol, metadata.Version, pool)
+			state.ABIByAddress[address] = siloed_lock_release_token_pool.SiloedLockReleaseTokenPoolABI
 		case cldf.NewTypeAndVersion(ccipshared.TokenGovernor, deployment.Version1_6_0).String():
 			tokenGovernor, err := token_governor.NewTokenGovernor(common.HexToAddress(address), chain.Client)
 			if err != nil {
@@ -0,0 +1,140 @@
+package stateview
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	cldf_chain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	aptosstate "github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/aptos"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/evm"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview/solana"
+
+	suistate "github.com/smartcontractkit/chainlink-sui/deployment"
+	tonstate "github.com/smartcontractkit/chainlink-ton/deployment/state"
+)
+
+// LoadOnchainStateFiltered is LoadOnchainState restricted to the chains of selectors and to the contracts of
+// contractTypes, so that callers which only need the contracts of a lane do not load the whole environment.
+// All the chains are loaded if selectors is empty, and all the contracts if contractTypes is empty.
+func LoadOnchainStateFiltered(e cldf.Environment, selectors []uint64, contractTypes []cldf.ContractType, opts ...LoadOption) (CCIPOnChainState, error) {
+	filtered, err := filterEnvironment(e, selectors, contractTypes)
+	if err != nil {
+		return CCIPOnChainState{}, err
+	}
+	return LoadOnchainState(filtered, opts...)
+}
+
+// filterEnvironment returns a copy of e with the chains of selectors, and an address book with the contracts of
+// contractTypes.
+func filterEnvironment(e cldf.Environment, selectors []uint64, contractTypes []cldf.ContractType) (cldf.Environment, error) {
+	if len(selectors) > 0 {
+		chains := make(map[uint64]cldf_chain.BlockChain, len(selectors))
+		for _, selector := range selectors {
+			chain, err := e.BlockChains.GetBySelector(selector)
+			if err != nil {
+				return e, fmt.Errorf("failed to get chain %d: %w", selector, err)
+			}
+			chains[selector] = chain
+		}
+		e.BlockChains = cldf_chain.NewBlockChains(chains)
+	}
+	if len(contractTypes) > 0 {
+		addresses := make(map[uint64]map[string]cldf.TypeAndVersion)
+		for selector := range e.BlockChains.All() {
+			chainAddresses, err := e.ExistingAddresses.AddressesForChain(selector)
+			if errors.Is(err, cldf.ErrChainNotFound) {
+				continue
+			} else if err != nil {
+				return e, fmt.Errorf("failed to get addresses for chain %d: %w", selector, err)
+			}
+			addresses[selector] = make(map[string]cldf.TypeAndVersion)
+			for address, tv := range chainAddresses {
+				if slices.Contains(contractTypes, tv.Type) {
+					addresses[selector][address] = tv
+				}
+			}
+		}
+		e.ExistingAddresses = cldf.NewMemoryAddressBookFromMap(addresses)
+	}
+	return e, nil
+}
+
+// LazyOnchainState loads the state of the chains of an environment on first use, for callers which only need a few
+// chains. It is safe for concurrent use.
+type LazyOnchainState struct {
+	e             cldf.Environment
+	contractTypes []cldf.ContractType
+	opts          []LoadOption
+
+	mu     sync.Mutex
+	state  CCIPOnChainState
+	loaded map[uint64]struct{}
+}
+
+// NewLazyOnchainState returns a LazyOnchainState of e which loads the contracts of contractTypes, all of them if
+// contractTypes is empty.
+func NewLazyOnchainState(e cldf.Environment, contractTypes []cldf.ContractType, opts ...LoadOption) *LazyOnchainState {
+	return &LazyOnchainState{
+		e:             e,
+		contractTypes: contractTypes,
+		opts:          opts,
+		state: CCIPOnChainState{
+			Chains:      make(map[uint64]evm.CCIPChainState),
+			SolChains:   make(map[uint64]solana.CCIPChainState),
+			AptosChains: make(map[uint64]aptosstate.CCIPChainState),
+			SuiChains:   make(map[uint64]suistate.CCIPChainState),
+			TonChains:   make(map[uint64]tonstate.CCIPChainState),
+			evmMu:       &sync.RWMutex{},
+		},
+		loaded: make(map[uint64]struct{}),
+	}
+}
+
+// Load loads the chains of selectors which are not loaded yet, and returns the state of all the loaded chains.
+// The returned state is not modified by later loads.
+func (l *LazyOnchainState) Load(selectors ...uint64) (CCIPOnChainState, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var missing []uint64
+	for _, selector := range selectors {
+		if _, ok := l.loaded[selector]; !ok && !slices.Contains(missing, selector) {
+			missing = append(missing, selector)
+		}
+	}
+	if len(missing) == 0 {
+		return l.state, nil
+	}
+
+	state, err := LoadOnchainStateFiltered(l.e, missing, l.contractTypes, l.opts...)
+	if err != nil {
+		return CCIPOnChainState{}, err
+	}
+	// The EVM chains of the returned states may be written with WriteEVMChainState
+	l.state.evmMu.RLock()
+	evmChains := mergeChains(l.state.Chains, state.Chains)
+	l.state.evmMu.RUnlock()
+	l.state = CCIPOnChainState{
+		Chains:      evmChains,
+		SolChains:   mergeChains(l.state.SolChains, state.SolChains),
+		AptosChains: mergeChains(l.state.AptosChains, state.AptosChains),
+		SuiChains:   mergeChains(l.state.SuiChains, state.SuiChains),
+		TonChains:   mergeChains(l.state.TonChains, state.TonChains),
+		evmMu:       &sync.RWMutex{},
+	}
+	for _, selector := range missing {
+		l.loaded[selector] = struct{}{}
+	}
+	return l.state, nil
+}
+
+func mergeChains[S any](loaded, added map[uint64]S) map[uint64]S {
+	merged := maps.Clone(loaded)
+	maps.Copy(merged, added)
+	return merged
+}
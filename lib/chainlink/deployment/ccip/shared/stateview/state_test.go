@@ -69,6 +69,52 @@ func TestMCMSState(t *testing.T) {
 	require.Equal(t, addr.String(), state.Chains[tenv.HomeChainSel].CancellerMcm.Address().String())
 }
 
+func TestLoadOnchainStateFiltered(t *testing.T) {
+	tenv, _ := testhelpers.NewMemoryEnvironment(t, testhelpers.WithNumOfChains(3))
+	state, err := stateview.LoadOnchainStateFiltered(tenv.Env, []uint64{tenv.HomeChainSel}, []cldf.ContractType{shared.FeeQuoter})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{tenv.HomeChainSel}, state.EVMChains())
+	require.NotNil(t, state.Chains[tenv.HomeChainSel].FeeQuoter)
+	require.Nil(t, state.Chains[tenv.HomeChainSel].OnRamp)
+
+	_, err = stateview.LoadOnchainStateFiltered(tenv.Env, []uint64{chain_selectors.ETHEREUM_MAINNET.Selector}, nil)
+	require.Error(t, err)
+}
+
+func TestLazyOnchainState(t *testing.T) {
+	tenv, _ := testhelpers.NewMemoryEnvironment(t, testhelpers.WithNumOfChains(3))
+	full, err := stateview.LoadOnchainState(tenv.Env)
+	require.NoError(t, err)
+
+	lazy := stateview.NewLazyOnchainState(tenv.Env, nil)
+	state, err := lazy.Load(tenv.HomeChainSel)
+	require.NoError(t, err)
+	require.Len(t, state.Chains, 1)
+	require.Equal(t, full.Chains[tenv.HomeChainSel].OnRamp.Address(), state.Chains[tenv.HomeChainSel].OnRamp.Address())
+
+	state2, err := lazy.Load(tenv.Env.BlockChains.ListChainSelectors(cldf_chain.WithFamily(chain_selectors.FamilyEVM))...)
+	require.NoError(t, err)
+	require.Len(t, state2.Chains, 3)
+	require.Len(t, state.Chains, 1, "previously returned state must not be modified")
+}
+
+func TestLaneAudit(t *testing.T) {
+	tenv, _ := testhelpers.NewMemoryEnvironment(t, testhelpers.WithNumOfChains(3))
+	state, err := stateview.LoadOnchainState(tenv.Env)
+	require.NoError(t, err)
+	chains := tenv.Env.BlockChains.ListChainSelectors(cldf_chain.WithFamily(chain_selectors.FamilyEVM))
+	require.NoError(t, testhelpers.AddLaneWithDefaultPricesAndFeeQuoterConfig(t, &tenv, state, chains[0], chains[1], false))
+
+	report, err := state.LaneAudit(tenv.Env)
+	require.NoError(t, err)
+	require.Len(t, report, 6)
+	require.NoError(t, report.Err())
+	for _, result := range report {
+		isLane := result.SourceChain == chains[0] && result.DestChain == chains[1]
+		require.Equal(t, isLane, result.Configured, "lane %d -> %d", result.SourceChain, result.DestChain)
+	}
+}
+
 func TestEnforceMCMSUsageIfProd(t *testing.T) {
 	t.Parallel()
 
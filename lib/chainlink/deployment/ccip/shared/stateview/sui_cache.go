@@ -0,0 +1,192 @@
+package stateview
+
+import (
+	"context"
+	"sync"
+
+	"github.com/block-vision/sui-go-sdk/models"
+	"github.com/block-vision/sui-go-sdk/sui"
+
+	cldf_chain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// pastObjectVersionFound is the status of the past object reads which found the version.
+const pastObjectVersionFound = "VersionFound"
+
+// SuiObjectCache is a Sui client which caches the object reads of another client, so that long tests which read the
+// same objects in several phases do not read them again. The latest versions of objects are cached until they are
+// invalidated, and must be invalidated after the transactions which mutate them, e.g. after applying changesets.
+// Past versions of objects are immutable and cached by object ID and version.
+// Reads which fail or return an object error are not cached. SuiObjectCache is safe for concurrent use.
+type SuiObjectCache struct {
+	sui.ISuiAPI
+
+	mu     sync.Mutex
+	latest map[suiObjectKey]models.SuiObjectResponse
+	past   map[suiPastObjectKey]models.PastObjectResponse
+}
+
+type suiObjectKey struct {
+	objectID string
+	options  models.SuiObjectDataOptions
+}
+
+type suiPastObjectKey struct {
+	suiObjectKey
+	version uint64
+}
+
+// NewSuiObjectCache returns a SuiObjectCache of the object reads of client.
+func NewSuiObjectCache(client sui.ISuiAPI) *SuiObjectCache {
+	return &SuiObjectCache{
+		ISuiAPI: client,
+		latest:  make(map[suiObjectKey]models.SuiObjectResponse),
+		past:    make(map[suiPastObjectKey]models.PastObjectResponse),
+	}
+}
+
+func (c *SuiObjectCache) SuiGetObject(ctx context.Context, req models.SuiGetObjectRequest) (models.SuiObjectResponse, error) {
+	key := suiObjectKey{objectID: req.ObjectId, options: req.Options}
+	c.mu.Lock()
+	rsp, ok := c.latest[key]
+	c.mu.Unlock()
+	if ok {
+		return rsp, nil
+	}
+
+	rsp, err := c.ISuiAPI.SuiGetObject(ctx, req)
+	if err != nil {
+		return rsp, err
+	}
+	c.mu.Lock()
+	c.storeLatest(key, rsp)
+	c.mu.Unlock()
+	return rsp, nil
+}
+
+// SuiMultiGetObjects reads the objects which are not cached in a single request.
+func (c *SuiObjectCache) SuiMultiGetObjects(ctx context.Context, req models.SuiMultiGetObjectsRequest) ([]*models.SuiObjectResponse, error) {
+	rsps := make([]*models.SuiObjectResponse, len(req.ObjectIds))
+	var missing []string
+	c.mu.Lock()
+	for i, objectID := range req.ObjectIds {
+		if rsp, ok := c.latest[suiObjectKey{objectID: objectID, options: req.Options}]; ok {
+			rsps[i] = &rsp
+		} else {
+			missing = append(missing, objectID)
+		}
+	}
+	c.mu.Unlock()
+	if len(missing) == 0 {
+		return rsps, nil
+	}
+
+	read, err := c.ISuiAPI.SuiMultiGetObjects(ctx, models.SuiMultiGetObjectsRequest{ObjectIds: missing, Options: req.Options})
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range rsps {
+		if rsps[i] != nil {
+			continue
+		}
+		if len(read) == 0 {
+			break
+		}
+		rsps[i], read = read[0], read[1:]
+		if rsps[i] != nil {
+			c.storeLatest(suiObjectKey{objectID: req.ObjectIds[i], options: req.Options}, *rsps[i])
+		}
+	}
+	return rsps, nil
+}
+
+func (c *SuiObjectCache) SuiTryGetPastObject(ctx context.Context, req models.SuiTryGetPastObjectRequest) (models.PastObjectResponse, error) {
+	key := suiPastObjectKey{suiObjectKey: suiObjectKey{objectID: req.ObjectId, options: req.Options}, version: req.Version}
+	c.mu.Lock()
+	rsp, ok := c.past[key]
+	c.mu.Unlock()
+	if ok {
+		return rsp, nil
+	}
+
+	rsp, err := c.ISuiAPI.SuiTryGetPastObject(ctx, req)
+	if err != nil {
+		return rsp, err
+	}
+	if rsp.Status == pastObjectVersionFound {
+		c.mu.Lock()
+		c.past[key] = rsp
+		c.mu.Unlock()
+	}
+	return rsp, nil
+}
+
+// storeLatest caches the latest version of an object. c.mu must be held.
+func (c *SuiObjectCache) storeLatest(key suiObjectKey, rsp models.SuiObjectResponse) {
+	if rsp.Error == nil && rsp.Data != nil {
+		c.latest[key] = rsp
+	}
+}
+
+// Invalidate drops the latest versions of objectIDs from the cache.
+func (c *SuiObjectCache) Invalidate(objectIDs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.latest {
+		for _, objectID := range objectIDs {
+			if key.objectID == objectID {
+				delete(c.latest, key)
+				break
+			}
+		}
+	}
+}
+
+// InvalidateAll drops the latest versions of all the objects from the cache.
+func (c *SuiObjectCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.latest)
+}
+
+// SuiObjectCaches are the object caches of the Sui chains of an environment, by chain selector.
+type SuiObjectCaches map[uint64]*SuiObjectCache
+
+// Invalidate drops the latest versions of objectIDs from the cache of a chain.
+func (c SuiObjectCaches) Invalidate(selector uint64, objectIDs ...string) {
+	if cache, ok := c[selector]; ok {
+		cache.Invalidate(objectIDs...)
+	}
+}
+
+// InvalidateAll drops the latest versions of all the objects from the caches of all the chains, e.g. after applying
+// changesets.
+func (c SuiObjectCaches) InvalidateAll() {
+	for _, cache := range c {
+		cache.InvalidateAll()
+	}
+}
+
+// WithSuiObjectCaches returns a copy of e whose Sui chains read objects through a SuiObjectCache, and the caches.
+// The state loaded from the returned environment, and the changesets applied to it, share the caches.
+func WithSuiObjectCaches(e cldf.Environment) (cldf.Environment, SuiObjectCaches) {
+	caches := make(SuiObjectCaches)
+	chains := make(map[uint64]cldf_chain.BlockChain)
+	for selector, chain := range e.BlockChains.All() {
+		chains[selector] = chain
+	}
+	for selector, chain := range e.BlockChains.SuiChains() {
+		if cache, ok := chain.Client.(*SuiObjectCache); ok {
+			caches[selector] = cache
+			continue
+		}
+		caches[selector] = NewSuiObjectCache(chain.Client)
+		chain.Client = caches[selector]
+		chains[selector] = chain
+	}
+	e.BlockChains = cldf_chain.NewBlockChains(chains)
+	return e, caches
+}
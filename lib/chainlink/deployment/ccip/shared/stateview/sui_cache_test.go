@@ -0,0 +1,82 @@
+package stateview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/block-vision/sui-go-sdk/models"
+	"github.com/block-vision/sui-go-sdk/sui"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSuiClient returns the objects of its map, at version 1, and counts the objects read.
+type countingSuiClient struct {
+	sui.ISuiAPI
+	objects map[string]string
+	reads   map[string]int
+}
+
+func (c *countingSuiClient) read(objectID string) models.SuiObjectResponse {
+	c.reads[objectID]++
+	objectType, ok := c.objects[objectID]
+	if !ok {
+		return models.SuiObjectResponse{Error: &models.SuiObjectResponseError{Code: "notExists", ObjectId: objectID}}
+	}
+	return models.SuiObjectResponse{Data: &models.SuiObjectData{ObjectId: objectID, Version: "1", Type: objectType}}
+}
+
+func (c *countingSuiClient) SuiGetObject(_ context.Context, req models.SuiGetObjectRequest) (models.SuiObjectResponse, error) {
+	return c.read(req.ObjectId), nil
+}
+
+func (c *countingSuiClient) SuiMultiGetObjects(_ context.Context, req models.SuiMultiGetObjectsRequest) ([]*models.SuiObjectResponse, error) {
+	rsps := make([]*models.SuiObjectResponse, 0, len(req.ObjectIds))
+	for _, objectID := range req.ObjectIds {
+		rsp := c.read(objectID)
+		rsps = append(rsps, &rsp)
+	}
+	return rsps, nil
+}
+
+func TestSuiObjectCache(t *testing.T) {
+	ctx := t.Context()
+	client := &countingSuiClient{objects: map[string]string{"0x1": "a", "0x2": "b"}, reads: make(map[string]int)}
+	cache := NewSuiObjectCache(client)
+
+	for range 2 {
+		rsp, err := cache.SuiGetObject(ctx, models.SuiGetObjectRequest{ObjectId: "0x1"})
+		require.NoError(t, err)
+		require.Equal(t, "a", rsp.Data.Type)
+	}
+	require.Equal(t, 1, client.reads["0x1"])
+
+	// only the objects which are not cached are read
+	rsps, err := cache.SuiMultiGetObjects(ctx, models.SuiMultiGetObjectsRequest{ObjectIds: []string{"0x1", "0x2", "0x3"}})
+	require.NoError(t, err)
+	require.Len(t, rsps, 3)
+	require.Equal(t, "a", rsps[0].Data.Type)
+	require.Equal(t, "b", rsps[1].Data.Type)
+	require.NotNil(t, rsps[2].Error)
+	require.Equal(t, map[string]int{"0x1": 1, "0x2": 1, "0x3": 1}, client.reads)
+
+	// objects which do not exist are not cached
+	client.objects["0x3"] = "c"
+	rsp, err := cache.SuiGetObject(ctx, models.SuiGetObjectRequest{ObjectId: "0x3"})
+	require.NoError(t, err)
+	require.Equal(t, "c", rsp.Data.Type)
+	require.Equal(t, 2, client.reads["0x3"])
+
+	client.objects["0x1"] = "a2"
+	cache.Invalidate("0x1")
+	rsp, err = cache.SuiGetObject(ctx, models.SuiGetObjectRequest{ObjectId: "0x1"})
+	require.NoError(t, err)
+	require.Equal(t, "a2", rsp.Data.Type)
+	_, err = cache.SuiGetObject(ctx, models.SuiGetObjectRequest{ObjectId: "0x2"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"0x1": 2, "0x2": 1, "0x3": 2}, client.reads)
+
+	cache.InvalidateAll()
+	_, err = cache.SuiGetObject(ctx, models.SuiGetObjectRequest{ObjectId: "0x2"})
+	require.NoError(t, err)
+	require.Equal(t, 2, client.reads["0x2"])
+}
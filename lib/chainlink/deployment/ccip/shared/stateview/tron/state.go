@@ -0,0 +1,88 @@
+package tron
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+)
+
+// CCIPChainState holds the addresses of the CCIP contracts deployed on a Tron chain. The contracts are the EVM
+// contracts of CCIP 1.6, so they are called with the EVM bindings. A nil address means there is no such contract on
+// the chain.
+type CCIPChainState struct {
+	LinkToken          address.Address
+	WETH9              address.Address
+	RMNRemote          address.Address
+	RMNProxy           address.Address
+	TokenAdminRegistry address.Address
+	NonceManager       address.Address
+	Router             address.Address
+	FeeQuoter          address.Address
+	OnRamp             address.Address
+	OffRamp            address.Address
+}
+
+// LoadOnchainState loads the state of the Tron chains of the environment from the address book.
+func LoadOnchainState(e cldf.Environment) (map[uint64]CCIPChainState, error) {
+	chains := make(map[uint64]CCIPChainState)
+	for chainSelector := range e.BlockChains.TronChains() {
+		addresses, err := e.ExistingAddresses.AddressesForChain(chainSelector)
+		if err != nil {
+			// Chain not found in address book, initialize empty
+			if !errors.Is(err, cldf.ErrChainNotFound) {
+				return chains, err
+			}
+			addresses = make(map[string]cldf.TypeAndVersion)
+		}
+		chainState, err := LoadChainState(addresses)
+		if err != nil {
+			return chains, fmt.Errorf("failed to load chain %d: %w", chainSelector, err)
+		}
+		chains[chainSelector] = chainState
+	}
+	return chains, nil
+}
+
+// LoadChainState loads the state of a Tron chain from its addresses. Addresses of other contract types are ignored.
+func LoadChainState(addresses map[string]cldf.TypeAndVersion) (CCIPChainState, error) {
+	var state CCIPChainState
+	for addr, tv := range addresses {
+		var field *address.Address
+		switch tv.Type {
+		case commontypes.LinkToken:
+			field = &state.LinkToken
+		case shared.WETH9:
+			field = &state.WETH9
+		case shared.RMNRemote:
+			field = &state.RMNRemote
+		case shared.ARMProxy:
+			field = &state.RMNProxy
+		case shared.TokenAdminRegistry:
+			field = &state.TokenAdminRegistry
+		case shared.NonceManager:
+			field = &state.NonceManager
+		case shared.Router:
+			field = &state.Router
+		case shared.FeeQuoter:
+			field = &state.FeeQuoter
+		case shared.OnRamp:
+			field = &state.OnRamp
+		case shared.OffRamp:
+			field = &state.OffRamp
+		default:
+			continue
+		}
+		parsed, err := address.StringToAddress(addr)
+		if err != nil {
+			return state, fmt.Errorf("failed to parse address %s of %s: %w", addr, tv.Type, err)
+		}
+		*field = parsed
+	}
+	return state, nil
+}
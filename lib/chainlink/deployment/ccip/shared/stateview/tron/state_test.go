@@ -0,0 +1,38 @@
+package tron
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/stretchr/testify/require"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared"
+)
+
+func TestLoadChainState(t *testing.T) {
+	router := address.EVMAddressToAddress(common.HexToAddress("0x1"))
+	onRamp := address.EVMAddressToAddress(common.HexToAddress("0x2"))
+	offRamp := address.EVMAddressToAddress(common.HexToAddress("0x3"))
+
+	// addresses are parsed in base58, Tron hex and EVM hex
+	state, err := LoadChainState(map[string]cldf.TypeAndVersion{
+		router.String():                      cldf.NewTypeAndVersion(shared.Router, deployment.Version1_2_0),
+		onRamp.Hex():                         cldf.NewTypeAndVersion(shared.OnRamp, deployment.Version1_6_0),
+		offRamp.EthAddress().Hex():           cldf.NewTypeAndVersion(shared.OffRamp, deployment.Version1_6_0),
+		"TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH": cldf.NewTypeAndVersion(shared.TokenPoolLookupTable, deployment.Version1_0_0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, router, state.Router)
+	require.Equal(t, onRamp, state.OnRamp)
+	require.Equal(t, offRamp, state.OffRamp)
+	require.Nil(t, state.FeeQuoter)
+
+	_, err = LoadChainState(map[string]cldf.TypeAndVersion{
+		"invalid": cldf.NewTypeAndVersion(shared.Router, deployment.Version1_2_0),
+	})
+	require.Error(t, err)
+}
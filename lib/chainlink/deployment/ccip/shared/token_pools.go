@@ -44,6 +44,7 @@ var TokenPoolTypes = map[cldf.ContractType]struct{}{
 	HybridWithExternalMinterFastTransferTokenPool:   {},
 	BurnMintWithExternalMinterTokenPool:             {},
 	HybridWithExternalMinterTokenPool:               {},
+	SiloedLockReleaseTokenPool:                      {},
 }
 
 var TokenPoolVersions = map[semver.Version]struct{}{
@@ -0,0 +1,423 @@
+package changeset
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	sollib "github.com/gagliardetto/solana-go"
+	solrpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/google/uuid"
+
+	solCommonUtil "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/common"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/solana/provider/rpcclient"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// AuditRecord is the record of an ApplyChangesets run in the audit log.
+type AuditRecord struct {
+	RunID       string `json:"runID"`
+	Environment string `json:"environment"`
+	// User is the OS user who ran the changesets.
+	User string `json:"user"`
+	// GitSHA is the commit of the working directory the changesets were run from, empty outside a git repository.
+	GitSHA     string           `json:"gitSHA"`
+	StartedAt  time.Time        `json:"startedAt"`
+	FinishedAt time.Time        `json:"finishedAt"`
+	Err        string           `json:"error,omitempty"`
+	Changesets []AuditChangeset `json:"changesets"`
+}
+
+// AuditChangeset is the record of a changeset of a run.
+type AuditChangeset struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	// ConfigHash is the SHA-256 of the JSON encoding of the config of the changeset.
+	ConfigHash string `json:"configHash"`
+	Err        string `json:"error,omitempty"`
+	// Transactions are the transactions sent with the deployer key while applying the changeset and executing its
+	// proposals.
+	Transactions []AuditTransaction `json:"transactions,omitempty"`
+	Proposals    []AuditProposal    `json:"proposals,omitempty"`
+	// Addresses are the contracts deployed by the changeset.
+	Addresses []AuditContract `json:"addresses,omitempty"`
+}
+
+// AuditTransaction is a transaction sent by a changeset.
+type AuditTransaction struct {
+	ChainSelector uint64 `json:"chainSelector"`
+	// Hash is the hash of an EVM transaction, or the signature of a Solana transaction.
+	Hash string `json:"hash"`
+	// To is the receiver of an EVM transaction, empty for contract deployments and Solana transactions.
+	To string `json:"to,omitempty"`
+}
+
+// AuditProposal is a proposal produced by a changeset.
+type AuditProposal struct {
+	// Kind is either "timelock" or "mcms".
+	Kind        string `json:"kind"`
+	Action      string `json:"action,omitempty"`
+	Description string `json:"description"`
+	// Digest is the SHA-256 of the JSON encoding of the proposal.
+	Digest    string          `json:"digest"`
+	Contracts []AuditContract `json:"contracts"`
+}
+
+// AuditContract is a contract deployed or called by a changeset.
+type AuditContract struct {
+	ChainSelector uint64 `json:"chainSelector"`
+	Address       string `json:"address"`
+	Type          string `json:"type,omitempty"`
+}
+
+// AuditFilter selects the changesets of the audit history. Zero fields match any value.
+type AuditFilter struct {
+	ChainSelector uint64
+	// Address is the address of a contract deployed or called by the changesets, compared case-insensitively.
+	Address string
+}
+
+// AuditLog is an append-only log of the ApplyChangesets runs, stored as a file with one JSON record per line.
+type AuditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAuditLog creates the audit log stored in the file, which is created on the first record.
+func NewAuditLog(path string) (*AuditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	return &AuditLog{path: path}, nil
+}
+
+// Append appends the record to the log.
+func (l *AuditLog) Append(record AuditRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", l.path, err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return errors.Join(fmt.Errorf("failed to write audit log %s: %w", l.path, err), f.Close())
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Join(fmt.Errorf("failed to sync audit log %s: %w", l.path, err), f.Close())
+	}
+	return f.Close()
+}
+
+// Records returns all the records of the log, oldest first.
+func (l *AuditLog) Records() ([]AuditRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", l.path, err)
+	}
+	defer f.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("failed to decode line %d of audit log %s: %w", line, l.path, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", l.path, err)
+	}
+	return records, nil
+}
+
+// History returns the records of the runs with changesets matching the filter, oldest first. The changesets of the
+// returned records are restricted to the matching ones.
+func (l *AuditLog) History(filter AuditFilter) ([]AuditRecord, error) {
+	records, err := l.Records()
+	if err != nil {
+		return nil, err
+	}
+	var history []AuditRecord
+	for _, record := range records {
+		var changesets []AuditChangeset
+		for _, cs := range record.Changesets {
+			if filter.matches(cs) {
+				changesets = append(changesets, cs)
+			}
+		}
+		if len(changesets) > 0 {
+			record.Changesets = changesets
+			history = append(history, record)
+		}
+	}
+	return history, nil
+}
+
+func (f AuditFilter) matches(cs AuditChangeset) bool {
+	if f.ChainSelector == 0 && f.Address == "" {
+		return true
+	}
+	contracts := slices.Clone(cs.Addresses)
+	for _, tx := range cs.Transactions {
+		contracts = append(contracts, AuditContract{ChainSelector: tx.ChainSelector, Address: tx.To})
+	}
+	for _, proposal := range cs.Proposals {
+		contracts = append(contracts, proposal.Contracts...)
+	}
+	return slices.ContainsFunc(contracts, func(c AuditContract) bool {
+		return (f.ChainSelector == 0 || c.ChainSelector == f.ChainSelector) &&
+			(f.Address == "" || strings.EqualFold(c.Address, f.Address))
+	})
+}
+
+// WithAuditLog appends the record of the run to the audit log: who ran it and from which commit, and for each
+// changeset the hash of its config, the EVM and Solana transactions sent with the deployer keys, the proposals it
+// produced and the contracts it deployed. The record is appended whether the run succeeds or not.
+func WithAuditLog(log *AuditLog) ApplyChangesetsOptions {
+	return func(o *applyChangesetOptions) *applyChangesetOptions {
+		o.auditLog = log
+		return o
+	}
+}
+
+// auditChangesets applies the changesets to an environment recording the transactions sent, and appends the record
+// of the run to the audit log.
+func auditChangesets(t *testing.T, e cldf.Environment, changesetApplications []ConfiguredChangeSet, opt applyChangesetOptions) (cldf.Environment, []cldf.ChangesetOutput, error) {
+	run := newAuditRun(e)
+	opt.audit = run
+	env, outputs, err := applyChangesets(t, run.environment(e), changesetApplications, opt)
+	if appendErr := opt.auditLog.Append(run.finish(err)); appendErr != nil {
+		return e, nil, errors.Join(err, fmt.Errorf("failed to append audit record: %w", appendErr))
+	}
+	if err != nil {
+		return e, nil, err
+	}
+	env.BlockChains = e.BlockChains
+	return env, outputs, nil
+}
+
+// auditRun records a run, attributing the transactions sent to the changeset being applied.
+type auditRun struct {
+	mu     sync.Mutex
+	record AuditRecord
+}
+
+func newAuditRun(e cldf.Environment) *auditRun {
+	return &auditRun{record: AuditRecord{
+		RunID:       uuid.NewString(),
+		Environment: e.Name,
+		User:        auditUser(),
+		GitSHA:      auditGitSHA(),
+		StartedAt:   time.Now().UTC(),
+	}}
+}
+
+func (r *auditRun) begin(index int, csa ConfiguredChangeSet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cs := AuditChangeset{Index: index, Name: changesetName(csa)}
+	if hashed, ok := csa.(interface{ configHash() (string, error) }); ok {
+		hash, err := hashed.configHash()
+		if err != nil {
+			hash = fmt.Sprintf("unavailable: %v", err)
+		}
+		cs.ConfigHash = hash
+	}
+	r.record.Changesets = append(r.record.Changesets, cs)
+}
+
+func (r *auditRun) applied(out cldf.ChangesetOutput, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cs := &r.record.Changesets[len(r.record.Changesets)-1]
+	if err != nil {
+		cs.Err = err.Error()
+	}
+	for _, proposal := range out.MCMSTimelockProposals {
+		p := AuditProposal{Kind: "timelock", Action: string(proposal.Action), Description: proposal.Description, Digest: auditDigest(proposal)}
+		for _, op := range proposal.Operations {
+			for _, tx := range op.Transactions {
+				p.Contracts = append(p.Contracts, AuditContract{ChainSelector: uint64(op.ChainSelector), Address: tx.To, Type: tx.ContractType})
+			}
+		}
+		cs.Proposals = append(cs.Proposals, p)
+	}
+	for _, proposal := range out.MCMSProposals {
+		p := AuditProposal{Kind: "mcms", Description: proposal.Description, Digest: auditDigest(proposal)}
+		for _, op := range proposal.Operations {
+			p.Contracts = append(p.Contracts, AuditContract{ChainSelector: uint64(op.ChainSelector), Address: op.Transaction.To, Type: op.Transaction.ContractType})
+		}
+		cs.Proposals = append(cs.Proposals, p)
+	}
+	if out.AddressBook != nil {
+		addresses, err := out.AddressBook.Addresses()
+		if err == nil {
+			for _, selector := range sortedKeys(addresses) {
+				for _, addr := range sortedKeys(addresses[selector]) {
+					cs.Addresses = append(cs.Addresses, AuditContract{ChainSelector: selector, Address: addr, Type: addresses[selector][addr].String()})
+				}
+			}
+		}
+	}
+	if out.DataStore != nil {
+		refs, err := out.DataStore.Addresses().Fetch()
+		if err == nil {
+			for _, ref := range refs {
+				if !slices.ContainsFunc(cs.Addresses, func(c AuditContract) bool {
+					return c.ChainSelector == ref.ChainSelector && strings.EqualFold(c.Address, ref.Address)
+				}) {
+					cs.Addresses = append(cs.Addresses, AuditContract{ChainSelector: ref.ChainSelector, Address: ref.Address, Type: string(ref.Type)})
+				}
+			}
+		}
+	}
+}
+
+func (r *auditRun) recordTransaction(tx AuditTransaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.record.Changesets) == 0 {
+		return
+	}
+	cs := &r.record.Changesets[len(r.record.Changesets)-1]
+	cs.Transactions = append(cs.Transactions, tx)
+}
+
+func (r *auditRun) finish(err error) AuditRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record.FinishedAt = time.Now().UTC()
+	if err != nil {
+		r.record.Err = err.Error()
+	}
+	return r.record
+}
+
+// auditEVMClient records the transactions it sends.
+type auditEVMClient struct {
+	cldf_evm.OnchainClient
+	selector uint64
+	run      *auditRun
+}
+
+func (c auditEVMClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := c.OnchainClient.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	record := AuditTransaction{ChainSelector: c.selector, Hash: tx.Hash().Hex()}
+	if tx.To() != nil {
+		record.To = tx.To().Hex()
+	}
+	c.run.recordTransaction(record)
+	return nil
+}
+
+// environment returns a copy of the environment whose EVM and Solana transactions are recorded in the run. The
+// signature of a Solana transaction is not returned by the chain, it is read back as the latest signature of the
+// deployer once the transaction is confirmed.
+func (r *auditRun) environment(e cldf.Environment) cldf.Environment {
+	chains := make(map[uint64]chain.BlockChain)
+	for selector, c := range e.BlockChains.All() {
+		chains[selector] = c
+	}
+	for selector, evmChain := range e.BlockChains.EVMChains() {
+		evmChain.Client = auditEVMClient{OnchainClient: evmChain.Client, selector: selector, run: r}
+		chains[selector] = evmChain
+	}
+	for selector, solChain := range e.BlockChains.SolanaChains() {
+		confirm, sendAndConfirm := solChain.Confirm, solChain.SendAndConfirm
+		recordLatest := func(ctx context.Context) {
+			if solChain.Client == nil || solChain.DeployerKey == nil {
+				return
+			}
+			limit := 1
+			sigs, err := solChain.Client.GetSignaturesForAddressWithOpts(ctx, solChain.DeployerKey.PublicKey(), &solrpc.GetSignaturesForAddressOpts{
+				Limit:      &limit,
+				Commitment: solrpc.CommitmentConfirmed,
+			})
+			if err != nil || len(sigs) == 0 {
+				e.Logger.Warnw("Failed to read signature of audited Solana transaction", "chain", selector, "err", err)
+				return
+			}
+			r.recordTransaction(AuditTransaction{ChainSelector: selector, Hash: sigs[0].Signature.String()})
+		}
+		if confirm != nil {
+			solChain.Confirm = func(instructions []sollib.Instruction, opts ...solCommonUtil.TxModifier) error {
+				if err := confirm(instructions, opts...); err != nil {
+					return err
+				}
+				recordLatest(e.GetContext())
+				return nil
+			}
+		}
+		if sendAndConfirm != nil {
+			solChain.SendAndConfirm = func(ctx context.Context, instructions []sollib.Instruction, opts ...rpcclient.TxModifier) error {
+				if err := sendAndConfirm(ctx, instructions, opts...); err != nil {
+					return err
+				}
+				recordLatest(ctx)
+				return nil
+			}
+		}
+		chains[selector] = solChain
+	}
+	e.BlockChains = chain.NewBlockChains(chains)
+	return e
+}
+
+func auditUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+func auditGitSHA() string {
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func auditDigest(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return sha256Hex(data)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,202 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// ValidateConfig validates a changeset config with the `validate` tags of its fields, and returns the joined errors
+// naming each invalid field with its path from the config, e.g. "Config.Lanes[0].Chains[1].Selector", and the
+// expected format. Nested structs, slices, arrays, maps and pointers are walked. The comma-separated rules are:
+//
+//   - required: the field is not the zero value, and not empty for strings, slices and maps.
+//   - hexaddress: the string is a 0x-prefixed hex EVM address.
+//   - chainselector: the chain selector is known, and with a family, e.g. chainselector=solana, of a chain of the
+//     family.
+//
+// The hexaddress and chainselector rules apply to the elements of slices and arrays and to the keys of maps, and skip
+// zero values: combine them with required for mandatory fields.
+func ValidateConfig(config any) error {
+	v := reflect.ValueOf(config)
+	if !v.IsValid() {
+		return nil
+	}
+	t := v.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	w := configWalker{seen: make(map[uintptr]bool)}
+	w.validateValue(v, t.Name())
+	return errors.Join(w.errs...)
+}
+
+// WithConfigValidation returns the changeset validating its config with ValidateConfig before its preconditions.
+// ApplyChangesets validates the configs of the configured changesets without it.
+func WithConfigValidation[C any](changeset cldf.ChangeSetV2[C]) cldf.ChangeSetV2[C] {
+	return cldf.CreateChangeSet(
+		func(e cldf.Environment, config C) (cldf.ChangesetOutput, error) {
+			return changeset.Apply(e, config)
+		},
+		func(e cldf.Environment, config C) error {
+			if err := ValidateConfig(config); err != nil {
+				return fmt.Errorf("%w: %w", err, cldf.ErrInvalidConfig)
+			}
+			return changeset.VerifyPreconditions(e, config)
+		},
+	)
+}
+
+// configWalker walks a config, the pointers already seen are not walked again.
+type configWalker struct {
+	seen map[uintptr]bool
+	errs []error
+}
+
+func (w *configWalker) validateValue(v reflect.Value, path string) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Pointer {
+			if w.seen[v.Pointer()] {
+				return
+			}
+			w.seen[v.Pointer()] = true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := path + "." + field.Name
+			if tag := field.Tag.Get("validate"); tag != "" && tag != "-" {
+				if err := validateField(v.Field(i), tag); err != nil {
+					w.errs = append(w.errs, fmt.Errorf("%s: %w", fieldPath, err))
+					continue
+				}
+			}
+			w.validateValue(v.Field(i), fieldPath)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := range v.Len() {
+			w.validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		for _, key := range keys {
+			w.validateValue(v.MapIndex(key), fmt.Sprintf("%s[%v]", path, key.Interface()))
+		}
+	default:
+	}
+}
+
+// validateField checks the rules of the tag, and returns the error of the first rule which fails.
+func validateField(v reflect.Value, tag string) error {
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		var check func(reflect.Value) error
+		switch name {
+		case "required":
+			if isEmpty(v) {
+				return errors.New("is required")
+			}
+			continue
+		case "hexaddress":
+			check = checkHexAddress
+		case "chainselector":
+			check = func(v reflect.Value) error { return checkChainSelector(v, param) }
+		default:
+			return fmt.Errorf("unknown validation rule %q", name)
+		}
+		if err := eachValue(v, check); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// eachValue calls check with the value, with the elements of a slice or array, or with the keys of a map.
+func eachValue(v reflect.Value, check func(reflect.Value) error) error {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := eachValue(v.Index(i), check); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if err := eachValue(key, check); err != nil {
+				return fmt.Errorf("key %v: %w", key.Interface(), err)
+			}
+		}
+		return nil
+	default:
+		if v.IsZero() {
+			return nil
+		}
+		return check(v)
+	}
+}
+
+func checkHexAddress(v reflect.Value) error {
+	if v.Kind() != reflect.String {
+		return fmt.Errorf("hexaddress applies to strings, not %s", v.Type())
+	}
+	s := v.String()
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != 20 {
+		return fmt.Errorf("expected a 0x-prefixed hex address of 20 bytes, e.g. 0x52908400098527886E0F7030069857D2E4169EE7, got %q", s)
+	}
+	return nil
+}
+
+func checkChainSelector(v reflect.Value, family string) error {
+	if !v.CanUint() {
+		return fmt.Errorf("chainselector applies to unsigned integers, not %s", v.Type())
+	}
+	selector := v.Uint()
+	got, err := chain_selectors.GetSelectorFamily(selector)
+	if err != nil {
+		return fmt.Errorf("expected a chain selector, got unknown selector %d", selector)
+	}
+	if family != "" && got != family {
+		return fmt.Errorf("expected the selector of a %s chain, got %d of a %s chain", family, selector, got)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+type testLaneConfig struct {
+	Selector uint64 `validate:"required,chainselector=evm"`
+	Router   string `validate:"hexaddress"`
+}
+
+type testValidatedConfig struct {
+	Owner  string                    `validate:"required,hexaddress"`
+	Solana uint64                    `validate:"chainselector=solana"`
+	Lanes  []testLaneConfig          `validate:"required"`
+	Remote map[uint64]testLaneConfig `validate:"chainselector"`
+}
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+	evm := chain_selectors.ETHEREUM_TESTNET_SEPOLIA.Selector
+	solana := chain_selectors.SOLANA_DEVNET.Selector
+
+	valid := testValidatedConfig{
+		Owner:  "0x52908400098527886E0F7030069857D2E4169EE7",
+		Solana: solana,
+		Lanes:  []testLaneConfig{{Selector: evm}},
+		Remote: map[uint64]testLaneConfig{evm: {Selector: evm, Router: "0x52908400098527886E0F7030069857D2E4169EE7"}},
+	}
+	require.NoError(t, ValidateConfig(valid))
+	require.NoError(t, ValidateConfig(&valid))
+	require.NoError(t, ValidateConfig(uint32(1)))
+
+	invalid := testValidatedConfig{
+		Owner:  "52908400098527886E0F7030069857D2E4169EE7",
+		Solana: evm,
+		Lanes:  []testLaneConfig{{Selector: evm}, {Router: "0x1234"}},
+		Remote: map[uint64]testLaneConfig{1: {Selector: solana}},
+	}
+	err := ValidateConfig(&invalid)
+	require.ErrorContains(t, err, `testValidatedConfig.Owner: expected a 0x-prefixed hex address of 20 bytes`)
+	require.ErrorContains(t, err, `testValidatedConfig.Solana: expected the selector of a solana chain`)
+	require.ErrorContains(t, err, "testValidatedConfig.Lanes[1].Selector: is required")
+	require.ErrorContains(t, err, `testValidatedConfig.Lanes[1].Router: expected a 0x-prefixed hex address of 20 bytes, e.g. 0x52908400098527886E0F7030069857D2E4169EE7, got "0x1234"`)
+	require.ErrorContains(t, err, "testValidatedConfig.Remote: key 1: expected a chain selector, got unknown selector 1")
+
+	// the configs are validated before the preconditions of the changesets
+	validated := false
+	csv2 := cldf.CreateChangeSet(
+		func(e cldf.Environment, config testValidatedConfig) (cldf.ChangesetOutput, error) {
+			return cldf.ChangesetOutput{}, nil
+		},
+		func(e cldf.Environment, config testValidatedConfig) error {
+			validated = true
+			return nil
+		},
+	)
+	e := NewNoopEnvironment(t)
+	_, _, err = ApplyChangesets(t, e, []ConfiguredChangeSet{Configure(csv2, testValidatedConfig{})})
+	require.ErrorIs(t, err, cldf.ErrInvalidConfig)
+	require.ErrorContains(t, err, "testValidatedConfig.Owner: is required")
+	require.False(t, validated)
+	require.ErrorIs(t, WithConfigValidation(csv2).VerifyPreconditions(e, invalid), cldf.ErrInvalidConfig)
+	require.NoError(t, WithConfigValidation(csv2).VerifyPreconditions(e, valid))
+	require.True(t, validated)
+}
@@ -0,0 +1,140 @@
+package changeset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+// FileReporter is an operations.Reporter which persists the reports of a changeset run to a JSON file, after each
+// report. As the operations framework skips the operations with a previous successful report for the same input, a
+// changeset run which failed halfway can be resumed from the reports of the file without re-running its successful
+// operations.
+type FileReporter struct {
+	*operations.MemoryReporter
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileReporter creates the reporter of the changeset run runID, whose reports are stored in dir. The reports of a
+// previous run with the same ID are loaded if resume is set, otherwise they are discarded.
+func NewFileReporter(dir, runID string, resume bool) (*FileReporter, error) {
+	if runID == "" || filepath.Base(runID) != runID {
+		return nil, fmt.Errorf("invalid run ID %q", runID)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create reports directory %s: %w", dir, err)
+	}
+	r := &FileReporter{path: filepath.Join(dir, runID+".json")}
+
+	var reports []operations.Report[any, any]
+	if resume {
+		data, err := os.ReadFile(r.path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			// nothing to resume from, the run starts from scratch
+		case err != nil:
+			return nil, fmt.Errorf("failed to read reports of run %s: %w", runID, err)
+		default:
+			if err := json.Unmarshal(data, &reports); err != nil {
+				return nil, fmt.Errorf("failed to decode reports of run %s: %w", runID, err)
+			}
+		}
+	}
+	r.MemoryReporter = operations.NewMemoryReporter(operations.WithReports(reports))
+	if err := r.persist(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Path returns the path of the file of the reports.
+func (r *FileReporter) Path() string {
+	return r.path
+}
+
+// AddReport adds the report and persists all the reports of the run.
+func (r *FileReporter) AddReport(report operations.Report[any, any]) error {
+	if err := r.MemoryReporter.AddReport(report); err != nil {
+		return err
+	}
+	return r.persist()
+}
+
+// persist writes the reports to a temporary file which replaces the file of the reports, so that a crash while
+// writing does not corrupt the reports of the previous operations.
+func (r *FileReporter) persist() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reports, err := r.GetReports()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode reports: %w", err)
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write reports to %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, r.path); err != nil {
+		return fmt.Errorf("failed to write reports to %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// WithOperationsReporter returns a copy of the environment whose operations bundle uses the reporter.
+func WithOperationsReporter(env deployment.Environment, reporter operations.Reporter) deployment.Environment {
+	var opts []operations.BundleOption
+	if env.OperationsBundle.OperationRegistry != nil {
+		opts = append(opts, operations.WithOperationRegistry(env.OperationsBundle.OperationRegistry))
+	}
+	env.OperationsBundle = operations.NewBundle(env.GetContext, env.Logger, reporter, opts...)
+	return env
+}
+
+// ResumableRunConfig configures RunResumableChangeset.
+type ResumableRunConfig struct {
+	// ReportsDir is the directory of the reports of the changeset runs.
+	ReportsDir string
+	// RunID identifies the run, and names the file of its reports.
+	RunID string
+	// Resume resumes a previous run with the same ID: the operations which succeeded with the same input are skipped.
+	// A new run discards the reports of a previous run with the same ID.
+	Resume bool
+}
+
+// RunResumableChangeset runs a changeset like RunChangeset, with the reports of its operations persisted to disk. If
+// the run fails halfway, it can be run again with Resume set to skip the operations which already succeeded.
+func RunResumableChangeset[C any](
+	operation deployment.ChangeSetV2[C],
+	env deployment.Environment,
+	config C,
+	runCfg ResumableRunConfig,
+) (deployment.ChangesetOutput, error) {
+	reporter, err := NewFileReporter(runCfg.ReportsDir, runCfg.RunID, runCfg.Resume)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to create reporter of run %s: %w", runCfg.RunID, err)
+	}
+	if runCfg.Resume {
+		reports, err := reporter.GetReports()
+		if err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+		env.Logger.Infow("Resuming changeset run", "runID", runCfg.RunID, "previousReports", len(reports))
+	}
+
+	out, err := RunChangeset(operation, WithOperationsReporter(env, reporter), config)
+	if err != nil {
+		return out, fmt.Errorf("changeset run %s failed, resume it with the reports of %s: %w", runCfg.RunID, reporter.Path(), err)
+	}
+	return out, nil
+}
@@ -0,0 +1,77 @@
+package changeset
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/require"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+func TestRunResumableChangeset(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+	dir := t.TempDir()
+
+	executions := make(map[int]int)
+	failing := map[int]bool{2: true}
+	op := operations.NewOperation("test-step", semver.MustParse("1.0.0"), "Test step",
+		func(b operations.Bundle, _ any, step int) (int, error) {
+			executions[step]++
+			if failing[step] {
+				return 0, errors.New("step failed")
+			}
+			return step * 10, nil
+		})
+	cs := cldf.CreateChangeSet(
+		func(e cldf.Environment, steps []int) (cldf.ChangesetOutput, error) {
+			out := cldf.ChangesetOutput{}
+			for _, step := range steps {
+				report, err := operations.ExecuteOperation(e.OperationsBundle, op, nil, step)
+				if err != nil {
+					return out, err
+				}
+				out.Reports = append(out.Reports, report.ToGenericReport())
+			}
+			return out, nil
+		},
+		func(e cldf.Environment, steps []int) error {
+			return nil
+		},
+	)
+	runCfg := ResumableRunConfig{ReportsDir: dir, RunID: "run-1"}
+
+	// the run fails on the second step, after the first one succeeded
+	_, err := RunResumableChangeset(cs, e, []int{1, 2, 3}, runCfg)
+	require.ErrorContains(t, err, "step failed")
+	require.Equal(t, map[int]int{1: 1, 2: 1}, executions)
+	_, err = os.Stat(dir + "/run-1.json")
+	require.NoError(t, err)
+
+	// the resumed run skips the first step
+	failing[2] = false
+	runCfg.Resume = true
+	out, err := RunResumableChangeset(cs, e, []int{1, 2, 3}, runCfg)
+	require.NoError(t, err)
+	require.Equal(t, map[int]int{1: 1, 2: 2, 3: 1}, executions)
+	require.Len(t, out.Reports, 3)
+	require.Equal(t, 10, out.Reports[0].Output)
+
+	// a step whose input changed is executed again
+	_, err = RunResumableChangeset(cs, e, []int{1, 4}, runCfg)
+	require.NoError(t, err)
+	require.Equal(t, map[int]int{1: 1, 2: 2, 3: 1, 4: 1}, executions)
+
+	// a new run with the same ID starts from scratch
+	runCfg.Resume = false
+	_, err = RunResumableChangeset(cs, e, []int{1}, runCfg)
+	require.NoError(t, err)
+	require.Equal(t, 2, executions[1])
+
+	_, err = NewFileReporter(dir, "../run-1", false)
+	require.Error(t, err)
+}
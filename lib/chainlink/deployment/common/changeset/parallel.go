@@ -0,0 +1,125 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+// ConfigureForChains configures a changeset like Configure, with the selectors of the chains it changes, so that it can
+// be applied concurrently with the changesets changing other chains, see WithParallelChains.
+func ConfigureForChains[C any](
+	changeset cldf.ChangeSetV2[C],
+	config C,
+	chainSelectors ...uint64,
+) ConfiguredChangeSet {
+	return configuredChangeSetImpl[C]{
+		changeset: changeset,
+		config:    config,
+		chains:    chainSelectors,
+	}
+}
+
+// ConfigurePerChain configures a changeset once per chain with the config of the chain, ordered by chain selector.
+// The changesets can be applied concurrently, see WithParallelChains.
+func ConfigurePerChain[C any](changeset cldf.ChangeSetV2[C], configs map[uint64]C) []ConfiguredChangeSet {
+	changesets := make([]ConfiguredChangeSet, 0, len(configs))
+	for _, selector := range sortedKeys(configs) {
+		changesets = append(changesets, ConfigureForChains(changeset, configs[selector], selector))
+	}
+	return changesets
+}
+
+func (ca configuredChangeSetImpl[C]) chainSelectors() []uint64 {
+	return ca.chains
+}
+
+// WithParallelChains applies concurrently the consecutive changesets which change disjoint chains, as configured with
+// ConfigureForChains or ConfigurePerChain. The changesets without chains are applied sequentially. The changesets
+// applied concurrently are applied to the same environment, so they do not see the addresses recorded by each other,
+// and their proposals are executed after all of them are applied, in order. A failed changeset does not stop the
+// others: the errors of all the failed changesets are returned. It has no effect with WithAuditLog, since the
+// transactions could not be attributed to the changesets.
+func WithParallelChains() ApplyChangesetsOptions {
+	return func(o *applyChangesetOptions) *applyChangesetOptions {
+		o.parallelChains = true
+		return o
+	}
+}
+
+func changesetChains(csa ConfiguredChangeSet) []uint64 {
+	if scoped, ok := csa.(interface{ chainSelectors() []uint64 }); ok {
+		return scoped.chainSelectors()
+	}
+	return nil
+}
+
+// parallelGroups returns the groups of consecutive changesets changing disjoint chains, by index of their first
+// changeset. Only the groups with more than one changeset are returned.
+func parallelGroups(changesetApplications []ConfiguredChangeSet) map[int][]int {
+	groups := make(map[int][]int)
+	var group []int
+	chains := make(map[uint64]bool)
+	closeGroup := func() {
+		if len(group) > 1 {
+			groups[group[0]] = group
+		}
+		group = nil
+		clear(chains)
+	}
+	for i, csa := range changesetApplications {
+		selectors := changesetChains(csa)
+		if len(selectors) == 0 {
+			closeGroup()
+			continue
+		}
+		if slices.ContainsFunc(selectors, func(selector uint64) bool { return chains[selector] }) {
+			closeGroup()
+		}
+		group = append(group, i)
+		for _, selector := range selectors {
+			chains[selector] = true
+		}
+	}
+	closeGroup()
+	return groups
+}
+
+// applyConcurrently applies the changesets of the group concurrently, each with its own operations bundle, and returns
+// their outputs by index with the joined errors of the failed changesets.
+func applyConcurrently(e cldf.Environment, changesetApplications []ConfiguredChangeSet, group []int) (map[int]cldf.ChangesetOutput, error) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		outputs = make(map[int]cldf.ChangesetOutput, len(group))
+		errs    = make([]error, len(group))
+	)
+	for j, i := range group {
+		csa := changesetApplications[i]
+		env := e
+		env.OperationsBundle = operations.NewBundle(e.GetContext, e.Logger, operations.NewMemoryReporter())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[j] = fmt.Errorf("changeset at index %d on chains %v panicked: %v", i, changesetChains(csa), r)
+				}
+			}()
+			out, err := csa.Apply(env)
+			if err != nil {
+				errs[j] = fmt.Errorf("failed to apply changeset at index %d on chains %v: %w", i, changesetChains(csa), err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			outputs[i] = out
+		}()
+	}
+	wg.Wait()
+	return outputs, errors.Join(errs...)
+}
@@ -0,0 +1,280 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// PipelineOutputs are the outputs of the steps of a pipeline, by step name.
+type PipelineOutputs map[string]cldf.ChangesetOutput
+
+// PipelineStep is a changeset of a Pipeline, whose config is built from the outputs of the steps it depends on.
+type PipelineStep struct {
+	Name      string
+	DependsOn []string
+	// Changeset returns the configured changeset of the step. The outputs of the steps it depends on are available.
+	Changeset func(outputs PipelineOutputs) (ConfiguredChangeSet, error)
+}
+
+// Step returns a pipeline step applying the changeset with the config built from the outputs of its dependencies.
+func Step[C any](name string, changeset cldf.ChangeSetV2[C], config func(outputs PipelineOutputs) (C, error), dependsOn ...string) PipelineStep {
+	return PipelineStep{
+		Name:      name,
+		DependsOn: dependsOn,
+		Changeset: func(outputs PipelineOutputs) (ConfiguredChangeSet, error) {
+			cfg, err := config(outputs)
+			if err != nil {
+				return nil, err
+			}
+			return Configure(changeset, cfg), nil
+		},
+	}
+}
+
+// StepWithRollback returns a pipeline step like Step, which is rolled back with the changeset built by rollback.
+func StepWithRollback[C any](name string, changeset cldf.ChangeSetV2[C], config func(outputs PipelineOutputs) (C, error), rollback RollbackFunc[C], dependsOn ...string) PipelineStep {
+	return PipelineStep{
+		Name:      name,
+		DependsOn: dependsOn,
+		Changeset: func(outputs PipelineOutputs) (ConfiguredChangeSet, error) {
+			cfg, err := config(outputs)
+			if err != nil {
+				return nil, err
+			}
+			return ConfigureWithRollback(changeset, cfg, rollback), nil
+		},
+	}
+}
+
+// StepWithSnapshotRollback returns a pipeline step like StepWithRollback, which is rolled back with the changeset
+// restoring the state read by snapshot before the step is applied, see ConfigureWithSnapshotRollback.
+func StepWithSnapshotRollback[C, S any](name string, changeset cldf.ChangeSetV2[C], config func(outputs PipelineOutputs) (C, error), snapshot SnapshotFunc[C, S], rollback SnapshotRollbackFunc[C, S], dependsOn ...string) PipelineStep {
+	return PipelineStep{
+		Name:      name,
+		DependsOn: dependsOn,
+		Changeset: func(outputs PipelineOutputs) (ConfiguredChangeSet, error) {
+			cfg, err := config(outputs)
+			if err != nil {
+				return nil, err
+			}
+			return ConfigureWithSnapshotRollback(changeset, cfg, snapshot, rollback), nil
+		},
+	}
+}
+
+// StaticStep returns a pipeline step applying a changeset whose config does not depend on other steps.
+func StaticStep(name string, changeset ConfiguredChangeSet, dependsOn ...string) PipelineStep {
+	return PipelineStep{
+		Name:      name,
+		DependsOn: dependsOn,
+		Changeset: func(PipelineOutputs) (ConfiguredChangeSet, error) {
+			return changeset, nil
+		},
+	}
+}
+
+// ReportOutput returns the output of the first report of the step whose output has the type T, e.g. the output of
+// the deployment operation of a changeset.
+func ReportOutput[T any](outputs PipelineOutputs, step string) (T, error) {
+	var zero T
+	out, ok := outputs[step]
+	if !ok {
+		return zero, fmt.Errorf("no output for step %s", step)
+	}
+	for _, report := range out.Reports {
+		if output, ok := report.Output.(T); ok {
+			return output, nil
+		}
+	}
+	return zero, fmt.Errorf("no report of step %s has an output of type %T", step, zero)
+}
+
+// Pipeline is a set of changesets with explicit dependencies on the outputs of each other. The steps are applied in
+// the order of their dependencies, and the outputs of a step are wired to the configs of the steps depending on it.
+type Pipeline struct {
+	steps map[string]PipelineStep
+	order []string
+}
+
+// NewPipeline validates the dependencies of the steps and orders them. Steps without dependencies between them keep
+// their relative order.
+func NewPipeline(steps ...PipelineStep) (*Pipeline, error) {
+	p := &Pipeline{steps: make(map[string]PipelineStep, len(steps))}
+	for _, step := range steps {
+		if step.Name == "" {
+			return nil, errors.New("pipeline step without name")
+		}
+		if step.Changeset == nil {
+			return nil, fmt.Errorf("pipeline step %s has no changeset", step.Name)
+		}
+		if _, ok := p.steps[step.Name]; ok {
+			return nil, fmt.Errorf("duplicate pipeline step %s", step.Name)
+		}
+		p.steps[step.Name] = step
+	}
+	for _, step := range steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := p.steps[dep]; !ok {
+				return nil, fmt.Errorf("pipeline step %s depends on unknown step %s", step.Name, dep)
+			}
+		}
+	}
+
+	// depth-first topological sort, following the declaration order of the steps
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	marks := make(map[string]int, len(steps))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch marks[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("pipeline has a dependency cycle: %v", append(path, name))
+		}
+		marks[name] = visiting
+		for _, dep := range p.steps[name].DependsOn {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		p.order = append(p.order, name)
+		return nil
+	}
+	for _, step := range steps {
+		if err := visit(step.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Order returns the names of the steps in the order they are applied.
+func (p *Pipeline) Order() []string {
+	return slices.Clone(p.order)
+}
+
+type pipelineRunOptions struct {
+	completed         PipelineOutputs
+	applyOpts         []ApplyChangesetsOptions
+	rollbackOnFailure bool
+}
+
+type PipelineRunOption func(*pipelineRunOptions)
+
+// WithCompletedSteps resumes a pipeline run from the outputs of a previous run: the steps with an output are not
+// applied again, and their outputs are wired to the remaining steps.
+func WithCompletedSteps(outputs PipelineOutputs) PipelineRunOption {
+	return func(o *pipelineRunOptions) {
+		o.completed = outputs
+	}
+}
+
+// WithApplyOptions sets the options of ApplyChangesets used to apply each step.
+func WithApplyOptions(opts ...ApplyChangesetsOptions) PipelineRunOption {
+	return func(o *pipelineRunOptions) {
+		o.applyOpts = opts
+	}
+}
+
+// WithRollbackOnFailure rolls back the steps applied by the run, in reverse order, when a step fails. The steps
+// completed by a previous run are not rolled back.
+func WithRollbackOnFailure() PipelineRunOption {
+	return func(o *pipelineRunOptions) {
+		o.rollbackOnFailure = true
+	}
+}
+
+// Run applies the steps of the pipeline in order, and returns the updated environment with the outputs of the steps.
+// If a step fails, the outputs of the steps applied so far are returned with the environment updated by them, so
+// that the run can be resumed with WithCompletedSteps. With WithRollbackOnFailure, the steps applied by the run are
+// rolled back instead, and their outputs are removed.
+func (p *Pipeline) Run(t *testing.T, e cldf.Environment, opts ...PipelineRunOption) (cldf.Environment, PipelineOutputs, error) {
+	var o pipelineRunOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	outputs := make(PipelineOutputs, len(p.order))
+	for name, out := range o.completed {
+		if _, ok := p.steps[name]; ok {
+			outputs[name] = out
+		}
+	}
+
+	var applied []string
+	for _, name := range p.order {
+		if _, ok := outputs[name]; ok {
+			e.Logger.Infow("Skipping completed pipeline step", "step", name)
+			continue
+		}
+		cs, err := p.steps[name].Changeset(outputs)
+		if err != nil {
+			err = fmt.Errorf("failed to configure pipeline step %s: %w", name, err)
+			if o.rollbackOnFailure {
+				return p.rollbackRun(t, e, outputs, applied, err, o.applyOpts)
+			}
+			return e, outputs, err
+		}
+		env, out, err := ApplyChangesets(t, e, []ConfiguredChangeSet{cs}, o.applyOpts...)
+		if err != nil {
+			err = fmt.Errorf("failed to apply pipeline step %s: %w", name, err)
+			if o.rollbackOnFailure {
+				return p.rollbackRun(t, e, outputs, applied, err, o.applyOpts)
+			}
+			return e, outputs, err
+		}
+		e = env
+		outputs[name] = out[0]
+		applied = append(applied, name)
+	}
+	return e, outputs, nil
+}
+
+// Rollback rolls back the steps with an output, in the reverse order of the pipeline, and returns the updated
+// environment with the outputs of the steps which were not rolled back.
+func (p *Pipeline) Rollback(t *testing.T, e cldf.Environment, outputs PipelineOutputs, opts ...ApplyChangesetsOptions) (cldf.Environment, PipelineOutputs, error) {
+	var steps []string
+	for _, name := range p.order {
+		if _, ok := outputs[name]; ok {
+			steps = append(steps, name)
+		}
+	}
+	return p.rollbackSteps(t, e, outputs, steps, opts)
+}
+
+func (p *Pipeline) rollbackRun(t *testing.T, e cldf.Environment, outputs PipelineOutputs, applied []string, runErr error, opts []ApplyChangesetsOptions) (cldf.Environment, PipelineOutputs, error) {
+	e, outputs, err := p.rollbackSteps(t, e, outputs, applied, opts)
+	if err != nil {
+		return e, outputs, errors.Join(runErr, fmt.Errorf("failed to roll back the pipeline: %w", err))
+	}
+	return e, outputs, fmt.Errorf("pipeline rolled back: %w", runErr)
+}
+
+// rollbackSteps rolls back the steps in reverse order. The changesets of the steps are configured again from the
+// outputs, which the rolled back steps are removed from.
+func (p *Pipeline) rollbackSteps(t *testing.T, e cldf.Environment, outputs PipelineOutputs, steps []string, opts []ApplyChangesetsOptions) (cldf.Environment, PipelineOutputs, error) {
+	remaining := make(PipelineOutputs, len(outputs))
+	for name, out := range outputs {
+		remaining[name] = out
+	}
+	applied := make([]AppliedChangeset, 0, len(steps))
+	for _, name := range steps {
+		cs, err := p.steps[name].Changeset(outputs)
+		if err != nil {
+			return e, remaining, fmt.Errorf("failed to configure pipeline step %s: %w", name, err)
+		}
+		applied = append(applied, AppliedChangeset{Name: name, Changeset: cs, Output: outputs[name]})
+	}
+	e, rolledBack, err := RollbackChangesets(t, e, applied, opts...)
+	for _, name := range rolledBack {
+		delete(remaining, name)
+	}
+	return e, remaining, err
+}
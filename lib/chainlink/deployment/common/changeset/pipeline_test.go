@@ -0,0 +1,174 @@
+package changeset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+type testDeployOutput struct {
+	PackageID string
+}
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+
+	var applied []string
+	failRegister := true
+	deploy := cldf.CreateChangeSet(
+		func(e cldf.Environment, packageID string) (cldf.ChangesetOutput, error) {
+			applied = append(applied, "deploy")
+			return cldf.ChangesetOutput{Reports: []operations.Report[any, any]{{Output: testDeployOutput{PackageID: packageID}}}}, nil
+		},
+		func(e cldf.Environment, packageID string) error {
+			return nil
+		},
+	)
+	register := cldf.CreateChangeSet(
+		func(e cldf.Environment, packageID string) (cldf.ChangesetOutput, error) {
+			applied = append(applied, "register "+packageID)
+			if failRegister {
+				return cldf.ChangesetOutput{}, errors.New("register failed")
+			}
+			return cldf.ChangesetOutput{}, nil
+		},
+		func(e cldf.Environment, packageID string) error {
+			return nil
+		},
+	)
+	registerConfig := func(outputs PipelineOutputs) (string, error) {
+		out, err := ReportOutput[testDeployOutput](outputs, "deploy")
+		return out.PackageID, err
+	}
+
+	// the steps are ordered by their dependencies
+	pipeline, err := NewPipeline(
+		Step("register", register, registerConfig, "deploy"),
+		StaticStep("deploy", Configure(deploy, "0x1")),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"deploy", "register"}, pipeline.Order())
+
+	// the output of deploy is wired to register, and the completed steps are returned on failure
+	_, outputs, err := pipeline.Run(t, e)
+	require.ErrorContains(t, err, "failed to apply pipeline step register")
+	require.Equal(t, []string{"deploy", "register 0x1"}, applied)
+	require.Contains(t, outputs, "deploy")
+	require.NotContains(t, outputs, "register")
+
+	// a resumed run skips the completed steps
+	failRegister = false
+	_, outputs, err = pipeline.Run(t, e, WithCompletedSteps(outputs))
+	require.NoError(t, err)
+	require.Equal(t, []string{"deploy", "register 0x1", "register 0x1"}, applied)
+	require.Len(t, outputs, 2)
+
+	_, err = NewPipeline(
+		StaticStep("a", Configure(deploy, "0x1"), "b"),
+		StaticStep("b", Configure(deploy, "0x2"), "a"),
+	)
+	require.ErrorContains(t, err, "dependency cycle")
+	_, err = NewPipeline(StaticStep("a", Configure(deploy, "0x1"), "unknown"))
+	require.ErrorContains(t, err, "unknown step")
+	_, err = NewPipeline(StaticStep("a", Configure(deploy, "0x1")), StaticStep("a", Configure(deploy, "0x2")))
+	require.ErrorContains(t, err, "duplicate pipeline step")
+}
+
+func TestPipelineRollback(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+
+	var applied []string
+	step := func(name string, fail bool) cldf.ChangeSetV2[string] {
+		return cldf.CreateChangeSet(
+			func(e cldf.Environment, config string) (cldf.ChangesetOutput, error) {
+				if fail {
+					return cldf.ChangesetOutput{}, errors.New(name + " failed")
+				}
+				applied = append(applied, name+" "+config)
+				return cldf.ChangesetOutput{}, nil
+			},
+			func(e cldf.Environment, config string) error {
+				return nil
+			},
+		)
+	}
+	undo := step("undo", false)
+	rollback := func(e cldf.Environment, config string, out cldf.ChangesetOutput) (ConfiguredChangeSet, error) {
+		return Configure(undo, config), nil
+	}
+	static := func(outputs PipelineOutputs) (string, error) {
+		return "a", nil
+	}
+
+	// the steps applied by the run are rolled back in reverse order
+	pipeline, err := NewPipeline(
+		StepWithRollback("add-lane", step("add-lane", false), static, rollback),
+		StepWithRollback("add-nop", step("add-nop", false), static, rollback, "add-lane"),
+		StaticStep("register", Configure(step("register", true), "a"), "add-nop"),
+	)
+	require.NoError(t, err)
+	_, outputs, err := pipeline.Run(t, e, WithRollbackOnFailure())
+	require.ErrorContains(t, err, "pipeline rolled back")
+	require.ErrorContains(t, err, "register failed")
+	require.Equal(t, []string{"add-lane a", "add-nop a", "undo a", "undo a"}, applied)
+	require.Empty(t, outputs)
+
+	// the rollback stops at the first step which cannot be rolled back
+	applied = nil
+	pipeline, err = NewPipeline(
+		StepWithRollback("add-lane", step("add-lane", false), static, rollback),
+		StaticStep("add-nop", Configure(step("add-nop", false), "a"), "add-lane"),
+	)
+	require.NoError(t, err)
+	_, outputs, err = pipeline.Run(t, e)
+	require.NoError(t, err)
+	_, outputs, err = pipeline.Rollback(t, e, outputs)
+	require.ErrorIs(t, err, ErrRollbackNotSupported)
+	require.Len(t, outputs, 2)
+	require.Equal(t, []string{"add-lane a", "add-nop a"}, applied)
+}
+
+func TestPipelineSnapshotRollback(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+
+	value := "initial"
+	set := cldf.CreateChangeSet(
+		func(e cldf.Environment, config string) (cldf.ChangesetOutput, error) {
+			value = config
+			return cldf.ChangesetOutput{}, nil
+		},
+		func(e cldf.Environment, config string) error {
+			return nil
+		},
+	)
+	snapshot := func(e cldf.Environment, config string) (string, error) {
+		return value, nil
+	}
+	restore := func(e cldf.Environment, config string, before string, out cldf.ChangesetOutput) (ConfiguredChangeSet, error) {
+		return Configure(set, before), nil
+	}
+
+	// the rollback restores the value read before the step was applied, from the output of the step
+	pipeline, err := NewPipeline(
+		StepWithSnapshotRollback("set", set, func(PipelineOutputs) (string, error) { return "updated", nil }, snapshot, restore),
+	)
+	require.NoError(t, err)
+	_, outputs, err := pipeline.Run(t, e)
+	require.NoError(t, err)
+	require.Equal(t, "updated", value)
+	_, outputs, err = pipeline.Rollback(t, e, outputs)
+	require.NoError(t, err)
+	require.Empty(t, outputs)
+	require.Equal(t, "initial", value)
+
+	// the rollback cannot be built without the snapshot
+	_, err = ConfigureWithSnapshotRollback(set, "updated", snapshot, restore).(Rollback).Rollback(e, cldf.ChangesetOutput{})
+	require.ErrorContains(t, err, "no snapshot")
+}
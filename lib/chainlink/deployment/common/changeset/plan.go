@@ -0,0 +1,266 @@
+package changeset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	sollib "github.com/gagliardetto/solana-go"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	solCommonUtil "github.com/smartcontractkit/chainlink-ccip/chains/solana/utils/common"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/chain/solana/provider/rpcclient"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// Plan is the result of ApplyChangesets in plan mode: what each changeset would do, without submitting anything.
+type Plan struct {
+	Changesets []ChangesetPlan
+}
+
+// ChangesetPlan is what a changeset would do when applied.
+type ChangesetPlan struct {
+	Index int
+	Name  string
+	// Err is the error of the validation or of the application of the changeset. Changesets depending on the
+	// changes of previous changesets usually fail in plan mode, since these changes are not applied.
+	Err error
+	// Transactions are the EVM transactions the changeset would send with the deployer key.
+	Transactions []PlannedTransaction
+	// Instructions are the Solana instructions the changeset would send with the deployer key.
+	Instructions []PlannedInstruction
+	// Output is the output of the changeset, with its proposals and the addresses it would deploy.
+	Output cldf.ChangesetOutput
+}
+
+// PlannedTransaction is an EVM transaction built by a changeset in plan mode.
+type PlannedTransaction struct {
+	ChainSelector uint64
+	From          common.Address
+	// To is nil for contract deployments.
+	To    *common.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// PlannedInstruction is a Solana instruction built by a changeset in plan mode.
+type PlannedInstruction struct {
+	ChainSelector uint64
+	ProgramID     sollib.PublicKey
+	Accounts      int
+	Data          []byte
+}
+
+// WithPlan enables the plan mode of ApplyChangesets: every changeset is validated and applied to a copy of the
+// environment whose EVM and Solana transactions are recorded in the plan instead of being sent, and whose proposals
+// are neither signed nor executed. The chains of other families are left out of the environment, since their
+// transactions cannot be intercepted. The returned environment is the environment passed to ApplyChangesets.
+func WithPlan(plan *Plan) ApplyChangesetsOptions {
+	return func(o *applyChangesetOptions) *applyChangesetOptions {
+		o.plan = plan
+		return o
+	}
+}
+
+// planChangesets validates and applies every changeset in plan mode, and returns the joined errors of the changesets.
+func planChangesets(e cldf.Environment, changesetApplications []ConfiguredChangeSet, plan *Plan) ([]cldf.ChangesetOutput, error) {
+	outputs := make([]cldf.ChangesetOutput, 0, len(changesetApplications))
+	var errs []error
+	for i, csa := range changesetApplications {
+		cs := ChangesetPlan{Index: i, Name: changesetName(csa)}
+		planEnv, recorder := newPlanEnvironment(e)
+		cs.Output, cs.Err = csa.Apply(planEnv)
+		cs.Transactions, cs.Instructions = recorder.transactions, recorder.instructions
+		if cs.Err != nil {
+			errs = append(errs, fmt.Errorf("changeset at index %d: %w", i, cs.Err))
+		}
+		plan.Changesets = append(plan.Changesets, cs)
+		outputs = append(outputs, cs.Output)
+	}
+	return outputs, errors.Join(errs...)
+}
+
+func changesetName(csa ConfiguredChangeSet) string {
+	if named, ok := csa.(interface{ name() string }); ok {
+		return named.name()
+	}
+	return fmt.Sprintf("%T", csa)
+}
+
+// planRecorder records the transactions and instructions sent in a plan environment.
+type planRecorder struct {
+	mu           sync.Mutex
+	transactions []PlannedTransaction
+	instructions []PlannedInstruction
+}
+
+// planEVMClient records the transactions instead of sending them.
+type planEVMClient struct {
+	cldf_evm.OnchainClient
+	selector uint64
+	recorder *planRecorder
+}
+
+func (c planEVMClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to recover sender of planned transaction: %w", err)
+	}
+	c.recorder.mu.Lock()
+	defer c.recorder.mu.Unlock()
+	c.recorder.transactions = append(c.recorder.transactions, PlannedTransaction{
+		ChainSelector: c.selector,
+		From:          from,
+		To:            tx.To(),
+		Value:         tx.Value(),
+		Data:          tx.Data(),
+	})
+	return nil
+}
+
+func (r *planRecorder) recordInstructions(selector uint64, instructions []sollib.Instruction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ix := range instructions {
+		data, err := ix.Data()
+		if err != nil {
+			return fmt.Errorf("failed to encode planned instruction: %w", err)
+		}
+		r.instructions = append(r.instructions, PlannedInstruction{
+			ChainSelector: selector,
+			ProgramID:     ix.ProgramID(),
+			Accounts:      len(ix.Accounts()),
+			Data:          data,
+		})
+	}
+	return nil
+}
+
+// newPlanEnvironment returns a copy of the environment whose EVM and Solana transactions are recorded instead of
+// being sent. Sent transactions are confirmed right away, without receipt.
+func newPlanEnvironment(e cldf.Environment) (cldf.Environment, *planRecorder) {
+	recorder := &planRecorder{}
+	chains := make(map[uint64]chain.BlockChain)
+	for selector, evmChain := range e.BlockChains.EVMChains() {
+		evmChain.Client = planEVMClient{OnchainClient: evmChain.Client, selector: selector, recorder: recorder}
+		evmChain.Confirm = func(*types.Transaction) (uint64, error) {
+			return 0, nil
+		}
+		chains[selector] = evmChain
+	}
+	for selector, solChain := range e.BlockChains.SolanaChains() {
+		solChain.Confirm = func(instructions []sollib.Instruction, _ ...solCommonUtil.TxModifier) error {
+			return recorder.recordInstructions(selector, instructions)
+		}
+		solChain.SendAndConfirm = func(_ context.Context, instructions []sollib.Instruction, _ ...rpcclient.TxModifier) error {
+			return recorder.recordInstructions(selector, instructions)
+		}
+		chains[selector] = solChain
+	}
+	e.BlockChains = chain.NewBlockChains(chains)
+	return e, recorder
+}
+
+// String returns the plan in a human-readable form, grouped by changeset and chain.
+func (p Plan) String() string {
+	var sb strings.Builder
+	for _, cs := range p.Changesets {
+		fmt.Fprintf(&sb, "Changeset %d: %s\n", cs.Index, cs.Name)
+		if cs.Err != nil {
+			fmt.Fprintf(&sb, "  ERROR: %v\n", cs.Err)
+		}
+		if len(cs.Transactions)+len(cs.Instructions)+len(cs.Output.MCMSTimelockProposals)+len(cs.Output.MCMSProposals) == 0 && cs.Output.AddressBook == nil {
+			sb.WriteString("  no changes\n")
+		}
+
+		txsByChain := make(map[uint64][]PlannedTransaction)
+		for _, tx := range cs.Transactions {
+			txsByChain[tx.ChainSelector] = append(txsByChain[tx.ChainSelector], tx)
+		}
+		for _, selector := range sortedKeys(txsByChain) {
+			fmt.Fprintf(&sb, "  %s: %d transaction(s)\n", planChainName(selector), len(txsByChain[selector]))
+			for _, tx := range txsByChain[selector] {
+				to := "contract creation"
+				if tx.To != nil {
+					to = tx.To.Hex()
+				}
+				fmt.Fprintf(&sb, "    - from %s to %s, value %s, %s\n", tx.From.Hex(), to, tx.Value, planCallData(tx.Data))
+			}
+		}
+
+		ixsByChain := make(map[uint64][]PlannedInstruction)
+		for _, ix := range cs.Instructions {
+			ixsByChain[ix.ChainSelector] = append(ixsByChain[ix.ChainSelector], ix)
+		}
+		for _, selector := range sortedKeys(ixsByChain) {
+			fmt.Fprintf(&sb, "  %s: %d instruction(s)\n", planChainName(selector), len(ixsByChain[selector]))
+			for _, ix := range ixsByChain[selector] {
+				fmt.Fprintf(&sb, "    - program %s, %d account(s), %d byte(s) of data\n", ix.ProgramID, ix.Accounts, len(ix.Data))
+			}
+		}
+
+		for _, proposal := range cs.Output.MCMSTimelockProposals {
+			fmt.Fprintf(&sb, "  Timelock proposal (%s): %s\n", proposal.Action, proposal.Description)
+			for _, op := range proposal.Operations {
+				fmt.Fprintf(&sb, "    - %s: %d transaction(s)\n", planChainName(uint64(op.ChainSelector)), len(op.Transactions))
+				for _, tx := range op.Transactions {
+					fmt.Fprintf(&sb, "      - %s %s, %s\n", tx.ContractType, tx.To, planCallData(tx.Data))
+				}
+			}
+		}
+		for _, proposal := range cs.Output.MCMSProposals {
+			fmt.Fprintf(&sb, "  MCMS proposal: %s\n", proposal.Description)
+			for _, op := range proposal.Operations {
+				fmt.Fprintf(&sb, "    - %s: %s %s, %s\n", planChainName(uint64(op.ChainSelector)), op.Transaction.ContractType, op.Transaction.To, planCallData(op.Transaction.Data))
+			}
+		}
+
+		if cs.Output.AddressBook != nil {
+			addresses, err := cs.Output.AddressBook.Addresses()
+			if err != nil {
+				fmt.Fprintf(&sb, "  ERROR: failed to read new addresses: %v\n", err)
+				continue
+			}
+			for _, selector := range sortedKeys(addresses) {
+				for _, addr := range sortedKeys(addresses[selector]) {
+					fmt.Fprintf(&sb, "  %s: new %s at %s\n", planChainName(selector), addresses[selector][addr], addr)
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+func planChainName(selector uint64) string {
+	name, err := chainsel.GetChainNameFromSelector(selector)
+	if err != nil {
+		return fmt.Sprintf("chain %d", selector)
+	}
+	return fmt.Sprintf("%s (%d)", name, selector)
+}
+
+// planCallData summarizes call data by its selector and length.
+func planCallData(data []byte) string {
+	if len(data) < 4 {
+		return fmt.Sprintf("%d byte(s) of data", len(data))
+	}
+	return fmt.Sprintf("selector 0x%x, %d byte(s) of data", data[:4], len(data))
+}
+
+func sortedKeys[K int | uint64 | string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,154 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+// ErrRollbackNotSupported is returned when rolling back a changeset configured without rollback.
+var ErrRollbackNotSupported = errors.New("changeset does not support rollback")
+
+// RollbackFunc returns the changeset undoing the changes of a changeset applied with the config, from the environment
+// updated by the changeset and its output. The preconditions of the returned changeset check that the changes can
+// still be undone.
+type RollbackFunc[C any] func(e cldf.Environment, config C, out cldf.ChangesetOutput) (ConfiguredChangeSet, error)
+
+// Rollback is implemented by the configured changesets which can be rolled back.
+type Rollback interface {
+	// Rollback returns the changeset undoing the changes of the changeset, see RollbackFunc.
+	Rollback(e cldf.Environment, out cldf.ChangesetOutput) (ConfiguredChangeSet, error)
+}
+
+// ConfigureWithRollback configures a changeset like Configure, with the function building its rollback.
+func ConfigureWithRollback[C any](
+	changeset cldf.ChangeSetV2[C],
+	config C,
+	rollback RollbackFunc[C],
+) ConfiguredChangeSet {
+	return configuredChangeSetImpl[C]{
+		changeset: changeset,
+		config:    config,
+		rollback:  rollback,
+	}
+}
+
+// SnapshotFunc reads the state of the environment which a changeset applied with the config changes, so that its
+// rollback can restore it.
+type SnapshotFunc[C, S any] func(e cldf.Environment, config C) (S, error)
+
+// SnapshotRollbackFunc is a RollbackFunc restoring the state read by a SnapshotFunc before the changeset was applied.
+type SnapshotRollbackFunc[C, S any] func(e cldf.Environment, config C, before S, out cldf.ChangesetOutput) (ConfiguredChangeSet, error)
+
+// snapshotReportDef is the definition of the report recording the state read before applying a changeset configured
+// with ConfigureWithSnapshotRollback.
+var snapshotReportDef = operations.Definition{
+	ID:          "rollback-snapshot",
+	Version:     semver.MustParse("1.0.0"),
+	Description: "State restored by the rollback of a changeset, read before applying it",
+}
+
+// ConfigureWithSnapshotRollback configures a changeset like ConfigureWithRollback, with a rollback restoring the state
+// read by snapshot right before the changeset is applied. The state is recorded as a report of the output of the
+// changeset, so that the rollback is built from the output alone, e.g. when a pipeline step is configured again by
+// Pipeline.Rollback.
+func ConfigureWithSnapshotRollback[C, S any](
+	changeset cldf.ChangeSetV2[C],
+	config C,
+	snapshot SnapshotFunc[C, S],
+	rollback SnapshotRollbackFunc[C, S],
+) ConfiguredChangeSet {
+	return configuredChangeSetImpl[C]{
+		changeset: changeset,
+		config:    config,
+		snapshot: func(e cldf.Environment, config C) (operations.Report[any, any], error) {
+			before, err := snapshot(e, config)
+			if err != nil {
+				return operations.Report[any, any]{}, err
+			}
+			return operations.NewReport[any, any](snapshotReportDef, config, before, nil), nil
+		},
+		rollback: func(e cldf.Environment, config C, out cldf.ChangesetOutput) (ConfiguredChangeSet, error) {
+			before, err := snapshotFromOutput[S](out)
+			if err != nil {
+				return nil, err
+			}
+			return rollback(e, config, before, out)
+		},
+	}
+}
+
+// snapshotFromOutput returns the state recorded in the output by a changeset configured with
+// ConfigureWithSnapshotRollback.
+func snapshotFromOutput[S any](out cldf.ChangesetOutput) (S, error) {
+	var zero S
+	for _, report := range out.Reports {
+		if report.Def.ID != snapshotReportDef.ID {
+			continue
+		}
+		before, ok := report.Output.(S)
+		if !ok {
+			return zero, fmt.Errorf("snapshot of the output has the type %T, expected %T", report.Output, zero)
+		}
+		return before, nil
+	}
+	return zero, errors.New("no snapshot in the output of the changeset")
+}
+
+func (ca configuredChangeSetImpl[C]) Rollback(e cldf.Environment, out cldf.ChangesetOutput) (ConfiguredChangeSet, error) {
+	if ca.rollback == nil {
+		return nil, fmt.Errorf("%s: %w", ca.name(), ErrRollbackNotSupported)
+	}
+	return ca.rollback(e, ca.config, out)
+}
+
+// NoopRollback returns the changeset rolling back a changeset which changed nothing, e.g. when everything it would have
+// changed was already set before it was applied.
+func NoopRollback() ConfiguredChangeSet {
+	return Configure(cldf.CreateChangeSet(
+		func(e cldf.Environment, _ struct{}) (cldf.ChangesetOutput, error) {
+			return cldf.ChangesetOutput{}, nil
+		},
+		func(e cldf.Environment, _ struct{}) error {
+			return nil
+		},
+	), struct{}{})
+}
+
+// AppliedChangeset is a changeset applied with its output.
+type AppliedChangeset struct {
+	Name      string
+	Changeset ConfiguredChangeSet
+	Output    cldf.ChangesetOutput
+}
+
+// RollbackChangesets rolls back the applied changesets in reverse order, and returns the updated environment with the
+// names of the changesets rolled back. The rollback of each changeset is built from the environment updated by the
+// rollbacks of the changesets applied after it, and the rollbacks stop at the first failure.
+func RollbackChangesets(t *testing.T, e cldf.Environment, applied []AppliedChangeset, opts ...ApplyChangesetsOptions) (cldf.Environment, []string, error) {
+	var rolledBack []string
+	for _, cs := range slices.Backward(applied) {
+		rollbackable, ok := cs.Changeset.(Rollback)
+		if !ok {
+			return e, rolledBack, fmt.Errorf("failed to roll back %s: %w", cs.Name, ErrRollbackNotSupported)
+		}
+		rollback, err := rollbackable.Rollback(e, cs.Output)
+		if err != nil {
+			return e, rolledBack, fmt.Errorf("failed to build rollback of %s: %w", cs.Name, err)
+		}
+		env, _, err := ApplyChangesets(t, e, []ConfiguredChangeSet{rollback}, opts...)
+		if err != nil {
+			return e, rolledBack, fmt.Errorf("failed to roll back %s: %w", cs.Name, err)
+		}
+		e.Logger.Infow("Rolled back changeset", "changeset", cs.Name)
+		e = env
+		rolledBack = append(rolledBack, cs.Name)
+	}
+	return e, rolledBack, nil
+}
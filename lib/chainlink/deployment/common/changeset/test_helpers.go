@@ -1,7 +1,10 @@
 package changeset
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	"testing"
 
@@ -16,12 +19,14 @@ import (
 
 	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
 
+	mcmslib "github.com/smartcontractkit/mcms"
 	mcmsTypes "github.com/smartcontractkit/mcms/types"
 
 	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
 
 	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 
+	"github.com/smartcontractkit/chainlink/deployment"
 	commonState "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
 	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
 )
@@ -45,16 +50,56 @@ func Configure[C any](
 }
 
 type configuredChangeSetImpl[C any] struct {
-	changeset cldf.ChangeSetV2[C]
-	config    C
+	changeset      cldf.ChangeSetV2[C]
+	config         C
+	postConditions []PostCondition[C]
+	rollback       RollbackFunc[C]
+	// snapshot reads the state restored by the rollback before the changeset is applied, see
+	// ConfigureWithSnapshotRollback.
+	snapshot func(e cldf.Environment, config C) (operations.Report[any, any], error)
+	chains   []uint64
 }
 
 func (ca configuredChangeSetImpl[C]) Apply(e cldf.Environment) (cldf.ChangesetOutput, error) {
-	err := ca.changeset.VerifyPreconditions(e, ca.config)
+	err := ca.VerifyPreconditions(e)
 	if err != nil {
 		return cldf.ChangesetOutput{}, err
 	}
-	return ca.changeset.Apply(e, ca.config)
+	if ca.snapshot == nil {
+		return ca.changeset.Apply(e, ca.config)
+	}
+	report, err := ca.snapshot(e, ca.config)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to read the state restored by the rollback of %s: %w", ca.name(), err)
+	}
+	out, err := ca.changeset.Apply(e, ca.config)
+	if err != nil {
+		return out, err
+	}
+	out.Reports = append(out.Reports, report)
+	return out, nil
+}
+
+// VerifyPreconditions validates the config of the changeset without applying it. The `validate` tags of the config
+// are checked first, see ValidateConfig.
+func (ca configuredChangeSetImpl[C]) VerifyPreconditions(e cldf.Environment) error {
+	if err := ValidateConfig(ca.config); err != nil {
+		return fmt.Errorf("%w: %w", err, cldf.ErrInvalidConfig)
+	}
+	return ca.changeset.VerifyPreconditions(e, ca.config)
+}
+
+func (ca configuredChangeSetImpl[C]) name() string {
+	return fmt.Sprintf("%T", ca.changeset)
+}
+
+// configHash returns the SHA-256 of the JSON encoding of the config.
+func (ca configuredChangeSetImpl[C]) configHash() (string, error) {
+	data, err := json.Marshal(ca.config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+	return sha256Hex(data), nil
 }
 
 // Apply applies the changeset applications to the environment and returns the updated environment. This is the
@@ -67,6 +112,16 @@ func Apply(t *testing.T, e cldf.Environment, first ConfiguredChangeSet, rest ...
 
 type applyChangesetOptions struct {
 	realBackend bool
+	bundleDir   string
+	bundleDesc  string
+	plan        *Plan
+	preflight   bool
+	migrateDS   bool
+	auditLog    *AuditLog
+	audit       *auditRun
+	verifyOnly  *ComplianceReport
+	// parallelChains applies the changesets changing disjoint chains concurrently.
+	parallelChains bool
 }
 
 type ApplyChangesetsOptions func(*applyChangesetOptions) *applyChangesetOptions
@@ -78,19 +133,150 @@ func WithRealBackend() ApplyChangesetsOptions {
 	}
 }
 
+// WithProposalBundle writes the timelock proposals of all the changesets as a proposal bundle in the directory.
+func WithProposalBundle(dir, description string) ApplyChangesetsOptions {
+	return func(o *applyChangesetOptions) *applyChangesetOptions {
+		o.bundleDir = dir
+		o.bundleDesc = description
+		return o
+	}
+}
+
+// WithPreflightValidation validates all the changesets before applying any of them, so that an invalid config aborts
+// the whole batch before the first changesets mutate the chains. The changesets are validated against the environment
+// passed to ApplyChangesets: it cannot be used when the preconditions of a changeset depend on the changes of previous
+// changesets, e.g. on contracts they deploy.
+func WithPreflightValidation() ApplyChangesetsOptions {
+	return func(o *applyChangesetOptions) *applyChangesetOptions {
+		o.preflight = true
+		return o
+	}
+}
+
+// WithDataStoreMigration records in the DataStore the addresses of the changesets which only record them in the
+// address book, and fails if a changeset recording addresses in both stores misses some in its DataStore.
+func WithDataStoreMigration() ApplyChangesetsOptions {
+	return func(o *applyChangesetOptions) *applyChangesetOptions {
+		o.migrateDS = true
+		return o
+	}
+}
+
+// migrateChangesetOutput records the address book of the changeset output in its DataStore.
+func migrateChangesetOutput(out *cldf.ChangesetOutput) error {
+	if out.AddressBook == nil {
+		return nil
+	}
+	if out.DataStore == nil {
+		ds, err := deployment.MigrateAddressBook(out.AddressBook)
+		if err != nil {
+			return fmt.Errorf("failed to migrate address book to datastore: %w", err)
+		}
+		out.DataStore = ds
+		return nil
+	}
+	diff, err := deployment.VerifyAddressBookMigration(out.AddressBook, out.DataStore.Seal())
+	if err != nil {
+		return err
+	}
+	if len(diff.OnlyInAddressBook) > 0 {
+		return fmt.Errorf("addresses missing from the datastore:\n%s", deployment.AddressStoreDiff{OnlyInAddressBook: diff.OnlyInAddressBook})
+	}
+	return nil
+}
+
+// preflightChangesets validates all the changesets, and returns the joined errors of the invalid ones.
+func preflightChangesets(e cldf.Environment, changesetApplications []ConfiguredChangeSet) error {
+	var errs []error
+	for i, csa := range changesetApplications {
+		validator, ok := csa.(interface {
+			VerifyPreconditions(e cldf.Environment) error
+		})
+		if !ok {
+			continue
+		}
+		if err := validator.VerifyPreconditions(e); err != nil {
+			errs = append(errs, fmt.Errorf("changeset at index %d: %w", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("preflight validation failed, no changeset was applied: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
 // ApplyChangesets applies the changeset applications to the environment and returns the updated environment.
 func ApplyChangesets(t *testing.T, e cldf.Environment, changesetApplications []ConfiguredChangeSet, opts ...ApplyChangesetsOptions) (cldf.Environment, []cldf.ChangesetOutput, error) {
 	opt := applyChangesetOptions{}
 	for _, o := range opts {
 		opt = *o(&opt)
 	}
+	if opt.plan != nil {
+		outputs, err := planChangesets(e, changesetApplications, opt.plan)
+		return e, outputs, err
+	}
+	if opt.verifyOnly != nil {
+		return e, nil, verifyChangesets(e, changesetApplications, opt.verifyOnly)
+	}
+	if opt.preflight {
+		if err := preflightChangesets(e, changesetApplications); err != nil {
+			return e, nil, err
+		}
+	}
+	if opt.auditLog != nil {
+		return auditChangesets(t, e, changesetApplications, opt)
+	}
+	return applyChangesets(t, e, changesetApplications, opt)
+}
 
+func applyChangesets(t *testing.T, e cldf.Environment, changesetApplications []ConfiguredChangeSet, opt applyChangesetOptions) (cldf.Environment, []cldf.ChangesetOutput, error) {
 	currentEnv := e
 	outputs := make([]cldf.ChangesetOutput, 0, len(changesetApplications))
-	for i, csa := range changesetApplications {
-		out, err := csa.Apply(currentEnv)
+	writeBundle := func() error {
+		if opt.bundleDir == "" {
+			return nil
+		}
+		var proposals []mcmslib.TimelockProposal
+		for _, out := range outputs {
+			proposals = append(proposals, out.MCMSTimelockProposals...)
+		}
+		bundle, err := proposalutils.NewProposalBundle(e.Name, opt.bundleDesc, proposals)
 		if err != nil {
-			return e, nil, fmt.Errorf("failed to apply changeset at index %d: %w", i, err)
+			return fmt.Errorf("failed to create proposal bundle: %w", err)
+		}
+		return bundle.Write(opt.bundleDir)
+	}
+	groups := map[int][]int{}
+	if opt.parallelChains && opt.audit == nil {
+		groups = parallelGroups(changesetApplications)
+	}
+	concurrent := make(map[int]cldf.ChangesetOutput)
+	for i, csa := range changesetApplications {
+		if group, ok := groups[i]; ok {
+			outs, err := applyConcurrently(currentEnv, changesetApplications, group)
+			if err != nil {
+				return e, nil, err
+			}
+			maps.Copy(concurrent, outs)
+		}
+		out, applied := concurrent[i]
+		var err error
+		if !applied {
+			if opt.audit != nil {
+				opt.audit.begin(i, csa)
+			}
+			out, err = csa.Apply(currentEnv)
+			if opt.audit != nil {
+				opt.audit.applied(out, err)
+			}
+			if err != nil {
+				return e, nil, fmt.Errorf("failed to apply changeset at index %d: %w", i, err)
+			}
+		}
+		if opt.migrateDS {
+			if err := migrateChangesetOutput(&out); err != nil {
+				return e, nil, fmt.Errorf("changeset at index %d: %w", i, err)
+			}
 		}
 		outputs = append(outputs, out)
 		var addresses cldf.AddressBook
@@ -163,6 +349,9 @@ func ApplyChangesets(t *testing.T, e cldf.Environment, changesetApplications []C
 				if prop.Action != mcmsTypes.TimelockActionSchedule {
 					// We don't need to execute the proposal if it's not a schedule action
 					// because the proposal is already executed in the previous step.
+					if err := writeBundle(); err != nil {
+						return cldf.Environment{}, nil, err
+					}
 					return currentEnv, outputs, nil
 				}
 				err = proposalutils.ExecuteMCMSTimelockProposalV2(t, currentEnv, &prop)
@@ -186,6 +375,9 @@ func ApplyChangesets(t *testing.T, e cldf.Environment, changesetApplications []C
 			}
 		}
 	}
+	if err := writeBundle(); err != nil {
+		return cldf.Environment{}, nil, err
+	}
 	return currentEnv, outputs, nil
 }
 
@@ -2,16 +2,23 @@ package changeset
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/stretchr/testify/require"
 
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+
 	"github.com/smartcontractkit/chainlink-deployments-framework/chain"
 	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
 	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	focr "github.com/smartcontractkit/chainlink-deployments-framework/offchain/ocr"
 
+	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
 
@@ -65,6 +72,240 @@ func TestChangeSetLegacyFunction_ErrorCase(t *testing.T) {
 	require.Equal(t, "failed to apply changeset at index 0: you shall not pass", err.Error())
 }
 
+func TestApplyChangesetsPreflightValidation(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+
+	executed := 0
+	csv2 := cldf.CreateChangeSet(
+		func(e cldf.Environment, config uint32) (cldf.ChangesetOutput, error) {
+			executed++
+			return cldf.ChangesetOutput{}, nil
+		},
+		func(e cldf.Environment, config uint32) error {
+			if config == 0 {
+				return errors.New("you shall not pass")
+			}
+			return nil
+		},
+	)
+	_, _, err := ApplyChangesets(t, e, []ConfiguredChangeSet{Configure(csv2, 1), Configure(csv2, 0)}, WithPreflightValidation())
+	require.Equal(t, "preflight validation failed, no changeset was applied: changeset at index 1: you shall not pass", err.Error())
+	require.Zero(t, executed, "No changeset should have been applied")
+
+	_, _, err = ApplyChangesets(t, e, []ConfiguredChangeSet{Configure(csv2, 1), Configure(csv2, 2)}, WithPreflightValidation())
+	require.NoError(t, err)
+	require.Equal(t, 2, executed)
+}
+
+func TestApplyChangesetsPlan(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+
+	executed := 0
+	deploy := cldf.CreateChangeSet(
+		func(e cldf.Environment, config uint32) (cldf.ChangesetOutput, error) {
+			executed++
+			ab := cldf.NewMemoryAddressBook()
+			err := ab.Save(chain_selectors.ETHEREUM_TESTNET_SEPOLIA.Selector, "0x0000000000000000000000000000000000000001",
+				cldf.NewTypeAndVersion("TestContract", *semver.MustParse("1.0.0")))
+			return cldf.ChangesetOutput{AddressBook: ab}, err
+		},
+		func(e cldf.Environment, config uint32) error {
+			return nil
+		},
+	)
+	invalid := cldf.CreateChangeSet(
+		func(e cldf.Environment, config uint32) (cldf.ChangesetOutput, error) {
+			executed++
+			return cldf.ChangesetOutput{}, nil
+		},
+		func(e cldf.Environment, config uint32) error {
+			return errors.New("you shall not pass")
+		},
+	)
+
+	plan := &Plan{}
+	env, outputs, err := ApplyChangesets(t, e, []ConfiguredChangeSet{Configure(deploy, 1), Configure(invalid, 2), Configure(deploy, 3)}, WithPlan(plan))
+	require.ErrorContains(t, err, "changeset at index 1: you shall not pass")
+	require.Equal(t, 2, executed, "all the valid changesets should be planned")
+	require.Len(t, outputs, 3)
+	require.Len(t, plan.Changesets, 3)
+	require.NoError(t, plan.Changesets[0].Err)
+	require.Error(t, plan.Changesets[1].Err)
+
+	// the addresses of the plan are not added to the environment
+	addresses, err := env.ExistingAddresses.Addresses()
+	require.NoError(t, err)
+	require.Empty(t, addresses)
+
+	summary := plan.String()
+	require.Contains(t, summary, "Changeset 0:")
+	require.Contains(t, summary, "new TestContract 1.0.0 at 0x0000000000000000000000000000000000000001")
+	require.Contains(t, summary, "ERROR: you shall not pass")
+}
+
+func TestApplyChangesetsDataStoreMigration(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+	selector := chain_selectors.ETHEREUM_TESTNET_SEPOLIA.Selector
+	tv := cldf.NewTypeAndVersion("TestContract", *semver.MustParse("1.0.0"))
+
+	deploy := cldf.CreateChangeSet(
+		func(e cldf.Environment, address string) (cldf.ChangesetOutput, error) {
+			ab := cldf.NewMemoryAddressBook()
+			err := ab.Save(selector, address, tv)
+			return cldf.ChangesetOutput{AddressBook: ab}, err
+		},
+		func(e cldf.Environment, address string) error {
+			return nil
+		},
+	)
+	partial := cldf.CreateChangeSet(
+		func(e cldf.Environment, address string) (cldf.ChangesetOutput, error) {
+			ab := cldf.NewMemoryAddressBook()
+			err := ab.Save(selector, address, tv)
+			return cldf.ChangesetOutput{AddressBook: ab, DataStore: datastore.NewMemoryDataStore()}, err
+		},
+		func(e cldf.Environment, address string) error {
+			return nil
+		},
+	)
+
+	// the addresses only recorded in the address book are recorded in the datastore
+	e, _, err := ApplyChangesets(t, e, []ConfiguredChangeSet{Configure(deploy, "0x0000000000000000000000000000000000000001")}, WithDataStoreMigration())
+	require.NoError(t, err)
+	diff, err := deployment.VerifyAddressBookMigration(e.ExistingAddresses, e.DataStore)
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty(), diff.String())
+
+	// a changeset missing addresses in its datastore fails
+	_, _, err = ApplyChangesets(t, e, []ConfiguredChangeSet{Configure(partial, "0x0000000000000000000000000000000000000002")}, WithDataStoreMigration())
+	require.ErrorContains(t, err, "addresses missing from the datastore")
+}
+
+func TestApplyChangesetsAuditLog(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+	sepolia := chain_selectors.ETHEREUM_TESTNET_SEPOLIA.Selector
+	fuji := chain_selectors.AVALANCHE_TESTNET_FUJI.Selector
+	tv := cldf.NewTypeAndVersion("TestContract", *semver.MustParse("1.0.0"))
+
+	type deployConfig struct {
+		ChainSelector uint64
+		Address       string
+	}
+	deploy := cldf.CreateChangeSet(
+		func(e cldf.Environment, cfg deployConfig) (cldf.ChangesetOutput, error) {
+			ab := cldf.NewMemoryAddressBook()
+			err := ab.Save(cfg.ChainSelector, cfg.Address, tv)
+			return cldf.ChangesetOutput{AddressBook: ab}, err
+		},
+		func(e cldf.Environment, cfg deployConfig) error {
+			return nil
+		},
+	)
+	failing := cldf.CreateChangeSet(
+		func(e cldf.Environment, cfg deployConfig) (cldf.ChangesetOutput, error) {
+			return cldf.ChangesetOutput{}, errors.New("you shall not pass")
+		},
+		func(e cldf.Environment, cfg deployConfig) error {
+			return nil
+		},
+	)
+
+	log, err := NewAuditLog(filepath.Join(t.TempDir(), "audit", "runs.jsonl"))
+	require.NoError(t, err)
+
+	_, _, err = ApplyChangesets(t, e, []ConfiguredChangeSet{
+		Configure(deploy, deployConfig{ChainSelector: sepolia, Address: "0x0000000000000000000000000000000000000001"}),
+		Configure(deploy, deployConfig{ChainSelector: fuji, Address: "0x0000000000000000000000000000000000000002"}),
+	}, WithAuditLog(log))
+	require.NoError(t, err)
+	_, _, err = ApplyChangesets(t, e, []ConfiguredChangeSet{
+		Configure(failing, deployConfig{ChainSelector: sepolia}),
+	}, WithAuditLog(log))
+	require.ErrorContains(t, err, "you shall not pass")
+
+	// every run is recorded, including the failed ones
+	records, err := log.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, e.Name, records[0].Environment)
+	require.NotEqual(t, records[0].RunID, records[1].RunID)
+	require.Empty(t, records[0].Err)
+	require.Len(t, records[0].Changesets, 2)
+	require.NotEqual(t, records[0].Changesets[0].ConfigHash, records[0].Changesets[1].ConfigHash)
+	require.Equal(t, []AuditContract{{ChainSelector: sepolia, Address: "0x0000000000000000000000000000000000000001", Type: tv.String()}}, records[0].Changesets[0].Addresses)
+	require.Contains(t, records[1].Err, "you shall not pass")
+	require.Contains(t, records[1].Changesets[0].Err, "you shall not pass")
+
+	// the history of a chain or a contract only has the changesets touching it
+	history, err := log.History(AuditFilter{ChainSelector: fuji})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Len(t, history[0].Changesets, 1)
+	require.Equal(t, 1, history[0].Changesets[0].Index)
+
+	history, err = log.History(AuditFilter{Address: "0x0000000000000000000000000000000000000001"})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, 0, history[0].Changesets[0].Index)
+
+	history, err = log.History(AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+}
+
+func TestApplyChangesetsVerifyOnly(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+
+	applied := false
+	cs := cldf.CreateChangeSet(
+		func(e cldf.Environment, config uint32) (cldf.ChangesetOutput, error) {
+			applied = true
+			return cldf.ChangesetOutput{}, nil
+		},
+		func(e cldf.Environment, config uint32) error {
+			if config == 0 {
+				return errors.New("config must be positive")
+			}
+			return nil
+		},
+	)
+	isEven := func(e cldf.Environment, config uint32) error {
+		if config%2 != 0 {
+			return errors.New("config is odd")
+		}
+		return nil
+	}
+
+	var report ComplianceReport
+	_, outputs, err := ApplyChangesets(t, e, []ConfiguredChangeSet{
+		ConfigureWithPostConditions(cs, 2, isEven),
+		ConfigureWithPostConditions(cs, 3, isEven),
+		ConfigureWithPostConditions(cs, 0, isEven),
+		Configure(cs, 1),
+	}, WithVerifyOnly(&report))
+	require.ErrorContains(t, err, "config is odd")
+	require.ErrorContains(t, err, "config must be positive")
+	require.False(t, applied, "Not expected to have executed the changesets")
+	require.Nil(t, outputs)
+
+	require.False(t, report.Compliant())
+	require.Len(t, report.Changesets, 4)
+	require.True(t, report.Changesets[0].Compliant())
+	require.Equal(t, 1, report.Changesets[0].PostConditions)
+	require.Len(t, report.Changesets[1].Violations, 1)
+	require.Error(t, report.Changesets[2].ValidationErr)
+	require.Empty(t, report.Changesets[2].Violations)
+	require.True(t, report.Changesets[3].Compliant())
+	require.Zero(t, report.Changesets[3].PostConditions)
+	require.Contains(t, report.String(), "Changeset 1: ")
+	require.Contains(t, report.String(), "NON-COMPLIANT")
+}
+
 func NewNoopEnvironment(t *testing.T) cldf.Environment {
 	return *cldf.NewEnvironment(
 		"noop",
@@ -79,6 +320,58 @@ func NewNoopEnvironment(t *testing.T) cldf.Environment {
 	)
 }
 
+func TestApplyChangesetsParallelChains(t *testing.T) {
+	t.Parallel()
+	e := NewNoopEnvironment(t)
+
+	var started sync.WaitGroup
+	started.Add(2)
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+	// the changesets on chains 1 and 2 wait for each other, so that they only succeed if applied concurrently
+	csv2 := cldf.CreateChangeSet(
+		func(e cldf.Environment, selector uint64) (cldf.ChangesetOutput, error) {
+			if selector > 2 {
+				return cldf.ChangesetOutput{}, fmt.Errorf("chain %d failed", selector)
+			}
+			started.Done()
+			select {
+			case <-allStarted:
+				return cldf.ChangesetOutput{}, nil
+			case <-time.After(10 * time.Second):
+				return cldf.ChangesetOutput{}, errors.New("not applied concurrently")
+			}
+		},
+		func(e cldf.Environment, selector uint64) error {
+			return nil
+		},
+	)
+	changesets := ConfigurePerChain(csv2, map[uint64]uint64{2: 2, 1: 1})
+	_, outputs, err := ApplyChangesets(t, e, changesets, WithParallelChains())
+	require.NoError(t, err)
+	require.Len(t, outputs, 2)
+
+	// the errors of all the failed changesets are reported
+	changesets = ConfigurePerChain(csv2, map[uint64]uint64{3: 3, 4: 4})
+	_, _, err = ApplyChangesets(t, e, changesets, WithParallelChains())
+	require.ErrorContains(t, err, "failed to apply changeset at index 0 on chains [3]: chain 3 failed")
+	require.ErrorContains(t, err, "failed to apply changeset at index 1 on chains [4]: chain 4 failed")
+
+	// the changesets changing the same chains, or without chains, are applied sequentially
+	groups := parallelGroups([]ConfiguredChangeSet{
+		ConfigureForChains(csv2, 1, 1, 2),
+		ConfigureForChains(csv2, 3, 3),
+		ConfigureForChains(csv2, 2, 2),
+		Configure(csv2, 4),
+		ConfigureForChains(csv2, 5, 5),
+		ConfigureForChains(csv2, 6, 6),
+	})
+	require.Equal(t, map[int][]int{0: {0, 1}, 4: {4, 5}}, groups)
+}
+
 func TestApplyChangesetsHelpers(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,125 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// PostCondition checks that the changes of a changeset applied with the config are in effect on the chains, e.g. that
+// the admin of a token on the token admin registry is the expected one. It must not send any transaction.
+type PostCondition[C any] func(e cldf.Environment, config C) error
+
+// ConfigureWithPostConditions configures a changeset like Configure, with the post-conditions checked by the
+// verify-only mode of ApplyChangesets.
+func ConfigureWithPostConditions[C any](
+	changeset cldf.ChangeSetV2[C],
+	config C,
+	postConditions ...PostCondition[C],
+) ConfiguredChangeSet {
+	return configuredChangeSetImpl[C]{
+		changeset:      changeset,
+		config:         config,
+		postConditions: postConditions,
+	}
+}
+
+// verifyPostConditions checks all the post-conditions, and returns their number and the errors of the failed ones.
+func (ca configuredChangeSetImpl[C]) verifyPostConditions(e cldf.Environment) (int, []error) {
+	var errs []error
+	for i, postCondition := range ca.postConditions {
+		if err := postCondition(e, ca.config); err != nil {
+			errs = append(errs, fmt.Errorf("post-condition %d: %w", i, err))
+		}
+	}
+	return len(ca.postConditions), errs
+}
+
+// ComplianceReport is the result of ApplyChangesets in verify-only mode.
+type ComplianceReport struct {
+	Changesets []ChangesetCompliance
+}
+
+// ChangesetCompliance is the result of the verification of a previously applied changeset.
+type ChangesetCompliance struct {
+	Index int
+	Name  string
+	// ValidationErr is the error of the validation of the config of the changeset.
+	ValidationErr error
+	// PostConditions is the number of post-conditions checked.
+	PostConditions int
+	// Violations are the errors of the failed post-conditions.
+	Violations []error
+}
+
+// Compliant reports whether the changeset config is valid and all its post-conditions hold.
+func (c ChangesetCompliance) Compliant() bool {
+	return c.ValidationErr == nil && len(c.Violations) == 0
+}
+
+// Compliant reports whether all the changesets are compliant.
+func (r ComplianceReport) Compliant() bool {
+	for _, cs := range r.Changesets {
+		if !cs.Compliant() {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the report in a human-readable form.
+func (r ComplianceReport) String() string {
+	var sb strings.Builder
+	for _, cs := range r.Changesets {
+		status := "COMPLIANT"
+		if !cs.Compliant() {
+			status = "NON-COMPLIANT"
+		}
+		fmt.Fprintf(&sb, "Changeset %d: %s: %s\n", cs.Index, cs.Name, status)
+		if cs.ValidationErr != nil {
+			fmt.Fprintf(&sb, "  validation failed: %v\n", cs.ValidationErr)
+		}
+		fmt.Fprintf(&sb, "  %d/%d post-condition(s) hold\n", cs.PostConditions-len(cs.Violations), cs.PostConditions)
+		for _, violation := range cs.Violations {
+			fmt.Fprintf(&sb, "  - %v\n", violation)
+		}
+	}
+	return sb.String()
+}
+
+// WithVerifyOnly enables the verify-only mode of ApplyChangesets: the changesets, which were previously applied, are
+// not applied again. Instead their configs are validated and their post-conditions are checked against the chains of
+// the environment, see ConfigureWithPostConditions, and the results are recorded in the report. Nothing is sent to
+// the chains. The returned environment is the environment passed to ApplyChangesets.
+func WithVerifyOnly(report *ComplianceReport) ApplyChangesetsOptions {
+	return func(o *applyChangesetOptions) *applyChangesetOptions {
+		o.verifyOnly = report
+		return o
+	}
+}
+
+// verifyChangesets validates every changeset and checks its post-conditions, and returns the joined errors of the
+// non-compliant changesets.
+func verifyChangesets(e cldf.Environment, changesetApplications []ConfiguredChangeSet, report *ComplianceReport) error {
+	var errs []error
+	for i, csa := range changesetApplications {
+		cs := ChangesetCompliance{Index: i, Name: changesetName(csa)}
+		if validator, ok := csa.(interface {
+			VerifyPreconditions(e cldf.Environment) error
+		}); ok {
+			cs.ValidationErr = validator.VerifyPreconditions(e)
+		}
+		if verifier, ok := csa.(interface {
+			verifyPostConditions(e cldf.Environment) (int, []error)
+		}); ok {
+			cs.PostConditions, cs.Violations = verifier.verifyPostConditions(e)
+		}
+		if !cs.Compliant() {
+			errs = append(errs, fmt.Errorf("changeset at index %d is not compliant: %w", i, errors.Join(append([]error{cs.ValidationErr}, cs.Violations...)...)))
+		}
+		report.Changesets = append(report.Changesets, cs)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,486 @@
+package changeset
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/block-vision/sui-go-sdk/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gagliardetto/solana-go"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf_solana "github.com/smartcontractkit/chainlink-deployments-framework/chain/solana"
+	cldf_sui "github.com/smartcontractkit/chainlink-deployments-framework/chain/sui"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+var (
+	_ cldf.ChangeSet[VerifyProvenanceConfig] = VerifyProvenanceChangeset
+)
+
+// solanaProgramDataHeaderSize is the size of the header of a ProgramData account, before the executable: the loader
+// state type, the deployment slot and the optional upgrade authority.
+const solanaProgramDataHeaderSize = 45
+
+// ByteRange is a range of bytes of an EVM runtime bytecode.
+type ByteRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// EVMArtifact is the runtime bytecode of an EVM contract built locally or downloaded from a release.
+type EVMArtifact struct {
+	RuntimeBytecode hexutil.Bytes `json:"runtimeBytecode"`
+	// ImmutableReferences are the ranges of the immutable variables in the runtime bytecode, which are set at
+	// deployment and ignored by the comparison.
+	ImmutableReferences []ByteRange `json:"immutableReferences,omitempty"`
+	// IgnoreMetadata ignores the CBOR metadata appended by solc, which changes with the source paths and comments.
+	IgnoreMetadata bool `json:"ignoreMetadata,omitempty"`
+}
+
+// LoadEVMArtifact loads the runtime bytecode of a Hardhat or Foundry artifact. The immutable references of Foundry
+// artifacts are loaded too.
+func LoadEVMArtifact(path string) (EVMArtifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return EVMArtifact{}, fmt.Errorf("failed to read artifact %s: %w", path, err)
+	}
+	var raw struct {
+		DeployedBytecode json.RawMessage `json:"deployedBytecode"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return EVMArtifact{}, fmt.Errorf("failed to decode artifact %s: %w", path, err)
+	}
+	if len(raw.DeployedBytecode) == 0 {
+		return EVMArtifact{}, fmt.Errorf("artifact %s has no deployedBytecode", path)
+	}
+
+	var artifact EVMArtifact
+	// Hardhat stores the bytecode as a string, Foundry as an object with the immutable references
+	var hardhat string
+	if err := json.Unmarshal(raw.DeployedBytecode, &hardhat); err == nil {
+		artifact.RuntimeBytecode, err = hexutil.Decode(hardhat)
+		if err != nil {
+			return EVMArtifact{}, fmt.Errorf("invalid deployedBytecode of artifact %s: %w", path, err)
+		}
+		return artifact, nil
+	}
+	var foundry struct {
+		Object              string                 `json:"object"`
+		ImmutableReferences map[string][]ByteRange `json:"immutableReferences"`
+	}
+	if err := json.Unmarshal(raw.DeployedBytecode, &foundry); err != nil {
+		return EVMArtifact{}, fmt.Errorf("invalid deployedBytecode of artifact %s: %w", path, err)
+	}
+	artifact.RuntimeBytecode, err = hexutil.Decode(foundry.Object)
+	if err != nil {
+		return EVMArtifact{}, fmt.Errorf("invalid deployedBytecode of artifact %s: %w", path, err)
+	}
+	for _, refs := range foundry.ImmutableReferences {
+		artifact.ImmutableReferences = append(artifact.ImmutableReferences, refs...)
+	}
+	slices.SortFunc(artifact.ImmutableReferences, func(a, b ByteRange) int {
+		return a.Start - b.Start
+	})
+	return artifact, nil
+}
+
+// SolanaProgramHash returns the hash of a program binary, which is the SHA-256 of the binary without its trailing
+// zeros, as computed by solana-verify.
+func SolanaProgramHash(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read program %s: %w", path, err)
+	}
+	return solanaExecutableHash(data), nil
+}
+
+// SuiPackageDigest returns the digest of the modules of a package built with
+// `sui move build --dump-bytecode-as-base64`.
+func SuiPackageDigest(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read package %s: %w", path, err)
+	}
+	var build struct {
+		Modules []string `json:"modules"`
+	}
+	if err := json.Unmarshal(data, &build); err != nil {
+		return "", fmt.Errorf("failed to decode package %s: %w", path, err)
+	}
+	if len(build.Modules) == 0 {
+		return "", fmt.Errorf("package %s has no modules", path)
+	}
+	return suiModulesDigest(build.Modules)
+}
+
+// ProvenanceArtifacts are the artifacts of a release, by contract type.
+type ProvenanceArtifacts struct {
+	EVM map[cldf.ContractType]EVMArtifact `json:"evm,omitempty"`
+	// SolanaPrograms are the hashes of the program binaries, see SolanaProgramHash.
+	SolanaPrograms map[cldf.ContractType]string `json:"solanaPrograms,omitempty"`
+	// SuiPackages are the digests of the packages, see SuiPackageDigest.
+	SuiPackages map[cldf.ContractType]string `json:"suiPackages,omitempty"`
+}
+
+// ProvenanceResult is the result of the verification of a deployed contract.
+type ProvenanceResult struct {
+	ChainSelector uint64            `json:"chainSelector"`
+	Address       string            `json:"address"`
+	ContractType  cldf.ContractType `json:"contractType"`
+	Match         bool              `json:"match"`
+	// Expected and Actual are the hashes of the artifact and of the deployed code.
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	// Reason explains a mismatch.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (r ProvenanceResult) String() string {
+	if r.Match {
+		return fmt.Sprintf("%s %s on chain %d matches %s", r.ContractType, r.Address, r.ChainSelector, r.Expected)
+	}
+	return fmt.Sprintf("%s %s on chain %d does not match: %s (expected %s, got %s)", r.ContractType, r.Address, r.ChainSelector, r.Reason, r.Expected, r.Actual)
+}
+
+// VerifyEVMBytecodeInput is the input of VerifyEVMBytecodeOp.
+type VerifyEVMBytecodeInput struct {
+	ChainSelector uint64            `json:"chainSelector"`
+	Address       common.Address    `json:"address"`
+	ContractType  cldf.ContractType `json:"contractType"`
+	Artifact      EVMArtifact       `json:"artifact"`
+}
+
+// VerifyEVMBytecodeOp compares the runtime bytecode of a contract with the bytecode of its artifact, without the
+// immutable variables and optionally the metadata. The hashes of the result are the keccak256 of the compared
+// bytecodes.
+var VerifyEVMBytecodeOp = operations.NewOperation(
+	"VerifyEVMBytecodeOp",
+	semver.MustParse("1.0.0"),
+	"Compares the runtime bytecode of an EVM contract with its artifact",
+	func(b operations.Bundle, chain cldf_evm.Chain, input VerifyEVMBytecodeInput) (ProvenanceResult, error) {
+		if input.ChainSelector != chain.Selector {
+			return ProvenanceResult{}, fmt.Errorf("mismatch between inputted chain selector and selector defined within dependencies: %d != %d", input.ChainSelector, chain.Selector)
+		}
+		code, err := chain.Client.CodeAt(b.GetContext(), input.Address, nil)
+		if err != nil {
+			return ProvenanceResult{}, fmt.Errorf("failed to get code of %s on %s: %w", input.Address, chain, err)
+		}
+		return compareEVMBytecode(input, code), nil
+	},
+)
+
+// VerifySolanaProgramInput is the input of VerifySolanaProgramOp.
+type VerifySolanaProgramInput struct {
+	ChainSelector uint64            `json:"chainSelector"`
+	ProgramID     solana.PublicKey  `json:"programID"`
+	ContractType  cldf.ContractType `json:"contractType"`
+	// ExpectedHash is the hash of the program binary, see SolanaProgramHash.
+	ExpectedHash string `json:"expectedHash"`
+}
+
+// VerifySolanaProgramOp compares the hash of the executable of an upgradeable program with the hash of its binary.
+var VerifySolanaProgramOp = operations.NewOperation(
+	"VerifySolanaProgramOp",
+	semver.MustParse("1.0.0"),
+	"Compares the executable of a Solana program with its binary",
+	func(b operations.Bundle, chain cldf_solana.Chain, input VerifySolanaProgramInput) (ProvenanceResult, error) {
+		if input.ChainSelector != chain.Selector {
+			return ProvenanceResult{}, fmt.Errorf("mismatch between inputted chain selector and selector defined within dependencies: %d != %d", input.ChainSelector, chain.Selector)
+		}
+		result := ProvenanceResult{
+			ChainSelector: input.ChainSelector,
+			Address:       input.ProgramID.String(),
+			ContractType:  input.ContractType,
+			Expected:      input.ExpectedHash,
+		}
+		programData, err := deployment.GetProgramDataAddress(chain.Client, input.ProgramID)
+		if err != nil {
+			result.Reason = err.Error()
+			return result, nil
+		}
+		account, err := chain.Client.GetAccountInfo(b.GetContext(), programData)
+		if err != nil {
+			return ProvenanceResult{}, fmt.Errorf("failed to get program data %s of program %s on %s: %w", programData, input.ProgramID, chain, err)
+		}
+		data := account.Value.Data.GetBinary()
+		if len(data) < solanaProgramDataHeaderSize {
+			result.Reason = fmt.Sprintf("program data %s is too short", programData)
+			return result, nil
+		}
+		result.Actual = solanaExecutableHash(data[solanaProgramDataHeaderSize:])
+		result.Match = strings.EqualFold(result.Actual, result.Expected)
+		if !result.Match {
+			result.Reason = "program executable differs from the binary"
+		}
+		return result, nil
+	},
+)
+
+// VerifySuiPackageInput is the input of VerifySuiPackageOp.
+type VerifySuiPackageInput struct {
+	ChainSelector uint64            `json:"chainSelector"`
+	PackageID     string            `json:"packageID"`
+	ContractType  cldf.ContractType `json:"contractType"`
+	// ExpectedDigest is the digest of the package, see SuiPackageDigest.
+	ExpectedDigest string `json:"expectedDigest"`
+}
+
+// VerifySuiPackageOp compares the digest of the modules of a published package with the digest of its build.
+var VerifySuiPackageOp = operations.NewOperation(
+	"VerifySuiPackageOp",
+	semver.MustParse("1.0.0"),
+	"Compares the modules of a Sui package with its build",
+	func(b operations.Bundle, chain cldf_sui.Chain, input VerifySuiPackageInput) (ProvenanceResult, error) {
+		if input.ChainSelector != chain.Selector {
+			return ProvenanceResult{}, fmt.Errorf("mismatch between inputted chain selector and selector defined within dependencies: %d != %d", input.ChainSelector, chain.Selector)
+		}
+		result := ProvenanceResult{
+			ChainSelector: input.ChainSelector,
+			Address:       input.PackageID,
+			ContractType:  input.ContractType,
+			Expected:      input.ExpectedDigest,
+		}
+		rsp, err := chain.Client.SuiGetObject(b.GetContext(), models.SuiGetObjectRequest{
+			ObjectId: input.PackageID,
+			Options:  models.SuiObjectDataOptions{ShowBcs: true},
+		})
+		if err != nil {
+			return ProvenanceResult{}, fmt.Errorf("failed to get package %s on %s: %w", input.PackageID, chain, err)
+		}
+		if rsp.Data == nil || rsp.Data.Bcs == nil || len(rsp.Data.Bcs.ModuleMap) == 0 {
+			result.Reason = "package not found"
+			return result, nil
+		}
+		modules := make([]string, 0, len(rsp.Data.Bcs.ModuleMap))
+		for _, module := range rsp.Data.Bcs.ModuleMap {
+			modules = append(modules, module)
+		}
+		result.Actual, err = suiModulesDigest(modules)
+		if err != nil {
+			return ProvenanceResult{}, fmt.Errorf("invalid modules of package %s on %s: %w", input.PackageID, chain, err)
+		}
+		result.Match = result.Actual == result.Expected
+		if !result.Match {
+			result.Reason = "package modules differ from the build"
+		}
+		return result, nil
+	},
+)
+
+// VerifyProvenanceConfig configures VerifyProvenanceChangeset.
+type VerifyProvenanceConfig struct {
+	// Version is the version of the release, only the contracts of the address book with this version are verified.
+	Version semver.Version
+	// Artifacts are the artifacts of the release. The contracts without artifact are skipped.
+	Artifacts ProvenanceArtifacts
+	// ChainSelectors are the chains to verify, all the EVM, Solana and Sui chains of the environment if empty.
+	ChainSelectors []uint64
+	// FailOnMismatch fails the changeset if a contract does not match its artifact, the mismatches are only reported
+	// otherwise.
+	FailOnMismatch bool
+}
+
+func (cfg VerifyProvenanceConfig) Validate(e cldf.Environment) error {
+	if len(cfg.Artifacts.EVM)+len(cfg.Artifacts.SolanaPrograms)+len(cfg.Artifacts.SuiPackages) == 0 {
+		return errors.New("no artifacts to verify against")
+	}
+	for contractType, artifact := range cfg.Artifacts.EVM {
+		if len(artifact.RuntimeBytecode) == 0 {
+			return fmt.Errorf("empty runtime bytecode for %s", contractType)
+		}
+	}
+	for _, selector := range cfg.ChainSelectors {
+		if !e.BlockChains.Exists(selector) {
+			return fmt.Errorf("chain %d not found in environment", selector)
+		}
+	}
+	return nil
+}
+
+// VerifyProvenanceChangeset verifies that the contracts of a release deployed on the chains match the artifacts of the
+// release: the runtime bytecode of EVM contracts, the executable of Solana programs and the modules of Sui packages.
+// Each contract is verified by an operation whose result is in the reports of the output, and the mismatches are
+// logged per address.
+func VerifyProvenanceChangeset(e cldf.Environment, cfg VerifyProvenanceConfig) (cldf.ChangesetOutput, error) {
+	if err := cfg.Validate(e); err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("%w: %w", err, cldf.ErrInvalidConfig)
+	}
+	selectors := cfg.ChainSelectors
+	if len(selectors) == 0 {
+		selectors = e.BlockChains.ListChainSelectors()
+	}
+	slices.Sort(selectors)
+
+	out := cldf.ChangesetOutput{}
+	var mismatches []string
+	for _, selector := range selectors {
+		family, err := chainsel.GetSelectorFamily(selector)
+		if err != nil {
+			return out, err
+		}
+		addresses, err := e.ExistingAddresses.AddressesForChain(selector)
+		if err != nil {
+			if errors.Is(err, cldf.ErrChainNotFound) {
+				continue
+			}
+			return out, fmt.Errorf("failed to get addresses of chain %d: %w", selector, err)
+		}
+		for _, addr := range sortedKeys(addresses) {
+			tv := addresses[addr]
+			if !tv.Version.Equal(&cfg.Version) {
+				continue
+			}
+			var report operations.Report[any, any]
+			switch family {
+			case chainsel.FamilyEVM:
+				artifact, ok := cfg.Artifacts.EVM[tv.Type]
+				if !ok {
+					continue
+				}
+				r, err := operations.ExecuteOperation(e.OperationsBundle, VerifyEVMBytecodeOp, e.BlockChains.EVMChains()[selector], VerifyEVMBytecodeInput{
+					ChainSelector: selector,
+					Address:       common.HexToAddress(addr),
+					ContractType:  tv.Type,
+					Artifact:      artifact,
+				})
+				if err != nil {
+					return out, err
+				}
+				report = r.ToGenericReport()
+			case chainsel.FamilySolana:
+				hash, ok := cfg.Artifacts.SolanaPrograms[tv.Type]
+				if !ok {
+					continue
+				}
+				programID, err := solana.PublicKeyFromBase58(addr)
+				if err != nil {
+					return out, fmt.Errorf("invalid program ID %s of %s on chain %d: %w", addr, tv.Type, selector, err)
+				}
+				r, err := operations.ExecuteOperation(e.OperationsBundle, VerifySolanaProgramOp, e.BlockChains.SolanaChains()[selector], VerifySolanaProgramInput{
+					ChainSelector: selector,
+					ProgramID:     programID,
+					ContractType:  tv.Type,
+					ExpectedHash:  hash,
+				})
+				if err != nil {
+					return out, err
+				}
+				report = r.ToGenericReport()
+			case chainsel.FamilySui:
+				digest, ok := cfg.Artifacts.SuiPackages[tv.Type]
+				if !ok {
+					continue
+				}
+				r, err := operations.ExecuteOperation(e.OperationsBundle, VerifySuiPackageOp, e.BlockChains.SuiChains()[selector], VerifySuiPackageInput{
+					ChainSelector:  selector,
+					PackageID:      addr,
+					ContractType:   tv.Type,
+					ExpectedDigest: digest,
+				})
+				if err != nil {
+					return out, err
+				}
+				report = r.ToGenericReport()
+			default:
+				continue
+			}
+			out.Reports = append(out.Reports, report)
+			result, ok := report.Output.(ProvenanceResult)
+			if !ok {
+				return out, fmt.Errorf("unexpected output of %s", report.Def.ID)
+			}
+			if !result.Match {
+				e.Logger.Errorw("Deployed contract does not match its artifact", "chain", selector, "address", addr, "type", tv.Type, "reason", result.Reason, "expected", result.Expected, "actual", result.Actual)
+				mismatches = append(mismatches, result.String())
+				continue
+			}
+			e.Logger.Infow("Deployed contract matches its artifact", "chain", selector, "address", addr, "type", tv.Type, "hash", result.Actual)
+		}
+	}
+	if cfg.FailOnMismatch && len(mismatches) > 0 {
+		return out, fmt.Errorf("%d contract(s) do not match the artifacts of version %s:\n%s", len(mismatches), cfg.Version.String(), strings.Join(mismatches, "\n"))
+	}
+	return out, nil
+}
+
+// compareEVMBytecode compares deployed runtime bytecode with an artifact.
+func compareEVMBytecode(input VerifyEVMBytecodeInput, code []byte) ProvenanceResult {
+	result := ProvenanceResult{
+		ChainSelector: input.ChainSelector,
+		Address:       input.Address.Hex(),
+		ContractType:  input.ContractType,
+	}
+	expected := maskEVMBytecode(input.Artifact.RuntimeBytecode, input.Artifact)
+	actual := maskEVMBytecode(code, input.Artifact)
+	result.Expected = crypto.Keccak256Hash(expected).Hex()
+	result.Actual = crypto.Keccak256Hash(actual).Hex()
+	switch {
+	case len(code) == 0:
+		result.Reason = "no code at address"
+	case len(actual) != len(expected):
+		result.Reason = fmt.Sprintf("bytecode length %d differs from the artifact length %d", len(actual), len(expected))
+	case !bytes.Equal(actual, expected):
+		result.Reason = "bytecode differs from the artifact"
+	default:
+		result.Match = true
+	}
+	return result
+}
+
+// maskEVMBytecode returns a copy of the bytecode with the immutable variables zeroed, and without its metadata if
+// the artifact ignores it.
+func maskEVMBytecode(code []byte, artifact EVMArtifact) []byte {
+	masked := slices.Clone(code)
+	for _, ref := range artifact.ImmutableReferences {
+		if ref.Start < 0 || ref.Length < 0 || ref.Start+ref.Length > len(masked) {
+			continue
+		}
+		clear(masked[ref.Start : ref.Start+ref.Length])
+	}
+	if artifact.IgnoreMetadata && len(masked) >= 2 {
+		// solc appends the CBOR encoded metadata followed by its length on 2 bytes
+		metadataLen := int(binary.BigEndian.Uint16(masked[len(masked)-2:]))
+		if metadataLen+2 <= len(masked) {
+			masked = masked[:len(masked)-metadataLen-2]
+		}
+	}
+	return masked
+}
+
+func solanaExecutableHash(data []byte) string {
+	sum := sha256.Sum256(bytes.TrimRight(data, "\x00"))
+	return hex.EncodeToString(sum[:])
+}
+
+// suiModulesDigest returns the SHA-256 of the sorted SHA-256 hashes of the base64 encoded modules, which does not
+// depend on the order of the modules.
+func suiModulesDigest(modules []string) (string, error) {
+	hashes := make([][]byte, 0, len(modules))
+	for i, module := range modules {
+		data, err := base64.StdEncoding.DecodeString(module)
+		if err != nil {
+			return "", fmt.Errorf("invalid module %d: %w", i, err)
+		}
+		sum := sha256.Sum256(data)
+		hashes = append(hashes, sum[:])
+	}
+	slices.SortFunc(hashes, bytes.Compare)
+	return sha256Hex(bytes.Join(hashes, nil)), nil
+}
@@ -0,0 +1,82 @@
+package changeset
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEVMArtifact(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	hardhat := filepath.Join(dir, "hardhat.json")
+	require.NoError(t, os.WriteFile(hardhat, []byte(`{"deployedBytecode": "0x6001600255"}`), 0o600))
+	artifact, err := LoadEVMArtifact(hardhat)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x60, 0x01, 0x60, 0x02, 0x55}, []byte(artifact.RuntimeBytecode))
+	require.Empty(t, artifact.ImmutableReferences)
+
+	foundry := filepath.Join(dir, "foundry.json")
+	require.NoError(t, os.WriteFile(foundry, []byte(`{"deployedBytecode": {"object": "0x6001600255", "immutableReferences": {"7": [{"start": 3, "length": 1}], "3": [{"start": 1, "length": 1}]}}}`), 0o600))
+	artifact, err = LoadEVMArtifact(foundry)
+	require.NoError(t, err)
+	require.Equal(t, []ByteRange{{Start: 1, Length: 1}, {Start: 3, Length: 1}}, artifact.ImmutableReferences)
+
+	_, err = LoadEVMArtifact(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
+
+func TestCompareEVMBytecode(t *testing.T) {
+	t.Parallel()
+	// runtime code, an immutable at [2, 4) and 3 bytes of metadata followed by their length
+	artifact := EVMArtifact{
+		RuntimeBytecode:     []byte{0x60, 0x80, 0x00, 0x00, 0x56, 0xa1, 0xa2, 0xa3, 0x00, 0x03},
+		ImmutableReferences: []ByteRange{{Start: 2, Length: 2}},
+	}
+	input := VerifyEVMBytecodeInput{ChainSelector: 1, Address: common.HexToAddress("0x01"), ContractType: "TestContract", Artifact: artifact}
+
+	deployed := []byte{0x60, 0x80, 0xbe, 0xef, 0x56, 0xa1, 0xa2, 0xa3, 0x00, 0x03}
+	require.True(t, compareEVMBytecode(input, deployed).Match)
+
+	otherMetadata := []byte{0x60, 0x80, 0xbe, 0xef, 0x56, 0xb1, 0xb2, 0xb3, 0x00, 0x03}
+	result := compareEVMBytecode(input, otherMetadata)
+	require.False(t, result.Match)
+	require.Equal(t, "bytecode differs from the artifact", result.Reason)
+
+	input.Artifact.IgnoreMetadata = true
+	require.True(t, compareEVMBytecode(input, otherMetadata).Match)
+
+	result = compareEVMBytecode(input, nil)
+	require.False(t, result.Match)
+	require.Equal(t, "no code at address", result.Reason)
+}
+
+func TestSolanaAndSuiHashes(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	// the program data account is padded with zeros to the max size of the program
+	program := filepath.Join(dir, "program.so")
+	require.NoError(t, os.WriteFile(program, []byte{0x7f, 'E', 'L', 'F', 0x01}, 0o600))
+	hash, err := SolanaProgramHash(program)
+	require.NoError(t, err)
+	require.Equal(t, hash, solanaExecutableHash([]byte{0x7f, 'E', 'L', 'F', 0x01, 0x00, 0x00, 0x00}))
+
+	// the digest does not depend on the order of the modules
+	a, b := base64.StdEncoding.EncodeToString([]byte("module a")), base64.StdEncoding.EncodeToString([]byte("module b"))
+	build := filepath.Join(dir, "package.json")
+	require.NoError(t, os.WriteFile(build, []byte(`{"modules": ["`+a+`", "`+b+`"], "dependencies": []}`), 0o600))
+	digest, err := SuiPackageDigest(build)
+	require.NoError(t, err)
+	reversed, err := suiModulesDigest([]string{b, a})
+	require.NoError(t, err)
+	require.Equal(t, digest, reversed)
+	other, err := suiModulesDigest([]string{a})
+	require.NoError(t, err)
+	require.NotEqual(t, digest, other)
+}
@@ -75,8 +75,11 @@ func NewEVMCallOperation[IN any, C any](
 		name,
 		version,
 		description,
-		func(b operations.Bundle, chain cldf_evm.Chain, input EVMCallInput[IN]) (EVMCallOutput, error) {
+		func(b operations.Bundle, chain cldf_evm.Chain, input EVMCallInput[IN]) (_ EVMCallOutput, err error) {
+			obs := observeOperation(b.GetContext(), name, version, input.ChainSelector)
+			defer func() { obs.done(err) }()
 			if input.ChainSelector != chain.Selector {
+				obs.fail(FailureInvalidInput)
 				return EVMCallOutput{}, fmt.Errorf("mismatch between inputted chain selector and selector defined within dependencies: %d != %d", input.ChainSelector, chain.Selector)
 			}
 			opts := CloneTransactOptsWithGas(chain.DeployerKey, input.GasLimit, input.GasPrice)
@@ -85,16 +88,24 @@ func NewEVMCallOperation[IN any, C any](
 			}
 			contract, err := constructor(input.Address, chain.Client)
 			if err != nil {
+				obs.fail(FailureBind)
 				return EVMCallOutput{}, fmt.Errorf("failed to create contract instance for %s at %s on %s: %w", name, input.Address, chain, err)
 			}
 			tx, err := call(contract, opts, input.CallInput)
 			confirmed := false
+			if err != nil {
+				obs.fail(FailureSend)
+			}
 			if !input.NoSend {
 				// If the call has actually been sent, we need check the call error and confirm the transaction.
 				_, err := cldf.ConfirmIfNoErrorWithABI(chain, tx, abi, err)
 				if err != nil {
+					if obs.metrics.Failure == "" {
+						obs.fail(FailureConfirm)
+					}
 					return EVMCallOutput{}, fmt.Errorf("failed to confirm %s tx against %s on %s: %w", name, input.Address, chain, err)
 				}
+				obs.confirmed(b.GetContext(), chain, tx)
 				b.Logger.Debugw(fmt.Sprintf("Confirmed %s tx against %s on %s", name, input.Address, chain), "hash", tx.Hash().Hex(), "input", input.CallInput)
 				confirmed = true
 			} else {
@@ -271,7 +282,10 @@ func NewEVMDeployOperation[IN any](
 		name,
 		version,
 		description,
-		func(b operations.Bundle, chain cldf_evm.Chain, input EVMDeployInput[IN]) (EVMDeployOutput, error) {
+		func(b operations.Bundle, chain cldf_evm.Chain, input EVMDeployInput[IN]) (_ EVMDeployOutput, err error) {
+			obs := observeOperation(b.GetContext(), name, version, input.ChainSelector)
+			defer func() { obs.done(err) }()
+			obs.fail(FailureInvalidInput)
 			if input.ChainSelector != chain.Selector {
 				return EVMDeployOutput{}, fmt.Errorf("mismatch between inputted chain selector and selector defined within dependencies: %d != %d", input.ChainSelector, chain.Selector)
 			}
@@ -296,6 +310,7 @@ func NewEVMDeployOperation[IN any](
 			if parsedABI == nil {
 				return EVMDeployOutput{}, fmt.Errorf("ABI is nil for %s", typeAndVersion)
 			}
+			obs.fail(FailureSend)
 
 			var (
 				addr common.Address
@@ -325,11 +340,13 @@ func NewEVMDeployOperation[IN any](
 			}
 			// ZkSync transactions are confirmed in deployZkContract
 			if !chain.IsZkSyncVM {
+				obs.fail(FailureConfirm)
 				_, err := chain.Confirm(tx)
 				if err != nil {
 					b.Logger.Errorw("Failed to confirm deployment", "typeAndVersion", typeAndVersion, "chain", chain.String(), "err", err.Error())
 					return EVMDeployOutput{}, fmt.Errorf("failed to confirm deployment of %s on %s: %w", typeAndVersion, chain, err)
 				}
+				obs.confirmed(b.GetContext(), chain, tx)
 			}
 			return EVMDeployOutput{
 				Address:        addr,
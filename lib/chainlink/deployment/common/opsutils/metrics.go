@@ -0,0 +1,225 @@
+package opsutils
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+)
+
+// FailureType is the step of an operation which failed.
+type FailureType string
+
+const (
+	FailureInvalidInput FailureType = "invalid_input"
+	FailureBind         FailureType = "bind"
+	FailureSend         FailureType = "send"
+	FailureConfirm      FailureType = "confirm"
+	FailureUnknown      FailureType = "unknown"
+)
+
+// OperationMetrics are the metrics of an execution of an operation.
+type OperationMetrics struct {
+	Operation     string
+	Version       string
+	ChainSelector uint64
+	Duration      time.Duration
+	// Transactions is the number of transactions sent and confirmed.
+	Transactions int
+	// GasUsed is the gas used by the confirmed transactions.
+	GasUsed uint64
+	// Failure is empty if the operation succeeded.
+	Failure FailureType
+}
+
+// MetricsSink receives the metrics of the EVM call and deploy operations, as they are executed.
+type MetricsSink interface {
+	ObserveOperation(m OperationMetrics)
+}
+
+type metricsSinkKey struct{}
+
+var defaultMetricsSink atomic.Pointer[MetricsSink]
+
+// SetDefaultMetricsSink sets the sink of the operations whose context has no sink, nil disables it.
+func SetDefaultMetricsSink(sink MetricsSink) {
+	if sink == nil {
+		defaultMetricsSink.Store(nil)
+		return
+	}
+	defaultMetricsSink.Store(&sink)
+}
+
+// ContextWithMetricsSink returns a copy of the context with the sink, which receives the metrics of the operations
+// executed with a bundle using the context, e.g. the operations bundle of an environment whose GetContext returns it.
+func ContextWithMetricsSink(ctx context.Context, sink MetricsSink) context.Context {
+	return context.WithValue(ctx, metricsSinkKey{}, sink)
+}
+
+func metricsSinkFromContext(ctx context.Context) MetricsSink {
+	if ctx != nil {
+		if sink, ok := ctx.Value(metricsSinkKey{}).(MetricsSink); ok {
+			return sink
+		}
+	}
+	if sink := defaultMetricsSink.Load(); sink != nil {
+		return *sink
+	}
+	return nil
+}
+
+// operationObserver collects the metrics of an execution of an operation, nothing is collected without sink.
+type operationObserver struct {
+	sink    MetricsSink
+	start   time.Time
+	metrics OperationMetrics
+}
+
+func observeOperation(ctx context.Context, name string, version *semver.Version, chainSelector uint64) *operationObserver {
+	o := &operationObserver{
+		sink:  metricsSinkFromContext(ctx),
+		start: time.Now(),
+		metrics: OperationMetrics{
+			Operation:     name,
+			ChainSelector: chainSelector,
+		},
+	}
+	if version != nil {
+		o.metrics.Version = version.String()
+	}
+	return o
+}
+
+// fail sets the type of the failure of the operation.
+func (o *operationObserver) fail(failure FailureType) {
+	o.metrics.Failure = failure
+}
+
+// confirmed counts a confirmed transaction and its gas used, read from its receipt.
+func (o *operationObserver) confirmed(ctx context.Context, chain cldf_evm.Chain, tx *types.Transaction) {
+	if o.sink == nil || tx == nil {
+		return
+	}
+	o.metrics.Transactions++
+	receipt, err := chain.Client.TransactionReceipt(ctx, tx.Hash())
+	if err == nil && receipt != nil {
+		o.metrics.GasUsed += receipt.GasUsed
+	}
+}
+
+// done sends the metrics of the operation to the sink.
+func (o *operationObserver) done(err error) {
+	if o.sink == nil {
+		return
+	}
+	if err != nil && o.metrics.Failure == "" {
+		o.metrics.Failure = FailureUnknown
+	}
+	if err == nil {
+		o.metrics.Failure = ""
+	}
+	o.metrics.Duration = time.Since(o.start)
+	o.sink.ObserveOperation(o.metrics)
+}
+
+// PrometheusMetricsSink exposes the metrics of the operations as Prometheus metrics.
+type PrometheusMetricsSink struct {
+	duration     *prometheus.HistogramVec
+	transactions *prometheus.CounterVec
+	gasUsed      *prometheus.CounterVec
+	failures     *prometheus.CounterVec
+}
+
+// NewPrometheusMetricsSink creates the sink, whose metrics are registered with the registerer.
+func NewPrometheusMetricsSink(registerer prometheus.Registerer) (*PrometheusMetricsSink, error) {
+	labels := []string{"operation", "version", "chain_selector"}
+	s := &PrometheusMetricsSink{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "deployment_operation_duration_seconds",
+			Help:    "Duration of the deployment operations",
+			Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300},
+		}, append(labels, "result")),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "deployment_operation_transactions_total",
+			Help: "Transactions confirmed by the deployment operations",
+		}, labels),
+		gasUsed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "deployment_operation_gas_used_total",
+			Help: "Gas used by the transactions of the deployment operations",
+		}, labels),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "deployment_operation_failures_total",
+			Help: "Failures of the deployment operations by type",
+		}, append(labels, "type")),
+	}
+	for _, c := range []prometheus.Collector{s.duration, s.transactions, s.gasUsed, s.failures} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *PrometheusMetricsSink) ObserveOperation(m OperationMetrics) {
+	labels := prometheus.Labels{"operation": m.Operation, "version": m.Version, "chain_selector": strconv.FormatUint(m.ChainSelector, 10)}
+	result := "success"
+	if m.Failure != "" {
+		result = "failure"
+		s.failures.MustCurryWith(labels).WithLabelValues(string(m.Failure)).Inc()
+	}
+	s.duration.MustCurryWith(labels).WithLabelValues(result).Observe(m.Duration.Seconds())
+	s.transactions.With(labels).Add(float64(m.Transactions))
+	s.gasUsed.With(labels).Add(float64(m.GasUsed))
+}
+
+// OTelMetricsSink records the metrics of the operations with an OpenTelemetry meter, e.g. to export them with OTLP.
+type OTelMetricsSink struct {
+	duration     metric.Float64Histogram
+	transactions metric.Int64Counter
+	gasUsed      metric.Int64Counter
+	failures     metric.Int64Counter
+}
+
+// NewOTelMetricsSink creates the sink, whose instruments are created with the meter.
+func NewOTelMetricsSink(meter metric.Meter) (*OTelMetricsSink, error) {
+	s := &OTelMetricsSink{}
+	var err error
+	if s.duration, err = meter.Float64Histogram("deployment.operation.duration", metric.WithUnit("s"), metric.WithDescription("Duration of the deployment operations")); err != nil {
+		return nil, err
+	}
+	if s.transactions, err = meter.Int64Counter("deployment.operation.transactions", metric.WithDescription("Transactions confirmed by the deployment operations")); err != nil {
+		return nil, err
+	}
+	if s.gasUsed, err = meter.Int64Counter("deployment.operation.gas_used", metric.WithDescription("Gas used by the transactions of the deployment operations")); err != nil {
+		return nil, err
+	}
+	if s.failures, err = meter.Int64Counter("deployment.operation.failures", metric.WithDescription("Failures of the deployment operations by type")); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *OTelMetricsSink) ObserveOperation(m OperationMetrics) {
+	ctx := context.Background()
+	attrs := []attribute.KeyValue{
+		attribute.String("operation", m.Operation),
+		attribute.String("version", m.Version),
+		attribute.String("chain_selector", strconv.FormatUint(m.ChainSelector, 10)),
+	}
+	result := "success"
+	if m.Failure != "" {
+		result = "failure"
+		s.failures.Add(ctx, 1, metric.WithAttributes(append(attrs, attribute.String("type", string(m.Failure)))...))
+	}
+	s.duration.Record(ctx, m.Duration.Seconds(), metric.WithAttributes(append(attrs, attribute.String("result", result))...))
+	s.transactions.Add(ctx, int64(m.Transactions), metric.WithAttributes(attrs...))
+	s.gasUsed.Add(ctx, int64(m.GasUsed), metric.WithAttributes(attrs...)) //nolint:gosec // gas used fits in int64
+}
@@ -0,0 +1,96 @@
+package opsutils_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	"github.com/smartcontractkit/chainlink-deployments-framework/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink/deployment/common/opsutils"
+)
+
+type recordingSink struct {
+	mu      sync.Mutex
+	metrics []opsutils.OperationMetrics
+}
+
+func (s *recordingSink) ObserveOperation(m opsutils.OperationMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics = append(s.metrics, m)
+}
+
+func TestEVMCallOperationMetrics(t *testing.T) {
+	t.Parallel()
+	sink := &recordingSink{}
+	bundle := operations.NewBundle(func() context.Context {
+		return opsutils.ContextWithMetricsSink(t.Context(), sink)
+	}, logger.Nop(), operations.NewMemoryReporter())
+
+	op := opsutils.NewEVMCallOperation[string, any](
+		"test",
+		semver.MustParse("1.0.0"),
+		"description",
+		"abi",
+		cldf.ContractType("TestContract"),
+		func(address common.Address, backend bind.ContractBackend) (any, error) {
+			if address == (common.Address{}) {
+				return nil, errors.New("constructor failed")
+			}
+			return struct{}{}, nil
+		},
+		func(contract any, opts *bind.TransactOpts, input string) (*types.Transaction, error) {
+			return types.NewTransaction(0, common.HexToAddress("0x1234"), big.NewInt(0), 21000, big.NewInt(0), nil), nil
+		},
+	)
+	chain := cldf_evm.Chain{Selector: 123}
+
+	_, err := operations.ExecuteOperation(bundle, op, chain, opsutils.EVMCallInput[string]{ChainSelector: 456, Address: common.HexToAddress("0x1234")})
+	require.Error(t, err)
+	_, err = operations.ExecuteOperation(bundle, op, chain, opsutils.EVMCallInput[string]{ChainSelector: 123})
+	require.Error(t, err)
+	_, err = operations.ExecuteOperation(bundle, op, chain, opsutils.EVMCallInput[string]{ChainSelector: 123, Address: common.HexToAddress("0x1234"), NoSend: true})
+	require.NoError(t, err)
+
+	require.Len(t, sink.metrics, 3)
+	require.Equal(t, opsutils.FailureInvalidInput, sink.metrics[0].Failure)
+	require.Equal(t, opsutils.FailureBind, sink.metrics[1].Failure)
+	require.Empty(t, sink.metrics[2].Failure)
+	require.Equal(t, "test", sink.metrics[2].Operation)
+	require.Equal(t, "1.0.0", sink.metrics[2].Version)
+	require.Equal(t, uint64(123), sink.metrics[2].ChainSelector)
+	// transactions which are not sent are not counted
+	require.Zero(t, sink.metrics[2].Transactions)
+}
+
+func TestPrometheusMetricsSink(t *testing.T) {
+	t.Parallel()
+	registry := prometheus.NewRegistry()
+	sink, err := opsutils.NewPrometheusMetricsSink(registry)
+	require.NoError(t, err)
+
+	sink.ObserveOperation(opsutils.OperationMetrics{Operation: "deploy", Version: "1.0.0", ChainSelector: 1, Transactions: 1, GasUsed: 21000})
+	sink.ObserveOperation(opsutils.OperationMetrics{Operation: "deploy", Version: "1.0.0", ChainSelector: 1, Failure: opsutils.FailureConfirm})
+
+	require.Equal(t, 1, testutil.CollectAndCount(registry, "deployment_operation_gas_used_total"))
+	require.Equal(t, 2, testutil.CollectAndCount(registry, "deployment_operation_duration_seconds"))
+	require.Equal(t, 1, testutil.CollectAndCount(registry, "deployment_operation_failures_total"))
+
+	// the metrics cannot be registered twice
+	_, err = opsutils.NewPrometheusMetricsSink(registry)
+	require.Error(t, err)
+}
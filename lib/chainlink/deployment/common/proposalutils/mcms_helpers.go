@@ -108,6 +108,22 @@ func McmsInspectorForChain(env cldf.Environment, chain uint64, opts ...MCMSInspe
 	}
 }
 
+func McmsTimelockInspectorForChain(env cldf.Environment, chain uint64) (mcmssdk.TimelockInspector, error) {
+	chainFamily, err := mcmstypes.GetChainSelectorFamily(mcmstypes.ChainSelector(chain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain family for chain %d: %w", chain, err)
+	}
+
+	switch chainFamily {
+	case chain_selectors.FamilyEVM:
+		return mcmsevmsdk.NewTimelockInspector(env.BlockChains.EVMChains()[chain].Client), nil
+	case chain_selectors.FamilySolana:
+		return mcmssolanasdk.NewTimelockInspector(env.BlockChains.SolanaChains()[chain].Client), nil
+	default:
+		return nil, fmt.Errorf("unsupported chain family %s", chainFamily)
+	}
+}
+
 func McmsInspectors(env cldf.Environment) (map[uint64]mcmssdk.Inspector, error) {
 	evmChains := env.BlockChains.EVMChains()
 	solanaChains := env.BlockChains.SolanaChains()
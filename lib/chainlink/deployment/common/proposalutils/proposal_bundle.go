@@ -0,0 +1,321 @@
+package proposalutils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	mcmslib "github.com/smartcontractkit/mcms"
+	"github.com/smartcontractkit/mcms/types"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// ProposalBundleManifestFile is the name of the manifest in a bundle directory.
+const ProposalBundleManifestFile = "bundle.json"
+
+// ProposalBundlePart references the proposal of a single chain family in a bundle.
+type ProposalBundlePart struct {
+	// File is the proposal file, relative to the bundle directory.
+	File           string   `json:"file"`
+	Family         string   `json:"family"`
+	ChainSelectors []uint64 `json:"chainSelectors"`
+	// Order is the execution order of the part. Parts with the same order can be executed in any order.
+	Order int `json:"order"`
+	// DependsOn are the files of the parts which must be executed before this one.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// SHA256 is the hash of the proposal file, so that edited parts are detected.
+	SHA256 string `json:"sha256"`
+}
+
+// ProposalBundle links the proposals of a change spanning several chain families, e.g. EVM, Solana and Sui, which
+// are signed and executed separately.
+type ProposalBundle struct {
+	Environment string               `json:"environment"`
+	Description string               `json:"description"`
+	Parts       []ProposalBundlePart `json:"parts"`
+
+	proposals map[string]*mcmslib.TimelockProposal
+}
+
+// NewProposalBundle splits the proposals by chain family. The parts of a proposal depend on all the parts of the
+// previous proposal, since proposals are usually generated by changesets applied in sequence.
+func NewProposalBundle(environment, description string, proposals []mcmslib.TimelockProposal) (*ProposalBundle, error) {
+	bundle := &ProposalBundle{
+		Environment: environment,
+		Description: description,
+		proposals:   make(map[string]*mcmslib.TimelockProposal),
+	}
+
+	var previous []string
+	for i := range proposals {
+		byFamily, err := splitProposalByFamily(&proposals[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to split proposal %d: %w", i, err)
+		}
+
+		var current []string
+		for _, family := range slices.Sorted(maps.Keys(byFamily)) {
+			proposal := byFamily[family]
+			if proposal.Description == "" {
+				proposal.Description = description
+			}
+			raw, err := encodeTimelockProposal(proposal)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode %s part of proposal %d: %w", family, i, err)
+			}
+
+			file := fmt.Sprintf("%02d-%s.json", i, family)
+			bundle.Parts = append(bundle.Parts, ProposalBundlePart{
+				File:           file,
+				Family:         family,
+				ChainSelectors: proposalChainSelectors(proposal),
+				Order:          i,
+				DependsOn:      slices.Clone(previous),
+				SHA256:         sha256Hex(raw),
+			})
+			bundle.proposals[file] = proposal
+			current = append(current, file)
+		}
+		previous = current
+	}
+
+	return bundle, nil
+}
+
+// Proposal returns the proposal of a part.
+func (b *ProposalBundle) Proposal(part ProposalBundlePart) (*mcmslib.TimelockProposal, error) {
+	proposal, ok := b.proposals[part.File]
+	if !ok {
+		return nil, fmt.Errorf("proposal %s not found in bundle", part.File)
+	}
+	return proposal, nil
+}
+
+// Write writes the manifest and the proposals to the directory.
+func (b *ProposalBundle) Write(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+
+	for _, part := range b.Parts {
+		proposal, err := b.Proposal(part)
+		if err != nil {
+			return err
+		}
+		raw, err := encodeTimelockProposal(proposal)
+		if err != nil {
+			return fmt.Errorf("failed to encode proposal %s: %w", part.File, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, part.File), raw, 0o600); err != nil {
+			return fmt.Errorf("failed to write proposal %s: %w", part.File, err)
+		}
+	}
+
+	manifest, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ProposalBundleManifestFile), manifest, 0o600); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+
+	return nil
+}
+
+// LoadProposalBundle reads a bundle written by Write, checking that the proposal files match the manifest.
+func LoadProposalBundle(dir string) (*ProposalBundle, error) {
+	manifest, err := os.ReadFile(filepath.Join(dir, ProposalBundleManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle manifest: %w", err)
+	}
+	var bundle ProposalBundle
+	if err := json.Unmarshal(manifest, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bundle manifest: %w", err)
+	}
+
+	bundle.proposals = make(map[string]*mcmslib.TimelockProposal, len(bundle.Parts))
+	for _, part := range bundle.Parts {
+		if part.File != filepath.Base(part.File) {
+			return nil, fmt.Errorf("proposal %s is outside of the bundle directory", part.File)
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, part.File))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read proposal %s: %w", part.File, err)
+		}
+		if sum := sha256Hex(raw); sum != part.SHA256 {
+			return nil, fmt.Errorf("proposal %s was modified: expected sha256 %s, got %s", part.File, part.SHA256, sum)
+		}
+		proposal, err := mcmslib.NewTimelockProposal(bytes.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proposal %s: %w", part.File, err)
+		}
+		bundle.proposals[part.File] = proposal
+	}
+
+	return &bundle, nil
+}
+
+// Verify checks that every part targets the environment: the chains are part of it, belong to the family of the
+// part, and the MCMS and timelock addresses are known for the chain. It also checks the dependencies are executed
+// before the parts depending on them.
+func (b *ProposalBundle) Verify(env cldf.Environment) error {
+	var errs []error
+	if b.Environment != env.Name {
+		errs = append(errs, fmt.Errorf("bundle targets environment %s, not %s", b.Environment, env.Name))
+	}
+
+	orders := make(map[string]int, len(b.Parts))
+	for _, part := range b.Parts {
+		orders[part.File] = part.Order
+	}
+
+	for _, part := range b.Parts {
+		proposal, err := b.Proposal(part)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if got := proposalChainSelectors(proposal); !slices.Equal(got, part.ChainSelectors) {
+			errs = append(errs, fmt.Errorf("%s: proposal targets chains %v, manifest lists %v", part.File, got, part.ChainSelectors))
+		}
+		for _, dep := range part.DependsOn {
+			order, ok := orders[dep]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: depends on unknown part %s", part.File, dep))
+			} else if order >= part.Order {
+				errs = append(errs, fmt.Errorf("%s: depends on %s which is not ordered before it", part.File, dep))
+			}
+		}
+
+		for sel, metadata := range proposal.ChainMetadata {
+			chainSel := uint64(sel)
+			family, err := chain_selectors.GetSelectorFamily(chainSel)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: unknown chain %d: %w", part.File, chainSel, err))
+				continue
+			}
+			if family != part.Family {
+				errs = append(errs, fmt.Errorf("%s: chain %d is %s, not %s", part.File, chainSel, family, part.Family))
+			}
+			if !env.BlockChains.Exists(chainSel) {
+				errs = append(errs, fmt.Errorf("%s: chain %d is not part of environment %s", part.File, chainSel, env.Name))
+				continue
+			}
+
+			known := knownAddresses(env, chainSel)
+			if !known[normalizeBundleAddress(family, metadata.MCMAddress)] {
+				errs = append(errs, fmt.Errorf("%s: MCM %s is not deployed on chain %d in environment %s", part.File, metadata.MCMAddress, chainSel, env.Name))
+			}
+			if timelock := proposal.TimelockAddresses[sel]; !known[normalizeBundleAddress(family, timelock)] {
+				errs = append(errs, fmt.Errorf("%s: timelock %s is not deployed on chain %d in environment %s", part.File, timelock, chainSel, env.Name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// splitProposalByFamily returns a proposal per chain family, with the operations, metadata and timelocks of the
+// chains of that family.
+func splitProposalByFamily(proposal *mcmslib.TimelockProposal) (map[string]*mcmslib.TimelockProposal, error) {
+	out := make(map[string]*mcmslib.TimelockProposal)
+	part := func(sel types.ChainSelector) (*mcmslib.TimelockProposal, error) {
+		family, err := chain_selectors.GetSelectorFamily(uint64(sel))
+		if err != nil {
+			return nil, fmt.Errorf("unknown chain %d: %w", sel, err)
+		}
+		if p, ok := out[family]; ok {
+			return p, nil
+		}
+		p := *proposal
+		p.Signatures = nil
+		p.ChainMetadata = make(map[types.ChainSelector]types.ChainMetadata)
+		p.TimelockAddresses = make(map[types.ChainSelector]string)
+		p.Operations = nil
+		out[family] = &p
+		return &p, nil
+	}
+
+	for sel, metadata := range proposal.ChainMetadata {
+		p, err := part(sel)
+		if err != nil {
+			return nil, err
+		}
+		p.ChainMetadata[sel] = metadata
+		if timelock, ok := proposal.TimelockAddresses[sel]; ok {
+			p.TimelockAddresses[sel] = timelock
+		}
+	}
+	for _, op := range proposal.Operations {
+		p, err := part(op.ChainSelector)
+		if err != nil {
+			return nil, err
+		}
+		p.Operations = append(p.Operations, op)
+	}
+
+	return out, nil
+}
+
+func proposalChainSelectors(proposal *mcmslib.TimelockProposal) []uint64 {
+	sels := make([]uint64, 0, len(proposal.ChainMetadata))
+	for sel := range proposal.ChainMetadata {
+		sels = append(sels, uint64(sel))
+	}
+	slices.Sort(sels)
+	return sels
+}
+
+func encodeTimelockProposal(proposal *mcmslib.TimelockProposal) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := mcmslib.WriteTimelockProposal(&buf, proposal); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func sha256Hex(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// knownAddresses returns the addresses of the chain in the datastore and the address book of the environment.
+func knownAddresses(env cldf.Environment, chainSel uint64) map[string]bool {
+	family, _ := chain_selectors.GetSelectorFamily(chainSel)
+	known := make(map[string]bool)
+	if env.DataStore != nil {
+		for _, ref := range env.DataStore.Addresses().Filter(datastore.AddressRefByChainSelector(chainSel)) {
+			known[normalizeBundleAddress(family, ref.Address)] = true
+		}
+	}
+	if env.ExistingAddresses != nil {
+		addresses, err := env.ExistingAddresses.AddressesForChain(chainSel)
+		if err == nil {
+			for addr := range addresses {
+				known[normalizeBundleAddress(family, addr)] = true
+			}
+		}
+	}
+	return known
+}
+
+// normalizeBundleAddress makes addresses comparable. Hex addresses are case-insensitive, and Solana MCMS addresses
+// are the program ID followed by the seed.
+func normalizeBundleAddress(family, addr string) string {
+	if family == chain_selectors.FamilySolana {
+		program, _, _ := strings.Cut(addr, ".")
+		return program
+	}
+	return strings.ToLower(addr)
+}
@@ -0,0 +1,137 @@
+package proposalutils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+	solanasdk "github.com/gagliardetto/solana-go"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	mcmslib "github.com/smartcontractkit/mcms"
+	"github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cldf_chain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf_solana "github.com/smartcontractkit/chainlink-deployments-framework/chain/solana"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+func TestProposalBundle(t *testing.T) {
+	evmSelector := chain_selectors.TEST_90000001.Selector
+	solSelector := chain_selectors.TEST_22222222222222222222222222222222222222222222.Selector
+	evmMCM := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	evmTimelock := common.HexToAddress("0x00000000000000000000000000000000000000a2")
+	target := common.HexToAddress("0x00000000000000000000000000000000000000a3")
+	solMCM := solanasdk.NewWallet().PublicKey()
+	solTimelock := solanasdk.NewWallet().PublicKey()
+	program := solanasdk.NewWallet().PublicKey()
+
+	solFields, err := json.Marshal(map[string]any{"accounts": []*solanasdk.AccountMeta{{PublicKey: solanasdk.NewWallet().PublicKey()}}})
+	require.NoError(t, err)
+
+	proposal := func(description string) mcmslib.TimelockProposal {
+		return mcmslib.TimelockProposal{
+			BaseProposal: mcmslib.BaseProposal{
+				Version:     "v1",
+				Kind:        types.KindTimelockProposal,
+				Description: description,
+				ValidUntil:  uint32(time.Now().Add(time.Hour).Unix()), //nolint:gosec // G115
+				ChainMetadata: map[types.ChainSelector]types.ChainMetadata{
+					types.ChainSelector(evmSelector): {MCMAddress: evmMCM.Hex()},
+					types.ChainSelector(solSelector): {MCMAddress: solMCM.String() + ".seed"},
+				},
+			},
+			Action: types.TimelockActionSchedule,
+			Delay:  types.NewDuration(time.Hour),
+			TimelockAddresses: map[types.ChainSelector]string{
+				types.ChainSelector(evmSelector): evmTimelock.Hex(),
+				types.ChainSelector(solSelector): solTimelock.String() + ".seed",
+			},
+			Operations: []types.BatchOperation{
+				{
+					ChainSelector: types.ChainSelector(evmSelector),
+					Transactions:  []types.Transaction{{To: target.Hex(), Data: []byte{0x01}, AdditionalFields: json.RawMessage(`{"value": 0}`)}},
+				},
+				{
+					ChainSelector: types.ChainSelector(solSelector),
+					Transactions:  []types.Transaction{{To: program.String(), Data: []byte{0x02}, AdditionalFields: solFields}},
+				},
+			},
+		}
+	}
+
+	bundle, err := NewProposalBundle("testnet", "upgrade", []mcmslib.TimelockProposal{proposal(""), proposal("second")})
+	require.NoError(t, err)
+	require.Len(t, bundle.Parts, 4)
+	assert.Equal(t, "00-evm.json", bundle.Parts[0].File)
+	assert.Equal(t, []uint64{evmSelector}, bundle.Parts[0].ChainSelectors)
+	assert.Equal(t, "00-solana.json", bundle.Parts[1].File)
+	assert.Empty(t, bundle.Parts[1].DependsOn)
+	assert.Equal(t, 1, bundle.Parts[2].Order)
+	assert.Equal(t, []string{"00-evm.json", "00-solana.json"}, bundle.Parts[2].DependsOn)
+
+	first, err := bundle.Proposal(bundle.Parts[0])
+	require.NoError(t, err)
+	assert.Equal(t, "upgrade", first.Description)
+	require.Len(t, first.Operations, 1)
+	assert.Len(t, first.TimelockAddresses, 1)
+
+	dir := t.TempDir()
+	require.NoError(t, bundle.Write(dir))
+	loaded, err := LoadProposalBundle(dir)
+	require.NoError(t, err)
+	assert.Equal(t, bundle.Parts, loaded.Parts)
+
+	ds := datastore.NewMemoryDataStore()
+	for _, ref := range []datastore.AddressRef{
+		{ChainSelector: evmSelector, Address: evmMCM.Hex(), Type: "ManyChainMultiSig", Version: semver.MustParse("1.0.0")},
+		{ChainSelector: evmSelector, Address: evmTimelock.Hex(), Type: "RBACTimelock", Version: semver.MustParse("1.0.0")},
+		{ChainSelector: solSelector, Address: solMCM.String(), Type: "ManyChainMultiSigProgram", Version: semver.MustParse("1.0.0")},
+		{ChainSelector: solSelector, Address: solTimelock.String(), Type: "RBACTimelockProgram", Version: semver.MustParse("1.0.0")},
+	} {
+		require.NoError(t, ds.Addresses().Add(ref))
+	}
+	env := cldf.Environment{
+		Name:      "testnet",
+		DataStore: ds.Seal(),
+		BlockChains: cldf_chain.NewBlockChains(map[uint64]cldf_chain.BlockChain{
+			evmSelector: cldf_evm.Chain{Selector: evmSelector},
+			solSelector: cldf_solana.Chain{Selector: solSelector},
+		}),
+	}
+
+	t.Run("verify", func(t *testing.T) {
+		require.NoError(t, loaded.Verify(env))
+	})
+
+	t.Run("wrong environment", func(t *testing.T) {
+		other := env
+		other.Name = "mainnet"
+		other.BlockChains = cldf_chain.NewBlockChains(map[uint64]cldf_chain.BlockChain{
+			evmSelector: cldf_evm.Chain{Selector: evmSelector},
+		})
+		other.DataStore = datastore.NewMemoryDataStore().Seal()
+
+		err := loaded.Verify(other)
+		require.ErrorContains(t, err, "bundle targets environment testnet, not mainnet")
+		require.ErrorContains(t, err, "is not part of environment mainnet")
+		require.ErrorContains(t, err, "MCM "+evmMCM.Hex()+" is not deployed")
+	})
+
+	t.Run("modified part", func(t *testing.T) {
+		path := filepath.Join(dir, "01-evm.json")
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, append(raw, '\n'), 0o600))
+
+		_, err = LoadProposalBundle(dir)
+		require.ErrorContains(t, err, "proposal 01-evm.json was modified")
+	})
+}
@@ -0,0 +1,250 @@
+package proposalutils
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmssolanasdk "github.com/smartcontractkit/mcms/sdk/solana"
+	"github.com/smartcontractkit/mcms/types"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// DecodedArgument is a decoded input of a call.
+type DecodedArgument struct {
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	Annotation string `json:"annotation,omitempty"`
+}
+
+// DecodedTransaction is a transaction of a proposal, decoded when its target is known.
+type DecodedTransaction struct {
+	ChainSelector    uint64 `json:"chainSelector"`
+	ChainName        string `json:"chainName,omitempty"`
+	OperationIndex   int    `json:"operationIndex"`
+	TransactionIndex int    `json:"transactionIndex"`
+	To               string `json:"to"`
+	// Contract is the type and version of the target, resolved from the addresses.
+	Contract string            `json:"contract,omitempty"`
+	Method   string            `json:"method,omitempty"`
+	Inputs   []DecodedArgument `json:"inputs,omitempty"`
+	// Accounts are the accounts of Solana instructions.
+	Accounts []string `json:"accounts,omitempty"`
+	// Data is the raw calldata, for reviewers to double check.
+	Data hexutil.Bytes `json:"data"`
+	// Error is why the transaction could not be decoded.
+	Error string `json:"error,omitempty"`
+}
+
+// DecodedProposal is the human-readable content of a timelock proposal.
+type DecodedProposal struct {
+	Description  string               `json:"description"`
+	Action       types.TimelockAction `json:"action"`
+	Delay        string               `json:"delay"`
+	ValidUntil   uint32               `json:"validUntil"`
+	Transactions []DecodedTransaction `json:"transactions"`
+}
+
+// ProposalDecoder decodes the transactions of timelock proposals for review. Targets are resolved with Addresses,
+// then EVM calldata is decoded with the ABI of the contract type and Solana instructions with the instruction names
+// of the program type.
+type ProposalDecoder struct {
+	Addresses cldf.AddressesByChain
+	ABIs      map[cldf.ContractType]*abi.ABI
+	// SolanaInstructions are the Anchor instruction names of each program type.
+	SolanaInstructions map[cldf.ContractType][]string
+	// Analyzers are extra analyzers of the EVM arguments.
+	Analyzers []Analyzer
+}
+
+// AddressesByChainFromRefs converts datastore address refs, so targets can be resolved with the datastore as well
+// as with the address book.
+func AddressesByChainFromRefs(refs []datastore.AddressRef) cldf.AddressesByChain {
+	out := make(cldf.AddressesByChain)
+	for _, ref := range refs {
+		if ref.Version == nil {
+			continue
+		}
+		if out[ref.ChainSelector] == nil {
+			out[ref.ChainSelector] = make(map[string]cldf.TypeAndVersion)
+		}
+		tv := cldf.NewTypeAndVersion(cldf.ContractType(ref.Type), *ref.Version)
+		for _, label := range ref.Labels.List() {
+			tv.AddLabel(label)
+		}
+		out[ref.ChainSelector][ref.Address] = tv
+	}
+	return out
+}
+
+// Decode decodes all the transactions. Transactions which cannot be decoded keep their raw data and the reason.
+func (d *ProposalDecoder) Decode(proposal *mcmslib.TimelockProposal) DecodedProposal {
+	out := DecodedProposal{
+		Description: proposal.Description,
+		Action:      proposal.Action,
+		Delay:       proposal.Delay.String(),
+		ValidUntil:  proposal.ValidUntil,
+	}
+
+	argCtx := NewArgumentContext(d.Addresses)
+	for i, op := range proposal.Operations {
+		chainSelector := uint64(op.ChainSelector)
+		chainName, _ := GetChainNameBySelector(chainSelector)
+		for j, tx := range op.Transactions {
+			decoded := DecodedTransaction{
+				ChainSelector:    chainSelector,
+				ChainName:        chainName,
+				OperationIndex:   i,
+				TransactionIndex: j,
+				To:               tx.To,
+				Data:             tx.Data,
+			}
+			if err := d.decodeTransaction(&decoded, chainSelector, tx, argCtx); err != nil {
+				decoded.Error = err.Error()
+			}
+			out.Transactions = append(out.Transactions, decoded)
+		}
+	}
+
+	return out
+}
+
+func (d *ProposalDecoder) decodeTransaction(out *DecodedTransaction, chainSelector uint64, tx types.Transaction, argCtx *ArgumentContext) error {
+	contractType := cldf.ContractType(tx.ContractType)
+	if tv, ok := d.lookup(chainSelector, tx.To); ok {
+		out.Contract = tv.String()
+		contractType = tv.Type
+	}
+	if contractType == "" {
+		return errors.New("unknown target")
+	}
+
+	family, err := chain_selectors.GetSelectorFamily(chainSelector)
+	if err != nil {
+		return err
+	}
+	switch family {
+	case chain_selectors.FamilyEVM:
+		contractABI, ok := d.ABIs[contractType]
+		if !ok {
+			return fmt.Errorf("no ABI for %s", contractType)
+		}
+		call, err := NewTxCallDecoder(d.Analyzers).Analyze(tx.To, contractABI, tx.Data)
+		if err != nil {
+			return fmt.Errorf("failed to decode calldata: %w", err)
+		}
+		out.Method = call.Method
+		for _, input := range call.Inputs {
+			arg := DecodedArgument{Name: input.Name, Value: input.Value.Describe(argCtx)}
+			if addr, ok := input.Value.(AddressArgument); ok {
+				arg.Annotation = addr.Annotation(argCtx)
+			}
+			out.Inputs = append(out.Inputs, arg)
+		}
+	case chain_selectors.FamilySolana:
+		instructions, ok := d.SolanaInstructions[contractType]
+		if !ok {
+			return fmt.Errorf("no instructions for %s", contractType)
+		}
+		name, ok := solanaInstruction(instructions, tx.Data)
+		if !ok {
+			return errors.New("unknown instruction discriminator")
+		}
+		out.Method = name
+		out.Inputs = []DecodedArgument{{Name: "data", Value: hexutil.Encode(tx.Data[8:])}}
+
+		var fields mcmssolanasdk.AdditionalFields
+		if err := json.Unmarshal(tx.AdditionalFields, &fields); err != nil {
+			return fmt.Errorf("failed to unmarshal accounts: %w", err)
+		}
+		for _, account := range fields.Accounts {
+			out.Accounts = append(out.Accounts, account.PublicKey.String())
+		}
+	default:
+		return fmt.Errorf("decoding %s transactions is not supported", family)
+	}
+
+	return nil
+}
+
+// lookup resolves the address, ignoring the case of EVM hex addresses.
+func (d *ProposalDecoder) lookup(chainSelector uint64, address string) (cldf.TypeAndVersion, bool) {
+	for addr, tv := range d.Addresses[chainSelector] {
+		if strings.EqualFold(addr, address) {
+			return tv, true
+		}
+	}
+	return cldf.TypeAndVersion{}, false
+}
+
+// solanaInstruction returns the Anchor instruction whose discriminator, the first 8 bytes of
+// sha256("global:<name>"), prefixes the data.
+func solanaInstruction(instructions []string, data []byte) (string, bool) {
+	if len(data) < 8 {
+		return "", false
+	}
+	for _, name := range instructions {
+		discriminator := sha256.Sum256([]byte("global:" + name))
+		if string(discriminator[:8]) == string(data[:8]) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// JSON renders the decoded proposal as indented JSON.
+func (p DecodedProposal) JSON() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// Markdown renders the decoded proposal for reviewers, one section per transaction.
+func (p DecodedProposal) Markdown() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## %s\n\n", p.Description))
+	b.WriteString(fmt.Sprintf("**Action:** `%s` **Delay:** `%s` **Valid until:** `%d`\n\n", p.Action, p.Delay, p.ValidUntil))
+
+	for _, tx := range p.Transactions {
+		chainName := tx.ChainName
+		if chainName == "" {
+			chainName = "<chain unknown>"
+		}
+		b.WriteString(fmt.Sprintf("### Operation %d, transaction %d\n", tx.OperationIndex, tx.TransactionIndex))
+		b.WriteString(fmt.Sprintf("**Chain selector:** `%d` (`%s`)\n", tx.ChainSelector, chainName))
+		b.WriteString(fmt.Sprintf("**Address:** `%s`", tx.To))
+		if tx.Contract != "" {
+			b.WriteString(fmt.Sprintf(" <sub><i>%s</i></sub>", tx.Contract))
+		}
+		b.WriteString("\n")
+
+		if tx.Error != "" {
+			b.WriteString(fmt.Sprintf("**Not decoded:** %s\n\n**Data:** `%s`\n\n", tx.Error, tx.Data))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("**Method:** `%s`\n\n", tx.Method))
+		if len(tx.Inputs) > 0 {
+			b.WriteString("| Name | Value | Annotation |\n")
+			b.WriteString("|------|-------|------------|\n")
+			for _, in := range tx.Inputs {
+				value := strings.ReplaceAll(strings.ReplaceAll(in.Value, "|", "\\|"), "\n", "<br>")
+				b.WriteString(fmt.Sprintf("| `%s` | `%s` | %s |\n", in.Name, value, strings.ReplaceAll(in.Annotation, "|", "\\|")))
+			}
+			b.WriteString("\n")
+		}
+		for _, account := range tx.Accounts {
+			b.WriteString(fmt.Sprintf("- account `%s`\n", account))
+		}
+		if len(tx.Accounts) > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
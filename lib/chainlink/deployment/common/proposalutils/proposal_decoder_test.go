@@ -0,0 +1,98 @@
+package proposalutils
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	solanasdk "github.com/gagliardetto/solana-go"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	mcmslib "github.com/smartcontractkit/mcms"
+	"github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+func TestProposalDecoder(t *testing.T) {
+	evmSelector := chain_selectors.TEST_90000001.Selector
+	solSelector := chain_selectors.TEST_22222222222222222222222222222222222222222222.Selector
+	target := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	program := solanasdk.NewWallet().PublicKey()
+	account := solanasdk.NewWallet().PublicKey()
+
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"transferOwnership","inputs":[{"name":"to","type":"address"}],"outputs":[]}]`))
+	require.NoError(t, err)
+	evmData, err := parsed.Pack("transferOwnership", owner)
+	require.NoError(t, err)
+
+	discriminator := sha256.Sum256([]byte("global:accept_ownership"))
+	solData := append(discriminator[:8], 0x01)
+	solFields, err := json.Marshal(map[string]any{"accounts": []*solanasdk.AccountMeta{{PublicKey: account}}})
+	require.NoError(t, err)
+
+	addresses := AddressesByChainFromRefs([]datastore.AddressRef{
+		{ChainSelector: evmSelector, Address: target.Hex(), Type: "Registry", Version: semver.MustParse("1.0.0")},
+		{ChainSelector: evmSelector, Address: owner.Hex(), Type: "RBACTimelock", Version: semver.MustParse("1.0.0")},
+		{ChainSelector: solSelector, Address: program.String(), Type: "Router", Version: semver.MustParse("1.0.0")},
+	})
+	decoder := &ProposalDecoder{
+		Addresses:          addresses,
+		ABIs:               map[cldf.ContractType]*abi.ABI{"Registry": &parsed},
+		SolanaInstructions: map[cldf.ContractType][]string{"Router": {"transfer_ownership", "accept_ownership"}},
+	}
+
+	proposal := &mcmslib.TimelockProposal{
+		BaseProposal: mcmslib.BaseProposal{Description: "transfer ownership", ValidUntil: 100},
+		Action:       types.TimelockActionSchedule,
+		Operations: []types.BatchOperation{
+			{ChainSelector: types.ChainSelector(evmSelector), Transactions: []types.Transaction{
+				// Address book keys are checksummed, calldata targets may not be
+				{To: strings.ToLower(target.Hex()), Data: evmData},
+				{To: "0x00000000000000000000000000000000000000cc", Data: evmData},
+			}},
+			{ChainSelector: types.ChainSelector(solSelector), Transactions: []types.Transaction{
+				{To: program.String(), Data: solData, AdditionalFields: solFields},
+			}},
+		},
+	}
+
+	decoded := decoder.Decode(proposal)
+	require.Len(t, decoded.Transactions, 3)
+
+	evmTx := decoded.Transactions[0]
+	assert.Empty(t, evmTx.Error)
+	assert.Equal(t, "Registry 1.0.0", evmTx.Contract)
+	assert.Equal(t, parsed.Methods["transferOwnership"].String(), evmTx.Method)
+	require.Len(t, evmTx.Inputs, 1)
+	assert.Equal(t, "to", evmTx.Inputs[0].Name)
+	assert.Equal(t, owner.Hex(), evmTx.Inputs[0].Value)
+	assert.Contains(t, evmTx.Inputs[0].Annotation, "RBACTimelock 1.0.0")
+
+	assert.Equal(t, "unknown target", decoded.Transactions[1].Error)
+
+	solTx := decoded.Transactions[2]
+	assert.Empty(t, solTx.Error)
+	assert.Equal(t, "accept_ownership", solTx.Method)
+	assert.Equal(t, []string{account.String()}, solTx.Accounts)
+	assert.Equal(t, "0x01", solTx.Inputs[0].Value)
+
+	markdown := decoded.Markdown()
+	assert.Contains(t, markdown, "## transfer ownership")
+	assert.Contains(t, markdown, "### Operation 0, transaction 1")
+	assert.Contains(t, markdown, "**Not decoded:** unknown target")
+	assert.Contains(t, markdown, "- account `"+account.String()+"`")
+
+	raw, err := decoded.JSON()
+	require.NoError(t, err)
+	var roundTrip DecodedProposal
+	require.NoError(t, json.Unmarshal(raw, &roundTrip))
+	assert.Equal(t, decoded, roundTrip)
+}
@@ -0,0 +1,327 @@
+package proposalutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmssdk "github.com/smartcontractkit/mcms/sdk"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// DefaultExpiryWarning is how long before validUntil a proposal which is not signed is reported.
+const DefaultExpiryWarning = 24 * time.Hour
+
+// ProposalChainState is the state of a timelock proposal on one chain.
+type ProposalChainState string
+
+const (
+	// ProposalUnsigned means the root of the proposal is not set on the MCM yet.
+	ProposalUnsigned ProposalChainState = "unsigned"
+	// ProposalRootSet means the signed root is set on the MCM, but the operations are not executed yet.
+	ProposalRootSet ProposalChainState = "root-set"
+	// ProposalScheduled means the operations are scheduled on the timelock, waiting for the delay.
+	ProposalScheduled ProposalChainState = "scheduled"
+	// ProposalReady means the delay elapsed and the operations can be executed on the timelock.
+	ProposalReady ProposalChainState = "ready"
+	// ProposalExecuted means all the operations are executed.
+	ProposalExecuted ProposalChainState = "executed"
+	// ProposalCancelled means the operations were scheduled and then cancelled.
+	ProposalCancelled ProposalChainState = "cancelled"
+	// ProposalExpired means the proposal is past validUntil without its root set.
+	ProposalExpired ProposalChainState = "expired"
+)
+
+// Final returns whether the state can no longer change.
+func (s ProposalChainState) Final() bool {
+	return s == ProposalExecuted || s == ProposalCancelled || s == ProposalExpired
+}
+
+// ProposalChainStatus is the status of a timelock proposal on one chain.
+type ProposalChainStatus struct {
+	ChainSelector uint64             `json:"chainSelector"`
+	State         ProposalChainState `json:"state"`
+	// Operations is the number of timelock operations of the proposal on the chain, and Done how many are executed.
+	Operations int `json:"operations"`
+	Done       int `json:"done"`
+	// Error is why the status could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// ProposalStatus is the status of a timelock proposal on all its chains.
+type ProposalStatus struct {
+	Description string                `json:"description"`
+	ValidUntil  time.Time             `json:"validUntil"`
+	Remaining   time.Duration         `json:"remaining"`
+	Chains      []ProposalChainStatus `json:"chains"`
+	Warnings    []string              `json:"warnings,omitempty"`
+}
+
+// Pending returns whether the proposal can still progress on any chain.
+func (s ProposalStatus) Pending() bool {
+	return slices.ContainsFunc(s.Chains, func(c ProposalChainStatus) bool { return !c.State.Final() })
+}
+
+// ProposalMonitor reads the status of timelock proposals on their chains, to catch proposals expiring unsigned.
+type ProposalMonitor struct {
+	Inspectors         map[uint64]mcmssdk.Inspector
+	TimelockInspectors map[uint64]mcmssdk.TimelockInspector
+	Converters         map[uint64]mcmssdk.TimelockConverter
+	// ExpiryWarning is how long before validUntil a proposal which is not signed is reported, DefaultExpiryWarning
+	// if zero.
+	ExpiryWarning time.Duration
+	// Now is the clock, time.Now if nil.
+	Now func() time.Time
+}
+
+// NewProposalMonitor creates a monitor for the EVM and Solana chains of the environment.
+func NewProposalMonitor(env cldf.Environment) (*ProposalMonitor, error) {
+	m := &ProposalMonitor{
+		Inspectors:         make(map[uint64]mcmssdk.Inspector),
+		TimelockInspectors: make(map[uint64]mcmssdk.TimelockInspector),
+		Converters:         make(map[uint64]mcmssdk.TimelockConverter),
+	}
+
+	selectors := make([]uint64, 0)
+	for sel := range env.BlockChains.EVMChains() {
+		selectors = append(selectors, sel)
+	}
+	for sel := range env.BlockChains.SolanaChains() {
+		selectors = append(selectors, sel)
+	}
+	for _, sel := range selectors {
+		var err error
+		if m.Inspectors[sel], err = McmsInspectorForChain(env, sel); err != nil {
+			return nil, fmt.Errorf("failed to get mcms inspector for chain %d: %w", sel, err)
+		}
+		if m.TimelockInspectors[sel], err = McmsTimelockInspectorForChain(env, sel); err != nil {
+			return nil, fmt.Errorf("failed to get timelock inspector for chain %d: %w", sel, err)
+		}
+		if m.Converters[sel], err = McmsTimelockConverterForChain(sel); err != nil {
+			return nil, fmt.Errorf("failed to get timelock converter for chain %d: %w", sel, err)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *ProposalMonitor) now() time.Time {
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+// Check reads the status of the proposal on each of its chains. Chains which cannot be read are reported with an
+// error instead of failing the whole check.
+func (m *ProposalMonitor) Check(ctx context.Context, proposal *mcmslib.TimelockProposal) (ProposalStatus, error) {
+	validUntil := time.Unix(int64(proposal.ValidUntil), 0)
+	status := ProposalStatus{
+		Description: proposal.Description,
+		ValidUntil:  validUntil,
+		Remaining:   max(validUntil.Sub(m.now()), 0),
+	}
+
+	converted, opIDs, err := m.convert(ctx, proposal)
+	if err != nil {
+		return ProposalStatus{}, err
+	}
+	tree, err := converted.MerkleTree()
+	if err != nil {
+		return ProposalStatus{}, fmt.Errorf("failed to compute merkle root: %w", err)
+	}
+	mcmOps := converted.TransactionCounts()
+
+	selectors := make([]mcmstypes.ChainSelector, 0, len(proposal.ChainMetadata))
+	for sel := range proposal.ChainMetadata {
+		selectors = append(selectors, sel)
+	}
+	slices.Sort(selectors)
+
+	for _, sel := range selectors {
+		chain, err := m.checkChain(ctx, proposal, sel, tree.Root, mcmOps[sel], opIDs[sel])
+		if err != nil {
+			chain = ProposalChainStatus{ChainSelector: uint64(sel), Operations: len(opIDs[sel]), Error: err.Error()}
+		}
+		status.Chains = append(status.Chains, chain)
+
+		switch {
+		case chain.Error != "":
+			status.Warnings = append(status.Warnings, fmt.Sprintf("failed to read status on chain %d: %s", sel, chain.Error))
+		case chain.State == ProposalExpired:
+			status.Warnings = append(status.Warnings, fmt.Sprintf("proposal expired at %s without being signed on chain %d", validUntil.UTC().Format(time.RFC3339), sel))
+		case chain.State == ProposalUnsigned && status.Remaining < m.expiryWarning():
+			status.Warnings = append(status.Warnings, fmt.Sprintf("proposal expires in %s and is not signed on chain %d", status.Remaining.Round(time.Second), sel))
+		}
+	}
+
+	return status, nil
+}
+
+func (m *ProposalMonitor) expiryWarning() time.Duration {
+	if m.ExpiryWarning > 0 {
+		return m.ExpiryWarning
+	}
+	return DefaultExpiryWarning
+}
+
+// convert converts the proposal to the proposal executed by the MCMs, and returns the IDs of the timelock operations
+// of each chain.
+func (m *ProposalMonitor) convert(ctx context.Context, proposal *mcmslib.TimelockProposal) (*mcmslib.Proposal, map[mcmstypes.ChainSelector][]common.Hash, error) {
+	base := proposal.BaseProposal
+	base.Kind = mcmstypes.KindProposal
+	converted := &mcmslib.Proposal{BaseProposal: base}
+
+	opIDs := make(map[mcmstypes.ChainSelector][]common.Hash)
+	for _, bop := range proposal.Operations {
+		converter, ok := m.Converters[uint64(bop.ChainSelector)]
+		if !ok {
+			return nil, nil, fmt.Errorf("no timelock converter for chain %d", bop.ChainSelector)
+		}
+		metadata := proposal.ChainMetadata[bop.ChainSelector]
+
+		predecessor := mcmslib.ZERO_HASH
+		if ids := opIDs[bop.ChainSelector]; len(ids) > 0 {
+			predecessor = ids[len(ids)-1]
+		}
+		ops, opID, err := converter.ConvertBatchToChainOperations(ctx, metadata, bop, proposal.TimelockAddresses[bop.ChainSelector],
+			metadata.MCMAddress, proposal.Delay, proposal.Action, predecessor, proposal.Salt())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert operation on chain %d: %w", bop.ChainSelector, err)
+		}
+		converted.Operations = append(converted.Operations, ops...)
+		opIDs[bop.ChainSelector] = append(opIDs[bop.ChainSelector], opID)
+	}
+
+	return converted, opIDs, nil
+}
+
+func (m *ProposalMonitor) checkChain(
+	ctx context.Context,
+	proposal *mcmslib.TimelockProposal,
+	sel mcmstypes.ChainSelector,
+	root common.Hash,
+	mcmOps uint64,
+	opIDs []common.Hash,
+) (ProposalChainStatus, error) {
+	status := ProposalChainStatus{ChainSelector: uint64(sel), Operations: len(opIDs)}
+	inspector, ok := m.Inspectors[uint64(sel)]
+	if !ok {
+		return status, errors.New("no mcms inspector")
+	}
+	metadata := proposal.ChainMetadata[sel]
+
+	opCount, err := inspector.GetOpCount(ctx, metadata.MCMAddress)
+	if err != nil {
+		return status, fmt.Errorf("failed to get op count: %w", err)
+	}
+	onchainRoot, _, err := inspector.GetRoot(ctx, metadata.MCMAddress)
+	if err != nil {
+		return status, fmt.Errorf("failed to get root: %w", err)
+	}
+	mcmExecuted := opCount >= metadata.StartingOpCount+mcmOps
+
+	// Bypass and cancel proposals are done once the MCM executed them.
+	if proposal.Action != mcmstypes.TimelockActionSchedule {
+		if mcmExecuted {
+			status.State, status.Done = ProposalExecuted, len(opIDs)
+			return status, nil
+		}
+		status.State = m.signatureState(onchainRoot == root, proposal.ValidUntil)
+		return status, nil
+	}
+
+	if !mcmExecuted {
+		status.State = m.signatureState(onchainRoot == root, proposal.ValidUntil)
+		return status, nil
+	}
+
+	timelockInspector, ok := m.TimelockInspectors[uint64(sel)]
+	if !ok {
+		return status, errors.New("no timelock inspector")
+	}
+	timelock := proposal.TimelockAddresses[sel]
+	status.State = ProposalReady
+	for _, id := range opIDs {
+		done, err := timelockInspector.IsOperationDone(ctx, timelock, id)
+		if err != nil {
+			return status, fmt.Errorf("failed to check operation %s: %w", id, err)
+		}
+		if done {
+			status.Done++
+			continue
+		}
+		// Cancelled operations are deleted from the timelock.
+		exists, err := timelockInspector.IsOperation(ctx, timelock, id)
+		if err != nil {
+			return status, fmt.Errorf("failed to check operation %s: %w", id, err)
+		}
+		if !exists {
+			status.State = ProposalCancelled
+			return status, nil
+		}
+		ready, err := timelockInspector.IsOperationReady(ctx, timelock, id)
+		if err != nil {
+			return status, fmt.Errorf("failed to check operation %s: %w", id, err)
+		}
+		if !ready {
+			status.State = ProposalScheduled
+		}
+	}
+	if status.Done == len(opIDs) {
+		status.State = ProposalExecuted
+	}
+
+	return status, nil
+}
+
+func (m *ProposalMonitor) signatureState(rootSet bool, validUntil uint32) ProposalChainState {
+	switch {
+	case rootSet:
+		return ProposalRootSet
+	case m.now().Unix() > int64(validUntil):
+		return ProposalExpired
+	default:
+		return ProposalUnsigned
+	}
+}
+
+// Watch checks the proposals every interval and logs their warnings, until none of them can progress anymore or the
+// context is done. It returns the last statuses.
+func (m *ProposalMonitor) Watch(ctx context.Context, lggr logger.Logger, proposals []*mcmslib.TimelockProposal, interval time.Duration) ([]ProposalStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		statuses := make([]ProposalStatus, 0, len(proposals))
+		pending := false
+		for _, proposal := range proposals {
+			status, err := m.Check(ctx, proposal)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check proposal %q: %w", proposal.Description, err)
+			}
+			for _, warning := range status.Warnings {
+				lggr.Warnw(warning, "proposal", status.Description, "validUntil", status.ValidUntil)
+			}
+			pending = pending || status.Pending()
+			statuses = append(statuses, status)
+		}
+		if !pending {
+			return statuses, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return statuses, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
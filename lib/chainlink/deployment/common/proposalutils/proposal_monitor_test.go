@@ -0,0 +1,164 @@
+package proposalutils
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	chain_selectors "github.com/smartcontractkit/chain-selectors"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmssdk "github.com/smartcontractkit/mcms/sdk"
+	mcmsevmsdk "github.com/smartcontractkit/mcms/sdk/evm"
+	"github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+type fakeMCMInspector struct {
+	mcmssdk.Inspector
+	opCount uint64
+	root    common.Hash
+}
+
+func (i *fakeMCMInspector) GetOpCount(context.Context, string) (uint64, error) {
+	return i.opCount, nil
+}
+
+func (i *fakeMCMInspector) GetRoot(context.Context, string) (common.Hash, uint32, error) {
+	return i.root, 0, nil
+}
+
+type fakeTimelockInspector struct {
+	mcmssdk.TimelockInspector
+	operations map[[32]byte]string
+}
+
+func (i *fakeTimelockInspector) IsOperation(_ context.Context, _ string, id [32]byte) (bool, error) {
+	_, ok := i.operations[id]
+	return ok, nil
+}
+
+func (i *fakeTimelockInspector) IsOperationReady(_ context.Context, _ string, id [32]byte) (bool, error) {
+	return i.operations[id] == "ready", nil
+}
+
+func (i *fakeTimelockInspector) IsOperationDone(_ context.Context, _ string, id [32]byte) (bool, error) {
+	return i.operations[id] == "done", nil
+}
+
+func TestProposalMonitor(t *testing.T) {
+	sel := chain_selectors.TEST_90000001.Selector
+	now := time.Unix(1_700_000_000, 0)
+	tx := types.Transaction{To: common.HexToAddress("0x0e").Hex(), Data: []byte{0x01}, AdditionalFields: json.RawMessage(`{"value": 0}`)}
+	proposal := &mcmslib.TimelockProposal{
+		BaseProposal: mcmslib.BaseProposal{
+			Version:       "v1",
+			Kind:          types.KindTimelockProposal,
+			Description:   "upgrade",
+			ValidUntil:    uint32(now.Add(72 * time.Hour).Unix()), //nolint:gosec // G115
+			ChainMetadata: map[types.ChainSelector]types.ChainMetadata{types.ChainSelector(sel): {MCMAddress: common.HexToAddress("0x0d").Hex(), StartingOpCount: 3}},
+		},
+		Action:            types.TimelockActionSchedule,
+		Delay:             types.NewDuration(time.Hour),
+		TimelockAddresses: map[types.ChainSelector]string{types.ChainSelector(sel): common.HexToAddress("0x0c").Hex()},
+		Operations: []types.BatchOperation{
+			{ChainSelector: types.ChainSelector(sel), Transactions: []types.Transaction{tx}},
+			{ChainSelector: types.ChainSelector(sel), Transactions: []types.Transaction{tx, tx}},
+		},
+	}
+
+	mcm := &fakeMCMInspector{opCount: 3}
+	timelock := &fakeTimelockInspector{operations: map[[32]byte]string{}}
+	clock := now
+	m := &ProposalMonitor{
+		Inspectors:         map[uint64]mcmssdk.Inspector{sel: mcm},
+		TimelockInspectors: map[uint64]mcmssdk.TimelockInspector{sel: timelock},
+		Converters:         map[uint64]mcmssdk.TimelockConverter{sel: &mcmsevmsdk.TimelockConverter{}},
+		Now:                func() time.Time { return clock },
+	}
+
+	converted, opIDs, err := m.convert(t.Context(), proposal)
+	require.NoError(t, err)
+	ids := opIDs[types.ChainSelector(sel)]
+	require.Len(t, ids, 2)
+	tree, err := converted.MerkleTree()
+	require.NoError(t, err)
+
+	check := func(t *testing.T) ProposalStatus {
+		status, err := m.Check(t.Context(), proposal)
+		require.NoError(t, err)
+		require.Len(t, status.Chains, 1)
+		require.Empty(t, status.Chains[0].Error)
+		return status
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		status := check(t)
+		assert.Equal(t, ProposalUnsigned, status.Chains[0].State)
+		assert.Equal(t, 72*time.Hour, status.Remaining)
+		assert.Empty(t, status.Warnings)
+		assert.True(t, status.Pending())
+	})
+
+	t.Run("unsigned close to expiry", func(t *testing.T) {
+		clock = now.Add(60 * time.Hour)
+		defer func() { clock = now }()
+
+		status := check(t)
+		assert.Equal(t, ProposalUnsigned, status.Chains[0].State)
+		require.Len(t, status.Warnings, 1)
+		assert.Contains(t, status.Warnings[0], "proposal expires in 12h0m0s and is not signed")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		clock = now.Add(73 * time.Hour)
+		defer func() { clock = now }()
+
+		status := check(t)
+		assert.Equal(t, ProposalExpired, status.Chains[0].State)
+		assert.Zero(t, status.Remaining)
+		require.Len(t, status.Warnings, 1)
+		assert.Contains(t, status.Warnings[0], "proposal expired")
+		assert.False(t, status.Pending())
+	})
+
+	t.Run("root set", func(t *testing.T) {
+		mcm.root = tree.Root
+		assert.Equal(t, ProposalRootSet, check(t).Chains[0].State)
+	})
+
+	mcm.opCount = 5
+	t.Run("scheduled", func(t *testing.T) {
+		timelock.operations[ids[0]] = "ready"
+		timelock.operations[ids[1]] = "pending"
+		assert.Equal(t, ProposalScheduled, check(t).Chains[0].State)
+	})
+
+	t.Run("ready", func(t *testing.T) {
+		timelock.operations[ids[1]] = "ready"
+		assert.Equal(t, ProposalReady, check(t).Chains[0].State)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		timelock.operations = map[[32]byte]string{ids[0]: "done"}
+		status := check(t)
+		assert.Equal(t, ProposalCancelled, status.Chains[0].State)
+		assert.Equal(t, 1, status.Chains[0].Done)
+	})
+
+	t.Run("executed", func(t *testing.T) {
+		timelock.operations = map[[32]byte]string{ids[0]: "done", ids[1]: "done"}
+		status := check(t)
+		assert.Equal(t, ProposalExecuted, status.Chains[0].State)
+		assert.Equal(t, 2, status.Chains[0].Done)
+
+		statuses, err := m.Watch(t.Context(), logger.Test(t), []*mcmslib.TimelockProposal{proposal}, time.Millisecond)
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		assert.False(t, statuses[0].Pending())
+	})
+}
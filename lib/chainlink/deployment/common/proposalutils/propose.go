@@ -34,6 +34,31 @@ type TimelockConfig struct {
 	MCMSAction                types.TimelockAction `json:"mcmsAction"`
 	OverrideRoot              bool                 `json:"overrideRoot"`                        // if true, override the previous root with the new one.
 	TimelockQualifierPerChain map[uint64]string    `json:"timelockQualifierPerChain,omitempty"` // optional qualifier to fetch timelock address from datastore
+	ValidUntil                time.Duration        `json:"validUntil,omitempty"`                // how long the proposal is valid once built, DefaultValidUntil if not set.
+	SigningWindow             time.Duration        `json:"signingWindow,omitempty"`             // time reserved to collect the signatures before the proposal is set.
+}
+
+// ValidFor returns how long the proposal is valid once built.
+func (tc TimelockConfig) ValidFor() time.Duration {
+	if tc.ValidUntil == 0 {
+		return DefaultValidUntil
+	}
+	return tc.ValidUntil
+}
+
+// ValidateWindow checks that a proposal can still be signed and executed before it expires, i.e. that an explicit
+// ValidUntil exceeds the delay plus the signing window.
+func (tc TimelockConfig) ValidateWindow() error {
+	if tc.MinDelay < 0 || tc.ValidUntil < 0 || tc.SigningWindow < 0 {
+		return errors.New("minDelay, validUntil and signingWindow must not be negative")
+	}
+	if tc.ValidUntil == 0 {
+		return nil
+	}
+	if tc.ValidUntil <= tc.MinDelay+tc.SigningWindow {
+		return fmt.Errorf("validUntil %s must exceed minDelay %s plus signingWindow %s", tc.ValidUntil, tc.MinDelay, tc.SigningWindow)
+	}
+	return nil
 }
 
 func (tc *TimelockConfig) MCMBasedOnActionSolana(s state.MCMSWithTimelockStateSolana) (string, error) {
@@ -92,7 +117,7 @@ func (tc *TimelockConfig) validateCommon() error {
 		tc.MCMSAction != types.TimelockActionBypass {
 		return fmt.Errorf("invalid MCMS type %s", tc.MCMSAction)
 	}
-	return nil
+	return tc.ValidateWindow()
 }
 
 func (tc *TimelockConfig) Validate(chain cldf_evm.Chain, s state.MCMSWithTimelockState) error {
@@ -198,6 +223,9 @@ func BuildProposalFromBatchesV2(
 	if len(batches) == 0 {
 		return nil, errors.New("no operations in batch")
 	}
+	if err := mcmsCfg.ValidateWindow(); err != nil {
+		return nil, err
+	}
 
 	chains := mapset.NewSet[uint64]()
 	for _, op := range batches {
@@ -212,7 +240,7 @@ func BuildProposalFromBatchesV2(
 		return nil, err
 	}
 
-	validUntil := time.Now().Unix() + int64(DefaultValidUntil.Seconds())
+	validUntil := time.Now().Unix() + int64(mcmsCfg.ValidFor().Seconds())
 
 	builder := mcmslib.NewTimelockProposalBuilder()
 	builder.
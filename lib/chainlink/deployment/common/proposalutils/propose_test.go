@@ -185,3 +185,46 @@ func TestBuildProposalFromBatchesV2(t *testing.T) {
 		})
 	}
 }
+
+func TestTimelockConfig_ValidateWindow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config proposalutils.TimelockConfig
+		errMsg string
+	}{
+		{
+			name:   "defaults",
+			config: proposalutils.TimelockConfig{MinDelay: time.Hour},
+		},
+		{
+			name:   "valid window",
+			config: proposalutils.TimelockConfig{MinDelay: time.Hour, SigningWindow: 24 * time.Hour, ValidUntil: 48 * time.Hour},
+		},
+		{
+			name:   "window too short",
+			config: proposalutils.TimelockConfig{MinDelay: 24 * time.Hour, SigningWindow: 24 * time.Hour, ValidUntil: 48 * time.Hour},
+			errMsg: "validUntil 48h0m0s must exceed minDelay 24h0m0s plus signingWindow 24h0m0s",
+		},
+		{
+			name:   "negative",
+			config: proposalutils.TimelockConfig{SigningWindow: -time.Hour},
+			errMsg: "minDelay, validUntil and signingWindow must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.ValidateWindow()
+			if tt.errMsg != "" {
+				require.EqualError(t, err, tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	assert.Equal(t, proposalutils.DefaultValidUntil, proposalutils.TimelockConfig{}.ValidFor())
+	assert.Equal(t, time.Hour, proposalutils.TimelockConfig{ValidUntil: time.Hour}.ValidFor())
+}
@@ -31,6 +31,9 @@ type ConfigureCapabilitiesRegistryInput struct {
 	Nodes                       []CapabilitiesRegistryNodeParams   `json:"nodes,omitempty" yaml:"nodes,omitempty"`
 	DONs                        []CapabilitiesRegistryNewDONParams `json:"dons,omitempty" yaml:"dons,omitempty"`
 	Qualifier                   string                             `json:"qualifier,omitempty" yaml:"qualifier,omitempty"`
+	// UpdateNopAdmins updates the admin of already registered node operators whose admin differs from the input.
+	// If not set, such a mismatch fails the changeset.
+	UpdateNopAdmins bool `json:"updateNopAdmins,omitempty" yaml:"updateNopAdmins,omitempty"`
 }
 
 type ConfigureCapabilitiesRegistryDeps struct {
@@ -120,6 +123,7 @@ func (l ConfigureCapabilitiesRegistry) Apply(e cldf.Environment, config Configur
 			Capabilities:     capabilities,
 			Nodes:            nodes,
 			DONs:             dons,
+			UpdateNopAdmins:  config.UpdateNopAdmins,
 		},
 	)
 	if err != nil {
@@ -130,8 +130,8 @@ func suite(t *testing.T, fixture *testFixture) {
 
 		// Create the appropriate strategy
 		strategy, err := strategies.CreateStrategy(
-			chain,
 			mcmsFixture.env,
+			chain.Selector,
 			mcmsFixture.configureInput.MCMSConfig,
 			mcmsContracts,
 			common.HexToAddress(mcmsFixture.capabilitiesRegistryAddress),
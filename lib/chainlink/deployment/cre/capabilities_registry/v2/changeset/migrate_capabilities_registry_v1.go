@@ -0,0 +1,133 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	capabilities_registry_v1 "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/capabilities_registry_1_1_0"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/sequences"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	crecontracts "github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+var _ cldf.ChangeSetV2[MigrateCapabilitiesRegistryV1Input] = MigrateCapabilitiesRegistryV1{}
+
+type MigrateCapabilitiesRegistryV1Input struct {
+	ChainSelector uint64 `json:"chainSelector" yaml:"chainSelector"`
+	// V1RegistryAddress is the address of the V1 registry to migrate from, on the same chain as the V2 registry.
+	V1RegistryAddress string `json:"v1RegistryAddress" yaml:"v1RegistryAddress"`
+	// Qualifier is the qualifier of the V2 registry to migrate to.
+	Qualifier string               `json:"qualifier" yaml:"qualifier"`
+	Rules     pkg.V1MigrationRules `json:"rules" yaml:"rules"`
+	// DONConfig is the config of every migrated DON, since V1 DONs have no config.
+	DONConfig map[string]any `json:"donConfig,omitempty" yaml:"donConfig,omitempty"`
+
+	MCMSConfig *crecontracts.MCMSConfig `json:"mcmsConfig,omitempty" yaml:"mcmsConfig,omitempty"`
+}
+
+// MigrateCapabilitiesRegistryV1 registers the node operators, capabilities, nodes and DONs of a V1 registry into a V2 registry.
+// V1 capabilities are identified by `<labelledName>@<version>` in V2, and DONs are named and assigned families using the rules.
+// Nothing is removed from the V2 registry, so the changeset can be re-applied after a partial failure.
+// Without MCMS, both registries are compared once the changes are applied.
+type MigrateCapabilitiesRegistryV1 struct{}
+
+func (l MigrateCapabilitiesRegistryV1) VerifyPreconditions(e cldf.Environment, config MigrateCapabilitiesRegistryV1Input) error {
+	if config.Qualifier == "" {
+		return errors.New("qualifier must be provided")
+	}
+	if _, ok := e.BlockChains.EVMChains()[config.ChainSelector]; !ok {
+		return fmt.Errorf("chain %d not found in environment", config.ChainSelector)
+	}
+	if !common.IsHexAddress(config.V1RegistryAddress) {
+		return fmt.Errorf("invalid V1 registry address: %s", config.V1RegistryAddress)
+	}
+	if _, err := e.DataStore.Addresses().Get(pkg.GetCapRegV2AddressRefKey(config.ChainSelector, config.Qualifier)); err != nil {
+		return fmt.Errorf("failed to get V2 registry address: %w", err)
+	}
+	for p2pID, csaKey := range config.Rules.CSAKeys {
+		if _, err := pkg.HexStringTo32Bytes(csaKey); err != nil {
+			return fmt.Errorf("invalid CSA key for node %s: %w", p2pID, err)
+		}
+	}
+
+	return nil
+}
+
+func (l MigrateCapabilitiesRegistryV1) Apply(e cldf.Environment, config MigrateCapabilitiesRegistryV1Input) (cldf.ChangesetOutput, error) {
+	chain, ok := e.BlockChains.EVMChains()[config.ChainSelector]
+	if !ok {
+		return cldf.ChangesetOutput{}, fmt.Errorf("chain %d not found in environment", config.ChainSelector)
+	}
+
+	v1Reg, err := capabilities_registry_v1.NewCapabilitiesRegistry(common.HexToAddress(config.V1RegistryAddress), chain.Client)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to create V1 CapabilitiesRegistry: %w", err)
+	}
+	v1, err := pkg.ReadV1RegistryState(v1Reg)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to read V1 registry: %w", err)
+	}
+
+	rules := config.Rules
+	donConfig := pkg.CapabilityConfig(config.DONConfig)
+	rules.DONConfig, err = donConfig.MarshalProto()
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to marshal DON config: %w", err)
+	}
+
+	desired, err := pkg.MapV1Registry(v1, rules)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to map V1 registry: %w", err)
+	}
+
+	var mcmsContracts *commonchangeset.MCMSWithTimelockState
+	if config.MCMSConfig != nil {
+		mcmsContracts, err = strategies.GetMCMSContracts(e, config.ChainSelector, emptyQualifier)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to get MCMS contracts: %w", err)
+		}
+	}
+
+	registryRef := pkg.GetCapRegV2AddressRefKey(config.ChainSelector, config.Qualifier)
+	report, err := operations.ExecuteSequence(
+		e.OperationsBundle,
+		sequences.ReconcileCapabilitiesRegistry,
+		sequences.ReconcileCapabilitiesRegistryDeps{
+			Env:           &e,
+			MCMSContracts: mcmsContracts,
+		},
+		sequences.ReconcileCapabilitiesRegistryInput{
+			RegistryRef: registryRef,
+			MCMSConfig:  config.MCMSConfig,
+			Desired:     desired,
+		},
+	)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to register V1 state into V2 registry: %w", err)
+	}
+
+	// With MCMS, nothing is applied until the proposal is executed
+	if config.MCMSConfig == nil {
+		v2, err := readCapabilitiesRegistryState(e, CapabilitiesRegistryRef{ChainSelector: config.ChainSelector, Qualifier: config.Qualifier})
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to read V2 registry: %w", err)
+		}
+		if err := pkg.VerifyV1Migration(v1, v2, rules); err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("V2 registry does not match V1 registry after migration: %w", err)
+		}
+		e.Logger.Infow("Verified V1 registry migration", "v1", config.V1RegistryAddress, "chainSelector", config.ChainSelector)
+	}
+
+	return cldf.ChangesetOutput{
+		Reports:               report.ExecutionReports,
+		MCMSTimelockProposals: report.Output.MCMSTimelockProposals,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package changeset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/test"
+)
+
+func TestMigrateCapabilitiesRegistryV1_VerifyPreconditions(t *testing.T) {
+	cs := changeset.MigrateCapabilitiesRegistryV1{}
+
+	env := test.SetupEnvV2(t, false)
+
+	t.Run("empty qualifier", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.MigrateCapabilitiesRegistryV1Input{
+			ChainSelector: env.RegistrySelector,
+		})
+		require.ErrorContains(t, err, "qualifier must be provided")
+	})
+
+	t.Run("invalid V1 address", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.MigrateCapabilitiesRegistryV1Input{
+			ChainSelector:     env.RegistrySelector,
+			Qualifier:         test.RegistryQualifier,
+			V1RegistryAddress: "invalid",
+		})
+		require.ErrorContains(t, err, "invalid V1 registry address")
+	})
+
+	t.Run("unknown V2 registry", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.MigrateCapabilitiesRegistryV1Input{
+			ChainSelector:     env.RegistrySelector,
+			Qualifier:         "unknown",
+			V1RegistryAddress: env.RegistryAddress.Hex(),
+		})
+		require.ErrorContains(t, err, "failed to get V2 registry address")
+	})
+
+	t.Run("invalid CSA key", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.MigrateCapabilitiesRegistryV1Input{
+			ChainSelector:     env.RegistrySelector,
+			Qualifier:         test.RegistryQualifier,
+			V1RegistryAddress: env.RegistryAddress.Hex(),
+			Rules:             pkg.V1MigrationRules{CSAKeys: map[string]string{"node": "invalid"}},
+		})
+		require.ErrorContains(t, err, "invalid CSA key for node node")
+	})
+}
@@ -0,0 +1,249 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+// DefaultAddNodesChunkSize is the maximum number of nodes added per transaction when AddNodesInput.ChunkSize is not set.
+// It keeps each AddNodes call well below the block gas limit of the chains the registry is deployed to.
+const DefaultAddNodesChunkSize = 25
+
+type AddNodesDeps struct {
+	Env      *cldf.Environment
+	Strategy strategies.TransactionStrategy
+}
+
+type AddNodesInput struct {
+	Address       string
+	ChainSelector uint64
+	// Nodes is the node manifest, see pkg.LoadNodeManifest.
+	Nodes []pkg.NodeManifestEntry
+	// ChunkSize is the maximum number of nodes per AddNodes call. Defaults to DefaultAddNodesChunkSize.
+	ChunkSize  int
+	MCMSConfig *contracts.MCMSConfig
+}
+
+func (i *AddNodesInput) Validate() error {
+	if i.Address == "" {
+		return errors.New("address is not set")
+	}
+	if i.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+	if i.ChunkSize < 0 {
+		return errors.New("chunkSize cannot be negative")
+	}
+
+	var errs []error
+	for idx, node := range i.Nodes {
+		if _, err := node.Decode(); err != nil {
+			errs = append(errs, fmt.Errorf("node %d (%s): %w", idx, node.P2PID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type AddNodesOutput struct {
+	// Added are the P2P IDs of the nodes that were added, or proposed to be added if MCMS is used.
+	Added []string
+	// Skipped are the P2P IDs of the nodes that were skipped because they are already registered or duplicated in the manifest.
+	Skipped []string
+	// Nodes are the parsed NodeAdded events, only set when MCMS is not used.
+	Nodes []*capabilities_registry_v2.CapabilitiesRegistryNodeAdded
+	// Operations contains one batch operation per chunk when MCMS is used.
+	Operations []mcmstypes.BatchOperation
+}
+
+// AddNodes is an operation that adds the nodes of a node manifest to the V2 Capabilities Registry contract.
+// The nodes are added in chunks, so large manifests don't exceed the gas limit of a single transaction.
+var AddNodes = operations.NewOperation[AddNodesInput, AddNodesOutput, AddNodesDeps](
+	"add-nodes-op",
+	semver.MustParse("1.0.0"),
+	"Add Nodes from a manifest to Capabilities Registry",
+	func(b operations.Bundle, deps AddNodesDeps, input AddNodesInput) (AddNodesOutput, error) {
+		if err := input.Validate(); err != nil {
+			return AddNodesOutput{}, fmt.Errorf("invalid node manifest: %w", err)
+		}
+
+		out := AddNodesOutput{
+			Added:   []string{},
+			Skipped: []string{},
+			Nodes:   []*capabilities_registry_v2.CapabilitiesRegistryNodeAdded{},
+		}
+		if len(input.Nodes) == 0 {
+			return out, nil
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return AddNodesOutput{}, fmt.Errorf("chain not found for selector %d", input.ChainSelector)
+		}
+
+		capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(
+			common.HexToAddress(input.Address),
+			chain.Client,
+		)
+		if err != nil {
+			return AddNodesOutput{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+		}
+
+		params, skipped, err := makeAddNodesParams(capReg, input.Nodes)
+		if err != nil {
+			return AddNodesOutput{}, err
+		}
+		out.Skipped = skipped
+		for _, p := range skipped {
+			deps.Env.Logger.Infof("Node with P2P ID %s already registered in contract or duplicated in manifest, skipping", p)
+		}
+		if len(params) == 0 {
+			deps.Env.Logger.Info("All nodes are already registered in the contract, nothing to do")
+			return out, nil
+		}
+
+		chunkSize := input.ChunkSize
+		if chunkSize == 0 {
+			chunkSize = DefaultAddNodesChunkSize
+		}
+
+		for start := 0; start < len(params); start += chunkSize {
+			chunk := params[start:min(start+chunkSize, len(params))]
+
+			// Execute the transaction using the strategy
+			operation, tx, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+				return capReg.AddNodes(opts, chunk)
+			})
+			if err != nil {
+				err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+				return out, fmt.Errorf("failed to execute AddNodes for nodes %d to %d: %w", start, start+len(chunk)-1, err)
+			}
+
+			if operation != nil {
+				out.Operations = append(out.Operations, *operation)
+			} else {
+				ctx := b.GetContext()
+				receipt, err := bind.WaitMined(ctx, chain.Client, tx)
+				if err != nil {
+					return out, fmt.Errorf("failed to mine AddNodes transaction %s: %w", tx.Hash().String(), err)
+				}
+
+				for i, log := range receipt.Logs {
+					if log == nil {
+						continue
+					}
+
+					o, err := capReg.ParseNodeAdded(*log)
+					if err != nil {
+						return out, fmt.Errorf("failed to parse log %d for node added: %w", i, err)
+					}
+					out.Nodes = append(out.Nodes, o)
+				}
+			}
+
+			for _, p := range chunk {
+				out.Added = append(out.Added, p2pkey.PeerID(p.P2pId).String())
+			}
+		}
+
+		if len(out.Operations) > 0 {
+			deps.Env.Logger.Infof("Created %d MCMS operations for AddNodes on chain %d", len(out.Operations), input.ChainSelector)
+		} else {
+			deps.Env.Logger.Infof("Successfully added %d nodes on chain %d", len(out.Nodes), input.ChainSelector)
+		}
+
+		return out, nil
+	},
+)
+
+// makeAddNodesParams converts the manifest into contract params. Nodes already registered in the contract,
+// or appearing more than once in the manifest, are returned as skipped. It fails if a node references an unknown
+// node operator or an unknown or deprecated capability.
+func makeAddNodesParams(
+	capReg *capabilities_registry_v2.CapabilitiesRegistry,
+	manifest []pkg.NodeManifestEntry,
+) ([]capabilities_registry_v2.CapabilitiesRegistryNodeParams, []string, error) {
+	contractNOPs, err := pkg.GetNodeOperatorsWithIDs(nil, capReg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch node operators from contract: %w", err)
+	}
+	nopIDs := pkg.NodeOperatorIDsByName(contractNOPs)
+
+	contractCaps, err := pkg.GetCapabilities(nil, capReg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch capabilities from contract: %w", err)
+	}
+	capDeprecated := make(map[string]bool, len(contractCaps))
+	for _, c := range contractCaps {
+		capDeprecated[c.CapabilityId] = c.IsDeprecated
+	}
+
+	contractNodes, err := pkg.GetNodes(nil, capReg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch nodes from contract: %w", err)
+	}
+	registered := make(map[[32]byte]struct{}, len(contractNodes))
+	for _, n := range contractNodes {
+		registered[n.P2pId] = struct{}{}
+	}
+
+	var params []capabilities_registry_v2.CapabilitiesRegistryNodeParams
+	var skipped []string
+	var errs []error
+	for _, entry := range manifest {
+		keys, err := entry.Decode()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("node %s: %w", entry.P2PID, err))
+			continue
+		}
+
+		nopID, exists := nopIDs[entry.NOP]
+		if !exists {
+			errs = append(errs, fmt.Errorf("node %s: node operator `%s` not found in contract", entry.P2PID, entry.NOP))
+			continue
+		}
+		for _, id := range entry.CapabilityIDs {
+			deprecated, exists := capDeprecated[id]
+			if !exists {
+				errs = append(errs, fmt.Errorf("node %s: capability %s not found in contract", entry.P2PID, id))
+			} else if deprecated {
+				errs = append(errs, fmt.Errorf("node %s: capability %s is deprecated", entry.P2PID, id))
+			}
+		}
+
+		if _, exists := registered[keys.P2PID]; exists {
+			skipped = append(skipped, keys.P2PID.String())
+			continue
+		}
+		registered[keys.P2PID] = struct{}{}
+
+		params = append(params, capabilities_registry_v2.CapabilitiesRegistryNodeParams{
+			NodeOperatorId:      nopID,
+			Signer:              keys.Signer,
+			P2pId:               keys.P2PID,
+			EncryptionPublicKey: keys.EncryptionPublicKey,
+			CsaKey:              keys.CSAKey,
+			CapabilityIds:       entry.CapabilityIDs,
+		})
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	return params, skipped, nil
+}
@@ -0,0 +1,202 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+type BatchSetDONFamiliesDeps struct {
+	Env                  *cldf.Environment
+	Strategy             strategies.TransactionStrategy
+	CapabilitiesRegistry *capabilities_registry_v2.CapabilitiesRegistry
+}
+
+type DONFamiliesUpdate struct {
+	AddToFamilies      []string `json:"addToFamilies" yaml:"addToFamilies"`
+	RemoveFromFamilies []string `json:"removeFromFamilies" yaml:"removeFromFamilies"`
+}
+
+type BatchSetDONFamiliesInput struct {
+	// Changes maps DON names to the families to add them to and remove them from.
+	Changes map[string]DONFamiliesUpdate
+
+	RegistryChainSel uint64
+
+	MCMSConfig *contracts.MCMSConfig
+}
+
+func (i *BatchSetDONFamiliesInput) Validate() error {
+	if len(i.Changes) == 0 {
+		return errors.New("must specify at least one DON")
+	}
+
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(i.Changes)) {
+		change := i.Changes[name]
+		if name == "" {
+			errs = append(errs, errors.New("DON name cannot be empty"))
+			continue
+		}
+		if len(change.AddToFamilies) == 0 && len(change.RemoveFromFamilies) == 0 {
+			errs = append(errs, fmt.Errorf("DON %s: must specify at least one family to add or remove", name))
+			continue
+		}
+		for _, family := range append(slices.Clone(change.AddToFamilies), change.RemoveFromFamilies...) {
+			if family == "" {
+				errs = append(errs, fmt.Errorf("DON %s: family name cannot be empty", name))
+			}
+		}
+		for _, family := range change.AddToFamilies {
+			if slices.Contains(change.RemoveFromFamilies, family) {
+				errs = append(errs, fmt.Errorf("DON %s: family %s is both added and removed", name, family))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type BatchSetDONFamiliesOutput struct {
+	// DonsInfo maps DON names to their info after the update. Empty when using MCMS.
+	DonsInfo  map[string]capabilities_registry_v2.CapabilitiesRegistryDONInfo
+	Operation *mcmstypes.BatchOperation
+}
+
+// BatchSetDONFamilies is an operation that sets the families of many DONs in the V2 Capabilities Registry contract.
+// All the DONs are validated before any call is made. Only the families which differ from the current ones are set,
+// and DONs already in the requested families are skipped. With MCMS, the calls are in a single batch operation,
+// otherwise they are sent as sequential transactions.
+var BatchSetDONFamilies = operations.NewOperation[BatchSetDONFamiliesInput, BatchSetDONFamiliesOutput, BatchSetDONFamiliesDeps](
+	"batch-set-don-families-op",
+	semver.MustParse("1.0.0"),
+	"Batch Set DON Families in Capabilities Registry",
+	func(b operations.Bundle, deps BatchSetDONFamiliesDeps, input BatchSetDONFamiliesInput) (BatchSetDONFamiliesOutput, error) {
+		if err := input.Validate(); err != nil {
+			return BatchSetDONFamiliesOutput{}, err
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.RegistryChainSel]
+		if !ok {
+			return BatchSetDONFamiliesOutput{}, cldf.ErrChainNotFound
+		}
+
+		// Resolve all the DONs first, so nothing is sent if any of them does not exist
+		names := slices.Sorted(maps.Keys(input.Changes))
+		dons := make(map[string]capabilities_registry_v2.CapabilitiesRegistryDONInfo, len(names))
+		donIDs := make(map[string]uint32, len(names))
+		var errs []error
+		for _, name := range names {
+			don, err := deps.CapabilitiesRegistry.GetDONByName(&bind.CallOpts{}, name)
+			if err != nil {
+				err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+				errs = append(errs, fmt.Errorf("failed to call GetDONByName for DON %s: %w", name, err))
+				continue
+			}
+			dons[name] = don
+			donIDs[name] = don.Id
+		}
+		if err := errors.Join(errs...); err != nil {
+			return BatchSetDONFamiliesOutput{}, err
+		}
+
+		var batch *mcmstypes.BatchOperation
+		for _, name := range names {
+			change := diffDONFamilies(dons[name].DonFamilies, input.Changes[name])
+			if len(change.AddToFamilies) == 0 && len(change.RemoveFromFamilies) == 0 {
+				deps.Env.Logger.Infof("DON %s is already in the requested families, skipping", name)
+				continue
+			}
+			operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+				return deps.CapabilitiesRegistry.SetDONFamilies(opts, donIDs[name], change.AddToFamilies, change.RemoveFromFamilies)
+			}, func() (bool, error) {
+				// A previous attempt landed if the DON is in the added families and out of the removed ones
+				don, err := deps.CapabilitiesRegistry.GetDON(nil, donIDs[name])
+				if err != nil {
+					return false, err
+				}
+				for _, family := range change.AddToFamilies {
+					if !slices.Contains(don.DonFamilies, family) {
+						return false, nil
+					}
+				}
+				for _, family := range change.RemoveFromFamilies {
+					if slices.Contains(don.DonFamilies, family) {
+						return false, nil
+					}
+				}
+				return true, nil
+			})
+			if err != nil {
+				err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+				return BatchSetDONFamiliesOutput{}, fmt.Errorf("failed to execute SetDONFamilies for DON %s: %w", name, err)
+			}
+
+			if operation != nil {
+				if batch == nil {
+					batch = operation
+				} else {
+					batch.Transactions = append(batch.Transactions, operation.Transactions...)
+				}
+				continue
+			}
+
+			if tx == nil {
+				deps.Env.Logger.Infof("Families of DON %s were set by a previous attempt", name)
+				continue
+			}
+			if _, err := bind.WaitMined(b.GetContext(), chain.Client, tx); err != nil {
+				return BatchSetDONFamiliesOutput{}, fmt.Errorf("failed to mine SetDONFamilies transaction %s for DON %s: %w", tx.Hash().String(), name, err)
+			}
+		}
+
+		donsInfo := make(map[string]capabilities_registry_v2.CapabilitiesRegistryDONInfo)
+		if batch != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for BatchSetDONFamilies of %d DONs on chain %d", len(batch.Transactions), input.RegistryChainSel)
+		} else {
+			for _, name := range names {
+				don, err := deps.CapabilitiesRegistry.GetDON(&bind.CallOpts{}, donIDs[name])
+				if err != nil {
+					err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+					return BatchSetDONFamiliesOutput{}, fmt.Errorf("failed to call GetDON for DON %s: %w", name, err)
+				}
+				donsInfo[name] = don
+			}
+			deps.Env.Logger.Infof("Successfully set families of %d DONs on chain %d", len(names), input.RegistryChainSel)
+		}
+
+		return BatchSetDONFamiliesOutput{
+			DonsInfo:  donsInfo,
+			Operation: batch,
+		}, nil
+	},
+)
+
+// diffDONFamilies returns the families of the change the DON is not in yet, and the ones it is still in.
+func diffDONFamilies(current []string, change DONFamiliesUpdate) DONFamiliesUpdate {
+	var diff DONFamiliesUpdate
+	for _, family := range change.AddToFamilies {
+		if !slices.Contains(current, family) {
+			diff.AddToFamilies = append(diff.AddToFamilies, family)
+		}
+	}
+	for _, family := range change.RemoveFromFamilies {
+		if slices.Contains(current, family) {
+			diff.RemoveFromFamilies = append(diff.RemoveFromFamilies, family)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,133 @@
+package contracts_test
+
+import (
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/operations/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	crecontracts "github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+func TestBatchSetDONFamilies(t *testing.T) {
+	f := setupRegistry(t, chainsel.TEST_90000001.Selector)
+	p2pIDs := addTestNodes(t, f, 8)
+	addTestDON(t, f, "don-1", p2pIDs[:4], 1)
+	addTestDON(t, f, "don-2", p2pIDs[4:], 1)
+
+	chain := f.env.BlockChains.EVMChains()[f.selector]
+	direct, err := strategies.CreateStrategy(f.env, f.selector, nil, nil, f.registry.Address(), "")
+	require.NoError(t, err)
+	mcms := &strategies.MCMSTransaction{
+		Config:   &crecontracts.MCMSConfig{},
+		Address:  f.registry.Address(),
+		ChainSel: f.selector,
+		Env:      f.env,
+	}
+
+	batchSetDONFamilies := func(t *testing.T, strategy strategies.TransactionStrategy, changes map[string]contracts.DONFamiliesUpdate) contracts.BatchSetDONFamiliesOutput {
+		t.Helper()
+
+		f.env.OperationsBundle = operations.NewBundle(f.env.GetContext, f.env.Logger, operations.NewMemoryReporter())
+		report, err := operations.ExecuteOperation(f.env.OperationsBundle, contracts.BatchSetDONFamilies, contracts.BatchSetDONFamiliesDeps{
+			Env:                  &f.env,
+			Strategy:             strategy,
+			CapabilitiesRegistry: f.registry,
+		}, contracts.BatchSetDONFamiliesInput{
+			Changes:          changes,
+			RegistryChainSel: f.selector,
+		})
+		require.NoError(t, err)
+		return report.Output
+	}
+	requireFamilies := func(t *testing.T, name string, families ...string) {
+		t.Helper()
+
+		don, err := f.registry.GetDONByName(nil, name)
+		require.NoError(t, err)
+		require.ElementsMatch(t, families, don.DonFamilies)
+	}
+	setDONFamiliesData := func(t *testing.T, name string, add, remove []string) []byte {
+		t.Helper()
+
+		don, err := f.registry.GetDONByName(nil, name)
+		require.NoError(t, err)
+		parsed, err := capabilities_registry_v2.CapabilitiesRegistryMetaData.GetAbi()
+		require.NoError(t, err)
+		data, err := parsed.Pack("setDONFamilies", don.Id, add, remove)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("sets the families of the DONs", func(t *testing.T) {
+		out := batchSetDONFamilies(t, direct, map[string]contracts.DONFamiliesUpdate{
+			"don-1": {AddToFamilies: []string{"family-a", "family-b"}},
+			"don-2": {AddToFamilies: []string{"family-a"}},
+		})
+		assert.Nil(t, out.Operation)
+		require.Len(t, out.DonsInfo, 2)
+		assert.ElementsMatch(t, []string{"family-a", "family-b"}, out.DonsInfo["don-1"].DonFamilies)
+		assert.ElementsMatch(t, []string{"family-a"}, out.DonsInfo["don-2"].DonFamilies)
+		requireFamilies(t, "don-1", "family-a", "family-b")
+		requireFamilies(t, "don-2", "family-a")
+	})
+
+	t.Run("skips the DONs already in the families", func(t *testing.T) {
+		nonce, err := chain.Client.PendingNonceAt(t.Context(), chain.DeployerKey.From)
+		require.NoError(t, err)
+
+		out := batchSetDONFamilies(t, direct, map[string]contracts.DONFamiliesUpdate{
+			"don-1": {AddToFamilies: []string{"family-b"}, RemoveFromFamilies: []string{"family-c"}},
+			"don-2": {AddToFamilies: []string{"family-a"}},
+		})
+		assert.Nil(t, out.Operation)
+		require.Len(t, out.DonsInfo, 2)
+		assert.ElementsMatch(t, []string{"family-a", "family-b"}, out.DonsInfo["don-1"].DonFamilies)
+
+		// no transaction was sent
+		after, err := chain.Client.PendingNonceAt(t.Context(), chain.DeployerKey.From)
+		require.NoError(t, err)
+		assert.Equal(t, nonce, after)
+	})
+
+	t.Run("only proposes the families which differ with MCMS", func(t *testing.T) {
+		out := batchSetDONFamilies(t, mcms, map[string]contracts.DONFamiliesUpdate{
+			"don-1": {AddToFamilies: []string{"family-a", "family-c"}, RemoveFromFamilies: []string{"family-b", "family-d"}},
+			"don-2": {AddToFamilies: []string{"family-b"}, RemoveFromFamilies: []string{"family-a"}},
+		})
+		require.NotNil(t, out.Operation)
+		assert.Empty(t, out.DonsInfo)
+
+		// one call per DON in a single batch, sorted by DON name
+		require.Len(t, out.Operation.Transactions, 2)
+		assert.Equal(t, setDONFamiliesData(t, "don-1", []string{"family-c"}, []string{"family-b"}), out.Operation.Transactions[0].Data)
+		assert.Equal(t, setDONFamiliesData(t, "don-2", []string{"family-b"}, []string{"family-a"}), out.Operation.Transactions[1].Data)
+
+		// nothing is sent until the proposal is executed
+		requireFamilies(t, "don-1", "family-a", "family-b")
+		requireFamilies(t, "don-2", "family-a")
+	})
+
+	t.Run("fails before any call if a DON does not exist", func(t *testing.T) {
+		f.env.OperationsBundle = operations.NewBundle(f.env.GetContext, f.env.Logger, operations.NewMemoryReporter())
+		_, err := operations.ExecuteOperation(f.env.OperationsBundle, contracts.BatchSetDONFamilies, contracts.BatchSetDONFamiliesDeps{
+			Env:                  &f.env,
+			Strategy:             direct,
+			CapabilitiesRegistry: f.registry,
+		}, contracts.BatchSetDONFamiliesInput{
+			Changes: map[string]contracts.DONFamiliesUpdate{
+				"don-1":   {AddToFamilies: []string{"family-c"}},
+				"unknown": {AddToFamilies: []string{"family-c"}},
+			},
+			RegistryChainSel: f.selector,
+		})
+		require.ErrorContains(t, err, "failed to call GetDONByName for DON unknown")
+		requireFamilies(t, "don-1", "family-a", "family-b")
+	})
+}
@@ -0,0 +1,224 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+type DeprecateCapabilitiesDeps struct {
+	Env      *cldf.Environment
+	Strategy strategies.TransactionStrategy
+}
+
+type DeprecateCapabilitiesInput struct {
+	Address       string
+	ChainSelector uint64
+	CapabilityIDs []string
+
+	// Force deprecates the capabilities even if they are still configured on a DON.
+	// DONs keep working with deprecated capabilities, but they can no longer be added to new DONs or nodes.
+	Force bool
+
+	MCMSConfig *contracts.MCMSConfig
+}
+
+func (i *DeprecateCapabilitiesInput) Validate() error {
+	if i.Address == "" {
+		return errors.New("address is not set")
+	}
+	if i.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+	for _, id := range i.CapabilityIDs {
+		if id == "" {
+			return errors.New("capability ID cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+type DeprecateCapabilitiesOutput struct {
+	// Deprecated are the capabilities IDs that were deprecated, or proposed to be deprecated if MCMS is used.
+	Deprecated []string
+	// Events are the parsed CapabilityDeprecated events, only set when MCMS is not used.
+	Events []*capabilities_registry_v2.CapabilitiesRegistryCapabilityDeprecated
+	// Capabilities is the state of all the capabilities in the registry after the deprecation, only set when MCMS is not used.
+	Capabilities []capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo
+	Operation    *mcmstypes.BatchOperation
+}
+
+// DeprecateCapabilities is an operation that deprecates capabilities in the V2 Capabilities Registry contract.
+var DeprecateCapabilities = operations.NewOperation[DeprecateCapabilitiesInput, DeprecateCapabilitiesOutput, DeprecateCapabilitiesDeps](
+	"deprecate-capabilities-op",
+	semver.MustParse("1.0.0"),
+	"Deprecate Capabilities in Capabilities Registry",
+	func(b operations.Bundle, deps DeprecateCapabilitiesDeps, input DeprecateCapabilitiesInput) (DeprecateCapabilitiesOutput, error) {
+		if err := input.Validate(); err != nil {
+			return DeprecateCapabilitiesOutput{}, err
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return DeprecateCapabilitiesOutput{}, fmt.Errorf("chain not found for selector %d", input.ChainSelector)
+		}
+
+		capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(
+			common.HexToAddress(input.Address),
+			chain.Client,
+		)
+		if err != nil {
+			return DeprecateCapabilitiesOutput{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+		}
+
+		toDeprecate, err := filterCapabilitiesToDeprecate(b, capReg, input.CapabilityIDs, input.Force)
+		if err != nil {
+			return DeprecateCapabilitiesOutput{}, err
+		}
+		if len(toDeprecate) == 0 {
+			b.Logger.Info("no capabilities to deprecate, skipping operation")
+			return DeprecateCapabilitiesOutput{
+				Deprecated: []string{},
+				Events:     []*capabilities_registry_v2.CapabilitiesRegistryCapabilityDeprecated{},
+			}, nil
+		}
+
+		// Execute the transaction using the strategy
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return capReg.DeprecateCapabilities(opts, toDeprecate)
+		}, func() (bool, error) {
+			// A previous attempt landed if all the capabilities are deprecated
+			caps, err := pkg.GetCapabilities(nil, capReg)
+			if err != nil {
+				return false, err
+			}
+			return !slices.ContainsFunc(caps, func(c capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo) bool {
+				return !c.IsDeprecated && slices.Contains(toDeprecate, c.CapabilityId)
+			}), nil
+		})
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return DeprecateCapabilitiesOutput{}, fmt.Errorf("failed to execute DeprecateCapabilities: %w", err)
+		}
+
+		out := DeprecateCapabilitiesOutput{
+			Deprecated: toDeprecate,
+			Operation:  operation,
+		}
+
+		if operation != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for DeprecateCapabilities on chain %d", input.ChainSelector)
+			return out, nil
+		}
+
+		if tx == nil {
+			deps.Env.Logger.Infof("Capabilities on chain %d were deprecated by a previous attempt", input.ChainSelector)
+		} else {
+			receipt, err := bind.WaitMined(b.GetContext(), chain.Client, tx)
+			if err != nil {
+				return DeprecateCapabilitiesOutput{}, fmt.Errorf("failed to mine DeprecateCapabilities transaction %s: %w", tx.Hash().String(), err)
+			}
+
+			// Parse the logs to get the deprecated capabilities
+			out.Events = make([]*capabilities_registry_v2.CapabilitiesRegistryCapabilityDeprecated, 0, len(receipt.Logs))
+			for i, log := range receipt.Logs {
+				if log == nil {
+					continue
+				}
+
+				o, err := capReg.ParseCapabilityDeprecated(*log)
+				if err != nil {
+					return DeprecateCapabilitiesOutput{}, fmt.Errorf("failed to parse log %d for capability deprecated: %w", i, err)
+				}
+				out.Events = append(out.Events, o)
+			}
+		}
+
+		out.Capabilities, err = pkg.GetCapabilities(nil, capReg)
+		if err != nil {
+			return DeprecateCapabilitiesOutput{}, fmt.Errorf("failed to call GetCapabilities: %w", err)
+		}
+
+		deps.Env.Logger.Infof("Successfully deprecated %d capabilities on chain %d", len(out.Events), input.ChainSelector)
+
+		return out, nil
+	},
+)
+
+// filterCapabilitiesToDeprecate returns the capability IDs that have to be deprecated, in input order.
+// It fails if a capability is not registered, or if it is still configured on a DON and force is false.
+// Capabilities that are already deprecated are skipped, since the contract reverts on deprecating them twice.
+func filterCapabilitiesToDeprecate(
+	b operations.Bundle,
+	capReg *capabilities_registry_v2.CapabilitiesRegistry,
+	capabilityIDs []string,
+	force bool,
+) ([]string, error) {
+	caps, err := pkg.GetCapabilities(nil, capReg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetCapabilities: %w", err)
+	}
+	existingByID := make(map[string]capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo, len(caps))
+	for _, c := range caps {
+		existingByID[c.CapabilityId] = c
+	}
+
+	dons, err := pkg.GetDONs(nil, capReg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetDONs: %w", err)
+	}
+	donsByCapability := make(map[string][]string)
+	for _, don := range dons {
+		for _, cfg := range don.CapabilityConfigurations {
+			donsByCapability[cfg.CapabilityId] = append(donsByCapability[cfg.CapabilityId], don.Name)
+		}
+	}
+
+	var out []string
+	var errs []error
+	for _, id := range capabilityIDs {
+		if slices.Contains(out, id) {
+			continue
+		}
+
+		existing, exists := existingByID[id]
+		if !exists {
+			errs = append(errs, fmt.Errorf("capability %s not found in contract", id))
+			continue
+		}
+		if existing.IsDeprecated {
+			b.Logger.Infof("capability %s is already deprecated, skipping", id)
+			continue
+		}
+
+		if donNames := donsByCapability[id]; len(donNames) > 0 {
+			if !force {
+				errs = append(errs, fmt.Errorf("capability %s is still configured on DONs %v, use force to deprecate it anyway", id, donNames))
+				continue
+			}
+			b.Logger.Warnf("capability %s is still configured on DONs %v, deprecating anyway", id, donNames)
+		}
+
+		out = append(out, id)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return out, nil
+}
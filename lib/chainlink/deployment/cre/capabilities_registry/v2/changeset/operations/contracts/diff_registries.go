@@ -0,0 +1,121 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+)
+
+type DiffRegistriesDeps struct {
+	Env *cldf.Environment
+}
+
+// RegistrySnapshotSource is either a registry deployed on a chain, or a snapshot file written from a RegistrySnapshot.
+type RegistrySnapshotSource struct {
+	ChainSelector uint64
+	Address       string
+	SnapshotFile  string
+}
+
+func (s RegistrySnapshotSource) Validate() error {
+	if s.SnapshotFile != "" {
+		if s.ChainSelector != 0 || s.Address != "" {
+			return errors.New("either snapshotFile or chainSelector and address must be set, not both")
+		}
+		return nil
+	}
+	if s.Address == "" {
+		return errors.New("address is not set")
+	}
+	if s.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+
+	return nil
+}
+
+func (s RegistrySnapshotSource) String() string {
+	if s.SnapshotFile != "" {
+		return "snapshot " + s.SnapshotFile
+	}
+	return fmt.Sprintf("registry %s on chain %d", s.Address, s.ChainSelector)
+}
+
+func (s RegistrySnapshotSource) read(ctx context.Context, env *cldf.Environment) (pkg.RegistrySnapshot, error) {
+	if s.SnapshotFile != "" {
+		return pkg.LoadRegistrySnapshot(s.SnapshotFile)
+	}
+	return readRegistrySnapshot(ctx, env, s.ChainSelector, s.Address)
+}
+
+type DiffRegistriesInput struct {
+	Source RegistrySnapshotSource
+	Target RegistrySnapshotSource
+}
+
+func (i *DiffRegistriesInput) Validate() error {
+	if err := i.Source.Validate(); err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+	if err := i.Target.Validate(); err != nil {
+		return fmt.Errorf("invalid target: %w", err)
+	}
+
+	return nil
+}
+
+type DiffRegistriesOutput struct {
+	Source pkg.RegistrySnapshot
+	Target pkg.RegistrySnapshot
+	Diff   pkg.RegistryDiff
+}
+
+// DiffRegistries is a read-only operation that compares the state of two V2 Capabilities Registry contracts, each read
+// from a chain or a snapshot file, and reports the missing and differing node operators, nodes, capabilities, DONs
+// and DON families, for audits.
+var DiffRegistries = operations.NewOperation[DiffRegistriesInput, DiffRegistriesOutput, DiffRegistriesDeps](
+	"diff-registries-op",
+	semver.MustParse("1.0.0"),
+	"Diff Capabilities Registries",
+	func(b operations.Bundle, deps DiffRegistriesDeps, input DiffRegistriesInput) (DiffRegistriesOutput, error) {
+		if err := input.Validate(); err != nil {
+			return DiffRegistriesOutput{}, err
+		}
+
+		source, err := input.Source.read(b.GetContext(), deps.Env)
+		if err != nil {
+			return DiffRegistriesOutput{}, fmt.Errorf("failed to read source %s: %w", input.Source, err)
+		}
+		target, err := input.Target.read(b.GetContext(), deps.Env)
+		if err != nil {
+			return DiffRegistriesOutput{}, fmt.Errorf("failed to read target %s: %w", input.Target, err)
+		}
+
+		diff := pkg.DiffRegistrySnapshots(source, target)
+		if diff.IsEmpty() {
+			deps.Env.Logger.Infof("No differences between source %s and target %s", input.Source, input.Target)
+		} else {
+			deps.Env.Logger.Infof("Found differences between source %s and target %s: %d node operators, %d nodes, %d capabilities, %d DONs, %d families",
+				input.Source, input.Target,
+				countEntityDiff(diff.NodeOperators), countEntityDiff(diff.Nodes), countEntityDiff(diff.Capabilities),
+				countEntityDiff(diff.DONs), countEntityDiff(diff.Families))
+		}
+
+		return DiffRegistriesOutput{
+			Source: source,
+			Target: target,
+			Diff:   diff,
+		}, nil
+	},
+)
+
+func countEntityDiff(d pkg.EntityDiff) int {
+	return len(d.MissingInTarget) + len(d.MissingInSource) + len(d.Differences)
+}
@@ -0,0 +1,93 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+)
+
+type ReadRegistrySnapshotDeps struct {
+	Env *cldf.Environment
+}
+
+type ReadRegistrySnapshotInput struct {
+	Address       string
+	ChainSelector uint64
+}
+
+func (i *ReadRegistrySnapshotInput) Validate() error {
+	if i.Address == "" {
+		return errors.New("address is not set")
+	}
+	if i.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+
+	return nil
+}
+
+type ReadRegistrySnapshotOutput struct {
+	Snapshot pkg.RegistrySnapshot
+}
+
+// ReadRegistrySnapshot is a read-only operation that reads the full state of the V2 Capabilities Registry contract.
+// All the calls are pinned to the latest block at the time the operation starts, so the snapshot is consistent.
+var ReadRegistrySnapshot = operations.NewOperation[ReadRegistrySnapshotInput, ReadRegistrySnapshotOutput, ReadRegistrySnapshotDeps](
+	"read-registry-snapshot-op",
+	semver.MustParse("1.0.0"),
+	"Read Capabilities Registry snapshot",
+	func(b operations.Bundle, deps ReadRegistrySnapshotDeps, input ReadRegistrySnapshotInput) (ReadRegistrySnapshotOutput, error) {
+		if err := input.Validate(); err != nil {
+			return ReadRegistrySnapshotOutput{}, err
+		}
+
+		snapshot, err := readRegistrySnapshot(b.GetContext(), deps.Env, input.ChainSelector, input.Address)
+		if err != nil {
+			return ReadRegistrySnapshotOutput{}, err
+		}
+
+		deps.Env.Logger.Infof("Read registry snapshot on chain %d at block %d: %d node operators, %d nodes, %d capabilities, %d DONs",
+			input.ChainSelector, snapshot.BlockNumber, len(snapshot.NodeOperators), len(snapshot.Nodes), len(snapshot.Capabilities), len(snapshot.DONs))
+
+		return ReadRegistrySnapshotOutput{Snapshot: snapshot}, nil
+	},
+)
+
+// readRegistrySnapshot reads the snapshot of the registry at address, pinned to the latest block of the chain.
+func readRegistrySnapshot(ctx context.Context, env *cldf.Environment, chainSelector uint64, address string) (pkg.RegistrySnapshot, error) {
+	chain, ok := env.BlockChains.EVMChains()[chainSelector]
+	if !ok {
+		return pkg.RegistrySnapshot{}, fmt.Errorf("chain not found for selector %d", chainSelector)
+	}
+
+	capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(
+		common.HexToAddress(address),
+		chain.Client,
+	)
+	if err != nil {
+		return pkg.RegistrySnapshot{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+	}
+
+	header, err := chain.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return pkg.RegistrySnapshot{}, fmt.Errorf("failed to get latest block header: %w", err)
+	}
+
+	snapshot, err := pkg.ReadRegistrySnapshot(&bind.CallOpts{Context: ctx, BlockNumber: header.Number}, capReg)
+	if err != nil {
+		return pkg.RegistrySnapshot{}, fmt.Errorf("failed to read registry snapshot: %w", err)
+	}
+	snapshot.ChainSelector = chainSelector
+
+	return snapshot, nil
+}
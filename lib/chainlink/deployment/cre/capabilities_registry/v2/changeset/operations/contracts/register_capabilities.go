@@ -1,8 +1,11 @@
 package contracts
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -10,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	mcmstypes "github.com/smartcontractkit/mcms/types"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
 	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
@@ -31,12 +35,23 @@ type RegisterCapabilitiesInput struct {
 	MCMSConfig    *contracts.MCMSConfig
 }
 
+func (i *RegisterCapabilitiesInput) Validate() error {
+	var errs []error
+	for _, c := range i.Capabilities {
+		if err := validateCapability(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 type RegisterCapabilitiesOutput struct {
 	Capabilities []*capabilities_registry_v2.CapabilitiesRegistryCapabilityConfigured
 	Operation    *mcmstypes.BatchOperation
 }
 
-// RegisterCapabilities is an operation that registers nodes in the V2 Capabilities Registry contract.
+// RegisterCapabilities is an operation that registers capabilities in the V2 Capabilities Registry contract.
 var RegisterCapabilities = operations.NewOperation[RegisterCapabilitiesInput, RegisterCapabilitiesOutput, RegisterCapabilitiesDeps](
 	"register-capabilities-op",
 	semver.MustParse("1.0.0"),
@@ -49,6 +64,10 @@ var RegisterCapabilities = operations.NewOperation[RegisterCapabilitiesInput, Re
 			}, nil
 		}
 
+		if err := input.Validate(); err != nil {
+			return RegisterCapabilitiesOutput{}, fmt.Errorf("invalid capabilities: %w", err)
+		}
+
 		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
 		if !ok {
 			return RegisterCapabilitiesOutput{}, fmt.Errorf("chain not found for selector %d", input.ChainSelector)
@@ -65,7 +84,7 @@ var RegisterCapabilities = operations.NewOperation[RegisterCapabilitiesInput, Re
 
 		// We have to make sure the capabilities are not already in the contract, to avoid reverting the transaction.
 		// This is also important when we use MCMS, so the whole batch doesn't get reverted.
-		capabilities, err := dedupCapabilities(capabilitiesRegistry, input.Capabilities)
+		capabilities, err := dedupCapabilities(b.Logger, capabilitiesRegistry, input.Capabilities)
 		if err != nil {
 			return RegisterCapabilitiesOutput{}, fmt.Errorf("failed to deduplicate capabilities: %w", err)
 		}
@@ -81,16 +100,22 @@ var RegisterCapabilities = operations.NewOperation[RegisterCapabilitiesInput, Re
 		var resultCapabilities []*capabilities_registry_v2.CapabilitiesRegistryCapabilityConfigured
 
 		// Execute the transaction using the strategy
-		operation, tx, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 			return capabilitiesRegistry.AddCapabilities(opts, capabilities)
+		}, func() (bool, error) {
+			// A previous attempt landed if none of the capabilities is missing anymore
+			missing, err := dedupCapabilities(b.Logger, capabilitiesRegistry, input.Capabilities)
+			return len(missing) == 0, err
 		})
 		if err != nil {
 			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
 			return RegisterCapabilitiesOutput{}, fmt.Errorf("failed to execute AddCapabilities: %w", err)
 		}
 
-		if input.MCMSConfig != nil {
+		if operation != nil {
 			deps.Env.Logger.Infof("Created MCMS proposal for RegisterCapabilities on chain %d", input.ChainSelector)
+		} else if tx == nil {
+			deps.Env.Logger.Infof("Capabilities on chain %d were registered by a previous attempt", input.ChainSelector)
 		} else {
 			deps.Env.Logger.Infof("Successfully registered %d capabilities on chain %d", len(resultCapabilities), input.ChainSelector)
 
@@ -124,7 +149,11 @@ var RegisterCapabilities = operations.NewOperation[RegisterCapabilitiesInput, Re
 
 // dedupCapabilities deduplicates the capabilities with respect to the registry
 // The contract reverts on adding the same capability twice and that would cause the whole transaction to revert.
+// Capabilities that are already registered with a different configuration contract or metadata cannot be updated
+// through AddCapabilities, so a warning is logged for them. The same capability provided twice with different
+// payloads is rejected.
 func dedupCapabilities(
+	lggr logger.Logger,
 	capReg *capabilities_registry_v2.CapabilitiesRegistry,
 	capabilities []capabilities_registry_v2.CapabilitiesRegistryCapability,
 ) ([]capabilities_registry_v2.CapabilitiesRegistryCapability, error) {
@@ -141,27 +170,68 @@ func dedupCapabilities(
 		return nil, fmt.Errorf("failed to call GetCapabilities: %w", err)
 	}
 
-	existingByID := make(map[string]struct{})
+	existingByID := make(map[string]capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo)
 	for _, existingCap := range caps {
-		existingByID[existingCap.CapabilityId] = struct{}{}
+		existingByID[existingCap.CapabilityId] = existingCap
 	}
 
 	var out []capabilities_registry_v2.CapabilitiesRegistryCapability
 
 	// Deduplicate capabilities by their ID
-	seen := make(map[string]struct{}, len(capabilities))
+	seen := make(map[string]capabilities_registry_v2.CapabilitiesRegistryCapability, len(capabilities))
 	for _, candidate := range capabilities {
 		// Process a capability only once in terms of the input list, to avoid duplicates in the output
-		if _, exists := seen[candidate.CapabilityId]; exists {
+		if prev, exists := seen[candidate.CapabilityId]; exists {
+			if prev.ConfigurationContract != candidate.ConfigurationContract || !bytes.Equal(prev.Metadata, candidate.Metadata) {
+				return nil, fmt.Errorf("capability %s is provided more than once with different payloads", candidate.CapabilityId)
+			}
 			continue
 		}
-		seen[candidate.CapabilityId] = struct{}{}
+		seen[candidate.CapabilityId] = candidate
 
 		// Skip capabilities that already exist in the registry
-		if _, exists := existingByID[candidate.CapabilityId]; !exists {
+		existing, exists := existingByID[candidate.CapabilityId]
+		if !exists {
 			out = append(out, candidate)
+			continue
+		}
+
+		if existing.IsDeprecated {
+			lggr.Warnf("capability %s is already registered but deprecated, skipping", candidate.CapabilityId)
+		}
+		if existing.ConfigurationContract != candidate.ConfigurationContract || !bytes.Equal(existing.Metadata, candidate.Metadata) {
+			lggr.Warnw("capability already registered with a different payload, skipping",
+				"capabilityID", candidate.CapabilityId,
+				"existingConfigurationContract", existing.ConfigurationContract,
+				"configurationContract", candidate.ConfigurationContract,
+				"existingMetadata", string(existing.Metadata),
+				"metadata", string(candidate.Metadata),
+			)
 		}
 	}
 
 	return out, nil
 }
+
+// validateCapability checks that the capability ID has the `name@version` form and that the
+// metadata, if any, is a JSON object.
+func validateCapability(c capabilities_registry_v2.CapabilitiesRegistryCapability) error {
+	idx := strings.LastIndex(c.CapabilityId, "@")
+	if idx <= 0 || idx == len(c.CapabilityId)-1 {
+		return fmt.Errorf("capability ID %q must have the form `name@version`", c.CapabilityId)
+	}
+	if _, err := semver.StrictNewVersion(c.CapabilityId[idx+1:]); err != nil {
+		return fmt.Errorf("capability ID %q has an invalid version: %w", c.CapabilityId, err)
+	}
+
+	metadata := bytes.TrimSpace(c.Metadata)
+	if len(metadata) == 0 || bytes.Equal(metadata, []byte("null")) {
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal(metadata, &m); err != nil {
+		return fmt.Errorf("capability %s metadata must be a JSON object: %w", c.CapabilityId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/operations/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+)
+
+func TestRegisterCapabilitiesInput_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		capability capabilities_registry_v2.CapabilitiesRegistryCapability
+		wantErr    string
+	}{
+		{
+			name:       "valid without metadata",
+			capability: capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "cap@1.0.0"},
+		},
+		{
+			name:       "valid with null metadata",
+			capability: capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "cap@1.0.0", Metadata: []byte(" null ")},
+		},
+		{
+			name:       "valid with object metadata",
+			capability: capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "my@cap@1.0.0-beta.1", Metadata: []byte(`{"key":"value"}`)},
+		},
+		{
+			name:       "missing version",
+			capability: capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "cap"},
+			wantErr:    "must have the form `name@version`",
+		},
+		{
+			name:       "empty version",
+			capability: capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "cap@"},
+			wantErr:    "must have the form `name@version`",
+		},
+		{
+			name:       "empty name",
+			capability: capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "@1.0.0"},
+			wantErr:    "must have the form `name@version`",
+		},
+		{
+			name:       "invalid version",
+			capability: capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "cap@1.0"},
+			wantErr:    `capability ID "cap@1.0" has an invalid version`,
+		},
+		{
+			name:       "metadata not an object",
+			capability: capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "cap@1.0.0", Metadata: []byte(`["value"]`)},
+			wantErr:    "capability cap@1.0.0 metadata must be a JSON object",
+		},
+		{
+			name:       "metadata not JSON",
+			capability: capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "cap@1.0.0", Metadata: []byte("value")},
+			wantErr:    "capability cap@1.0.0 metadata must be a JSON object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := contracts.RegisterCapabilitiesInput{
+				Capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapability{tt.capability},
+			}
+			err := input.Validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRegisterCapabilities_Dedup(t *testing.T) {
+	registeredCap := capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "registered@1.0.0", Metadata: []byte(`{"key":"value"}`)}
+	deprecatedCap := capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "deprecated@1.0.0"}
+	newCap := capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "new@1.0.0"}
+	otherCap := capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: "other@1.0.0", Metadata: []byte(`{"key":"other"}`)}
+
+	tests := []struct {
+		name         string
+		capabilities []capabilities_registry_v2.CapabilitiesRegistryCapability
+		wantErr      string
+		wantAdded    []string
+	}{
+		{
+			name:         "registers new capabilities",
+			capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapability{newCap, otherCap},
+			wantAdded:    []string{"new@1.0.0", "other@1.0.0"},
+		},
+		{
+			name:         "skips registered capabilities",
+			capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapability{registeredCap, newCap},
+			wantAdded:    []string{"new@1.0.0"},
+		},
+		{
+			name: "skips registered capabilities with a different payload",
+			capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapability{
+				{CapabilityId: "registered@1.0.0", Metadata: []byte(`{"key":"other"}`)},
+			},
+		},
+		{
+			name:         "skips deprecated capabilities",
+			capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapability{deprecatedCap},
+		},
+		{
+			name:         "registers a capability given twice once",
+			capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapability{newCap, newCap},
+			wantAdded:    []string{"new@1.0.0"},
+		},
+		{
+			name: "capability given twice with different payloads",
+			capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapability{
+				newCap,
+				{CapabilityId: "new@1.0.0", Metadata: []byte(`{"key":"value"}`)},
+			},
+			wantErr: "capability new@1.0.0 is provided more than once with different payloads",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupRegistry(t, chainsel.TEST_90000001.Selector)
+			chain := f.env.BlockChains.EVMChains()[f.selector]
+			tx, err := f.registry.AddCapabilities(chain.DeployerKey, []capabilities_registry_v2.CapabilitiesRegistryCapability{registeredCap, deprecatedCap})
+			confirm(t, chain, tx, err)
+			tx, err = f.registry.DeprecateCapabilities(chain.DeployerKey, []string{deprecatedCap.CapabilityId})
+			confirm(t, chain, tx, err)
+
+			strategy, err := strategies.CreateStrategy(f.env, f.selector, nil, nil, f.registry.Address(), "")
+			require.NoError(t, err)
+			f.env.OperationsBundle = operations.NewBundle(f.env.GetContext, f.env.Logger, operations.NewMemoryReporter())
+			report, err := operations.ExecuteOperation(f.env.OperationsBundle, contracts.RegisterCapabilities, contracts.RegisterCapabilitiesDeps{
+				Env:      &f.env,
+				Strategy: strategy,
+			}, contracts.RegisterCapabilitiesInput{
+				Address:       f.address,
+				ChainSelector: f.selector,
+				Capabilities:  tt.capabilities,
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, report.Output.Operation)
+
+			// the IDs of the events are hashed, as they are indexed
+			wantAdded := make([]common.Hash, 0, len(tt.wantAdded))
+			for _, id := range tt.wantAdded {
+				wantAdded = append(wantAdded, crypto.Keccak256Hash([]byte(id)))
+			}
+			added := make([]common.Hash, 0, len(report.Output.Capabilities))
+			for _, c := range report.Output.Capabilities {
+				added = append(added, c.CapabilityId)
+			}
+			assert.ElementsMatch(t, wantAdded, added)
+
+			// the registered capabilities are left unchanged
+			caps, err := pkg.GetCapabilities(nil, f.registry)
+			require.NoError(t, err)
+			require.Len(t, caps, 2+len(tt.wantAdded))
+			for _, c := range caps {
+				if c.CapabilityId == registeredCap.CapabilityId {
+					assert.Equal(t, registeredCap.Metadata, c.Metadata)
+				}
+			}
+		})
+	}
+}
@@ -59,15 +59,31 @@ var RegisterDons = operations.NewOperation[RegisterDonsInput, RegisterDonsOutput
 		var resultDONs []capabilities_registry_v2.CapabilitiesRegistryDONInfo
 
 		// Execute the transaction using the strategy
-		operation, _, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		operation, _, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 			return capReg.AddDONs(opts, input.DONs)
+		}, func() (bool, error) {
+			// A previous attempt landed if all the DONs are registered
+			dons, err := pkg.GetDONs(nil, capReg)
+			if err != nil {
+				return false, err
+			}
+			registered := make(map[string]struct{}, len(dons))
+			for _, don := range dons {
+				registered[don.Name] = struct{}{}
+			}
+			for _, don := range input.DONs {
+				if _, ok := registered[don.Name]; !ok {
+					return false, nil
+				}
+			}
+			return true, nil
 		})
 		if err != nil {
 			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
 			return RegisterDonsOutput{}, fmt.Errorf("failed to execute AddDONs: %w", err)
 		}
 
-		if input.MCMSConfig != nil {
+		if operation != nil {
 			deps.Env.Logger.Infof("Created MCMS proposal for RegisterDons on chain %d", input.ChainSelector)
 		} else {
 			deps.Env.Logger.Infof("Successfully registered %d DONs on chain %d", len(resultDONs), input.ChainSelector)
@@ -34,7 +34,10 @@ type RegisterNodesInput struct {
 }
 
 type NodesInput struct {
-	NOP                 string
+	NOP string
+	// NodeOperatorID is the ID of the node operator, resolved from the contract by NOP name when not set. Set it to
+	// register nodes of node operators added in the same MCMS proposal.
+	NodeOperatorID      uint32
 	Signer              [32]byte
 	P2pID               [32]byte
 	EncryptionPublicKey [32]byte
@@ -93,29 +96,25 @@ var RegisterNodes = operations.NewOperation[RegisterNodesInput, RegisterNodesOut
 			}, nil
 		}
 
-		contractNOPs, err := pkg.GetNodeOperators(nil, capReg)
+		contractNOPs, err := pkg.GetNodeOperatorsWithIDs(nil, capReg)
 		if err != nil {
 			return RegisterNodesOutput{}, fmt.Errorf("failed to fetch node operators from contract: %w", err)
 		}
 
-		allNOPsNamesInContract := make(map[string]int)
-		for i, nop := range contractNOPs {
-			// NodeOperatorId is 1-based and returned in order from the contract.
-			// So the ID is the index + 1
-			// See the implementation of `AddNodeOperators` in the contract for reference:
-			// https://github.com/smartcontractkit/chainlink-evm/blob/develop/contracts/src/v0.8/workflow/v2/CapabilitiesRegistry.sol#L568
-			allNOPsNamesInContract[nop.Name] = i + 1
-		}
+		allNOPsNamesInContract := pkg.NodeOperatorIDsByName(contractNOPs)
 
 		var nodes []capabilities_registry_v2.CapabilitiesRegistryNodeParams
 		for _, node := range dedupedNodes {
-			index, exists := allNOPsNamesInContract[node.NOP]
-			if !exists {
-				return RegisterNodesOutput{}, fmt.Errorf("node operator `%s` not found in contract", node.NOP)
+			nopID := node.NodeOperatorID
+			if nopID == 0 {
+				var exists bool
+				if nopID, exists = allNOPsNamesInContract[node.NOP]; !exists {
+					return RegisterNodesOutput{}, fmt.Errorf("node operator `%s` not found in contract", node.NOP)
+				}
 			}
 
 			nodes = append(nodes, capabilities_registry_v2.CapabilitiesRegistryNodeParams{
-				NodeOperatorId:      uint32(index),
+				NodeOperatorId:      nopID,
 				Signer:              node.Signer,
 				EncryptionPublicKey: node.EncryptionPublicKey,
 				P2pId:               node.P2pID,
@@ -131,16 +130,22 @@ var RegisterNodes = operations.NewOperation[RegisterNodesInput, RegisterNodesOut
 		var resultNodes []*capabilities_registry_v2.CapabilitiesRegistryNodeAdded
 
 		// Execute the transaction using the strategy
-		operation, tx, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 			return capReg.AddNodes(opts, nodes)
+		}, func() (bool, error) {
+			// A previous attempt landed if none of the nodes is missing anymore
+			missing, err := dedupNodes(deps.Env.Logger, dedupedNodes, capReg)
+			return len(missing) == 0, err
 		})
 		if err != nil {
 			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
 			return RegisterNodesOutput{}, fmt.Errorf("failed to execute AddNodes: %w", err)
 		}
 
-		if input.MCMSConfig != nil {
+		if operation != nil {
 			deps.Env.Logger.Infof("Created MCMS proposal for RegisterNodes on chain %d", input.ChainSelector)
+		} else if tx == nil {
+			deps.Env.Logger.Infof("Nodes on chain %d were registered by a previous attempt", input.ChainSelector)
 		} else {
 			deps.Env.Logger.Infof("Successfully registered %d nodes on chain %d", len(resultNodes), input.ChainSelector)
 
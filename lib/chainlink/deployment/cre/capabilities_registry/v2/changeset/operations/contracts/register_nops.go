@@ -2,6 +2,7 @@ package contracts
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -28,12 +29,19 @@ type RegisterNopsInput struct {
 	Address       string
 	ChainSelector uint64
 	Nops          []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams
-	MCMSConfig    *contracts.MCMSConfig
+	// UpdateAdmins updates the admin of the already registered node operators whose admin differs from the input.
+	// If not set, such a mismatch is an error.
+	UpdateAdmins bool
+	MCMSConfig   *contracts.MCMSConfig
 }
 
 type RegisterNopsOutput struct {
-	Nops      []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorAdded
-	Operation *mcmstypes.BatchOperation
+	Nops []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorAdded
+	// AdminUpdates are the admin changes of already registered node operators, only when UpdateAdmins is set.
+	AdminUpdates []NopDiff
+	// UpdatedNops are the parsed NodeOperatorUpdated events of the admin changes, only set when MCMS is not used.
+	UpdatedNops []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated
+	Operation   *mcmstypes.BatchOperation
 }
 
 // RegisterNops is an operation that registers node operators in the V2 Capabilities Registry contract.
@@ -64,83 +72,154 @@ var RegisterNops = operations.NewOperation[RegisterNopsInput, RegisterNopsOutput
 			return RegisterNopsOutput{}, fmt.Errorf("failed to create NewCapabilitiesRegistry: %w", err)
 		}
 
-		dedupedNOPs, err := dedupNOPs(deps.Env.Logger, input.Nops, capReg)
+		dedupedNOPs, adminUpdates, err := dedupNOPs(deps.Env.Logger, input.Nops, capReg)
 		if err != nil {
 			return RegisterNopsOutput{}, fmt.Errorf("failed to dedupe NOPs: %w", err)
 		}
+		if len(adminUpdates) > 0 && !input.UpdateAdmins {
+			mismatches := make([]string, 0, len(adminUpdates))
+			for _, u := range adminUpdates {
+				mismatches = append(mismatches, fmt.Sprintf("`%s` has admin %s in contract but %s in input", u.OldName, u.OldAdmin, u.NewAdmin))
+			}
+			return RegisterNopsOutput{}, fmt.Errorf("already registered node operators have a different admin, set UpdateAdmins to update them: %s", strings.Join(mismatches, "; "))
+		}
 
-		var resultNops []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorAdded
+		var updateOperation *mcmstypes.BatchOperation
+		var updateTx *types.Transaction
+		if len(adminUpdates) > 0 {
+			ids := make([]uint32, 0, len(adminUpdates))
+			params := make([]capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams, 0, len(adminUpdates))
+			for _, u := range adminUpdates {
+				ids = append(ids, u.NodeOperatorID)
+				params = append(params, capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{Admin: u.NewAdmin, Name: u.NewName})
+			}
+
+			updateOperation, updateTx, err = strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+				return capReg.UpdateNodeOperators(opts, ids, params)
+			}, func() (bool, error) {
+				_, remaining, err := dedupNOPs(deps.Env.Logger, input.Nops, capReg)
+				return len(remaining) == 0, err
+			})
+			if err != nil {
+				err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+				return RegisterNopsOutput{}, fmt.Errorf("failed to execute UpdateNodeOperators: %w", err)
+			}
+		}
 
 		// Execute the transaction using the strategy
-		operation, tx, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 			return capReg.AddNodeOperators(opts, dedupedNOPs)
+		}, func() (bool, error) {
+			// A previous attempt landed if none of the node operators is missing anymore
+			missing, _, err := dedupNOPs(deps.Env.Logger, dedupedNOPs, capReg)
+			return len(missing) == 0, err
 		})
 		if err != nil {
 			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
 			return RegisterNopsOutput{}, fmt.Errorf("failed to execute AddNodeOperators: %w", err)
 		}
 
-		if input.MCMSConfig != nil {
+		var resultNops []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorAdded
+		var resultUpdatedNops []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated
+		if operation != nil {
 			deps.Env.Logger.Infof("Created MCMS proposal for RegisterNops on chain %d", input.ChainSelector)
 		} else {
-			deps.Env.Logger.Infof("Successfully registered %d node operators on chain %d", len(resultNops), input.ChainSelector)
-
-			ctx := b.GetContext()
-			receipt, err := bind.WaitMined(ctx, chain.Client, tx)
+			// Wait for both transactions at once, a nil transaction was applied by a previous attempt
+			pool := strategies.NewConfirmationPool(chain.Client, 0)
+			pool.Add(updateTx, tx)
+			result, err := pool.Wait(b.GetContext())
 			if err != nil {
-				return RegisterNopsOutput{}, fmt.Errorf("failed to mine AddNodeOperators transaction %s: %w", tx.Hash().String(), err)
+				return RegisterNopsOutput{}, fmt.Errorf("failed to mine RegisterNops transactions: %w", err)
 			}
 
-			// Get the CapabilitiesRegistryFilterer contract for parsing logs
-			capabilityRegistryFilterer, err := capabilities_registry_v2.NewCapabilitiesRegistryFilterer(
-				common.HexToAddress(input.Address),
-				chain.Client,
-			)
-			if err != nil {
-				return RegisterNopsOutput{}, fmt.Errorf("failed to create CapabilitiesRegistryFilterer: %w", err)
+			if updateTx != nil {
+				events, err := pkg.ParseRegistryLogs(capReg, result.ReceiptOf(updateTx.Hash()).Logs)
+				if err != nil {
+					return RegisterNopsOutput{}, fmt.Errorf("failed to parse logs of transaction %s: %w", updateTx.Hash().String(), err)
+				}
+				resultUpdatedNops = events.NodeOperatorsUpdated
+				deps.Env.Logger.Infof("Successfully updated admin of %d node operators on chain %d", len(resultUpdatedNops), input.ChainSelector)
 			}
 
-			// Parse the logs to get the added node operators
-			resultNops = make([]*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorAdded, 0, len(receipt.Logs))
-			for i, log := range receipt.Logs {
-				if log == nil {
-					continue
+			if tx == nil {
+				deps.Env.Logger.Infof("Node operators on chain %d were registered by a previous attempt", input.ChainSelector)
+			} else {
+				// Get the CapabilitiesRegistryFilterer contract for parsing logs
+				capabilityRegistryFilterer, err := capabilities_registry_v2.NewCapabilitiesRegistryFilterer(
+					common.HexToAddress(input.Address),
+					chain.Client,
+				)
+				if err != nil {
+					return RegisterNopsOutput{}, fmt.Errorf("failed to create CapabilitiesRegistryFilterer: %w", err)
 				}
 
-				o, err := capabilityRegistryFilterer.ParseNodeOperatorAdded(*log)
-				if err != nil {
-					return RegisterNopsOutput{}, fmt.Errorf("failed to parse log %d for operator added: %w", i, err)
+				// Parse the logs to get the added node operators
+				receipt := result.ReceiptOf(tx.Hash())
+				resultNops = make([]*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorAdded, 0, len(receipt.Logs))
+				for i, log := range receipt.Logs {
+					if log == nil {
+						continue
+					}
+
+					o, err := capabilityRegistryFilterer.ParseNodeOperatorAdded(*log)
+					if err != nil {
+						return RegisterNopsOutput{}, fmt.Errorf("failed to parse log %d for operator added: %w", i, err)
+					}
+					resultNops = append(resultNops, o)
 				}
-				resultNops = append(resultNops, o)
+				deps.Env.Logger.Infof("Successfully registered %d node operators on chain %d", len(resultNops), input.ChainSelector)
 			}
 		}
 
+		// Both calls are part of the same batch when using MCMS
+		if updateOperation != nil {
+			updateOperation.Transactions = append(updateOperation.Transactions, operation.Transactions...)
+			operation = updateOperation
+		}
+
 		return RegisterNopsOutput{
-			Nops:      resultNops,
-			Operation: operation,
+			Nops:         resultNops,
+			AdminUpdates: adminUpdates,
+			UpdatedNops:  resultUpdatedNops,
+			Operation:    operation,
 		}, nil
 	},
 )
 
-func dedupNOPs(lggr logger.Logger, inputNOPs []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams, capReg *capabilities_registry_v2.CapabilitiesRegistry) ([]capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams, error) {
-	contractNOPs, err := pkg.GetNodeOperators(nil, capReg)
+// dedupNOPs filters out the node operators already registered in the contract, identified by name. It also returns
+// the admin changes of the already registered node operators whose admin differs from the input.
+func dedupNOPs(lggr logger.Logger, inputNOPs []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams, capReg *capabilities_registry_v2.CapabilitiesRegistry) ([]capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams, []NopDiff, error) {
+	contractNOPs, err := pkg.GetNodeOperatorsWithIDs(nil, capReg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch nodes from contract: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch nodes from contract: %w", err)
 	}
-	contractNOPsMap := make(map[string]struct{})
+	contractByName := make(map[string]pkg.NodeOperator, len(contractNOPs))
 	for _, nop := range contractNOPs {
-		contractNOPsMap[nop.Name] = struct{}{}
+		contractByName[nop.Name] = nop
 	}
 
 	var dedupedNOPs []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams
+	var adminUpdates []NopDiff
 	for i, nop := range inputNOPs {
-		if _, exists := contractNOPsMap[nop.Name]; exists {
-			lggr.Infof("NOP with name %s already registered in contract, skipping", nop.Name)
+		current, exists := contractByName[nop.Name]
+		if !exists {
+			dedupedNOPs = append(dedupedNOPs, inputNOPs[i])
 			continue
 		}
 
-		dedupedNOPs = append(dedupedNOPs, inputNOPs[i])
+		if current.Admin != nop.Admin {
+			lggr.Warnf("NOP with name %s already registered in contract with admin %s, input admin is %s", nop.Name, current.Admin, nop.Admin)
+			adminUpdates = append(adminUpdates, NopDiff{
+				NodeOperatorID: current.ID,
+				OldName:        current.Name,
+				NewName:        current.Name,
+				OldAdmin:       current.Admin,
+				NewAdmin:       nop.Admin,
+			})
+			continue
+		}
+		lggr.Infof("NOP with name %s already registered in contract, skipping", nop.Name)
 	}
 
-	return dedupedNOPs, nil
+	return dedupedNOPs, adminUpdates, nil
 }
@@ -0,0 +1,154 @@
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/operations/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+)
+
+var testNops = []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+	{Admin: common.HexToAddress("0x01"), Name: "nop-1"},
+	{Admin: common.HexToAddress("0x02"), Name: "nop-2"},
+}
+
+func registerNops(t *testing.T, f *registryFixture, strategy strategies.TransactionStrategy) contracts.RegisterNopsOutput {
+	t.Helper()
+
+	// a new bundle, so that the same input is executed again with another strategy
+	f.env.OperationsBundle = operations.NewBundle(f.env.GetContext, f.env.Logger, operations.NewMemoryReporter())
+	report, err := operations.ExecuteOperation(f.env.OperationsBundle, contracts.RegisterNops, contracts.RegisterNopsDeps{
+		Env:      &f.env,
+		Strategy: strategy,
+	}, contracts.RegisterNopsInput{
+		Address:       f.address,
+		ChainSelector: f.selector,
+		Nops:          testNops,
+	})
+	require.NoError(t, err)
+	return report.Output
+}
+
+func requireNopCount(t *testing.T, f *registryFixture, count int) {
+	t.Helper()
+
+	nops, err := pkg.GetNodeOperatorsWithIDs(nil, f.registry)
+	require.NoError(t, err)
+	require.Len(t, nops, count)
+}
+
+func TestRegisterNops_Simulation(t *testing.T) {
+	f := setupRegistry(t, chainsel.TEST_90000001.Selector)
+
+	strategy, err := strategies.CreateStrategy(f.env, f.selector, nil, nil, f.registry.Address(), "",
+		strategies.WithSimulation(capabilities_registry_v2.CapabilitiesRegistryABI))
+	require.NoError(t, err)
+	sim, ok := strategy.(*strategies.SimulateTransaction)
+	require.True(t, ok)
+
+	// the dry run returns the batch operation instead of waiting for a transaction which is never sent
+	out := registerNops(t, f, strategy)
+	require.NotNil(t, out.Operation)
+	require.Len(t, out.Operation.Transactions, 1)
+	assert.Empty(t, out.Nops)
+
+	results := sim.Results()
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Reverted)
+	assert.Equal(t, f.env.BlockChains.EVMChains()[f.selector].DeployerKey.From, results[0].From)
+	require.NoError(t, sim.Err())
+	requireNopCount(t, f, 0)
+
+	_, err = strategy.BuildProposal([]mcmstypes.BatchOperation{*out.Operation})
+	require.ErrorIs(t, err, strategies.ErrNoProposal)
+
+	// the same operation with the direct strategy registers the node operators
+	direct, err := strategies.CreateStrategy(f.env, f.selector, nil, nil, f.registry.Address(), "")
+	require.NoError(t, err)
+	out = registerNops(t, f, direct)
+	assert.Nil(t, out.Operation)
+	assert.Len(t, out.Nops, 2)
+	requireNopCount(t, f, 2)
+}
+
+// safeStubCode is the init code of a contract returning 42 to any call, standing for the nonce of a Safe.
+var safeStubCode = common.FromHex("0x600a600c600039600a6000f3" + "602a60005260206000f3")
+
+func TestRegisterNops_Safe(t *testing.T) {
+	f := setupRegistry(t, chainsel.TEST_90000001.Selector)
+	chain := f.env.BlockChains.EVMChains()[f.selector]
+
+	safe, tx, _, err := bind.DeployContract(chain.DeployerKey, abi.ABI{}, safeStubCode, chain.Client)
+	require.NoError(t, err)
+	_, err = chain.Confirm(tx)
+	require.NoError(t, err)
+
+	strategy, err := strategies.CreateStrategy(f.env, f.selector, nil, nil, f.registry.Address(), "",
+		strategies.WithSafes(map[uint64]common.Address{f.selector: safe}))
+	require.NoError(t, err)
+	safeStrategy, ok := strategy.(*strategies.SafeTransaction)
+	require.True(t, ok)
+
+	// the call is encoded as a Safe transaction instead of waiting for a transaction which is never sent
+	out := registerNops(t, f, strategy)
+	require.NotNil(t, out.Operation)
+	require.Len(t, out.Operation.Transactions, 1)
+	requireNopCount(t, f, 0)
+
+	safeTxs := safeStrategy.Transactions()
+	require.Len(t, safeTxs, 1)
+	assert.Equal(t, safe, safeTxs[0].Safe)
+	assert.Equal(t, f.registry.Address(), safeTxs[0].To)
+	assert.Equal(t, uint64(42), safeTxs[0].Nonce)
+	assert.Equal(t, out.Operation.Transactions[0].Data, []byte(safeTxs[0].Data))
+
+	_, err = strategy.BuildProposal([]mcmstypes.BatchOperation{*out.Operation})
+	require.ErrorIs(t, err, strategies.ErrNoProposal)
+}
+
+func TestRegisterNops_Export(t *testing.T) {
+	// the chain ID of the selector is the one of the simulated chains, so the exported transactions can be broadcast
+	f := setupRegistry(t, chainsel.GETH_TESTNET.Selector)
+	chain := f.env.BlockChains.EVMChains()[f.selector]
+
+	export := &strategies.ExportTransaction{Chain: chain, From: chain.DeployerKey.From, Dir: t.TempDir()}
+
+	// the transaction is exported instead of waiting for a transaction which is never sent
+	out := registerNops(t, f, export)
+	require.NotNil(t, out.Operation)
+	requireNopCount(t, f, 0)
+
+	_, err := export.BuildProposal([]mcmstypes.BatchOperation{*out.Operation})
+	require.ErrorIs(t, err, strategies.ErrNoProposal)
+
+	exported := export.Exported()
+	require.Len(t, exported, 1)
+	assert.Equal(t, f.registry.Address(), *exported[0].To)
+
+	// sign offline with the cold key, then broadcast
+	var unsigned types.Transaction
+	require.NoError(t, unsigned.UnmarshalBinary(exported[0].Raw))
+	signed, err := chain.DeployerKey.Signer(chain.DeployerKey.From, &unsigned)
+	require.NoError(t, err)
+	raw, err := signed.MarshalBinary()
+	require.NoError(t, err)
+	tx, err := strategies.BroadcastSignedTransaction(t.Context(), chain.Client, f.selector, chain.DeployerKey.From, raw)
+	require.NoError(t, err)
+	_, err = chain.Confirm(tx)
+	require.NoError(t, err)
+
+	requireNopCount(t, f, len(testNops))
+}
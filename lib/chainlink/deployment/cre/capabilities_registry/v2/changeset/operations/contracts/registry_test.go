@@ -0,0 +1,124 @@
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/test/environment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/engine/test/runtime"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset"
+)
+
+type registryFixture struct {
+	env      cldf.Environment
+	selector uint64
+	address  string
+	registry *capabilities_registry_v2.CapabilitiesRegistry
+}
+
+// setupRegistry deploys a capabilities registry on a simulated chain with the given selector.
+func setupRegistry(t *testing.T, selector uint64) *registryFixture {
+	t.Helper()
+
+	rt, err := runtime.New(t.Context(), runtime.WithEnvOpts(
+		environment.WithEVMSimulated(t, []uint64{selector}),
+		environment.WithLogger(logger.Test(t)),
+	))
+	require.NoError(t, err)
+
+	qualifier := "operations-tests"
+	deployTask := runtime.ChangesetTask(changeset.DeployCapabilitiesRegistry{}, changeset.DeployCapabilitiesRegistryInput{
+		ChainSelector: selector,
+		Qualifier:     qualifier,
+	})
+	require.NoError(t, rt.Exec(deployTask))
+	addr := rt.State().Outputs[deployTask.ID()].DataStore.Addresses().Filter(datastore.AddressRefByQualifier(qualifier))[0].Address
+
+	env := rt.Environment()
+	registry, err := capabilities_registry_v2.NewCapabilitiesRegistry(common.HexToAddress(addr), env.BlockChains.EVMChains()[selector].Client)
+	require.NoError(t, err)
+
+	return &registryFixture{env: env, selector: selector, address: addr, registry: registry}
+}
+
+// deployRegistry deploys another capabilities registry with the given params to the chain of the fixture.
+func deployRegistry(t *testing.T, f *registryFixture, params capabilities_registry_v2.CapabilitiesRegistryConstructorParams) *registryFixture {
+	t.Helper()
+
+	chain := f.env.BlockChains.EVMChains()[f.selector]
+	addr, tx, registry, err := capabilities_registry_v2.DeployCapabilitiesRegistry(chain.DeployerKey, chain.Client, params)
+	confirm(t, chain, tx, err)
+
+	return &registryFixture{env: f.env, selector: f.selector, address: addr.Hex(), registry: registry}
+}
+
+const testCapabilityID = "test-capability@1.0.0"
+
+// addTestNodes registers a node operator, a capability and count nodes of the operator supporting the capability with
+// the deployer key. It returns the P2P IDs of the nodes.
+func addTestNodes(t *testing.T, f *registryFixture, count int) [][32]byte {
+	t.Helper()
+
+	chain := f.env.BlockChains.EVMChains()[f.selector]
+	tx, err := f.registry.AddNodeOperators(chain.DeployerKey, []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+		{Admin: chain.DeployerKey.From, Name: "test-nop"},
+	})
+	confirm(t, chain, tx, err)
+	tx, err = f.registry.AddCapabilities(chain.DeployerKey, []capabilities_registry_v2.CapabilitiesRegistryCapability{
+		{CapabilityId: testCapabilityID},
+	})
+	confirm(t, chain, tx, err)
+
+	nodes := make([]capabilities_registry_v2.CapabilitiesRegistryNodeParams, 0, count)
+	p2pIDs := make([][32]byte, 0, count)
+	for i := range count {
+		key := func(kind byte) [32]byte {
+			return [32]byte{0: kind, 31: byte(i + 1)}
+		}
+		nodes = append(nodes, capabilities_registry_v2.CapabilitiesRegistryNodeParams{
+			NodeOperatorId:      1,
+			Signer:              key(1),
+			P2pId:               key(2),
+			EncryptionPublicKey: key(3),
+			CsaKey:              key(4),
+			CapabilityIds:       []string{testCapabilityID},
+		})
+		p2pIDs = append(p2pIDs, key(2))
+	}
+	tx, err = f.registry.AddNodes(chain.DeployerKey, nodes)
+	confirm(t, chain, tx, err)
+
+	return p2pIDs
+}
+
+// addTestDON registers a DON of the nodes, supporting the capability of addTestNodes, with the deployer key.
+func addTestDON(t *testing.T, f *registryFixture, name string, p2pIDs [][32]byte, faultTolerance uint8) {
+	t.Helper()
+
+	chain := f.env.BlockChains.EVMChains()[f.selector]
+	tx, err := f.registry.AddDONs(chain.DeployerKey, []capabilities_registry_v2.CapabilitiesRegistryNewDONParams{{
+		Name:                     name,
+		Nodes:                    p2pIDs,
+		F:                        faultTolerance,
+		IsPublic:                 true,
+		CapabilityConfigurations: []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration{{CapabilityId: testCapabilityID}},
+	}})
+	confirm(t, chain, tx, err)
+}
+
+func confirm(t *testing.T, chain cldf_evm.Chain, tx *types.Transaction, err error) {
+	t.Helper()
+
+	require.NoError(t, err)
+	_, err = chain.Confirm(tx)
+	require.NoError(t, err)
+}
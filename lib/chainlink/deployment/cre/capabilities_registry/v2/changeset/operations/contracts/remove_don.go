@@ -0,0 +1,152 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+type RemoveDONDeps struct {
+	Env                  *cldf.Environment
+	Strategy             strategies.TransactionStrategy
+	CapabilitiesRegistry *capabilities_registry_v2.CapabilitiesRegistry
+}
+
+type RemoveDONInput struct {
+	ChainSelector uint64
+
+	// DonName to remove, this is required
+	DonName string
+
+	// Force indicates whether to remove the DON even if it still belongs to DON families or accepts workflows.
+	// Workflows targeting a removed DON stop working, be very careful with this option.
+	Force bool
+
+	MCMSConfig *contracts.MCMSConfig
+}
+
+func (i *RemoveDONInput) Validate() error {
+	if i.DonName == "" {
+		return errors.New("must specify DonName")
+	}
+
+	return nil
+}
+
+type RemoveDONOutput struct {
+	// RemovedDON is the state of the DON before it was removed.
+	RemovedDON capabilities_registry_v2.CapabilitiesRegistryDONInfo
+	// DONs are the DONs left in the registry after the removal, only set when MCMS is not used.
+	DONs      []capabilities_registry_v2.CapabilitiesRegistryDONInfo
+	Operation *mcmstypes.BatchOperation
+}
+
+// RemoveDON is an operation that removes a DON, looked up by name, from the V2 Capabilities Registry contract.
+var RemoveDON = operations.NewOperation[RemoveDONInput, RemoveDONOutput, RemoveDONDeps](
+	"remove-don-op",
+	semver.MustParse("1.0.0"),
+	"Remove DON from Capabilities Registry",
+	func(b operations.Bundle, deps RemoveDONDeps, input RemoveDONInput) (RemoveDONOutput, error) {
+		if err := input.Validate(); err != nil {
+			return RemoveDONOutput{}, err
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return RemoveDONOutput{}, cldf.ErrChainNotFound
+		}
+
+		don, err := deps.CapabilitiesRegistry.GetDONByName(&bind.CallOpts{}, input.DonName)
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return RemoveDONOutput{}, fmt.Errorf("failed to call GetDONByName: %w", err)
+		}
+
+		if err := checkDONRemovable(b, don, input.Force); err != nil {
+			return RemoveDONOutput{}, err
+		}
+
+		// Execute the transaction using the strategy
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return deps.CapabilitiesRegistry.RemoveDONsByName(opts, []string{input.DonName})
+		}, func() (bool, error) {
+			// A previous attempt landed if the DON is not registered anymore
+			dons, err := pkg.GetDONs(nil, deps.CapabilitiesRegistry)
+			if err != nil {
+				return false, err
+			}
+			return !slices.ContainsFunc(dons, func(d capabilities_registry_v2.CapabilitiesRegistryDONInfo) bool {
+				return d.Name == input.DonName
+			}), nil
+		})
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return RemoveDONOutput{}, fmt.Errorf("failed to execute RemoveDONsByName: %w", err)
+		}
+
+		out := RemoveDONOutput{
+			RemovedDON: don,
+			Operation:  operation,
+		}
+
+		if operation != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for RemoveDON '%s' on chain %d", input.DonName, input.ChainSelector)
+			return out, nil
+		}
+
+		if tx == nil {
+			deps.Env.Logger.Infof("DON '%s' on chain %d was removed by a previous attempt", input.DonName, input.ChainSelector)
+		} else if _, err = bind.WaitMined(b.GetContext(), chain.Client, tx); err != nil {
+			return RemoveDONOutput{}, fmt.Errorf("failed to mine RemoveDONsByName transaction %s: %w", tx.Hash().String(), err)
+		}
+
+		out.DONs, err = pkg.GetDONs(nil, deps.CapabilitiesRegistry)
+		if err != nil {
+			return RemoveDONOutput{}, fmt.Errorf("failed to call GetDONs: %w", err)
+		}
+		for _, d := range out.DONs {
+			if d.Name == input.DonName {
+				return RemoveDONOutput{}, fmt.Errorf("DON '%s' is still registered after removal", input.DonName)
+			}
+		}
+
+		deps.Env.Logger.Infof("Successfully removed DON '%s' on chain %d, %d DONs left", input.DonName, input.ChainSelector, len(out.DONs))
+
+		return out, nil
+	},
+)
+
+// checkDONRemovable fails if the DON still belongs to DON families or accepts workflows, unless force is set.
+func checkDONRemovable(b operations.Bundle, don capabilities_registry_v2.CapabilitiesRegistryDONInfo, force bool) error {
+	var reasons []string
+	if len(don.DonFamilies) > 0 {
+		reasons = append(reasons, fmt.Sprintf("it belongs to DON families %v", don.DonFamilies))
+	}
+	if don.AcceptsWorkflows {
+		reasons = append(reasons, "it accepts workflows")
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	if !force {
+		return fmt.Errorf("refusing to remove DON '%s' because %s, use force to remove it anyway", don.Name, strings.Join(reasons, " and "))
+	}
+	b.Logger.Warnf("removing DON '%s' although %s", don.Name, strings.Join(reasons, " and "))
+
+	return nil
+}
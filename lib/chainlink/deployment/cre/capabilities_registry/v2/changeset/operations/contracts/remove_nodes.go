@@ -0,0 +1,135 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+type RemoveNodesDeps struct {
+	Env      *cldf.Environment
+	Strategy strategies.TransactionStrategy
+}
+
+type RemoveNodesInput struct {
+	Address       string
+	ChainSelector uint64
+	P2PIDs        []p2pkey.PeerID
+	MCMSConfig    *contracts.MCMSConfig
+}
+
+func (i *RemoveNodesInput) Validate() error {
+	if i.Address == "" {
+		return errors.New("address is not set")
+	}
+	if i.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+
+	return nil
+}
+
+type RemoveNodesOutput struct {
+	Nodes     []*capabilities_registry_v2.CapabilitiesRegistryNodeRemoved
+	Operation *mcmstypes.BatchOperation
+}
+
+// RemoveNodes is an operation that removes nodes from the V2 Capabilities Registry contract.
+// The nodes must not belong to any DON anymore, otherwise the contract reverts.
+var RemoveNodes = operations.NewOperation[RemoveNodesInput, RemoveNodesOutput, RemoveNodesDeps](
+	"remove-nodes-op",
+	semver.MustParse("1.0.0"),
+	"Remove Nodes from Capabilities Registry",
+	func(b operations.Bundle, deps RemoveNodesDeps, input RemoveNodesInput) (RemoveNodesOutput, error) {
+		if err := input.Validate(); err != nil {
+			return RemoveNodesOutput{}, err
+		}
+		if len(input.P2PIDs) == 0 {
+			return RemoveNodesOutput{
+				Nodes: []*capabilities_registry_v2.CapabilitiesRegistryNodeRemoved{},
+			}, nil
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return RemoveNodesOutput{}, fmt.Errorf("chain not found for selector %d", input.ChainSelector)
+		}
+
+		capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(
+			common.HexToAddress(input.Address),
+			chain.Client,
+		)
+		if err != nil {
+			return RemoveNodesOutput{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+		}
+
+		var resultNodes []*capabilities_registry_v2.CapabilitiesRegistryNodeRemoved
+
+		// Execute the transaction using the strategy
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return capReg.RemoveNodes(opts, pkg.PeerIDsToBytes(input.P2PIDs))
+		}, func() (bool, error) {
+			// A previous attempt landed if none of the nodes is registered anymore
+			nodes, err := pkg.GetNodes(nil, capReg)
+			if err != nil {
+				return false, err
+			}
+			removed := pkg.PeerIDsToBytes(input.P2PIDs)
+			return !slices.ContainsFunc(nodes, func(n capabilities_registry_v2.INodeInfoProviderNodeInfo) bool {
+				return slices.Contains(removed, n.P2pId)
+			}), nil
+		})
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return RemoveNodesOutput{}, fmt.Errorf("failed to execute RemoveNodes: %w", err)
+		}
+
+		if operation != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for RemoveNodes on chain %d", input.ChainSelector)
+		} else if tx == nil {
+			deps.Env.Logger.Infof("Nodes on chain %d were removed by a previous attempt", input.ChainSelector)
+		} else {
+			ctx := b.GetContext()
+			receipt, err := bind.WaitMined(ctx, chain.Client, tx)
+			if err != nil {
+				return RemoveNodesOutput{}, fmt.Errorf("failed to mine RemoveNodes transaction %s: %w", tx.Hash().String(), err)
+			}
+
+			// Parse the logs to get the removed nodes
+			resultNodes = make([]*capabilities_registry_v2.CapabilitiesRegistryNodeRemoved, 0, len(receipt.Logs))
+			for i, log := range receipt.Logs {
+				if log == nil {
+					continue
+				}
+
+				o, err := capReg.ParseNodeRemoved(*log)
+				if err != nil {
+					return RemoveNodesOutput{}, fmt.Errorf("failed to parse log %d for node removed: %w", i, err)
+				}
+				resultNodes = append(resultNodes, o)
+			}
+
+			deps.Env.Logger.Infof("Successfully removed %d nodes on chain %d", len(resultNodes), input.ChainSelector)
+		}
+
+		return RemoveNodesOutput{
+			Nodes:     resultNodes,
+			Operation: operation,
+		}, nil
+	},
+)
@@ -0,0 +1,170 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+type RemoveNopsDeps struct {
+	Env      *cldf.Environment
+	Strategy strategies.TransactionStrategy
+}
+
+type RemoveNopsInput struct {
+	Address       string
+	ChainSelector uint64
+	// NopNames are the names of the node operators to remove. The IDs are resolved from the contract.
+	NopNames   []string
+	MCMSConfig *contracts.MCMSConfig
+}
+
+func (i *RemoveNopsInput) Validate() error {
+	if i.Address == "" {
+		return errors.New("address is not set")
+	}
+	if i.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+	for _, name := range i.NopNames {
+		if name == "" {
+			return errors.New("node operator name cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+type RemoveNopsOutput struct {
+	Nops      []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorRemoved
+	Operation *mcmstypes.BatchOperation
+}
+
+// RemoveNops is an operation that removes node operators from the V2 Capabilities Registry contract.
+var RemoveNops = operations.NewOperation[RemoveNopsInput, RemoveNopsOutput, RemoveNopsDeps](
+	"remove-nops-op",
+	semver.MustParse("1.0.0"),
+	"Remove Node Operators from Capabilities Registry",
+	func(b operations.Bundle, deps RemoveNopsDeps, input RemoveNopsInput) (RemoveNopsOutput, error) {
+		if err := input.Validate(); err != nil {
+			return RemoveNopsOutput{}, err
+		}
+		if len(input.NopNames) == 0 {
+			return RemoveNopsOutput{
+				Nops: []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorRemoved{},
+			}, nil
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return RemoveNopsOutput{}, fmt.Errorf("chain not found for selector %d", input.ChainSelector)
+		}
+
+		capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(
+			common.HexToAddress(input.Address),
+			chain.Client,
+		)
+		if err != nil {
+			return RemoveNopsOutput{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+		}
+
+		nopIDs, err := resolveNOPIDs(capReg, input.NopNames)
+		if err != nil {
+			return RemoveNopsOutput{}, err
+		}
+
+		var resultNops []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorRemoved
+
+		// Execute the transaction using the strategy
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return capReg.RemoveNodeOperators(opts, nopIDs)
+		}, func() (bool, error) {
+			// A previous attempt landed if none of the node operators is registered anymore
+			nops, err := pkg.GetNodeOperatorsWithIDs(nil, capReg)
+			if err != nil {
+				return false, err
+			}
+			return !slices.ContainsFunc(nops, func(nop pkg.NodeOperator) bool {
+				return slices.Contains(nopIDs, nop.ID)
+			}), nil
+		})
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return RemoveNopsOutput{}, fmt.Errorf("failed to execute RemoveNodeOperators: %w", err)
+		}
+
+		if operation != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for RemoveNops on chain %d", input.ChainSelector)
+		} else if tx == nil {
+			deps.Env.Logger.Infof("Node operators on chain %d were removed by a previous attempt", input.ChainSelector)
+		} else {
+			ctx := b.GetContext()
+			receipt, err := bind.WaitMined(ctx, chain.Client, tx)
+			if err != nil {
+				return RemoveNopsOutput{}, fmt.Errorf("failed to mine RemoveNodeOperators transaction %s: %w", tx.Hash().String(), err)
+			}
+
+			// Parse the logs to get the removed node operators
+			resultNops = make([]*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorRemoved, 0, len(receipt.Logs))
+			for i, log := range receipt.Logs {
+				if log == nil {
+					continue
+				}
+
+				o, err := capReg.ParseNodeOperatorRemoved(*log)
+				if err != nil {
+					return RemoveNopsOutput{}, fmt.Errorf("failed to parse log %d for operator removed: %w", i, err)
+				}
+				resultNops = append(resultNops, o)
+			}
+
+			deps.Env.Logger.Infof("Successfully removed %d node operators on chain %d", len(resultNops), input.ChainSelector)
+		}
+
+		return RemoveNopsOutput{
+			Nops:      resultNops,
+			Operation: operation,
+		}, nil
+	},
+)
+
+// resolveNOPIDs returns the contract IDs of the node operators with the given names, in input order.
+// Duplicate names are only resolved once. It fails if any of the names is not registered in the contract.
+func resolveNOPIDs(capReg *capabilities_registry_v2.CapabilitiesRegistry, names []string) ([]uint32, error) {
+	contractNOPs, err := pkg.GetNodeOperatorsWithIDs(nil, capReg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch node operators from contract: %w", err)
+	}
+	idsByName := pkg.NodeOperatorIDsByName(contractNOPs)
+
+	var ids []uint32
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		id, exists := idsByName[name]
+		if !exists {
+			return nil, fmt.Errorf("node operator `%s` not found in contract", name)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,61 @@
+package contracts_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/operations/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+)
+
+// landedTimeoutStrategy mines the first call, but reports a timeout as if the confirmation was lost.
+type landedTimeoutStrategy struct {
+	strategies.TransactionStrategy
+	calls int
+}
+
+func (s *landedTimeoutStrategy) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	s.calls++
+	op, tx, err := s.TransactionStrategy.Apply(callFn)
+	if err == nil && s.calls == 1 {
+		return op, nil, errors.New("request timed out")
+	}
+	return op, tx, err
+}
+
+func (s *landedTimeoutStrategy) BuildProposal(ops []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
+	return s.TransactionStrategy.BuildProposal(ops)
+}
+
+func TestRemoveNops_RetryAfterLandedCall(t *testing.T) {
+	f := setupRegistry(t, chainsel.TEST_90000001.Selector)
+	direct, err := strategies.CreateStrategy(f.env, f.selector, nil, nil, f.registry.Address(), "")
+	require.NoError(t, err)
+	registerNops(t, f, direct)
+
+	// The removal landed although the first attempt failed, so sending it again would revert
+	landed := &landedTimeoutStrategy{TransactionStrategy: direct}
+	retrying := strategies.NewRetryingTransaction(landed, strategies.RetryPolicy{MaxAttempts: 3})
+	report, err := operations.ExecuteOperation(f.env.OperationsBundle, contracts.RemoveNops, contracts.RemoveNopsDeps{
+		Env:      &f.env,
+		Strategy: retrying,
+	}, contracts.RemoveNopsInput{
+		Address:       f.address,
+		ChainSelector: f.selector,
+		NopNames:      []string{testNops[0].Name},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, landed.calls)
+	assert.Nil(t, report.Output.Operation)
+	requireNopCount(t, f, 1)
+}
@@ -0,0 +1,176 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+type RotateNodeKeysDeps struct {
+	Env                  *cldf.Environment
+	Strategy             strategies.TransactionStrategy
+	CapabilitiesRegistry *capabilities_registry_v2.CapabilitiesRegistry
+}
+
+type RotateNodeKeysInput struct {
+	ChainSelector uint64
+	P2PID         string
+	// Signer is the new hex encoded signer, if omitted the signer is left unchanged.
+	Signer string
+	// EncryptionPublicKey is the new hex encoded encryption public key, if omitted the key is left unchanged.
+	EncryptionPublicKey string
+	MCMSConfig          *contracts.MCMSConfig
+}
+
+func (i *RotateNodeKeysInput) Validate() error {
+	if i.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+	if _, err := p2pkey.MakePeerID(i.P2PID); err != nil {
+		return fmt.Errorf("invalid p2p id %s: %w", i.P2PID, err)
+	}
+	if i.Signer == "" && i.EncryptionPublicKey == "" {
+		return errors.New("must specify a new signer or encryption public key")
+	}
+	if i.Signer != "" {
+		if k, err := pkg.HexStringTo32Bytes(i.Signer); err != nil {
+			return fmt.Errorf("invalid signer: %w", err)
+		} else if k == ([32]byte{}) {
+			return errors.New("signer cannot be zero")
+		}
+	}
+	if i.EncryptionPublicKey != "" {
+		if k, err := pkg.HexStringTo32Bytes(i.EncryptionPublicKey); err != nil {
+			return fmt.Errorf("invalid encryption public key: %w", err)
+		} else if k == ([32]byte{}) {
+			return errors.New("encryption public key cannot be zero")
+		}
+	}
+
+	return nil
+}
+
+// NodeKeyRotation is the change of keys of a single node.
+type NodeKeyRotation struct {
+	P2PID                  string   `json:"p2pID"`
+	OldSigner              [32]byte `json:"oldSigner"`
+	NewSigner              [32]byte `json:"newSigner"`
+	OldEncryptionPublicKey [32]byte `json:"oldEncryptionPublicKey"`
+	NewEncryptionPublicKey [32]byte `json:"newEncryptionPublicKey"`
+}
+
+type RotateNodeKeysOutput struct {
+	// Rotation is the change that was applied, or proposed if MCMS is used.
+	Rotation NodeKeyRotation
+	// Node is the parsed NodeUpdated event, only set when MCMS is not used.
+	Node      *capabilities_registry_v2.CapabilitiesRegistryNodeUpdated
+	Operation *mcmstypes.BatchOperation
+}
+
+// RotateNodeKeys is an operation that updates the signer and/or encryption public key of an existing node in the
+// V2 Capabilities Registry contract. The node operator, CSA key and capabilities of the node are left unchanged.
+var RotateNodeKeys = operations.NewOperation[RotateNodeKeysInput, RotateNodeKeysOutput, RotateNodeKeysDeps](
+	"rotate-node-keys-op",
+	semver.MustParse("1.0.0"),
+	"Rotate Node keys in Capabilities Registry",
+	func(b operations.Bundle, deps RotateNodeKeysDeps, input RotateNodeKeysInput) (RotateNodeKeysOutput, error) {
+		if err := input.Validate(); err != nil {
+			return RotateNodeKeysOutput{}, err
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return RotateNodeKeysOutput{}, cldf.ErrChainNotFound
+		}
+
+		p2pID, err := p2pkey.MakePeerID(input.P2PID)
+		if err != nil {
+			return RotateNodeKeysOutput{}, fmt.Errorf("invalid p2p id %s: %w", input.P2PID, err)
+		}
+
+		node, err := deps.CapabilitiesRegistry.GetNode(&bind.CallOpts{}, p2pID)
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return RotateNodeKeysOutput{}, fmt.Errorf("failed to call GetNode: %w", err)
+		}
+		// The contract returns an empty node for unknown P2P IDs
+		if node.P2pId != p2pID {
+			return RotateNodeKeysOutput{}, fmt.Errorf("node %s not found in contract", input.P2PID)
+		}
+
+		rotation := NodeKeyRotation{
+			P2PID:                  input.P2PID,
+			OldSigner:              node.Signer,
+			NewSigner:              node.Signer,
+			OldEncryptionPublicKey: node.EncryptionPublicKey,
+			NewEncryptionPublicKey: node.EncryptionPublicKey,
+		}
+		if input.Signer != "" {
+			rotation.NewSigner, _ = pkg.HexStringTo32Bytes(input.Signer) // validated above
+		}
+		if input.EncryptionPublicKey != "" {
+			rotation.NewEncryptionPublicKey, _ = pkg.HexStringTo32Bytes(input.EncryptionPublicKey) // validated above
+		}
+		if rotation.OldSigner == rotation.NewSigner && rotation.OldEncryptionPublicKey == rotation.NewEncryptionPublicKey {
+			deps.Env.Logger.Infof("Node %s already has the given keys, nothing to do", input.P2PID)
+			return RotateNodeKeysOutput{Rotation: rotation}, nil
+		}
+
+		params := capabilities_registry_v2.CapabilitiesRegistryNodeParams{
+			NodeOperatorId:      node.NodeOperatorId,
+			Signer:              rotation.NewSigner,
+			P2pId:               node.P2pId,
+			EncryptionPublicKey: rotation.NewEncryptionPublicKey,
+			CsaKey:              node.CsaKey,
+			CapabilityIds:       node.CapabilityIds,
+		}
+
+		operation, tx, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return deps.CapabilitiesRegistry.UpdateNodes(opts, []capabilities_registry_v2.CapabilitiesRegistryNodeParams{params})
+		})
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return RotateNodeKeysOutput{}, fmt.Errorf("failed to execute UpdateNodes: %w", err)
+		}
+
+		var resultNode *capabilities_registry_v2.CapabilitiesRegistryNodeUpdated
+		if operation != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for RotateNodeKeys %s on chain %d", input.P2PID, input.ChainSelector)
+		} else {
+			receipt, err := bind.WaitMined(b.GetContext(), chain.Client, tx)
+			if err != nil {
+				return RotateNodeKeysOutput{}, fmt.Errorf("failed to mine UpdateNodes transaction %s: %w", tx.Hash().String(), err)
+			}
+
+			events, err := pkg.ParseRegistryLogs(deps.CapabilitiesRegistry, receipt.Logs)
+			if err != nil {
+				return RotateNodeKeysOutput{}, fmt.Errorf("failed to parse logs of transaction %s: %w", tx.Hash().String(), err)
+			}
+			if len(events.NodesUpdated) != 1 {
+				return RotateNodeKeysOutput{}, fmt.Errorf("expected 1 NodeUpdated event, got %d", len(events.NodesUpdated))
+			}
+			resultNode = events.NodesUpdated[0]
+
+			deps.Env.Logger.Infof("Successfully rotated keys of node %s on chain %d", input.P2PID, input.ChainSelector)
+		}
+
+		return RotateNodeKeysOutput{
+			Rotation:  rotation,
+			Node:      resultNode,
+			Operation: operation,
+		}, nil
+	},
+)
@@ -0,0 +1,135 @@
+package contracts_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/operations/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+func TestRotateNodeKeys(t *testing.T) {
+	// the keys of the first node registered by addTestNodes
+	oldSigner := [32]byte{0: 1, 31: 1}
+	oldEncryptionPublicKey := [32]byte{0: 3, 31: 1}
+	newSigner := [32]byte{0: 5, 31: 1}
+	newEncryptionPublicKey := [32]byte{0: 6, 31: 1}
+
+	tests := []struct {
+		name                    string
+		p2pID                   string
+		signer                  string
+		encryptionPublicKey     string
+		wantErr                 string
+		wantSigner              [32]byte
+		wantEncryptionPublicKey [32]byte
+		wantUpdated             bool
+	}{
+		{
+			name:                    "rotates the signer",
+			signer:                  hex.EncodeToString(newSigner[:]),
+			wantSigner:              newSigner,
+			wantEncryptionPublicKey: oldEncryptionPublicKey,
+			wantUpdated:             true,
+		},
+		{
+			name:                    "rotates the encryption public key",
+			encryptionPublicKey:     "0x" + hex.EncodeToString(newEncryptionPublicKey[:]),
+			wantSigner:              oldSigner,
+			wantEncryptionPublicKey: newEncryptionPublicKey,
+			wantUpdated:             true,
+		},
+		{
+			name:                    "rotates both keys",
+			signer:                  hex.EncodeToString(newSigner[:]),
+			encryptionPublicKey:     hex.EncodeToString(newEncryptionPublicKey[:]),
+			wantSigner:              newSigner,
+			wantEncryptionPublicKey: newEncryptionPublicKey,
+			wantUpdated:             true,
+		},
+		{
+			name:                    "unchanged keys",
+			signer:                  hex.EncodeToString(oldSigner[:]),
+			wantSigner:              oldSigner,
+			wantEncryptionPublicKey: oldEncryptionPublicKey,
+		},
+		{
+			name:    "unknown node",
+			p2pID:   p2pkey.PeerID([32]byte{0: 2, 31: 9}).String(),
+			signer:  hex.EncodeToString(newSigner[:]),
+			wantErr: "not found in contract",
+		},
+		{
+			name:    "invalid signer",
+			signer:  "0x1234",
+			wantErr: "invalid signer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupRegistry(t, chainsel.TEST_90000001.Selector)
+			p2pIDs := addTestNodes(t, f, 2)
+			strategy, err := strategies.CreateStrategy(f.env, f.selector, nil, nil, f.registry.Address(), "")
+			require.NoError(t, err)
+
+			p2pID := tt.p2pID
+			if p2pID == "" {
+				p2pID = p2pkey.PeerID(p2pIDs[0]).String()
+			}
+			f.env.OperationsBundle = operations.NewBundle(f.env.GetContext, f.env.Logger, operations.NewMemoryReporter())
+			report, err := operations.ExecuteOperation(f.env.OperationsBundle, contracts.RotateNodeKeys, contracts.RotateNodeKeysDeps{
+				Env:                  &f.env,
+				Strategy:             strategy,
+				CapabilitiesRegistry: f.registry,
+			}, contracts.RotateNodeKeysInput{
+				ChainSelector:       f.selector,
+				P2PID:               p2pID,
+				Signer:              tt.signer,
+				EncryptionPublicKey: tt.encryptionPublicKey,
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, contracts.NodeKeyRotation{
+				P2PID:                  p2pID,
+				OldSigner:              oldSigner,
+				NewSigner:              tt.wantSigner,
+				OldEncryptionPublicKey: oldEncryptionPublicKey,
+				NewEncryptionPublicKey: tt.wantEncryptionPublicKey,
+			}, report.Output.Rotation)
+			assert.Nil(t, report.Output.Operation)
+			if tt.wantUpdated {
+				require.NotNil(t, report.Output.Node)
+				assert.Equal(t, p2pIDs[0], report.Output.Node.P2pId)
+				assert.Equal(t, tt.wantSigner, report.Output.Node.Signer)
+			} else {
+				assert.Nil(t, report.Output.Node)
+			}
+
+			// the keys are rotated, the rest of the node is left unchanged
+			node, err := f.registry.GetNode(nil, p2pIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSigner, node.Signer)
+			assert.Equal(t, tt.wantEncryptionPublicKey, node.EncryptionPublicKey)
+			assert.Equal(t, uint32(1), node.NodeOperatorId)
+			assert.Equal(t, [32]byte{0: 4, 31: 1}, node.CsaKey)
+			assert.Equal(t, []string{testCapabilityID}, node.CapabilityIds)
+
+			// the other nodes are not touched
+			other, err := f.registry.GetNode(nil, p2pIDs[1])
+			require.NoError(t, err)
+			assert.Equal(t, [32]byte{0: 1, 31: 2}, other.Signer)
+			assert.Equal(t, [32]byte{0: 3, 31: 2}, other.EncryptionPublicKey)
+		})
+	}
+}
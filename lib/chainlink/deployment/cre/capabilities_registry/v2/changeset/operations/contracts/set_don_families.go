@@ -82,7 +82,7 @@ var SetDONFamilies = operations.NewOperation[SetDONFamiliesInput, SetDONFamilies
 			return SetDONFamiliesOutput{}, fmt.Errorf("failed to execute SetDONFamilies: %w", err)
 		}
 
-		if input.MCMSConfig != nil {
+		if operation != nil {
 			deps.Env.Logger.Infof("Created MCMS proposal for SetDONFamilies '%s' on chain %d", input.DonName, input.RegistryChainSel)
 		} else {
 			deps.Env.Logger.Infof("Successfully set DON families '%s' on chain %d", input.DonName, input.RegistryChainSel)
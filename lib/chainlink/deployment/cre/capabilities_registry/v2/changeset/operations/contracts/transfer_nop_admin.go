@@ -0,0 +1,166 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+type TransferNopAdminDeps struct {
+	Env      *cldf.Environment
+	Strategy strategies.TransactionStrategy
+}
+
+type TransferNopAdminInput struct {
+	Address       string
+	ChainSelector uint64
+	// NopName is the name of the node operator in the registry.
+	NopName    string
+	NewAdmin   common.Address
+	MCMSConfig *contracts.MCMSConfig
+}
+
+func (i *TransferNopAdminInput) Validate() error {
+	if i.Address == "" {
+		return errors.New("address is not set")
+	}
+	if i.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+	if i.NopName == "" {
+		return errors.New("node operator name cannot be empty")
+	}
+	if i.NewAdmin == (common.Address{}) {
+		return errors.New("new admin cannot be the zero address")
+	}
+
+	return nil
+}
+
+type TransferNopAdminOutput struct {
+	// Update is the change that was applied, or proposed if MCMS is used.
+	Update NopDiff
+	// Nop is the parsed NodeOperatorUpdated event, only set when MCMS is not used.
+	Nop       *capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated
+	Operation *mcmstypes.BatchOperation
+}
+
+// TransferNopAdmin is an operation that changes the admin of an existing node operator in the V2 Capabilities Registry contract.
+// The new admin cannot be the admin of another node operator.
+var TransferNopAdmin = operations.NewOperation[TransferNopAdminInput, TransferNopAdminOutput, TransferNopAdminDeps](
+	"transfer-nop-admin-op",
+	semver.MustParse("1.0.0"),
+	"Transfer Node Operator admin in Capabilities Registry",
+	func(b operations.Bundle, deps TransferNopAdminDeps, input TransferNopAdminInput) (TransferNopAdminOutput, error) {
+		if err := input.Validate(); err != nil {
+			return TransferNopAdminOutput{}, err
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return TransferNopAdminOutput{}, fmt.Errorf("chain not found for selector %d", input.ChainSelector)
+		}
+
+		capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(
+			common.HexToAddress(input.Address),
+			chain.Client,
+		)
+		if err != nil {
+			return TransferNopAdminOutput{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+		}
+
+		contractNOPs, err := pkg.GetNodeOperatorsWithIDs(nil, capReg)
+		if err != nil {
+			return TransferNopAdminOutput{}, fmt.Errorf("failed to fetch node operators from contract: %w", err)
+		}
+
+		update, err := diffNopAdmin(contractNOPs, input.NopName, input.NewAdmin)
+		if err != nil {
+			return TransferNopAdminOutput{}, err
+		}
+		if update.OldAdmin == update.NewAdmin {
+			deps.Env.Logger.Infof("Node operator `%s` already has admin %s, nothing to do", input.NopName, input.NewAdmin)
+			return TransferNopAdminOutput{Update: update}, nil
+		}
+
+		operation, tx, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return capReg.UpdateNodeOperators(opts, []uint32{update.NodeOperatorID}, []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+				{Admin: update.NewAdmin, Name: update.NewName},
+			})
+		})
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return TransferNopAdminOutput{}, fmt.Errorf("failed to execute UpdateNodeOperators: %w", err)
+		}
+
+		var resultNop *capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated
+		if operation != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for TransferNopAdmin `%s` on chain %d", input.NopName, input.ChainSelector)
+		} else {
+			receipt, err := bind.WaitMined(b.GetContext(), chain.Client, tx)
+			if err != nil {
+				return TransferNopAdminOutput{}, fmt.Errorf("failed to mine UpdateNodeOperators transaction %s: %w", tx.Hash().String(), err)
+			}
+
+			events, err := pkg.ParseRegistryLogs(capReg, receipt.Logs)
+			if err != nil {
+				return TransferNopAdminOutput{}, fmt.Errorf("failed to parse logs of transaction %s: %w", tx.Hash().String(), err)
+			}
+			if len(events.NodeOperatorsUpdated) != 1 {
+				return TransferNopAdminOutput{}, fmt.Errorf("expected 1 NodeOperatorUpdated event, got %d", len(events.NodeOperatorsUpdated))
+			}
+			resultNop = events.NodeOperatorsUpdated[0]
+
+			deps.Env.Logger.Infof("Successfully transferred admin of node operator `%s` from %s to %s on chain %d",
+				input.NopName, update.OldAdmin, update.NewAdmin, input.ChainSelector)
+		}
+
+		return TransferNopAdminOutput{
+			Update:    update,
+			Nop:       resultNop,
+			Operation: operation,
+		}, nil
+	},
+)
+
+// diffNopAdmin computes the change of admin of the node operator with the given name, making sure the new admin
+// does not already administer another node operator.
+func diffNopAdmin(
+	contractNOPs []pkg.NodeOperator,
+	name string,
+	newAdmin common.Address,
+) (NopDiff, error) {
+	index := slices.IndexFunc(contractNOPs, func(nop pkg.NodeOperator) bool { return nop.Name == name })
+	if index < 0 {
+		return NopDiff{}, fmt.Errorf("node operator `%s` not found in contract", name)
+	}
+
+	for _, nop := range contractNOPs {
+		if nop.Name != name && nop.Admin == newAdmin {
+			return NopDiff{}, fmt.Errorf("cannot transfer admin of node operator `%s` to %s: already admin of node operator `%s`", name, newAdmin, nop.Name)
+		}
+	}
+
+	current := contractNOPs[index]
+	return NopDiff{
+		NodeOperatorID: current.ID,
+		OldName:        current.Name,
+		NewName:        current.Name,
+		OldAdmin:       current.Admin,
+		NewAdmin:       newAdmin,
+	}, nil
+}
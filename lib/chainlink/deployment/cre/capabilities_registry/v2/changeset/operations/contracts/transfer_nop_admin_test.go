@@ -0,0 +1,113 @@
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/operations/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+)
+
+func TestTransferNopAdmin(t *testing.T) {
+	newAdmin := common.HexToAddress("0x03")
+
+	tests := []struct {
+		name       string
+		nopName    string
+		newAdmin   common.Address
+		wantErr    string
+		wantUpdate contracts.NopDiff
+		wantAdmins []common.Address
+	}{
+		{
+			name:     "unchanged admin",
+			nopName:  "nop-1",
+			newAdmin: testNops[0].Admin,
+			wantUpdate: contracts.NopDiff{
+				NodeOperatorID: 1,
+				OldName:        "nop-1",
+				NewName:        "nop-1",
+				OldAdmin:       testNops[0].Admin,
+				NewAdmin:       testNops[0].Admin,
+			},
+			wantAdmins: []common.Address{testNops[0].Admin, testNops[1].Admin},
+		},
+		{
+			name:     "changed admin",
+			nopName:  "nop-1",
+			newAdmin: newAdmin,
+			wantUpdate: contracts.NopDiff{
+				NodeOperatorID: 1,
+				OldName:        "nop-1",
+				NewName:        "nop-1",
+				OldAdmin:       testNops[0].Admin,
+				NewAdmin:       newAdmin,
+			},
+			wantAdmins: []common.Address{newAdmin, testNops[1].Admin},
+		},
+		{
+			name:     "admin of another NOP",
+			nopName:  "nop-1",
+			newAdmin: testNops[1].Admin,
+			wantErr:  "already admin of node operator `nop-2`",
+		},
+		{
+			name:     "unknown NOP",
+			nopName:  "nop-3",
+			newAdmin: newAdmin,
+			wantErr:  "node operator `nop-3` not found in contract",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupRegistry(t, chainsel.TEST_90000001.Selector)
+			strategy, err := strategies.CreateStrategy(f.env, f.selector, nil, nil, f.registry.Address(), "")
+			require.NoError(t, err)
+			registerNops(t, f, strategy)
+
+			f.env.OperationsBundle = operations.NewBundle(f.env.GetContext, f.env.Logger, operations.NewMemoryReporter())
+			report, err := operations.ExecuteOperation(f.env.OperationsBundle, contracts.TransferNopAdmin, contracts.TransferNopAdminDeps{
+				Env:      &f.env,
+				Strategy: strategy,
+			}, contracts.TransferNopAdminInput{
+				Address:       f.address,
+				ChainSelector: f.selector,
+				NopName:       tt.nopName,
+				NewAdmin:      tt.newAdmin,
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUpdate, report.Output.Update)
+			assert.Nil(t, report.Output.Operation)
+
+			// the event is only parsed when a transaction was sent
+			if tt.wantUpdate.OldAdmin == tt.wantUpdate.NewAdmin {
+				assert.Nil(t, report.Output.Nop)
+			} else {
+				require.NotNil(t, report.Output.Nop)
+				assert.Equal(t, tt.wantUpdate.NodeOperatorID, report.Output.Nop.NodeOperatorId)
+				assert.Equal(t, tt.wantUpdate.NewAdmin, report.Output.Nop.Admin)
+				assert.Equal(t, tt.wantUpdate.NewName, report.Output.Nop.Name)
+			}
+
+			nops, err := pkg.GetNodeOperatorsWithIDs(nil, f.registry)
+			require.NoError(t, err)
+			admins := make([]common.Address, 0, len(nops))
+			for _, nop := range nops {
+				admins = append(admins, nop.Admin)
+			}
+			assert.Equal(t, tt.wantAdmins, admins)
+		})
+	}
+}
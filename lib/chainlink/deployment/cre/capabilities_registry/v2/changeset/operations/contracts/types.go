@@ -3,13 +3,23 @@ package contracts
 const (
 	AddCapabilitiesDescription               = "add capabilities"
 	ConfigureCapabilitiesRegistryDescription = "configure capabilities registry"
+	ReconcileCapabilitiesRegistryDescription = "reconcile capabilities registry"
 	SetDONFamiliesDescription                = "set DON families"
 	RegisterNopsDescription                  = "register node operators"
+	RemoveNopsDescription                    = "remove node operators"
+	UpdateNopsDescription                    = "update node operators"
+	TransferNopAdminDescription              = "transfer node operator admin"
 	RegisterCapabilitiesDescription          = "register capabilities"
+	DeprecateCapabilitiesDescription         = "deprecate capabilities"
 	RegisterNodesDescription                 = "register nodes"
+	AddNodesDescription                      = "add nodes"
+	RemoveNodesDescription                   = "remove nodes"
 	RegisterDONsDescription                  = "register DONs"
 	UpdateDONDescription                     = "update DON"
+	UpdateDONCapabilityConfigsDescription    = "update DON capability configurations"
+	RemoveDONDescription                     = "remove DON"
 	UpdateNodeDescription                    = "update node"
 	UpdateNodesDescription                   = "update nodes"
+	RotateNodeKeysDescription                = "rotate node keys"
 	ConfigureForwarderDescription            = "configure forwarder"
 )
@@ -30,9 +30,19 @@ type UpdateDONInput struct {
 	ChainSelector uint64
 
 	// P2PIDs are the peer ids that compose the don. Optional, only provided if the DON composition is changing.
-	P2PIDs            []p2pkey.PeerID
+	// If omitted, the existing nodes fetched from the registry are used
+	P2PIDs []p2pkey.PeerID
+
+	// CapabilityConfigs are the capability configurations of the don.
+	// If omitted, the existing configurations fetched from the registry are used
 	CapabilityConfigs []CapabilityConfig
 
+	// EncodedCapabilityConfigs are already encoded capability configurations. Optional, takes precedence over CapabilityConfigs
+	EncodedCapabilityConfigs []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration
+
+	// Config is the encoded DON config. Optional, if omitted the existing value fetched from the registry is used
+	Config []byte
+
 	// DonName to update, this is required
 	DonName string
 
@@ -63,8 +73,11 @@ func (r *UpdateDONInput) Validate() error {
 }
 
 type UpdateDONOutput struct {
-	DonInfo   capabilities_registry_v2.CapabilitiesRegistryDONInfo
-	Operation *mcmstypes.BatchOperation
+	// DonInfo is the state of the DON after the update, only set when MCMS is not used.
+	DonInfo capabilities_registry_v2.CapabilitiesRegistryDONInfo
+	// ConfigCount is the config count the DON has once the update is applied.
+	ConfigCount uint32
+	Operation   *mcmstypes.BatchOperation
 }
 
 // CapabilityConfig is a struct that holds a capability and its configuration
@@ -83,6 +96,8 @@ type Capability struct {
 	Metadata map[string]any `json:"metadata" yaml:"metadata"`
 }
 
+// UpdateDON is an operation that updates a DON, looked up by name, in the V2 Capabilities Registry contract.
+// Every update bumps the config count of the DON.
 var UpdateDON = operations.NewOperation[UpdateDONInput, UpdateDONOutput, UpdateDONDeps](
 	"update-don-op",
 	semver.MustParse("1.0.0"),
@@ -113,15 +128,34 @@ var UpdateDON = operations.NewOperation[UpdateDONInput, UpdateDONOutput, UpdateD
 			return UpdateDONOutput{}, fmt.Errorf("refusing to update workflow don %d at config version %d because we cannot validate that all forwarder contracts are ready to accept the new configure version", don.Id, don.ConfigCount)
 		}
 
-		cfgs, err := computeConfigs(input.CapabilityConfigs, don.CapabilityConfigurations)
-		if err != nil {
-			return UpdateDONOutput{}, fmt.Errorf("failed to compute configs: %w", err)
+		cfgs := input.EncodedCapabilityConfigs
+		if len(cfgs) == 0 {
+			cfgs, err = computeConfigs(input.CapabilityConfigs, don.CapabilityConfigurations)
+			if err != nil {
+				return UpdateDONOutput{}, fmt.Errorf("failed to compute configs: %w", err)
+			}
+		}
+
+		donConfig := don.Config
+		if len(input.Config) > 0 {
+			donConfig = input.Config
+		}
+
+		nodes := pkg.PeerIDsToBytes(input.P2PIDs)
+		if len(nodes) == 0 {
+			nodes = don.NodeP2PIds
 		}
 
 		f := input.F
 		if f == 0 {
 			f = don.F
 		}
+		// the contract reverts with InvalidFaultTolerance otherwise, fail early with a readable error. A zero f, e.g. of a
+		// one-node DON, is left to the contract: it is only valid if the registry was deployed with canAddOneNodeDONs,
+		// which the registry does not expose
+		if int(f)+1 > len(nodes) {
+			return UpdateDONOutput{}, fmt.Errorf("invalid f value %d for DON '%s' with %d nodes: f must be lower than the number of nodes", f, input.DonName, len(nodes))
+		}
 		// this is implement as such to maintain backwards compatibility; the default (omitted) value of a bool is false
 		var isPublic bool
 		if input.IsPrivate {
@@ -135,31 +169,37 @@ var UpdateDON = operations.NewOperation[UpdateDONInput, UpdateDONOutput, UpdateD
 			name = input.NewDonName
 		}
 
+		expectedConfigCount := don.ConfigCount + 1
 		var resultDon capabilities_registry_v2.CapabilitiesRegistryDONInfo
 
 		// Execute the transaction using the strategy
-		operation, tx, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 			return registry.UpdateDONByName(opts, input.DonName, capabilities_registry_v2.CapabilitiesRegistryUpdateDONParams{
 				Name:                     name,
-				Nodes:                    pkg.PeerIDsToBytes(input.P2PIDs),
+				Nodes:                    nodes,
 				CapabilityConfigurations: cfgs,
 				IsPublic:                 isPublic,
 				F:                        f,
-				Config:                   don.Config,
+				Config:                   donConfig,
 			})
+		}, func() (bool, error) {
+			// A previous attempt landed if the config count of the DON was bumped
+			updated, err := registry.GetDON(nil, don.Id)
+			return updated.ConfigCount >= expectedConfigCount, err
 		})
 		if err != nil {
 			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
 			return UpdateDONOutput{}, fmt.Errorf("failed to execute UpdateDON: %w", err)
 		}
 
-		if input.MCMSConfig != nil {
-			deps.Env.Logger.Infof("Created MCMS proposal for UpdateDON '%s' on chain %d", input.DonName, input.ChainSelector)
+		if operation != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for UpdateDON '%s' on chain %d, config count will be bumped to %d", input.DonName, input.ChainSelector, expectedConfigCount)
 		} else {
 			deps.Env.Logger.Infof("Successfully updated DON '%s' on chain %d", input.DonName, input.ChainSelector)
 
-			ctx := b.GetContext()
-			if _, err = bind.WaitMined(ctx, chain.Client, tx); err != nil {
+			if tx == nil {
+				deps.Env.Logger.Infof("DON '%s' on chain %d was updated by a previous attempt", input.DonName, input.ChainSelector)
+			} else if _, err = bind.WaitMined(b.GetContext(), chain.Client, tx); err != nil {
 				return UpdateDONOutput{}, fmt.Errorf("failed to mine UpdateDON transaction %s: %w", tx.Hash().String(), err)
 			}
 
@@ -169,18 +209,28 @@ var UpdateDON = operations.NewOperation[UpdateDONInput, UpdateDONOutput, UpdateD
 				return UpdateDONOutput{}, fmt.Errorf("failed to call GetDONByName: %w", err)
 			}
 
+			if don.ConfigCount != expectedConfigCount {
+				return UpdateDONOutput{}, fmt.Errorf("unexpected config count for DON '%s' after update: expected %d, got %d", name, expectedConfigCount, don.ConfigCount)
+			}
+
 			// Get the updated DON info
 			resultDon = don
 		}
 
 		return UpdateDONOutput{
-			DonInfo:   resultDon,
-			Operation: operation,
+			DonInfo:     resultDon,
+			ConfigCount: expectedConfigCount,
+			Operation:   operation,
 		}, nil
 	},
 )
 
+// computeConfigs marshals the capability configurations, falling back to the existing ones when none are provided.
 func computeConfigs(capCfgs []CapabilityConfig, existingCapConfigs []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration) ([]capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration, error) {
+	if len(capCfgs) == 0 {
+		return existingCapConfigs, nil
+	}
+
 	var out []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration
 	for _, capCfg := range capCfgs {
 		cfg := capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration{}
@@ -0,0 +1,198 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+type UpdateDONCapabilityConfigsDeps struct {
+	Env                  *cldf.Environment
+	Strategy             strategies.TransactionStrategy
+	CapabilitiesRegistry *capabilities_registry_v2.CapabilitiesRegistry
+}
+
+type UpdateDONCapabilityConfigsInput struct {
+	ChainSelector uint64
+
+	// DonName to update, this is required
+	DonName string
+
+	// Configs maps capability IDs to their new configuration on the DON. Use pkg.NewCapabilityConfigFromProto
+	// to build them from typed proto messages. Capabilities not yet configured on the DON are added.
+	Configs map[string]pkg.CapabilityConfig
+
+	// Force allows updating DONs accepting workflows, see UpdateDONInput.Force.
+	Force bool
+
+	MCMSConfig *contracts.MCMSConfig
+}
+
+func (i *UpdateDONCapabilityConfigsInput) Validate() error {
+	if i.DonName == "" {
+		return errors.New("must specify DONName")
+	}
+	if len(i.Configs) == 0 {
+		return errors.New("must specify at least one capability configuration")
+	}
+	for id, cfg := range i.Configs {
+		if id == "" {
+			return errors.New("capability ID cannot be empty")
+		}
+		if cfg == nil {
+			return fmt.Errorf("config is required for capability %s", id)
+		}
+	}
+
+	return nil
+}
+
+type UpdateDONCapabilityConfigsOutput struct {
+	// DonInfo is the state of the DON after the update, only set when MCMS is not used.
+	DonInfo capabilities_registry_v2.CapabilitiesRegistryDONInfo
+	// ConfigCount is the config count the DON has once the update is applied.
+	ConfigCount uint32
+	Operation   *mcmstypes.BatchOperation
+}
+
+// UpdateDONCapabilityConfigs is an operation that updates the capability configurations of an existing DON,
+// looked up by name, in the V2 Capabilities Registry contract. The nodes, f, visibility and config of the DON,
+// as well as the configurations of the other capabilities, are left unchanged.
+var UpdateDONCapabilityConfigs = operations.NewOperation[UpdateDONCapabilityConfigsInput, UpdateDONCapabilityConfigsOutput, UpdateDONCapabilityConfigsDeps](
+	"update-don-capability-configs-op",
+	semver.MustParse("1.0.0"),
+	"Update DON capability configurations in Capabilities Registry",
+	func(b operations.Bundle, deps UpdateDONCapabilityConfigsDeps, input UpdateDONCapabilityConfigsInput) (UpdateDONCapabilityConfigsOutput, error) {
+		if err := input.Validate(); err != nil {
+			return UpdateDONCapabilityConfigsOutput{}, err
+		}
+
+		registry := deps.CapabilitiesRegistry
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return UpdateDONCapabilityConfigsOutput{}, cldf.ErrChainNotFound
+		}
+
+		don, err := registry.GetDONByName(&bind.CallOpts{}, input.DonName)
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return UpdateDONCapabilityConfigsOutput{}, fmt.Errorf("failed to call GetDONByName: %w", err)
+		}
+
+		if don.AcceptsWorkflows && !input.Force {
+			return UpdateDONCapabilityConfigsOutput{}, fmt.Errorf("refusing to update workflow don %d at config version %d because we cannot validate that all forwarder contracts are ready to accept the new configure version", don.Id, don.ConfigCount)
+		}
+
+		registered, err := pkg.GetCapabilities(nil, registry)
+		if err != nil {
+			return UpdateDONCapabilityConfigsOutput{}, fmt.Errorf("failed to fetch capabilities from contract: %w", err)
+		}
+
+		cfgs, err := mergeCapabilityConfigs(don.CapabilityConfigurations, input.Configs, registered)
+		if err != nil {
+			return UpdateDONCapabilityConfigsOutput{}, err
+		}
+
+		expectedConfigCount := don.ConfigCount + 1
+		var resultDon capabilities_registry_v2.CapabilitiesRegistryDONInfo
+
+		operation, tx, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return registry.UpdateDONByName(opts, input.DonName, capabilities_registry_v2.CapabilitiesRegistryUpdateDONParams{
+				Name:                     don.Name,
+				Nodes:                    don.NodeP2PIds,
+				CapabilityConfigurations: cfgs,
+				IsPublic:                 don.IsPublic,
+				F:                        don.F,
+				Config:                   don.Config,
+			})
+		})
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return UpdateDONCapabilityConfigsOutput{}, fmt.Errorf("failed to execute UpdateDON: %w", err)
+		}
+
+		if operation != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for UpdateDONCapabilityConfigs '%s' on chain %d, config count will be bumped to %d", input.DonName, input.ChainSelector, expectedConfigCount)
+		} else {
+			if _, err = bind.WaitMined(b.GetContext(), chain.Client, tx); err != nil {
+				return UpdateDONCapabilityConfigsOutput{}, fmt.Errorf("failed to mine UpdateDON transaction %s: %w", tx.Hash().String(), err)
+			}
+
+			resultDon, err = registry.GetDONByName(&bind.CallOpts{}, input.DonName)
+			if err != nil {
+				err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+				return UpdateDONCapabilityConfigsOutput{}, fmt.Errorf("failed to call GetDONByName: %w", err)
+			}
+			if resultDon.ConfigCount != expectedConfigCount {
+				return UpdateDONCapabilityConfigsOutput{}, fmt.Errorf("unexpected config count for DON '%s' after update: expected %d, got %d", input.DonName, expectedConfigCount, resultDon.ConfigCount)
+			}
+
+			deps.Env.Logger.Infof("Successfully updated %d capability configurations of DON '%s' on chain %d", len(input.Configs), input.DonName, input.ChainSelector)
+		}
+
+		return UpdateDONCapabilityConfigsOutput{
+			DonInfo:     resultDon,
+			ConfigCount: expectedConfigCount,
+			Operation:   operation,
+		}, nil
+	},
+)
+
+// mergeCapabilityConfigs replaces the existing configurations of the given capabilities, keeping the order of the
+// existing ones, and appends the configurations of capabilities not yet configured on the DON.
+// Every given capability must be registered and not deprecated.
+func mergeCapabilityConfigs(
+	existing []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration,
+	updates map[string]pkg.CapabilityConfig,
+	registered []capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo,
+) ([]capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration, error) {
+	encoded := make(map[string][]byte, len(updates))
+	for _, id := range slices.Sorted(maps.Keys(updates)) {
+		idx := slices.IndexFunc(registered, func(c capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo) bool {
+			return c.CapabilityId == id
+		})
+		if idx == -1 {
+			return nil, fmt.Errorf("capability %s is not registered", id)
+		}
+		if registered[idx].IsDeprecated {
+			return nil, fmt.Errorf("capability %s is deprecated", id)
+		}
+
+		cfg, err := updates[id].MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal config of capability %s: %w", id, err)
+		}
+		encoded[id] = cfg
+	}
+
+	out := make([]capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration, 0, len(existing)+len(encoded))
+	for _, cfg := range existing {
+		if newCfg, ok := encoded[cfg.CapabilityId]; ok {
+			cfg.Config = newCfg
+			delete(encoded, cfg.CapabilityId)
+		}
+		out = append(out, cfg)
+	}
+	for _, id := range slices.Sorted(maps.Keys(encoded)) {
+		out = append(out, capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration{
+			CapabilityId: id,
+			Config:       encoded[id],
+		})
+	}
+
+	return out, nil
+}
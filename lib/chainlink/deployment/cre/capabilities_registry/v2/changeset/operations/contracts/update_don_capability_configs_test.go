@@ -0,0 +1,86 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+)
+
+func TestMergeCapabilityConfigs(t *testing.T) {
+	localOnly := pkg.CapabilityConfig{"localOnly": true}
+	localOnlyProto, err := localOnly.MarshalProto()
+	require.NoError(t, err)
+
+	registered := []capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo{
+		{CapabilityId: "cap-a@1.0.0"},
+		{CapabilityId: "cap-b@1.0.0"},
+		{CapabilityId: "cap-c@1.0.0"},
+		{CapabilityId: "cap-d@1.0.0"},
+		{CapabilityId: "cap-deprecated@1.0.0", IsDeprecated: true},
+	}
+	existing := []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration{
+		{CapabilityId: "cap-b@1.0.0", Config: []byte("config-b")},
+		{CapabilityId: "cap-a@1.0.0", Config: []byte("config-a")},
+	}
+
+	tests := []struct {
+		name    string
+		updates map[string]pkg.CapabilityConfig
+		want    []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration
+		wantErr string
+	}{
+		{
+			name:    "overrides an existing config in place",
+			updates: map[string]pkg.CapabilityConfig{"cap-a@1.0.0": localOnly},
+			want: []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration{
+				{CapabilityId: "cap-b@1.0.0", Config: []byte("config-b")},
+				{CapabilityId: "cap-a@1.0.0", Config: localOnlyProto},
+			},
+		},
+		{
+			name:    "keeps the configs of the other capabilities and appends new ones sorted",
+			updates: map[string]pkg.CapabilityConfig{"cap-d@1.0.0": localOnly, "cap-c@1.0.0": {}},
+			want: []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration{
+				{CapabilityId: "cap-b@1.0.0", Config: []byte("config-b")},
+				{CapabilityId: "cap-a@1.0.0", Config: []byte("config-a")},
+				{CapabilityId: "cap-c@1.0.0", Config: []byte{}},
+				{CapabilityId: "cap-d@1.0.0", Config: localOnlyProto},
+			},
+		},
+		{
+			name:    "unknown capability",
+			updates: map[string]pkg.CapabilityConfig{"cap-a@1.0.0": localOnly, "cap-unknown@1.0.0": localOnly},
+			wantErr: "capability cap-unknown@1.0.0 is not registered",
+		},
+		{
+			name:    "deprecated capability",
+			updates: map[string]pkg.CapabilityConfig{"cap-deprecated@1.0.0": localOnly},
+			wantErr: "capability cap-deprecated@1.0.0 is deprecated",
+		},
+		{
+			name:    "config not matching the proto",
+			updates: map[string]pkg.CapabilityConfig{"cap-a@1.0.0": {"unknownField": true}},
+			wantErr: "failed to marshal config of capability cap-a@1.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeCapabilityConfigs(existing, tt.updates, registered)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// the existing configs are not modified
+			assert.Equal(t, []byte("config-a"), existing[1].Config)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package contracts_test
+
+import (
+	"testing"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/operations/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+)
+
+func TestUpdateDON_OneNodeDON(t *testing.T) {
+	f := deployRegistry(t, setupRegistry(t, chainsel.TEST_90000001.Selector), capabilities_registry_v2.CapabilitiesRegistryConstructorParams{
+		CanAddOneNodeDONs: true,
+	})
+	addTestDON(t, f, "one-node-don", addTestNodes(t, f, 1), 0)
+
+	tests := []struct {
+		name      string
+		f         uint8
+		wantErr   string
+		wantCount uint32
+	}{
+		{
+			name:      "keeps zero f",
+			wantCount: 2,
+		},
+		{
+			name:    "f not lower than the number of nodes",
+			f:       1,
+			wantErr: "invalid f value 1 for DON 'one-node-don' with 1 nodes: f must be lower than the number of nodes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := strategies.CreateStrategy(f.env, f.selector, nil, nil, f.registry.Address(), "")
+			require.NoError(t, err)
+
+			f.env.OperationsBundle = operations.NewBundle(f.env.GetContext, f.env.Logger, operations.NewMemoryReporter())
+			report, err := operations.ExecuteOperation(f.env.OperationsBundle, contracts.UpdateDON, contracts.UpdateDONDeps{
+				Env:                  &f.env,
+				Strategy:             strategy,
+				CapabilitiesRegistry: f.registry,
+			}, contracts.UpdateDONInput{
+				ChainSelector: f.selector,
+				DonName:       "one-node-don",
+				Config:        []byte("new config"),
+				F:             tt.f,
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			don := report.Output.DonInfo
+			assert.Equal(t, tt.wantCount, don.ConfigCount)
+			assert.Equal(t, uint8(0), don.F)
+			assert.Len(t, don.NodeP2PIds, 1)
+			assert.Equal(t, []byte("new config"), don.Config)
+		})
+	}
+}
@@ -67,16 +67,30 @@ var UpdateNodes = operations.NewOperation[UpdateNodesInput, UpdateNodesOutput, U
 		var resultNodes []*capabilities_registry_v2.CapabilitiesRegistryNodeUpdated
 
 		// Execute the transaction using the strategy
-		operation, tx, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 			return deps.CapabilitiesRegistry.UpdateNodes(opts, nodeParams)
+		}, func() (bool, error) {
+			// A previous attempt landed if the nodes have their new configuration
+			for _, params := range nodeParams {
+				node, err := deps.CapabilitiesRegistry.GetNode(nil, params.P2pId)
+				if err != nil {
+					return false, err
+				}
+				if !nodeMatchesParams(node, params) {
+					return false, nil
+				}
+			}
+			return true, nil
 		})
 		if err != nil {
 			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
 			return UpdateNodesOutput{}, fmt.Errorf("failed to execute UpdateNodes: %w", err)
 		}
 
-		if input.MCMSConfig != nil {
+		if operation != nil {
 			deps.Env.Logger.Infof("Created MCMS proposal for UpdateNodes on chain %d", input.ChainSelector)
+		} else if tx == nil {
+			deps.Env.Logger.Infof("Nodes on chain %d were updated by a previous attempt", input.ChainSelector)
 		} else {
 			deps.Env.Logger.Infof("Successfully updated %d nodes on chain %d", len(resultNodes), input.ChainSelector)
 
@@ -191,3 +205,25 @@ func makeNodeParams(
 
 	return out, nil
 }
+
+// nodeMatchesParams reports whether the node in the registry has the configuration of the params.
+func nodeMatchesParams(node capabilities_registry_v2.INodeInfoProviderNodeInfo, params capabilities_registry_v2.CapabilitiesRegistryNodeParams) bool {
+	if node.NodeOperatorId != params.NodeOperatorId || node.Signer != params.Signer ||
+		node.EncryptionPublicKey != params.EncryptionPublicKey || node.CsaKey != params.CsaKey {
+		return false
+	}
+
+	// the params may list a capability twice, since the new capabilities are merged with the existing ones
+	want := make(map[string]struct{}, len(params.CapabilityIds))
+	for _, id := range params.CapabilityIds {
+		want[id] = struct{}{}
+	}
+	got := make(map[string]struct{}, len(node.CapabilityIds))
+	for _, id := range node.CapabilityIds {
+		if _, ok := want[id]; !ok {
+			return false
+		}
+		got[id] = struct{}{}
+	}
+	return len(got) == len(want)
+}
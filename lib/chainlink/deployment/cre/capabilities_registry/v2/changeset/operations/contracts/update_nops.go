@@ -0,0 +1,238 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+type UpdateNopsDeps struct {
+	Env      *cldf.Environment
+	Strategy strategies.TransactionStrategy
+}
+
+// NopUpdate describes the desired state of an existing node operator.
+type NopUpdate struct {
+	// Name is the current name of the node operator in the registry, this is required.
+	Name string `json:"name" yaml:"name"`
+	// NewName is optional, if omitted the name is left unchanged.
+	NewName string `json:"newName,omitempty" yaml:"newName,omitempty"`
+	// Admin is optional, if omitted the admin is left unchanged.
+	Admin common.Address `json:"admin,omitempty" yaml:"admin,omitempty"`
+}
+
+type UpdateNopsInput struct {
+	Address       string
+	ChainSelector uint64
+	Nops          []NopUpdate
+	MCMSConfig    *contracts.MCMSConfig
+}
+
+func (i *UpdateNopsInput) Validate() error {
+	if i.Address == "" {
+		return errors.New("address is not set")
+	}
+	if i.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+
+	seen := make(map[string]struct{}, len(i.Nops))
+	for _, nop := range i.Nops {
+		if nop.Name == "" {
+			return errors.New("node operator name cannot be empty")
+		}
+		if _, exists := seen[nop.Name]; exists {
+			return fmt.Errorf("duplicate update for node operator `%s`", nop.Name)
+		}
+		seen[nop.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// NopDiff is a change to a single node operator, computed against the onchain state.
+type NopDiff struct {
+	NodeOperatorID uint32         `json:"nodeOperatorID"`
+	OldName        string         `json:"oldName"`
+	NewName        string         `json:"newName"`
+	OldAdmin       common.Address `json:"oldAdmin"`
+	NewAdmin       common.Address `json:"newAdmin"`
+}
+
+type UpdateNopsOutput struct {
+	// Updates are the changes that were applied, or proposed if MCMS is used.
+	Updates []NopDiff
+	// Nops are the parsed NodeOperatorUpdated events, only set when MCMS is not used.
+	Nops      []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated
+	Operation *mcmstypes.BatchOperation
+}
+
+// UpdateNops is an operation that updates node operators in the V2 Capabilities Registry contract.
+// Only node operators whose onchain state differs from the desired state are updated.
+var UpdateNops = operations.NewOperation[UpdateNopsInput, UpdateNopsOutput, UpdateNopsDeps](
+	"update-nops-op",
+	semver.MustParse("1.0.0"),
+	"Update Node Operators in Capabilities Registry",
+	func(b operations.Bundle, deps UpdateNopsDeps, input UpdateNopsInput) (UpdateNopsOutput, error) {
+		if err := input.Validate(); err != nil {
+			return UpdateNopsOutput{}, err
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return UpdateNopsOutput{}, fmt.Errorf("chain not found for selector %d", input.ChainSelector)
+		}
+
+		capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(
+			common.HexToAddress(input.Address),
+			chain.Client,
+		)
+		if err != nil {
+			return UpdateNopsOutput{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+		}
+
+		contractNOPs, err := pkg.GetNodeOperatorsWithIDs(nil, capReg)
+		if err != nil {
+			return UpdateNopsOutput{}, fmt.Errorf("failed to fetch node operators from contract: %w", err)
+		}
+
+		diffs, err := DiffNops(contractNOPs, input.Nops)
+		if err != nil {
+			return UpdateNopsOutput{}, err
+		}
+		if len(diffs) == 0 {
+			deps.Env.Logger.Info("All node operators are up to date in the contract, nothing to do")
+			return UpdateNopsOutput{
+				Updates: []NopDiff{},
+				Nops:    []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated{},
+			}, nil
+		}
+
+		ids := make([]uint32, 0, len(diffs))
+		params := make([]capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams, 0, len(diffs))
+		for _, d := range diffs {
+			ids = append(ids, d.NodeOperatorID)
+			params = append(params, capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+				Admin: d.NewAdmin,
+				Name:  d.NewName,
+			})
+		}
+
+		var resultNops []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated
+
+		// Execute the transaction using the strategy
+		operation, tx, err := strategies.ApplyWithCheck(deps.Strategy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return capReg.UpdateNodeOperators(opts, ids, params)
+		}, func() (bool, error) {
+			// A previous attempt landed if the node operators have their new name and admin
+			for i, id := range ids {
+				nop, err := capReg.GetNodeOperator(nil, id)
+				if err != nil {
+					return false, err
+				}
+				if nop.Name != params[i].Name || nop.Admin != params[i].Admin {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+		if err != nil {
+			err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+			return UpdateNopsOutput{}, fmt.Errorf("failed to execute UpdateNodeOperators: %w", err)
+		}
+
+		if operation != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for UpdateNops on chain %d", input.ChainSelector)
+		} else if tx == nil {
+			deps.Env.Logger.Infof("Node operators on chain %d were updated by a previous attempt", input.ChainSelector)
+		} else {
+			ctx := b.GetContext()
+			receipt, err := bind.WaitMined(ctx, chain.Client, tx)
+			if err != nil {
+				return UpdateNopsOutput{}, fmt.Errorf("failed to mine UpdateNodeOperators transaction %s: %w", tx.Hash().String(), err)
+			}
+
+			// Parse the logs to get the updated node operators
+			resultNops = make([]*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated, 0, len(receipt.Logs))
+			for i, log := range receipt.Logs {
+				if log == nil {
+					continue
+				}
+
+				o, err := capReg.ParseNodeOperatorUpdated(*log)
+				if err != nil {
+					return UpdateNopsOutput{}, fmt.Errorf("failed to parse log %d for operator updated: %w", i, err)
+				}
+				resultNops = append(resultNops, o)
+			}
+
+			deps.Env.Logger.Infof("Successfully updated %d node operators on chain %d", len(resultNops), input.ChainSelector)
+		}
+
+		return UpdateNopsOutput{
+			Updates:   diffs,
+			Nops:      resultNops,
+			Operation: operation,
+		}, nil
+	},
+)
+
+// DiffNops computes the updates needed to bring the node operators in the contract to the desired state.
+// Desired node operators that already match the contract are omitted from the result. Node operators are neither
+// added nor removed, see RegisterNops and RemoveNops: unknown node operators are rejected, and the node operators of
+// the contract missing from desired are left unchanged.
+func DiffNops(
+	contractNOPs []pkg.NodeOperator,
+	desired []NopUpdate,
+) ([]NopDiff, error) {
+	byName := make(map[string]pkg.NodeOperator, len(contractNOPs))
+	for _, nop := range contractNOPs {
+		byName[nop.Name] = nop
+	}
+
+	var diffs []NopDiff
+	for _, nop := range desired {
+		current, exists := byName[nop.Name]
+		if !exists {
+			return nil, fmt.Errorf("node operator `%s` not found in contract", nop.Name)
+		}
+		id := current.ID
+
+		d := NopDiff{
+			NodeOperatorID: id,
+			OldName:        current.Name,
+			NewName:        current.Name,
+			OldAdmin:       current.Admin,
+			NewAdmin:       current.Admin,
+		}
+		if nop.NewName != "" {
+			if other, taken := byName[nop.NewName]; taken && other.ID != id {
+				return nil, fmt.Errorf("cannot rename node operator `%s` to `%s`: name already used by node operator %d", nop.Name, nop.NewName, other.ID)
+			}
+			d.NewName = nop.NewName
+		}
+		if nop.Admin != (common.Address{}) {
+			d.NewAdmin = nop.Admin
+		}
+
+		if d.OldName == d.NewName && d.OldAdmin == d.NewAdmin {
+			continue
+		}
+		diffs = append(diffs, d)
+	}
+
+	return diffs, nil
+}
@@ -0,0 +1,90 @@
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/operations/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+)
+
+func TestDiffNops(t *testing.T) {
+	admin1 := common.HexToAddress("0x01")
+	admin2 := common.HexToAddress("0x02")
+	newAdmin := common.HexToAddress("0x03")
+	contractNOPs := []pkg.NodeOperator{
+		{ID: 1, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Admin: admin1, Name: "nop-1"}},
+		{ID: 2, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Admin: admin2, Name: "nop-2"}},
+	}
+
+	tests := []struct {
+		name    string
+		desired []contracts.NopUpdate
+		want    []contracts.NopDiff
+		wantErr string
+	}{
+		{
+			name:    "unchanged node operators are omitted",
+			desired: []contracts.NopUpdate{{Name: "nop-1"}, {Name: "nop-2", NewName: "nop-2", Admin: admin2}},
+		},
+		{
+			name:    "rename",
+			desired: []contracts.NopUpdate{{Name: "nop-1", NewName: "nop-1-renamed"}},
+			want: []contracts.NopDiff{
+				{NodeOperatorID: 1, OldName: "nop-1", NewName: "nop-1-renamed", OldAdmin: admin1, NewAdmin: admin1},
+			},
+		},
+		{
+			name:    "admin-only change",
+			desired: []contracts.NopUpdate{{Name: "nop-2", Admin: newAdmin}},
+			want: []contracts.NopDiff{
+				{NodeOperatorID: 2, OldName: "nop-2", NewName: "nop-2", OldAdmin: admin2, NewAdmin: newAdmin},
+			},
+		},
+		{
+			name: "rename and admin change of several node operators",
+			desired: []contracts.NopUpdate{
+				{Name: "nop-2", NewName: "nop-2-renamed", Admin: newAdmin},
+				{Name: "nop-1", NewName: "nop-1-renamed", Admin: admin1},
+			},
+			want: []contracts.NopDiff{
+				{NodeOperatorID: 2, OldName: "nop-2", NewName: "nop-2-renamed", OldAdmin: admin2, NewAdmin: newAdmin},
+				{NodeOperatorID: 1, OldName: "nop-1", NewName: "nop-1-renamed", OldAdmin: admin1, NewAdmin: admin1},
+			},
+		},
+		{
+			name:    "node operators missing from desired are not removed",
+			desired: []contracts.NopUpdate{{Name: "nop-1", Admin: newAdmin}},
+			want: []contracts.NopDiff{
+				{NodeOperatorID: 1, OldName: "nop-1", NewName: "nop-1", OldAdmin: admin1, NewAdmin: newAdmin},
+			},
+		},
+		{
+			name:    "unknown node operators are not added",
+			desired: []contracts.NopUpdate{{Name: "nop-1", Admin: newAdmin}, {Name: "nop-3", Admin: newAdmin}},
+			wantErr: "node operator `nop-3` not found in contract",
+		},
+		{
+			name:    "rename to the name of another node operator",
+			desired: []contracts.NopUpdate{{Name: "nop-1", NewName: "nop-2"}},
+			wantErr: "cannot rename node operator `nop-1` to `nop-2`: name already used by node operator 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := contracts.DiffNops(contractNOPs, tt.desired)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
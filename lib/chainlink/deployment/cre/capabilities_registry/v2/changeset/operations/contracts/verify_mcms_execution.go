@@ -0,0 +1,157 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+)
+
+type VerifyMCMSExecutionDeps struct {
+	Env *cldf.Environment
+}
+
+type VerifyMCMSExecutionInput struct {
+	Address       string
+	ChainSelector uint64
+	// Proposal is the executed proposal. It is used to check that the proposal targets the registry, and is optional.
+	Proposal *mcmslib.TimelockProposal
+	// ExecutionTxHashes are the hashes of the timelock execution transactions on the registry chain.
+	ExecutionTxHashes []string
+}
+
+func (i *VerifyMCMSExecutionInput) Validate() error {
+	if !common.IsHexAddress(i.Address) {
+		return fmt.Errorf("invalid address: %s", i.Address)
+	}
+	if i.ChainSelector == 0 {
+		return errors.New("chainSelector is not set")
+	}
+	if len(i.ExecutionTxHashes) == 0 {
+		return errors.New("must specify at least one execution transaction hash")
+	}
+	for _, h := range i.ExecutionTxHashes {
+		if len(common.FromHex(h)) != common.HashLength {
+			return fmt.Errorf("invalid transaction hash: %s", h)
+		}
+	}
+
+	return nil
+}
+
+// VerifyMCMSExecutionOutput has the same typed results as the direct execution path of the registry operations.
+type VerifyMCMSExecutionOutput struct {
+	// Nops are the node operators added, as returned by RegisterNops.
+	Nops []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorAdded
+	// Nodes are the nodes added, as returned by RegisterNodes.
+	Nodes []*capabilities_registry_v2.CapabilitiesRegistryNodeAdded
+	// Capabilities are the capabilities configured, as returned by RegisterCapabilities.
+	Capabilities []*capabilities_registry_v2.CapabilitiesRegistryCapabilityConfigured
+	// DONs are the DONs configured or whose families changed, read after the execution, as returned by RegisterDons and SetDONFamilies.
+	DONs []capabilities_registry_v2.CapabilitiesRegistryDONInfo
+	// Events are all the registry events emitted by the execution.
+	Events pkg.RegistryEvents
+}
+
+// VerifyMCMSExecution is a read-only operation that parses the events emitted by the V2 Capabilities Registry contract
+// once an MCMS proposal has been executed by the timelock, since they can't be parsed when the proposal is created.
+var VerifyMCMSExecution = operations.NewOperation[VerifyMCMSExecutionInput, VerifyMCMSExecutionOutput, VerifyMCMSExecutionDeps](
+	"verify-mcms-execution-op",
+	semver.MustParse("1.0.0"),
+	"Verify MCMS execution of Capabilities Registry operations",
+	func(b operations.Bundle, deps VerifyMCMSExecutionDeps, input VerifyMCMSExecutionInput) (VerifyMCMSExecutionOutput, error) {
+		if err := input.Validate(); err != nil {
+			return VerifyMCMSExecutionOutput{}, err
+		}
+
+		if input.Proposal != nil {
+			if err := checkProposalTargetsRegistry(input.Proposal, input.ChainSelector, input.Address); err != nil {
+				return VerifyMCMSExecutionOutput{}, err
+			}
+		}
+
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return VerifyMCMSExecutionOutput{}, fmt.Errorf("chain not found for selector %d", input.ChainSelector)
+		}
+
+		capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(
+			common.HexToAddress(input.Address),
+			chain.Client,
+		)
+		if err != nil {
+			return VerifyMCMSExecutionOutput{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+		}
+
+		ctx := b.GetContext()
+		var logs []*types.Log
+		for _, h := range input.ExecutionTxHashes {
+			receipt, err := chain.Client.TransactionReceipt(ctx, common.HexToHash(h))
+			if err != nil {
+				return VerifyMCMSExecutionOutput{}, fmt.Errorf("failed to get receipt of execution transaction %s: %w", h, err)
+			}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return VerifyMCMSExecutionOutput{}, fmt.Errorf("execution transaction %s reverted", h)
+			}
+			logs = append(logs, receipt.Logs...)
+		}
+
+		events, err := pkg.ParseRegistryLogs(capReg, logs)
+		if err != nil {
+			return VerifyMCMSExecutionOutput{}, fmt.Errorf("failed to parse registry events: %w", err)
+		}
+		if events.IsEmpty() {
+			return VerifyMCMSExecutionOutput{}, fmt.Errorf("no Capabilities Registry events found in the execution transactions on chain %d", input.ChainSelector)
+		}
+
+		dons := make([]capabilities_registry_v2.CapabilitiesRegistryDONInfo, 0, len(events.DONIDs()))
+		for _, id := range events.DONIDs() {
+			don, err := capReg.GetDON(&bind.CallOpts{Context: ctx}, id)
+			if err != nil {
+				err = cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+				return VerifyMCMSExecutionOutput{}, fmt.Errorf("failed to call GetDON for DON %d: %w", id, err)
+			}
+			dons = append(dons, don)
+		}
+
+		deps.Env.Logger.Infof("Verified MCMS execution on chain %d: %d node operators, %d nodes, %d capabilities and %d DONs changed",
+			input.ChainSelector, len(events.NodeOperatorsAdded)+len(events.NodeOperatorsUpdated)+len(events.NodeOperatorsRemoved),
+			len(events.NodesAdded)+len(events.NodesUpdated)+len(events.NodesRemoved),
+			len(events.CapabilitiesConfigured)+len(events.CapabilitiesDeprecated), len(dons))
+
+		return VerifyMCMSExecutionOutput{
+			Nops:         events.NodeOperatorsAdded,
+			Nodes:        events.NodesAdded,
+			Capabilities: events.CapabilitiesConfigured,
+			DONs:         dons,
+			Events:       events,
+		}, nil
+	},
+)
+
+func checkProposalTargetsRegistry(proposal *mcmslib.TimelockProposal, chainSelector uint64, address string) error {
+	for _, op := range proposal.Operations {
+		if op.ChainSelector != mcmstypes.ChainSelector(chainSelector) {
+			continue
+		}
+		for _, tx := range op.Transactions {
+			if strings.EqualFold(tx.To, address) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("proposal has no operation on Capabilities Registry %s on chain %d", address, chainSelector)
+}
@@ -78,6 +78,39 @@ func (c *CapabilityConfig) UnmarshalProto(data []byte) error {
 	return nil
 }
 
+// NewCapabilityConfigFromProto converts a typed CapabilityConfig proto message into a CapabilityConfig
+func NewCapabilityConfigFromProto(msg *pb.CapabilityConfig) (CapabilityConfig, error) {
+	if msg == nil {
+		return nil, errors.New("cannot convert nil CapabilityConfig proto message")
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to proto marshal %T: %w", msg, err)
+	}
+
+	c := CapabilityConfig{}
+	if err := c.UnmarshalProto(data); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// ToProto converts the CapabilityConfig into a typed CapabilityConfig proto message
+func (c CapabilityConfig) ToProto() (*pb.CapabilityConfig, error) {
+	data, err := c.MarshalProto()
+	if err != nil {
+		return nil, err
+	}
+
+	pbCfg := &pb.CapabilityConfig{}
+	if err := proto.Unmarshal(data, pbCfg); err != nil {
+		return nil, fmt.Errorf("failed to proto unmarshal data into %T: %w", pbCfg, err)
+	}
+
+	return pbCfg, nil
+}
+
 func (c CapabilityConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any(c)) // avoid infinite recursion by casting to underlying type
 }
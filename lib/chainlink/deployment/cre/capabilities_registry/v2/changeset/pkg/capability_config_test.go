@@ -2,9 +2,14 @@ package pkg_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/pb"
 
 	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
 )
@@ -158,3 +163,33 @@ func TestCapabilityConfig_UnmarshalWithValidation(t *testing.T) {
 		require.NotNil(t, protoBts)
 	})
 }
+
+func TestCapabilityConfig_Proto(t *testing.T) {
+	t.Parallel()
+
+	msg := &pb.CapabilityConfig{
+		MethodConfigs: map[string]*pb.CapabilityMethodConfig{
+			"BalanceAt": {
+				RemoteConfig: &pb.CapabilityMethodConfig_RemoteExecutableConfig{
+					RemoteExecutableConfig: &pb.RemoteExecutableConfig{
+						RequestTimeout:            durationpb.New(30 * time.Second),
+						ServerMaxParallelRequests: 10,
+					},
+				},
+			},
+		},
+		LocalOnly: true,
+	}
+
+	cfg, err := pkg.NewCapabilityConfigFromProto(msg)
+	require.NoError(t, err)
+	assert.Equal(t, true, cfg["localOnly"])
+	assert.Contains(t, cfg, "methodConfigs")
+
+	got, err := cfg.ToProto()
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(msg, got))
+
+	_, err = pkg.NewCapabilityConfigFromProto(nil)
+	require.Error(t, err)
+}
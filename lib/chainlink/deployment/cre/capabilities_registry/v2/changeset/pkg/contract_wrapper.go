@@ -1,43 +1,164 @@
 package pkg
 
 import (
+	"fmt"
+	"maps"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"golang.org/x/sync/errgroup"
 
 	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
 	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
 )
 
-// TODO: we have to support pagination eventually
 var (
-	MaxCapabilities = big.NewInt(128)
-	MaxDONs         = big.NewInt(32)
-	MaxNodes        = big.NewInt(256)
-	MaxNOPs         = big.NewInt(128)
+	// PageSize is the number of entries fetched per call by the paginated getters.
+	PageSize int64 = 64
+	// PageConcurrency is the number of pages fetched concurrently by the paginated getters.
+	PageConcurrency = 4
 )
 
+// pageGetter is a contract getter with `start` and `limit` pagination parameters.
+type pageGetter[T any] func(opts *bind.CallOpts, start *big.Int, limit *big.Int) ([]T, error)
+
+// getAllPages fetches every page of a paginated getter, PageConcurrency pages at a time, until a page is not full.
+// Callers should pin opts.BlockNumber, otherwise pages may be read from different blocks.
+func getAllPages[T any](opts *bind.CallOpts, get pageGetter[T]) ([]T, error) {
+	var out []T
+	for start := int64(0); ; start += PageSize * int64(PageConcurrency) {
+		pages := make([][]T, PageConcurrency)
+		var g errgroup.Group
+		for i := range pages {
+			g.Go(func() error {
+				page, err := get(opts, big.NewInt(start+int64(i)*PageSize), big.NewInt(PageSize))
+				if err != nil {
+					return cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+				}
+				pages[i] = page
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+
+		for _, page := range pages {
+			out = append(out, page...)
+			if int64(len(page)) < PageSize {
+				return out, nil
+			}
+		}
+	}
+}
+
 func GetCapabilities(opts *bind.CallOpts, capReg *capabilities_registry_v2.CapabilitiesRegistry) ([]capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo, error) {
-	caps, err := capReg.GetCapabilities(opts, big.NewInt(0), MaxCapabilities)
-	return caps, cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+	return getAllPages(opts, capReg.GetCapabilities)
 }
 
 func GetNodeOperators(opts *bind.CallOpts, capReg *capabilities_registry_v2.CapabilitiesRegistry) ([]capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo, error) {
-	nops, err := capReg.GetNodeOperators(opts, big.NewInt(0), MaxNOPs)
-	return nops, cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+	return getAllPages(opts, capReg.GetNodeOperators)
 }
 
 func GetNodes(opts *bind.CallOpts, capReg *capabilities_registry_v2.CapabilitiesRegistry) ([]capabilities_registry_v2.INodeInfoProviderNodeInfo, error) {
-	nodes, err := capReg.GetNodes(opts, big.NewInt(0), MaxNodes)
-	return nodes, cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+	return getAllPages(opts, capReg.GetNodes)
 }
 
 func GetDONs(opts *bind.CallOpts, capReg *capabilities_registry_v2.CapabilitiesRegistry) ([]capabilities_registry_v2.CapabilitiesRegistryDONInfo, error) {
-	donsInfo, err := capReg.GetDONs(opts, big.NewInt(0), MaxDONs)
-	return donsInfo, cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+	return getAllPages(opts, capReg.GetDONs)
+}
+
+func GetDONFamilies(opts *bind.CallOpts, capReg *capabilities_registry_v2.CapabilitiesRegistry) ([]string, error) {
+	return getAllPages(opts, capReg.GetDONFamilies)
 }
 
 func GetDONsInFamily(opts *bind.CallOpts, capReg *capabilities_registry_v2.CapabilitiesRegistry, family string) ([]*big.Int, error) {
-	donsInfo, err := capReg.GetDONsInFamily(opts, family, big.NewInt(0), MaxDONs)
-	return donsInfo, cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+	return getAllPages(opts, func(opts *bind.CallOpts, start *big.Int, limit *big.Int) ([]*big.Int, error) {
+		return capReg.GetDONsInFamily(opts, family, start, limit)
+	})
+}
+
+// NodeOperator is a node operator of the registry with its ID, which the contract getters do not return.
+type NodeOperator struct {
+	ID uint32
+	capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo
+}
+
+// GetNodeOperatorsWithIDs returns the node operators with their IDs, in the order of GetNodeOperators.
+// The position of a node operator in GetNodeOperators is not its ID: the contract stores the IDs in an enumerable set,
+// which moves the last ID in place of a removed one. The IDs are found by reading the node operators by ID instead.
+func GetNodeOperatorsWithIDs(opts *bind.CallOpts, capReg *capabilities_registry_v2.CapabilitiesRegistry) ([]NodeOperator, error) {
+	nops, err := GetNodeOperators(opts, capReg)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := nodeOperatorIDs(nops, func(id uint32) (capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo, error) {
+		nop, err := capReg.GetNodeOperator(opts, id)
+		if err != nil {
+			return nop, cldf.DecodeErr(capabilities_registry_v2.CapabilitiesRegistryABI, err)
+		}
+		return nop, nil
+	}, func(nop capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo) string {
+		return nop.Admin.Hex() + "/" + nop.Name
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]NodeOperator, len(nops))
+	for i, nop := range nops {
+		out[i] = NodeOperator{ID: ids[i], CapabilitiesRegistryNodeOperatorInfo: nop}
+	}
+	return out, nil
+}
+
+// nodeOperatorIDs returns the IDs of the node operators, in order, by reading the node operators by ID from 1 until
+// all of them are found. Node operators are identified by key, and removed node operators, which have no admin, never
+// match. It fails if a whole page of consecutive IDs has no node operator left to find, i.e. the node operators are
+// not all registered, or more than a page of consecutive node operators were removed.
+func nodeOperatorIDs[T any](nops []T, get func(id uint32) (T, error), key func(T) string) ([]uint32, error) {
+	indexes := make(map[string]int, len(nops))
+	for i, nop := range nops {
+		indexes[key(nop)] = i
+	}
+
+	ids := make([]uint32, len(nops))
+	for first := uint32(1); len(indexes) > 0; first += uint32(PageSize) { //nolint:gosec // G115
+		page := make([]T, PageSize)
+		var g errgroup.Group
+		g.SetLimit(PageConcurrency)
+		for i := range page {
+			g.Go(func() (err error) {
+				page[i], err = get(first + uint32(i)) //nolint:gosec // G115
+				return err
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return nil, err
+		}
+
+		found := false
+		for i, nop := range page {
+			if index, ok := indexes[key(nop)]; ok {
+				ids[index] = first + uint32(i) //nolint:gosec // G115
+				delete(indexes, key(nop))
+				found = true
+			}
+		}
+		if !found {
+			missing := slices.Sorted(maps.Keys(indexes))
+			return nil, fmt.Errorf("failed to find the IDs of node operators %v, no node operator found from ID %d to %d", missing, first, first+uint32(PageSize)-1) //nolint:gosec // G115
+		}
+	}
+	return ids, nil
+}
+
+// NodeOperatorIDsByName maps the name of each node operator to its ID.
+func NodeOperatorIDsByName(nops []NodeOperator) map[string]uint32 {
+	out := make(map[string]uint32, len(nops))
+	for _, nop := range nops {
+		out[nop.Name] = nop.ID
+	}
+	return out
 }
@@ -0,0 +1,92 @@
+package pkg
+
+import (
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllPages(t *testing.T) {
+	pageSize, concurrency := PageSize, PageConcurrency
+	t.Cleanup(func() { PageSize, PageConcurrency = pageSize, concurrency })
+	PageSize, PageConcurrency = 3, 2
+
+	newGetter := func(total int) (pageGetter[int], *atomic.Int32) {
+		calls := &atomic.Int32{}
+		return func(_ *bind.CallOpts, start *big.Int, limit *big.Int) ([]int, error) {
+			calls.Add(1)
+			var page []int
+			for i := start.Int64(); i < start.Int64()+limit.Int64() && i < int64(total); i++ {
+				page = append(page, int(i))
+			}
+			return page, nil
+		}, calls
+	}
+
+	tests := []struct {
+		name      string
+		total     int
+		wantCalls int32
+	}{
+		{name: "empty", total: 0, wantCalls: 2},
+		{name: "single partial page", total: 2, wantCalls: 2},
+		{name: "exactly one round", total: 6, wantCalls: 4},
+		{name: "several rounds", total: 14, wantCalls: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			get, calls := newGetter(tt.total)
+			got, err := getAllPages(nil, get)
+			require.NoError(t, err)
+			require.Len(t, got, tt.total)
+			for i, v := range got {
+				assert.Equal(t, i, v, "entries are in contract order")
+			}
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		_, err := getAllPages(nil, func(_ *bind.CallOpts, start *big.Int, _ *big.Int) ([]int, error) {
+			if start.Int64() > 0 {
+				return nil, errors.New("out of gas")
+			}
+			return []int{0, 1, 2}, nil
+		})
+		require.ErrorContains(t, err, "out of gas")
+	})
+}
+
+func TestNodeOperatorIDs(t *testing.T) {
+	pageSize := PageSize
+	t.Cleanup(func() { PageSize = pageSize })
+	PageSize = 2
+
+	// node operators by ID, 2 was removed, so the contract moved 4 in its place in the list
+	registry := map[uint32]string{1: "nop-1", 3: "nop-3", 4: "nop-4"}
+	get := func(id uint32) (string, error) {
+		return registry[id], nil
+	}
+	key := func(name string) string { return name }
+
+	ids, err := nodeOperatorIDs([]string{"nop-1", "nop-4", "nop-3"}, get, key)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 4, 3}, ids)
+
+	ids, err = nodeOperatorIDs([]string{}, get, key)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	_, err = nodeOperatorIDs([]string{"nop-1", "nop-5"}, get, key)
+	require.ErrorContains(t, err, "nop-5")
+
+	_, err = nodeOperatorIDs([]string{"nop-1"}, func(uint32) (string, error) {
+		return "", errors.New("out of gas")
+	}, key)
+	require.ErrorContains(t, err, "out of gas")
+}
@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+// NodeManifestCSVHeader is the header expected on the first line of a CSV node manifest.
+// Capability IDs are separated by semicolons within their column.
+var NodeManifestCSVHeader = []string{"nop", "p2p_id", "signer", "encryption_public_key", "csa_key", "capability_ids"}
+
+// NodeManifestEntry describes a node to be registered in the capabilities registry.
+// Keys are hex encoded, with or without the 0x prefix. The P2P ID uses the `p2p_` prefixed peer ID format.
+type NodeManifestEntry struct {
+	NOP                 string   `json:"nop" yaml:"nop"`
+	P2PID               string   `json:"p2pID" yaml:"p2pID"`
+	Signer              string   `json:"signer" yaml:"signer"`
+	EncryptionPublicKey string   `json:"encryptionPublicKey" yaml:"encryptionPublicKey"`
+	CSAKey              string   `json:"csaKey" yaml:"csaKey"`
+	CapabilityIDs       []string `json:"capabilityIDs" yaml:"capabilityIDs"`
+}
+
+// NodeManifestKeys are the decoded keys of a NodeManifestEntry.
+type NodeManifestKeys struct {
+	P2PID               p2pkey.PeerID
+	Signer              [32]byte
+	EncryptionPublicKey [32]byte
+	CSAKey              [32]byte
+}
+
+// Decode validates the format of the entry and decodes its keys.
+func (e NodeManifestEntry) Decode() (NodeManifestKeys, error) {
+	var keys NodeManifestKeys
+	if e.NOP == "" {
+		return keys, errors.New("nop cannot be empty")
+	}
+	if len(e.CapabilityIDs) == 0 {
+		return keys, errors.New("capabilityIDs cannot be empty")
+	}
+
+	var err error
+	if keys.P2PID, err = p2pkey.MakePeerID(e.P2PID); err != nil {
+		return keys, fmt.Errorf("invalid p2pID %q: %w", e.P2PID, err)
+	}
+	if keys.Signer, err = decodeNonZero32Bytes(e.Signer); err != nil {
+		return keys, fmt.Errorf("invalid signer: %w", err)
+	}
+	if keys.EncryptionPublicKey, err = decodeNonZero32Bytes(e.EncryptionPublicKey); err != nil {
+		return keys, fmt.Errorf("invalid encryptionPublicKey: %w", err)
+	}
+	if keys.CSAKey, err = decodeNonZero32Bytes(e.CSAKey); err != nil {
+		return keys, fmt.Errorf("invalid csaKey: %w", err)
+	}
+
+	return keys, nil
+}
+
+func decodeNonZero32Bytes(s string) ([32]byte, error) {
+	b, err := HexStringTo32Bytes(s)
+	if err != nil {
+		return b, err
+	}
+	if b == [32]byte{} {
+		return b, errors.New("cannot be all zeros")
+	}
+	return b, nil
+}
+
+// LoadNodeManifest reads a node manifest from a JSON or CSV file, based on the file extension.
+func LoadNodeManifest(path string) ([]NodeManifestEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open node manifest %s: %w", path, err)
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return ParseNodeManifestJSON(f)
+	case ".csv":
+		return ParseNodeManifestCSV(f)
+	default:
+		return nil, fmt.Errorf("unsupported node manifest extension %q, expected .json or .csv", ext)
+	}
+}
+
+// ParseNodeManifestJSON parses a JSON array of NodeManifestEntry.
+func ParseNodeManifestJSON(r io.Reader) ([]NodeManifestEntry, error) {
+	var entries []NodeManifestEntry
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON node manifest: %w", err)
+	}
+	return entries, nil
+}
+
+// ParseNodeManifestCSV parses a CSV node manifest whose first line is NodeManifestCSVHeader.
+func ParseNodeManifestCSV(r io.Reader) ([]NodeManifestEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(NodeManifestCSVHeader)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV node manifest: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("CSV node manifest is empty")
+	}
+
+	for i, column := range records[0] {
+		if strings.TrimSpace(strings.ToLower(column)) != NodeManifestCSVHeader[i] {
+			return nil, fmt.Errorf("unexpected CSV node manifest header %v, expected %v", records[0], NodeManifestCSVHeader)
+		}
+	}
+
+	entries := make([]NodeManifestEntry, 0, len(records)-1)
+	for _, record := range records[1:] {
+		var capabilityIDs []string
+		for _, id := range strings.Split(record[5], ";") {
+			if id = strings.TrimSpace(id); id != "" {
+				capabilityIDs = append(capabilityIDs, id)
+			}
+		}
+
+		entries = append(entries, NodeManifestEntry{
+			NOP:                 strings.TrimSpace(record[0]),
+			P2PID:               strings.TrimSpace(record[1]),
+			Signer:              strings.TrimSpace(record[2]),
+			EncryptionPublicKey: strings.TrimSpace(record[3]),
+			CSAKey:              strings.TrimSpace(record[4]),
+			CapabilityIDs:       capabilityIDs,
+		})
+	}
+
+	return entries, nil
+}
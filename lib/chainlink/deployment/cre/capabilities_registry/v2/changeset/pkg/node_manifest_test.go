@@ -0,0 +1,108 @@
+package pkg
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+const (
+	testSigner = "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+	testEncKey = "2102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+	testCSAKey = "0x3102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+)
+
+func TestLoadNodeManifest(t *testing.T) {
+	peerID := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PeerID()
+	expected := []NodeManifestEntry{{
+		NOP:                 "nop-1",
+		P2PID:               peerID.String(),
+		Signer:              testSigner,
+		EncryptionPublicKey: testEncKey,
+		CSAKey:              testCSAKey,
+		CapabilityIDs:       []string{"trigger@1.0.0", "write-chain@1.0.1"},
+	}}
+
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nodes.json")
+		content := `[{"nop":"nop-1","p2pID":"` + peerID.String() + `","signer":"` + testSigner +
+			`","encryptionPublicKey":"` + testEncKey + `","csaKey":"` + testCSAKey +
+			`","capabilityIDs":["trigger@1.0.0","write-chain@1.0.1"]}]`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		entries, err := LoadNodeManifest(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, entries)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nodes.csv")
+		content := strings.Join(NodeManifestCSVHeader, ",") + "\n" +
+			"nop-1," + peerID.String() + "," + testSigner + "," + testEncKey + "," + testCSAKey + ",trigger@1.0.0;write-chain@1.0.1\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		entries, err := LoadNodeManifest(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, entries)
+	})
+
+	t.Run("csv with wrong header", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nodes.csv")
+		require.NoError(t, os.WriteFile(path, []byte("a,b,c,d,e,f\n"), 0o600))
+
+		_, err := LoadNodeManifest(path)
+		require.ErrorContains(t, err, "unexpected CSV node manifest header")
+	})
+
+	t.Run("unsupported extension", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nodes.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(""), 0o600))
+
+		_, err := LoadNodeManifest(path)
+		require.ErrorContains(t, err, "unsupported node manifest extension")
+	})
+}
+
+func TestNodeManifestEntry_Decode(t *testing.T) {
+	peerID := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PeerID()
+	valid := NodeManifestEntry{
+		NOP:                 "nop-1",
+		P2PID:               peerID.String(),
+		Signer:              testSigner,
+		EncryptionPublicKey: testEncKey,
+		CSAKey:              testCSAKey,
+		CapabilityIDs:       []string{"trigger@1.0.0"},
+	}
+
+	keys, err := valid.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, peerID, keys.P2PID)
+	assert.Equal(t, byte(0x21), keys.EncryptionPublicKey[0])
+
+	tests := []struct {
+		name   string
+		modify func(e *NodeManifestEntry)
+		errMsg string
+	}{
+		{"missing nop", func(e *NodeManifestEntry) { e.NOP = "" }, "nop cannot be empty"},
+		{"missing capabilities", func(e *NodeManifestEntry) { e.CapabilityIDs = nil }, "capabilityIDs cannot be empty"},
+		{"invalid p2p id", func(e *NodeManifestEntry) { e.P2PID = "p2p_invalid" }, "invalid p2pID"},
+		{"short signer", func(e *NodeManifestEntry) { e.Signer = "0x0102" }, "invalid signer"},
+		{"zero csa key", func(e *NodeManifestEntry) { e.CSAKey = strings.Repeat("0", 64) }, "cannot be all zeros"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := valid
+			tt.modify(&e)
+			_, err := e.Decode()
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package pkg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/pb"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+// RegistryState is the state of a V2 Capabilities Registry contract, as returned by the contract getters.
+type RegistryState struct {
+	Nops         []NodeOperator
+	Nodes        []capabilities_registry_v2.INodeInfoProviderNodeInfo
+	Capabilities []capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo
+	DONs         []capabilities_registry_v2.CapabilitiesRegistryDONInfo
+}
+
+// ReadRegistryState reads all the node operators, nodes, capabilities and DONs of a V2 Capabilities Registry.
+func ReadRegistryState(opts *bind.CallOpts, capReg *capabilities_registry_v2.CapabilitiesRegistry) (RegistryState, error) {
+	nops, err := GetNodeOperatorsWithIDs(opts, capReg)
+	if err != nil {
+		return RegistryState{}, fmt.Errorf("failed to fetch node operators from contract: %w", err)
+	}
+	nodes, err := GetNodes(opts, capReg)
+	if err != nil {
+		return RegistryState{}, fmt.Errorf("failed to fetch nodes from contract: %w", err)
+	}
+	caps, err := GetCapabilities(opts, capReg)
+	if err != nil {
+		return RegistryState{}, fmt.Errorf("failed to fetch capabilities from contract: %w", err)
+	}
+	dons, err := GetDONs(opts, capReg)
+	if err != nil {
+		return RegistryState{}, fmt.Errorf("failed to fetch DONs from contract: %w", err)
+	}
+
+	return RegistryState{
+		Nops:         nops,
+		Nodes:        nodes,
+		Capabilities: caps,
+		DONs:         dons,
+	}, nil
+}
+
+// DesiredRegistryState is the state a V2 Capabilities Registry contract should converge to.
+// Node operators are identified by name, nodes by P2P ID, capabilities by ID and DONs by name.
+type DesiredRegistryState struct {
+	Nops         []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams
+	Capabilities []capabilities_registry_v2.CapabilitiesRegistryCapability
+	Nodes        []DesiredNode
+	DONs         []capabilities_registry_v2.CapabilitiesRegistryNewDONParams
+}
+
+// DesiredNode is a node whose node operator is referenced by name, since the ID of new node operators is not known upfront.
+type DesiredNode struct {
+	NOP                 string
+	Signer              [32]byte
+	P2PID               [32]byte
+	EncryptionPublicKey [32]byte
+	CSAKey              [32]byte
+	CapabilityIDs       []string
+}
+
+// NopAdminChange changes the admin of the node operator with the given name.
+type NopAdminChange struct {
+	Name  string
+	Admin common.Address
+}
+
+// DONFamiliesChange adds and removes the families of the DON with the given name.
+type DONFamiliesChange struct {
+	DonName            string
+	AddToFamilies      []string
+	RemoveFromFamilies []string
+}
+
+// ReconcilePlan is the set of changes needed to converge a registry to a DesiredRegistryState.
+// Apply the changes in the order of the fields: additions and updates first, then removals, so nodes and
+// node operators are no longer referenced when they are removed.
+type ReconcilePlan struct {
+	AddNops         []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams
+	UpdateNops      []NopAdminChange
+	AddCapabilities []capabilities_registry_v2.CapabilitiesRegistryCapability
+	AddNodes        []DesiredNode
+	UpdateNodes     []DesiredNode
+	UpdateDONs      []capabilities_registry_v2.CapabilitiesRegistryNewDONParams
+	AddDONs         []capabilities_registry_v2.CapabilitiesRegistryNewDONParams
+	DONFamilies     []DONFamiliesChange
+
+	// Removals are only planned when pruning.
+	RemoveDONs            []string
+	RemoveNodes           [][32]byte
+	RemoveNops            []string
+	DeprecateCapabilities []string
+
+	// NodeOperatorIDs maps the name of each desired node operator to its ID, including the IDs that new
+	// node operators will be assigned once added. The IDs of new node operators are predicted from the highest ID in
+	// the registry, so they are off if the node operator with the highest ID ever assigned was removed.
+	NodeOperatorIDs map[string]uint32
+	// Warnings are differences that cannot be reconciled, since the contract does not allow to update them.
+	Warnings []string
+}
+
+// IsEmpty reports whether the plan has no changes to apply.
+func (p ReconcilePlan) IsEmpty() bool {
+	return len(p.AddNops) == 0 && len(p.UpdateNops) == 0 && len(p.AddCapabilities) == 0 &&
+		len(p.AddNodes) == 0 && len(p.UpdateNodes) == 0 && len(p.UpdateDONs) == 0 && len(p.AddDONs) == 0 &&
+		len(p.DONFamilies) == 0 && len(p.RemoveDONs) == 0 && len(p.RemoveNodes) == 0 && len(p.RemoveNops) == 0 &&
+		len(p.DeprecateCapabilities) == 0
+}
+
+// PlanReconcile diffs the current registry state against the desired one. When prune is set, node operators,
+// nodes and DONs missing from the desired state are removed, and missing capabilities are deprecated.
+func PlanReconcile(current RegistryState, desired DesiredRegistryState, prune bool) (ReconcilePlan, error) {
+	if err := validateDesiredRegistryState(desired); err != nil {
+		return ReconcilePlan{}, fmt.Errorf("invalid desired registry state: %w", err)
+	}
+
+	plan := ReconcilePlan{NodeOperatorIDs: NodeOperatorIDsByName(current.Nops)}
+	planNops(&plan, current, desired, prune)
+	planCapabilities(&plan, current, desired, prune)
+	if err := planNodes(&plan, current, desired, prune); err != nil {
+		return ReconcilePlan{}, err
+	}
+	planDONs(&plan, current, desired, prune)
+
+	return plan, nil
+}
+
+func validateDesiredRegistryState(desired DesiredRegistryState) error {
+	var errs []error
+	seen := make(map[string]struct{})
+	check := func(kind, key string) {
+		if key == "" {
+			errs = append(errs, fmt.Errorf("%s identifier cannot be empty", kind))
+			return
+		}
+		if _, exists := seen[kind+"/"+key]; exists {
+			errs = append(errs, fmt.Errorf("duplicate %s %s", kind, key))
+		}
+		seen[kind+"/"+key] = struct{}{}
+	}
+
+	for _, nop := range desired.Nops {
+		check("node operator", nop.Name)
+	}
+	for _, c := range desired.Capabilities {
+		check("capability", c.CapabilityId)
+	}
+	for _, node := range desired.Nodes {
+		check("node", p2pkey.PeerID(node.P2PID).String())
+	}
+	for _, don := range desired.DONs {
+		check("DON", don.Name)
+	}
+
+	return errors.Join(errs...)
+}
+
+func planNops(plan *ReconcilePlan, current RegistryState, desired DesiredRegistryState, prune bool) {
+	currentByName := make(map[string]NodeOperator, len(current.Nops))
+	var nextID uint32
+	for _, nop := range current.Nops {
+		currentByName[nop.Name] = nop
+		nextID = max(nextID, nop.ID)
+	}
+
+	for _, nop := range desired.Nops {
+		existing, exists := currentByName[nop.Name]
+		if !exists {
+			nextID++
+			plan.NodeOperatorIDs[nop.Name] = nextID
+			plan.AddNops = append(plan.AddNops, nop)
+			continue
+		}
+		if existing.Admin != nop.Admin {
+			plan.UpdateNops = append(plan.UpdateNops, NopAdminChange{Name: nop.Name, Admin: nop.Admin})
+		}
+	}
+
+	if prune {
+		for _, nop := range current.Nops {
+			if !slices.ContainsFunc(desired.Nops, func(d capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams) bool {
+				return d.Name == nop.Name
+			}) {
+				plan.RemoveNops = append(plan.RemoveNops, nop.Name)
+			}
+		}
+	}
+}
+
+func planCapabilities(plan *ReconcilePlan, current RegistryState, desired DesiredRegistryState, prune bool) {
+	currentByID := make(map[string]capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo, len(current.Capabilities))
+	for _, c := range current.Capabilities {
+		currentByID[c.CapabilityId] = c
+	}
+
+	desiredIDs := make(map[string]struct{}, len(desired.Capabilities))
+	for _, c := range desired.Capabilities {
+		desiredIDs[c.CapabilityId] = struct{}{}
+		existing, exists := currentByID[c.CapabilityId]
+		if !exists {
+			plan.AddCapabilities = append(plan.AddCapabilities, c)
+			continue
+		}
+		if existing.IsDeprecated {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("capability %s is deprecated and cannot be reactivated", c.CapabilityId))
+		}
+		if existing.ConfigurationContract != c.ConfigurationContract || !bytes.Equal(existing.Metadata, c.Metadata) {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("capability %s differs from the registry, capabilities cannot be updated", c.CapabilityId))
+		}
+	}
+
+	if prune {
+		for _, c := range current.Capabilities {
+			if _, exists := desiredIDs[c.CapabilityId]; !exists && !c.IsDeprecated {
+				plan.DeprecateCapabilities = append(plan.DeprecateCapabilities, c.CapabilityId)
+			}
+		}
+	}
+}
+
+func planNodes(plan *ReconcilePlan, current RegistryState, desired DesiredRegistryState, prune bool) error {
+	currentByP2PID := make(map[[32]byte]capabilities_registry_v2.INodeInfoProviderNodeInfo, len(current.Nodes))
+	for _, node := range current.Nodes {
+		currentByP2PID[node.P2pId] = node
+	}
+
+	desiredP2PIDs := make(map[[32]byte]struct{}, len(desired.Nodes))
+	for _, node := range desired.Nodes {
+		desiredP2PIDs[node.P2PID] = struct{}{}
+		nopID, exists := plan.NodeOperatorIDs[node.NOP]
+		if !exists {
+			return fmt.Errorf("node %s references node operator `%s` which is neither registered nor desired", p2pkey.PeerID(node.P2PID), node.NOP)
+		}
+
+		existing, exists := currentByP2PID[node.P2PID]
+		if !exists {
+			plan.AddNodes = append(plan.AddNodes, node)
+			continue
+		}
+
+		if existing.NodeOperatorId != nopID ||
+			existing.Signer != node.Signer ||
+			existing.EncryptionPublicKey != node.EncryptionPublicKey ||
+			existing.CsaKey != node.CSAKey ||
+			!isSubset(node.CapabilityIDs, existing.CapabilityIds) {
+			plan.UpdateNodes = append(plan.UpdateNodes, node)
+		}
+		if !isSubset(existing.CapabilityIds, node.CapabilityIDs) {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("node %s supports capabilities that are not desired, capabilities are never removed from nodes", p2pkey.PeerID(node.P2PID)))
+		}
+	}
+
+	if prune {
+		for _, node := range current.Nodes {
+			if _, exists := desiredP2PIDs[node.P2pId]; !exists {
+				plan.RemoveNodes = append(plan.RemoveNodes, node.P2pId)
+			}
+		}
+	}
+
+	return nil
+}
+
+func planDONs(plan *ReconcilePlan, current RegistryState, desired DesiredRegistryState, prune bool) {
+	currentByName := make(map[string]capabilities_registry_v2.CapabilitiesRegistryDONInfo, len(current.DONs))
+	for _, don := range current.DONs {
+		currentByName[don.Name] = don
+	}
+
+	desiredNames := make(map[string]struct{}, len(desired.DONs))
+	for _, don := range desired.DONs {
+		desiredNames[don.Name] = struct{}{}
+		existing, exists := currentByName[don.Name]
+		if !exists {
+			plan.AddDONs = append(plan.AddDONs, don)
+			continue
+		}
+
+		if existing.AcceptsWorkflows != don.AcceptsWorkflows {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("DON %s differs in acceptsWorkflows, which cannot be updated", don.Name))
+		}
+		if !existing.IsPublic && don.IsPublic {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("DON %s is private and cannot be made public", don.Name))
+		}
+		if existing.F != don.F ||
+			(existing.IsPublic && !don.IsPublic) ||
+			!equalConfigs(existing.Config, don.Config) ||
+			!sameElements(existing.NodeP2PIds, don.Nodes) ||
+			!sameCapabilityConfigurations(existing.CapabilityConfigurations, don.CapabilityConfigurations) {
+			plan.UpdateDONs = append(plan.UpdateDONs, don)
+		}
+
+		add := difference(don.DonFamilies, existing.DonFamilies)
+		remove := difference(existing.DonFamilies, don.DonFamilies)
+		if len(add) > 0 || len(remove) > 0 {
+			plan.DONFamilies = append(plan.DONFamilies, DONFamiliesChange{
+				DonName:            don.Name,
+				AddToFamilies:      add,
+				RemoveFromFamilies: remove,
+			})
+		}
+	}
+
+	if prune {
+		for _, don := range current.DONs {
+			if _, exists := desiredNames[don.Name]; !exists {
+				plan.RemoveDONs = append(plan.RemoveDONs, don.Name)
+			}
+		}
+	}
+}
+
+func sameCapabilityConfigurations(a, b []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byID := make(map[string][]byte, len(a))
+	for _, cfg := range a {
+		byID[cfg.CapabilityId] = cfg.Config
+	}
+	for _, cfg := range b {
+		existing, exists := byID[cfg.CapabilityId]
+		if !exists || !equalConfigs(existing, cfg.Config) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalConfigs compares proto encoded capability configs. The encoding of map fields is not deterministic, so configs
+// with different bytes are decoded and compared field by field.
+func equalConfigs(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	pbA, pbB := &pb.CapabilityConfig{}, &pb.CapabilityConfig{}
+	if proto.Unmarshal(a, pbA) != nil || proto.Unmarshal(b, pbB) != nil {
+		return false
+	}
+	return proto.Equal(pbA, pbB)
+}
+
+// sameElements reports whether a and b contain the same elements, regardless of order.
+func sameElements[T comparable](a, b []T) bool {
+	return len(a) == len(b) && isSubset(a, b) && isSubset(b, a)
+}
+
+// isSubset reports whether every element of a is in b.
+func isSubset[T comparable](a, b []T) bool {
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// difference returns the elements of a that are not in b.
+func difference[T comparable](a, b []T) []T {
+	var out []T
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,142 @@
+package pkg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+func TestPlanReconcile(t *testing.T) {
+	peer1 := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PeerID()
+	peer2 := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(2)).PeerID()
+	peer3 := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(3)).PeerID()
+
+	current := RegistryState{
+		Nops: []NodeOperator{
+			{ID: 1, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Name: "nop-1", Admin: common.HexToAddress("0x01")}},
+			{ID: 2, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Name: "nop-2", Admin: common.HexToAddress("0x02")}},
+		},
+		Capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo{
+			{CapabilityId: "trigger@1.0.0", Metadata: []byte(`{}`)},
+			{CapabilityId: "old@1.0.0", Metadata: []byte(`{}`)},
+		},
+		Nodes: []capabilities_registry_v2.INodeInfoProviderNodeInfo{
+			{NodeOperatorId: 1, P2pId: peer1, Signer: [32]byte{1}, CapabilityIds: []string{"trigger@1.0.0"}},
+			{NodeOperatorId: 2, P2pId: peer2, Signer: [32]byte{2}, CapabilityIds: []string{"trigger@1.0.0"}},
+		},
+		DONs: []capabilities_registry_v2.CapabilitiesRegistryDONInfo{
+			{Name: "don-1", F: 1, IsPublic: true, NodeP2PIds: [][32]byte{peer1, peer2}, DonFamilies: []string{"family-a"}},
+			{Name: "don-old", F: 1, NodeP2PIds: [][32]byte{peer2}},
+		},
+	}
+
+	desired := DesiredRegistryState{
+		Nops: []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+			{Name: "nop-1", Admin: common.HexToAddress("0x11")},
+			{Name: "nop-3", Admin: common.HexToAddress("0x03")},
+		},
+		Capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapability{
+			{CapabilityId: "trigger@1.0.0", Metadata: []byte(`{}`)},
+			{CapabilityId: "write@1.0.0", Metadata: []byte(`{}`)},
+		},
+		Nodes: []DesiredNode{
+			{NOP: "nop-1", P2PID: peer1, Signer: [32]byte{1}, CapabilityIDs: []string{"trigger@1.0.0"}},
+			{NOP: "nop-3", P2PID: peer3, Signer: [32]byte{3}, CapabilityIDs: []string{"write@1.0.0"}},
+		},
+		DONs: []capabilities_registry_v2.CapabilitiesRegistryNewDONParams{
+			{Name: "don-1", F: 1, IsPublic: true, Nodes: [][32]byte{peer3, peer1}, DonFamilies: []string{"family-b"}},
+			{Name: "don-2", F: 1, Nodes: [][32]byte{peer3}},
+		},
+	}
+
+	t.Run("without prune", func(t *testing.T) {
+		plan, err := PlanReconcile(current, desired, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{desired.Nops[1]}, plan.AddNops)
+		assert.Equal(t, []NopAdminChange{{Name: "nop-1", Admin: common.HexToAddress("0x11")}}, plan.UpdateNops)
+		assert.Equal(t, uint32(3), plan.NodeOperatorIDs["nop-3"])
+		assert.Equal(t, []capabilities_registry_v2.CapabilitiesRegistryCapability{desired.Capabilities[1]}, plan.AddCapabilities)
+		assert.Equal(t, []DesiredNode{desired.Nodes[1]}, plan.AddNodes)
+		assert.Empty(t, plan.UpdateNodes)
+		assert.Equal(t, []capabilities_registry_v2.CapabilitiesRegistryNewDONParams{desired.DONs[0]}, plan.UpdateDONs)
+		assert.Equal(t, []capabilities_registry_v2.CapabilitiesRegistryNewDONParams{desired.DONs[1]}, plan.AddDONs)
+		assert.Equal(t, []DONFamiliesChange{{
+			DonName:            "don-1",
+			AddToFamilies:      []string{"family-b"},
+			RemoveFromFamilies: []string{"family-a"},
+		}}, plan.DONFamilies)
+
+		assert.Empty(t, plan.RemoveDONs)
+		assert.Empty(t, plan.RemoveNodes)
+		assert.Empty(t, plan.RemoveNops)
+		assert.Empty(t, plan.DeprecateCapabilities)
+		assert.False(t, plan.IsEmpty())
+	})
+
+	t.Run("with prune", func(t *testing.T) {
+		plan, err := PlanReconcile(current, desired, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"don-old"}, plan.RemoveDONs)
+		assert.Equal(t, [][32]byte{peer2}, plan.RemoveNodes)
+		assert.Equal(t, []string{"nop-2"}, plan.RemoveNops)
+		assert.Equal(t, []string{"old@1.0.0"}, plan.DeprecateCapabilities)
+	})
+
+	t.Run("node update and warnings", func(t *testing.T) {
+		d := DesiredRegistryState{
+			Nops: []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+				{Name: "nop-1", Admin: common.HexToAddress("0x01")},
+			},
+			Capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapability{
+				{CapabilityId: "trigger@1.0.0", Metadata: []byte(`{"changed":true}`)},
+			},
+			Nodes: []DesiredNode{
+				{NOP: "nop-1", P2PID: peer1, Signer: [32]byte{9}, CapabilityIDs: []string{"trigger@1.0.0"}},
+			},
+		}
+
+		plan, err := PlanReconcile(current, d, false)
+		require.NoError(t, err)
+		assert.Equal(t, d.Nodes, plan.UpdateNodes)
+		require.Len(t, plan.Warnings, 1)
+		assert.Contains(t, plan.Warnings[0], "capabilities cannot be updated")
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		d := DesiredRegistryState{
+			Nops: []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+				{Name: "nop-1", Admin: common.HexToAddress("0x01")},
+			},
+		}
+
+		plan, err := PlanReconcile(current, d, false)
+		require.NoError(t, err)
+		assert.True(t, plan.IsEmpty())
+	})
+
+	t.Run("unknown node operator", func(t *testing.T) {
+		d := DesiredRegistryState{
+			Nodes: []DesiredNode{{NOP: "missing", P2PID: peer3}},
+		}
+
+		_, err := PlanReconcile(current, d, false)
+		require.ErrorContains(t, err, "node operator `missing` which is neither registered nor desired")
+	})
+
+	t.Run("duplicate DON", func(t *testing.T) {
+		d := DesiredRegistryState{
+			DONs: []capabilities_registry_v2.CapabilitiesRegistryNewDONParams{{Name: "don-1"}, {Name: "don-1"}},
+		}
+
+		_, err := PlanReconcile(current, d, false)
+		require.ErrorContains(t, err, "duplicate DON don-1")
+	})
+}
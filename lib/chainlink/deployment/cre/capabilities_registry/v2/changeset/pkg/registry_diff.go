@@ -0,0 +1,174 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RegistryDiff is the structured difference between two registry snapshots, for audits.
+// Entities are matched by name, P2P ID or capability ID, since IDs are assigned by each registry.
+type RegistryDiff struct {
+	NodeOperators EntityDiff `json:"nodeOperators"`
+	Nodes         EntityDiff `json:"nodes"`
+	Capabilities  EntityDiff `json:"capabilities"`
+	DONs          EntityDiff `json:"dons"`
+	// Families compares the names of the DONs in each family.
+	Families EntityDiff `json:"families"`
+}
+
+// EntityDiff lists the entities missing on either side, and the fields that differ for entities on both sides.
+type EntityDiff struct {
+	MissingInTarget []string    `json:"missingInTarget,omitempty"`
+	MissingInSource []string    `json:"missingInSource,omitempty"`
+	Differences     []FieldDiff `json:"differences,omitempty"`
+}
+
+// FieldDiff is a field of an entity that differs between the source and the target.
+type FieldDiff struct {
+	Key    string `json:"key"`
+	Field  string `json:"field"`
+	Source any    `json:"source"`
+	Target any    `json:"target"`
+}
+
+// IsEmpty reports whether there are no differences.
+func (d EntityDiff) IsEmpty() bool {
+	return len(d.MissingInTarget) == 0 && len(d.MissingInSource) == 0 && len(d.Differences) == 0
+}
+
+// IsEmpty reports whether both registries are identical.
+func (d RegistryDiff) IsEmpty() bool {
+	return d.NodeOperators.IsEmpty() && d.Nodes.IsEmpty() && d.Capabilities.IsEmpty() && d.DONs.IsEmpty() && d.Families.IsEmpty()
+}
+
+// JSON returns the indented JSON encoding of the diff.
+func (d RegistryDiff) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// LoadRegistrySnapshot reads a registry snapshot from a JSON file, as written from RegistrySnapshot.JSON.
+func LoadRegistrySnapshot(path string) (RegistrySnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RegistrySnapshot{}, fmt.Errorf("failed to read snapshot file %s: %w", path, err)
+	}
+	var s RegistrySnapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return RegistrySnapshot{}, fmt.Errorf("failed to unmarshal snapshot file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// DiffRegistrySnapshots compares the source snapshot with the target one. Chain specific values, such as IDs,
+// config counts and the DONs a node belongs to, are not compared.
+func DiffRegistrySnapshots(source, target RegistrySnapshot) RegistryDiff {
+	return RegistryDiff{
+		NodeOperators: diffEntities(keyBy(source.NodeOperators, func(n NodeOperatorSnapshot) string { return n.Name }),
+			keyBy(target.NodeOperators, func(n NodeOperatorSnapshot) string { return n.Name }),
+			func(d *entityDiffer, a, b NodeOperatorSnapshot) {
+				d.compare("admin", a.Admin, b.Admin)
+				d.compareSets("nodes", a.Nodes, b.Nodes)
+			}),
+		Nodes: diffEntities(keyBy(source.Nodes, func(n NodeSnapshot) string { return n.P2PID }),
+			keyBy(target.Nodes, func(n NodeSnapshot) string { return n.P2PID }),
+			func(d *entityDiffer, a, b NodeSnapshot) {
+				d.compare("nodeOperatorName", a.NodeOperatorName, b.NodeOperatorName)
+				d.compare("signer", a.Signer, b.Signer)
+				d.compare("encryptionPublicKey", a.EncryptionPublicKey, b.EncryptionPublicKey)
+				d.compare("csaKey", a.CSAKey, b.CSAKey)
+				d.compareSets("capabilityIDs", a.CapabilityIDs, b.CapabilityIDs)
+			}),
+		Capabilities: diffEntities(keyBy(source.Capabilities, func(c CapabilitySnapshot) string { return c.CapabilityID }),
+			keyBy(target.Capabilities, func(c CapabilitySnapshot) string { return c.CapabilityID }),
+			func(d *entityDiffer, a, b CapabilitySnapshot) {
+				d.compare("configurationContract", a.ConfigurationContract, b.ConfigurationContract)
+				d.compare("isDeprecated", a.IsDeprecated, b.IsDeprecated)
+				d.compare("metadata", a.Metadata, b.Metadata)
+			}),
+		DONs: diffEntities(keyBy(source.DONs, func(d DONSnapshot) string { return d.Name }),
+			keyBy(target.DONs, func(d DONSnapshot) string { return d.Name }),
+			func(d *entityDiffer, a, b DONSnapshot) {
+				d.compare("f", a.F, b.F)
+				d.compare("isPublic", a.IsPublic, b.IsPublic)
+				d.compare("acceptsWorkflows", a.AcceptsWorkflows, b.AcceptsWorkflows)
+				d.compareSets("nodes", a.Nodes, b.Nodes)
+				d.compareSets("families", a.Families, b.Families)
+				d.compareConfigs("config", a.Config, b.Config)
+
+				aCfgs := keyBy(a.CapabilityConfigurations, func(c CapabilityConfigurationSnapshot) string { return c.CapabilityID })
+				bCfgs := keyBy(b.CapabilityConfigurations, func(c CapabilityConfigurationSnapshot) string { return c.CapabilityID })
+				d.compareSets("capabilityConfigurations", slices.Collect(maps.Keys(aCfgs)), slices.Collect(maps.Keys(bCfgs)))
+				for _, id := range slices.Sorted(maps.Keys(aCfgs)) {
+					if bCfg, ok := bCfgs[id]; ok {
+						d.compareConfigs("capabilityConfigurations."+id, aCfgs[id].Config, bCfg.Config)
+					}
+				}
+			}),
+		Families: diffEntities(source.Families, target.Families, func(d *entityDiffer, a, b []string) {
+			d.compareSets("dons", a, b)
+		}),
+	}
+}
+
+// entityDiffer collects the field differences of a single entity.
+type entityDiffer struct {
+	key   string
+	diffs []FieldDiff
+}
+
+func (d *entityDiffer) compare(field string, a, b any) {
+	if a != b {
+		d.diffs = append(d.diffs, FieldDiff{Key: d.key, Field: field, Source: a, Target: b})
+	}
+}
+
+func (d *entityDiffer) compareSets(field string, a, b []string) {
+	if !sameElements(a, b) {
+		d.diffs = append(d.diffs, FieldDiff{Key: d.key, Field: field, Source: slices.Sorted(slices.Values(a)), Target: slices.Sorted(slices.Values(b))})
+	}
+}
+
+// compareConfigs compares hex encoded proto configs semantically, see equalConfigs.
+func (d *entityDiffer) compareConfigs(field string, a, b string) {
+	if a == b {
+		return
+	}
+	aBytes, errA := hexutil.Decode(a)
+	bBytes, errB := hexutil.Decode(b)
+	if errA != nil || errB != nil || !equalConfigs(aBytes, bBytes) {
+		d.diffs = append(d.diffs, FieldDiff{Key: d.key, Field: field, Source: a, Target: b})
+	}
+}
+
+func keyBy[T any](items []T, key func(T) string) map[string]T {
+	out := make(map[string]T, len(items))
+	for _, item := range items {
+		out[key(item)] = item
+	}
+	return out
+}
+
+func diffEntities[T any](source, target map[string]T, compare func(d *entityDiffer, a, b T)) EntityDiff {
+	var out EntityDiff
+	for _, key := range slices.Sorted(maps.Keys(source)) {
+		b, ok := target[key]
+		if !ok {
+			out.MissingInTarget = append(out.MissingInTarget, key)
+			continue
+		}
+		d := &entityDiffer{key: key}
+		compare(d, source[key], b)
+		out.Differences = append(out.Differences, d.diffs...)
+	}
+	for _, key := range slices.Sorted(maps.Keys(target)) {
+		if _, ok := source[key]; !ok {
+			out.MissingInSource = append(out.MissingInSource, key)
+		}
+	}
+	return out
+}
@@ -0,0 +1,143 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities/pb"
+)
+
+func TestDiffRegistrySnapshots(t *testing.T) {
+	source := RegistrySnapshot{
+		ChainSelector: 1,
+		NodeOperators: []NodeOperatorSnapshot{
+			{ID: 1, Name: "nop-1", Admin: common.HexToAddress("0x01"), Nodes: []string{"p2p-1", "p2p-2"}},
+			{ID: 2, Name: "nop-2", Admin: common.HexToAddress("0x02")},
+		},
+		Nodes: []NodeSnapshot{
+			{P2PID: "p2p-1", NodeOperatorName: "nop-1", Signer: "0xaa", CapabilityIDs: []string{"a@1.0.0", "b@1.0.0"}},
+			{P2PID: "p2p-2", NodeOperatorName: "nop-1", Signer: "0xbb"},
+		},
+		Capabilities: []CapabilitySnapshot{
+			{CapabilityID: "a@1.0.0", Metadata: `{"k":"v"}`},
+			{CapabilityID: "b@1.0.0"},
+		},
+		DONs: []DONSnapshot{{
+			ID:       1,
+			Name:     "don-a",
+			F:        1,
+			Nodes:    []string{"p2p-1", "p2p-2"},
+			Families: []string{"family-a"},
+			Config:   "0x",
+			CapabilityConfigurations: []CapabilityConfigurationSnapshot{
+				{CapabilityID: "a@1.0.0", Config: "0x0a"},
+			},
+		}},
+		Families: map[string][]string{"family-a": {"don-a"}},
+	}
+
+	t.Run("identical except chain specific values", func(t *testing.T) {
+		target := source
+		target.ChainSelector = 2
+		target.NodeOperators = []NodeOperatorSnapshot{
+			{ID: 2, Name: "nop-2", Admin: common.HexToAddress("0x02")},
+			{ID: 1, Name: "nop-1", Admin: common.HexToAddress("0x01"), Nodes: []string{"p2p-2", "p2p-1"}},
+		}
+		target.DONs = []DONSnapshot{source.DONs[0]}
+		target.DONs[0].ID = 7
+		target.DONs[0].ConfigCount = 3
+
+		assert.True(t, DiffRegistrySnapshots(source, target).IsEmpty())
+	})
+
+	t.Run("differences", func(t *testing.T) {
+		target := RegistrySnapshot{
+			NodeOperators: []NodeOperatorSnapshot{
+				{ID: 1, Name: "nop-1", Admin: common.HexToAddress("0x0a"), Nodes: []string{"p2p-1", "p2p-2"}},
+				{ID: 3, Name: "nop-3", Admin: common.HexToAddress("0x03")},
+			},
+			Nodes: []NodeSnapshot{
+				{P2PID: "p2p-1", NodeOperatorName: "nop-1", Signer: "0xaa", CapabilityIDs: []string{"a@1.0.0"}},
+				{P2PID: "p2p-2", NodeOperatorName: "nop-1", Signer: "0xbb"},
+			},
+			Capabilities: []CapabilitySnapshot{
+				{CapabilityID: "a@1.0.0", Metadata: `{"k":"v"}`, IsDeprecated: true},
+			},
+			DONs: []DONSnapshot{{
+				Name:     "don-a",
+				F:        1,
+				Nodes:    []string{"p2p-1", "p2p-2"},
+				Families: []string{"family-b"},
+				Config:   "0x",
+			}},
+			Families: map[string][]string{"family-b": {"don-a"}},
+		}
+
+		diff := DiffRegistrySnapshots(source, target)
+		require.False(t, diff.IsEmpty())
+
+		assert.Equal(t, []string{"nop-2"}, diff.NodeOperators.MissingInTarget)
+		assert.Equal(t, []string{"nop-3"}, diff.NodeOperators.MissingInSource)
+		assert.Equal(t, []FieldDiff{{Key: "nop-1", Field: "admin", Source: common.HexToAddress("0x01"), Target: common.HexToAddress("0x0a")}}, diff.NodeOperators.Differences)
+
+		assert.Equal(t, []FieldDiff{{
+			Key: "p2p-1", Field: "capabilityIDs", Source: []string{"a@1.0.0", "b@1.0.0"}, Target: []string{"a@1.0.0"},
+		}}, diff.Nodes.Differences)
+
+		assert.Equal(t, []string{"b@1.0.0"}, diff.Capabilities.MissingInTarget)
+		assert.Equal(t, []FieldDiff{{Key: "a@1.0.0", Field: "isDeprecated", Source: false, Target: true}}, diff.Capabilities.Differences)
+
+		assert.Equal(t, []FieldDiff{
+			{Key: "don-a", Field: "families", Source: []string{"family-a"}, Target: []string{"family-b"}},
+			{Key: "don-a", Field: "capabilityConfigurations", Source: []string{"a@1.0.0"}, Target: []string(nil)},
+		}, diff.DONs.Differences)
+
+		assert.Equal(t, []string{"family-a"}, diff.Families.MissingInTarget)
+		assert.Equal(t, []string{"family-b"}, diff.Families.MissingInSource)
+
+		b, err := diff.JSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"missingInTarget"`)
+	})
+
+	t.Run("equivalent capability configs", func(t *testing.T) {
+		cfg, err := proto.Marshal(&pb.CapabilityConfig{LocalOnly: true})
+		require.NoError(t, err)
+
+		// Same bytes, different hex encoding
+		d := &entityDiffer{key: "don-a"}
+		d.compareConfigs("config", hexutil.Encode(cfg), "0x"+strings.ToUpper(hexutil.Encode(cfg)[2:]))
+		assert.Empty(t, d.diffs)
+
+		d.compareConfigs("config", hexutil.Encode(cfg), "0x")
+		assert.Len(t, d.diffs, 1)
+	})
+}
+
+func TestLoadRegistrySnapshot(t *testing.T) {
+	snapshot := RegistrySnapshot{
+		ChainSelector: 1,
+		BlockNumber:   10,
+		NodeOperators: []NodeOperatorSnapshot{{ID: 1, Name: "nop-1", Admin: common.HexToAddress("0x01")}},
+	}
+	b, err := snapshot.JSON()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+
+	loaded, err := LoadRegistrySnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, loaded)
+
+	_, err = LoadRegistrySnapshot(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorContains(t, err, "failed to read snapshot file")
+}
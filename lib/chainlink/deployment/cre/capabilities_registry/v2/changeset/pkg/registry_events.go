@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+)
+
+// RegistryEvents are the events emitted by a V2 Capabilities Registry contract, grouped by type.
+type RegistryEvents struct {
+	NodeOperatorsAdded     []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorAdded
+	NodeOperatorsUpdated   []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated
+	NodeOperatorsRemoved   []*capabilities_registry_v2.CapabilitiesRegistryNodeOperatorRemoved
+	NodesAdded             []*capabilities_registry_v2.CapabilitiesRegistryNodeAdded
+	NodesUpdated           []*capabilities_registry_v2.CapabilitiesRegistryNodeUpdated
+	NodesRemoved           []*capabilities_registry_v2.CapabilitiesRegistryNodeRemoved
+	CapabilitiesConfigured []*capabilities_registry_v2.CapabilitiesRegistryCapabilityConfigured
+	CapabilitiesDeprecated []*capabilities_registry_v2.CapabilitiesRegistryCapabilityDeprecated
+	ConfigsSet             []*capabilities_registry_v2.CapabilitiesRegistryConfigSet
+	DONsAddedToFamily      []*capabilities_registry_v2.CapabilitiesRegistryDONAddedToFamily
+	DONsRemovedFromFamily  []*capabilities_registry_v2.CapabilitiesRegistryDONRemovedFromFamily
+}
+
+// IsEmpty returns true if no registry event was found.
+func (e RegistryEvents) IsEmpty() bool {
+	return len(e.NodeOperatorsAdded) == 0 && len(e.NodeOperatorsUpdated) == 0 && len(e.NodeOperatorsRemoved) == 0 &&
+		len(e.NodesAdded) == 0 && len(e.NodesUpdated) == 0 && len(e.NodesRemoved) == 0 &&
+		len(e.CapabilitiesConfigured) == 0 && len(e.CapabilitiesDeprecated) == 0 &&
+		len(e.ConfigsSet) == 0 && len(e.DONsAddedToFamily) == 0 && len(e.DONsRemovedFromFamily) == 0
+}
+
+// DONIDs returns the sorted IDs of the DONs that were configured or whose families changed.
+func (e RegistryEvents) DONIDs() []uint32 {
+	var ids []uint32
+	for _, ev := range e.ConfigsSet {
+		ids = append(ids, ev.DonId)
+	}
+	for _, ev := range e.DONsAddedToFamily {
+		ids = append(ids, ev.DonId)
+	}
+	for _, ev := range e.DONsRemovedFromFamily {
+		ids = append(ids, ev.DonId)
+	}
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+// ParseRegistryLogs parses the logs emitted by the given registry, e.g. from the receipts of a timelock execution.
+// Logs emitted by other contracts, such as the timelock itself, are ignored.
+func ParseRegistryLogs(capReg *capabilities_registry_v2.CapabilitiesRegistry, logs []*types.Log) (RegistryEvents, error) {
+	var events RegistryEvents
+	for i, log := range logs {
+		if log == nil || log.Address != capReg.Address() || len(log.Topics) == 0 {
+			continue
+		}
+
+		parsed, err := capReg.ParseLog(*log)
+		if err != nil {
+			return RegistryEvents{}, fmt.Errorf("failed to parse log %d: %w", i, err)
+		}
+
+		switch ev := parsed.(type) {
+		case *capabilities_registry_v2.CapabilitiesRegistryNodeOperatorAdded:
+			events.NodeOperatorsAdded = append(events.NodeOperatorsAdded, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryNodeOperatorUpdated:
+			events.NodeOperatorsUpdated = append(events.NodeOperatorsUpdated, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryNodeOperatorRemoved:
+			events.NodeOperatorsRemoved = append(events.NodeOperatorsRemoved, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryNodeAdded:
+			events.NodesAdded = append(events.NodesAdded, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryNodeUpdated:
+			events.NodesUpdated = append(events.NodesUpdated, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryNodeRemoved:
+			events.NodesRemoved = append(events.NodesRemoved, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryCapabilityConfigured:
+			events.CapabilitiesConfigured = append(events.CapabilitiesConfigured, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryCapabilityDeprecated:
+			events.CapabilitiesDeprecated = append(events.CapabilitiesDeprecated, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryConfigSet:
+			events.ConfigsSet = append(events.ConfigsSet, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryDONAddedToFamily:
+			events.DONsAddedToFamily = append(events.DONsAddedToFamily, ev)
+		case *capabilities_registry_v2.CapabilitiesRegistryDONRemovedFromFamily:
+			events.DONsRemovedFromFamily = append(events.DONsRemovedFromFamily, ev)
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,68 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+)
+
+func TestParseRegistryLogs(t *testing.T) {
+	registryAddress := common.HexToAddress("0x01")
+	capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(registryAddress, nil)
+	require.NoError(t, err)
+
+	registryABI, err := capabilities_registry_v2.CapabilitiesRegistryMetaData.GetAbi()
+	require.NoError(t, err)
+
+	newLog := func(address common.Address, event string, indexed []any, nonIndexed ...any) *types.Log {
+		ev := registryABI.Events[event]
+		data, err := ev.Inputs.NonIndexed().Pack(nonIndexed...)
+		require.NoError(t, err)
+		query := make([][]any, len(indexed))
+		for i, v := range indexed {
+			query[i] = []any{v}
+		}
+		topics, err := abi.MakeTopics(query...)
+		require.NoError(t, err)
+		log := &types.Log{Address: address, Topics: []common.Hash{ev.ID}, Data: data}
+		for _, topic := range topics {
+			log.Topics = append(log.Topics, topic[0])
+		}
+		return log
+	}
+
+	logs := []*types.Log{
+		newLog(registryAddress, "NodeOperatorAdded", []any{uint32(1), common.HexToAddress("0x0a")}, "nop-1"),
+		newLog(registryAddress, "ConfigSet", []any{uint32(2)}, uint32(3)),
+		newLog(registryAddress, "DONAddedToFamily", []any{uint32(1), "family"}),
+		newLog(registryAddress, "DONRemovedFromFamily", []any{uint32(2), "family"}),
+		// Logs from other contracts, e.g. the timelock, are ignored
+		newLog(common.HexToAddress("0x02"), "NodeOperatorAdded", []any{uint32(9), common.HexToAddress("0x0b")}, "other"),
+		nil,
+	}
+
+	events, err := ParseRegistryLogs(capReg, logs)
+	require.NoError(t, err)
+	require.False(t, events.IsEmpty())
+
+	require.Len(t, events.NodeOperatorsAdded, 1)
+	assert.Equal(t, uint32(1), events.NodeOperatorsAdded[0].NodeOperatorId)
+	assert.Equal(t, common.HexToAddress("0x0a"), events.NodeOperatorsAdded[0].Admin)
+	assert.Equal(t, "nop-1", events.NodeOperatorsAdded[0].Name)
+
+	require.Len(t, events.ConfigsSet, 1)
+	assert.Equal(t, uint32(3), events.ConfigsSet[0].ConfigCount)
+	assert.Len(t, events.DONsAddedToFamily, 1)
+	assert.Len(t, events.DONsRemovedFromFamily, 1)
+	assert.Equal(t, []uint32{1, 2}, events.DONIDs())
+
+	empty, err := ParseRegistryLogs(capReg, logs[4:])
+	require.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+}
@@ -0,0 +1,242 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+// RegistrySnapshot is the full state of a V2 Capabilities Registry contract at a given block.
+// Byte fields are 0x prefixed hex strings and P2P IDs use the `p2p_` prefixed peer ID format, so the JSON form
+// can be diffed and audited by external tools.
+type RegistrySnapshot struct {
+	ChainSelector uint64 `json:"chainSelector"`
+	Address       string `json:"address"`
+	// BlockNumber is the block the snapshot was read at, 0 if it was read at the latest block.
+	BlockNumber uint64 `json:"blockNumber"`
+
+	NodeOperators []NodeOperatorSnapshot `json:"nodeOperators"`
+	Nodes         []NodeSnapshot         `json:"nodes"`
+	Capabilities  []CapabilitySnapshot   `json:"capabilities"`
+	DONs          []DONSnapshot          `json:"dons"`
+	// Families maps each DON family to the names of its DONs, sorted.
+	Families map[string][]string `json:"families"`
+}
+
+type NodeOperatorSnapshot struct {
+	ID    uint32         `json:"id"`
+	Name  string         `json:"name"`
+	Admin common.Address `json:"admin"`
+	Nodes []string       `json:"nodes"`
+}
+
+type NodeSnapshot struct {
+	P2PID               string   `json:"p2pID"`
+	NodeOperatorID      uint32   `json:"nodeOperatorID"`
+	NodeOperatorName    string   `json:"nodeOperatorName"`
+	ConfigCount         uint32   `json:"configCount"`
+	WorkflowDONID       uint32   `json:"workflowDONID"`
+	Signer              string   `json:"signer"`
+	EncryptionPublicKey string   `json:"encryptionPublicKey"`
+	CSAKey              string   `json:"csaKey"`
+	CapabilityIDs       []string `json:"capabilityIDs"`
+	CapabilitiesDONIDs  []uint32 `json:"capabilitiesDONIDs"`
+}
+
+type CapabilitySnapshot struct {
+	CapabilityID          string         `json:"capabilityID"`
+	ConfigurationContract common.Address `json:"configurationContract"`
+	IsDeprecated          bool           `json:"isDeprecated"`
+	// Metadata is the raw JSON metadata of the capability.
+	Metadata string `json:"metadata"`
+}
+
+type DONSnapshot struct {
+	ID                       uint32                            `json:"id"`
+	Name                     string                            `json:"name"`
+	ConfigCount              uint32                            `json:"configCount"`
+	F                        uint8                             `json:"f"`
+	IsPublic                 bool                              `json:"isPublic"`
+	AcceptsWorkflows         bool                              `json:"acceptsWorkflows"`
+	Nodes                    []string                          `json:"nodes"`
+	Families                 []string                          `json:"families"`
+	Config                   string                            `json:"config"`
+	CapabilityConfigurations []CapabilityConfigurationSnapshot `json:"capabilityConfigurations"`
+}
+
+type CapabilityConfigurationSnapshot struct {
+	CapabilityID string `json:"capabilityID"`
+	// Config is the proto encoded capability configuration.
+	Config string `json:"config"`
+}
+
+// ReadRegistrySnapshot reads all the node operators, nodes, capabilities, DONs and DON families from the registry.
+// Set opts.BlockNumber to read a consistent state across all the calls.
+func ReadRegistrySnapshot(opts *bind.CallOpts, capReg *capabilities_registry_v2.CapabilitiesRegistry) (RegistrySnapshot, error) {
+	nops, err := GetNodeOperatorsWithIDs(opts, capReg)
+	if err != nil {
+		return RegistrySnapshot{}, fmt.Errorf("failed to call GetNodeOperators: %w", err)
+	}
+	nodes, err := GetNodes(opts, capReg)
+	if err != nil {
+		return RegistrySnapshot{}, fmt.Errorf("failed to call GetNodes: %w", err)
+	}
+	caps, err := GetCapabilities(opts, capReg)
+	if err != nil {
+		return RegistrySnapshot{}, fmt.Errorf("failed to call GetCapabilities: %w", err)
+	}
+	dons, err := GetDONs(opts, capReg)
+	if err != nil {
+		return RegistrySnapshot{}, fmt.Errorf("failed to call GetDONs: %w", err)
+	}
+
+	families, err := GetDONFamilies(opts, capReg)
+	if err != nil {
+		return RegistrySnapshot{}, fmt.Errorf("failed to call GetDONFamilies: %w", err)
+	}
+	familyDONIDs := make(map[string][]uint32, len(families))
+	for _, family := range families {
+		ids, err := GetDONsInFamily(opts, capReg, family)
+		if err != nil {
+			return RegistrySnapshot{}, fmt.Errorf("failed to call GetDONsInFamily for family %s: %w", family, err)
+		}
+		for _, id := range ids {
+			familyDONIDs[family] = append(familyDONIDs[family], uint32(id.Uint64())) //nolint:gosec // G115, DON IDs are uint32 in the contract
+		}
+	}
+
+	snapshot := NewRegistrySnapshot(nops, nodes, caps, dons, familyDONIDs)
+	snapshot.Address = capReg.Address().Hex()
+	if opts != nil && opts.BlockNumber != nil {
+		snapshot.BlockNumber = opts.BlockNumber.Uint64()
+	}
+
+	return snapshot, nil
+}
+
+// NewRegistrySnapshot converts the contract types into a RegistrySnapshot.
+// familyDONIDs maps each DON family to the IDs of its DONs.
+func NewRegistrySnapshot(
+	nops []NodeOperator,
+	nodes []capabilities_registry_v2.INodeInfoProviderNodeInfo,
+	caps []capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo,
+	dons []capabilities_registry_v2.CapabilitiesRegistryDONInfo,
+	familyDONIDs map[string][]uint32,
+) RegistrySnapshot {
+	out := RegistrySnapshot{
+		NodeOperators: make([]NodeOperatorSnapshot, 0, len(nops)),
+		Nodes:         make([]NodeSnapshot, 0, len(nodes)),
+		Capabilities:  make([]CapabilitySnapshot, 0, len(caps)),
+		DONs:          make([]DONSnapshot, 0, len(dons)),
+		Families:      make(map[string][]string, len(familyDONIDs)),
+	}
+
+	nopNames := make(map[uint32]string, len(nops))
+	for _, nop := range nops {
+		nopNames[nop.ID] = nop.Name
+		out.NodeOperators = append(out.NodeOperators, NodeOperatorSnapshot{
+			ID:    nop.ID,
+			Name:  nop.Name,
+			Admin: nop.Admin,
+			Nodes: p2pIDStrings(nop.NodeP2PIDs),
+		})
+	}
+
+	for _, node := range nodes {
+		nopName := nopNames[node.NodeOperatorId]
+		donIDs := make([]uint32, 0, len(node.CapabilitiesDONIds))
+		for _, id := range node.CapabilitiesDONIds {
+			donIDs = append(donIDs, uint32(id.Uint64())) //nolint:gosec // G115, DON IDs are uint32 in the contract
+		}
+		out.Nodes = append(out.Nodes, NodeSnapshot{
+			P2PID:               p2pkey.PeerID(node.P2pId).String(),
+			NodeOperatorID:      node.NodeOperatorId,
+			NodeOperatorName:    nopName,
+			ConfigCount:         node.ConfigCount,
+			WorkflowDONID:       node.WorkflowDONId,
+			Signer:              hexutil.Encode(node.Signer[:]),
+			EncryptionPublicKey: hexutil.Encode(node.EncryptionPublicKey[:]),
+			CSAKey:              hexutil.Encode(node.CsaKey[:]),
+			CapabilityIDs:       slices.Clone(node.CapabilityIds),
+			CapabilitiesDONIDs:  donIDs,
+		})
+	}
+
+	for _, c := range caps {
+		out.Capabilities = append(out.Capabilities, CapabilitySnapshot{
+			CapabilityID:          c.CapabilityId,
+			ConfigurationContract: c.ConfigurationContract,
+			IsDeprecated:          c.IsDeprecated,
+			Metadata:              string(c.Metadata),
+		})
+	}
+
+	donNames := make(map[uint32]string, len(dons))
+	for _, don := range dons {
+		donNames[don.Id] = don.Name
+		cfgs := make([]CapabilityConfigurationSnapshot, 0, len(don.CapabilityConfigurations))
+		for _, cfg := range don.CapabilityConfigurations {
+			cfgs = append(cfgs, CapabilityConfigurationSnapshot{
+				CapabilityID: cfg.CapabilityId,
+				Config:       hexutil.Encode(cfg.Config),
+			})
+		}
+		out.DONs = append(out.DONs, DONSnapshot{
+			ID:                       don.Id,
+			Name:                     don.Name,
+			ConfigCount:              don.ConfigCount,
+			F:                        don.F,
+			IsPublic:                 don.IsPublic,
+			AcceptsWorkflows:         don.AcceptsWorkflows,
+			Nodes:                    p2pIDStrings(don.NodeP2PIds),
+			Families:                 slices.Clone(don.DonFamilies),
+			Config:                   hexutil.Encode(don.Config),
+			CapabilityConfigurations: cfgs,
+		})
+	}
+
+	for family, ids := range familyDONIDs {
+		names := make([]string, 0, len(ids))
+		for _, id := range ids {
+			name, ok := donNames[id]
+			if !ok {
+				name = fmt.Sprintf("don-%d", id)
+			}
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		out.Families[family] = names
+	}
+
+	return out
+}
+
+// JSON returns the indented JSON encoding of the snapshot.
+func (s RegistrySnapshot) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// DON returns the DON with the given name.
+func (s RegistrySnapshot) DON(name string) (DONSnapshot, bool) {
+	for _, don := range s.DONs {
+		if don.Name == name {
+			return don, true
+		}
+	}
+	return DONSnapshot{}, false
+}
+
+func p2pIDStrings(ids [][32]byte) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, p2pkey.PeerID(id).String())
+	}
+	return out
+}
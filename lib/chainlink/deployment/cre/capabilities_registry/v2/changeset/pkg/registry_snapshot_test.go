@@ -0,0 +1,81 @@
+package pkg
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+func TestNewRegistrySnapshot(t *testing.T) {
+	peerID := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PeerID()
+
+	nops := []NodeOperator{
+		{ID: 1, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Admin: common.HexToAddress("0x01"), Name: "nop-1", NodeP2PIDs: [][32]byte{peerID}}},
+		{ID: 2, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Admin: common.HexToAddress("0x02"), Name: "nop-2"}},
+	}
+	nodes := []capabilities_registry_v2.INodeInfoProviderNodeInfo{{
+		NodeOperatorId:     1,
+		ConfigCount:        1,
+		Signer:             [32]byte{0x01},
+		P2pId:              peerID,
+		CapabilityIds:      []string{"trigger@1.0.0"},
+		CapabilitiesDONIds: []*big.Int{big.NewInt(1)},
+	}}
+	caps := []capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo{
+		{CapabilityId: "trigger@1.0.0", Metadata: []byte(`{"k":"v"}`)},
+	}
+	dons := []capabilities_registry_v2.CapabilitiesRegistryDONInfo{
+		{
+			Id:          1,
+			ConfigCount: 2,
+			F:           1,
+			NodeP2PIds:  [][32]byte{peerID},
+			DonFamilies: []string{"family-a"},
+			Name:        "don-a",
+			Config:      []byte{0xab},
+			CapabilityConfigurations: []capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration{
+				{CapabilityId: "trigger@1.0.0", Config: []byte{0x0a}},
+			},
+		},
+		{Id: 2, Name: "don-b", DonFamilies: []string{"family-a"}},
+	}
+	familyDONIDs := map[string][]uint32{"family-a": {2, 1}}
+
+	snapshot := NewRegistrySnapshot(nops, nodes, caps, dons, familyDONIDs)
+
+	require.Len(t, snapshot.NodeOperators, 2)
+	assert.Equal(t, uint32(2), snapshot.NodeOperators[1].ID)
+	assert.Equal(t, []string{peerID.String()}, snapshot.NodeOperators[0].Nodes)
+
+	require.Len(t, snapshot.Nodes, 1)
+	assert.Equal(t, "nop-1", snapshot.Nodes[0].NodeOperatorName)
+	assert.Equal(t, peerID.String(), snapshot.Nodes[0].P2PID)
+	assert.Equal(t, []uint32{1}, snapshot.Nodes[0].CapabilitiesDONIDs)
+	assert.Equal(t, "0x01"+strings.Repeat("00", 31), snapshot.Nodes[0].Signer)
+
+	require.Len(t, snapshot.Capabilities, 1)
+	assert.JSONEq(t, `{"k":"v"}`, snapshot.Capabilities[0].Metadata)
+
+	don, ok := snapshot.DON("don-a")
+	require.True(t, ok)
+	assert.Equal(t, "0xab", don.Config)
+	assert.Equal(t, []CapabilityConfigurationSnapshot{{CapabilityID: "trigger@1.0.0", Config: "0x0a"}}, don.CapabilityConfigurations)
+	_, ok = snapshot.DON("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, map[string][]string{"family-a": {"don-a", "don-b"}}, snapshot.Families)
+
+	b, err := snapshot.JSON()
+	require.NoError(t, err)
+	var decoded RegistrySnapshot
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, snapshot, decoded)
+}
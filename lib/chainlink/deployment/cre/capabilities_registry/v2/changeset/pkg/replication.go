@@ -0,0 +1,110 @@
+package pkg
+
+import (
+	"fmt"
+	"slices"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+// DesiredStateFromRegistry returns the desired state replicating the given registry state on another registry.
+// Node operators and DONs are sorted by ID, so that they get the same IDs when added to an empty registry, unless
+// some of them were removed from the source registry.
+// Deprecated capabilities are only replicated when nodes or DONs still reference them.
+func DesiredStateFromRegistry(state RegistryState) (DesiredRegistryState, error) {
+	var out DesiredRegistryState
+
+	nops := slices.Clone(state.Nops)
+	slices.SortFunc(nops, func(a, b NodeOperator) int {
+		return int(a.ID) - int(b.ID)
+	})
+	nopNames := make(map[uint32]string, len(nops))
+	for _, nop := range nops {
+		nopNames[nop.ID] = nop.Name
+		out.Nops = append(out.Nops, capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+			Admin: nop.Admin,
+			Name:  nop.Name,
+		})
+	}
+
+	referenced := make(map[string]struct{})
+	for _, node := range state.Nodes {
+		nopName, ok := nopNames[node.NodeOperatorId]
+		if !ok {
+			return DesiredRegistryState{}, fmt.Errorf("node %s references unknown node operator %d", p2pkey.PeerID(node.P2pId), node.NodeOperatorId)
+		}
+		for _, id := range node.CapabilityIds {
+			referenced[id] = struct{}{}
+		}
+		out.Nodes = append(out.Nodes, DesiredNode{
+			NOP:                 nopName,
+			Signer:              node.Signer,
+			P2PID:               node.P2pId,
+			EncryptionPublicKey: node.EncryptionPublicKey,
+			CSAKey:              node.CsaKey,
+			CapabilityIDs:       slices.Clone(node.CapabilityIds),
+		})
+	}
+
+	dons := slices.Clone(state.DONs)
+	slices.SortFunc(dons, func(a, b capabilities_registry_v2.CapabilitiesRegistryDONInfo) int {
+		return int(a.Id) - int(b.Id)
+	})
+	for _, don := range dons {
+		for _, cfg := range don.CapabilityConfigurations {
+			referenced[cfg.CapabilityId] = struct{}{}
+		}
+		out.DONs = append(out.DONs, capabilities_registry_v2.CapabilitiesRegistryNewDONParams{
+			Name:                     don.Name,
+			DonFamilies:              slices.Clone(don.DonFamilies),
+			Config:                   don.Config,
+			CapabilityConfigurations: slices.Clone(don.CapabilityConfigurations),
+			Nodes:                    slices.Clone(don.NodeP2PIds),
+			F:                        don.F,
+			IsPublic:                 don.IsPublic,
+			AcceptsWorkflows:         don.AcceptsWorkflows,
+		})
+	}
+
+	for _, c := range state.Capabilities {
+		if _, ok := referenced[c.CapabilityId]; c.IsDeprecated && !ok {
+			continue
+		}
+		out.Capabilities = append(out.Capabilities, capabilities_registry_v2.CapabilitiesRegistryCapability{
+			CapabilityId:          c.CapabilityId,
+			ConfigurationContract: c.ConfigurationContract,
+			Metadata:              c.Metadata,
+		})
+	}
+
+	return out, nil
+}
+
+// RegistryIDMismatches lists the node operators and DONs of the source registry whose ID differs in the replica.
+// The replica IDs are keyed by name, and names missing from them are ignored.
+func RegistryIDMismatches(source RegistryState, nopIDs map[string]uint32, donIDs map[string]uint32) []string {
+	var out []string
+	for name, id := range NodeOperatorIDsByName(source.Nops) {
+		if replicaID, ok := nopIDs[name]; ok && replicaID != id {
+			out = append(out, fmt.Sprintf("node operator `%s` has ID %d on the source registry and %d on the replica", name, id, replicaID))
+		}
+	}
+	for _, don := range source.DONs {
+		if replicaID, ok := donIDs[don.Name]; ok && replicaID != don.Id {
+			out = append(out, fmt.Sprintf("DON %s has ID %d on the source registry and %d on the replica", don.Name, don.Id, replicaID))
+		}
+	}
+	slices.Sort(out)
+
+	return out
+}
+
+// DONIDsByName maps the name of each DON to its ID.
+func DONIDsByName(dons []capabilities_registry_v2.CapabilitiesRegistryDONInfo) map[string]uint32 {
+	out := make(map[string]uint32, len(dons))
+	for _, don := range dons {
+		out[don.Name] = don.Id
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package pkg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+func TestDesiredStateFromRegistry(t *testing.T) {
+	peer1 := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PeerID()
+
+	source := RegistryState{
+		Nops: []NodeOperator{
+			{ID: 1, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Name: "nop-1", Admin: common.HexToAddress("0x01")}},
+			{ID: 2, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Name: "nop-2", Admin: common.HexToAddress("0x02")}},
+		},
+		Capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo{
+			{CapabilityId: "trigger@1.0.0", Metadata: []byte(`{}`)},
+			{CapabilityId: "old@1.0.0", IsDeprecated: true},
+			{CapabilityId: "used@1.0.0", IsDeprecated: true},
+		},
+		Nodes: []capabilities_registry_v2.INodeInfoProviderNodeInfo{
+			{NodeOperatorId: 2, P2pId: peer1, CsaKey: [32]byte{0x01}, CapabilityIds: []string{"trigger@1.0.0", "used@1.0.0"}},
+		},
+		DONs: []capabilities_registry_v2.CapabilitiesRegistryDONInfo{
+			{Id: 3, Name: "don-b", F: 1, NodeP2PIds: [][32]byte{peer1}},
+			{Id: 1, Name: "don-a", F: 1, DonFamilies: []string{"family"}, NodeP2PIds: [][32]byte{peer1}},
+		},
+	}
+
+	desired, err := DesiredStateFromRegistry(source)
+	require.NoError(t, err)
+
+	assert.Equal(t, []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+		{Name: "nop-1", Admin: common.HexToAddress("0x01")},
+		{Name: "nop-2", Admin: common.HexToAddress("0x02")},
+	}, desired.Nops)
+
+	require.Len(t, desired.Capabilities, 2, "unreferenced deprecated capabilities are not replicated")
+	assert.Equal(t, "trigger@1.0.0", desired.Capabilities[0].CapabilityId)
+	assert.Equal(t, "used@1.0.0", desired.Capabilities[1].CapabilityId)
+
+	require.Len(t, desired.Nodes, 1)
+	assert.Equal(t, "nop-2", desired.Nodes[0].NOP)
+	assert.Equal(t, [32]byte{0x01}, desired.Nodes[0].CSAKey)
+
+	require.Len(t, desired.DONs, 2)
+	assert.Equal(t, "don-a", desired.DONs[0].Name, "DONs are in ID order")
+	assert.Equal(t, []string{"family"}, desired.DONs[0].DonFamilies)
+	assert.Equal(t, "don-b", desired.DONs[1].Name)
+
+	// Replicating onto an empty registry is a full copy
+	plan, err := PlanReconcile(RegistryState{}, desired, false)
+	require.NoError(t, err)
+	assert.Empty(t, RegistryIDMismatches(source, plan.NodeOperatorIDs, nil))
+
+	t.Run("unknown node operator", func(t *testing.T) {
+		s := source
+		s.Nops = s.Nops[:1]
+		_, err := DesiredStateFromRegistry(s)
+		require.ErrorContains(t, err, "references unknown node operator 2")
+	})
+}
+
+func TestRegistryIDMismatches(t *testing.T) {
+	source := RegistryState{
+		Nops: []NodeOperator{
+			{ID: 1, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Name: "nop-1"}},
+			{ID: 2, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Name: "nop-2"}},
+		},
+		DONs: []capabilities_registry_v2.CapabilitiesRegistryDONInfo{{Id: 1, Name: "don-a"}, {Id: 2, Name: "don-b"}},
+	}
+
+	mismatches := RegistryIDMismatches(source,
+		map[string]uint32{"nop-1": 1, "nop-2": 3},
+		DONIDsByName([]capabilities_registry_v2.CapabilitiesRegistryDONInfo{{Id: 2, Name: "don-a"}}),
+	)
+	assert.Equal(t, []string{
+		"DON don-a has ID 1 on the source registry and 2 on the replica",
+		"node operator `nop-2` has ID 2 on the source registry and 3 on the replica",
+	}, mismatches)
+}
@@ -0,0 +1,264 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	capabilities_registry_v1 "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/capabilities_registry_1_1_0"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+// V1RegistryState is the state of a V1 Capabilities Registry contract, as returned by the contract getters.
+type V1RegistryState struct {
+	Nops         []V1NodeOperator
+	Nodes        []capabilities_registry_v1.INodeInfoProviderNodeInfo
+	Capabilities []capabilities_registry_v1.CapabilitiesRegistryCapabilityInfo
+	DONs         []capabilities_registry_v1.CapabilitiesRegistryDONInfo
+}
+
+// V1NodeOperator is a node operator of a V1 registry with its ID, which the contract getters do not return.
+type V1NodeOperator struct {
+	ID uint32
+	capabilities_registry_v1.CapabilitiesRegistryNodeOperator
+}
+
+// ReadV1RegistryState reads all the node operators, nodes, capabilities and DONs of a V1 Capabilities Registry.
+func ReadV1RegistryState(capReg *capabilities_registry_v1.CapabilitiesRegistry) (V1RegistryState, error) {
+	nops, err := capReg.GetNodeOperators(nil)
+	if err != nil {
+		return V1RegistryState{}, fmt.Errorf("failed to call GetNodeOperators: %w", err)
+	}
+	// GetNodeOperators skips the removed node operators, so their position is not their ID
+	ids, err := nodeOperatorIDs(nops, func(id uint32) (capabilities_registry_v1.CapabilitiesRegistryNodeOperator, error) {
+		return capReg.GetNodeOperator(nil, id)
+	}, func(nop capabilities_registry_v1.CapabilitiesRegistryNodeOperator) string {
+		return nop.Admin.Hex() + "/" + nop.Name
+	})
+	if err != nil {
+		return V1RegistryState{}, fmt.Errorf("failed to call GetNodeOperator: %w", err)
+	}
+	nopsWithIDs := make([]V1NodeOperator, len(nops))
+	for i, nop := range nops {
+		nopsWithIDs[i] = V1NodeOperator{ID: ids[i], CapabilitiesRegistryNodeOperator: nop}
+	}
+	nodes, err := capReg.GetNodes(nil)
+	if err != nil {
+		return V1RegistryState{}, fmt.Errorf("failed to call GetNodes: %w", err)
+	}
+	caps, err := capReg.GetCapabilities(nil)
+	if err != nil {
+		return V1RegistryState{}, fmt.Errorf("failed to call GetCapabilities: %w", err)
+	}
+	dons, err := capReg.GetDONs(nil)
+	if err != nil {
+		return V1RegistryState{}, fmt.Errorf("failed to call GetDONs: %w", err)
+	}
+
+	return V1RegistryState{
+		Nops:         nopsWithIDs,
+		Nodes:        nodes,
+		Capabilities: caps,
+		DONs:         dons,
+	}, nil
+}
+
+// V1DONMapping configures how a V1 DON, which has no name nor families, is registered in V2.
+type V1DONMapping struct {
+	Name     string   `json:"name" yaml:"name"`
+	Families []string `json:"families" yaml:"families"`
+}
+
+// V1MigrationRules configures the mapping of a V1 registry to V2.
+type V1MigrationRules struct {
+	// DONs maps V1 DON IDs to their V2 name and families. DONs without mapping are named `don-<id>`.
+	DONs map[uint32]V1DONMapping `json:"dons" yaml:"dons"`
+	// DefaultFamilies are added to every DON.
+	DefaultFamilies []string `json:"defaultFamilies" yaml:"defaultFamilies"`
+	// WorkflowDONFamilies are added to DONs accepting workflows.
+	WorkflowDONFamilies []string `json:"workflowDONFamilies" yaml:"workflowDONFamilies"`
+	// CSAKeys maps the P2P ID of each node to its CSA key, since V1 does not store CSA keys. Required for all nodes.
+	CSAKeys map[string]string `json:"csaKeys" yaml:"csaKeys"`
+	// DONConfig is the encoded config of every migrated DON, since V1 DONs have no config.
+	DONConfig []byte `json:"-" yaml:"-"`
+}
+
+// V1CapabilityID returns the V2 ID of a V1 capability.
+func V1CapabilityID(c capabilities_registry_v1.CapabilitiesRegistryCapabilityInfo) string {
+	return c.LabelledName + "@" + c.Version
+}
+
+// MapV1Registry maps the state of a V1 registry into the desired state of a V2 registry.
+// Deprecated V1 capabilities are only migrated when nodes or DONs still reference them.
+func MapV1Registry(v1 V1RegistryState, rules V1MigrationRules) (DesiredRegistryState, error) {
+	var out DesiredRegistryState
+
+	nopNames := make(map[uint32]string, len(v1.Nops))
+	for _, nop := range v1.Nops {
+		if slices.ContainsFunc(out.Nops, func(n capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams) bool {
+			return n.Name == nop.Name
+		}) {
+			return DesiredRegistryState{}, fmt.Errorf("node operator name `%s` is not unique in V1, V2 node operators are identified by name", nop.Name)
+		}
+		nopNames[nop.ID] = nop.Name
+		out.Nops = append(out.Nops, capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+			Admin: nop.Admin,
+			Name:  nop.Name,
+		})
+	}
+
+	capIDs := make(map[[32]byte]string, len(v1.Capabilities))
+	referenced := make(map[[32]byte]struct{})
+	for _, c := range v1.Capabilities {
+		capIDs[c.HashedId] = V1CapabilityID(c)
+	}
+	resolveCapability := func(hashedID [32]byte) (string, error) {
+		id, ok := capIDs[hashedID]
+		if !ok {
+			return "", fmt.Errorf("capability %x not found in V1", hashedID)
+		}
+		referenced[hashedID] = struct{}{}
+		return id, nil
+	}
+
+	for _, node := range v1.Nodes {
+		p2pID := p2pkey.PeerID(node.P2pId).String()
+		nopName, ok := nopNames[node.NodeOperatorId]
+		if !ok {
+			return DesiredRegistryState{}, fmt.Errorf("node %s references unknown node operator %d", p2pID, node.NodeOperatorId)
+		}
+		csaKeyHex, ok := rules.CSAKeys[p2pID]
+		if !ok {
+			return DesiredRegistryState{}, fmt.Errorf("missing CSA key for node %s", p2pID)
+		}
+		csaKey, err := HexStringTo32Bytes(csaKeyHex)
+		if err != nil {
+			return DesiredRegistryState{}, fmt.Errorf("invalid CSA key for node %s: %w", p2pID, err)
+		}
+
+		ids := make([]string, 0, len(node.HashedCapabilityIds))
+		for _, hashedID := range node.HashedCapabilityIds {
+			id, err := resolveCapability(hashedID)
+			if err != nil {
+				return DesiredRegistryState{}, fmt.Errorf("node %s: %w", p2pID, err)
+			}
+			ids = append(ids, id)
+		}
+
+		out.Nodes = append(out.Nodes, DesiredNode{
+			NOP:                 nopName,
+			Signer:              node.Signer,
+			P2PID:               node.P2pId,
+			EncryptionPublicKey: node.EncryptionPublicKey,
+			CSAKey:              csaKey,
+			CapabilityIDs:       ids,
+		})
+	}
+
+	for _, don := range v1.DONs {
+		mapping, ok := rules.DONs[don.Id]
+		if !ok || mapping.Name == "" {
+			mapping.Name = fmt.Sprintf("don-%d", don.Id)
+		}
+		if slices.ContainsFunc(out.DONs, func(d capabilities_registry_v2.CapabilitiesRegistryNewDONParams) bool {
+			return d.Name == mapping.Name
+		}) {
+			return DesiredRegistryState{}, fmt.Errorf("duplicate V2 DON name %s", mapping.Name)
+		}
+
+		families := slices.Clone(mapping.Families)
+		families = append(families, rules.DefaultFamilies...)
+		if don.AcceptsWorkflows {
+			families = append(families, rules.WorkflowDONFamilies...)
+		}
+		slices.Sort(families)
+		families = slices.Compact(families)
+
+		cfgs := make([]capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration, 0, len(don.CapabilityConfigurations))
+		for _, cfg := range don.CapabilityConfigurations {
+			id, err := resolveCapability(cfg.CapabilityId)
+			if err != nil {
+				return DesiredRegistryState{}, fmt.Errorf("DON %d: %w", don.Id, err)
+			}
+			cfgs = append(cfgs, capabilities_registry_v2.CapabilitiesRegistryCapabilityConfiguration{
+				CapabilityId: id,
+				Config:       cfg.Config,
+			})
+		}
+
+		out.DONs = append(out.DONs, capabilities_registry_v2.CapabilitiesRegistryNewDONParams{
+			Name:                     mapping.Name,
+			DonFamilies:              families,
+			Config:                   rules.DONConfig,
+			CapabilityConfigurations: cfgs,
+			Nodes:                    slices.Clone(don.NodeP2PIds),
+			F:                        don.F,
+			IsPublic:                 don.IsPublic,
+			AcceptsWorkflows:         don.AcceptsWorkflows,
+		})
+	}
+
+	for _, c := range v1.Capabilities {
+		if _, ok := referenced[c.HashedId]; c.IsDeprecated && !ok {
+			continue
+		}
+		metadata, err := json.Marshal(map[string]any{
+			"capabilityType": c.CapabilityType,
+			"responseType":   c.ResponseType,
+		})
+		if err != nil {
+			return DesiredRegistryState{}, fmt.Errorf("failed to marshal metadata of capability %s: %w", V1CapabilityID(c), err)
+		}
+		out.Capabilities = append(out.Capabilities, capabilities_registry_v2.CapabilitiesRegistryCapability{
+			CapabilityId:          V1CapabilityID(c),
+			ConfigurationContract: c.ConfigurationContract,
+			Metadata:              metadata,
+		})
+	}
+
+	return out, nil
+}
+
+// VerifyV1Migration compares a V1 registry with the V2 registry it was migrated to, using the same rules.
+// It fails if any node operator, node, capability or DON of V1 is missing or different in V2.
+func VerifyV1Migration(v1 V1RegistryState, v2 RegistryState, rules V1MigrationRules) error {
+	desired, err := MapV1Registry(v1, rules)
+	if err != nil {
+		return fmt.Errorf("failed to map V1 registry: %w", err)
+	}
+
+	plan, err := PlanReconcile(v2, desired, false)
+	if err != nil {
+		return fmt.Errorf("failed to diff registries: %w", err)
+	}
+
+	var errs []error
+	for _, nop := range plan.AddNops {
+		errs = append(errs, fmt.Errorf("node operator `%s` is missing in V2", nop.Name))
+	}
+	for _, nop := range plan.UpdateNops {
+		errs = append(errs, fmt.Errorf("node operator `%s` has a different admin in V2", nop.Name))
+	}
+	for _, c := range plan.AddCapabilities {
+		errs = append(errs, fmt.Errorf("capability %s is missing in V2", c.CapabilityId))
+	}
+	for _, n := range plan.AddNodes {
+		errs = append(errs, fmt.Errorf("node %s is missing in V2", p2pkey.PeerID(n.P2PID)))
+	}
+	for _, n := range plan.UpdateNodes {
+		errs = append(errs, fmt.Errorf("node %s differs in V2", p2pkey.PeerID(n.P2PID)))
+	}
+	for _, don := range plan.AddDONs {
+		errs = append(errs, fmt.Errorf("DON %s is missing in V2", don.Name))
+	}
+	for _, don := range plan.UpdateDONs {
+		errs = append(errs, fmt.Errorf("DON %s differs in V2", don.Name))
+	}
+	for _, change := range plan.DONFamilies {
+		errs = append(errs, fmt.Errorf("DON %s has different families in V2", change.DonName))
+	}
+
+	return errors.Join(errs...)
+}
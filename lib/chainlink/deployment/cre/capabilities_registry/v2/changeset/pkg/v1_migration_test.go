@@ -0,0 +1,128 @@
+package pkg
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capabilities_registry_v1 "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/capabilities_registry_1_1_0"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+func TestMapV1Registry(t *testing.T) {
+	peer1 := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PeerID()
+	peer2 := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(2)).PeerID()
+	trigger := [32]byte{0x01}
+	deprecated := [32]byte{0x02}
+
+	v1 := V1RegistryState{
+		Nops: []V1NodeOperator{
+			{ID: 1, CapabilitiesRegistryNodeOperator: capabilities_registry_v1.CapabilitiesRegistryNodeOperator{Admin: common.HexToAddress("0x01"), Name: "nop-1"}},
+		},
+		Capabilities: []capabilities_registry_v1.CapabilitiesRegistryCapabilityInfo{
+			{HashedId: trigger, LabelledName: "trigger", Version: "1.0.0", CapabilityType: 0},
+			{HashedId: deprecated, LabelledName: "old", Version: "1.0.0", IsDeprecated: true},
+		},
+		Nodes: []capabilities_registry_v1.INodeInfoProviderNodeInfo{
+			{NodeOperatorId: 1, P2pId: peer1, Signer: [32]byte{1}, HashedCapabilityIds: [][32]byte{trigger}},
+			{NodeOperatorId: 1, P2pId: peer2, Signer: [32]byte{2}, HashedCapabilityIds: [][32]byte{trigger}},
+		},
+		DONs: []capabilities_registry_v1.CapabilitiesRegistryDONInfo{
+			{
+				Id:               1,
+				F:                1,
+				IsPublic:         true,
+				AcceptsWorkflows: true,
+				NodeP2PIds:       [][32]byte{peer1, peer2},
+				CapabilityConfigurations: []capabilities_registry_v1.CapabilitiesRegistryCapabilityConfiguration{
+					{CapabilityId: trigger, Config: []byte{0x0a}},
+				},
+			},
+			{Id: 2, F: 1, NodeP2PIds: [][32]byte{peer1, peer2}},
+		},
+	}
+	rules := V1MigrationRules{
+		DONs:                map[uint32]V1DONMapping{1: {Name: "workflow", Families: []string{"zone-a"}}},
+		DefaultFamilies:     []string{"all"},
+		WorkflowDONFamilies: []string{"workflow", "zone-a"},
+		CSAKeys: map[string]string{
+			peer1.String(): "0x" + strings.Repeat("11", 32),
+			peer2.String(): strings.Repeat("22", 32),
+		},
+		DONConfig: []byte{0xff},
+	}
+
+	desired, err := MapV1Registry(v1, rules)
+	require.NoError(t, err)
+
+	assert.Equal(t, []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{{Admin: common.HexToAddress("0x01"), Name: "nop-1"}}, desired.Nops)
+
+	require.Len(t, desired.Capabilities, 1, "unreferenced deprecated capabilities are not migrated")
+	assert.Equal(t, "trigger@1.0.0", desired.Capabilities[0].CapabilityId)
+	assert.JSONEq(t, `{"capabilityType":0,"responseType":0}`, string(desired.Capabilities[0].Metadata))
+
+	require.Len(t, desired.Nodes, 2)
+	assert.Equal(t, "nop-1", desired.Nodes[0].NOP)
+	assert.Equal(t, []string{"trigger@1.0.0"}, desired.Nodes[0].CapabilityIDs)
+	assert.Equal(t, byte(0x22), desired.Nodes[1].CSAKey[0])
+
+	require.Len(t, desired.DONs, 2)
+	assert.Equal(t, "workflow", desired.DONs[0].Name)
+	assert.Equal(t, []string{"all", "workflow", "zone-a"}, desired.DONs[0].DonFamilies)
+	assert.Equal(t, []byte{0xff}, desired.DONs[0].Config)
+	assert.Equal(t, "trigger@1.0.0", desired.DONs[0].CapabilityConfigurations[0].CapabilityId)
+	assert.Equal(t, "don-2", desired.DONs[1].Name)
+	assert.Equal(t, []string{"all"}, desired.DONs[1].DonFamilies)
+
+	t.Run("missing CSA key", func(t *testing.T) {
+		r := rules
+		r.CSAKeys = map[string]string{peer1.String(): strings.Repeat("11", 32)}
+		_, err := MapV1Registry(v1, r)
+		require.ErrorContains(t, err, "missing CSA key for node "+peer2.String())
+	})
+
+	t.Run("duplicate DON name", func(t *testing.T) {
+		r := rules
+		r.DONs = map[uint32]V1DONMapping{1: {Name: "don-2"}}
+		_, err := MapV1Registry(v1, r)
+		require.ErrorContains(t, err, "duplicate V2 DON name don-2")
+	})
+}
+
+func TestVerifyV1Migration(t *testing.T) {
+	peer1 := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PeerID()
+	csaKey := [32]byte{0x11}
+
+	v1 := V1RegistryState{
+		Nops: []V1NodeOperator{{ID: 1, CapabilitiesRegistryNodeOperator: capabilities_registry_v1.CapabilitiesRegistryNodeOperator{Name: "nop-1"}}},
+		Capabilities: []capabilities_registry_v1.CapabilitiesRegistryCapabilityInfo{
+			{HashedId: [32]byte{0x01}, LabelledName: "trigger", Version: "1.0.0"},
+		},
+		Nodes: []capabilities_registry_v1.INodeInfoProviderNodeInfo{
+			{NodeOperatorId: 1, P2pId: peer1, HashedCapabilityIds: [][32]byte{{0x01}}},
+		},
+		DONs: []capabilities_registry_v1.CapabilitiesRegistryDONInfo{{Id: 1, NodeP2PIds: [][32]byte{peer1}}},
+	}
+	rules := V1MigrationRules{CSAKeys: map[string]string{peer1.String(): common.Bytes2Hex(csaKey[:])}}
+
+	v2 := RegistryState{
+		Nops: []NodeOperator{{ID: 1, CapabilitiesRegistryNodeOperatorInfo: capabilities_registry_v2.CapabilitiesRegistryNodeOperatorInfo{Name: "nop-1"}}},
+		Capabilities: []capabilities_registry_v2.CapabilitiesRegistryCapabilityInfo{
+			{CapabilityId: "trigger@1.0.0", Metadata: []byte(`{"capabilityType":0,"responseType":0}`)},
+		},
+		Nodes: []capabilities_registry_v2.INodeInfoProviderNodeInfo{
+			{NodeOperatorId: 1, P2pId: peer1, CsaKey: csaKey, CapabilityIds: []string{"trigger@1.0.0"}},
+		},
+		DONs: []capabilities_registry_v2.CapabilitiesRegistryDONInfo{{Name: "don-1", NodeP2PIds: [][32]byte{peer1}}},
+	}
+
+	require.NoError(t, VerifyV1Migration(v1, v2, rules))
+
+	v2.DONs = nil
+	require.ErrorContains(t, VerifyV1Migration(v1, v2, rules), "DON don-1 is missing in V2")
+}
@@ -0,0 +1,204 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/sequences"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	crecontracts "github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+var _ cldf.ChangeSetV2[ReconcileCapabilitiesRegistryInput] = ReconcileCapabilitiesRegistry{}
+
+// CapabilitiesRegistryDesiredState is the full desired state of the capabilities registry.
+// DON families are part of the DON definitions.
+type CapabilitiesRegistryDesiredState struct {
+	Nops         []CapabilitiesRegistryNodeOperator `json:"nops" yaml:"nops"`
+	Capabilities []CapabilitiesRegistryCapability   `json:"capabilities" yaml:"capabilities"`
+	Nodes        []CapabilitiesRegistryNodeParams   `json:"nodes" yaml:"nodes"`
+	DONs         []CapabilitiesRegistryNewDONParams `json:"dons" yaml:"dons"`
+}
+
+func (s CapabilitiesRegistryDesiredState) ToPkg() (pkg.DesiredRegistryState, error) {
+	out := pkg.DesiredRegistryState{
+		Nops:         make([]capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams, 0, len(s.Nops)),
+		Capabilities: make([]capabilities_registry_v2.CapabilitiesRegistryCapability, 0, len(s.Capabilities)),
+		Nodes:        make([]pkg.DesiredNode, 0, len(s.Nodes)),
+		DONs:         make([]capabilities_registry_v2.CapabilitiesRegistryNewDONParams, 0, len(s.DONs)),
+	}
+
+	for _, nop := range s.Nops {
+		out.Nops = append(out.Nops, nop.ToWrapper())
+	}
+	for i, c := range s.Capabilities {
+		wc, err := c.ToWrapper()
+		if err != nil {
+			return pkg.DesiredRegistryState{}, fmt.Errorf("failed to convert capability %d: %w", i, err)
+		}
+		out.Capabilities = append(out.Capabilities, wc)
+	}
+	for i, node := range s.Nodes {
+		n, err := node.ToWrapper()
+		if err != nil {
+			return pkg.DesiredRegistryState{}, fmt.Errorf("failed to convert node %d: %w", i, err)
+		}
+		out.Nodes = append(out.Nodes, pkg.DesiredNode{
+			NOP:                 n.NOP,
+			Signer:              n.Signer,
+			P2PID:               n.P2pID,
+			EncryptionPublicKey: n.EncryptionPublicKey,
+			CSAKey:              n.CsaKey,
+			CapabilityIDs:       n.CapabilityIDs,
+		})
+	}
+	for i, don := range s.DONs {
+		d, err := don.ToWrapper()
+		if err != nil {
+			return pkg.DesiredRegistryState{}, fmt.Errorf("failed to convert DON %d: %w", i, err)
+		}
+		out.DONs = append(out.DONs, d)
+	}
+
+	return out, nil
+}
+
+type ReconcileCapabilitiesRegistryInput struct {
+	ChainSelector uint64                           `json:"chainSelector" yaml:"chainSelector"`
+	Qualifier     string                           `json:"qualifier" yaml:"qualifier"`
+	DesiredState  CapabilitiesRegistryDesiredState `json:"desiredState" yaml:"desiredState"`
+
+	// Prune removes the DONs, nodes and node operators missing from the desired state, and deprecates the missing capabilities.
+	Prune bool `json:"prune,omitempty" yaml:"prune,omitempty"`
+	// Force updates and removes DONs accepting workflows, and deprecates capabilities still used by DONs.
+	Force bool `json:"force,omitempty" yaml:"force,omitempty"`
+
+	// NonceManagement assigns the nonces of direct transactions locally, so concurrent changesets don't collide.
+	NonceManagement bool `json:"nonceManagement,omitempty" yaml:"nonceManagement,omitempty"`
+	// Replacement replaces direct transactions not mined in time with bumped fees. The replacements are reported
+	// in the sequence output.
+	Replacement *strategies.ReplacementConfig `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+	// Retry retries the calls on transient errors, checking the registry before each retry so that calls which
+	// landed are not applied twice.
+	Retry *strategies.RetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
+
+	MCMSConfig *crecontracts.MCMSConfig `json:"mcmsConfig,omitempty" yaml:"mcmsConfig,omitempty"`
+	// SimulateProposal executes the MCMS proposal on an anvil fork of the registry chain, and fails if any call
+	// reverts. The simulation report is part of the changeset reports.
+	SimulateProposal bool `json:"simulateProposal,omitempty" yaml:"simulateProposal,omitempty"`
+	// ForkRPCURL is the RPC URL of the anvil fork the proposal is simulated on, started with --auto-impersonate.
+	ForkRPCURL string `json:"forkRPCURL,omitempty" yaml:"forkRPCURL,omitempty"`
+}
+
+// ReconcileCapabilitiesRegistry converges the capabilities registry to a desired state, adding, updating and
+// optionally removing node operators, capabilities, nodes and DONs. With MCMS, all the changes are in a single proposal.
+type ReconcileCapabilitiesRegistry struct{}
+
+func (l ReconcileCapabilitiesRegistry) VerifyPreconditions(e cldf.Environment, config ReconcileCapabilitiesRegistryInput) error {
+	if config.Qualifier == "" {
+		return errors.New("qualifier must be provided")
+	}
+	if _, ok := e.BlockChains.EVMChains()[config.ChainSelector]; !ok {
+		return fmt.Errorf("chain %d not found in environment", config.ChainSelector)
+	}
+	if _, err := config.DesiredState.ToPkg(); err != nil {
+		return fmt.Errorf("invalid desired state: %w", err)
+	}
+	if config.Replacement != nil {
+		if err := config.Replacement.Validate(); err != nil {
+			return fmt.Errorf("invalid replacement config: %w", err)
+		}
+	}
+	if config.SimulateProposal && config.MCMSConfig == nil {
+		return errors.New("simulateProposal requires mcmsConfig")
+	}
+	if config.SimulateProposal && config.ForkRPCURL == "" {
+		return errors.New("simulateProposal requires forkRPCURL")
+	}
+	if config.Retry != nil {
+		if err := config.Retry.Validate(); err != nil {
+			return fmt.Errorf("invalid retry policy: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (l ReconcileCapabilitiesRegistry) Apply(e cldf.Environment, config ReconcileCapabilitiesRegistryInput) (cldf.ChangesetOutput, error) {
+	var mcmsContracts *commonchangeset.MCMSWithTimelockState
+	if config.MCMSConfig != nil {
+		var err error
+		mcmsContracts, err = strategies.GetMCMSContracts(e, config.ChainSelector, emptyQualifier)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to get MCMS contracts: %w", err)
+		}
+	}
+
+	desired, err := config.DesiredState.ToPkg()
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("invalid desired state: %w", err)
+	}
+
+	report, err := operations.ExecuteSequence(
+		e.OperationsBundle,
+		sequences.ReconcileCapabilitiesRegistry,
+		sequences.ReconcileCapabilitiesRegistryDeps{
+			Env:           &e,
+			MCMSContracts: mcmsContracts,
+		},
+		sequences.ReconcileCapabilitiesRegistryInput{
+			RegistryRef:     pkg.GetCapRegV2AddressRefKey(config.ChainSelector, config.Qualifier),
+			MCMSConfig:      config.MCMSConfig,
+			Desired:         desired,
+			Prune:           config.Prune,
+			Force:           config.Force,
+			NonceManagement: config.NonceManagement,
+			Replacement:     config.Replacement,
+			Retry:           config.Retry,
+		},
+	)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to reconcile capabilities registry: %w", err)
+	}
+
+	reports := report.ExecutionReports
+	if config.SimulateProposal && len(report.Output.MCMSTimelockProposals) > 0 {
+		registryRef, err := e.DataStore.Addresses().Get(pkg.GetCapRegV2AddressRefKey(config.ChainSelector, config.Qualifier))
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to get registry address: %w", err)
+		}
+		abis := map[common.Address]string{
+			common.HexToAddress(registryRef.Address): capabilities_registry_v2.CapabilitiesRegistryABI,
+		}
+		fork, err := strategies.NewAnvilForkClient(e.GetContext(), config.ForkRPCURL)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+		defer fork.Close()
+
+		for _, proposal := range report.Output.MCMSTimelockProposals {
+			simReport, err := operations.ExecuteOperation(e.OperationsBundle, strategies.SimulateProposalOp,
+				strategies.SimulateProposalDeps{Forks: map[uint64]strategies.ForkClient{config.ChainSelector: fork}},
+				strategies.SimulateProposalInput{Proposal: proposal, ABIs: abis},
+			)
+			if err != nil {
+				return cldf.ChangesetOutput{}, fmt.Errorf("failed to simulate MCMS proposal: %w", err)
+			}
+			reports = append(reports, simReport.ToGenericReport())
+		}
+	}
+
+	return cldf.ChangesetOutput{
+		Reports:               reports,
+		MCMSTimelockProposals: report.Output.MCMSTimelockProposals,
+	}, nil
+}
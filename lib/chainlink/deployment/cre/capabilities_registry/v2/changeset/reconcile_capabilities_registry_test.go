@@ -0,0 +1,186 @@
+package changeset_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	crecontracts "github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/test"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+)
+
+func TestReconcileCapabilitiesRegistry_VerifyPreconditions(t *testing.T) {
+	cs := changeset.ReconcileCapabilitiesRegistry{}
+
+	env := test.SetupEnvV2(t, false)
+
+	t.Run("empty qualifier", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.ReconcileCapabilitiesRegistryInput{
+			ChainSelector: env.RegistrySelector,
+		})
+		require.ErrorContains(t, err, "qualifier must be provided")
+	})
+
+	t.Run("unknown chain", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.ReconcileCapabilitiesRegistryInput{
+			ChainSelector: 1,
+			Qualifier:     test.RegistryQualifier,
+		})
+		require.ErrorContains(t, err, "chain 1 not found in environment")
+	})
+
+	t.Run("invalid node", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.ReconcileCapabilitiesRegistryInput{
+			ChainSelector: env.RegistrySelector,
+			Qualifier:     test.RegistryQualifier,
+			DesiredState: changeset.CapabilitiesRegistryDesiredState{
+				Nodes: []changeset.CapabilitiesRegistryNodeParams{{NOP: "Operator 1", P2pID: "invalid"}},
+			},
+		})
+		require.ErrorContains(t, err, "invalid desired state")
+	})
+}
+
+func TestReconcileCapabilitiesRegistry_Apply(t *testing.T) {
+	cs := changeset.ReconcileCapabilitiesRegistry{}
+
+	env := test.SetupEnvV2(t, false)
+
+	chain, ok := env.Env.BlockChains.EVMChains()[env.RegistrySelector]
+	require.True(t, ok, "chain not found for selector")
+
+	capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(env.RegistryAddress, chain.Client)
+	require.NoError(t, err)
+
+	input := changeset.ReconcileCapabilitiesRegistryInput{
+		ChainSelector: env.RegistrySelector,
+		Qualifier:     test.RegistryQualifier,
+		DesiredState: changeset.CapabilitiesRegistryDesiredState{
+			Nops: []changeset.CapabilitiesRegistryNodeOperator{
+				{Name: "Operator 1", Admin: common.HexToAddress("0x0a")},
+				{Name: "Operator 2", Admin: common.HexToAddress("0x02")},
+			},
+		},
+	}
+
+	_, err = cs.Apply(*env.Env, input)
+	require.NoError(t, err)
+
+	nops, err := pkg.GetNodeOperators(nil, capReg)
+	require.NoError(t, err)
+	require.Len(t, nops, 2)
+	assert.Equal(t, common.HexToAddress("0x0a"), nops[0].Admin)
+	assert.Equal(t, "Operator 2", nops[1].Name)
+
+	// The DON, nodes and capabilities are untouched since they are not pruned
+	don, err := capReg.GetDONByName(nil, test.DONName)
+	require.NoError(t, err)
+	assert.NotEmpty(t, don.NodeP2PIds)
+
+	// Applying the same desired state again is a no-op
+	out, err := cs.Apply(*env.Env, input)
+	require.NoError(t, err)
+	assert.Empty(t, out.MCMSTimelockProposals)
+}
+
+func TestReconcileCapabilitiesRegistry_AfterNopRemoval(t *testing.T) {
+	cs := changeset.ReconcileCapabilitiesRegistry{}
+
+	env := test.SetupEnvV2(t, false)
+
+	chain, ok := env.Env.BlockChains.EVMChains()[env.RegistrySelector]
+	require.True(t, ok, "chain not found for selector")
+
+	capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(env.RegistryAddress, chain.Client)
+	require.NoError(t, err)
+
+	nops := []changeset.CapabilitiesRegistryNodeOperator{
+		{Name: "Operator 1", Admin: common.HexToAddress("0x01")},
+		{Name: "Operator 2", Admin: common.HexToAddress("0x02")},
+		{Name: "Operator 3", Admin: common.HexToAddress("0x03")},
+		{Name: "Operator 4", Admin: common.HexToAddress("0x04")},
+	}
+	input := changeset.ReconcileCapabilitiesRegistryInput{
+		ChainSelector: env.RegistrySelector,
+		Qualifier:     test.RegistryQualifier,
+		DesiredState:  changeset.CapabilitiesRegistryDesiredState{Nops: nops},
+	}
+	_, err = cs.Apply(*env.Env, input)
+	require.NoError(t, err)
+
+	// Removing Operator 3 moves Operator 4 in its place in the list of the contract
+	tx, err := capReg.RemoveNodeOperators(chain.DeployerKey, []uint32{3})
+	require.NoError(t, err)
+	_, err = chain.Confirm(tx)
+	require.NoError(t, err)
+
+	contractNOPs, err := pkg.GetNodeOperatorsWithIDs(nil, capReg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint32{"Operator 1": 1, "Operator 2": 2, "Operator 4": 4}, pkg.NodeOperatorIDsByName(contractNOPs))
+
+	// Operator 4 is resolved by name to its ID, not to its position in the list
+	input.DesiredState.Nops = []changeset.CapabilitiesRegistryNodeOperator{
+		nops[0], nops[1], {Name: "Operator 4", Admin: common.HexToAddress("0x0d")},
+	}
+	_, err = cs.Apply(*env.Env, input)
+	require.NoError(t, err)
+
+	nop, err := capReg.GetNodeOperator(nil, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "Operator 4", nop.Name)
+	assert.Equal(t, common.HexToAddress("0x0d"), nop.Admin)
+	nop, err = capReg.GetNodeOperator(nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x02"), nop.Admin)
+}
+
+func TestReconcileCapabilitiesRegistry_NewNopNodesWithMCMS(t *testing.T) {
+	env := test.SetupEnvV2(t, true)
+
+	chain, ok := env.Env.BlockChains.EVMChains()[env.RegistrySelector]
+	require.True(t, ok, "chain not found for selector")
+
+	capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(env.RegistryAddress, chain.Client)
+	require.NoError(t, err)
+
+	// The node of Operator 2 is registered in the same proposal as Operator 2, with the ID planned for it
+	peerID := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(100)).PeerID()
+	input := changeset.ReconcileCapabilitiesRegistryInput{
+		ChainSelector: env.RegistrySelector,
+		Qualifier:     test.RegistryQualifier,
+		MCMSConfig:    &crecontracts.MCMSConfig{MinDelay: 0},
+		DesiredState: changeset.CapabilitiesRegistryDesiredState{
+			Nops: []changeset.CapabilitiesRegistryNodeOperator{
+				{Name: "Operator 1", Admin: common.HexToAddress("0x01")},
+				{Name: "Operator 2", Admin: common.HexToAddress("0x02")},
+			},
+			Nodes: []changeset.CapabilitiesRegistryNodeParams{{
+				NOP:                 "Operator 2",
+				P2pID:               peerID.String(),
+				Signer:              "0x1111111111111111111111111111111111111111111111111111111111111111",
+				CsaKey:              "0x2222222222222222222222222222222222222222222222222222222222222222",
+				EncryptionPublicKey: "0x3333333333333333333333333333333333333333333333333333333333333333",
+				CapabilityIDs:       []string{"test-capability@1.0.0"},
+			}},
+		},
+	}
+
+	_, err = commonchangeset.Apply(t, *env.Env, commonchangeset.Configure(changeset.ReconcileCapabilitiesRegistry{}, input))
+	require.NoError(t, err)
+
+	node, err := capReg.GetNode(nil, peerID)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), node.NodeOperatorId)
+	nop, err := capReg.GetNodeOperator(nil, node.NodeOperatorId)
+	require.NoError(t, err)
+	assert.Equal(t, "Operator 2", nop.Name)
+}
@@ -0,0 +1,147 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/sequences"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	crecontracts "github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+var _ cldf.ChangeSetV2[ReplicateCapabilitiesRegistryInput] = ReplicateCapabilitiesRegistry{}
+
+type CapabilitiesRegistryRef struct {
+	ChainSelector uint64 `json:"chainSelector" yaml:"chainSelector"`
+	Qualifier     string `json:"qualifier" yaml:"qualifier"`
+}
+
+type ReplicateCapabilitiesRegistryInput struct {
+	Source  CapabilitiesRegistryRef   `json:"source" yaml:"source"`
+	Targets []CapabilitiesRegistryRef `json:"targets" yaml:"targets"`
+
+	// Prune removes from the targets the DONs, nodes and node operators missing from the source, and deprecates the missing capabilities.
+	Prune bool `json:"prune,omitempty" yaml:"prune,omitempty"`
+	// Force updates and removes DONs accepting workflows, and deprecates capabilities still used by DONs.
+	Force bool `json:"force,omitempty" yaml:"force,omitempty"`
+
+	MCMSConfig *crecontracts.MCMSConfig `json:"mcmsConfig,omitempty" yaml:"mcmsConfig,omitempty"`
+}
+
+// ReplicateCapabilitiesRegistry reads the registry on a source chain and reconciles the registries on the target chains
+// with its node operators, capabilities, nodes and DONs. With MCMS, there is one proposal per target chain.
+// Node operator and DON IDs are kept consistent where possible, i.e. when the targets have no other entries,
+// and a warning is logged for each ID that differs.
+type ReplicateCapabilitiesRegistry struct{}
+
+func (l ReplicateCapabilitiesRegistry) VerifyPreconditions(e cldf.Environment, config ReplicateCapabilitiesRegistryInput) error {
+	if len(config.Targets) == 0 {
+		return errors.New("must specify at least one target registry")
+	}
+
+	seen := make(map[uint64]struct{}, len(config.Targets)+1)
+	for _, ref := range append([]CapabilitiesRegistryRef{config.Source}, config.Targets...) {
+		if ref.Qualifier == "" {
+			return fmt.Errorf("qualifier must be provided for chain %d", ref.ChainSelector)
+		}
+		if _, ok := e.BlockChains.EVMChains()[ref.ChainSelector]; !ok {
+			return fmt.Errorf("chain %d not found in environment", ref.ChainSelector)
+		}
+		if _, exists := seen[ref.ChainSelector]; exists {
+			return fmt.Errorf("chain %d is specified more than once", ref.ChainSelector)
+		}
+		seen[ref.ChainSelector] = struct{}{}
+		if _, err := e.DataStore.Addresses().Get(pkg.GetCapRegV2AddressRefKey(ref.ChainSelector, ref.Qualifier)); err != nil {
+			return fmt.Errorf("failed to get registry address on chain %d: %w", ref.ChainSelector, err)
+		}
+	}
+
+	return nil
+}
+
+func (l ReplicateCapabilitiesRegistry) Apply(e cldf.Environment, config ReplicateCapabilitiesRegistryInput) (cldf.ChangesetOutput, error) {
+	source, err := readCapabilitiesRegistryState(e, config.Source)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to read source registry: %w", err)
+	}
+
+	desired, err := pkg.DesiredStateFromRegistry(source)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to build desired state from source registry: %w", err)
+	}
+
+	var out cldf.ChangesetOutput
+	for _, target := range config.Targets {
+		var mcmsContracts *commonchangeset.MCMSWithTimelockState
+		if config.MCMSConfig != nil {
+			mcmsContracts, err = strategies.GetMCMSContracts(e, target.ChainSelector, emptyQualifier)
+			if err != nil {
+				return out, fmt.Errorf("failed to get MCMS contracts on chain %d: %w", target.ChainSelector, err)
+			}
+		}
+
+		report, err := operations.ExecuteSequence(
+			e.OperationsBundle,
+			sequences.ReconcileCapabilitiesRegistry,
+			sequences.ReconcileCapabilitiesRegistryDeps{
+				Env:           &e,
+				MCMSContracts: mcmsContracts,
+			},
+			sequences.ReconcileCapabilitiesRegistryInput{
+				RegistryRef: pkg.GetCapRegV2AddressRefKey(target.ChainSelector, target.Qualifier),
+				MCMSConfig:  config.MCMSConfig,
+				Desired:     desired,
+				Prune:       config.Prune,
+				Force:       config.Force,
+			},
+		)
+		if err != nil {
+			return out, fmt.Errorf("failed to replicate registry on chain %d: %w", target.ChainSelector, err)
+		}
+		out.Reports = append(out.Reports, report.ExecutionReports...)
+		out.MCMSTimelockProposals = append(out.MCMSTimelockProposals, report.Output.MCMSTimelockProposals...)
+
+		// With MCMS, only the node operator IDs are known before the proposal is executed
+		nopIDs, donIDs := report.Output.Plan.NodeOperatorIDs, map[string]uint32(nil)
+		if config.MCMSConfig == nil {
+			replica, err := readCapabilitiesRegistryState(e, target)
+			if err != nil {
+				return out, fmt.Errorf("failed to read registry on chain %d: %w", target.ChainSelector, err)
+			}
+			nopIDs, donIDs = pkg.NodeOperatorIDsByName(replica.Nops), pkg.DONIDsByName(replica.DONs)
+		}
+		for _, w := range pkg.RegistryIDMismatches(source, nopIDs, donIDs) {
+			e.Logger.Warnf("Registry on chain %d: %s", target.ChainSelector, w)
+		}
+	}
+
+	return out, nil
+}
+
+func readCapabilitiesRegistryState(e cldf.Environment, ref CapabilitiesRegistryRef) (pkg.RegistryState, error) {
+	chain, ok := e.BlockChains.EVMChains()[ref.ChainSelector]
+	if !ok {
+		return pkg.RegistryState{}, fmt.Errorf("chain %d not found in environment", ref.ChainSelector)
+	}
+
+	addressRef, err := e.DataStore.Addresses().Get(pkg.GetCapRegV2AddressRefKey(ref.ChainSelector, ref.Qualifier))
+	if err != nil {
+		return pkg.RegistryState{}, fmt.Errorf("failed to get registry address: %w", err)
+	}
+
+	capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(common.HexToAddress(addressRef.Address), chain.Client)
+	if err != nil {
+		return pkg.RegistryState{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+	}
+
+	return pkg.ReadRegistryState(nil, capReg)
+}
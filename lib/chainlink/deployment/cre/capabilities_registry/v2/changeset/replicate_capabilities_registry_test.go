@@ -0,0 +1,38 @@
+package changeset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/cre/test"
+)
+
+func TestReplicateCapabilitiesRegistry_VerifyPreconditions(t *testing.T) {
+	cs := changeset.ReplicateCapabilitiesRegistry{}
+
+	env := test.SetupEnvV2(t, false)
+	source := changeset.CapabilitiesRegistryRef{ChainSelector: env.RegistrySelector, Qualifier: test.RegistryQualifier}
+
+	t.Run("no targets", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.ReplicateCapabilitiesRegistryInput{Source: source})
+		require.ErrorContains(t, err, "must specify at least one target registry")
+	})
+
+	t.Run("unknown target chain", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.ReplicateCapabilitiesRegistryInput{
+			Source:  source,
+			Targets: []changeset.CapabilitiesRegistryRef{{ChainSelector: 1, Qualifier: test.RegistryQualifier}},
+		})
+		require.ErrorContains(t, err, "chain 1 not found in environment")
+	})
+
+	t.Run("target is the source", func(t *testing.T) {
+		err := cs.VerifyPreconditions(*env.Env, changeset.ReplicateCapabilitiesRegistryInput{
+			Source:  source,
+			Targets: []changeset.CapabilitiesRegistryRef{source},
+		})
+		require.ErrorContains(t, err, "is specified more than once")
+	})
+}
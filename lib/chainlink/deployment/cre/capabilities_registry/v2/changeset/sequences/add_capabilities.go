@@ -124,8 +124,8 @@ var AddCapabilities = operations.NewSequence[AddCapabilitiesInput, AddCapabiliti
 
 		// Create the appropriate strategy
 		strategy, err := strategies.CreateStrategy(
-			chain,
 			*deps.Env,
+			chain.Selector,
 			input.MCMSConfig,
 			deps.MCMSContracts,
 			common.HexToAddress(registryAddressRef.Address),
@@ -196,15 +196,21 @@ var AddCapabilities = operations.NewSequence[AddCapabilitiesInput, AddCapabiliti
 
 		var proposals []mcmslib.TimelockProposal
 
-		if input.MCMSConfig != nil {
-			proposal, mcmsErr := strategy.BuildProposal([]types.BatchOperation{
-				*regCapsReport.Output.Operation, *updateNodesReport.Output.Operation, *updateDonReport.Output.Operation,
-			})
-			if mcmsErr != nil {
-				return AddCapabilitiesOutput{}, fmt.Errorf("failed to build MCMS proposal: %w", mcmsErr)
+		var operations []types.BatchOperation
+		for _, op := range []*types.BatchOperation{regCapsReport.Output.Operation, updateNodesReport.Output.Operation, updateDonReport.Output.Operation} {
+			if op != nil {
+				operations = append(operations, *op)
 			}
+		}
 
-			proposals = append(proposals, *proposal)
+		if len(operations) > 0 {
+			proposal, mcmsErr := strategy.BuildProposal(operations)
+			if mcmsErr != nil && !errors.Is(mcmsErr, strategies.ErrNoProposal) {
+				return AddCapabilitiesOutput{}, fmt.Errorf("failed to build MCMS proposal: %w", mcmsErr)
+			}
+			if proposal != nil {
+				proposals = append(proposals, *proposal)
+			}
 		}
 
 		return AddCapabilitiesOutput{
@@ -38,6 +38,8 @@ type ConfigureCapabilitiesRegistryInput struct {
 	Nodes           []contracts.NodesInput
 	Capabilities    []capabilities_registry_v2.CapabilitiesRegistryCapability
 	DONs            []capabilities_registry_v2.CapabilitiesRegistryNewDONParams
+	// UpdateNopAdmins updates the admin of already registered node operators whose admin differs from the input.
+	UpdateNopAdmins bool
 }
 
 func (c ConfigureCapabilitiesRegistryInput) Validate() error {
@@ -81,8 +83,8 @@ var ConfigureCapabilitiesRegistry = operations.NewSequence(
 
 		// Create the appropriate strategy
 		strategy, err := strategies.CreateStrategy(
-			chain,
 			*deps.Env,
+			chain.Selector,
 			input.MCMSConfig,
 			deps.MCMSContracts,
 			common.HexToAddress(addr),
@@ -100,6 +102,7 @@ var ConfigureCapabilitiesRegistry = operations.NewSequence(
 			ChainSelector: input.RegistryChainSel,
 			Address:       addr,
 			Nops:          input.Nops,
+			UpdateAdmins:  input.UpdateNopAdmins,
 			MCMSConfig:    input.MCMSConfig,
 		})
 		if err != nil {
@@ -164,11 +167,12 @@ var ConfigureCapabilitiesRegistry = operations.NewSequence(
 
 		if len(allOperations) > 0 {
 			proposal, mErr := strategy.BuildProposal(allOperations)
-			if mErr != nil {
+			if mErr != nil && !errors.Is(mErr, strategies.ErrNoProposal) {
 				return ConfigureCapabilitiesRegistryOutput{}, fmt.Errorf("failed to build MCMS proposal: %w", mErr)
 			}
-
-			proposals = append(proposals, *proposal)
+			if proposal != nil {
+				proposals = append(proposals, *proposal)
+			}
 		}
 
 		return ConfigureCapabilitiesRegistryOutput{
@@ -0,0 +1,403 @@
+package sequences
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+	mcmslib "github.com/smartcontractkit/mcms"
+	"github.com/smartcontractkit/mcms/types"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/operations/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/capabilities_registry/v2/changeset/pkg"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	crecontracts "github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+type ReconcileCapabilitiesRegistryDeps struct {
+	Env           *cldf.Environment
+	MCMSContracts *commonchangeset.MCMSWithTimelockState // Required if MCMSConfig is not nil
+}
+
+type ReconcileCapabilitiesRegistryInput struct {
+	RegistryRef datastore.AddressRefKey
+	MCMSConfig  *crecontracts.MCMSConfig
+	Desired     pkg.DesiredRegistryState
+
+	// Prune removes the DONs, nodes and node operators missing from the desired state, and deprecates the missing capabilities.
+	Prune bool
+	// Force updates and removes DONs accepting workflows, and deprecates capabilities still used by DONs.
+	Force bool
+
+	// NonceManagement and Replacement only apply to direct transactions, see strategies.WithNonceManagement and
+	// strategies.WithReplacement.
+	NonceManagement bool
+	Replacement     *strategies.ReplacementConfig
+	// Retry retries the calls on transient errors, see strategies.WithRetryPolicy.
+	Retry *strategies.RetryPolicy
+}
+
+func (i *ReconcileCapabilitiesRegistryInput) Validate() error {
+	if i.RegistryRef == nil {
+		return errors.New("must set registry ref")
+	}
+	return nil
+}
+
+type ReconcileCapabilitiesRegistryOutput struct {
+	Plan                  pkg.ReconcilePlan
+	MCMSTimelockProposals []mcmslib.TimelockProposal
+	// Transactions reports the direct transactions, including their fee-bumped replacements.
+	Transactions []strategies.TransactionReport
+}
+
+// ReconcileCapabilitiesRegistry diffs the desired state against the registry and applies the changes needed to converge.
+// When MCMS is used, all the changes are bundled in a single proposal.
+var ReconcileCapabilitiesRegistry = operations.NewSequence[ReconcileCapabilitiesRegistryInput, ReconcileCapabilitiesRegistryOutput, ReconcileCapabilitiesRegistryDeps](
+	"reconcile-capabilities-registry-seq",
+	semver.MustParse("1.0.0"),
+	"Reconciles the capabilities registry with a desired state",
+	func(b operations.Bundle, deps ReconcileCapabilitiesRegistryDeps, input ReconcileCapabilitiesRegistryInput) (ReconcileCapabilitiesRegistryOutput, error) {
+		if err := input.Validate(); err != nil {
+			return ReconcileCapabilitiesRegistryOutput{}, err
+		}
+
+		chainSel := input.RegistryRef.ChainSelector()
+		chain, ok := deps.Env.BlockChains.EVMChains()[chainSel]
+		if !ok {
+			return ReconcileCapabilitiesRegistryOutput{}, cldf.ErrChainNotFound
+		}
+
+		registryAddressRef, err := deps.Env.DataStore.Addresses().Get(input.RegistryRef)
+		if err != nil {
+			return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to get registry address: %w", err)
+		}
+		addr := registryAddressRef.Address
+
+		capReg, err := capabilities_registry_v2.NewCapabilitiesRegistry(common.HexToAddress(addr), chain.Client)
+		if err != nil {
+			return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to create CapabilitiesRegistry: %w", err)
+		}
+
+		current, err := pkg.ReadRegistryState(nil, capReg)
+		if err != nil {
+			return ReconcileCapabilitiesRegistryOutput{}, err
+		}
+
+		plan, err := pkg.PlanReconcile(current, input.Desired, input.Prune)
+		if err != nil {
+			return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to plan reconcile: %w", err)
+		}
+		for _, w := range plan.Warnings {
+			b.Logger.Warn(w)
+		}
+		if plan.IsEmpty() {
+			b.Logger.Info("capabilities registry already matches the desired state, nothing to do")
+			return ReconcileCapabilitiesRegistryOutput{Plan: plan}, nil
+		}
+
+		var strategyOpts []strategies.StrategyOption
+		if input.NonceManagement {
+			strategyOpts = append(strategyOpts, strategies.WithNonceManagement())
+		}
+		if input.Replacement != nil {
+			strategyOpts = append(strategyOpts, strategies.WithReplacement(*input.Replacement))
+		}
+		if input.Retry != nil {
+			strategyOpts = append(strategyOpts, strategies.WithRetryPolicy(*input.Retry))
+		}
+
+		// Create the appropriate strategy
+		strategy, err := strategies.CreateStrategy(
+			*deps.Env,
+			chain.Selector,
+			input.MCMSConfig,
+			deps.MCMSContracts,
+			capReg.Address(),
+			contracts.ReconcileCapabilitiesRegistryDescription,
+			strategyOpts...,
+		)
+		if err != nil {
+			return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to create strategy: %w", err)
+		}
+
+		var allOperations []types.BatchOperation
+		collect := func(op *types.BatchOperation) {
+			if op != nil {
+				allOperations = append(allOperations, *op)
+			}
+		}
+
+		if len(plan.AddNops) > 0 {
+			report, err := operations.ExecuteOperation(b, contracts.RegisterNops, contracts.RegisterNopsDeps{
+				Env:      deps.Env,
+				Strategy: strategy,
+			}, contracts.RegisterNopsInput{
+				Address:       addr,
+				ChainSelector: chainSel,
+				Nops:          plan.AddNops,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to register node operators: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		if len(plan.UpdateNops) > 0 {
+			updates := make([]contracts.NopUpdate, 0, len(plan.UpdateNops))
+			for _, u := range plan.UpdateNops {
+				updates = append(updates, contracts.NopUpdate{Name: u.Name, Admin: u.Admin})
+			}
+			report, err := operations.ExecuteOperation(b, contracts.UpdateNops, contracts.UpdateNopsDeps{
+				Env:      deps.Env,
+				Strategy: strategy,
+			}, contracts.UpdateNopsInput{
+				Address:       addr,
+				ChainSelector: chainSel,
+				Nops:          updates,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to update node operators: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		if len(plan.AddCapabilities) > 0 {
+			report, err := operations.ExecuteOperation(b, contracts.RegisterCapabilities, contracts.RegisterCapabilitiesDeps{
+				Env:      deps.Env,
+				Strategy: strategy,
+			}, contracts.RegisterCapabilitiesInput{
+				Address:       addr,
+				ChainSelector: chainSel,
+				Capabilities:  plan.AddCapabilities,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to register capabilities: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		if len(plan.AddNodes) > 0 {
+			nodes := make([]contracts.NodesInput, 0, len(plan.AddNodes))
+			for _, n := range plan.AddNodes {
+				nodes = append(nodes, contracts.NodesInput{
+					NOP:                 n.NOP,
+					NodeOperatorID:      plan.NodeOperatorIDs[n.NOP],
+					Signer:              n.Signer,
+					P2pID:               n.P2PID,
+					EncryptionPublicKey: n.EncryptionPublicKey,
+					CsaKey:              n.CSAKey,
+					CapabilityIDs:       n.CapabilityIDs,
+				})
+			}
+			report, err := operations.ExecuteOperation(b, contracts.RegisterNodes, contracts.RegisterNodesDeps{
+				Env:      deps.Env,
+				Strategy: strategy,
+			}, contracts.RegisterNodesInput{
+				Address:       addr,
+				ChainSelector: chainSel,
+				Nodes:         nodes,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to register nodes: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		if len(plan.UpdateNodes) > 0 {
+			updates := make(map[string]contracts.NodeConfig, len(plan.UpdateNodes))
+			for _, n := range plan.UpdateNodes {
+				caps := make([]capabilities_registry_v2.CapabilitiesRegistryCapability, 0, len(n.CapabilityIDs))
+				for _, id := range n.CapabilityIDs {
+					caps = append(caps, capabilities_registry_v2.CapabilitiesRegistryCapability{CapabilityId: id})
+				}
+				updates[p2pkey.PeerID(n.P2PID).String()] = contracts.NodeConfig{
+					EncryptionPublicKey: hex.EncodeToString(n.EncryptionPublicKey[:]),
+					NodeOperatorID:      plan.NodeOperatorIDs[n.NOP],
+					Signer:              n.Signer,
+					CSAKey:              hex.EncodeToString(n.CSAKey[:]),
+					Capabilities:        caps,
+				}
+			}
+			report, err := operations.ExecuteOperation(b, contracts.UpdateNodes, contracts.UpdateNodesDeps{
+				Env:                  deps.Env,
+				Strategy:             strategy,
+				CapabilitiesRegistry: capReg,
+			}, contracts.UpdateNodesInput{
+				ChainSelector: chainSel,
+				NodesUpdates:  updates,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to update nodes: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		for _, don := range plan.UpdateDONs {
+			p2pIDs := make([]p2pkey.PeerID, 0, len(don.Nodes))
+			for _, n := range don.Nodes {
+				p2pIDs = append(p2pIDs, p2pkey.PeerID(n))
+			}
+			report, err := operations.ExecuteOperation(b, contracts.UpdateDON, contracts.UpdateDONDeps{
+				Env:                  deps.Env,
+				Strategy:             strategy,
+				CapabilitiesRegistry: capReg,
+			}, contracts.UpdateDONInput{
+				ChainSelector:            chainSel,
+				P2PIDs:                   p2pIDs,
+				EncodedCapabilityConfigs: don.CapabilityConfigurations,
+				Config:                   don.Config,
+				DonName:                  don.Name,
+				F:                        don.F,
+				IsPrivate:                !don.IsPublic,
+				Force:                    input.Force,
+				MCMSConfig:               input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to update DON %s: %w", don.Name, err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		if len(plan.AddDONs) > 0 {
+			report, err := operations.ExecuteOperation(b, contracts.RegisterDons, contracts.RegisterDonsDeps{
+				Env:      deps.Env,
+				Strategy: strategy,
+			}, contracts.RegisterDonsInput{
+				Address:       addr,
+				ChainSelector: chainSel,
+				DONs:          plan.AddDONs,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to register DONs: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		if len(plan.DONFamilies) > 0 {
+			changes := make(map[string]contracts.DONFamiliesUpdate, len(plan.DONFamilies))
+			for _, change := range plan.DONFamilies {
+				changes[change.DonName] = contracts.DONFamiliesUpdate{
+					AddToFamilies:      change.AddToFamilies,
+					RemoveFromFamilies: change.RemoveFromFamilies,
+				}
+			}
+			report, err := operations.ExecuteOperation(b, contracts.BatchSetDONFamilies, contracts.BatchSetDONFamiliesDeps{
+				Env:                  deps.Env,
+				Strategy:             strategy,
+				CapabilitiesRegistry: capReg,
+			}, contracts.BatchSetDONFamiliesInput{
+				Changes:          changes,
+				RegistryChainSel: chainSel,
+				MCMSConfig:       input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to set DONs families: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		for _, name := range plan.RemoveDONs {
+			report, err := operations.ExecuteOperation(b, contracts.RemoveDON, contracts.RemoveDONDeps{
+				Env:                  deps.Env,
+				Strategy:             strategy,
+				CapabilitiesRegistry: capReg,
+			}, contracts.RemoveDONInput{
+				ChainSelector: chainSel,
+				DonName:       name,
+				Force:         input.Force,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to remove DON %s: %w", name, err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		if len(plan.RemoveNodes) > 0 {
+			p2pIDs := make([]p2pkey.PeerID, 0, len(plan.RemoveNodes))
+			for _, id := range plan.RemoveNodes {
+				p2pIDs = append(p2pIDs, p2pkey.PeerID(id))
+			}
+			report, err := operations.ExecuteOperation(b, contracts.RemoveNodes, contracts.RemoveNodesDeps{
+				Env:      deps.Env,
+				Strategy: strategy,
+			}, contracts.RemoveNodesInput{
+				Address:       addr,
+				ChainSelector: chainSel,
+				P2PIDs:        p2pIDs,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to remove nodes: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		if len(plan.RemoveNops) > 0 {
+			report, err := operations.ExecuteOperation(b, contracts.RemoveNops, contracts.RemoveNopsDeps{
+				Env:      deps.Env,
+				Strategy: strategy,
+			}, contracts.RemoveNopsInput{
+				Address:       addr,
+				ChainSelector: chainSel,
+				NopNames:      plan.RemoveNops,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to remove node operators: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		if len(plan.DeprecateCapabilities) > 0 {
+			report, err := operations.ExecuteOperation(b, contracts.DeprecateCapabilities, contracts.DeprecateCapabilitiesDeps{
+				Env:      deps.Env,
+				Strategy: strategy,
+			}, contracts.DeprecateCapabilitiesInput{
+				Address:       addr,
+				ChainSelector: chainSel,
+				CapabilityIDs: plan.DeprecateCapabilities,
+				Force:         input.Force,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to deprecate capabilities: %w", err)
+			}
+			collect(report.Output.Operation)
+		}
+
+		var proposals []mcmslib.TimelockProposal
+
+		if len(allOperations) > 0 {
+			proposal, err := strategy.BuildProposal(allOperations)
+			if err != nil && !errors.Is(err, strategies.ErrNoProposal) {
+				return ReconcileCapabilitiesRegistryOutput{}, fmt.Errorf("failed to build MCMS proposal: %w", err)
+			}
+			if proposal != nil {
+				proposals = append(proposals, *proposal)
+			}
+		}
+
+		return ReconcileCapabilitiesRegistryOutput{
+			Plan:                  plan,
+			MCMSTimelockProposals: proposals,
+			Transactions:          strategies.TransactionReports(strategy),
+		}, nil
+	},
+)
@@ -79,8 +79,8 @@ var SetDONsFamilies = operations.NewSequence[SetDONsFamiliesInput, SetDONsFamili
 
 		// Create the appropriate strategy
 		strategy, err := strategies.CreateStrategy(
-			chain,
 			*deps.Env,
+			chain.Selector,
 			input.MCMSConfig,
 			deps.MCMSContracts,
 			capReg.Address(),
@@ -90,33 +90,44 @@ var SetDONsFamilies = operations.NewSequence[SetDONsFamiliesInput, SetDONsFamili
 			return SetDONsFamiliesOutput{}, fmt.Errorf("failed to create strategy: %w", err)
 		}
 
-		var mcmsOperations []types.BatchOperation
-		var donsInfo []capabilities_registry_v2.CapabilitiesRegistryDONInfo
-
+		changes := make(map[string]contracts.DONFamiliesUpdate, len(input.DONsChanges))
 		for _, change := range input.DONsChanges {
-			report, err := operations.ExecuteOperation(
-				b,
-				contracts.SetDONFamilies,
-				contracts.SetDONFamiliesDeps{
-					Env:                  deps.Env,
-					CapabilitiesRegistry: capReg,
-					Strategy:             strategy,
-				},
-				contracts.SetDONFamiliesInput{
-					DonName:            change.DonName,
-					AddToFamilies:      change.AddToFamilies,
-					RemoveFromFamilies: change.RemoveFromFamilies,
-					MCMSConfig:         input.MCMSConfig,
-					RegistryChainSel:   input.RegistryRef.ChainSelector(),
-				},
-			)
-			if err != nil {
-				return SetDONsFamiliesOutput{}, fmt.Errorf("failed to set families for DON %s: %w", change.DonName, err)
+			if _, exists := changes[change.DonName]; exists {
+				return SetDONsFamiliesOutput{}, fmt.Errorf("DON %s is specified more than once", change.DonName)
+			}
+			changes[change.DonName] = contracts.DONFamiliesUpdate{
+				AddToFamilies:      change.AddToFamilies,
+				RemoveFromFamilies: change.RemoveFromFamilies,
 			}
+		}
+
+		report, err := operations.ExecuteOperation(
+			b,
+			contracts.BatchSetDONFamilies,
+			contracts.BatchSetDONFamiliesDeps{
+				Env:                  deps.Env,
+				CapabilitiesRegistry: capReg,
+				Strategy:             strategy,
+			},
+			contracts.BatchSetDONFamiliesInput{
+				Changes:          changes,
+				MCMSConfig:       input.MCMSConfig,
+				RegistryChainSel: input.RegistryRef.ChainSelector(),
+			},
+		)
+		if err != nil {
+			return SetDONsFamiliesOutput{}, fmt.Errorf("failed to set DONs families: %w", err)
+		}
+
+		var mcmsOperations []types.BatchOperation
+		if report.Output.Operation != nil {
+			mcmsOperations = append(mcmsOperations, *report.Output.Operation)
+		}
 
-			donsInfo = append(donsInfo, report.Output.DonInfo)
-			if report.Output.Operation != nil {
-				mcmsOperations = append(mcmsOperations, *report.Output.Operation)
+		var donsInfo []capabilities_registry_v2.CapabilitiesRegistryDONInfo
+		for _, change := range input.DONsChanges {
+			if don, ok := report.Output.DonsInfo[change.DonName]; ok {
+				donsInfo = append(donsInfo, don)
 			}
 		}
 
@@ -124,11 +135,12 @@ var SetDONsFamilies = operations.NewSequence[SetDONsFamiliesInput, SetDONsFamili
 
 		if len(mcmsOperations) > 0 {
 			proposal, err := strategy.BuildProposal(mcmsOperations)
-			if err != nil {
+			if err != nil && !errors.Is(err, strategies.ErrNoProposal) {
 				return SetDONsFamiliesOutput{}, fmt.Errorf("failed to build MCMS proposal: %w", err)
 			}
-
-			proposals = append(proposals, *proposal)
+			if proposal != nil {
+				proposals = append(proposals, *proposal)
+			}
 		}
 
 		return SetDONsFamiliesOutput{
@@ -92,8 +92,8 @@ func (u UpdateDON) Apply(e cldf.Environment, config UpdateDONInput) (cldf.Change
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		capReg.Address(),
@@ -131,11 +131,12 @@ func (u UpdateDON) Apply(e cldf.Environment, config UpdateDONInput) (cldf.Change
 
 	if updateDonReport.Output.Operation != nil {
 		proposal, mcmsErr := strategy.BuildProposal([]types.BatchOperation{*updateDonReport.Output.Operation})
-		if mcmsErr != nil {
+		if mcmsErr != nil && !errors.Is(mcmsErr, strategies.ErrNoProposal) {
 			return cldf.ChangesetOutput{}, fmt.Errorf("failed to build MCMS proposal for UpdateDON on chain %d: %w", config.RegistryChainSel, mcmsErr)
 		}
-
-		proposals = append(proposals, *proposal)
+		if proposal != nil {
+			proposals = append(proposals, *proposal)
+		}
 	}
 
 	return cldf.ChangesetOutput{
@@ -0,0 +1,94 @@
+package strategies
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+)
+
+// AggregatingTransaction wraps a strategy and collects the batch operations of every Apply call, so all the calls
+// of a changeset run end up in a single proposal with one batch operation per chain.
+// Without MCMS, the inner strategy executes the transactions directly and nothing is collected.
+type AggregatingTransaction struct {
+	Inner TransactionStrategy
+
+	mu         sync.Mutex
+	operations []mcmstypes.BatchOperation
+}
+
+// NewAggregatingTransaction wraps the inner strategy.
+func NewAggregatingTransaction(inner TransactionStrategy) *AggregatingTransaction {
+	return &AggregatingTransaction{Inner: inner}
+}
+
+func (a *AggregatingTransaction) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	op, tx, err := a.Inner.Apply(callFn)
+	if err != nil {
+		return op, tx, err
+	}
+
+	if op != nil {
+		a.mu.Lock()
+		a.operations = append(a.operations, *op)
+		a.mu.Unlock()
+	}
+
+	return op, tx, nil
+}
+
+// BuildProposal builds a proposal from the given batch operations, merged per chain.
+func (a *AggregatingTransaction) BuildProposal(operations []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
+	return a.Inner.BuildProposal(MergeBatchOperations(operations))
+}
+
+// Operations returns the batch operations collected so far, merged per chain.
+func (a *AggregatingTransaction) Operations() []mcmstypes.BatchOperation {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return MergeBatchOperations(a.operations)
+}
+
+// BuildAggregatedProposal builds a single proposal from all the batch operations collected so far.
+// It returns nil if nothing was collected, e.g. when the inner strategy executes transactions directly.
+func (a *AggregatingTransaction) BuildAggregatedProposal() (*mcmslib.TimelockProposal, error) {
+	operations := a.Operations()
+	if len(operations) == 0 {
+		return nil, nil
+	}
+
+	return a.Inner.BuildProposal(operations)
+}
+
+// Reset drops the collected batch operations.
+func (a *AggregatingTransaction) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.operations = nil
+}
+
+// MergeBatchOperations merges the batch operations into one batch operation per chain, in order of first appearance.
+// The order of the transactions of each chain is preserved, so calls depending on previous calls keep working.
+func MergeBatchOperations(operations []mcmstypes.BatchOperation) []mcmstypes.BatchOperation {
+	var merged []mcmstypes.BatchOperation
+	indexes := make(map[mcmstypes.ChainSelector]int)
+	for _, op := range operations {
+		i, ok := indexes[op.ChainSelector]
+		if !ok {
+			indexes[op.ChainSelector] = len(merged)
+			merged = append(merged, mcmstypes.BatchOperation{
+				ChainSelector: op.ChainSelector,
+				Transactions:  slices.Clone(op.Transactions),
+			})
+			continue
+		}
+		merged[i].Transactions = append(merged[i].Transactions, op.Transactions...)
+	}
+
+	return merged
+}
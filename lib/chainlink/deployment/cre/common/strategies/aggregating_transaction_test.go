@@ -0,0 +1,89 @@
+package strategies
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStrategy returns a batch operation with a single transaction on the next chain of chains for each Apply.
+type fakeStrategy struct {
+	chains   []mcmstypes.ChainSelector
+	applied  int
+	proposed [][]mcmstypes.BatchOperation
+}
+
+func (f *fakeStrategy) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	tx, err := callFn(&bind.TransactOpts{})
+	if err != nil {
+		return nil, nil, err
+	}
+	op := &mcmstypes.BatchOperation{
+		ChainSelector: f.chains[f.applied],
+		Transactions:  []mcmstypes.Transaction{{Data: tx.Data()}},
+	}
+	f.applied++
+	return op, tx, nil
+}
+
+func (f *fakeStrategy) BuildProposal(operations []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
+	f.proposed = append(f.proposed, operations)
+	return &mcmslib.TimelockProposal{}, nil
+}
+
+func txWithData(data ...byte) func(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return types.NewTx(&types.LegacyTx{Data: data}), nil
+	}
+}
+
+func TestMergeBatchOperations(t *testing.T) {
+	ops := []mcmstypes.BatchOperation{
+		{ChainSelector: 1, Transactions: []mcmstypes.Transaction{{Data: []byte{1}}}},
+		{ChainSelector: 2, Transactions: []mcmstypes.Transaction{{Data: []byte{2}}}},
+		{ChainSelector: 1, Transactions: []mcmstypes.Transaction{{Data: []byte{3}}, {Data: []byte{4}}}},
+	}
+
+	merged := MergeBatchOperations(ops)
+	require.Len(t, merged, 2)
+	assert.Equal(t, mcmstypes.ChainSelector(1), merged[0].ChainSelector)
+	assert.Equal(t, []mcmstypes.Transaction{{Data: []byte{1}}, {Data: []byte{3}}, {Data: []byte{4}}}, merged[0].Transactions)
+	assert.Equal(t, []mcmstypes.Transaction{{Data: []byte{2}}}, merged[1].Transactions)
+
+	// The input is not modified
+	assert.Len(t, ops[0].Transactions, 1)
+	assert.Empty(t, MergeBatchOperations(nil))
+}
+
+func TestAggregatingTransaction(t *testing.T) {
+	inner := &fakeStrategy{chains: []mcmstypes.ChainSelector{1, 1, 2}}
+	s := NewAggregatingTransaction(inner)
+
+	proposal, err := s.BuildAggregatedProposal()
+	require.NoError(t, err)
+	assert.Nil(t, proposal)
+
+	for i := range 3 {
+		op, _, err := s.Apply(txWithData(byte(i)))
+		require.NoError(t, err)
+		require.NotNil(t, op)
+	}
+
+	ops := s.Operations()
+	require.Len(t, ops, 2)
+	assert.Len(t, ops[0].Transactions, 2)
+
+	proposal, err = s.BuildAggregatedProposal()
+	require.NoError(t, err)
+	require.NotNil(t, proposal)
+	require.Len(t, inner.proposed, 1)
+	assert.Equal(t, ops, inner.proposed[0])
+
+	s.Reset()
+	assert.Empty(t, s.Operations())
+}
@@ -0,0 +1,108 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ConfirmationPool waits for several transactions of a chain concurrently, instead of one bind.WaitMined after the
+// other, with a timeout shared by all of them.
+type ConfirmationPool struct {
+	backend bind.DeployBackend
+	timeout time.Duration
+	txs     []*types.Transaction
+}
+
+// NewConfirmationPool creates a pool for the chain of the backend. A zero timeout waits as long as the context allows.
+func NewConfirmationPool(backend bind.DeployBackend, timeout time.Duration) *ConfirmationPool {
+	return &ConfirmationPool{backend: backend, timeout: timeout}
+}
+
+// Add tracks the transaction, nil transactions are ignored.
+func (p *ConfirmationPool) Add(txs ...*types.Transaction) {
+	for _, tx := range txs {
+		if tx != nil {
+			p.txs = append(p.txs, tx)
+		}
+	}
+}
+
+// ConfirmationFailure is a transaction which was not mined in time or reverted.
+type ConfirmationFailure struct {
+	Hash common.Hash
+	Err  error
+}
+
+// ConfirmationResult has the receipts of the transactions, in the order they were added.
+type ConfirmationResult struct {
+	Hashes []common.Hash
+	// Receipts are nil for the failed transactions.
+	Receipts []*types.Receipt
+	Failures []ConfirmationFailure
+}
+
+// ReceiptOf returns the receipt of the transaction, nil if it failed or was not added.
+func (r ConfirmationResult) ReceiptOf(hash common.Hash) *types.Receipt {
+	for i, h := range r.Hashes {
+		if h == hash {
+			return r.Receipts[i]
+		}
+	}
+	return nil
+}
+
+// Err joins the failures, nil if all the transactions were mined successfully.
+func (r ConfirmationResult) Err() error {
+	errs := make([]error, 0, len(r.Failures))
+	for _, f := range r.Failures {
+		errs = append(errs, fmt.Errorf("transaction %s: %w", f.Hash, f.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// Wait waits for all the transactions. The result always has the receipts of the successful transactions, the
+// error is set when some of them failed.
+func (p *ConfirmationPool) Wait(ctx context.Context) (ConfirmationResult, error) {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	receipts := make([]*types.Receipt, len(p.txs))
+	errs := make([]error, len(p.txs))
+	var wg sync.WaitGroup
+	for i, tx := range p.txs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			receipt, err := bind.WaitMined(ctx, p.backend, tx)
+			switch {
+			case err != nil:
+				errs[i] = fmt.Errorf("not mined: %w", err)
+			case receipt.Status != types.ReceiptStatusSuccessful:
+				errs[i] = errors.New("reverted")
+			default:
+				receipts[i] = receipt
+			}
+		}()
+	}
+	wg.Wait()
+
+	result := ConfirmationResult{Hashes: make([]common.Hash, len(p.txs)), Receipts: receipts}
+	for i, tx := range p.txs {
+		result.Hashes[i] = tx.Hash()
+		if errs[i] != nil {
+			result.Failures = append(result.Failures, ConfirmationFailure{Hash: tx.Hash(), Err: errs[i]})
+		}
+	}
+
+	return result, result.Err()
+}
@@ -0,0 +1,70 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+)
+
+// receiptClient returns the configured receipts, other transactions are never mined.
+type receiptClient struct {
+	cldf_evm.OnchainClient
+	receipts map[common.Hash]*types.Receipt
+}
+
+func (c *receiptClient) TransactionReceipt(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	if r, ok := c.receipts[hash]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func TestConfirmationPool(t *testing.T) {
+	mined := types.NewTx(&types.LegacyTx{Nonce: 1})
+	reverted := types.NewTx(&types.LegacyTx{Nonce: 2})
+	stuck := types.NewTx(&types.LegacyTx{Nonce: 3})
+	client := &receiptClient{receipts: map[common.Hash]*types.Receipt{
+		mined.Hash():    {Status: types.ReceiptStatusSuccessful, TxHash: mined.Hash()},
+		reverted.Hash(): {Status: types.ReceiptStatusFailed, TxHash: reverted.Hash()},
+	}}
+
+	t.Run("all mined", func(t *testing.T) {
+		pool := NewConfirmationPool(client, time.Second)
+		pool.Add(mined, nil)
+
+		result, err := pool.Wait(t.Context())
+		require.NoError(t, err)
+		require.Len(t, result.Receipts, 1)
+		assert.Equal(t, mined.Hash(), result.Receipts[0].TxHash)
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		pool := NewConfirmationPool(client, 100*time.Millisecond)
+		pool.Add(stuck, mined, reverted)
+
+		start := time.Now()
+		result, err := pool.Wait(t.Context())
+		require.Error(t, err)
+		// bind.WaitMined polls every second, the shared timeout bounds the whole wait
+		assert.Less(t, time.Since(start), 2*time.Second)
+
+		assert.Nil(t, result.Receipts[0])
+		assert.Equal(t, mined.Hash(), result.Receipts[1].TxHash)
+		assert.Same(t, result.Receipts[1], result.ReceiptOf(mined.Hash()))
+		assert.Nil(t, result.Receipts[2])
+		assert.Nil(t, result.ReceiptOf(reverted.Hash()))
+		require.Len(t, result.Failures, 2)
+		assert.Equal(t, stuck.Hash(), result.Failures[0].Hash)
+		assert.ErrorContains(t, result.Failures[0].Err, "not mined")
+		assert.Equal(t, reverted.Hash(), result.Failures[1].Hash)
+		assert.ErrorContains(t, err, "reverted")
+	})
+}
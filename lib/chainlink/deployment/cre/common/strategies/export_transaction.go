@@ -0,0 +1,210 @@
+package strategies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+// UnsignedTransaction is a fully populated transaction exported for offline signing.
+type UnsignedTransaction struct {
+	ChainSelector uint64          `json:"chainSelector"`
+	ChainID       *big.Int        `json:"chainId"`
+	From          common.Address  `json:"from"`
+	To            *common.Address `json:"to"`
+	Nonce         uint64          `json:"nonce"`
+	Value         *big.Int        `json:"value"`
+	Data          hexutil.Bytes   `json:"data"`
+	Gas           uint64          `json:"gas"`
+	GasPrice      *big.Int        `json:"gasPrice,omitempty"`
+	GasFeeCap     *big.Int        `json:"maxFeePerGas,omitempty"`
+	GasTipCap     *big.Int        `json:"maxPriorityFeePerGas,omitempty"`
+	// Raw is the binary encoding of the unsigned transaction, and SigningHash the hash to sign.
+	Raw         hexutil.Bytes `json:"raw"`
+	SigningHash common.Hash   `json:"signingHash"`
+}
+
+// ExportTransaction is a strategy for cold deployer keys: instead of sending, it writes each transaction, fully
+// populated with consecutive nonces, gas and fees, to a JSON file in Dir, to be signed offline and broadcast later
+// with BroadcastSignedTransaction.
+// Like MCMSTransaction, Apply returns a batch operation since nothing is mined, and BuildProposal returns
+// ErrNoProposal. Contract deployments are not supported, since they have no batch operation.
+type ExportTransaction struct {
+	Chain cldf_evm.Chain
+	// From is the cold key address.
+	From common.Address
+	Dir  string
+	// Gas controls the gas of the transactions, nil keeps the defaults.
+	Gas *GasConfig
+
+	mu        sync.Mutex
+	nextNonce *uint64
+	exported  []UnsignedTransaction
+}
+
+func (e *ExportTransaction) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	chainID, err := evmChainID(e.Chain.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ctx := context.Background()
+	if e.nextNonce == nil {
+		nonce, err := e.Chain.Client.PendingNonceAt(ctx, e.From)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get nonce of %s: %w", e.From, err)
+		}
+		e.nextNonce = &nonce
+	}
+
+	opts := &bind.TransactOpts{
+		From:    e.From,
+		Nonce:   new(big.Int).SetUint64(*e.nextNonce),
+		Context: ctx,
+		NoSend:  true,
+		// The transaction is signed offline
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+	}
+	if e.Gas != nil {
+		if err = e.Gas.applyFees(ctx, e.Chain.Client, opts); err != nil {
+			return nil, nil, err
+		}
+		opts.GasLimit = e.Gas.GasLimit
+	}
+
+	tx, err := callFn(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.Gas != nil && e.Gas.GasLimit == 0 && e.Gas.GasLimitMultiplier != 0 {
+		tx, err = callFn(withGasLimit(opts, e.Gas.gasLimit(tx.Gas())))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if tx.To() == nil {
+		return nil, tx, errors.New("exporting contract deployments is not supported")
+	}
+
+	unsigned, err := newUnsignedTransaction(e.Chain.Selector, chainID, e.From, tx)
+	if err != nil {
+		return nil, tx, err
+	}
+	if err = e.write(len(e.exported), unsigned); err != nil {
+		return nil, tx, err
+	}
+	e.exported = append(e.exported, unsigned)
+	*e.nextNonce++
+
+	op, err := proposalutils.BatchOperationForChain(e.Chain.Selector, tx.To().Hex(), tx.Data(), tx.Value(), "", nil)
+	if err != nil {
+		return nil, tx, err
+	}
+
+	return &op, tx, nil
+}
+
+func (e *ExportTransaction) BuildProposal(_ []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
+	return nil, ErrNoProposal
+}
+
+// Exported returns the transactions exported so far, in nonce order.
+func (e *ExportTransaction) Exported() []UnsignedTransaction {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]UnsignedTransaction(nil), e.exported...)
+}
+
+func (e *ExportTransaction) write(index int, tx UnsignedTransaction) error {
+	if err := os.MkdirAll(e.Dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+	b, err := json.MarshalIndent(tx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction: %w", err)
+	}
+	path := filepath.Join(e.Dir, fmt.Sprintf("%03d-%d-%d.json", index, tx.ChainSelector, tx.Nonce))
+	if err = os.WriteFile(path, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write transaction to %s: %w", path, err)
+	}
+	return nil
+}
+
+func newUnsignedTransaction(chainSelector uint64, chainID *big.Int, from common.Address, tx *types.Transaction) (UnsignedTransaction, error) {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return UnsignedTransaction{}, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	out := UnsignedTransaction{
+		ChainSelector: chainSelector,
+		ChainID:       chainID,
+		From:          from,
+		To:            tx.To(),
+		Nonce:         tx.Nonce(),
+		Value:         tx.Value(),
+		Data:          tx.Data(),
+		Gas:           tx.Gas(),
+		Raw:           raw,
+		SigningHash:   types.LatestSignerForChainID(chainID).Hash(tx),
+	}
+	if tx.Type() == types.LegacyTxType {
+		out.GasPrice = tx.GasPrice()
+	} else {
+		out.GasFeeCap = tx.GasFeeCap()
+		out.GasTipCap = tx.GasTipCap()
+	}
+
+	return out, nil
+}
+
+// BroadcastSignedTransaction sends a transaction signed offline, after checking it is signed by the expected sender
+// for the chain.
+func BroadcastSignedTransaction(ctx context.Context, client bind.ContractBackend, chainSelector uint64, from common.Address, signedRaw []byte) (*types.Transaction, error) {
+	chainID, err := evmChainID(chainSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx types.Transaction
+	if err = tx.UnmarshalBinary(signedRaw); err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %w", err)
+	}
+	if err = verifySignedTx(&tx, chainID, from); err != nil {
+		return nil, err
+	}
+	if err = client.SendTransaction(ctx, &tx); err != nil {
+		return nil, fmt.Errorf("failed to send transaction %s: %w", tx.Hash(), err)
+	}
+
+	return &tx, nil
+}
+
+func withGasLimit(opts *bind.TransactOpts, gasLimit uint64) *bind.TransactOpts {
+	out := *opts
+	out.GasLimit = gasLimit
+	return &out
+}
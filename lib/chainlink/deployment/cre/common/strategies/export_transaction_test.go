@@ -0,0 +1,118 @@
+package strategies
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+)
+
+// exportClient prices and estimates transactions with fixed values, and records the sent transactions.
+type exportClient struct {
+	cldf_evm.OnchainClient
+	sent []*types.Transaction
+}
+
+func (c *exportClient) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return 5, nil
+}
+
+func (c *exportClient) PendingCodeAt(context.Context, common.Address) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (c *exportClient) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(10)}, nil
+}
+
+func (c *exportClient) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(2), nil
+}
+
+func (c *exportClient) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 50_000, nil
+}
+
+func (c *exportClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func TestExportTransaction(t *testing.T) {
+	chain := chainsel.TEST_90000001
+	client := &exportClient{}
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	dir := t.TempDir()
+
+	s := &ExportTransaction{
+		Chain: cldf_evm.Chain{Selector: chain.Selector, Client: client},
+		From:  from,
+		Dir:   dir,
+		Gas:   &GasConfig{GasLimitMultiplier: 1.5},
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"foo","inputs":[],"outputs":[]}]`))
+	require.NoError(t, err)
+	contract := bind.NewBoundContract(common.HexToAddress("0x0b"), parsed, client, client, client)
+
+	for range 2 {
+		op, _, err := s.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.Transact(opts, "foo")
+		})
+		require.NoError(t, err)
+		require.NotNil(t, op)
+	}
+	assert.Empty(t, client.sent, "nothing is sent")
+	_, err = s.BuildProposal(nil)
+	require.ErrorIs(t, err, ErrNoProposal)
+
+	exported := s.Exported()
+	require.Len(t, exported, 2)
+	assert.Equal(t, uint64(5), exported[0].Nonce)
+	assert.Equal(t, uint64(6), exported[1].Nonce)
+	assert.Equal(t, uint64(75_000), exported[0].Gas)
+	assert.Equal(t, big.NewInt(2), exported[0].GasTipCap)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	b, err := os.ReadFile(filepath.Join(dir, files[1].Name()))
+	require.NoError(t, err)
+	var fromFile UnsignedTransaction
+	require.NoError(t, json.Unmarshal(b, &fromFile))
+	assert.Equal(t, exported[1].SigningHash, fromFile.SigningHash)
+
+	// Sign offline and broadcast
+	var unsigned types.Transaction
+	require.NoError(t, unsigned.UnmarshalBinary(fromFile.Raw))
+	chainID, err := evmChainID(chain.Selector)
+	require.NoError(t, err)
+	signed, err := types.SignTx(&unsigned, types.LatestSignerForChainID(chainID), key)
+	require.NoError(t, err)
+	raw, err := signed.MarshalBinary()
+	require.NoError(t, err)
+
+	_, err = BroadcastSignedTransaction(t.Context(), client, chain.Selector, common.HexToAddress("0x01"), raw)
+	require.ErrorContains(t, err, "is signed by")
+	tx, err := BroadcastSignedTransaction(t.Context(), client, chain.Selector, from, raw)
+	require.NoError(t, err)
+	assert.Equal(t, signed.Hash(), tx.Hash())
+	assert.Len(t, client.sent, 1)
+}
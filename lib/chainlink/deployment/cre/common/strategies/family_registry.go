@@ -0,0 +1,63 @@
+package strategies
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+// StrategyFactory creates the strategy of a chain of its family, see CreateStrategy.
+type StrategyFactory func(
+	env cldf.Environment,
+	chainSelector uint64,
+	mcmsConfig *contracts.MCMSConfig,
+	mcmsContracts *commonchangeset.MCMSWithTimelockState,
+	targetAddress common.Address,
+	description string,
+	options StrategyOptions,
+) (TransactionStrategy, error)
+
+// ErrUnsupportedFamily is returned by CreateStrategy for the chains of a family without a registered factory. Only EVM
+// chains are supported out of the box: the strategies of other families, e.g. Solana, Sui or Aptos, are registered by
+// the packages implementing them.
+var ErrUnsupportedFamily = errors.New("unsupported chain family")
+
+var (
+	strategyFactoriesMu sync.RWMutex
+	strategyFactories   = map[string]StrategyFactory{
+		chainsel.FamilyEVM: createEVMStrategy,
+	}
+)
+
+// RegisterStrategyFactory registers the factory of a chain family, e.g. chainsel.FamilySolana, replacing the previous
+// one. Only chainsel.FamilyEVM is registered by default.
+func RegisterStrategyFactory(family string, factory StrategyFactory) {
+	strategyFactoriesMu.Lock()
+	defer strategyFactoriesMu.Unlock()
+
+	strategyFactories[family] = factory
+}
+
+func strategyFactoryFor(chainSelector uint64) (StrategyFactory, error) {
+	family, err := chainsel.GetSelectorFamily(chainSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get family of chain %d: %w", chainSelector, err)
+	}
+
+	strategyFactoriesMu.RLock()
+	defer strategyFactoriesMu.RUnlock()
+
+	factory, ok := strategyFactories[family]
+	if !ok {
+		return nil, fmt.Errorf("%w %s (chain %d): no strategy registered", ErrUnsupportedFamily, family, chainSelector)
+	}
+	return factory, nil
+}
@@ -0,0 +1,82 @@
+package strategies
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cldf_chain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+func TestCreateStrategy_Families(t *testing.T) {
+	evmSelector := chainsel.TEST_90000001.Selector
+	env := cldf.Environment{
+		BlockChains: cldf_chain.NewBlockChains(map[uint64]cldf_chain.BlockChain{
+			evmSelector: cldf_evm.Chain{Selector: evmSelector},
+		}),
+	}
+
+	t.Run("EVM", func(t *testing.T) {
+		s, err := CreateStrategy(env, evmSelector, nil, nil, common.Address{}, "")
+		require.NoError(t, err)
+		assert.IsType(t, &SimpleTransaction{}, s)
+
+		_, err = CreateStrategy(env, chainsel.TEST_90000002.Selector, nil, nil, common.Address{}, "")
+		require.ErrorContains(t, err, "not found in environment")
+	})
+
+	t.Run("unknown selector", func(t *testing.T) {
+		_, err := CreateStrategy(env, 1, nil, nil, common.Address{}, "")
+		require.ErrorContains(t, err, "failed to get family of chain 1")
+	})
+
+	t.Run("unsupported families", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			selector uint64
+			family   string
+		}{
+			{name: "Solana", selector: chainsel.SOLANA_DEVNET.Selector, family: chainsel.FamilySolana},
+			{name: "Sui", selector: chainsel.SUI_TESTNET.Selector, family: chainsel.FamilySui},
+			{name: "Aptos", selector: chainsel.APTOS_LOCALNET.Selector, family: chainsel.FamilyAptos},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := CreateStrategy(env, tt.selector, nil, nil, common.Address{}, "")
+				require.ErrorIs(t, err, ErrUnsupportedFamily)
+				require.ErrorContains(t, err, "unsupported chain family "+tt.family)
+			})
+		}
+	})
+
+	t.Run("registered family", func(t *testing.T) {
+		aptosSelector := chainsel.APTOS_LOCALNET.Selector
+		_, err := CreateStrategy(env, aptosSelector, nil, nil, common.Address{}, "")
+		require.ErrorIs(t, err, ErrUnsupportedFamily)
+
+		want := &fakeStrategy{}
+		RegisterStrategyFactory(chainsel.FamilyAptos, func(_ cldf.Environment, chainSelector uint64, _ *contracts.MCMSConfig, _ *commonchangeset.MCMSWithTimelockState, _ common.Address, _ string, _ StrategyOptions) (TransactionStrategy, error) {
+			assert.Equal(t, aptosSelector, chainSelector)
+			return want, nil
+		})
+		t.Cleanup(func() {
+			strategyFactoriesMu.Lock()
+			defer strategyFactoriesMu.Unlock()
+			delete(strategyFactories, chainsel.FamilyAptos)
+		})
+
+		s, err := CreateStrategy(env, aptosSelector, nil, nil, common.Address{}, "", WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+		require.NoError(t, err)
+		require.IsType(t, &RetryingTransaction{}, s)
+		assert.Same(t, want, s.(*RetryingTransaction).Inner)
+	})
+}
@@ -0,0 +1,108 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+)
+
+// ForkClient is a client of a fork of an EVM chain, which sends transactions from any account without its key and
+// moves the time of the chain forward.
+type ForkClient interface {
+	cldf_evm.OnchainClient
+
+	// SendTransactionFrom sends a transaction from the account, mines it and returns its receipt.
+	SendTransactionFrom(ctx context.Context, from, to common.Address, value *big.Int, data []byte) (*types.Receipt, error)
+	// IncreaseTime moves the time of the chain forward by at least d.
+	IncreaseTime(ctx context.Context, d time.Duration) error
+}
+
+// minForkBalance is the balance the impersonated accounts are funded with to pay for gas.
+var minForkBalance = big.NewInt(1e18)
+
+// AnvilForkClient is a ForkClient of an anvil fork, which impersonates the senders.
+type AnvilForkClient struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+var _ ForkClient = (*AnvilForkClient)(nil)
+
+// NewAnvilForkClient dials the anvil fork at the RPC URL.
+func NewAnvilForkClient(ctx context.Context, url string) (*AnvilForkClient, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial fork %s: %w", url, err)
+	}
+	return &AnvilForkClient{Client: ethclient.NewClient(client), rpc: client}, nil
+}
+
+func (c *AnvilForkClient) SendTransactionFrom(ctx context.Context, from, to common.Address, value *big.Int, data []byte) (*types.Receipt, error) {
+	balance, err := c.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of %s: %w", from, err)
+	}
+	if balance.Cmp(minForkBalance) < 0 {
+		if err = c.rpc.CallContext(ctx, nil, "anvil_setBalance", from, hexutil.EncodeBig(minForkBalance)); err != nil {
+			return nil, fmt.Errorf("failed to fund %s: %w", from, err)
+		}
+	}
+
+	if err = c.rpc.CallContext(ctx, nil, "anvil_impersonateAccount", from); err != nil {
+		return nil, fmt.Errorf("failed to impersonate %s: %w", from, err)
+	}
+	defer func() {
+		_ = c.rpc.CallContext(context.WithoutCancel(ctx), nil, "anvil_stopImpersonatingAccount", from)
+	}()
+
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	var hash common.Hash
+	err = c.rpc.CallContext(ctx, &hash, "eth_sendTransaction", map[string]any{
+		"from":  from,
+		"to":    to,
+		"value": hexutil.EncodeBig(value),
+		"data":  hexutil.Bytes(data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction from %s: %w", from, err)
+	}
+
+	receipt, err := c.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		// automining is off
+		if err = c.rpc.CallContext(ctx, nil, "evm_mine"); err != nil {
+			return nil, fmt.Errorf("failed to mine transaction %s: %w", hash, err)
+		}
+		receipt, err = c.TransactionReceipt(ctx, hash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of transaction %s: %w", hash, err)
+	}
+
+	return receipt, nil
+}
+
+func (c *AnvilForkClient) IncreaseTime(ctx context.Context, d time.Duration) error {
+	seconds := int64((d + time.Second - 1) / time.Second)
+	if err := c.rpc.CallContext(ctx, nil, "evm_increaseTime", seconds); err != nil {
+		return fmt.Errorf("failed to increase time: %w", err)
+	}
+	// the time applies from the next block
+	if err := c.rpc.CallContext(ctx, nil, "evm_mine"); err != nil {
+		return fmt.Errorf("failed to mine block: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// GasConfig controls the gas of the transactions sent directly by SimpleTransaction.
+// The zero value keeps the defaults of the go-ethereum bindings.
+type GasConfig struct {
+	// MaxFeePerGas caps the EIP-1559 fee cap, and the gas price of legacy transactions.
+	MaxFeePerGas *big.Int `json:"maxFeePerGas,omitempty" yaml:"maxFeePerGas,omitempty"`
+	// MaxPriorityFeePerGas is the EIP-1559 tip cap. If not set, the suggested tip is used, capped by MaxFeePerGas.
+	MaxPriorityFeePerGas *big.Int `json:"maxPriorityFeePerGas,omitempty" yaml:"maxPriorityFeePerGas,omitempty"`
+	// LegacyGas sends legacy transactions. Chains without base fee always fall back to legacy transactions.
+	LegacyGas bool `json:"legacyGas,omitempty" yaml:"legacyGas,omitempty"`
+	// GasPriceMultiplier multiplies the suggested gas price of legacy transactions. Defaults to 1.
+	GasPriceMultiplier float64 `json:"gasPriceMultiplier,omitempty" yaml:"gasPriceMultiplier,omitempty"`
+	// GasLimit is a fixed gas limit for every transaction, instead of the estimated one.
+	GasLimit uint64 `json:"gasLimit,omitempty" yaml:"gasLimit,omitempty"`
+	// GasLimitMultiplier is a safety buffer applied to the estimated gas limit, e.g. 1.2. Defaults to 1.
+	GasLimitMultiplier float64 `json:"gasLimitMultiplier,omitempty" yaml:"gasLimitMultiplier,omitempty"`
+}
+
+func (c GasConfig) Validate() error {
+	if c.GasPriceMultiplier < 0 {
+		return errors.New("gasPriceMultiplier must not be negative")
+	}
+	if c.GasLimitMultiplier < 0 {
+		return errors.New("gasLimitMultiplier must not be negative")
+	}
+	if c.GasLimit != 0 && c.GasLimitMultiplier != 0 {
+		return errors.New("cannot set both gasLimit and gasLimitMultiplier")
+	}
+	if c.MaxFeePerGas != nil && c.MaxPriorityFeePerGas != nil && c.MaxPriorityFeePerGas.Cmp(c.MaxFeePerGas) > 0 {
+		return errors.New("maxPriorityFeePerGas must not exceed maxFeePerGas")
+	}
+	return nil
+}
+
+// gasOracle is the subset of the chain client needed to price transactions.
+type gasOracle interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// applyFees sets the gas price or the EIP-1559 fee caps of opts according to the config.
+func (c GasConfig) applyFees(ctx context.Context, client gasOracle, opts *bind.TransactOpts) error {
+	legacy := c.LegacyGas
+	if !legacy {
+		head, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get latest header: %w", err)
+		}
+		legacy = head.BaseFee == nil
+	}
+
+	if legacy {
+		if c.MaxFeePerGas == nil && c.GasPriceMultiplier == 0 {
+			return nil
+		}
+		price, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to suggest gas price: %w", err)
+		}
+		opts.GasPrice = capBig(mulBig(price, c.GasPriceMultiplier), c.MaxFeePerGas)
+		return nil
+	}
+
+	if c.MaxFeePerGas == nil && c.MaxPriorityFeePerGas == nil {
+		return nil
+	}
+	tip := c.MaxPriorityFeePerGas
+	if tip == nil {
+		suggested, err := client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to suggest gas tip cap: %w", err)
+		}
+		tip = capBig(suggested, c.MaxFeePerGas)
+	}
+	opts.GasTipCap = tip
+	opts.GasFeeCap = c.MaxFeePerGas
+	return nil
+}
+
+// gasLimit returns the gas limit to use for a transaction the bindings estimated at estimated gas.
+func (c GasConfig) gasLimit(estimated uint64) uint64 {
+	if c.GasLimit != 0 {
+		return c.GasLimit
+	}
+	if c.GasLimitMultiplier == 0 {
+		return estimated
+	}
+	return uint64(float64(estimated) * c.GasLimitMultiplier)
+}
+
+// mulBig multiplies v by m, m being ignored if 0.
+func mulBig(v *big.Int, m float64) *big.Int {
+	if m == 0 || m == 1 {
+		return v
+	}
+	out, _ := new(big.Float).Mul(new(big.Float).SetInt(v), big.NewFloat(m)).Int(nil)
+	return out
+}
+
+// capBig returns the minimum of v and limit, limit being ignored if nil.
+func capBig(v, limit *big.Int) *big.Int {
+	if limit != nil && v.Cmp(limit) > 0 {
+		return new(big.Int).Set(limit)
+	}
+	return v
+}
@@ -0,0 +1,96 @@
+package strategies
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGasOracle struct {
+	baseFee  *big.Int
+	gasPrice *big.Int
+	tip      *big.Int
+}
+
+func (f fakeGasOracle) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: f.baseFee}, nil
+}
+
+func (f fakeGasOracle) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return f.gasPrice, nil
+}
+
+func (f fakeGasOracle) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return f.tip, nil
+}
+
+func TestGasConfig_ApplyFees(t *testing.T) {
+	eip1559 := fakeGasOracle{baseFee: big.NewInt(10), gasPrice: big.NewInt(100), tip: big.NewInt(5)}
+	legacyChain := fakeGasOracle{gasPrice: big.NewInt(100)}
+
+	tests := []struct {
+		name     string
+		config   GasConfig
+		oracle   fakeGasOracle
+		gasPrice *big.Int
+		tipCap   *big.Int
+		feeCap   *big.Int
+	}{
+		{name: "defaults", oracle: eip1559},
+		{
+			name:   "fee caps",
+			config: GasConfig{MaxFeePerGas: big.NewInt(50), MaxPriorityFeePerGas: big.NewInt(2)},
+			oracle: eip1559,
+			tipCap: big.NewInt(2),
+			feeCap: big.NewInt(50),
+		},
+		{
+			name:   "suggested tip capped by max fee",
+			config: GasConfig{MaxFeePerGas: big.NewInt(3)},
+			oracle: eip1559,
+			tipCap: big.NewInt(3),
+			feeCap: big.NewInt(3),
+		},
+		{
+			name:     "forced legacy with multiplier",
+			config:   GasConfig{LegacyGas: true, GasPriceMultiplier: 1.5},
+			oracle:   eip1559,
+			gasPrice: big.NewInt(150),
+		},
+		{
+			name:     "legacy fallback capped by max fee",
+			config:   GasConfig{MaxFeePerGas: big.NewInt(80)},
+			oracle:   legacyChain,
+			gasPrice: big.NewInt(80),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.config.Validate())
+
+			var opts bind.TransactOpts
+			require.NoError(t, tt.config.applyFees(t.Context(), tt.oracle, &opts))
+			assert.Equal(t, tt.gasPrice, opts.GasPrice)
+			assert.Equal(t, tt.tipCap, opts.GasTipCap)
+			assert.Equal(t, tt.feeCap, opts.GasFeeCap)
+		})
+	}
+}
+
+func TestGasConfig_GasLimit(t *testing.T) {
+	assert.Equal(t, uint64(100), GasConfig{}.gasLimit(100))
+	assert.Equal(t, uint64(120), GasConfig{GasLimitMultiplier: 1.2}.gasLimit(100))
+	assert.Equal(t, uint64(500), GasConfig{GasLimit: 500}.gasLimit(100))
+}
+
+func TestGasConfig_Validate(t *testing.T) {
+	require.ErrorContains(t, GasConfig{GasLimit: 1, GasLimitMultiplier: 1.1}.Validate(), "cannot set both")
+	require.ErrorContains(t, GasConfig{MaxFeePerGas: big.NewInt(1), MaxPriorityFeePerGas: big.NewInt(2)}.Validate(), "must not exceed")
+	require.ErrorContains(t, GasConfig{GasPriceMultiplier: -1}.Validate(), "must not be negative")
+}
@@ -0,0 +1,164 @@
+package strategies
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// ApplyFunc is the signature of TransactionStrategy.Apply.
+type ApplyFunc func(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error)
+
+// ApplyHook decorates Apply, e.g. with logging, metrics or tracing. It must call next to execute the transaction.
+type ApplyHook func(next ApplyFunc) ApplyFunc
+
+// hookedTransaction wraps a strategy with hooks.
+type hookedTransaction struct {
+	inner TransactionStrategy
+	apply ApplyFunc
+}
+
+// WithHooks wraps the strategy so every Apply goes through the hooks, the first hook being the outermost one.
+// It works with any strategy, since hooks only see the inputs and outputs of Apply.
+func WithHooks(inner TransactionStrategy, hooks ...ApplyHook) TransactionStrategy {
+	apply := ApplyFunc(inner.Apply)
+	for i := len(hooks) - 1; i >= 0; i-- {
+		apply = hooks[i](apply)
+	}
+
+	return &hookedTransaction{inner: inner, apply: apply}
+}
+
+func (h *hookedTransaction) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	return h.apply(callFn)
+}
+
+func (h *hookedTransaction) BuildProposal(operations []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
+	return h.inner.BuildProposal(operations)
+}
+
+// Reports returns the transaction reports of the inner strategy, if any.
+func (h *hookedTransaction) Reports() []TransactionReport {
+	return TransactionReports(h.inner)
+}
+
+// LoggingHook logs every call, its outcome and duration.
+func LoggingHook(lggr logger.Logger) ApplyHook {
+	return func(next ApplyFunc) ApplyFunc {
+		return func(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+			start := time.Now()
+			op, tx, err := next(callFn)
+			duration := time.Since(start)
+
+			switch {
+			case err != nil:
+				lggr.Errorw("Transaction failed", "duration", duration, "err", err)
+			case op != nil:
+				lggr.Infow("Created batch operation", "chainSelector", op.ChainSelector, "transactions", len(op.Transactions), "duration", duration)
+			default:
+				lggr.Infow("Executed transaction", "tx", txHash(tx), "duration", duration)
+			}
+
+			return op, tx, err
+		}
+	}
+}
+
+// StrategyMetrics are the metrics collected by MetricsHook.
+type StrategyMetrics struct {
+	mu sync.Mutex
+
+	Calls    int
+	Failures int
+	// Transactions is the number of transactions sent directly.
+	Transactions int
+	// BatchOperations is the number of batch operations created for proposals.
+	BatchOperations int
+	// GasUsed by the transactions sent directly, only counted when a receipt backend is provided.
+	GasUsed  uint64
+	Duration time.Duration
+}
+
+// Snapshot returns a copy of the metrics, safe to read.
+func (m *StrategyMetrics) Snapshot() StrategyMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return StrategyMetrics{
+		Calls:           m.Calls,
+		Failures:        m.Failures,
+		Transactions:    m.Transactions,
+		BatchOperations: m.BatchOperations,
+		GasUsed:         m.GasUsed,
+		Duration:        m.Duration,
+	}
+}
+
+// receiptBackend fetches receipts of mined transactions.
+type receiptBackend interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// MetricsHook counts calls, transactions and their duration into m. If backend is not nil, the gas used by the
+// transactions sent directly is read from their receipts.
+func MetricsHook(m *StrategyMetrics, backend receiptBackend) ApplyHook {
+	return func(next ApplyFunc) ApplyFunc {
+		return func(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+			start := time.Now()
+			op, tx, err := next(callFn)
+			duration := time.Since(start)
+
+			var gasUsed uint64
+			if err == nil && op == nil && tx != nil && backend != nil {
+				if receipt, rErr := backend.TransactionReceipt(context.Background(), tx.Hash()); rErr == nil {
+					gasUsed = receipt.GasUsed
+				}
+			}
+
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.Calls++
+			m.Duration += duration
+			switch {
+			case err != nil:
+				m.Failures++
+			case op != nil:
+				m.BatchOperations++
+			default:
+				m.Transactions++
+				m.GasUsed += gasUsed
+			}
+
+			return op, tx, err
+		}
+	}
+}
+
+// SpanHook wraps each call in a tracing span. startSpan starts a span with the given name and returns the function
+// ending it with the outcome of the call, so any tracer can be plugged in.
+func SpanHook(name string, startSpan func(name string) (end func(err error))) ApplyHook {
+	return func(next ApplyFunc) ApplyFunc {
+		return func(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+			end := startSpan(name)
+			op, tx, err := next(callFn)
+			end(err)
+
+			return op, tx, err
+		}
+	}
+}
+
+func txHash(tx *types.Transaction) string {
+	if tx == nil {
+		return ""
+	}
+	return tx.Hash().Hex()
+}
@@ -0,0 +1,87 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+type fixedReceipts struct{}
+
+func (fixedReceipts) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	return &types.Receipt{GasUsed: 21000}, nil
+}
+
+// directStrategy executes the calls directly, returning no batch operation.
+type directStrategy struct{ fakeStrategy }
+
+func (d *directStrategy) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	tx, err := callFn(&bind.TransactOpts{})
+	return nil, tx, err
+}
+
+func TestWithHooks(t *testing.T) {
+	var order []string
+	tracking := func(name string) ApplyHook {
+		return func(next ApplyFunc) ApplyFunc {
+			return func(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+				order = append(order, name+" before")
+				op, tx, err := next(callFn)
+				order = append(order, name+" after")
+				return op, tx, err
+			}
+		}
+	}
+
+	inner := &fakeStrategy{chains: []mcmstypes.ChainSelector{1}}
+	s := WithHooks(inner, tracking("outer"), tracking("inner"), LoggingHook(logger.Test(t)))
+
+	op, _, err := s.Apply(txWithData(1))
+	require.NoError(t, err)
+	require.NotNil(t, op)
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, order)
+
+	_, err = s.BuildProposal([]mcmstypes.BatchOperation{*op})
+	require.NoError(t, err)
+	assert.Len(t, inner.proposed, 1)
+}
+
+func TestMetricsHook(t *testing.T) {
+	var metrics StrategyMetrics
+	s := WithHooks(&directStrategy{}, MetricsHook(&metrics, fixedReceipts{}))
+
+	_, _, err := s.Apply(txWithData(1))
+	require.NoError(t, err)
+	_, _, err = s.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return nil, errors.New("boom")
+	})
+	require.Error(t, err)
+
+	snapshot := metrics.Snapshot()
+	assert.Equal(t, 2, snapshot.Calls)
+	assert.Equal(t, 1, snapshot.Failures)
+	assert.Equal(t, 1, snapshot.Transactions)
+	assert.Equal(t, uint64(21000), snapshot.GasUsed)
+}
+
+func TestSpanHook(t *testing.T) {
+	var ended []error
+	s := WithHooks(&directStrategy{}, SpanHook("apply", func(name string) func(error) {
+		assert.Equal(t, "apply", name)
+		return func(err error) { ended = append(ended, err) }
+	}))
+
+	_, _, err := s.Apply(txWithData(1))
+	require.NoError(t, err)
+	require.Len(t, ended, 1)
+	assert.NoError(t, ended[0])
+}
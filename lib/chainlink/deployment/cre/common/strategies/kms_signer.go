@@ -0,0 +1,67 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+)
+
+// EVMSigner signs direct transactions instead of the deployer key, so the key does not have to be in memory.
+type EVMSigner interface {
+	// Address returns the address of the key.
+	Address() common.Address
+	// TransactOpts returns transact opts signing with the key for the chain.
+	TransactOpts(chainSelector uint64) (*bind.TransactOpts, error)
+}
+
+var (
+	_ EVMSigner = (*LedgerSigner)(nil)
+	_ EVMSigner = (*KMSSigner)(nil)
+)
+
+// KMSSigner signs transactions with a deployer key held by AWS KMS or GCP KMS, so no private key is needed in CI or
+// on operator laptops.
+type KMSSigner struct {
+	kms     deployment.EVMKMSSigner
+	address common.Address
+}
+
+// NewKMSSigner creates the signer of the KMS key, see deployment.NewEVMKMSClient for AWS KMS and
+// deployment.NewEVMGCPKMSClient for GCP KMS.
+func NewKMSSigner(kms deployment.EVMKMSSigner) (*KMSSigner, error) {
+	address, err := kms.GetKMSAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the address of the KMS key: %w", err)
+	}
+
+	return &KMSSigner{kms: kms, address: address}, nil
+}
+
+// NewAWSKMSSigner creates the signer of the AWS KMS key of the config, e.g. from KMSConfigFromEnvVars.
+func NewAWSKMSSigner(cfg deployment.KMS) (*KMSSigner, error) {
+	client, err := deployment.NewKMSClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create KMS client: %w", err)
+	}
+
+	return NewKMSSigner(deployment.NewEVMKMSClient(client, cfg.KmsDeployerKeyId))
+}
+
+// Address returns the address of the KMS key.
+func (s *KMSSigner) Address() common.Address {
+	return s.address
+}
+
+// TransactOpts returns transact opts signing with the KMS key for the chain, whose ID is derived from its selector.
+func (s *KMSSigner) TransactOpts(chainSelector uint64) (*bind.TransactOpts, error) {
+	chainID, err := evmChainID(chainSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.kms.GetKMSTransactOpts(context.Background(), chainID)
+}
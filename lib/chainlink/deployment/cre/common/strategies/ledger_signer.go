@@ -0,0 +1,159 @@
+package strategies
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+)
+
+// LedgerSignerConfig configures the Ledger used to sign direct transactions.
+type LedgerSignerConfig struct {
+	// DerivationPath of the account, defaults to the standard m/44'/60'/0'/0/0.
+	DerivationPath string `json:"derivationPath,omitempty" yaml:"derivationPath,omitempty"`
+	// ExpectedAddress, if set, fails the setup when the derived account is a different one,
+	// e.g. because the wrong Ledger or path is used.
+	ExpectedAddress *common.Address `json:"expectedAddress,omitempty" yaml:"expectedAddress,omitempty"`
+	// Prompt is where the user prompts are written, defaults to stderr.
+	Prompt io.Writer `json:"-" yaml:"-"`
+}
+
+// LedgerSigner signs transactions with a Ledger, so deployer key operations don't require a plaintext private key.
+// Every transaction must be confirmed on the device.
+type LedgerSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+	prompt  io.Writer
+
+	// The device signs one transaction at a time
+	mu sync.Mutex
+}
+
+// NewLedgerSigner opens the first connected Ledger and derives the configured account.
+// The Ethereum app must be open on the device. Close must be called once done.
+func NewLedgerSigner(cfg LedgerSignerConfig) (*LedgerSigner, error) {
+	path := accounts.DefaultBaseDerivationPath
+	if cfg.DerivationPath != "" {
+		var err error
+		path, err = accounts.ParseDerivationPath(cfg.DerivationPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %s: %w", cfg.DerivationPath, err)
+		}
+	}
+	prompt := cfg.Prompt
+	if prompt == nil {
+		prompt = os.Stderr
+	}
+
+	hub, err := usbwallet.NewLedgerHub()
+	if err != nil {
+		return nil, fmt.Errorf("failed to access USB devices: %w", err)
+	}
+	wallets := hub.Wallets()
+	if len(wallets) == 0 {
+		return nil, errors.New("no Ledger found, make sure it is connected and unlocked")
+	}
+
+	wallet := wallets[0]
+	if err = wallet.Open(""); err != nil {
+		return nil, fmt.Errorf("failed to open Ledger %s, make sure the Ethereum app is open: %w", wallet.URL(), err)
+	}
+
+	account, err := wallet.Derive(path, true)
+	if err != nil {
+		_ = wallet.Close()
+		return nil, fmt.Errorf("failed to derive account %s: %w", path, err)
+	}
+	if cfg.ExpectedAddress != nil && account.Address != *cfg.ExpectedAddress {
+		_ = wallet.Close()
+		return nil, fmt.Errorf("ledger account %s at %s does not match the expected address %s", account.Address, path, cfg.ExpectedAddress)
+	}
+
+	_, _ = fmt.Fprintf(prompt, "Using Ledger account %s at %s\n", account.Address, path)
+
+	return &LedgerSigner{
+		wallet:  wallet,
+		account: account,
+		prompt:  prompt,
+	}, nil
+}
+
+// Address returns the address of the Ledger account.
+func (l *LedgerSigner) Address() common.Address {
+	return l.account.Address
+}
+
+// Close releases the device.
+func (l *LedgerSigner) Close() error {
+	return l.wallet.Close()
+}
+
+// TransactOpts returns transact opts signing with the Ledger for the chain, whose ID is derived from its selector.
+func (l *LedgerSigner) TransactOpts(chainSelector uint64) (*bind.TransactOpts, error) {
+	chainID, err := evmChainID(chainSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bind.TransactOpts{
+		From: l.account.Address,
+		Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if from != l.account.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			_, _ = fmt.Fprintf(l.prompt, "Confirm on the Ledger: transaction to %s, chain ID %s, nonce %d, value %s wei, data %d bytes\n",
+				tx.To(), chainID, tx.Nonce(), tx.Value(), len(tx.Data()))
+			signed, err := l.wallet.SignTx(l.account, tx, chainID)
+			if err != nil {
+				return nil, fmt.Errorf("ledger failed to sign transaction: %w", err)
+			}
+			if err := verifySignedTx(signed, chainID, from); err != nil {
+				return nil, err
+			}
+			_, _ = fmt.Fprintf(l.prompt, "Signed transaction %s\n", signed.Hash())
+
+			return signed, nil
+		},
+	}, nil
+}
+
+// verifySignedTx checks the signed transaction is for the expected chain and sender.
+func verifySignedTx(tx *types.Transaction, chainID *big.Int, from common.Address) error {
+	if tx.ChainId().Cmp(chainID) != 0 {
+		return fmt.Errorf("signed transaction has chain ID %s, expected %s", tx.ChainId(), chainID)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return fmt.Errorf("failed to recover the signer of the transaction: %w", err)
+	}
+	if sender != from {
+		return fmt.Errorf("transaction is signed by %s, expected %s", sender, from)
+	}
+	return nil
+}
+
+func evmChainID(chainSelector uint64) (*big.Int, error) {
+	id, err := chainsel.GetChainIDFromSelector(chainSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID of chain %d: %w", chainSelector, err)
+	}
+	chainID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("chain %d is not an EVM chain, its chain ID is %s", chainSelector, id)
+	}
+	return new(big.Int).SetUint64(chainID), nil
+}
@@ -0,0 +1,40 @@
+package strategies
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignedTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(1337)
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1})
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	require.NoError(t, err)
+
+	require.NoError(t, verifySignedTx(signed, chainID, from))
+	require.ErrorContains(t, verifySignedTx(signed, big.NewInt(1), from), "expected 1")
+	require.ErrorContains(t, verifySignedTx(signed, chainID, common.HexToAddress("0x01")), "is signed by")
+}
+
+func TestEVMChainID(t *testing.T) {
+	id, err := evmChainID(chainsel.ETHEREUM_MAINNET.Selector)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), id)
+
+	_, err = evmChainID(chainsel.SOLANA_MAINNET.Selector)
+	require.ErrorContains(t, err, "is not an EVM chain")
+
+	_, err = evmChainID(1)
+	require.Error(t, err)
+}
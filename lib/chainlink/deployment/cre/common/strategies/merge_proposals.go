@@ -0,0 +1,119 @@
+package strategies
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+)
+
+// MergeProposalsOptions configures MergeProposals.
+type MergeProposalsOptions struct {
+	// Description replaces the descriptions of the merged proposals, which are joined by default.
+	Description string
+	// KeepDuplicates keeps the batch operations identical to a previous one on the same chain, dropped by default.
+	KeepDuplicates bool
+	// CombineBatches merges all the batch operations of a chain into one, executed atomically.
+	CombineBatches bool
+}
+
+// MergeProposals merges the proposals of several changeset runs into a single proposal per chain, sorted by
+// chain selector, so a release is reviewed as one proposal per chain.
+// The operations keep the order of the input proposals, so operations depending on the operations of a previous
+// changeset must come after it. Metadata is merged, the validity is the shortest one, the delay the longest one,
+// and the proposals must use the same MCMS and timelock per chain, and the same action.
+func MergeProposals(ctx context.Context, proposals []mcmslib.TimelockProposal, opts MergeProposalsOptions) ([]mcmslib.TimelockProposal, error) {
+	if len(proposals) == 0 {
+		return nil, errNoProposals
+	}
+
+	merged := make(map[mcmstypes.ChainSelector]*mcmslib.TimelockProposal)
+	for i, proposal := range proposals {
+		for _, sel := range slices.Sorted(maps.Keys(proposal.ChainMetadata)) {
+			part, err := proposalForChain(proposal, sel)
+			if err != nil {
+				return nil, fmt.Errorf("proposal %d: %w", i, err)
+			}
+			if len(part.Operations) == 0 {
+				continue
+			}
+
+			existing, ok := merged[sel]
+			if !ok {
+				merged[sel] = part
+				continue
+			}
+			if existing, err = existing.Merge(ctx, part); err != nil {
+				return nil, fmt.Errorf("failed to merge proposal %d on chain %d: %w", i, sel, err)
+			}
+			merged[sel] = existing
+		}
+	}
+
+	out := make([]mcmslib.TimelockProposal, 0, len(merged))
+	for _, sel := range slices.Sorted(maps.Keys(merged)) {
+		proposal := merged[sel]
+		if !opts.KeepDuplicates {
+			proposal.Operations = dedupBatchOperations(proposal.Operations)
+		}
+		if opts.CombineBatches {
+			proposal.Operations = MergeBatchOperations(proposal.Operations)
+		}
+		if opts.Description != "" {
+			proposal.Description = opts.Description
+		}
+		out = append(out, *proposal)
+	}
+
+	return out, nil
+}
+
+// proposalForChain returns a copy of the proposal with only the operations and metadata of the chain.
+func proposalForChain(proposal mcmslib.TimelockProposal, sel mcmstypes.ChainSelector) (*mcmslib.TimelockProposal, error) {
+	timelock, ok := proposal.TimelockAddresses[sel]
+	if !ok {
+		return nil, fmt.Errorf("no timelock address for chain %d", sel)
+	}
+
+	part := proposal
+	part.Signatures = nil
+	part.ChainMetadata = map[mcmstypes.ChainSelector]mcmstypes.ChainMetadata{sel: proposal.ChainMetadata[sel]}
+	part.TimelockAddresses = map[mcmstypes.ChainSelector]string{sel: timelock}
+	part.Metadata = maps.Clone(proposal.Metadata)
+	part.Operations = nil
+	for _, op := range proposal.Operations {
+		if op.ChainSelector == sel {
+			part.Operations = append(part.Operations, op)
+		}
+	}
+	if part.SaltOverride != nil {
+		salt := *part.SaltOverride
+		part.SaltOverride = &salt
+	}
+
+	return &part, nil
+}
+
+// dedupBatchOperations drops the batch operations identical to a previous one.
+func dedupBatchOperations(operations []mcmstypes.BatchOperation) []mcmstypes.BatchOperation {
+	var out []mcmstypes.BatchOperation
+	for _, op := range operations {
+		if !slices.ContainsFunc(out, func(o mcmstypes.BatchOperation) bool { return equalBatchOperations(o, op) }) {
+			out = append(out, op)
+		}
+	}
+	return out
+}
+
+func equalBatchOperations(a, b mcmstypes.BatchOperation) bool {
+	return a.ChainSelector == b.ChainSelector && slices.EqualFunc(a.Transactions, b.Transactions, func(x, y mcmstypes.Transaction) bool {
+		return x.To == y.To && bytes.Equal(x.Data, y.Data) && bytes.Equal(x.AdditionalFields, y.AdditionalFields)
+	})
+}
+
+var errNoProposals = errors.New("no proposals to merge")
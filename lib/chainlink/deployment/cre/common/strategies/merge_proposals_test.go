@@ -0,0 +1,78 @@
+package strategies
+
+import (
+	"testing"
+	"time"
+
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProposal(description string, validUntil uint32, delay time.Duration, ops ...mcmstypes.BatchOperation) mcmslib.TimelockProposal {
+	p := mcmslib.TimelockProposal{
+		BaseProposal: mcmslib.BaseProposal{
+			Version:       "v1",
+			Kind:          mcmstypes.KindTimelockProposal,
+			ValidUntil:    validUntil,
+			Description:   description,
+			ChainMetadata: map[mcmstypes.ChainSelector]mcmstypes.ChainMetadata{},
+		},
+		Action:            mcmstypes.TimelockActionSchedule,
+		Delay:             mcmstypes.NewDuration(delay),
+		TimelockAddresses: map[mcmstypes.ChainSelector]string{},
+		Operations:        ops,
+	}
+	for _, op := range ops {
+		p.ChainMetadata[op.ChainSelector] = mcmstypes.ChainMetadata{MCMAddress: "mcm"}
+		p.TimelockAddresses[op.ChainSelector] = "timelock"
+	}
+	return p
+}
+
+func batch(sel mcmstypes.ChainSelector, data ...byte) mcmstypes.BatchOperation {
+	return mcmstypes.BatchOperation{ChainSelector: sel, Transactions: []mcmstypes.Transaction{{To: "0x01", Data: data}}}
+}
+
+func TestMergeProposals(t *testing.T) {
+	first := testProposal("first", 200, time.Hour, batch(1, 1), batch(2, 1))
+	second := testProposal("second", 100, 2*time.Hour, batch(1, 2), batch(1, 1))
+
+	merged, err := MergeProposals(t.Context(), []mcmslib.TimelockProposal{first, second}, MergeProposalsOptions{})
+	require.NoError(t, err)
+	require.Len(t, merged, 2)
+
+	chain1 := merged[0]
+	assert.Equal(t, []mcmstypes.BatchOperation{batch(1, 1), batch(1, 2)}, chain1.Operations, "duplicates are dropped, order is kept")
+	assert.Equal(t, "first\nsecond", chain1.Description)
+	assert.Equal(t, uint32(100), chain1.ValidUntil)
+	assert.Equal(t, 2*time.Hour, chain1.Delay.Duration)
+	assert.Len(t, chain1.ChainMetadata, 1)
+
+	chain2 := merged[1]
+	assert.Equal(t, []mcmstypes.BatchOperation{batch(2, 1)}, chain2.Operations)
+	assert.Equal(t, "first", chain2.Description)
+
+	// The inputs are not modified
+	assert.Len(t, first.Operations, 2)
+	assert.Len(t, first.ChainMetadata, 2)
+
+	merged, err = MergeProposals(t.Context(), []mcmslib.TimelockProposal{first, second}, MergeProposalsOptions{
+		Description:    "release",
+		KeepDuplicates: true,
+		CombineBatches: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, merged[0].Operations, 1)
+	assert.Len(t, merged[0].Operations[0].Transactions, 3)
+	assert.Equal(t, "release", merged[0].Description)
+
+	_, err = MergeProposals(t.Context(), nil, MergeProposalsOptions{})
+	require.Error(t, err)
+
+	other := testProposal("other", 100, time.Hour, batch(1, 3))
+	other.TimelockAddresses[1] = "other-timelock"
+	_, err = MergeProposals(t.Context(), []mcmslib.TimelockProposal{first, other}, MergeProposalsOptions{})
+	require.ErrorContains(t, err, "different timelock addresses")
+}
@@ -0,0 +1,226 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NonceManager hands out consecutive nonces for a sender on a chain, so concurrent operations don't reuse nonces.
+// It resyncs with the pending nonce of the chain when the chain is ahead, e.g. after transactions sent by another
+// process, and after a failed send.
+type NonceManager struct {
+	mu   sync.Mutex
+	next *uint64
+}
+
+type nonceManagerKey struct {
+	chainSelector uint64
+	client        any
+	sender        common.Address
+}
+
+var nonceManagers sync.Map
+
+// SharedNonceManager returns the nonce manager of the sender on the chain reached through the client, shared by all
+// the strategies using the same client. The nonces handed out to a client are not reused by the other clients of the
+// chain, e.g. of another environment. A client which cannot be compared gets its own nonce manager.
+func SharedNonceManager(chainSelector uint64, client any, sender common.Address) *NonceManager {
+	if client == nil || !reflect.TypeOf(client).Comparable() {
+		return &NonceManager{}
+	}
+	m, _ := nonceManagers.LoadOrStore(nonceManagerKey{chainSelector: chainSelector, client: client, sender: sender}, &NonceManager{})
+	return m.(*NonceManager)
+}
+
+// Next reserves the next nonce of the sender, the pending nonce of the chain if it is ahead of the local one.
+func (m *NonceManager) Next(ctx context.Context, client interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}, sender common.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending, err := client.PendingNonceAt(ctx, sender)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending nonce of %s: %w", sender, err)
+	}
+	if m.next == nil || *m.next < pending {
+		m.next = &pending
+	}
+
+	nonce := *m.next
+	*m.next++
+	return nonce, nil
+}
+
+// Reset drops the local nonce, the next one is read from the chain again.
+func (m *NonceManager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.next = nil
+}
+
+// ReplacementConfig configures the replacement of transactions not mined in time by the same transaction with
+// bumped fees.
+type ReplacementConfig struct {
+	// After is how long to wait for a transaction to be mined before replacing it.
+	After time.Duration `json:"after" yaml:"after"`
+	// FeeBumpPercent is the fee increase of each replacement, at least 10 since nodes reject lower bumps.
+	FeeBumpPercent int64 `json:"feeBumpPercent" yaml:"feeBumpPercent"`
+	// MaxReplacements is the number of replacements before waiting for any of the transactions to be mined.
+	MaxReplacements int `json:"maxReplacements" yaml:"maxReplacements"`
+	// PollInterval is the interval between receipt checks, defaults to one second.
+	PollInterval time.Duration `json:"pollInterval,omitempty" yaml:"pollInterval,omitempty"`
+}
+
+func (c ReplacementConfig) Validate() error {
+	if c.After <= 0 {
+		return errors.New("after must be positive")
+	}
+	if c.FeeBumpPercent < 10 {
+		return errors.New("feeBumpPercent must be at least 10")
+	}
+	if c.MaxReplacements < 1 {
+		return errors.New("maxReplacements must be at least 1")
+	}
+	return nil
+}
+
+// TransactionReport reports how a transaction sent directly was mined.
+type TransactionReport struct {
+	Nonce uint64 `json:"nonce"`
+	// Hashes of the original transaction and its replacements, in order.
+	Hashes []common.Hash `json:"hashes"`
+	// MinedHash is the hash of the transaction which was mined.
+	MinedHash common.Hash `json:"minedHash"`
+}
+
+// Replaced reports whether the transaction was replaced at least once.
+func (r TransactionReport) Replaced() bool {
+	return len(r.Hashes) > 1
+}
+
+// TransactionReports returns the reports of the transactions sent directly by the strategy, nil for strategies not
+// sending transactions.
+func TransactionReports(strategy TransactionStrategy) []TransactionReport {
+	if r, ok := strategy.(interface{ Reports() []TransactionReport }); ok {
+		return r.Reports()
+	}
+	return nil
+}
+
+type replacementBackend interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// waitOrReplace waits for the transaction to be mined, replacing it with bumped fees every cfg.After, and returns
+// the mined transaction.
+func waitOrReplace(ctx context.Context, backend replacementBackend, opts *bind.TransactOpts, tx *types.Transaction, cfg ReplacementConfig) (*types.Transaction, TransactionReport, error) {
+	report := TransactionReport{Nonce: tx.Nonce(), Hashes: []common.Hash{tx.Hash()}}
+	sent := []*types.Transaction{tx}
+
+	for i := 0; ; i++ {
+		last := i == cfg.MaxReplacements
+		waitCtx, cancel := context.WithTimeout(ctx, cfg.After)
+		if last {
+			// Out of replacements, wait for any of the transactions as long as the caller allows
+			cancel()
+			waitCtx, cancel = context.WithCancel(ctx)
+		}
+		mined, err := waitAnyMined(waitCtx, backend, sent, cfg.PollInterval)
+		cancel()
+		if err == nil {
+			report.MinedHash = mined.Hash()
+			return mined, report, nil
+		}
+		if last || ctx.Err() != nil {
+			return nil, report, fmt.Errorf("transaction with nonce %d was not mined: %w", tx.Nonce(), err)
+		}
+
+		replacement, err := bumpFees(sent[len(sent)-1], cfg.FeeBumpPercent)
+		if err != nil {
+			return nil, report, err
+		}
+		signed, err := opts.Signer(opts.From, replacement)
+		if err != nil {
+			return nil, report, fmt.Errorf("failed to sign replacement transaction: %w", err)
+		}
+		if err = backend.SendTransaction(ctx, signed); err != nil {
+			return nil, report, fmt.Errorf("failed to send replacement transaction with nonce %d: %w", tx.Nonce(), err)
+		}
+		sent = append(sent, signed)
+		report.Hashes = append(report.Hashes, signed.Hash())
+	}
+}
+
+// waitAnyMined polls the receipts of the transactions until one of them is mined.
+func waitAnyMined(ctx context.Context, backend replacementBackend, txs []*types.Transaction, interval time.Duration) (*types.Transaction, error) {
+	if interval == 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, tx := range txs {
+			receipt, err := backend.TransactionReceipt(ctx, tx.Hash())
+			if err == nil && receipt != nil {
+				return tx, nil
+			}
+			if err != nil && !errors.Is(err, ethereum.NotFound) {
+				return nil, fmt.Errorf("failed to get receipt of %s: %w", tx.Hash(), err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// bumpFees returns an unsigned copy of the transaction with its fees increased by percent.
+func bumpFees(tx *types.Transaction, percent int64) (*types.Transaction, error) {
+	bump := func(v *big.Int) *big.Int {
+		out := new(big.Int).Mul(v, big.NewInt(100+percent))
+		return out.Div(out, big.NewInt(100)).Add(out, big.NewInt(1))
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: bump(tx.GasPrice()),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}), nil
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  bump(tx.GasTipCap()),
+			GasFeeCap:  bump(tx.GasFeeCap()),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	default:
+		return nil, fmt.Errorf("cannot replace transaction of type %d", tx.Type())
+	}
+}
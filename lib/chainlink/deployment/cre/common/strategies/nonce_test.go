@@ -0,0 +1,169 @@
+package strategies
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+)
+
+// stuckClient never mines the first sent transactions, only the one sent at index minedAt.
+type stuckClient struct {
+	exportClient
+	mu           sync.Mutex
+	pendingCalls int
+	minedAt      int
+	// external is the number of transactions sent by another process
+	external uint64
+}
+
+func (c *stuckClient) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pendingCalls++
+	return 7 + c.external, nil
+}
+
+func (c *stuckClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.exportClient.SendTransaction(ctx, tx)
+}
+
+func (c *stuckClient) TransactionReceipt(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.sent) > c.minedAt && c.sent[c.minedAt].Hash() == hash {
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func TestNonceManager(t *testing.T) {
+	client := &stuckClient{}
+	sender := common.HexToAddress("0x01")
+
+	m := SharedNonceManager(chainsel.TEST_90000001.Selector, client, sender)
+	require.Same(t, m, SharedNonceManager(chainsel.TEST_90000001.Selector, client, sender))
+	require.NotSame(t, m, SharedNonceManager(chainsel.TEST_90000002.Selector, client, sender))
+	// another client of the chain, e.g. of another environment, does not share the nonces
+	require.NotSame(t, m, SharedNonceManager(chainsel.TEST_90000001.Selector, &stuckClient{}, sender))
+
+	for _, want := range []uint64{7, 8, 9} {
+		nonce, err := m.Next(t.Context(), client, sender)
+		require.NoError(t, err)
+		assert.Equal(t, want, nonce)
+	}
+
+	// the pending nonce of the chain is used when it is ahead of the local one
+	client.external = 5
+	nonce, err := m.Next(t.Context(), client, sender)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), nonce)
+
+	client.external = 0
+	m.Reset()
+	nonce, err = m.Next(t.Context(), client, sender)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), nonce)
+}
+
+func TestReplacementConfig_Validate(t *testing.T) {
+	valid := ReplacementConfig{After: time.Minute, FeeBumpPercent: 10, MaxReplacements: 3}
+	require.NoError(t, valid.Validate())
+
+	low := valid
+	low.FeeBumpPercent = 5
+	require.ErrorContains(t, low.Validate(), "feeBumpPercent must be at least 10")
+
+	none := valid
+	none.MaxReplacements = 0
+	require.ErrorContains(t, none.Validate(), "maxReplacements must be at least 1")
+}
+
+func TestBumpFees(t *testing.T) {
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     3,
+		GasTipCap: big.NewInt(100),
+		GasFeeCap: big.NewInt(1000),
+		Gas:       21000,
+	})
+
+	bumped, err := bumpFees(tx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), bumped.Nonce())
+	assert.Equal(t, big.NewInt(111), bumped.GasTipCap())
+	assert.Equal(t, big.NewInt(1101), bumped.GasFeeCap())
+	assert.Equal(t, tx.Gas(), bumped.Gas())
+}
+
+func TestSimpleTransaction_Replacement(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1))
+	require.NoError(t, err)
+
+	client := &stuckClient{minedAt: 2}
+	var confirmed []common.Hash
+	s := &SimpleTransaction{
+		Chain: cldf_evm.Chain{
+			Selector:    chainsel.TEST_90000001.Selector,
+			Client:      client,
+			DeployerKey: opts,
+			Confirm: func(tx *types.Transaction) (uint64, error) {
+				confirmed = append(confirmed, tx.Hash())
+				return 0, nil
+			},
+		},
+		Nonces:      &NonceManager{},
+		Replacement: &ReplacementConfig{After: 20 * time.Millisecond, FeeBumpPercent: 10, MaxReplacements: 3, PollInterval: time.Millisecond},
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"foo","inputs":[],"outputs":[]}]`))
+	require.NoError(t, err)
+	contract := bind.NewBoundContract(common.HexToAddress("0x0b"), parsed, client, client, client)
+
+	_, tx, err := s.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.Transact(opts, "foo")
+	})
+	require.NoError(t, err)
+
+	require.Len(t, client.sent, 3)
+	assert.Equal(t, client.sent[2].Hash(), tx.Hash())
+	assert.Equal(t, []common.Hash{tx.Hash()}, confirmed)
+	for _, sent := range client.sent {
+		assert.Equal(t, uint64(7), sent.Nonce())
+	}
+	assert.Equal(t, 1, client.sent[1].GasFeeCap().Cmp(client.sent[0].GasFeeCap()))
+
+	reports := TransactionReports(WithHooks(s))
+	require.Len(t, reports, 1)
+	assert.True(t, reports[0].Replaced())
+	assert.Equal(t, uint64(7), reports[0].Nonce)
+	assert.Len(t, reports[0].Hashes, 3)
+	assert.Equal(t, tx.Hash(), reports[0].MinedHash)
+
+	// The next transaction gets the next local nonce, the pending nonce of the chain being behind
+	client.minedAt = 3
+	_, tx, err = s.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.Transact(opts, "foo")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), tx.Nonce())
+	assert.False(t, TransactionReports(s)[1].Replaced())
+}
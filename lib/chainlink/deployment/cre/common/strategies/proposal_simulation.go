@@ -0,0 +1,301 @@
+package strategies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmsevmsdk "github.com/smartcontractkit/mcms/sdk/evm"
+	"github.com/smartcontractkit/mcms/sdk/evm/bindings"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+)
+
+// Stages of a simulated proposal operation.
+const (
+	// StageSchedule is the call of the MCM to the timelock, i.e. scheduleBatch, cancel or bypasserExecuteBatch.
+	StageSchedule = "schedule"
+	// StageExecute is the executeBatch call of the timelock executor, once the delay has passed.
+	StageExecute = "execute"
+)
+
+// ProposalSimulationResult is the simulation of a call of a proposal operation.
+type ProposalSimulationResult struct {
+	SimulationResult
+	Stage string `json:"stage"`
+	// OperationIndex is the index of the batch operation in the proposal.
+	OperationIndex int `json:"operationIndex"`
+	// TransactionIndex is the index of the reverting transaction of an executed batch operation, -1 otherwise.
+	TransactionIndex int `json:"transactionIndex"`
+}
+
+// ProposalSimulationReport is the simulation of all the operations of a proposal.
+type ProposalSimulationReport struct {
+	Description string                     `json:"description"`
+	Results     []ProposalSimulationResult `json:"results"`
+}
+
+// Reverted returns the results of the calls which reverted.
+func (r ProposalSimulationReport) Reverted() []ProposalSimulationResult {
+	var out []ProposalSimulationResult
+	for _, res := range r.Results {
+		if res.Reverted {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// Err describes the reverted calls, nil if none reverted.
+func (r ProposalSimulationReport) Err() error {
+	reverted := r.Reverted()
+	if len(reverted) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(reverted))
+	for _, res := range reverted {
+		msgs = append(msgs, fmt.Sprintf("operation %d transaction %d (%s) on chain %d to %s: %s",
+			res.OperationIndex, res.TransactionIndex, res.Stage, res.ChainSelector, res.To, res.RevertReason))
+	}
+	return fmt.Errorf("%d calls of proposal %q revert: %s", len(reverted), r.Description, strings.Join(msgs, "; "))
+}
+
+// SimulateProposal executes the proposal on forks of the target chains, as MCMS would: each batch operation is
+// scheduled from the MCM, then once the time of the fork is past the delay, each batch is executed through the
+// timelock from its first executor. The calls are mined on the forks, so a call depending on the effects of a previous
+// operation is simulated with them.
+// Reverts are decoded with the ABI of the target, keyed by address in abis. Since the timelock does not bubble up the
+// revert of a call, a reverted batch is diagnosed by calling each of its calls from the timelock on its own.
+// Only EVM operations are simulated.
+func SimulateProposal(ctx context.Context, forks map[uint64]ForkClient, proposal *mcmslib.TimelockProposal, abis map[common.Address]string) (ProposalSimulationReport, error) {
+	report := ProposalSimulationReport{Description: proposal.Description}
+
+	converter := mcmsevmsdk.NewTimelockConverter()
+	predecessors := make(map[mcmstypes.ChainSelector]common.Hash)
+
+	// scheduled batch operations, executed once the delay has passed
+	type scheduledOp struct {
+		index       int
+		fork        ForkClient
+		timelock    common.Address
+		predecessor common.Hash
+	}
+	var toExecute []scheduledOp
+
+	for i, bop := range proposal.Operations {
+		family, err := chainsel.GetSelectorFamily(uint64(bop.ChainSelector))
+		if err != nil {
+			return ProposalSimulationReport{}, fmt.Errorf("failed to get family of chain %d: %w", bop.ChainSelector, err)
+		}
+		if family != chainsel.FamilyEVM {
+			continue
+		}
+		fork, ok := forks[uint64(bop.ChainSelector)]
+		if !ok {
+			return ProposalSimulationReport{}, fmt.Errorf("no fork of chain %d", bop.ChainSelector)
+		}
+		metadata, ok := proposal.ChainMetadata[bop.ChainSelector]
+		if !ok {
+			return ProposalSimulationReport{}, fmt.Errorf("missing chain metadata for chain %d", bop.ChainSelector)
+		}
+		timelock := common.HexToAddress(proposal.TimelockAddresses[bop.ChainSelector])
+		mcm := common.HexToAddress(metadata.MCMAddress)
+
+		predecessor := predecessors[bop.ChainSelector]
+		scheduled, opID, err := converter.ConvertBatchToChainOperations(ctx, metadata, bop,
+			timelock.Hex(), mcm.Hex(), proposal.Delay, proposal.Action, predecessor, proposal.Salt())
+		if err != nil {
+			return ProposalSimulationReport{}, fmt.Errorf("failed to convert operation %d: %w", i, err)
+		}
+		predecessors[bop.ChainSelector] = opID
+
+		reverted := false
+		for _, op := range scheduled {
+			res, err := sendCall(ctx, fork, uint64(bop.ChainSelector), mcm, op.Transaction, abis)
+			if err != nil {
+				return ProposalSimulationReport{}, fmt.Errorf("failed to simulate operation %d: %w", i, err)
+			}
+			reverted = reverted || res.Reverted
+			report.Results = append(report.Results, ProposalSimulationResult{SimulationResult: res, Stage: StageSchedule, OperationIndex: i, TransactionIndex: -1})
+		}
+
+		// Bypassed calls are executed by the schedule stage, cancelled calls are never executed
+		if proposal.Action == mcmstypes.TimelockActionSchedule && !reverted {
+			toExecute = append(toExecute, scheduledOp{index: i, fork: fork, timelock: timelock, predecessor: predecessor})
+		}
+	}
+	if len(toExecute) == 0 {
+		return report, nil
+	}
+
+	increased := make(map[ForkClient]bool)
+	for _, op := range toExecute {
+		if !increased[op.fork] {
+			if err := op.fork.IncreaseTime(ctx, proposal.Delay.Duration+time.Second); err != nil {
+				return ProposalSimulationReport{}, fmt.Errorf("failed to move time past the delay: %w", err)
+			}
+			increased[op.fork] = true
+		}
+
+		res, err := executeBatch(ctx, op.fork, op.timelock, proposal.Operations[op.index], op.predecessor, proposal.Salt(), abis)
+		if err != nil {
+			return ProposalSimulationReport{}, fmt.Errorf("failed to execute operation %d: %w", op.index, err)
+		}
+		res.OperationIndex = op.index
+		report.Results = append(report.Results, res)
+	}
+
+	return report, nil
+}
+
+// executeBatch executes the scheduled batch operation through the timelock, from its first executor. If the batch
+// reverts, the result is the one of its first call reverting on its own.
+func executeBatch(ctx context.Context, fork ForkClient, timelockAddr common.Address, bop mcmstypes.BatchOperation, predecessor, salt common.Hash, abis map[common.Address]string) (ProposalSimulationResult, error) {
+	timelock, err := bindings.NewRBACTimelock(timelockAddr, fork)
+	if err != nil {
+		return ProposalSimulationResult{}, fmt.Errorf("failed to bind timelock: %w", err)
+	}
+	role, err := timelock.EXECUTORROLE(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return ProposalSimulationResult{}, fmt.Errorf("failed to get executor role: %w", err)
+	}
+	executor, err := timelock.GetRoleMember(&bind.CallOpts{Context: ctx}, role, big.NewInt(0))
+	if err != nil {
+		return ProposalSimulationResult{}, fmt.Errorf("failed to get executor of timelock %s: %w", timelockAddr, err)
+	}
+
+	calls := make([]bindings.RBACTimelockCall, len(bop.Transactions))
+	for i, tx := range bop.Transactions {
+		value, err := transactionValue(tx)
+		if err != nil {
+			return ProposalSimulationResult{}, err
+		}
+		calls[i] = bindings.RBACTimelockCall{Target: common.HexToAddress(tx.To), Data: tx.Data, Value: value}
+	}
+	timelockABI, err := bindings.RBACTimelockMetaData.GetAbi()
+	if err != nil {
+		return ProposalSimulationResult{}, err
+	}
+	data, err := timelockABI.Pack("executeBatch", calls, predecessor, salt)
+	if err != nil {
+		return ProposalSimulationResult{}, fmt.Errorf("failed to pack executeBatch: %w", err)
+	}
+
+	res, err := sendCall(ctx, fork, uint64(bop.ChainSelector), executor, mcmstypes.Transaction{To: timelockAddr.Hex(), Data: data}, abis)
+	if err != nil {
+		return ProposalSimulationResult{}, err
+	}
+	result := ProposalSimulationResult{SimulationResult: res, Stage: StageExecute, TransactionIndex: -1}
+	if !res.Reverted {
+		return result, nil
+	}
+
+	for j, call := range calls {
+		_, err := fork.CallContract(ctx, ethereum.CallMsg{From: timelockAddr, To: &call.Target, Value: call.Value, Data: call.Data}, nil)
+		if err != nil {
+			result.TransactionIndex = j
+			result.RevertReason = decodeRevert(call.Target, err, abis)
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// sendCall sends the transaction from the sender on the fork. The call is checked first, so that its revert reason is
+// decoded, and a reverting call is not sent.
+func sendCall(ctx context.Context, fork ForkClient, selector uint64, from common.Address, tx mcmstypes.Transaction, abis map[common.Address]string) (SimulationResult, error) {
+	value, err := transactionValue(tx)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	to := common.HexToAddress(tx.To)
+	result := SimulationResult{
+		ChainSelector: selector,
+		From:          from,
+		To:            to,
+		Data:          tx.Data,
+	}
+	if _, err = fork.CallContract(ctx, ethereum.CallMsg{From: from, To: &to, Value: value, Data: tx.Data}, nil); err != nil {
+		result.Reverted = true
+		result.RevertReason = decodeRevert(to, err, abis)
+		return result, nil
+	}
+
+	receipt, err := fork.SendTransactionFrom(ctx, from, to, value, tx.Data)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		result.Reverted = true
+		result.RevertReason = fmt.Sprintf("transaction %s reverted", receipt.TxHash)
+	}
+
+	return result, nil
+}
+
+func transactionValue(tx mcmstypes.Transaction) (*big.Int, error) {
+	var fields mcmsevmsdk.AdditionalFields
+	if len(tx.AdditionalFields) > 0 {
+		if err := json.Unmarshal(tx.AdditionalFields, &fields); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal additional fields: %w", err)
+		}
+	}
+	if fields.Value == nil {
+		return big.NewInt(0), nil
+	}
+	return fields.Value, nil
+}
+
+func decodeRevert(to common.Address, err error, abis map[common.Address]string) string {
+	if contractABI, ok := abis[to]; ok {
+		return cldf.DecodeErr(contractABI, err).Error()
+	}
+	return err.Error()
+}
+
+type SimulateProposalDeps struct {
+	// Forks of the chains of the proposal, keyed by chain selector.
+	Forks map[uint64]ForkClient
+}
+
+type SimulateProposalInput struct {
+	Proposal mcmslib.TimelockProposal
+	// ABIs of the targets, used to decode reverts.
+	ABIs map[common.Address]string
+}
+
+// SimulateProposalOp simulates a proposal on forks, so the report is part of the changeset output. It fails if any call reverts.
+var SimulateProposalOp = operations.NewOperation[SimulateProposalInput, ProposalSimulationReport, SimulateProposalDeps](
+	"simulate-proposal-op",
+	semver.MustParse("1.0.0"),
+	"Simulate an MCMS proposal on forked chains",
+	func(b operations.Bundle, deps SimulateProposalDeps, input SimulateProposalInput) (ProposalSimulationReport, error) {
+		if len(input.Proposal.Operations) == 0 {
+			return ProposalSimulationReport{}, errors.New("proposal has no operations")
+		}
+
+		report, err := SimulateProposal(b.GetContext(), deps.Forks, &input.Proposal, input.ABIs)
+		if err != nil {
+			return ProposalSimulationReport{}, err
+		}
+		b.Logger.Infof("Simulated %d calls of proposal %q, %d reverted", len(report.Results), report.Description, len(report.Reverted()))
+
+		return report, report.Err()
+	},
+)
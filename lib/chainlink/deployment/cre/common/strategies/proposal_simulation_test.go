@@ -0,0 +1,164 @@
+package strategies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	mcmslib "github.com/smartcontractkit/mcms"
+	"github.com/smartcontractkit/mcms/sdk/evm/bindings"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf_evm_provider "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm/provider"
+	capabilities_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/capabilities_registry_wrapper_v2"
+)
+
+// simForkClient is a fork client of a simulated chain, which sends transactions from the accounts it has the keys of.
+type simForkClient struct {
+	*cldf_evm_provider.SimClient
+	keys map[common.Address]*bind.TransactOpts
+}
+
+func (c *simForkClient) SendTransactionFrom(ctx context.Context, from, to common.Address, value *big.Int, data []byte) (*types.Receipt, error) {
+	key, ok := c.keys[from]
+	if !ok {
+		return nil, fmt.Errorf("no key for %s", from)
+	}
+	tx, err := bind.NewBoundContract(to, abi.ABI{}, c, c, c).RawTransact(&bind.TransactOpts{
+		From:    key.From,
+		Signer:  key.Signer,
+		Value:   value,
+		Context: ctx,
+	}, data)
+	if err != nil {
+		return nil, err
+	}
+	c.Commit()
+	return bind.WaitMined(ctx, c, tx)
+}
+
+func (c *simForkClient) IncreaseTime(_ context.Context, d time.Duration) error {
+	return c.Backend().AdjustTime(d)
+}
+
+func TestSimulateProposal(t *testing.T) {
+	sel := chainsel.TEST_90000001.Selector
+	bc, err := cldf_evm_provider.NewSimChainProvider(t, sel, cldf_evm_provider.SimChainProviderConfig{}).Initialize(t.Context())
+	require.NoError(t, err)
+	chain, ok := bc.(cldf_evm.Chain)
+	require.True(t, ok)
+	client, ok := chain.Client.(*cldf_evm_provider.SimClient)
+	require.True(t, ok)
+	deployer := chain.DeployerKey.From
+	fork := &simForkClient{SimClient: client, keys: map[common.Address]*bind.TransactOpts{deployer: chain.DeployerKey}}
+
+	// The deployer stands for the MCM proposing, and for the executor of the timelock
+	delay := time.Hour
+	timelock, tx, _, err := bindings.DeployRBACTimelock(chain.DeployerKey, client, big.NewInt(int64(delay.Seconds())),
+		deployer, []common.Address{deployer}, []common.Address{deployer}, []common.Address{deployer}, []common.Address{deployer})
+	require.NoError(t, err)
+	_, err = chain.Confirm(tx)
+	require.NoError(t, err)
+
+	registryAddr, tx, registry, err := capabilities_registry_v2.DeployCapabilitiesRegistry(chain.DeployerKey, client,
+		capabilities_registry_v2.CapabilitiesRegistryConstructorParams{})
+	require.NoError(t, err)
+	_, err = chain.Confirm(tx)
+	require.NoError(t, err)
+	tx, err = registry.TransferOwnership(chain.DeployerKey, timelock)
+	require.NoError(t, err)
+	_, err = chain.Confirm(tx)
+	require.NoError(t, err)
+
+	registryABI, err := capabilities_registry_v2.CapabilitiesRegistryMetaData.GetAbi()
+	require.NoError(t, err)
+	acceptOwnership, err := registryABI.Pack("acceptOwnership")
+	require.NoError(t, err)
+	addNop := func(name string) []byte {
+		data, err := registryABI.Pack("addNodeOperators", []capabilities_registry_v2.CapabilitiesRegistryNodeOperatorParams{
+			{Admin: common.HexToAddress("0x01"), Name: name},
+		})
+		require.NoError(t, err)
+		return data
+	}
+	call := func(data []byte) mcmstypes.Transaction {
+		return mcmstypes.Transaction{To: registryAddr.Hex(), Data: data, AdditionalFields: json.RawMessage(`{"value": 0}`)}
+	}
+	newProposal := func(ops ...mcmstypes.BatchOperation) *mcmslib.TimelockProposal {
+		return &mcmslib.TimelockProposal{
+			BaseProposal: mcmslib.BaseProposal{
+				Description:   "test",
+				ChainMetadata: map[mcmstypes.ChainSelector]mcmstypes.ChainMetadata{mcmstypes.ChainSelector(sel): {MCMAddress: deployer.Hex()}},
+			},
+			Action:            mcmstypes.TimelockActionSchedule,
+			Delay:             mcmstypes.NewDuration(delay),
+			TimelockAddresses: map[mcmstypes.ChainSelector]string{mcmstypes.ChainSelector(sel): timelock.Hex()},
+			Operations:        ops,
+		}
+	}
+	forks := map[uint64]ForkClient{sel: fork}
+	abis := map[common.Address]string{registryAddr: capabilities_registry_v2.CapabilitiesRegistryABI}
+
+	// The second operation depends on the first: the timelock is not the owner of the registry until it accepts
+	_, err = client.CallContract(t.Context(), ethereum.CallMsg{From: timelock, To: &registryAddr, Data: addNop("nop-1")}, nil)
+	require.Error(t, err)
+
+	proposal := newProposal(
+		mcmstypes.BatchOperation{ChainSelector: mcmstypes.ChainSelector(sel), Transactions: []mcmstypes.Transaction{call(acceptOwnership)}},
+		mcmstypes.BatchOperation{ChainSelector: mcmstypes.ChainSelector(sel), Transactions: []mcmstypes.Transaction{call(addNop("nop-1"))}},
+	)
+	report, err := SimulateProposal(t.Context(), forks, proposal, abis)
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+
+	// Both operations are scheduled by the MCM, then executed through the timelock once the delay has passed
+	require.Len(t, report.Results, 4)
+	for i, res := range report.Results {
+		assert.Equal(t, timelock, res.To)
+		assert.Equal(t, i%2, res.OperationIndex)
+		assert.Equal(t, -1, res.TransactionIndex)
+	}
+	assert.Equal(t, StageSchedule, report.Results[0].Stage)
+	assert.Equal(t, StageExecute, report.Results[2].Stage)
+
+	owner, err := registry.Owner(nil)
+	require.NoError(t, err)
+	assert.Equal(t, timelock, owner)
+	nop, err := registry.GetNodeOperator(nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "nop-1", nop.Name)
+
+	t.Run("reverted batch", func(t *testing.T) {
+		// The ownership was already accepted, so the second call of the batch reverts
+		proposal := newProposal(mcmstypes.BatchOperation{
+			ChainSelector: mcmstypes.ChainSelector(sel),
+			Transactions:  []mcmstypes.Transaction{call(addNop("nop-2")), call(acceptOwnership)},
+		})
+		report, err := SimulateProposal(t.Context(), forks, proposal, abis)
+		require.NoError(t, err)
+
+		reverted := report.Reverted()
+		require.Len(t, reverted, 1)
+		assert.Equal(t, StageExecute, reverted[0].Stage)
+		assert.Equal(t, 0, reverted[0].OperationIndex)
+		assert.Equal(t, 1, reverted[0].TransactionIndex)
+		require.ErrorContains(t, report.Err(), `1 calls of proposal "test" revert: operation 0 transaction 1 (execute)`)
+	})
+
+	t.Run("missing fork", func(t *testing.T) {
+		_, err := SimulateProposal(t.Context(), nil, proposal, nil)
+		require.ErrorContains(t, err, "no fork of chain")
+	})
+}
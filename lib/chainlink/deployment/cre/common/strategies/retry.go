@@ -0,0 +1,172 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+)
+
+// RetryPolicy configures the retries of Apply on transient errors.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts, including the first one.
+	MaxAttempts int `json:"maxAttempts" yaml:"maxAttempts"`
+	// Backoff is the wait before the first retry, doubled on every retry.
+	Backoff time.Duration `json:"backoff" yaml:"backoff"`
+	// MaxBackoff caps the wait between retries, no cap if zero.
+	MaxBackoff time.Duration `json:"maxBackoff,omitempty" yaml:"maxBackoff,omitempty"`
+	// Retryable classifies the errors worth retrying, IsRetryableError if nil.
+	Retryable func(error) bool `json:"-" yaml:"-"`
+}
+
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 1 {
+		return errors.New("maxAttempts must be at least 1")
+	}
+	if p.Backoff < 0 || p.MaxBackoff < 0 {
+		return errors.New("backoff and maxBackoff must not be negative")
+	}
+	return nil
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryableError(err)
+}
+
+// backoff returns the wait before the given retry, starting at 1.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.Backoff
+	for i := 1; i < retry && (p.MaxBackoff == 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff != 0 && wait > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return wait
+}
+
+// retryableMessages are the RPC errors which are usually transient. Reverts are never retried.
+var retryableMessages = []string{
+	"timeout",
+	"timed out",
+	"connection refused",
+	"connection reset",
+	"EOF",
+	"too many requests",
+	"429",
+	"nonce too low",
+	"replacement transaction underpriced",
+	"already known",
+	"not mined",
+}
+
+// IsRetryableError reports whether the error is transient: timeouts, network errors and nonce races.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, "execution reverted") {
+		return false
+	}
+	for _, m := range retryableMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// AlreadyAppliedFunc checks the contract state before a retry. It returns true when a previous attempt landed,
+// e.g. the node operators are registered although the confirmation timed out, so the call must not be sent again.
+type AlreadyAppliedFunc func() (bool, error)
+
+// RetryingTransaction retries the calls of its inner strategy on retryable errors, when they are applied with a state
+// check, see ApplyChecked.
+type RetryingTransaction struct {
+	Inner  TransactionStrategy
+	Policy RetryPolicy
+
+	sleep func(time.Duration)
+}
+
+func NewRetryingTransaction(inner TransactionStrategy, policy RetryPolicy) *RetryingTransaction {
+	return &RetryingTransaction{Inner: inner, Policy: policy}
+}
+
+// Apply applies the call once, without retry: an error does not prove that no transaction was sent, e.g. a send
+// timing out may still land, so only ApplyChecked retries.
+func (r *RetryingTransaction) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	return r.Inner.Apply(callFn)
+}
+
+// ApplyChecked retries the call on retryable errors. Before each retry alreadyApplied checks whether a previous
+// attempt landed, in which case no transaction is returned, since the one which landed is unknown. Without check, the
+// call is applied once, like Apply.
+func (r *RetryingTransaction) ApplyChecked(callFn func(opts *bind.TransactOpts) (*types.Transaction, error), alreadyApplied AlreadyAppliedFunc) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	if alreadyApplied == nil {
+		return r.Apply(callFn)
+	}
+	sleep := r.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	var errs []error
+	for attempt := 1; ; attempt++ {
+		op, tx, err := r.Inner.Apply(callFn)
+		if err == nil {
+			return op, tx, nil
+		}
+		errs = append(errs, fmt.Errorf("attempt %d: %w", attempt, err))
+
+		if attempt >= r.Policy.MaxAttempts || !r.Policy.retryable(err) {
+			return op, tx, errors.Join(errs...)
+		}
+
+		sleep(r.Policy.backoff(attempt))
+
+		applied, checkErr := alreadyApplied()
+		if checkErr != nil {
+			return op, tx, errors.Join(append(errs, fmt.Errorf("failed to check state before retry: %w", checkErr))...)
+		}
+		if applied {
+			return op, nil, nil
+		}
+	}
+}
+
+func (r *RetryingTransaction) BuildProposal(operations []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
+	return r.Inner.BuildProposal(operations)
+}
+
+// Reports returns the transaction reports of the inner strategy, if any.
+func (r *RetryingTransaction) Reports() []TransactionReport {
+	return TransactionReports(r.Inner)
+}
+
+// ApplyWithCheck applies the call with the strategy, passing the state check to retrying strategies.
+func ApplyWithCheck(strategy TransactionStrategy, callFn func(opts *bind.TransactOpts) (*types.Transaction, error), alreadyApplied AlreadyAppliedFunc) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	if r, ok := strategy.(*RetryingTransaction); ok {
+		return r.ApplyChecked(callFn, alreadyApplied)
+	}
+	return strategy.Apply(callFn)
+}
@@ -0,0 +1,133 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyStrategy fails with the scripted errors, then succeeds. With sent, the failed attempts return a transaction.
+type flakyStrategy struct {
+	errs  []error
+	sent  bool
+	calls int
+}
+
+func (f *flakyStrategy) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	f.calls++
+	tx, err := callFn(&bind.TransactOpts{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.calls <= len(f.errs) {
+		if f.sent {
+			return nil, tx, f.errs[f.calls-1]
+		}
+		return nil, nil, f.errs[f.calls-1]
+	}
+	return nil, tx, nil
+}
+
+func (f *flakyStrategy) BuildProposal([]mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
+	return nil, nil
+}
+
+func TestIsRetryableError(t *testing.T) {
+	assert.True(t, IsRetryableError(context.DeadlineExceeded))
+	assert.True(t, IsRetryableError(fmt.Errorf("send: %w", errors.New("read tcp: connection reset by peer"))))
+	assert.True(t, IsRetryableError(errors.New("nonce too low")))
+	assert.False(t, IsRetryableError(errors.New("execution reverted: timeout")))
+	assert.False(t, IsRetryableError(context.Canceled))
+	assert.False(t, IsRetryableError(nil))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 3 * time.Second}
+	assert.Equal(t, time.Second, p.backoff(1))
+	assert.Equal(t, 2*time.Second, p.backoff(2))
+	assert.Equal(t, 3*time.Second, p.backoff(3))
+	assert.Equal(t, 3*time.Second, p.backoff(10))
+
+	require.ErrorContains(t, RetryPolicy{}.Validate(), "maxAttempts must be at least 1")
+}
+
+func TestRetryingTransaction(t *testing.T) {
+	timeout := errors.New("request timed out")
+	var waits []time.Duration
+	newRetrying := func(inner TransactionStrategy) *RetryingTransaction {
+		r := NewRetryingTransaction(inner, RetryPolicy{MaxAttempts: 3, Backoff: time.Second})
+		r.sleep = func(d time.Duration) { waits = append(waits, d) }
+		return r
+	}
+
+	notApplied := func() (bool, error) { return false, nil }
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		waits = nil
+		inner := &flakyStrategy{errs: []error{timeout, timeout}}
+		_, tx, err := ApplyWithCheck(newRetrying(inner), txWithData(1), notApplied)
+		require.NoError(t, err)
+		assert.NotNil(t, tx)
+		assert.Equal(t, 3, inner.calls)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		inner := &flakyStrategy{errs: []error{timeout, timeout, timeout}}
+		_, _, err := ApplyWithCheck(newRetrying(inner), txWithData(1), notApplied)
+		require.ErrorContains(t, err, "attempt 3: request timed out")
+		assert.Equal(t, 3, inner.calls)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		inner := &flakyStrategy{errs: []error{errors.New("execution reverted")}}
+		_, _, err := ApplyWithCheck(newRetrying(inner), txWithData(1), notApplied)
+		require.Error(t, err)
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("does not retry without state check", func(t *testing.T) {
+		// a send timing out returns no transaction, although it may still land
+		inner := &flakyStrategy{errs: []error{timeout}}
+		_, _, err := newRetrying(inner).Apply(txWithData(1))
+		require.ErrorContains(t, err, "request timed out")
+		assert.Equal(t, 1, inner.calls)
+
+		inner = &flakyStrategy{errs: []error{timeout}, sent: true}
+		_, _, err = ApplyWithCheck(newRetrying(inner), txWithData(1), nil)
+		require.Error(t, err)
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("stops when the previous attempt landed", func(t *testing.T) {
+		inner := &flakyStrategy{errs: []error{timeout}, sent: true}
+		_, tx, err := ApplyWithCheck(newRetrying(inner), txWithData(1), func() (bool, error) {
+			return true, nil
+		})
+		require.NoError(t, err)
+		// the transaction which landed is unknown
+		assert.Nil(t, tx)
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("retries when the previous attempt did not land", func(t *testing.T) {
+		inner := &flakyStrategy{errs: []error{timeout}, sent: true}
+		checks := 0
+		_, _, err := ApplyWithCheck(newRetrying(inner), txWithData(1), func() (bool, error) {
+			checks++
+			return false, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, inner.calls)
+		assert.Equal(t, 1, checks)
+	})
+}
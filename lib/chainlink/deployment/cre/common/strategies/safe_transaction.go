@@ -0,0 +1,234 @@
+package strategies
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+// safeNonceSelector is the selector of the nonce() getter of Safe contracts.
+var safeNonceSelector = hexutil.MustDecode("0xaffed0e0")
+
+// SafeTx is a Safe multisig transaction, with its EIP-712 payload and hash to sign.
+type SafeTx struct {
+	Safe      common.Address     `json:"safe"`
+	To        common.Address     `json:"to"`
+	Value     *big.Int           `json:"value"`
+	Data      hexutil.Bytes      `json:"data"`
+	Nonce     uint64             `json:"nonce"`
+	TypedData apitypes.TypedData `json:"typedData"`
+	Hash      common.Hash        `json:"safeTxHash"`
+}
+
+// SafeTransaction is a strategy for contracts owned by a Safe rather than MCMS. Each call is encoded as a Safe
+// transaction, with consecutive nonces starting at the current nonce of the Safe, to be signed by the Safe owners
+// or proposed to the Safe transaction service with Propose.
+// Like MCMSTransaction, Apply returns a batch operation since nothing is executed directly, but BuildProposal
+// returns ErrNoProposal.
+type SafeTransaction struct {
+	Chain cldf_evm.Chain
+	Safe  common.Address
+
+	mu        sync.Mutex
+	nextNonce *uint64
+	txs       []SafeTx
+}
+
+func (s *SafeTransaction) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	opts := cldf.SimTransactOpts()
+	opts.From = s.Safe
+
+	tx, err := callFn(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tx.To() == nil {
+		return nil, tx, errors.New("contract deployments are not supported through a Safe")
+	}
+
+	chainID, err := evmChainID(s.Chain.Selector)
+	if err != nil {
+		return nil, tx, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.nextNonce == nil {
+		nonce, err := s.safeNonce(opts.Context)
+		if err != nil {
+			return nil, tx, err
+		}
+		s.nextNonce = &nonce
+	}
+
+	safeTx, err := NewSafeTx(chainID, s.Safe, *tx.To(), tx.Value(), tx.Data(), *s.nextNonce)
+	if err != nil {
+		return nil, tx, err
+	}
+	s.txs = append(s.txs, safeTx)
+	*s.nextNonce++
+
+	op, err := proposalutils.BatchOperationForChain(s.Chain.Selector, tx.To().Hex(), tx.Data(), tx.Value(), "", nil)
+	if err != nil {
+		return nil, tx, err
+	}
+
+	return &op, tx, nil
+}
+
+func (s *SafeTransaction) BuildProposal(_ []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
+	return nil, ErrNoProposal
+}
+
+// Transactions returns the Safe transactions created so far, in nonce order.
+func (s *SafeTransaction) Transactions() []SafeTx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SafeTx(nil), s.txs...)
+}
+
+func (s *SafeTransaction) safeNonce(ctx context.Context) (uint64, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	out, err := s.Chain.Client.CallContract(ctx, ethereum.CallMsg{To: &s.Safe, Data: safeNonceSelector}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read nonce of Safe %s: %w", s.Safe, err)
+	}
+	nonce := new(big.Int).SetBytes(out)
+	if len(out) != 32 || !nonce.IsUint64() {
+		return 0, fmt.Errorf("invalid nonce returned by Safe %s: %x", s.Safe, out)
+	}
+	return nonce.Uint64(), nil
+}
+
+// NewSafeTx encodes a call from a Safe, without gas refund, as EIP-712 typed data.
+func NewSafeTx(chainID *big.Int, safe, to common.Address, value *big.Int, data []byte, nonce uint64) (SafeTx, error) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: safe.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             to.Hex(),
+			"value":          value.String(),
+			"data":           hexutil.Encode(data),
+			"operation":      "0",
+			"safeTxGas":      "0",
+			"baseGas":        "0",
+			"gasPrice":       "0",
+			"gasToken":       common.Address{}.Hex(),
+			"refundReceiver": common.Address{}.Hex(),
+			"nonce":          new(big.Int).SetUint64(nonce).String(),
+		},
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return SafeTx{}, fmt.Errorf("failed to hash Safe transaction: %w", err)
+	}
+
+	return SafeTx{
+		Safe:      safe,
+		To:        to,
+		Value:     value,
+		Data:      data,
+		Nonce:     nonce,
+		TypedData: typedData,
+		Hash:      common.BytesToHash(hash),
+	}, nil
+}
+
+// Propose submits the Safe transactions to the Safe transaction service at serviceURL, e.g.
+// https://safe-transaction-mainnet.safe.global, signed by sender, who must be an owner or delegate of the Safe.
+// sign returns the signature of a Safe transaction hash.
+func (s *SafeTransaction) Propose(ctx context.Context, serviceURL string, sender common.Address, sign func(hash common.Hash) ([]byte, error)) error {
+	for _, tx := range s.Transactions() {
+		signature, err := sign(tx.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to sign Safe transaction %d: %w", tx.Nonce, err)
+		}
+
+		body, err := json.Marshal(map[string]any{
+			"to":                      tx.To.Hex(),
+			"value":                   tx.Value.String(),
+			"data":                    tx.Data.String(),
+			"operation":               0,
+			"safeTxGas":               "0",
+			"baseGas":                 "0",
+			"gasPrice":                "0",
+			"gasToken":                common.Address{}.Hex(),
+			"refundReceiver":          common.Address{}.Hex(),
+			"nonce":                   tx.Nonce,
+			"contractTransactionHash": tx.Hash.Hex(),
+			"sender":                  sender.Hex(),
+			"signature":               hexutil.Encode(signature),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal Safe transaction %d: %w", tx.Nonce, err)
+		}
+
+		url := fmt.Sprintf("%s/api/v1/safes/%s/multisig-transactions/", strings.TrimSuffix(serviceURL, "/"), tx.Safe.Hex())
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to propose Safe transaction %d: %w", tx.Nonce, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			return fmt.Errorf("failed to propose Safe transaction %d: service returned %s", tx.Nonce, resp.Status)
+		}
+	}
+
+	return nil
+}
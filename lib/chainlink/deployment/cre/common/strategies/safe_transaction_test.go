@@ -0,0 +1,102 @@
+package strategies
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+)
+
+type safeClient struct {
+	cldf_evm.OnchainClient
+	nonce int64
+}
+
+func (c safeClient) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	return common.LeftPadBytes(big.NewInt(c.nonce).Bytes(), 32), nil
+}
+
+func TestNewSafeTx(t *testing.T) {
+	chainID := big.NewInt(1)
+	safe := common.HexToAddress("0x0a")
+	to := common.HexToAddress("0x0b")
+	data := []byte{0x01, 0x02}
+
+	safeTx, err := NewSafeTx(chainID, safe, to, nil, data, 7)
+	require.NoError(t, err)
+
+	// Hash computed as in the Safe contract
+	word := func(v *big.Int) []byte { return common.LeftPadBytes(v.Bytes(), 32) }
+	domainSeparator := crypto.Keccak256(
+		crypto.Keccak256([]byte("EIP712Domain(uint256 chainId,address verifyingContract)")),
+		word(chainID),
+		common.LeftPadBytes(safe.Bytes(), 32),
+	)
+	structHash := crypto.Keccak256(
+		crypto.Keccak256([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)")),
+		common.LeftPadBytes(to.Bytes(), 32),
+		word(big.NewInt(0)),
+		crypto.Keccak256(data),
+		word(big.NewInt(0)), word(big.NewInt(0)), word(big.NewInt(0)), word(big.NewInt(0)),
+		word(big.NewInt(0)), word(big.NewInt(0)),
+		word(big.NewInt(7)),
+	)
+	expected := crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash)
+	assert.Equal(t, common.BytesToHash(expected), safeTx.Hash)
+}
+
+func TestSafeTransaction(t *testing.T) {
+	safe := common.HexToAddress("0x0a")
+	to := common.HexToAddress("0x0b")
+	s := &SafeTransaction{
+		Chain: cldf_evm.Chain{Selector: chainsel.TEST_90000001.Selector, Client: safeClient{nonce: 3}},
+		Safe:  safe,
+	}
+
+	for i := range 2 {
+		op, _, err := s.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			assert.Equal(t, safe, opts.From)
+			return types.NewTx(&types.LegacyTx{To: &to, Data: []byte{byte(i)}}), nil
+		})
+		require.NoError(t, err)
+		require.NotNil(t, op)
+	}
+
+	txs := s.Transactions()
+	require.Len(t, txs, 2)
+	assert.Equal(t, uint64(3), txs[0].Nonce)
+	assert.Equal(t, uint64(4), txs[1].Nonce)
+	assert.NotEqual(t, txs[0].Hash, txs[1].Hash)
+
+	var proposed []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/safes/"+safe.Hex()+"/multisig-transactions/", r.URL.Path)
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		proposed = append(proposed, body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	sender := common.HexToAddress("0x0c")
+	err := s.Propose(t.Context(), server.URL, sender, func(hash common.Hash) ([]byte, error) {
+		return hash.Bytes(), nil
+	})
+	require.NoError(t, err)
+	require.Len(t, proposed, 2)
+	assert.Equal(t, txs[1].Hash.Hex(), proposed[1]["contractTransactionHash"])
+	assert.Equal(t, sender.Hex(), proposed[1]["sender"])
+}
@@ -0,0 +1,207 @@
+package strategies
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	mcmslib "github.com/smartcontractkit/mcms"
+	"github.com/smartcontractkit/mcms/sdk"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+// SigningPayload is what a signer of the MCM signs.
+type SigningPayload struct {
+	Signer common.Address `json:"signer"`
+	// Hash is the EIP-191 hash, signed with a private key.
+	Hash common.Hash `json:"hash"`
+	// Message is the hash without the EIP-191 prefix, for hardware wallets which add it themselves.
+	Message    common.Hash `json:"message"`
+	Root       common.Hash `json:"root"`
+	ValidUntil uint32      `json:"validUntil"`
+}
+
+// SignatureCollector collects the signatures of a proposal until the quorum of the MCM config is reached on every
+// chain of the proposal.
+type SignatureCollector struct {
+	proposal   *mcmslib.Proposal
+	inspectors map[mcmstypes.ChainSelector]sdk.Inspector
+	signable   *mcmslib.Signable
+	hash       common.Hash
+}
+
+// NewSignatureCollector converts the timelock proposal to the MCMS proposal which is signed.
+func NewSignatureCollector(ctx context.Context, env cldf.Environment, proposal *mcmslib.TimelockProposal) (*SignatureCollector, error) {
+	converters := make(map[mcmstypes.ChainSelector]sdk.TimelockConverter)
+	inspectors := make(map[mcmstypes.ChainSelector]sdk.Inspector)
+	for sel := range proposal.ChainMetadata {
+		converter, err := proposalutils.McmsTimelockConverterForChain(uint64(sel))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get converter for chain %d: %w", sel, err)
+		}
+		converters[sel] = converter
+
+		inspector, err := proposalutils.McmsInspectorForChain(env, uint64(sel))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inspector for chain %d: %w", sel, err)
+		}
+		inspectors[sel] = inspector
+	}
+
+	converted, _, err := proposal.Convert(ctx, converters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert proposal: %w", err)
+	}
+
+	return newSignatureCollector(&converted, inspectors)
+}
+
+func newSignatureCollector(proposal *mcmslib.Proposal, inspectors map[mcmstypes.ChainSelector]sdk.Inspector) (*SignatureCollector, error) {
+	signable, err := mcmslib.NewSignable(proposal, inspectors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signable proposal: %w", err)
+	}
+	hash, err := proposal.SigningHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute signing hash: %w", err)
+	}
+
+	return &SignatureCollector{proposal: proposal, inspectors: inspectors, signable: signable, hash: hash}, nil
+}
+
+// Payloads returns the payload of each signer of the MCM configs. The configs must be the same on all chains so
+// that a single set of signatures reaches the quorum everywhere.
+func (c *SignatureCollector) Payloads(ctx context.Context) ([]SigningPayload, error) {
+	if err := c.signable.ValidateConfigs(ctx); err != nil {
+		return nil, fmt.Errorf("MCM configs differ across chains: %w", err)
+	}
+	signers, err := c.signers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := c.proposal.SigningMessage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute signing message: %w", err)
+	}
+	tree, err := c.proposal.MerkleTree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute merkle tree: %w", err)
+	}
+
+	payloads := make([]SigningPayload, 0, len(signers))
+	for _, signer := range signers {
+		payloads = append(payloads, SigningPayload{
+			Signer:     signer,
+			Hash:       c.hash,
+			Message:    message,
+			Root:       tree.Root,
+			ValidUntil: c.proposal.ValidUntil,
+		})
+	}
+
+	return payloads, nil
+}
+
+// signers returns the sorted signers of the MCM config of the first chain of the proposal.
+func (c *SignatureCollector) signers(ctx context.Context) ([]common.Address, error) {
+	configs, err := c.signable.GetConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MCM configs: %w", err)
+	}
+	cfg, ok := configs[c.proposal.ChainSelectors()[0]]
+	if !ok || cfg == nil {
+		return nil, errors.New("MCM config not found")
+	}
+	signers := cfg.GetAllSigners()
+	slices.SortFunc(signers, func(a, b common.Address) int { return a.Cmp(b) })
+
+	return signers, nil
+}
+
+// Add verifies the signature is from a signer of the MCM config which has not signed yet and appends it to the
+// proposal. It returns the signer.
+func (c *SignatureCollector) Add(ctx context.Context, sig mcmstypes.Signature) (common.Address, error) {
+	signer, err := sig.Recover(c.hash)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	signers, err := c.signers(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !slices.Contains(signers, signer) {
+		return common.Address{}, fmt.Errorf("%s is not a signer of the MCM config", signer)
+	}
+	for _, existing := range c.proposal.Signatures {
+		if s, err := existing.Recover(c.hash); err == nil && s == signer {
+			return common.Address{}, fmt.Errorf("signer %s already signed", signer)
+		}
+	}
+
+	c.proposal.AppendSignature(sig)
+	return signer, nil
+}
+
+// AddFromReader adds the hex encoded signatures of the reader, one per line, e.g. from stdin. Blank lines and lines
+// starting with # are skipped.
+func (c *SignatureCollector) AddFromReader(ctx context.Context, r io.Reader) ([]common.Address, error) {
+	var signers []common.Address
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		raw, err := hexutil.Decode(text)
+		if err != nil {
+			return signers, fmt.Errorf("line %d: invalid hex signature: %w", line, err)
+		}
+		sig, err := mcmstypes.NewSignatureFromBytes(raw)
+		if err != nil {
+			return signers, fmt.Errorf("line %d: %w", line, err)
+		}
+		signer, err := c.Add(ctx, sig)
+		if err != nil {
+			return signers, fmt.Errorf("line %d: %w", line, err)
+		}
+		signers = append(signers, signer)
+	}
+
+	return signers, scanner.Err()
+}
+
+// AddFromFile adds the signatures of the file, see AddFromReader.
+func (c *SignatureCollector) AddFromFile(ctx context.Context, path string) ([]common.Address, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open signatures file: %w", err)
+	}
+	defer f.Close()
+
+	return c.AddFromReader(ctx, f)
+}
+
+// Finalize checks that the signatures reach the quorum of the MCM on every chain and returns the signed proposal,
+// ready to set the root and execute.
+func (c *SignatureCollector) Finalize(ctx context.Context) (*mcmslib.Proposal, error) {
+	if len(c.proposal.Signatures) == 0 {
+		return nil, errors.New("no signatures collected")
+	}
+	if _, err := c.signable.ValidateSignatures(ctx); err != nil {
+		return nil, fmt.Errorf("quorum not reached: %w", err)
+	}
+
+	return c.proposal, nil
+}
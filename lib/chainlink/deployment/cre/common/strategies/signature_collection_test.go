@@ -0,0 +1,133 @@
+package strategies
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	mcmslib "github.com/smartcontractkit/mcms"
+	"github.com/smartcontractkit/mcms/sdk"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type configInspector struct {
+	sdk.Inspector
+	config *mcmstypes.Config
+}
+
+func (i configInspector) GetConfig(context.Context, string) (*mcmstypes.Config, error) {
+	return i.config, nil
+}
+
+func TestSignatureCollector(t *testing.T) {
+	ctx := t.Context()
+	sel := mcmstypes.ChainSelector(chainsel.TEST_90000001.Selector)
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	signers := make([]common.Address, 3)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+		signers[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+	config, err := mcmstypes.NewConfig(2, signers, nil)
+	require.NoError(t, err)
+
+	newCollector := func(t *testing.T) *SignatureCollector {
+		proposal := &mcmslib.Proposal{
+			BaseProposal: mcmslib.BaseProposal{
+				Version:       "v1",
+				Kind:          mcmstypes.KindProposal,
+				ValidUntil:    uint32(time.Now().Add(time.Hour).Unix()), //nolint:gosec // G115
+				ChainMetadata: map[mcmstypes.ChainSelector]mcmstypes.ChainMetadata{sel: {MCMAddress: common.HexToAddress("0x0d").Hex()}},
+			},
+			Operations: []mcmstypes.Operation{{
+				ChainSelector: sel,
+				Transaction:   mcmstypes.Transaction{To: common.HexToAddress("0x0e").Hex(), Data: []byte{0x01}, AdditionalFields: json.RawMessage(`{"value": 0}`)},
+			}},
+		}
+		c, err := newSignatureCollector(proposal, map[mcmstypes.ChainSelector]sdk.Inspector{sel: configInspector{config: &config}})
+		require.NoError(t, err)
+		return c
+	}
+	sign := func(t *testing.T, c *SignatureCollector, key *ecdsa.PrivateKey) mcmstypes.Signature {
+		raw, err := crypto.Sign(c.hash.Bytes(), key)
+		require.NoError(t, err)
+		sig, err := mcmstypes.NewSignatureFromBytes(raw)
+		require.NoError(t, err)
+		return sig
+	}
+
+	t.Run("payloads", func(t *testing.T) {
+		c := newCollector(t)
+		payloads, err := c.Payloads(ctx)
+		require.NoError(t, err)
+		require.Len(t, payloads, 3)
+		for _, p := range payloads {
+			assert.Contains(t, signers, p.Signer)
+			assert.Equal(t, c.hash, p.Hash)
+			assert.NotEqual(t, p.Hash, p.Message)
+			assert.NotEqual(t, common.Hash{}, p.Root)
+		}
+	})
+
+	t.Run("quorum", func(t *testing.T) {
+		c := newCollector(t)
+
+		signer, err := c.Add(ctx, sign(t, c, keys[0]))
+		require.NoError(t, err)
+		assert.Equal(t, signers[0], signer)
+
+		_, err = c.Add(ctx, sign(t, c, keys[0]))
+		require.ErrorContains(t, err, "already signed")
+
+		_, err = c.Finalize(ctx)
+		require.ErrorContains(t, err, "quorum not reached")
+
+		_, err = c.Add(ctx, sign(t, c, keys[1]))
+		require.NoError(t, err)
+
+		proposal, err := c.Finalize(ctx)
+		require.NoError(t, err)
+		assert.Len(t, proposal.Signatures, 2)
+	})
+
+	t.Run("unknown signer", func(t *testing.T) {
+		c := newCollector(t)
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+
+		_, err = c.Add(ctx, sign(t, c, key))
+		require.ErrorContains(t, err, "is not a signer of the MCM config")
+	})
+
+	t.Run("from reader", func(t *testing.T) {
+		c := newCollector(t)
+		input := strings.Join([]string{
+			"# collected signatures",
+			hexutil.Encode(sign(t, c, keys[1]).ToBytes()),
+			"",
+			hexutil.Encode(sign(t, c, keys[2]).ToBytes()),
+		}, "\n")
+
+		added, err := c.AddFromReader(ctx, strings.NewReader(input))
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{signers[1], signers[2]}, added)
+
+		_, err = c.Finalize(ctx)
+		require.NoError(t, err)
+
+		_, err = c.AddFromReader(ctx, strings.NewReader("0x1234"))
+		require.ErrorContains(t, err, "line 1")
+	})
+}
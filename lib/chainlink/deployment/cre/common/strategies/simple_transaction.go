@@ -1,7 +1,12 @@
 package strategies
 
 import (
+	"context"
+	"fmt"
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	mcmslib "github.com/smartcontractkit/mcms"
 	mcmstypes "github.com/smartcontractkit/mcms/types"
@@ -12,18 +17,106 @@ import (
 // SimpleTransaction executes a transaction directly without MCMS
 type SimpleTransaction struct {
 	Chain cldf_evm.Chain
+	// Gas controls the gas of the transactions, nil keeps the defaults.
+	Gas *GasConfig
+	// Signer signs the transactions instead of the deployer key, e.g. with a Ledger or a KMS key.
+	Signer *bind.TransactOpts
+	// Nonces assigns the nonces locally so that concurrent transactions don't reuse the pending nonce, nil disables it.
+	Nonces *NonceManager
+	// Replacement replaces transactions not mined in time with bumped fees, nil waits for the original transaction.
+	Replacement *ReplacementConfig
+
+	reports []TransactionReport
 }
 
 func (s *SimpleTransaction) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
-	tx, err := callFn(s.Chain.DeployerKey)
+	opts, err := s.transactOpts(callFn)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if s.Nonces != nil {
+		nonce, err := s.Nonces.Next(ctx, s.Chain.Client, opts.From)
+		if err != nil {
+			return nil, nil, err
+		}
+		withNonce := *opts
+		withNonce.Nonce = new(big.Int).SetUint64(nonce)
+		opts = &withNonce
+	}
+
+	tx, err := callFn(opts)
+	if err != nil {
+		if s.Nonces != nil {
+			// The reserved nonce was not used, resync with the chain
+			s.Nonces.Reset()
+		}
+		return nil, nil, err
+	}
+
+	report := TransactionReport{Nonce: tx.Nonce(), Hashes: []common.Hash{tx.Hash()}, MinedHash: tx.Hash()}
+	if s.Replacement != nil {
+		var mined *types.Transaction
+		mined, report, err = waitOrReplace(ctx, s.Chain.Client, opts, tx, *s.Replacement)
+		s.reports = append(s.reports, report)
+		if err != nil {
+			return nil, tx, err
+		}
+		tx = mined
+	} else {
+		s.reports = append(s.reports, report)
+	}
+
 	_, err = s.Chain.Confirm(tx)
 	return nil, tx, err
 }
 
+// Reports returns how each transaction sent so far was mined, including its replacements.
+func (s *SimpleTransaction) Reports() []TransactionReport {
+	return s.reports
+}
+
 func (s *SimpleTransaction) BuildProposal(_ []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
 	return nil, nil
 }
+
+// transactOpts returns the signer, or deployer key, transact opts with the gas config applied.
+func (s *SimpleTransaction) transactOpts(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*bind.TransactOpts, error) {
+	signer := s.Chain.DeployerKey
+	if s.Signer != nil {
+		signer = s.Signer
+	}
+	if s.Gas == nil {
+		return signer, nil
+	}
+
+	opts := *signer
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := s.Gas.applyFees(ctx, s.Chain.Client, &opts); err != nil {
+		return nil, err
+	}
+
+	if opts.GasLimit == 0 && s.Gas.GasLimit != 0 {
+		opts.GasLimit = s.Gas.GasLimit
+	} else if opts.GasLimit == 0 && s.Gas.GasLimitMultiplier != 0 {
+		// Let the bindings estimate the gas limit without sending, then add the safety buffer
+		estimateOpts := opts
+		estimateOpts.NoSend = true
+		tx, err := callFn(&estimateOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		opts.GasLimit = s.Gas.gasLimit(tx.Gas())
+	}
+
+	return &opts, nil
+}
@@ -0,0 +1,112 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmslib "github.com/smartcontractkit/mcms"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+// SimulationResult is the outcome of the eth_call of a single transaction.
+type SimulationResult struct {
+	ChainSelector uint64         `json:"chainSelector"`
+	From          common.Address `json:"from"`
+	To            common.Address `json:"to"`
+	Data          hexutil.Bytes  `json:"data"`
+	Reverted      bool           `json:"reverted"`
+	// RevertReason is the revert reason, with custom errors decoded using the contract ABI.
+	RevertReason string `json:"revertReason,omitempty"`
+}
+
+// SimulateTransaction is a dry-run strategy: it eth_calls the calldata the real strategy would send, from the same
+// sender, and records the results instead of submitting anything.
+// Apply returns a batch operation like MCMSTransaction since no transaction is mined, and BuildProposal returns
+// ErrNoProposal.
+type SimulateTransaction struct {
+	Chain cldf_evm.Chain
+	// From is the sender of the real transactions: the deployer key, or the timelock with MCMS.
+	From common.Address
+	// ABI of the target contracts, used to decode custom errors.
+	ABI string
+
+	mu      sync.Mutex
+	results []SimulationResult
+}
+
+func (s *SimulateTransaction) Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error) {
+	opts := cldf.SimTransactOpts()
+	opts.From = s.From
+
+	tx, err := callFn(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tx.To() == nil {
+		return nil, tx, errors.New("simulating contract deployments is not supported")
+	}
+
+	result := SimulationResult{
+		ChainSelector: s.Chain.Selector,
+		From:          s.From,
+		To:            *tx.To(),
+		Data:          tx.Data(),
+	}
+	_, err = s.Chain.Client.CallContract(context.Background(), ethereum.CallMsg{
+		From:  s.From,
+		To:    tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}, nil)
+	if err != nil {
+		result.Reverted = true
+		result.RevertReason = cldf.DecodeErr(s.ABI, err).Error()
+	}
+
+	s.mu.Lock()
+	s.results = append(s.results, result)
+	s.mu.Unlock()
+
+	op, err := proposalutils.BatchOperationForChain(s.Chain.Selector, tx.To().Hex(), tx.Data(), big.NewInt(0), "", nil)
+	if err != nil {
+		return nil, tx, err
+	}
+
+	return &op, tx, nil
+}
+
+func (s *SimulateTransaction) BuildProposal(_ []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error) {
+	return nil, ErrNoProposal
+}
+
+// Results returns the results of all the simulated transactions, in order.
+func (s *SimulateTransaction) Results() []SimulationResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SimulationResult(nil), s.results...)
+}
+
+// Err returns an error listing the reverted transactions, or nil if none reverted.
+func (s *SimulateTransaction) Err() error {
+	var errs []error
+	for i, r := range s.Results() {
+		if r.Reverted {
+			errs = append(errs, fmt.Errorf("transaction %d to %s on chain %d reverts: %s", i, r.To, r.ChainSelector, r.RevertReason))
+		}
+	}
+	return errors.Join(errs...)
+}
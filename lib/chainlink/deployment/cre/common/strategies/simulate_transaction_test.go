@@ -0,0 +1,71 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+)
+
+// revertingClient reverts the calls whose data starts with 0xff.
+type revertingClient struct {
+	cldf_evm.OnchainClient
+	calls []ethereum.CallMsg
+}
+
+func (c *revertingClient) CallContract(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	c.calls = append(c.calls, msg)
+	if len(msg.Data) > 0 && msg.Data[0] == 0xff {
+		return nil, errors.New("execution reverted")
+	}
+	return nil, nil
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	client := &revertingClient{}
+	from := common.HexToAddress("0x0a")
+	to := common.HexToAddress("0x0b")
+	s := &SimulateTransaction{
+		Chain: cldf_evm.Chain{Selector: chainsel.TEST_90000001.Selector, Client: client},
+		From:  from,
+	}
+
+	call := func(data ...byte) func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			assert.True(t, opts.NoSend)
+			return types.NewTx(&types.LegacyTx{To: &to, Data: data}), nil
+		}
+	}
+
+	op, _, err := s.Apply(call(0x01))
+	require.NoError(t, err)
+	require.NotNil(t, op)
+	require.NoError(t, s.Err())
+
+	_, _, err = s.Apply(call(0xff, 0x01))
+	require.NoError(t, err)
+
+	require.Len(t, client.calls, 2)
+	assert.Equal(t, from, client.calls[1].From)
+	assert.Equal(t, &to, client.calls[1].To)
+
+	results := s.Results()
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Reverted)
+	assert.True(t, results[1].Reverted)
+	assert.Contains(t, results[1].RevertReason, "execution reverted")
+	require.ErrorContains(t, s.Err(), "transaction 1 to "+to.String())
+
+	_, err = s.BuildProposal(nil)
+	require.ErrorIs(t, err, ErrNoProposal)
+}
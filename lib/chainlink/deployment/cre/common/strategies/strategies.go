@@ -17,32 +17,189 @@ import (
 	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
 )
 
+// ErrNoProposal is returned by BuildProposal of the strategies which don't execute their batch operations through an
+// MCMS proposal, e.g. SafeTransaction collecting Safe transactions instead. Callers skip the proposal.
+var ErrNoProposal = errors.New("strategy does not build MCMS proposals")
+
 // TransactionStrategy interface for executing transactions with different strategies
 type TransactionStrategy interface {
 	// Apply executes the provided call function and returns the resulting MCMS batch operation if applicable.
 	// The callFn should accept transaction options and return a transaction or an error.
-	// If using MCMS, the returned BatchOperation can be used to build a proposal.
-	// If no MCMS is used, the returned BatchOperation will be nil, and the transaction will be confirmed.
+	// If the call is not executed, e.g. with MCMS or a dry run, the returned BatchOperation is not nil and the returned
+	// transaction is never mined: operations must branch on the BatchOperation rather than on their MCMS config.
+	// Otherwise, the returned BatchOperation will be nil, and the transaction will be confirmed.
 	Apply(callFn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*mcmstypes.BatchOperation, *types.Transaction, error)
 
 	// BuildProposal constructs a TimelockProposal from the provided batch operations.
-	// This is only applicable when using MCMS; otherwise, it returns an empty proposal.
+	// It returns ErrNoProposal when the batch operations are not executed through MCMS.
 	BuildProposal(operations []mcmstypes.BatchOperation) (*mcmslib.TimelockProposal, error)
 }
 
-// CreateStrategy is a factory function to create the appropriate strategy based on configuration
+// StrategyOptions are the optional settings of the strategies created by CreateStrategy.
+type StrategyOptions struct {
+	// GasConfigs controls the gas of direct transactions, per chain selector.
+	GasConfigs map[uint64]GasConfig
+	// Ledger signs direct transactions instead of the deployer key.
+	Ledger *LedgerSigner
+	// Signer signs direct transactions instead of the deployer key, e.g. a KMS key. It takes precedence over Ledger.
+	Signer EVMSigner
+	// SimulationABI, if set, creates a SimulateTransaction dry-run strategy decoding errors with this ABI.
+	SimulationABI string
+	// Safes are the Safe owning the contracts, per chain selector. Calls on these chains go through a SafeTransaction.
+	Safes map[uint64]common.Address
+	// NonceManagement assigns the nonces of direct transactions from a nonce manager shared per chain and sender.
+	NonceManagement bool
+	// Replacement replaces direct transactions not mined in time with bumped fees.
+	Replacement *ReplacementConfig
+	// Retry retries Apply on transient errors.
+	Retry *RetryPolicy
+}
+
+type StrategyOption func(*StrategyOptions)
+
+// WithGasConfigs sets the gas config of direct transactions, per chain selector.
+func WithGasConfigs(configs map[uint64]GasConfig) StrategyOption {
+	return func(o *StrategyOptions) {
+		o.GasConfigs = configs
+	}
+}
+
+// WithLedgerSigner signs direct transactions with the Ledger instead of the deployer key.
+func WithLedgerSigner(ledger *LedgerSigner) StrategyOption {
+	return func(o *StrategyOptions) {
+		o.Ledger = ledger
+	}
+}
+
+// WithSigner signs direct transactions with the signer instead of the deployer key, e.g. a KMSSigner.
+func WithSigner(signer EVMSigner) StrategyOption {
+	return func(o *StrategyOptions) {
+		o.Signer = signer
+	}
+}
+
+// WithSimulation creates a dry-run strategy instead, simulating the transactions from the deployer key or,
+// with MCMS, from the timelock. Custom errors are decoded with the contract ABI.
+func WithSimulation(contractABI string) StrategyOption {
+	return func(o *StrategyOptions) {
+		o.SimulationABI = contractABI
+	}
+}
+
+// WithSafes sends the calls through the Safe owning the contracts on the given chains, instead of MCMS or the deployer key.
+func WithSafes(safes map[uint64]common.Address) StrategyOption {
+	return func(o *StrategyOptions) {
+		o.Safes = safes
+	}
+}
+
+// WithNonceManagement assigns the nonces of direct transactions from the nonce manager shared per chain and sender,
+// so strategies sending concurrently from the same key don't collide.
+func WithNonceManagement() StrategyOption {
+	return func(o *StrategyOptions) {
+		o.NonceManagement = true
+	}
+}
+
+// WithReplacement replaces direct transactions which are not mined in time with the same transaction with bumped fees.
+func WithReplacement(cfg ReplacementConfig) StrategyOption {
+	return func(o *StrategyOptions) {
+		o.Replacement = &cfg
+	}
+}
+
+// WithRetryPolicy retries the calls applied with a state check, see ApplyWithCheck, on transient errors. The check
+// runs before each retry, so calls which landed despite an error are not applied twice. Calls applied without check
+// are not retried.
+func WithRetryPolicy(policy RetryPolicy) StrategyOption {
+	return func(o *StrategyOptions) {
+		o.Retry = &policy
+	}
+}
+
+// CreateStrategy is a factory function to create the appropriate strategy based on configuration. The strategy is
+// created by the factory registered for the family of the chain, see RegisterStrategyFactory, and ErrUnsupportedFamily
+// is returned for the families without one.
 func CreateStrategy(
+	env cldf.Environment,
+	chainSelector uint64,
+	mcmsConfig *contracts.MCMSConfig,
+	mcmsContracts *commonchangeset.MCMSWithTimelockState,
+	targetAddress common.Address,
+	description string,
+	opts ...StrategyOption,
+) (TransactionStrategy, error) {
+	var options StrategyOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	factory, err := strategyFactoryFor(chainSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy, err := factory(env, chainSelector, mcmsConfig, mcmsContracts, targetAddress, description, options)
+	if err != nil || options.Retry == nil {
+		return strategy, err
+	}
+	if err := options.Retry.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	return NewRetryingTransaction(strategy, *options.Retry), nil
+}
+
+// createEVMStrategy is the StrategyFactory of EVM chains.
+func createEVMStrategy(
+	env cldf.Environment,
+	chainSelector uint64,
+	mcmsConfig *contracts.MCMSConfig,
+	mcmsContracts *commonchangeset.MCMSWithTimelockState,
+	targetAddress common.Address,
+	description string,
+	options StrategyOptions,
+) (TransactionStrategy, error) {
+	chain, ok := env.BlockChains.EVMChains()[chainSelector]
+	if !ok {
+		return nil, fmt.Errorf("chain %d not found in environment", chainSelector)
+	}
+
+	return createStrategy(chain, env, mcmsConfig, mcmsContracts, targetAddress, description, options)
+}
+
+func createStrategy(
 	chain cldf_evm.Chain,
 	env cldf.Environment,
 	mcmsConfig *contracts.MCMSConfig,
 	mcmsContracts *commonchangeset.MCMSWithTimelockState,
 	targetAddress common.Address,
 	description string,
+	options StrategyOptions,
 ) (TransactionStrategy, error) {
+	if options.SimulationABI != "" {
+		from := chain.DeployerKey.From
+		if mcmsConfig != nil {
+			if mcmsContracts == nil || mcmsContracts.Timelock == nil {
+				return nil, errors.New("MCMS contracts are required when mcmsConfig is not nil")
+			}
+			from = mcmsContracts.Timelock.Address()
+		}
+
+		return &SimulateTransaction{Chain: chain, From: from, ABI: options.SimulationABI}, nil
+	}
+
+	if safe, ok := options.Safes[chain.Selector]; ok {
+		return &SafeTransaction{Chain: chain, Safe: safe}, nil
+	}
+
 	if mcmsConfig != nil {
 		if mcmsContracts == nil {
 			return nil, errors.New("MCMS contracts are required when mcmsConfig is not nil")
 		}
+		if err := mcmsConfig.ValidateWindow(); err != nil {
+			return nil, fmt.Errorf("invalid MCMS config: %w", err)
+		}
 
 		return &MCMSTransaction{
 			Config:        mcmsConfig,
@@ -54,7 +211,41 @@ func CreateStrategy(
 		}, nil
 	}
 
-	return &SimpleTransaction{Chain: chain}, nil
+	simple := &SimpleTransaction{Chain: chain}
+	if gas, ok := options.GasConfigs[chain.Selector]; ok {
+		if err := gas.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid gas config for chain %d: %w", chain.Selector, err)
+		}
+		simple.Gas = &gas
+	}
+	if options.Signer != nil {
+		signer, err := options.Signer.TransactOpts(chain.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create signer: %w", err)
+		}
+		simple.Signer = signer
+	} else if options.Ledger != nil {
+		signer, err := options.Ledger.TransactOpts(chain.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Ledger signer: %w", err)
+		}
+		simple.Signer = signer
+	}
+	if options.NonceManagement {
+		from := chain.DeployerKey.From
+		if simple.Signer != nil {
+			from = simple.Signer.From
+		}
+		simple.Nonces = SharedNonceManager(chain.Selector, chain.Client, from)
+	}
+	if options.Replacement != nil {
+		if err := options.Replacement.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid replacement config: %w", err)
+		}
+		simple.Replacement = options.Replacement
+	}
+
+	return simple, nil
 }
 
 // Legacy aliases for backward compatibility with existing CRE modules
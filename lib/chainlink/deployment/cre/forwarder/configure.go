@@ -116,8 +116,8 @@ var ConfigureSeq = operations.NewSequence[ConfigureSeqInput, ConfigureSeqOutput,
 			for _, addrRef := range addressesRefs {
 				// Create the appropriate strategy
 				strategy, err := strategies.CreateStrategy(
-					chain,
 					*deps.Env,
+					chain.Selector,
 					input.MCMSConfig,
 					mcmsContracts,
 					common.HexToAddress(addrRef.Address),
@@ -63,8 +63,8 @@ func (l AdminPauseWorkflow) Apply(e cldf.Environment, config AdminPauseWorkflowI
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
@@ -154,8 +154,8 @@ func (l AdminBatchPauseWorkflows) Apply(e cldf.Environment, config AdminBatchPau
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
@@ -238,8 +238,8 @@ func (l AdminPauseAllByOwner) Apply(e cldf.Environment, config AdminPauseAllByOw
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
@@ -322,8 +322,8 @@ func (l AdminPauseAllByDON) Apply(e cldf.Environment, config AdminPauseAllByDONI
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
@@ -66,8 +66,8 @@ func (l SetConfig) Apply(e cldf.Environment, config SetConfigInput) (cldf.Change
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
@@ -163,8 +163,8 @@ func (l UpdateAllowedSigners) Apply(e cldf.Environment, config UpdateAllowedSign
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
@@ -250,8 +250,8 @@ func (l SetWorkflowOwnerConfig) Apply(e cldf.Environment, config SetWorkflowOwne
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
@@ -338,8 +338,8 @@ func (l SetDONLimit) Apply(e cldf.Environment, config SetDONLimitInput) (cldf.Ch
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
@@ -427,8 +427,8 @@ func (l SetUserDONOverride) Apply(e cldf.Environment, config SetUserDONOverrideI
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
@@ -515,8 +515,8 @@ func (l SetCapabilitiesRegistry) Apply(e cldf.Environment, config SetCapabilitie
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		e,
+		chain.Selector,
 		config.MCMSConfig,
 		mcmsContracts,
 		registry.Address(),
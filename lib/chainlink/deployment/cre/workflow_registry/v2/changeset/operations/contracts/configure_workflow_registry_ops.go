@@ -20,12 +20,13 @@ import (
 )
 
 const (
-	SetConfigDescription               = "setConfig on workflow registry v2"
-	UpdateAllowedSignersDescription    = "updateAllowedSigners on workflow registry v2"
-	SetWorkflowOwnerConfigDescription  = "setWorkflowOwner config on workflow registry v2"
-	SetDONLimitDescription             = "setDonLimit on workflow registry v2"
-	SetUserDONOverrideDescription      = "setUserDonOverride on workflow registry v2"
-	SetCapabilitiesRegistryDescription = "setCapabilitiesRegistry on workflow registry v2"
+	SetConfigDescription                 = "setConfig on workflow registry v2"
+	UpdateAllowedSignersDescription      = "updateAllowedSigners on workflow registry v2"
+	SetWorkflowOwnerConfigDescription    = "setWorkflowOwner config on workflow registry v2"
+	SetDONLimitDescription               = "setDonLimit on workflow registry v2"
+	SetUserDONOverrideDescription        = "setUserDonOverride on workflow registry v2"
+	SetCapabilitiesRegistryDescription   = "setCapabilitiesRegistry on workflow registry v2"
+	ConfigureWorkflowRegistryDescription = "configure workflow registry v2"
 )
 
 // Common dependencies for workflow registry operations
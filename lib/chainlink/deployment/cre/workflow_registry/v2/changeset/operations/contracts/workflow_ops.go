@@ -0,0 +1,121 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+	workflow_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/workflow_registry_wrapper_v2"
+
+	"github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+)
+
+const (
+	PauseWorkflowsDescription    = "batchPauseWorkflows on workflow registry v2"
+	ActivateWorkflowsDescription = "batchActivateWorkflows on workflow registry v2"
+)
+
+// PauseWorkflows Operation. Unlike the admin pause operations, the workflows must be owned by the deployer key or,
+// with MCMS, by the timelock.
+type PauseWorkflowsOpInput struct {
+	ChainSelector uint64                `json:"chainSelector"`
+	Qualifier     string                `json:"qualifier"` // Qualifier to identify the specific workflow registry
+	WorkflowIDs   [][32]byte            `json:"workflowIDs"`
+	MCMSConfig    *contracts.MCMSConfig `json:"mcmsConfig,omitempty"`
+}
+
+type PauseWorkflowsOpOutput struct {
+	Success         bool                      `json:"success"`
+	RegistryAddress common.Address            `json:"registryAddress"`
+	MCMSOperation   *mcmstypes.BatchOperation `json:"mcmsOperation"`
+}
+
+var PauseWorkflowsOp = operations.NewOperation(
+	"pause-workflows-op",
+	semver.MustParse("1.0.0"),
+	"Pause Workflows in WorkflowRegistry V2",
+	func(b operations.Bundle, deps WorkflowRegistryOpDeps, input PauseWorkflowsOpInput) (PauseWorkflowsOpOutput, error) {
+		if len(input.WorkflowIDs) == 0 {
+			return PauseWorkflowsOpOutput{}, errors.New("must provide at least one workflow ID")
+		}
+
+		// Execute the transaction using the strategy
+		operation, _, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return deps.Registry.BatchPauseWorkflows(opts, input.WorkflowIDs)
+		})
+		if err != nil {
+			err = cldf.DecodeErr(workflow_registry_v2.WorkflowRegistryABI, err)
+			return PauseWorkflowsOpOutput{}, fmt.Errorf("failed to execute BatchPauseWorkflows: %w", err)
+		}
+
+		if input.MCMSConfig != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for BatchPauseWorkflows (%d workflows) on chain %d", len(input.WorkflowIDs), input.ChainSelector)
+		} else {
+			deps.Env.Logger.Infof("Successfully paused %d workflows on chain %d", len(input.WorkflowIDs), input.ChainSelector)
+		}
+
+		return PauseWorkflowsOpOutput{
+			Success:         true,
+			MCMSOperation:   operation,
+			RegistryAddress: deps.Registry.Address(),
+		}, nil
+	},
+)
+
+// ActivateWorkflows Operation, unpausing workflows on a DON family. The workflows must be owned by the deployer key
+// or, with MCMS, by the timelock.
+type ActivateWorkflowsOpInput struct {
+	ChainSelector uint64                `json:"chainSelector"`
+	Qualifier     string                `json:"qualifier"` // Qualifier to identify the specific workflow registry
+	WorkflowIDs   [][32]byte            `json:"workflowIDs"`
+	DONFamily     string                `json:"donFamily"`
+	MCMSConfig    *contracts.MCMSConfig `json:"mcmsConfig,omitempty"`
+}
+
+type ActivateWorkflowsOpOutput struct {
+	Success         bool                      `json:"success"`
+	RegistryAddress common.Address            `json:"registryAddress"`
+	MCMSOperation   *mcmstypes.BatchOperation `json:"mcmsOperation"`
+}
+
+var ActivateWorkflowsOp = operations.NewOperation(
+	"activate-workflows-op",
+	semver.MustParse("1.0.0"),
+	"Activate Workflows in WorkflowRegistry V2",
+	func(b operations.Bundle, deps WorkflowRegistryOpDeps, input ActivateWorkflowsOpInput) (ActivateWorkflowsOpOutput, error) {
+		if len(input.WorkflowIDs) == 0 {
+			return ActivateWorkflowsOpOutput{}, errors.New("must provide at least one workflow ID")
+		}
+		if input.DONFamily == "" {
+			return ActivateWorkflowsOpOutput{}, errors.New("must provide a DON family")
+		}
+
+		// Execute the transaction using the strategy
+		operation, _, err := deps.Strategy.Apply(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return deps.Registry.BatchActivateWorkflows(opts, input.WorkflowIDs, input.DONFamily)
+		})
+		if err != nil {
+			err = cldf.DecodeErr(workflow_registry_v2.WorkflowRegistryABI, err)
+			return ActivateWorkflowsOpOutput{}, fmt.Errorf("failed to execute BatchActivateWorkflows: %w", err)
+		}
+
+		if input.MCMSConfig != nil {
+			deps.Env.Logger.Infof("Created MCMS proposal for BatchActivateWorkflows (%d workflows) on chain %d", len(input.WorkflowIDs), input.ChainSelector)
+		} else {
+			deps.Env.Logger.Infof("Successfully activated %d workflows on DON family %s on chain %d", len(input.WorkflowIDs), input.DONFamily, input.ChainSelector)
+		}
+
+		return ActivateWorkflowsOpOutput{
+			Success:         true,
+			MCMSOperation:   operation,
+			RegistryAddress: deps.Registry.Address(),
+		}, nil
+	},
+)
@@ -0,0 +1,224 @@
+package sequences
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+	mcmslib "github.com/smartcontractkit/mcms"
+	"github.com/smartcontractkit/mcms/types"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	crecontracts "github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/workflow_registry/v2/changeset/operations/contracts"
+)
+
+type ConfigureWorkflowRegistryDeps struct {
+	Env           *cldf.Environment
+	MCMSContracts *commonchangeset.MCMSWithTimelockState // Required if MCMSConfig is not nil
+}
+
+type WorkflowRegistryConfig struct {
+	NameLen   uint8  `json:"nameLen" yaml:"nameLen"`
+	TagLen    uint8  `json:"tagLen" yaml:"tagLen"`
+	URLLen    uint8  `json:"urlLen" yaml:"urlLen"`
+	AttrLen   uint16 `json:"attrLen" yaml:"attrLen"`
+	ExpiryLen uint32 `json:"expiryLen" yaml:"expiryLen"`
+}
+
+// DONFamilyLimit allows workflows on a DON family, up to Limit workflows, and UserDefaultLimit workflows per owner.
+type DONFamilyLimit struct {
+	DONFamily        string `json:"donFamily" yaml:"donFamily"`
+	Limit            uint32 `json:"limit" yaml:"limit"`
+	UserDefaultLimit uint32 `json:"userDefaultLimit" yaml:"userDefaultLimit"`
+}
+
+type UserDONOverride struct {
+	User      common.Address `json:"user" yaml:"user"`
+	DONFamily string         `json:"donFamily" yaml:"donFamily"`
+	Limit     uint32         `json:"limit" yaml:"limit"`
+	Enabled   bool           `json:"enabled" yaml:"enabled"`
+}
+
+type WorkflowOwnerConfig struct {
+	Owner  common.Address `json:"owner" yaml:"owner"`
+	Config []byte         `json:"config" yaml:"config"`
+}
+
+type CapabilitiesRegistryRef struct {
+	Address       common.Address `json:"address" yaml:"address"`
+	ChainSelector uint64         `json:"chainSelector" yaml:"chainSelector"`
+}
+
+type ConfigureWorkflowRegistryInput struct {
+	ChainSelector uint64
+	Qualifier     string
+	MCMSConfig    *crecontracts.MCMSConfig
+
+	// All the fields below are optional, only the set ones are configured.
+	Config               *WorkflowRegistryConfig
+	AllowedSigners       []common.Address
+	DONFamilyLimits      []DONFamilyLimit
+	UserDONOverrides     []UserDONOverride
+	WorkflowOwnerConfigs []WorkflowOwnerConfig
+	CapabilitiesRegistry *CapabilitiesRegistryRef
+}
+
+type ConfigureWorkflowRegistryOutput struct {
+	RegistryAddress       common.Address
+	MCMSTimelockProposals []mcmslib.TimelockProposal
+}
+
+// ConfigureWorkflowRegistry configures a deployed workflow registry in one go: metadata config, capabilities registry,
+// allowed DON families and their limits, user overrides, workflow owner configs and allowed signers.
+// With MCMS, all the changes are in a single proposal.
+var ConfigureWorkflowRegistry = operations.NewSequence(
+	"configure-workflow-registry-seq",
+	semver.MustParse("1.0.0"),
+	"Configures the workflow registry v2",
+	func(b operations.Bundle, deps ConfigureWorkflowRegistryDeps, input ConfigureWorkflowRegistryInput) (ConfigureWorkflowRegistryOutput, error) {
+		chain, ok := deps.Env.BlockChains.EVMChains()[input.ChainSelector]
+		if !ok {
+			return ConfigureWorkflowRegistryOutput{}, fmt.Errorf("chain with selector %d not found", input.ChainSelector)
+		}
+
+		registry, err := contracts.GetWorkflowRegistryV2FromDatastore(deps.Env, input.ChainSelector, input.Qualifier)
+		if err != nil {
+			return ConfigureWorkflowRegistryOutput{}, fmt.Errorf("failed to get workflow registry address from datastore: %w", err)
+		}
+
+		// Create the appropriate strategy
+		strategy, err := strategies.CreateStrategy(
+			*deps.Env,
+			chain.Selector,
+			input.MCMSConfig,
+			deps.MCMSContracts,
+			registry.Address(),
+			contracts.ConfigureWorkflowRegistryDescription,
+		)
+		if err != nil {
+			return ConfigureWorkflowRegistryOutput{}, fmt.Errorf("failed to create strategy: %w", err)
+		}
+
+		opDeps := contracts.WorkflowRegistryOpDeps{
+			Env:      deps.Env,
+			Strategy: strategy,
+			Registry: registry,
+		}
+
+		var allOperations []types.BatchOperation
+		collect := func(operation *types.BatchOperation) {
+			if operation != nil {
+				allOperations = append(allOperations, *operation)
+			}
+		}
+
+		if input.Config != nil {
+			report, err := operations.ExecuteOperation(b, contracts.SetConfigOp, opDeps, contracts.SetConfigOpInput{
+				ChainSelector: input.ChainSelector,
+				Qualifier:     input.Qualifier,
+				NameLen:       input.Config.NameLen,
+				TagLen:        input.Config.TagLen,
+				URLLen:        input.Config.URLLen,
+				AttrLen:       input.Config.AttrLen,
+				ExpiryLen:     input.Config.ExpiryLen,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ConfigureWorkflowRegistryOutput{}, err
+			}
+			collect(report.Output.MCMSOperation)
+		}
+
+		if input.CapabilitiesRegistry != nil {
+			report, err := operations.ExecuteOperation(b, contracts.SetCapabilitiesRegistryOp, opDeps, contracts.SetCapabilitiesRegistryOpInput{
+				ChainSelector:    input.ChainSelector,
+				Qualifier:        input.Qualifier,
+				Registry:         input.CapabilitiesRegistry.Address,
+				ChainSelectorDON: input.CapabilitiesRegistry.ChainSelector,
+				MCMSConfig:       input.MCMSConfig,
+			})
+			if err != nil {
+				return ConfigureWorkflowRegistryOutput{}, err
+			}
+			collect(report.Output.MCMSOperation)
+		}
+
+		for _, limit := range input.DONFamilyLimits {
+			report, err := operations.ExecuteOperation(b, contracts.SetDONLimitOp, opDeps, contracts.SetDONLimitOpInput{
+				ChainSelector:    input.ChainSelector,
+				Qualifier:        input.Qualifier,
+				DONFamily:        limit.DONFamily,
+				DONLimit:         limit.Limit,
+				UserDefaultLimit: limit.UserDefaultLimit,
+				MCMSConfig:       input.MCMSConfig,
+			})
+			if err != nil {
+				return ConfigureWorkflowRegistryOutput{}, err
+			}
+			collect(report.Output.MCMSOperation)
+		}
+
+		for _, override := range input.UserDONOverrides {
+			report, err := operations.ExecuteOperation(b, contracts.SetUserDONOverrideOp, opDeps, contracts.SetUserDONOverrideOpInput{
+				ChainSelector: input.ChainSelector,
+				Qualifier:     input.Qualifier,
+				User:          override.User,
+				DONFamily:     override.DONFamily,
+				Limit:         override.Limit,
+				Enabled:       override.Enabled,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ConfigureWorkflowRegistryOutput{}, err
+			}
+			collect(report.Output.MCMSOperation)
+		}
+
+		for _, ownerConfig := range input.WorkflowOwnerConfigs {
+			report, err := operations.ExecuteOperation(b, contracts.SetWorkflowOwnerConfigOp, opDeps, contracts.SetWorkflowOwnerConfigOpInput{
+				ChainSelector: input.ChainSelector,
+				Qualifier:     input.Qualifier,
+				Owner:         ownerConfig.Owner,
+				Config:        ownerConfig.Config,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ConfigureWorkflowRegistryOutput{}, err
+			}
+			collect(report.Output.MCMSOperation)
+		}
+
+		if len(input.AllowedSigners) > 0 {
+			report, err := operations.ExecuteOperation(b, contracts.UpdateAllowedSignersOp, opDeps, contracts.UpdateAllowedSignersOpInput{
+				ChainSelector: input.ChainSelector,
+				Qualifier:     input.Qualifier,
+				Signers:       input.AllowedSigners,
+				Allowed:       true,
+				MCMSConfig:    input.MCMSConfig,
+			})
+			if err != nil {
+				return ConfigureWorkflowRegistryOutput{}, err
+			}
+			collect(report.Output.MCMSOperation)
+		}
+
+		var proposals []mcmslib.TimelockProposal
+		if len(allOperations) > 0 {
+			proposal, mErr := strategy.BuildProposal(allOperations)
+			if mErr != nil {
+				return ConfigureWorkflowRegistryOutput{}, fmt.Errorf("failed to build MCMS proposal: %w", mErr)
+			}
+			proposals = append(proposals, *proposal)
+		}
+
+		return ConfigureWorkflowRegistryOutput{
+			RegistryAddress:       registry.Address(),
+			MCMSTimelockProposals: proposals,
+		}, nil
+	},
+)
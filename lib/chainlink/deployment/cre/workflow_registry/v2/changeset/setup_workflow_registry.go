@@ -0,0 +1,100 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	"github.com/smartcontractkit/chainlink-deployments-framework/operations"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset/state"
+	"github.com/smartcontractkit/chainlink/deployment/cre/common/strategies"
+	crecontracts "github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/workflow_registry/v2/changeset/sequences"
+)
+
+var _ cldf.ChangeSetV2[SetupWorkflowRegistryInput] = SetupWorkflowRegistry{}
+
+// SetupWorkflowRegistryInput is the full configuration of a deployed workflow registry v2. Only the set fields are configured.
+type SetupWorkflowRegistryInput struct {
+	ChainSelector             uint64                             `json:"chainSelector"`
+	WorkflowRegistryQualifier string                             `json:"workflowRegistryQualifier"` // Qualifier to identify the specific workflow registry
+	Config                    *sequences.WorkflowRegistryConfig  `json:"config,omitempty"`          // Metadata validation settings
+	CapabilitiesRegistry      *sequences.CapabilitiesRegistryRef `json:"capabilitiesRegistry,omitempty"`
+	DONFamilyLimits           []sequences.DONFamilyLimit         `json:"donFamilyLimits,omitempty"` // Allowed DON families and their workflow limits
+	UserDONOverrides          []sequences.UserDONOverride        `json:"userDONOverrides,omitempty"`
+	WorkflowOwnerConfigs      []sequences.WorkflowOwnerConfig    `json:"workflowOwnerConfigs,omitempty"`
+	AllowedSigners            []common.Address                   `json:"allowedSigners,omitempty"` // Signers authorized to link workflow owners
+	MCMSConfig                *crecontracts.MCMSConfig           `json:"mcmsConfig,omitempty"`     // MCMS configuration
+}
+
+// SetupWorkflowRegistry configures a deployed workflow registry v2 in one go, so the CRE environment setup can be
+// scripted from a single input. With MCMS, all the changes are in a single proposal.
+type SetupWorkflowRegistry struct{}
+
+func (l SetupWorkflowRegistry) VerifyPreconditions(e cldf.Environment, config SetupWorkflowRegistryInput) error {
+	if _, ok := e.BlockChains.EVMChains()[config.ChainSelector]; !ok {
+		return fmt.Errorf("chain with selector %d not found", config.ChainSelector)
+	}
+	if config.Config == nil && config.CapabilitiesRegistry == nil && len(config.DONFamilyLimits) == 0 &&
+		len(config.UserDONOverrides) == 0 && len(config.WorkflowOwnerConfigs) == 0 && len(config.AllowedSigners) == 0 {
+		return errors.New("nothing to configure")
+	}
+	for i, limit := range config.DONFamilyLimits {
+		if limit.DONFamily == "" {
+			return fmt.Errorf("DON family limit %d: DON family must be provided", i)
+		}
+	}
+	for i, override := range config.UserDONOverrides {
+		if override.DONFamily == "" {
+			return fmt.Errorf("user DON override %d: DON family must be provided", i)
+		}
+	}
+	return nil
+}
+
+func (l SetupWorkflowRegistry) Apply(e cldf.Environment, config SetupWorkflowRegistryInput) (cldf.ChangesetOutput, error) {
+	if err := l.VerifyPreconditions(e, config); err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+
+	// Get MCMS contracts if needed
+	var mcmsContracts *commonchangeset.MCMSWithTimelockState
+	if config.MCMSConfig != nil {
+		var err error
+		mcmsContracts, err = strategies.GetMCMSContracts(e, config.ChainSelector, emptyQualifier)
+		if err != nil {
+			return cldf.ChangesetOutput{}, fmt.Errorf("failed to get MCMS contracts: %w", err)
+		}
+	}
+
+	report, err := operations.ExecuteSequence(
+		e.OperationsBundle,
+		sequences.ConfigureWorkflowRegistry,
+		sequences.ConfigureWorkflowRegistryDeps{
+			Env:           &e,
+			MCMSContracts: mcmsContracts,
+		},
+		sequences.ConfigureWorkflowRegistryInput{
+			ChainSelector:        config.ChainSelector,
+			Qualifier:            config.WorkflowRegistryQualifier,
+			MCMSConfig:           config.MCMSConfig,
+			Config:               config.Config,
+			AllowedSigners:       config.AllowedSigners,
+			DONFamilyLimits:      config.DONFamilyLimits,
+			UserDONOverrides:     config.UserDONOverrides,
+			WorkflowOwnerConfigs: config.WorkflowOwnerConfigs,
+			CapabilitiesRegistry: config.CapabilitiesRegistry,
+		},
+	)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to configure workflow registry: %w", err)
+	}
+
+	return cldf.ChangesetOutput{
+		Reports:               report.ExecutionReports,
+		MCMSTimelockProposals: report.Output.MCMSTimelockProposals,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package changeset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	workflow_registry_v2 "github.com/smartcontractkit/chainlink-evm/gethwrappers/workflow/generated/workflow_registry_wrapper_v2"
+
+	crecontracts "github.com/smartcontractkit/chainlink/deployment/cre/contracts"
+	"github.com/smartcontractkit/chainlink/deployment/cre/workflow_registry/v2/changeset/sequences"
+)
+
+func TestSetupWorkflowRegistry(t *testing.T) {
+	t.Parallel()
+
+	signer := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	input := func(fixture *testFixture) SetupWorkflowRegistryInput {
+		return SetupWorkflowRegistryInput{
+			ChainSelector:             fixture.selector,
+			WorkflowRegistryQualifier: fixture.workflowRegistryQualifier,
+			Config: &sequences.WorkflowRegistryConfig{
+				NameLen:   32,
+				TagLen:    16,
+				URLLen:    128,
+				AttrLen:   256,
+				ExpiryLen: 604800,
+			},
+			DONFamilyLimits: []sequences.DONFamilyLimit{
+				{DONFamily: "family-a", Limit: 100, UserDefaultLimit: 10},
+				{DONFamily: "family-b", Limit: 50, UserDefaultLimit: 5},
+			},
+			AllowedSigners: []common.Address{signer},
+		}
+	}
+
+	t.Run("nothing to configure", func(t *testing.T) {
+		fixture := setupTest(t)
+		err := SetupWorkflowRegistry{}.VerifyPreconditions(fixture.rt.Environment(), SetupWorkflowRegistryInput{
+			ChainSelector:             fixture.selector,
+			WorkflowRegistryQualifier: fixture.workflowRegistryQualifier,
+		})
+		require.ErrorContains(t, err, "nothing to configure")
+	})
+
+	t.Run("full setup", func(t *testing.T) {
+		fixture := setupTest(t)
+
+		output, err := SetupWorkflowRegistry{}.Apply(fixture.rt.Environment(), input(fixture))
+		require.NoError(t, err, "setup should succeed")
+		require.Empty(t, output.MCMSTimelockProposals)
+
+		env := fixture.rt.Environment()
+		registry, err := workflow_registry_v2.NewWorkflowRegistry(
+			common.HexToAddress(fixture.workflowRegistryAddress), env.BlockChains.EVMChains()[fixture.selector].Client,
+		)
+		require.NoError(t, err)
+
+		allowed, err := registry.IsAllowedSigner(nil, signer)
+		require.NoError(t, err)
+		require.True(t, allowed)
+
+		limit, err := registry.GetMaxWorkflowsPerDON(nil, "family-b")
+		require.NoError(t, err)
+		require.Equal(t, uint32(50), limit.MaxWorkflows)
+	})
+
+	t.Run("full setup with MCMS", func(t *testing.T) {
+		fixture := setupTestWithMCMS(t)
+
+		in := input(fixture)
+		in.MCMSConfig = &crecontracts.MCMSConfig{MinDelay: 30 * time.Second}
+		output, err := SetupWorkflowRegistry{}.Apply(fixture.rt.Environment(), in)
+		require.NoError(t, err, "MCMS setup should succeed")
+		require.Len(t, output.MCMSTimelockProposals, 1, "all the changes should be in a single proposal")
+		require.Len(t, output.MCMSTimelockProposals[0].Operations, 4)
+	})
+}
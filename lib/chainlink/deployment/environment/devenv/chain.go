@@ -65,6 +65,7 @@ type ChainConfig struct {
 	SolArtifactDir      string                                 // directory of pre-built solana artifacts, if any
 	Users               []*bind.TransactOpts                   // map of addresses to their transact opts to interact with the chain as users
 	MultiClientOpts     []func(c *cldf_evm_client.MultiClient) // options to configure the multi client
+	RPCFailover         RPCFailoverConfig                      // failover between the rpcs of Solana and Aptos chains
 	AptosDeployerKey    aptos.Account
 }
 
@@ -237,7 +238,7 @@ func NewChains(logger logger.Logger, configs []ChainConfig) (cldf_chain.BlockCha
 					}
 				}
 
-				sc := solRpc.New(chainCfg.HTTPRPCs[0].External)
+				sc := newSolanaFailoverClient(logger, chainCfg.ChainName, chainCfg.ExternalHTTPURLs(), chainCfg.RPCFailover)
 				solSyncMap.Store(chainDetails.ChainSelector, cldf_solana.Chain{
 					Selector:    chainDetails.ChainSelector,
 					Client:      sc,
@@ -261,11 +262,10 @@ func NewChains(logger logger.Logger, configs []ChainConfig) (cldf_chain.BlockCha
 					return err
 				}
 
-				ac, err := aptos.NewClient(aptos.NetworkConfig{
+				ac, err := newAptosFailoverClient(logger, aptos.NetworkConfig{
 					Name:    chainCfg.ChainName,
-					NodeUrl: chainCfg.HTTPRPCs[0].External,
 					ChainId: uint8(cID),
-				})
+				}, chainCfg.ExternalHTTPURLs(), chainCfg.RPCFailover)
 
 				if err != nil {
 					return err
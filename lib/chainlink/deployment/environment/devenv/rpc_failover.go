@@ -0,0 +1,267 @@
+package devenv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/aptos-labs/aptos-go-sdk/api"
+	solRpc "github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+const (
+	// Default failover configuration of the Solana and Aptos clients
+	RPCFailoverDefaultAttempts           = 1
+	RPCFailoverDefaultDelay              = 1000 * time.Millisecond
+	RPCFailoverDefaultHealthCheckTimeout = 2 * time.Second
+)
+
+// RPCFailoverConfig configures the failover of the Solana and Aptos clients between the RPCs of a chain. Calls are
+// retried on the current RPC, then on the next healthy RPC, which becomes the current one if the call succeeds.
+// Errors returned by the chain, e.g. reverts and missing accounts, are not retried.
+// EVM clients fail over with the MultiClient of the framework, which is configured by MultiClientOpts.
+type RPCFailoverConfig struct {
+	Attempts           uint          // attempts of a call on each RPC
+	Delay              time.Duration // delay between the attempts of a call on an RPC
+	HealthCheckTimeout time.Duration // timeout of the health check of an RPC before failing over to it
+}
+
+func (c RPCFailoverConfig) withDefaults() RPCFailoverConfig {
+	if c.Attempts == 0 {
+		c.Attempts = RPCFailoverDefaultAttempts
+	}
+	if c.Delay == 0 {
+		c.Delay = RPCFailoverDefaultDelay
+	}
+	if c.HealthCheckTimeout == 0 {
+		c.HealthCheckTimeout = RPCFailoverDefaultHealthCheckTimeout
+	}
+	return c
+}
+
+// ExternalHTTPURLs returns the external HTTP RPCs of the chain.
+func (c *ChainConfig) ExternalHTTPURLs() []string {
+	urls := make([]string, 0, len(c.HTTPRPCs))
+	for _, rpc := range c.HTTPRPCs {
+		urls = append(urls, rpc.External)
+	}
+	return urls
+}
+
+// rpcFailover calls the clients of the RPCs of a chain, failing over from one to the next.
+type rpcFailover[C any] struct {
+	cfg       RPCFailoverConfig
+	lggr      logger.Logger
+	chainName string
+	urls      []string
+	clients   []C
+	// healthCheck returns an error if the RPC of a client is not healthy
+	healthCheck func(ctx context.Context, client C) error
+	// retryable reports whether a call which returned err may succeed on a retry
+	retryable func(err error) bool
+
+	mu      sync.Mutex
+	current int
+}
+
+// call calls fn with the client of the current RPC, then of the next healthy RPCs until it succeeds.
+func (f *rpcFailover[C]) call(ctx context.Context, fn func(client C) error) error {
+	f.mu.Lock()
+	start := f.current
+	f.mu.Unlock()
+
+	var errs []error
+	for i := range f.clients {
+		idx := (start + i) % len(f.clients)
+		if i > 0 {
+			if err := f.checkHealth(ctx, f.clients[idx]); err != nil {
+				errs = append(errs, fmt.Errorf("rpc %s is unhealthy: %w", f.urls[idx], err))
+				continue
+			}
+		}
+		for attempt := range f.cfg.Attempts {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return errors.Join(append(errs, ctx.Err())...)
+				case <-time.After(f.cfg.Delay):
+				}
+			}
+			err := fn(f.clients[idx])
+			if err == nil || !f.retryable(err) {
+				if i > 0 && err == nil {
+					f.lggr.Warnw("Failed over to RPC", "chain", f.chainName, "rpc", f.urls[idx])
+					f.mu.Lock()
+					f.current = idx
+					f.mu.Unlock()
+				}
+				return err
+			}
+			errs = append(errs, fmt.Errorf("rpc %s: %w", f.urls[idx], err))
+		}
+	}
+	return fmt.Errorf("all rpcs of chain %s failed: %w", f.chainName, errors.Join(errs...))
+}
+
+func (f *rpcFailover[C]) checkHealth(ctx context.Context, client C) error {
+	ctx, cancel := context.WithTimeout(ctx, f.cfg.HealthCheckTimeout)
+	defer cancel()
+	return f.healthCheck(ctx, client)
+}
+
+// solanaFailoverClient is a Solana JSON RPC client which fails over between the RPCs of a chain.
+type solanaFailoverClient struct {
+	*rpcFailover[solRpc.JSONRPCClient]
+}
+
+var _ solRpc.JSONRPCClient = solanaFailoverClient{}
+
+// newSolanaFailoverClient returns a Solana client which fails over between urls.
+func newSolanaFailoverClient(lggr logger.Logger, chainName string, urls []string, cfg RPCFailoverConfig) *solRpc.Client {
+	clients := make([]solRpc.JSONRPCClient, 0, len(urls))
+	for _, url := range urls {
+		clients = append(clients, jsonrpc.NewClient(url))
+	}
+	return solRpc.NewWithCustomRPCClient(solanaFailoverClient{&rpcFailover[solRpc.JSONRPCClient]{
+		cfg:       cfg.withDefaults(),
+		lggr:      lggr,
+		chainName: chainName,
+		urls:      urls,
+		clients:   clients,
+		healthCheck: func(ctx context.Context, client solRpc.JSONRPCClient) error {
+			_, err := solRpc.NewWithCustomRPCClient(client).GetHealth(ctx)
+			return err
+		},
+		retryable: func(err error) bool {
+			var rpcErr *jsonrpc.RPCError
+			return !errors.As(err, &rpcErr)
+		},
+	}})
+}
+
+func (c solanaFailoverClient) CallForInto(ctx context.Context, out any, method string, params []any) error {
+	return c.call(ctx, func(client solRpc.JSONRPCClient) error {
+		return client.CallForInto(ctx, out, method, params)
+	})
+}
+
+func (c solanaFailoverClient) CallWithCallback(ctx context.Context, method string, params []any, callback func(*http.Request, *http.Response) error) error {
+	return c.call(ctx, func(client solRpc.JSONRPCClient) error {
+		return client.CallWithCallback(ctx, method, params, callback)
+	})
+}
+
+func (c solanaFailoverClient) CallBatch(ctx context.Context, requests jsonrpc.RPCRequests) (jsonrpc.RPCResponses, error) {
+	var responses jsonrpc.RPCResponses
+	err := c.call(ctx, func(client solRpc.JSONRPCClient) error {
+		var err error
+		responses, err = client.CallBatch(ctx, requests)
+		return err
+	})
+	return responses, err
+}
+
+// aptosFailoverClient is an Aptos client which fails over between the RPCs of a chain for the reads of the state and
+// the confirmation of transactions. The other calls use the first RPC.
+type aptosFailoverClient struct {
+	aptos.AptosRpcClient
+	failover *rpcFailover[aptos.AptosRpcClient]
+}
+
+// newAptosFailoverClient returns an Aptos client which fails over between urls.
+func newAptosFailoverClient(lggr logger.Logger, network aptos.NetworkConfig, urls []string, cfg RPCFailoverConfig) (aptos.AptosRpcClient, error) {
+	clients := make([]aptos.AptosRpcClient, 0, len(urls))
+	for _, url := range urls {
+		network.NodeUrl = url
+		client, err := aptos.NewClient(network)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client of rpc %s: %w", url, err)
+		}
+		clients = append(clients, client)
+	}
+	return newAptosFailover(lggr, network.Name, urls, clients, cfg), nil
+}
+
+func newAptosFailover(lggr logger.Logger, chainName string, urls []string, clients []aptos.AptosRpcClient, cfg RPCFailoverConfig) aptosFailoverClient {
+	return aptosFailoverClient{
+		AptosRpcClient: clients[0],
+		failover: &rpcFailover[aptos.AptosRpcClient]{
+			cfg:       cfg.withDefaults(),
+			lggr:      lggr,
+			chainName: chainName,
+			urls:      urls,
+			clients:   clients,
+			healthCheck: func(_ context.Context, client aptos.AptosRpcClient) error {
+				_, err := client.Info()
+				return err
+			},
+			retryable: func(err error) bool {
+				var httpErr *aptos.HttpError
+				if errors.As(err, &httpErr) {
+					return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+				}
+				return true
+			},
+		},
+	}
+}
+
+// call calls fn with the client of the current RPC, then of the next healthy RPCs until it succeeds.
+func aptosCall[T any](c aptosFailoverClient, fn func(client aptos.AptosRpcClient) (T, error)) (T, error) {
+	var out T
+	err := c.failover.call(context.Background(), func(client aptos.AptosRpcClient) error {
+		var err error
+		out, err = fn(client)
+		return err
+	})
+	return out, err
+}
+
+func (c aptosFailoverClient) Info() (aptos.NodeInfo, error) {
+	return aptosCall(c, func(client aptos.AptosRpcClient) (aptos.NodeInfo, error) {
+		return client.Info()
+	})
+}
+
+func (c aptosFailoverClient) Account(address aptos.AccountAddress, ledgerVersion ...uint64) (aptos.AccountInfo, error) {
+	return aptosCall(c, func(client aptos.AptosRpcClient) (aptos.AccountInfo, error) {
+		return client.Account(address, ledgerVersion...)
+	})
+}
+
+func (c aptosFailoverClient) AccountResource(address aptos.AccountAddress, resourceType string, ledgerVersion ...uint64) (map[string]any, error) {
+	return aptosCall(c, func(client aptos.AptosRpcClient) (map[string]any, error) {
+		return client.AccountResource(address, resourceType, ledgerVersion...)
+	})
+}
+
+func (c aptosFailoverClient) AccountResources(address aptos.AccountAddress, ledgerVersion ...uint64) ([]aptos.AccountResourceInfo, error) {
+	return aptosCall(c, func(client aptos.AptosRpcClient) ([]aptos.AccountResourceInfo, error) {
+		return client.AccountResources(address, ledgerVersion...)
+	})
+}
+
+func (c aptosFailoverClient) View(payload *aptos.ViewPayload, ledgerVersion ...uint64) ([]any, error) {
+	return aptosCall(c, func(client aptos.AptosRpcClient) ([]any, error) {
+		return client.View(payload, ledgerVersion...)
+	})
+}
+
+func (c aptosFailoverClient) TransactionByHash(txnHash string) (*api.Transaction, error) {
+	return aptosCall(c, func(client aptos.AptosRpcClient) (*api.Transaction, error) {
+		return client.TransactionByHash(txnHash)
+	})
+}
+
+func (c aptosFailoverClient) WaitForTransaction(txnHash string, options ...any) (*api.UserTransaction, error) {
+	return aptosCall(c, func(client aptos.AptosRpcClient) (*api.UserTransaction, error) {
+		return client.WaitForTransaction(txnHash, options...)
+	})
+}
@@ -0,0 +1,93 @@
+package devenv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aptos-labs/aptos-go-sdk"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// flakyAptosClient fails its views with err, and its health checks if unhealthy.
+type flakyAptosClient struct {
+	aptos.AptosRpcClient
+	err       error
+	unhealthy bool
+	views     int
+}
+
+func (c *flakyAptosClient) Info() (aptos.NodeInfo, error) {
+	if c.unhealthy {
+		return aptos.NodeInfo{}, errors.New("unhealthy")
+	}
+	return aptos.NodeInfo{}, nil
+}
+
+func (c *flakyAptosClient) View(*aptos.ViewPayload, ...uint64) ([]any, error) {
+	c.views++
+	return []any{c.views}, c.err
+}
+
+func TestAptosFailoverClient(t *testing.T) {
+	newClient := func(clients ...*flakyAptosClient) aptosFailoverClient {
+		rpcClients := make([]aptos.AptosRpcClient, 0, len(clients))
+		urls := make([]string, 0, len(clients))
+		for i, client := range clients {
+			rpcClients = append(rpcClients, client)
+			urls = append(urls, string(rune('a'+i)))
+		}
+		return newAptosFailover(logger.Test(t), "chain", urls, rpcClients, RPCFailoverConfig{Attempts: 2, Delay: time.Millisecond})
+	}
+
+	t.Run("fails over to the next healthy rpc", func(t *testing.T) {
+		down := &flakyAptosClient{err: errors.New("connection refused")}
+		unhealthy := &flakyAptosClient{unhealthy: true}
+		healthy := &flakyAptosClient{}
+		client := newClient(down, unhealthy, healthy)
+
+		_, err := client.View(&aptos.ViewPayload{})
+		require.NoError(t, err)
+		require.Equal(t, 2, down.views)
+		require.Equal(t, 0, unhealthy.views)
+		require.Equal(t, 1, healthy.views)
+
+		// the healthy rpc is now the current one
+		_, err = client.View(&aptos.ViewPayload{})
+		require.NoError(t, err)
+		require.Equal(t, 2, down.views)
+		require.Equal(t, 2, healthy.views)
+	})
+
+	t.Run("does not retry errors of the chain", func(t *testing.T) {
+		reverting := &flakyAptosClient{err: &aptos.HttpError{StatusCode: http.StatusBadRequest}}
+		healthy := &flakyAptosClient{}
+		client := newClient(reverting, healthy)
+
+		_, err := client.View(&aptos.ViewPayload{})
+		require.Error(t, err)
+		require.Equal(t, 1, reverting.views)
+		require.Equal(t, 0, healthy.views)
+	})
+
+	t.Run("returns the errors of all the rpcs", func(t *testing.T) {
+		client := newClient(&flakyAptosClient{err: errors.New("timeout")}, &flakyAptosClient{unhealthy: true})
+		_, err := client.View(&aptos.ViewPayload{})
+		require.ErrorContains(t, err, "all rpcs of chain chain failed")
+		require.ErrorContains(t, err, "timeout")
+		require.ErrorContains(t, err, "unhealthy")
+	})
+}
+
+func TestSolanaFailoverClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	client := newSolanaFailoverClient(logger.Test(t), "chain", []string{"http://127.0.0.1:1", "http://127.0.0.1:2"},
+		RPCFailoverConfig{Delay: time.Millisecond, HealthCheckTimeout: time.Second})
+	_, err := client.GetHealth(ctx)
+	require.ErrorContains(t, err, "all rpcs of chain chain failed")
+}
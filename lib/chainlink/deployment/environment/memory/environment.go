@@ -244,9 +244,9 @@ func NewMemoryEnvironment(
 	nodes := NewNodes(t, c)
 	var nodeIDs []string
 	for id, node := range nodes {
-		require.NoError(t, node.App.Start(t.Context()))
+		require.NoError(t, node.Start(t.Context()))
 		t.Cleanup(func() {
-			require.NoError(t, node.App.Stop())
+			require.NoError(t, node.Stop())
 		})
 		nodeIDs = append(nodeIDs, id)
 	}
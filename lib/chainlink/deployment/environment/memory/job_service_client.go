@@ -22,12 +22,12 @@ type autoApprovalNode struct {
 var _ JobApprover = &autoApprovalNode{}
 
 func (q *autoApprovalNode) AutoApproveJob(ctx context.Context, p *feeds.ProposeJobArgs) error {
-	appProposalID, err := q.App.GetFeedsService().ProposeJob(ctx, p)
+	appProposalID, err := q.Application().GetFeedsService().ProposeJob(ctx, p)
 	if err != nil {
 		return fmt.Errorf("failed to propose job: %w", err)
 	}
 	// auto approve
-	proposedSpec, err := q.App.GetFeedsService().ListSpecsByJobProposalIDs(ctx, []int64{appProposalID})
+	proposedSpec, err := q.Application().GetFeedsService().ListSpecsByJobProposalIDs(ctx, []int64{appProposalID})
 	if err != nil {
 		return fmt.Errorf("failed to list specs: %w", err)
 	}
@@ -35,7 +35,7 @@ func (q *autoApprovalNode) AutoApproveJob(ctx context.Context, p *feeds.ProposeJ
 	if len(proposedSpec) == 0 {
 		return fmt.Errorf("no specs found for job proposal id: %d", appProposalID)
 	}
-	err = q.App.GetFeedsService().ApproveSpec(ctx, proposedSpec[len(proposedSpec)-1].ID, true)
+	err = q.Application().GetFeedsService().ApproveSpec(ctx, proposedSpec[len(proposedSpec)-1].ID, true)
 	if err != nil {
 		return fmt.Errorf("failed to approve job: %w", err)
 	}
@@ -68,8 +68,10 @@ import (
 )
 
 type Node struct {
-	ID     string
-	Name   string
+	ID   string
+	Name string
+	// App is the application the node was created with, use Application to get the running one once the node was
+	// restarted.
 	App    chainlink.Application
 	Chains []uint64 // chain selectors
 	// Transmitter key/OCR keys for this node
@@ -77,6 +79,8 @@ type Node struct {
 	Addr       net.TCPAddr
 	IsBoostrap bool
 	Labels     []*ptypes.Label
+
+	lifecycle *nodeLifecycle
 }
 
 func (n Node) MultiAddr() string {
@@ -99,7 +103,7 @@ func (n Node) ReplayLogs(ctx context.Context, chains map[uint64]uint64) error {
 			fmt.Printf("ReplayFromBlock: family: %q chainID: %q\n", family, chainID)
 			continue
 		}
-		if err := n.App.ReplayFromBlock(ctx, family, chainID, block, false); err != nil {
+		if err := n.Application().ReplayFromBlock(ctx, family, chainID, block, false); err != nil {
 			return err
 		}
 	}
@@ -119,10 +123,10 @@ func (n Node) IsLogFilterRegistered(ctx context.Context, chainSel uint64, eventN
 	var exists bool
 	switch family {
 	case chainsel.FamilyEVM:
-		orm := evmlptesting.NewTestORM(n.App.GetDB())
+		orm := evmlptesting.NewTestORM(n.Application().GetDB())
 		exists, err = orm.HasFilterByEventSig(ctx, chainID, common.HexToHash(eventName), address)
 	case chainsel.FamilySolana:
-		orm := sollptesting.NewTestORM(n.App.GetDB())
+		orm := sollptesting.NewTestORM(n.Application().GetDB())
 		exists, err = orm.HasFilterByEventName(ctx, chainID, eventName, address)
 	default:
 		return false, fmt.Errorf("unsupported chain family; %v", family)
@@ -412,14 +416,6 @@ func NewNode(
 	// Set logging.
 	lggr := logger.NewSingleFileLogger(t)
 
-	// Create clients for the core node backed by sim.
-	clients := make(map[uint64]client.Client)
-	for chainID, chain := range evmchains {
-		if chain.Backend != nil {
-			clients[chainID] = client.NewSimulatedBackendClient(t, chain.Backend, big.NewInt(int64(chainID))) //nolint:gosec // it shouldn't overflow
-		}
-	}
-
 	master := keystore.New(db, utils.FastScryptParams, lggr.Infof)
 	ctx := t.Context()
 	require.NoError(t, master.Unlock(ctx, "password"))
@@ -427,35 +423,49 @@ func NewNode(
 	require.NoError(t, master.Workflow().EnsureKey(ctx))
 	require.NoError(t, master.OCR2().EnsureKeys(ctx, chaintype.EVM, chaintype.Solana, chaintype.Aptos))
 
-	app, err := chainlink.NewApplication(ctx, chainlink.ApplicationOpts{
-		CREOpts: chainlink.CREOpts{
-			CapabilitiesRegistry: capabilities.NewRegistry(lggr),
-		},
-		Config:   cfg,
-		DS:       db,
-		KeyStore: master,
-		// TODO BCF-2513 Stop injecting ethClient via override, instead use httptest.
-		EVMFactoryConfigFn: func(fc *chainlink.EVMFactoryConfig) {
-			// Create ChainStores that always sign with 1337
-			fc.GenChainStore = func(ks core.Keystore, i *big.Int) keys.ChainStore {
-				return keys.NewChainStore(ks, big.NewInt(1337))
+	// newApp creates an application of the node, it is called again to restart the node as a stopped application
+	// cannot be started again. The applications share the database and the keystore.
+	newApp := func(ctx context.Context) (chainlink.Application, error) {
+		// Create clients for the core node backed by sim.
+		clients := make(map[uint64]client.Client)
+		for chainID, chain := range evmchains {
+			if chain.Backend != nil {
+				clients[chainID] = client.NewSimulatedBackendClient(t, chain.Backend, big.NewInt(int64(chainID))) //nolint:gosec // it shouldn't overflow
 			}
-			fc.GenEthClient = func(i *big.Int) client.Client {
-				ethClient, ok := clients[i.Uint64()]
-				if !ok {
-					return client.NewNullClient(i, lggr)
+		}
+
+		return chainlink.NewApplication(ctx, chainlink.ApplicationOpts{
+			CREOpts: chainlink.CREOpts{
+				CapabilitiesRegistry: capabilities.NewRegistry(lggr),
+			},
+			Config:   cfg,
+			DS:       db,
+			KeyStore: master,
+			// TODO BCF-2513 Stop injecting ethClient via override, instead use httptest.
+			EVMFactoryConfigFn: func(fc *chainlink.EVMFactoryConfig) {
+				// Create ChainStores that always sign with 1337
+				fc.GenChainStore = func(ks core.Keystore, i *big.Int) keys.ChainStore {
+					return keys.NewChainStore(ks, big.NewInt(1337))
 				}
-				return ethClient
-			}
-		},
-		Logger:                   lggr,
-		ExternalInitiatorManager: nil,
-		CloseLogger:              lggr.Sync,
-		UnrestrictedHTTPClient:   &http.Client{},
-		RestrictedHTTPClient:     &http.Client{},
-		AuditLogger:              audit.NoopLogger,
-		RetirementReportCache:    retirement.NewRetirementReportCache(lggr, db),
-	})
+				fc.GenEthClient = func(i *big.Int) client.Client {
+					ethClient, ok := clients[i.Uint64()]
+					if !ok {
+						return client.NewNullClient(i, lggr)
+					}
+					return ethClient
+				}
+			},
+			Logger:                   lggr,
+			ExternalInitiatorManager: nil,
+			CloseLogger:              lggr.Sync,
+			UnrestrictedHTTPClient:   &http.Client{},
+			RestrictedHTTPClient:     &http.Client{},
+			AuditLogger:              audit.NoopLogger,
+			RetirementReportCache:    retirement.NewRetirementReportCache(lggr, db),
+		})
+	}
+
+	app, err := newApp(ctx)
 	require.NoError(t, err)
 	keys := CreateKeys(t, app,
 		nodecfg.BlockChains.EVMChains(),
@@ -491,6 +501,17 @@ func NewNode(
 		Addr:       net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: nodecfg.Port},
 		IsBoostrap: nodecfg.Bootstrap,
 		Labels:     nodeLabels,
+		lifecycle: &nodeLifecycle{
+			app: app,
+			newApp: func(ctx context.Context) (chainlink.Application, error) {
+				app, err := newApp(ctx)
+				if err != nil {
+					return nil, err
+				}
+				setFeedsConnectionsManager(t, app.GetFeedsService())
+				return app, nil
+			},
+		},
 	}
 }
 
@@ -781,15 +802,20 @@ func setupJD(t *testing.T, app chainlink.Application) {
 		PublicKey: *pkey,
 	}
 	f := app.GetFeedsService()
+	setFeedsConnectionsManager(t, f)
+
+	_, err = f.RegisterManager(testutils.Context(t), m)
+	require.NoError(t, err)
+}
+
+// setFeedsConnectionsManager replaces the connections to the job distributor of the feeds service with no-op ones.
+func setFeedsConnectionsManager(t *testing.T, f feeds2.Service) {
 	connManager := feedsMocks.NewConnectionsManager(t)
 	connManager.On("Connect", mock.Anything).Maybe()
 	connManager.On("GetClient", mock.Anything).Maybe().Return(noopFeedsClient{}, nil)
 	connManager.On("Close").Maybe().Return()
 	connManager.On("IsConnected", mock.Anything).Maybe().Return(true)
 	f.Unsafe_SetConnectionsManager(connManager)
-
-	_, err = f.RegisterManager(testutils.Context(t), m)
-	require.NoError(t, err)
 }
 
 func randomBytes32(t *testing.T) []byte {
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+)
+
+// nodeLifecycle is shared by the copies of a node, so that all of them see the application running after a restart.
+type nodeLifecycle struct {
+	mu      sync.Mutex
+	app     chainlink.Application
+	running bool
+	// newApp creates a new application of the node, backed by the same database and keystore.
+	newApp func(ctx context.Context) (chainlink.Application, error)
+}
+
+// Application returns the running application of the node, or the last one if the node is stopped.
+func (n Node) Application() chainlink.Application {
+	if n.lifecycle == nil {
+		return n.App
+	}
+	n.lifecycle.mu.Lock()
+	defer n.lifecycle.mu.Unlock()
+	return n.lifecycle.app
+}
+
+// Start starts the application of the node, it is a no-op if the node is running.
+func (n Node) Start(ctx context.Context) error {
+	if n.lifecycle == nil {
+		return n.App.Start(ctx)
+	}
+	n.lifecycle.mu.Lock()
+	defer n.lifecycle.mu.Unlock()
+	if n.lifecycle.running {
+		return nil
+	}
+	if err := n.lifecycle.app.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start node %s: %w", n.Name, err)
+	}
+	n.lifecycle.running = true
+	return nil
+}
+
+// Stop stops the application of the node, it is a no-op if the node is stopped.
+func (n Node) Stop() error {
+	if n.lifecycle == nil {
+		return n.App.Stop()
+	}
+	n.lifecycle.mu.Lock()
+	defer n.lifecycle.mu.Unlock()
+	if !n.lifecycle.running {
+		return nil
+	}
+	n.lifecycle.running = false
+	if err := n.lifecycle.app.Stop(); err != nil {
+		return fmt.Errorf("failed to stop node %s: %w", n.Name, err)
+	}
+	return nil
+}
+
+// Restart stops the node if it is running, and starts a new application of the node. The state of the node, i.e. its
+// keys, jobs and log poller filters, is kept in its database, so the node resumes its jobs like a restarted process.
+func (n Node) Restart(ctx context.Context) error {
+	if n.lifecycle == nil {
+		return errors.New("node cannot be restarted")
+	}
+	if err := n.Stop(); err != nil {
+		return err
+	}
+	n.lifecycle.mu.Lock()
+	defer n.lifecycle.mu.Unlock()
+	app, err := n.lifecycle.newApp(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create application of node %s: %w", n.Name, err)
+	}
+	if err := app.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start node %s: %w", n.Name, err)
+	}
+	n.lifecycle.app = app
+	n.lifecycle.running = true
+	return nil
+}
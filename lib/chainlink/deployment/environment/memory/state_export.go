@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+// EnvironmentStateVersion is bumped whenever the layout of EnvironmentState changes in a
+// backwards incompatible way.
+const EnvironmentStateVersion = 1
+
+// EnvironmentState is a serializable view of everything a memory environment has recorded
+// about its deployed contracts. It allows deploying once and reusing the result across tests,
+// or attaching the exact state to a bug report.
+type EnvironmentState struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	// Chains maps every chain selector the state refers to onto its chain family.
+	Chains map[uint64]string `json:"chains"`
+	// AddressBook is the content of the (legacy) address book.
+	AddressBook map[uint64]map[string]cldf.TypeAndVersion `json:"addressBook"`
+	// Addresses, ChainMetadata and ContractMetadata are the content of the datastore.
+	Addresses        []datastore.AddressRef       `json:"addresses"`
+	ChainMetadata    []datastore.ChainMetadata    `json:"chainMetadata"`
+	ContractMetadata []datastore.ContractMetadata `json:"contractMetadata"`
+}
+
+// NewEnvironmentState captures the address book and datastore of the given environment.
+func NewEnvironmentState(env cldf.Environment) (EnvironmentState, error) {
+	state := EnvironmentState{
+		Version:     EnvironmentStateVersion,
+		Name:        env.Name,
+		Chains:      make(map[uint64]string),
+		AddressBook: make(map[uint64]map[string]cldf.TypeAndVersion),
+	}
+
+	if env.ExistingAddresses != nil {
+		addrs, err := env.ExistingAddresses.Addresses()
+		if err != nil {
+			return EnvironmentState{}, fmt.Errorf("failed to read address book: %w", err)
+		}
+		state.AddressBook = addrs
+	}
+
+	if env.DataStore != nil {
+		refs, err := env.DataStore.Addresses().Fetch()
+		if err != nil {
+			return EnvironmentState{}, fmt.Errorf("failed to read datastore addresses: %w", err)
+		}
+		state.Addresses = refs
+
+		chainMetadata, err := env.DataStore.ChainMetadata().Fetch()
+		if err != nil {
+			return EnvironmentState{}, fmt.Errorf("failed to read datastore chain metadata: %w", err)
+		}
+		state.ChainMetadata = chainMetadata
+
+		contractMetadata, err := env.DataStore.ContractMetadata().Fetch()
+		if err != nil {
+			return EnvironmentState{}, fmt.Errorf("failed to read datastore contract metadata: %w", err)
+		}
+		state.ContractMetadata = contractMetadata
+	}
+
+	selectors := make([]uint64, 0)
+	for sel := range state.AddressBook {
+		selectors = append(selectors, sel)
+	}
+	for _, ref := range state.Addresses {
+		selectors = append(selectors, ref.ChainSelector)
+	}
+	for sel := range env.BlockChains.All() {
+		selectors = append(selectors, sel)
+	}
+	for _, sel := range selectors {
+		family, err := chainsel.GetSelectorFamily(sel)
+		if err != nil {
+			return EnvironmentState{}, fmt.Errorf("failed to resolve family for chain selector %d: %w", sel, err)
+		}
+		state.Chains[sel] = family
+	}
+
+	return state, nil
+}
+
+// ExportEnvironmentState writes the address book and datastore of the given environment to path as JSON.
+func ExportEnvironmentState(env cldf.Environment, path string) error {
+	state, err := NewEnvironmentState(env)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal environment state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write environment state to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadEnvironmentState reads an environment state previously written by ExportEnvironmentState.
+func LoadEnvironmentState(path string) (EnvironmentState, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return EnvironmentState{}, fmt.Errorf("failed to read environment state from %s: %w", path, err)
+	}
+	var state EnvironmentState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return EnvironmentState{}, fmt.Errorf("failed to unmarshal environment state: %w", err)
+	}
+	if state.Version != EnvironmentStateVersion {
+		return EnvironmentState{}, fmt.Errorf("unsupported environment state version %d, expected %d", state.Version, EnvironmentStateVersion)
+	}
+
+	return state, nil
+}
+
+// NewAddressBook reconstructs the address book held by the state.
+func (s EnvironmentState) NewAddressBook() *cldf.AddressBookMap {
+	return cldf.NewMemoryAddressBookFromMap(s.AddressBook)
+}
+
+// NewDataStore reconstructs the datastore held by the state.
+func (s EnvironmentState) NewDataStore() (*datastore.MemoryDataStore, error) {
+	ds := datastore.NewMemoryDataStore()
+	for _, ref := range s.Addresses {
+		if err := ds.Addresses().Add(ref); err != nil {
+			return nil, fmt.Errorf("failed to add address ref %s on chain %d: %w", ref.Address, ref.ChainSelector, err)
+		}
+	}
+	for _, md := range s.ChainMetadata {
+		if err := ds.ChainMetadata().Add(md); err != nil {
+			return nil, fmt.Errorf("failed to add chain metadata for chain %d: %w", md.ChainSelector, err)
+		}
+	}
+	for _, md := range s.ContractMetadata {
+		if err := ds.ContractMetadata().Add(md); err != nil {
+			return nil, fmt.Errorf("failed to add contract metadata for %s on chain %d: %w", md.Address, md.ChainSelector, err)
+		}
+	}
+
+	return ds, nil
+}
+
+// Apply returns a copy of env whose address book and datastore are replaced by the content of the state.
+// Every chain referenced by the state must be present in env.
+func (s EnvironmentState) Apply(env cldf.Environment) (cldf.Environment, error) {
+	var errs []error
+	for sel, family := range s.Chains {
+		if !env.BlockChains.Exists(sel) {
+			errs = append(errs, fmt.Errorf("%s chain %d from environment state is not present in environment", family, sel))
+		}
+	}
+	if len(errs) > 0 {
+		return cldf.Environment{}, errors.Join(errs...)
+	}
+
+	ds, err := s.NewDataStore()
+	if err != nil {
+		return cldf.Environment{}, err
+	}
+	env.ExistingAddresses = s.NewAddressBook()
+	env.DataStore = ds.Seal()
+
+	return env, nil
+}
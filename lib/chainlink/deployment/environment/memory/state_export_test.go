@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	cldf_chain "github.com/smartcontractkit/chainlink-deployments-framework/chain"
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+)
+
+func TestEnvironmentState_ExportLoad(t *testing.T) {
+	sel := chainsel.TEST_90000001.Selector
+	blockchains := cldf_chain.NewBlockChainsFromSlice([]cldf_chain.BlockChain{
+		cldf_evm.Chain{Selector: sel},
+	})
+
+	ab := cldf.NewMemoryAddressBook()
+	tv := cldf.NewTypeAndVersion("Router", *semver.MustParse("1.6.0"))
+	tv.AddLabel("primary")
+	require.NoError(t, ab.Save(sel, "0x0000000000000000000000000000000000000001", tv))
+
+	ds := datastore.NewMemoryDataStore()
+	require.NoError(t, ds.Addresses().Add(datastore.AddressRef{
+		Address:       "0x0000000000000000000000000000000000000002",
+		ChainSelector: sel,
+		Type:          "FeeQuoter",
+		Version:       semver.MustParse("1.6.0"),
+		Qualifier:     "test",
+		Labels:        datastore.NewLabelSet("a", "b"),
+	}))
+
+	env := cldf.Environment{
+		Name:              Memory,
+		ExistingAddresses: ab,
+		DataStore:         ds.Seal(),
+		BlockChains:       blockchains,
+	}
+
+	path := filepath.Join(t.TempDir(), "state", "env.json")
+	require.NoError(t, ExportEnvironmentState(env, path))
+
+	state, err := LoadEnvironmentState(path)
+	require.NoError(t, err)
+	require.Equal(t, Memory, state.Name)
+	require.Equal(t, map[uint64]string{sel: chainsel.FamilyEVM}, state.Chains)
+
+	t.Run("Apply", func(t *testing.T) {
+		fresh := cldf.Environment{Name: Memory, BlockChains: blockchains}
+		restored, err := state.Apply(fresh)
+		require.NoError(t, err)
+
+		addrs, err := restored.ExistingAddresses.AddressesForChain(sel)
+		require.NoError(t, err)
+		require.Len(t, addrs, 1)
+		require.True(t, tv.Equal(addrs["0x0000000000000000000000000000000000000001"]))
+
+		refs, err := restored.DataStore.Addresses().Fetch()
+		require.NoError(t, err)
+		require.Len(t, refs, 1)
+		require.Equal(t, "test", refs[0].Qualifier)
+		require.True(t, refs[0].Labels.Equal(datastore.NewLabelSet("a", "b")))
+	})
+
+	t.Run("Apply fails on missing chain", func(t *testing.T) {
+		_, err := state.Apply(cldf.Environment{Name: Memory})
+		require.ErrorContains(t, err, "is not present in environment")
+	})
+}
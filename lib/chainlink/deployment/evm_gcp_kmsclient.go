@@ -0,0 +1,110 @@
+package deployment
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+)
+
+// GCPKMSClient is the subset of the Cloud KMS API used to sign with an EC_SIGN_SECP256K1_SHA256 key version, e.g. an
+// adapter of the KeyManagementClient of cloud.google.com/go/kms/apiv1.
+type GCPKMSClient interface {
+	// GetPublicKey returns the PEM-encoded public key of the key version.
+	GetPublicKey(ctx context.Context, keyVersion string) (string, error)
+	// AsymmetricSign signs the 32-byte digest with the key version, and returns the DER-encoded signature.
+	AsymmetricSign(ctx context.Context, keyVersion string, digest []byte) ([]byte, error)
+}
+
+var _ EVMKMSSigner = (*EVMGCPKMSClient)(nil)
+
+// EVMGCPKMSClient signs EVM transactions with a secp256k1 key version of GCP KMS.
+type EVMGCPKMSClient struct {
+	Client GCPKMSClient
+	// KeyVersion is the resource name of the key version,
+	// e.g. projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1.
+	KeyVersion string
+	PublicKey  *ecdsa.PublicKey // singleton
+}
+
+func NewEVMGCPKMSClient(client GCPKMSClient, keyVersion string) *EVMGCPKMSClient {
+	return &EVMGCPKMSClient{
+		Client:     client,
+		KeyVersion: keyVersion,
+	}
+}
+
+func (c *EVMGCPKMSClient) GetKMSAddress() (common.Address, error) {
+	ecdsaPublicKey, err := c.GetECDSAPublicKey()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return crypto.PubkeyToAddress(*ecdsaPublicKey), nil
+}
+
+func (c *EVMGCPKMSClient) GetKMSTransactOpts(ctx context.Context, chainID *big.Int) (*bind.TransactOpts, error) {
+	keyAddr, err := c.GetKMSAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return kmsTransactOpts(ctx, keyAddr, chainID, c.SignHash)
+}
+
+func (c *EVMGCPKMSClient) SignHash(hash []byte) ([]byte, error) {
+	signature, err := c.Client.AsymmetricSign(context.Background(), c.KeyVersion, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call AsymmetricSign on hash: %w", err)
+	}
+
+	pubKey, err := c.GetECDSAPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	sig, err := kmsToEthSig(signature, secp256k1.S256().Marshal(pubKey.X, pubKey.Y), hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert KMS signature to Ethereum signature: %w", err)
+	}
+
+	return sig, nil
+}
+
+// GetECDSAPublicKey retrieves the public key from KMS and converts it to its ECDSA representation.
+func (c *EVMGCPKMSClient) GetECDSAPublicKey() (*ecdsa.PublicKey, error) {
+	if c.PublicKey != nil {
+		return c.PublicKey, nil
+	}
+
+	pemKey, err := c.Client.GetPublicKey(context.Background(), c.KeyVersion)
+	if err != nil {
+		return nil, fmt.Errorf("can not get public key from KMS for key version %s: %w", c.KeyVersion, err)
+	}
+
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, errors.New("can not decode PEM public key for key version " + c.KeyVersion)
+	}
+
+	// The x509 package does not support secp256k1, the subject public key info is unpacked like for AWS KMS.
+	var asn1pubKeyInfo asn1SubjectPublicKeyInfo
+	_, err = asn1.Unmarshal(block.Bytes, &asn1pubKeyInfo)
+	if err != nil {
+		return nil, fmt.Errorf("can not parse asn1 public key for key version %s: %w", c.KeyVersion, err)
+	}
+
+	c.PublicKey, err = crypto.UnmarshalPubkey(asn1pubKeyInfo.SubjectPublicKey.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("can not unmarshal public key bytes: %w", err)
+	}
+	return c.PublicKey, nil
+}
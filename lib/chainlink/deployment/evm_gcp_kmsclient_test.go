@@ -0,0 +1,61 @@
+package deployment
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGCPKMSClient signs with a local key like a GCP KMS secp256k1 key version.
+type fakeGCPKMSClient struct {
+	key *ecdsa.PrivateKey
+}
+
+func (c fakeGCPKMSClient) GetPublicKey(_ context.Context, _ string) (string, error) {
+	pub := crypto.FromECDSAPub(&c.key.PublicKey)
+	der, err := asn1.Marshal(asn1SubjectPublicKeyInfo{
+		AlgorithmIdentifier: asn1AlgorithmIdentifier{
+			Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+			Parameters: asn1.ObjectIdentifier{1, 3, 132, 0, 10},
+		},
+		SubjectPublicKey: asn1.BitString{Bytes: pub, BitLength: len(pub) * 8},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+func (c fakeGCPKMSClient) AsymmetricSign(_ context.Context, _ string, digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, c.key)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])})
+}
+
+func TestEVMGCPKMSClient(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	client := NewEVMGCPKMSClient(fakeGCPKMSClient{key: key}, "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1")
+
+	addr, err := client.GetKMSAddress()
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), addr)
+
+	chainID := big.NewInt(1337)
+	opts, err := client.GetKMSTransactOpts(t.Context(), chainID)
+	require.NoError(t, err)
+	signed, err := opts.Signer(addr, types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1}))
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, addr, sender)
+}
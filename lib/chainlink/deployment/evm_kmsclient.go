@@ -74,6 +74,14 @@ func NewKMSClient(config KMS) (KMSClient, error) {
 	return kms.New(awsSessionFn(config)), nil
 }
 
+// EVMKMSSigner is an EVM deployer key held by a KMS, see EVMKMSClient for AWS KMS and EVMGCPKMSClient for GCP KMS.
+type EVMKMSSigner interface {
+	GetKMSAddress() (common.Address, error)
+	GetKMSTransactOpts(ctx context.Context, chainID *big.Int) (*bind.TransactOpts, error)
+}
+
+var _ EVMKMSSigner = (*EVMKMSClient)(nil)
+
 type EVMKMSClient struct {
 	Client    KMSClient
 	KeyID     string
@@ -102,6 +110,11 @@ func (c *EVMKMSClient) GetKMSTransactOpts(ctx context.Context, chainID *big.Int)
 		return nil, err
 	}
 
+	return kmsTransactOpts(ctx, keyAddr, chainID, c.SignHash)
+}
+
+// kmsTransactOpts returns transact opts signing the transactions of the chain with the hash signer of a KMS key.
+func kmsTransactOpts(ctx context.Context, keyAddr common.Address, chainID *big.Int, signHash func(hash []byte) ([]byte, error)) (*bind.TransactOpts, error) {
 	if chainID == nil {
 		return nil, errors.New("chainID is required")
 	}
@@ -114,7 +127,7 @@ func (c *EVMKMSClient) GetKMSTransactOpts(ctx context.Context, chainID *big.Int)
 
 		txHashBytes := signer.Hash(tx).Bytes()
 
-		ethSig, err := c.SignHash(txHashBytes)
+		ethSig, err := signHash(txHashBytes)
 		if err != nil {
 			return nil, fmt.Errorf("failed to sign transaction hash: %w", err)
 		}
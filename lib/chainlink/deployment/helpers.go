@@ -86,37 +86,11 @@ func AddressListContainsEmptyAddress(addresses []common.Address) bool {
 	return slices.Contains(addresses, (common.Address{}))
 }
 
+// MigrateAddressBook returns a new datastore recording the entries of the address book.
 func MigrateAddressBook(addrBook cldf.AddressBook) (datastore.MutableDataStore, error) {
-	addrs, err := addrBook.Addresses()
-	if err != nil {
-		return nil, err
-	}
-
 	ds := datastore.NewMemoryDataStore()
-
-	for chainSelector, chainAddresses := range addrs {
-		for addr, typever := range chainAddresses {
-			ref := datastore.AddressRef{
-				ChainSelector: chainSelector,
-				Address:       addr,
-				Type:          datastore.ContractType(typever.Type),
-				Version:       &typever.Version,
-				// Since the address book does not have a qualifier, we use the address and type as a
-				// unique identifier for the addressRef. Otherwise, we would have some clashes in the
-				// between address refs.
-				Qualifier: fmt.Sprintf("%s-%s", addr, typever.Type),
-			}
-
-			// If the address book has labels, we need to add them to the addressRef
-			if !typever.Labels.IsEmpty() {
-				ref.Labels = datastore.NewLabelSet(typever.Labels.List()...)
-			}
-
-			if err = ds.Addresses().Add(ref); err != nil {
-				return nil, err
-			}
-		}
+	if err := MigrateAddressBookInto(addrBook, ds); err != nil {
+		return nil, err
 	}
-
 	return ds, nil
 }
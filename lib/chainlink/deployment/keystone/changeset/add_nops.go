@@ -18,8 +18,8 @@ import (
 )
 
 type AddNopsRequest struct {
-	RegistryChainSel uint64
-	Nops             []kcr.CapabilitiesRegistryNodeOperator
+	RegistryChainSel uint64                                 `validate:"required,chainselector=evm"`
+	Nops             []kcr.CapabilitiesRegistryNodeOperator `validate:"required"`
 
 	MCMSConfig *MCMSConfig // if non-nil, the changes will be proposed using MCMS.
 
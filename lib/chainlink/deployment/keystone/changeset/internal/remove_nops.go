@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	mcmstypes "github.com/smartcontractkit/mcms/types"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	kcr "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/capabilities_registry_1_1_0"
+
+	cldf_evm "github.com/smartcontractkit/chainlink-deployments-framework/chain/evm"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+)
+
+// RemoveNOPsRequest holds the parameters for the RemoveNOPs operation.
+type RemoveNOPsRequest struct {
+	Chain                cldf_evm.Chain
+	CapabilitiesRegistry *kcr.CapabilitiesRegistry
+	NOPs                 []uint32
+	UseMCMS              bool
+}
+
+// RemoveNOPsResponse represents the response from calling RemoveNOPs.
+type RemoveNOPsResponse struct {
+	// TxHash is the hash of the transaction if not using MCMS.
+	TxHash string
+	// Ops contains the MCMS operation (if any).
+	Ops *mcmstypes.BatchOperation
+}
+
+func (r *RemoveNOPsRequest) Validate() error {
+	if len(r.NOPs) == 0 {
+		return errors.New("NOPs list is empty")
+	}
+
+	if r.CapabilitiesRegistry == nil {
+		return errors.New("registry is required")
+	}
+	return nil
+}
+
+// RemoveNOPs calls the RemoveNodeOperators method on the capabilities registry contract.
+// The node operators must exist and must not have any node.
+func RemoveNOPs(lggr logger.Logger, req *RemoveNOPsRequest) (*RemoveNOPsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate request: %w", err)
+	}
+
+	if err := CheckNOPsRemovable(req.CapabilitiesRegistry, req.NOPs); err != nil {
+		return nil, err
+	}
+
+	txOpts := req.Chain.DeployerKey
+	if req.UseMCMS {
+		txOpts = cldf.SimTransactOpts()
+	}
+
+	tx, err := req.CapabilitiesRegistry.RemoveNodeOperators(txOpts, req.NOPs)
+	if err != nil {
+		err = cldf.DecodeErr(kcr.CapabilitiesRegistryABI, err)
+		return nil, fmt.Errorf("failed to call RemoveNodeOperators: %w", err)
+	}
+
+	var ops mcmstypes.BatchOperation
+	if !req.UseMCMS {
+		_, err = req.Chain.Confirm(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to confirm RemoveNodeOperators transaction %s: %w", tx.Hash().String(), err)
+		}
+	} else {
+		ops, err = proposalutils.BatchOperationForChain(req.Chain.Selector, req.CapabilitiesRegistry.Address().Hex(), tx.Data(), big.NewInt(0), string(CapabilitiesRegistry), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create batch operation: %w", err)
+		}
+	}
+	lggr.Infow("removed node operators", "ids", req.NOPs)
+
+	return &RemoveNOPsResponse{
+		TxHash: tx.Hash().String(),
+		Ops:    &ops,
+	}, nil
+}
+
+// CheckNOPsRemovable checks that the node operators exist and that none of their nodes is registered.
+func CheckNOPsRemovable(registry *kcr.CapabilitiesRegistry, ids []uint32) error {
+	for _, id := range ids {
+		nop, err := registry.GetNodeOperator(&bind.CallOpts{}, id)
+		if err != nil {
+			return fmt.Errorf("failed to get node operator %d: %w", id, err)
+		}
+		if nop.Admin == (common.Address{}) {
+			return fmt.Errorf("node operator %d not found in registry", id)
+		}
+	}
+	nodes, err := registry.GetNodes(&bind.CallOpts{})
+	if err != nil {
+		return fmt.Errorf("failed to get nodes: %w", err)
+	}
+	removed := make(map[uint32]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+	}
+	for _, node := range nodes {
+		if removed[node.NodeOperatorId] {
+			return fmt.Errorf("node operator %d still has node %x registered", node.NodeOperatorId, node.P2pId)
+		}
+	}
+	return nil
+}
+
+// NOPIDs returns the IDs of the registered node operators, which are looked up from the NodeOperatorAdded events of the
+// registry since the IDs of the removed node operators are not reused.
+func NOPIDs(registry *kcr.CapabilitiesRegistry, nops []kcr.CapabilitiesRegistryNodeOperator) ([]uint32, error) {
+	registered, err := RegisteredNOPIDs(registry, nops)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint32, 0, len(nops))
+	for _, nop := range nops {
+		id, ok := registered[nop]
+		if !ok {
+			return nil, fmt.Errorf("node operator %s (%s) not found in registry", nop.Name, nop.Admin)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// RegisteredNOPIDs returns the IDs of the node operators which are registered, like NOPIDs, skipping the ones which are
+// not.
+func RegisteredNOPIDs(registry *kcr.CapabilitiesRegistry, nops []kcr.CapabilitiesRegistryNodeOperator) (map[kcr.CapabilitiesRegistryNodeOperator]uint32, error) {
+	admins := make([]common.Address, len(nops))
+	for i, nop := range nops {
+		admins[i] = nop.Admin
+	}
+	it, err := registry.FilterNodeOperatorAdded(&bind.FilterOpts{}, nil, admins)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter NodeOperatorAdded events: %w", err)
+	}
+	defer it.Close()
+	added := make(map[kcr.CapabilitiesRegistryNodeOperator][]uint32)
+	for it.Next() {
+		nop := kcr.CapabilitiesRegistryNodeOperator{Admin: it.Event.Admin, Name: it.Event.Name}
+		added[nop] = append(added[nop], it.Event.NodeOperatorId)
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate NodeOperatorAdded events: %w", err)
+	}
+
+	registered := make(map[kcr.CapabilitiesRegistryNodeOperator]uint32, len(nops))
+	for _, nop := range nops {
+		// the node operator may have been added, removed and added again
+		for _, id := range added[nop] {
+			current, err := registry.GetNodeOperator(&bind.CallOpts{}, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get node operator %d: %w", id, err)
+			}
+			if current == nop {
+				registered[nop] = id
+				break
+			}
+		}
+	}
+	return registered, nil
+}
@@ -276,8 +276,8 @@ func configureForwarderOp(
 
 	// Create the appropriate strategy
 	strategy, err := strategies.CreateStrategy(
-		chain,
 		*deps.Env,
+		chain.Selector,
 		input.MCMSConfig,
 		mcmsContracts,
 		forwarderAddress,
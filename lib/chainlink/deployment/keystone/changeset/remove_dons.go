@@ -19,8 +19,8 @@ import (
 var _ cldf.ChangeSet[*RemoveDONsRequest] = RemoveDONs
 
 type RemoveDONsRequest struct {
-	RegistryChainSel uint64
-	DONs             []uint32
+	RegistryChainSel uint64   `validate:"required,chainselector=evm"`
+	DONs             []uint32 `validate:"required"`
 
 	// MCMSConfig is optional. If non-nil, the changes will be proposed using MCMS.
 	MCMSConfig *MCMSConfig
@@ -0,0 +1,178 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/smartcontractkit/mcms"
+	"github.com/smartcontractkit/mcms/sdk"
+	"github.com/smartcontractkit/mcms/types"
+
+	"github.com/smartcontractkit/chainlink-deployments-framework/datastore"
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/deployment/keystone/changeset/internal"
+)
+
+var _ cldf.ChangeSet[*RemoveNopsRequest] = RemoveNops
+
+type RemoveNopsRequest struct {
+	RegistryChainSel uint64 `validate:"required,chainselector=evm"`
+	// NopIDs are the IDs of the node operators to remove, which must not have any node.
+	NopIDs []uint32 `validate:"required"`
+
+	// MCMSConfig is optional. If non-nil, the changes will be proposed using MCMS.
+	MCMSConfig *MCMSConfig
+
+	RegistryRef datastore.AddressRefKey
+}
+
+func (r *RemoveNopsRequest) Validate(e cldf.Environment) error {
+	if len(r.NopIDs) == 0 {
+		return errors.New("nop ids are required")
+	}
+
+	_, exists := chainsel.ChainBySelector(r.RegistryChainSel)
+	if !exists {
+		return fmt.Errorf("invalid registry chain selector %d: selector does not exist", r.RegistryChainSel)
+	}
+
+	_, exists = e.BlockChains.EVMChains()[r.RegistryChainSel]
+	if !exists {
+		return fmt.Errorf("invalid registry chain selector %d: chain does not exist in environment", r.RegistryChainSel)
+	}
+	if err := shouldUseDatastore(e, r.RegistryRef); err != nil {
+		return fmt.Errorf("invalid registry reference: %w", err)
+	}
+	return nil
+}
+
+func (r RemoveNopsRequest) UseMCMS() bool {
+	return r.MCMSConfig != nil
+}
+
+// RemoveNops removes node operators from the capabilities registry
+func RemoveNops(env cldf.Environment, req *RemoveNopsRequest) (cldf.ChangesetOutput, error) {
+	if err := req.Validate(env); err != nil {
+		return cldf.ChangesetOutput{}, err
+	}
+	registryChain, ok := env.BlockChains.EVMChains()[req.RegistryChainSel]
+	if !ok {
+		return cldf.ChangesetOutput{}, fmt.Errorf("registry chain selector %d does not exist in environment", req.RegistryChainSel)
+	}
+	capReg, err := loadCapabilityRegistry(registryChain, env, req.RegistryRef)
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to load capability registry: %w", err)
+	}
+
+	resp, err := internal.RemoveNOPs(env.Logger, &internal.RemoveNOPsRequest{
+		Chain:                registryChain,
+		CapabilitiesRegistry: capReg.Contract,
+		NOPs:                 req.NopIDs,
+		UseMCMS:              req.UseMCMS(),
+	})
+	if err != nil {
+		return cldf.ChangesetOutput{}, fmt.Errorf("failed to remove nops: %w", err)
+	}
+
+	out := cldf.ChangesetOutput{}
+	if req.UseMCMS() {
+		if resp.Ops == nil {
+			return out, errors.New("expected MCMS operation to be non-nil")
+		}
+
+		if capReg.McmsContracts == nil {
+			return out, fmt.Errorf("expected capabiity registry contract %s to be owned by MCMS", capReg.Contract.Address().String())
+		}
+
+		timelocksPerChain := map[uint64]string{
+			req.RegistryChainSel: capReg.McmsContracts.Timelock.Address().Hex(),
+		}
+		proposerMCMSes := map[uint64]string{
+			req.RegistryChainSel: capReg.McmsContracts.ProposerMcm.Address().Hex(),
+		}
+		inspector, err := proposalutils.McmsInspectorForChain(env, req.RegistryChainSel)
+		if err != nil {
+			return cldf.ChangesetOutput{}, err
+		}
+		inspectorPerChain := map[uint64]sdk.Inspector{
+			req.RegistryChainSel: inspector,
+		}
+
+		proposal, err := proposalutils.BuildProposalFromBatchesV2(
+			env,
+			timelocksPerChain,
+			proposerMCMSes,
+			inspectorPerChain,
+			[]types.BatchOperation{*resp.Ops},
+			"proposal to remove nops",
+			*req.MCMSConfig,
+		)
+		if err != nil {
+			return out, fmt.Errorf("failed to build proposal: %w", err)
+		}
+		out.MCMSTimelockProposals = []mcms.TimelockProposal{*proposal}
+	}
+
+	return out, nil
+}
+
+// AddNopsSnapshot reads the IDs of the node operators of the request which are registered before AddNops is applied.
+// Use it with AddNopsRollback.
+func AddNopsSnapshot(env cldf.Environment, req *AddNopsRequest) ([]uint32, error) {
+	registryChain, ok := env.BlockChains.EVMChains()[req.RegistryChainSel]
+	if !ok {
+		return nil, fmt.Errorf("registry chain selector %d does not exist in environment", req.RegistryChainSel)
+	}
+	capReg, err := loadCapabilityRegistry(registryChain, env, req.RegistryRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load capability registry: %w", err)
+	}
+	registered, err := internal.RegisteredNOPIDs(capReg.Contract, req.Nops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nop ids: %w", err)
+	}
+	return slices.Sorted(maps.Values(registered)), nil
+}
+
+// AddNopsRollback rolls back AddNops by removing the node operators it registered: the node operators of the request,
+// except the ones which were registered before AddNops was applied, as read by AddNopsSnapshot. The rollback fails if
+// any of the node operators to remove has a node.
+// Use it with commonchangeset.ConfigureWithSnapshotRollback.
+func AddNopsRollback(env cldf.Environment, req *AddNopsRequest, before []uint32, _ cldf.ChangesetOutput) (commonchangeset.ConfiguredChangeSet, error) {
+	registryChain, ok := env.BlockChains.EVMChains()[req.RegistryChainSel]
+	if !ok {
+		return nil, fmt.Errorf("registry chain selector %d does not exist in environment", req.RegistryChainSel)
+	}
+	capReg, err := loadCapabilityRegistry(registryChain, env, req.RegistryRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load capability registry: %w", err)
+	}
+	ids, err := internal.NOPIDs(capReg.Contract, req.Nops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nop ids: %w", err)
+	}
+	// a node operator listed twice in the request is registered once
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	ids = slices.DeleteFunc(ids, func(id uint32) bool {
+		return slices.Contains(before, id)
+	})
+	if len(ids) == 0 {
+		return commonchangeset.NoopRollback(), nil
+	}
+	if err := internal.CheckNOPsRemovable(capReg.Contract, ids); err != nil {
+		return nil, fmt.Errorf("nops cannot be removed: %w", err)
+	}
+	return commonchangeset.Configure(cldf.CreateLegacyChangeSet(RemoveNops), &RemoveNopsRequest{
+		RegistryChainSel: req.RegistryChainSel,
+		NopIDs:           ids,
+		MCMSConfig:       req.MCMSConfig,
+		RegistryRef:      req.RegistryRef,
+	}), nil
+}
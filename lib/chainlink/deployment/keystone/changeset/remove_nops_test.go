@@ -0,0 +1,76 @@
+package changeset_test
+
+import (
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kcr "github.com/smartcontractkit/chainlink-evm/gethwrappers/keystone/generated/capabilities_registry_1_1_0"
+
+	cldf "github.com/smartcontractkit/chainlink-deployments-framework/deployment"
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/keystone/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/keystone/changeset/test"
+)
+
+func TestRemoveNops(t *testing.T) {
+	t.Parallel()
+
+	nops := []kcr.CapabilitiesRegistryNodeOperator{
+		{
+			Admin: gethcommon.HexToAddress("0x01"),
+			Name:  "new test nop1",
+		},
+		{
+			Admin: gethcommon.HexToAddress("0x02"),
+			Name:  "another test nop2",
+		},
+	}
+	te := test.SetupContractTestEnv(t, test.EnvWrapperConfig{
+		WFDonConfig:     test.DonConfig{Name: "wfDon", N: 4},
+		AssetDonConfig:  test.DonConfig{Name: "assetDon", N: 4},
+		WriterDonConfig: test.DonConfig{Name: "writerDon", N: 4},
+		NumChains:       1,
+	})
+
+	t.Run("rollback of AddNops", func(t *testing.T) {
+		// the node operator registered before AddNops is applied is not removed by its rollback
+		_, err := changeset.AddNops(te.Env, &changeset.AddNopsRequest{
+			RegistryChainSel: te.RegistrySelector,
+			Nops:             nops[:1],
+			RegistryRef:      te.CapabilityRegistryAddressRef(),
+		})
+		require.NoError(t, err)
+
+		addNops := commonchangeset.ConfigureWithSnapshotRollback(cldf.CreateLegacyChangeSet(changeset.AddNops), &changeset.AddNopsRequest{
+			RegistryChainSel: te.RegistrySelector,
+			Nops:             nops,
+			RegistryRef:      te.CapabilityRegistryAddressRef(),
+		}, changeset.AddNopsSnapshot, changeset.AddNopsRollback)
+		env, outputs, err := commonchangeset.ApplyChangesets(t, te.Env, []commonchangeset.ConfiguredChangeSet{addNops})
+		require.NoError(t, err)
+		assertNopsExist(t, te.CapabilitiesRegistry(), nops...)
+
+		_, rolledBack, err := commonchangeset.RollbackChangesets(t, env, []commonchangeset.AppliedChangeset{
+			{Name: "add-nops", Changeset: addNops, Output: outputs[0]},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"add-nops"}, rolledBack)
+
+		ops, err := te.CapabilitiesRegistry().GetNodeOperators(nil)
+		require.NoError(t, err)
+		assert.Contains(t, ops, nops[0])
+		assert.NotContains(t, ops, nops[1])
+	})
+
+	t.Run("nop with nodes", func(t *testing.T) {
+		_, err := changeset.RemoveNops(te.Env, &changeset.RemoveNopsRequest{
+			RegistryChainSel: te.RegistrySelector,
+			NopIDs:           []uint32{te.Nops()[0].NodeOperatorId},
+			RegistryRef:      te.CapabilityRegistryAddressRef(),
+		})
+		require.ErrorContains(t, err, "still has node")
+	})
+}
@@ -0,0 +1,115 @@
+package solutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	solRpc "github.com/gagliardetto/solana-go/rpc"
+)
+
+// Signer signs Solana transactions with a key which may be held by a remote signer, e.g. a KMS or an HSM service, so
+// the private key does not have to be in memory.
+type Signer interface {
+	// PublicKey returns the public key of the signer.
+	PublicKey() solana.PublicKey
+	// SignMessage signs the serialized message of a transaction, and returns its ed25519 signature.
+	SignMessage(ctx context.Context, message []byte) (solana.Signature, error)
+}
+
+// PrivateKeySigner signs with a private key in memory, e.g. for tests and local networks.
+type PrivateKeySigner struct {
+	Key solana.PrivateKey
+}
+
+var _ Signer = PrivateKeySigner{}
+
+func (s PrivateKeySigner) PublicKey() solana.PublicKey {
+	return s.Key.PublicKey()
+}
+
+func (s PrivateKeySigner) SignMessage(_ context.Context, message []byte) (solana.Signature, error) {
+	return s.Key.Sign(message)
+}
+
+// SignTransaction builds the transaction of the instructions paid by the signer, which must be its only required
+// signer, and signs it.
+func SignTransaction(ctx context.Context, signer Signer, blockhash solana.Hash, instructions []solana.Instruction) (*solana.Transaction, error) {
+	tx, err := solana.NewTransaction(instructions, blockhash, solana.TransactionPayer(signer.PublicKey()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
+	if n := tx.Message.Header.NumRequiredSignatures; n != 1 {
+		return nil, fmt.Errorf("transaction requires %d signatures, only the signer %s can sign", n, signer.PublicKey())
+	}
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize transaction message: %w", err)
+	}
+	signature, err := signer.SignMessage(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction with %s: %w", signer.PublicKey(), err)
+	}
+	tx.Signatures = []solana.Signature{signature}
+	if err := tx.VerifySignatures(); err != nil {
+		return nil, fmt.Errorf("invalid signature of %s: %w", signer.PublicKey(), err)
+	}
+	return tx, nil
+}
+
+// SendAndConfirmWithSigner sends the transaction of the instructions signed by the signer, see SignTransaction, and
+// waits for its confirmation.
+func SendAndConfirmWithSigner(
+	ctx context.Context, solanaGoClient *solRpc.Client, signer Signer, instructions []solana.Instruction,
+) (solana.Signature, error) {
+	recent, err := solanaGoClient.GetLatestBlockhash(ctx, solRpc.CommitmentConfirmed)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to get latest blockhash: %w", err)
+	}
+	tx, err := SignTransaction(ctx, signer, recent.Value.Blockhash, instructions)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	sig, err := solanaGoClient.SendTransactionWithOpts(ctx, tx, solRpc.TransactionOpts{
+		PreflightCommitment: solRpc.CommitmentConfirmed,
+	})
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	const timeout = 100 * time.Second
+	const pollInterval = 500 * time.Millisecond
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timeoutCtx.Done():
+			return sig, fmt.Errorf("transaction %s not confirmed within timeout", sig)
+		case <-ticker.C:
+			statusRes, sigErr := solanaGoClient.GetSignatureStatuses(timeoutCtx, true, sig)
+			if sigErr != nil {
+				return sig, sigErr
+			}
+			if statusRes == nil || len(statusRes.Value) == 0 {
+				return sig, errors.New("status response is nil")
+			}
+			status := statusRes.Value[0]
+			if status == nil {
+				continue
+			}
+			if status.Err != nil {
+				return sig, fmt.Errorf("transaction %s failed: %v", sig, status.Err)
+			}
+			if status.ConfirmationStatus == solRpc.ConfirmationStatusConfirmed || status.ConfirmationStatus == solRpc.ConfirmationStatusFinalized {
+				return sig, nil
+			}
+		}
+	}
+}
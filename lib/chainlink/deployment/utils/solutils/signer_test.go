@@ -0,0 +1,27 @@
+package solutils
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignTransaction(t *testing.T) {
+	key, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	signer := PrivateKeySigner{Key: key}
+	to := solana.NewWallet().PublicKey()
+
+	transfer := system.NewTransferInstruction(1, signer.PublicKey(), to).Build()
+	tx, err := SignTransaction(t.Context(), signer, solana.Hash{}, []solana.Instruction{transfer})
+	require.NoError(t, err)
+	require.Len(t, tx.Signatures, 1)
+	require.NoError(t, tx.VerifySignatures())
+
+	// the transfer from another account requires its signature too
+	other := system.NewTransferInstruction(1, to, signer.PublicKey()).Build()
+	_, err = SignTransaction(t.Context(), signer, solana.Hash{}, []solana.Instruction{other})
+	require.ErrorContains(t, err, "requires 2 signatures")
+}
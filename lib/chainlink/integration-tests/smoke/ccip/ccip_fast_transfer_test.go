@@ -70,6 +70,7 @@ type balanceAssertion func(t *testing.T, sourceToken balanceToken, destinationTo
 type fastTransferE2ETestCase struct {
 	name                                string
 	enableFiller                        bool
+	inProcessFiller                     bool // If true, the transfer is fast filled by the test instead of the relayer
 	allowlistEnabled                    bool
 	allowlistFiller                     bool
 	tokenSymbol                         string
@@ -143,6 +144,13 @@ func withFillerDisabled() fastTransferE2ETestCaseOption {
 	}
 }
 
+func withInProcessFiller() fastTransferE2ETestCaseOption {
+	return func(tc *fastTransferE2ETestCase) *fastTransferE2ETestCase {
+		tc.inProcessFiller = true
+		return tc
+	}
+}
+
 func withHybridPool(groupType v1_5_1.Group) fastTransferE2ETestCaseOption {
 	return func(tc *fastTransferE2ETestCase) *fastTransferE2ETestCase {
 		tc.isHybridPool = true
@@ -287,6 +295,7 @@ var fastTransferTestCases = []*fastTransferE2ETestCase{
 	ftfTc("external minter", withExternalMinter(), withFastFillSuccessAmountAssertions(), withFeeTokenType(feeTokenNative)),
 	ftfTc("external minter feeToken", withExternalMinter(), withFastFillSuccessAmountAssertions(), withFeeTokenType(feeTokenLink)),
 	ftfTc("settlement gas overhead too low", withSettlementGasOverhead(1), withExpectNoExecutionError(), withFeeTokenType(feeTokenNative)),
+	ftfTc("in process filler settlement", withInProcessFiller(), withFastFillSuccessAmountAssertions()),
 	ftfTc("max fast transfer fee too low", withCustomMaxFastTransferFee(big.NewInt(50)), withExpectRevert()),
 	ftfTc("hybrid pool lock release", withHybridPool(v1_5_1.LockAndRelease), withFeeTokenType(feeTokenNative), withFastFillSuccessAmountAssertionsWithPoolAmount(big.NewInt(0).Mul(big.NewInt(1e18), big.NewInt(1000)), true)),
 	ftfTc("hybrid pool", withHybridPool(v1_5_1.BurnAndMint), withFeeTokenType(feeTokenNative), withFastFillSuccessAmountAssertions()),
@@ -1446,7 +1455,7 @@ func runFastTransferTestCase(t *testing.T, ctx *fastTransferTestContext, tc *fas
 		fundAccountWithToken(t, ctx.DestinationChain(), fillerAddress, destinationMinter, initialFillerTokenAmountOnDest, ctx.destinationLock)
 		approveToken(t, ctx.DestinationChain(), fillerTransactor(), destinationToken, destinationTokenPoolAddress, ctx.destinationLock)
 
-		if tc.enableFiller {
+		if tc.enableFiller && !tc.inProcessFiller {
 			stop := startRelayer(t, ctx.SourceChainSelector(), ctx.DestinationChainSelector(), sourceTokenPoolAddress, destinationTokenPoolAddress, ctx.deployedEnv, fillerPrivateKey)
 			ctx.env.Logger.Infof("Started relayer for source chain %d and destination chain %d", ctx.SourceChainSelector(), ctx.DestinationChainSelector())
 
@@ -1465,7 +1474,10 @@ func runFastTransferTestCase(t *testing.T, ctx *fastTransferTestContext, tc *fas
 			userTransac.Value = fees.CcipSettlementFee
 		}
 
-		var seqNum uint64
+		var (
+			seqNum              uint64
+			fastTransferRequest *bindings.FastTransferRequestedEvent
+		)
 		func() {
 			ctx.sendLock.Lock()
 			defer ctx.sendLock.Unlock()
@@ -1491,8 +1503,11 @@ func runFastTransferTestCase(t *testing.T, ctx *fastTransferTestContext, tc *fas
 
 			ctx.env.Logger.Infof("Sending transaction: %s", tx.Hash().Hex())
 			require.NoError(t, err)
-			_, err = ctx.SourceChain().Confirm(tx)
+			blockNum, err := ctx.SourceChain().Confirm(tx)
 			require.NoError(t, err)
+			if tc.inProcessFiller {
+				fastTransferRequest = testhelpers.GetFastTransferRequest(t, pool, ctx.DestinationChainSelector(), blockNum)
+			}
 
 			filter, err := pool.FilterFastTransferRequested(nil, nil, nil, nil)
 			require.NoError(t, err)
@@ -1502,6 +1517,17 @@ func runFastTransferTestCase(t *testing.T, ctx *fastTransferTestContext, tc *fas
 			}
 		}()
 
+		var destinationPool *bindings.FastTransferTokenPoolWrapper
+		if fastTransferRequest != nil {
+			destinationPool, err = bindings.NewFastTransferTokenPoolWrapper(destinationTokenPoolAddress, ctx.DestinationChain().Client, contractType)
+			require.NoError(t, err)
+			func() {
+				ctx.destinationLock.Lock()
+				defer ctx.destinationLock.Unlock()
+				testhelpers.FastFillTransfer(t, ctx.DestinationChain(), destinationPool, fillerTransactor(), ctx.SourceChainSelector(), fastTransferRequest)
+			}()
+		}
+
 		// Skip post-transaction logic if we expect the transaction to revert
 		if !tc.expectRevert {
 			runAssertions(t, sourceToken, destinationToken, fillerAddress, tc.postFastTransferFillerAssertions, "Post Fast Transfer Filler Assertions")
@@ -1513,6 +1539,9 @@ func runFastTransferTestCase(t *testing.T, ctx *fastTransferTestContext, tc *fas
 			} else {
 				ctx.waitForExecution(t, seqNum)
 			}
+			if fastTransferRequest != nil {
+				testhelpers.ConfirmFastTransferSettled(t, destinationPool, fastTransferRequest, fillerAddress, time.Minute)
+			}
 
 			runAssertions(t, sourceToken, destinationToken, fillerAddress, tc.postRegularTransferFillerAssertions, "Post Regular Transfer Filler Assertions")
 			runAssertions(t, sourceToken, destinationToken, userAddress, tc.postRegularTransferUserAssertions, "Post Regular Transfer User Assertions")
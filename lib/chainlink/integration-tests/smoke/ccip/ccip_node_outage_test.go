@@ -0,0 +1,78 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_2_0/router"
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/v1_6_0/onramp"
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/shared/stateview"
+	testsetups "github.com/smartcontractkit/chainlink/integration-tests/testsetups/ccip"
+)
+
+// Stops f nodes of the DONs while messages are in flight, restarts them, and requires that all the messages are
+// committed and executed once the nodes are back.
+func Test_NodeOutage(t *testing.T) {
+	tenv, _, testEnv := testsetups.NewIntegrationEnvironment(t)
+	memEnv, ok := testEnv.(*testhelpers.MemoryEnvironment)
+	if !ok {
+		t.Skip("node outages are only supported in the memory environment")
+	}
+
+	e := tenv.Env
+	state, err := stateview.LoadOnchainState(e)
+	require.NoError(t, err)
+	testhelpers.AddLanesForAll(t, &tenv, state)
+
+	evmChains := e.BlockChains.EVMChains()
+	allChainSelectors := maps.Keys(evmChains)
+	sourceChain, destChain := allChainSelectors[0], allChainSelectors[1]
+
+	nodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
+	require.NoError(t, err)
+	f := int(nodes.NonBootstraps().DefaultF())
+	require.Positive(t, f, "the DONs do not tolerate any faulty node")
+
+	latestHeader, err := evmChains[destChain].Client.HeaderByNumber(t.Context(), nil)
+	require.NoError(t, err)
+	startBlock := latestHeader.Number.Uint64()
+
+	var msgs []testhelpers.InFlightMessage
+	sendMessage := func() {
+		msgEvent := testhelpers.TestSendRequest(t, e, state, sourceChain, destChain, false, router.ClientEVM2AnyMessage{
+			Receiver:     common.LeftPadBytes(state.MustGetEVMChainState(destChain).Receiver.Address().Bytes(), 32),
+			Data:         []byte("hello"),
+			TokenAmounts: nil,
+			FeeToken:     common.HexToAddress("0x0"),
+			ExtraArgs:    nil,
+		})
+		msgs = append(msgs, testhelpers.InFlightMessage{
+			SourceChain: sourceChain,
+			DestChain:   destChain,
+			MessageID:   msgEvent.RawEvent.(*onramp.OnRampCCIPMessageSent).Message.Header.MessageId,
+		})
+	}
+
+	// Messages sent before the outage are still in flight when the nodes are stopped
+	for range 3 {
+		sendMessage()
+	}
+	waitOutage := testhelpers.RunNodeOutage(t.Context(), testhelpers.NodeOutage{
+		Nodes:    testhelpers.PickNodes(t, memEnv.Nodes(), f),
+		Downtime: 30 * time.Second,
+	})
+	// Messages sent during the outage are handled by the remaining nodes, or once the nodes are back
+	for range 3 {
+		sendMessage()
+	}
+
+	testhelpers.RequireMessagesRecovered(t, e, state, waitOutage, msgs, 5*time.Minute,
+		testhelpers.WithTrackStartBlocks(map[uint64]*uint64{destChain: &startBlock}),
+	)
+}
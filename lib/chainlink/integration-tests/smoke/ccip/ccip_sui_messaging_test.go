@@ -25,7 +25,6 @@ import (
 	sui_deployment "github.com/smartcontractkit/chainlink-sui/deployment"
 	sui_cs "github.com/smartcontractkit/chainlink-sui/deployment/changesets"
 	sui_ops "github.com/smartcontractkit/chainlink-sui/deployment/ops"
-	ccipops "github.com/smartcontractkit/chainlink-sui/deployment/ops/ccip"
 	linkops "github.com/smartcontractkit/chainlink-sui/deployment/ops/link"
 
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/testhelpers"
@@ -330,35 +329,13 @@ func Test_CCIP_Messaging_EVM2Sui(t *testing.T) {
 		nativeFeeToken = "0x0"
 	)
 
-	// Deploy SUI Receiver
-	_, output, err := commoncs.ApplyChangesets(t, e.Env, []commoncs.ConfiguredChangeSet{
-		commoncs.Configure(sui_cs.DeployDummyReceiver{}, sui_cs.DeployDummyReceiverConfig{
-			SuiChainSelector: destChain,
-			McmsOwner:        "0x1",
-		}),
-	})
-	require.NoError(t, err)
-
-	rawOutput := output[0].Reports[0]
-
-	outputMap, ok := rawOutput.Output.(sui_ops.OpTxResult[ccipops.DeployDummyReceiverObjects])
-	require.True(t, ok)
+	// Deploy and register the SUI Receiver
+	outputMap := testhelpers.DeploySuiDummyReceiver(t, e.Env, destChain)
 
 	id := strings.TrimPrefix(outputMap.PackageId, "0x")
 	receiverByteDecoded, err := hex.DecodeString(id)
 	require.NoError(t, err)
 
-	// register the receiver
-	_, _, err = commoncs.ApplyChangesets(t, e.Env, []commoncs.ConfiguredChangeSet{
-		commoncs.Configure(sui_cs.RegisterDummyReceiver{}, sui_cs.RegisterDummyReceiverConfig{
-			SuiChainSelector:       destChain,
-			OwnerCapObjectId:       outputMap.Objects.OwnerCapObjectId,
-			CCIPObjectRefObjectId:  state.SuiChains[destChain].CCIPObjectRef,
-			DummyReceiverPackageId: outputMap.PackageId,
-		}),
-	})
-	require.NoError(t, err)
-
 	receiverByte := receiverByteDecoded
 
 	var clockObj [32]byte